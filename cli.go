@@ -0,0 +1,322 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	"go.uber.org/fx"
+
+	"github.com/goformx/goforms/internal/domain"
+	domainerrors "github.com/goformx/goforms/internal/domain/common/errors"
+	"github.com/goformx/goforms/internal/infrastructure"
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+	"github.com/goformx/goforms/internal/infrastructure/sanitization"
+	"github.com/goformx/goforms/internal/infrastructure/version"
+)
+
+// Exit codes returned by CLI subcommands
+const (
+	exitOK       = 0
+	exitFailure  = 1
+	exitUsage    = 2
+	exitNotFound = 3
+	exitConflict = 4
+)
+
+// DefaultCommandTimeout bounds how long a CLI subcommand may take to start and stop its dependencies
+const DefaultCommandTimeout = 30 * time.Second
+
+// commandLogLevel keeps CLI output readable; only warnings and errors are logged
+const commandLogLevel = "warn"
+
+// errUsage signals that a subcommand was invoked with invalid arguments
+var errUsage = errors.New("invalid usage")
+
+// command is a CLI subcommand that runs instead of the web server
+type command struct {
+	summary string
+	run     func(ctx context.Context, args []string) error
+}
+
+// commands lists the available subcommands keyed by name
+var commands = map[string]command{
+	"migrate": {
+		summary: "apply, roll back or inspect database schema migrations",
+		run:     runMigrate,
+	},
+	"user": {
+		summary: "create, promote, disable users or reset their password",
+		run:     runUser,
+	},
+	"form": {
+		summary: "export, import or transfer ownership of forms",
+		run:     runForm,
+	},
+	"submissions": {
+		summary: "purge old submissions and expired drafts, apply retention rules, re-encrypt, erase a submitter",
+		run:     runSubmissions,
+	},
+	"sessions": {
+		summary: "flush persisted login sessions",
+		run:     runSessions,
+	},
+	"events": {
+		summary: "replay stored domain events into a handler",
+		run:     runEvents,
+	},
+	"config": {
+		summary: "show the configuration with secrets redacted, encrypt or decrypt a secrets file",
+		run:     runConfig,
+	},
+}
+
+// subcommand is a named action within a command group, e.g. "user create"
+type subcommand struct {
+	usage string
+	run   func(ctx context.Context, args []string) error
+}
+
+// isCommand reports whether the program arguments select a CLI subcommand
+func isCommand(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+
+	_, ok := commands[args[0]]
+
+	return ok || args[0] == "help" || args[0] == "-h" || args[0] == "--help"
+}
+
+// runCommand dispatches a CLI subcommand and returns the process exit code
+func runCommand(args []string) int {
+	cmd, ok := commands[args[0]]
+	if !ok {
+		printUsage(os.Stdout)
+
+		return exitOK
+	}
+
+	err := cmd.run(context.Background(), args[1:])
+	if err == nil {
+		return exitOK
+	}
+
+	code := exitCodeFor(err)
+	if code == exitUsage {
+		return code
+	}
+
+	if wantsJSON(args) {
+		writeJSON(os.Stderr, map[string]any{"error": err.Error(), "exit_code": code})
+	} else {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", args[0], err)
+	}
+
+	return code
+}
+
+// exitCodeFor maps an error to a process exit code
+func exitCodeFor(err error) int {
+	switch {
+	case errors.Is(err, errUsage), errors.Is(err, flag.ErrHelp):
+		return exitUsage
+	case errors.Is(err, common.ErrNotFound), domainerrors.IsNotFound(err):
+		return exitNotFound
+	case domainerrors.IsConflictError(err):
+		return exitConflict
+	default:
+		return exitFailure
+	}
+}
+
+// printUsage writes the list of available subcommands
+func printUsage(w io.Writer) {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	fmt.Fprintln(w, "Usage: goforms [command] [arguments]")
+	fmt.Fprintln(w, "\nRunning without a command starts the web server.")
+	fmt.Fprintln(w, "\nCommands:")
+
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-12s %s\n", name, commands[name].summary)
+	}
+
+	fmt.Fprintln(w, "\nExit codes: 0 success, 1 failure, 2 usage error, 3 not found, 4 conflict")
+}
+
+// dispatch runs the named subcommand of a command group
+func dispatch(ctx context.Context, group string, args []string, subs map[string]subcommand) error {
+	if len(args) > 0 {
+		if sub, ok := subs[args[0]]; ok {
+			return sub.run(ctx, args[1:])
+		}
+
+		fmt.Fprintf(os.Stderr, "unknown %s subcommand %q\n\n", group, args[0])
+	}
+
+	names := make([]string, 0, len(subs))
+	for name := range subs {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	fmt.Fprintf(os.Stderr, "Usage: goforms %s <subcommand> [arguments]\n\nSubcommands:\n", group)
+
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %s\n", subs[name].usage)
+	}
+
+	return errUsage
+}
+
+// newFlagSet creates a flag set for a subcommand with the shared -json flag
+func newFlagSet(name string) (*flag.FlagSet, *bool) {
+	fs := flag.NewFlagSet("goforms "+name, flag.ContinueOnError)
+	asJSON := fs.Bool("json", false, "print machine-readable JSON output")
+
+	return fs, asJSON
+}
+
+// parseFlags parses args and converts flag errors into usage errors
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+
+	if fs.NArg() > 0 {
+		fmt.Fprintf(os.Stderr, "unexpected arguments: %v\n", fs.Args())
+		fs.Usage()
+
+		return errUsage
+	}
+
+	return nil
+}
+
+// requireFlag reports a usage error when a mandatory flag is empty
+func requireFlag(fs *flag.FlagSet, name, value string) error {
+	if value == "" {
+		fmt.Fprintf(os.Stderr, "-%s is required\n", name)
+		fs.Usage()
+
+		return errUsage
+	}
+
+	return nil
+}
+
+// wantsJSON reports whether -json was passed anywhere in the arguments
+func wantsJSON(args []string) bool {
+	for _, arg := range args {
+		if arg == "-json" || arg == "--json" || arg == "-json=true" || arg == "--json=true" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// writeJSON encodes v as indented JSON
+func writeJSON(w io.Writer, v any) {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(v); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to encode output: %v\n", err)
+	}
+}
+
+// printResult writes result as JSON when requested, otherwise the formatted text
+func printResult(asJSON bool, result any, format string, args ...any) {
+	if asJSON {
+		writeJSON(os.Stdout, result)
+
+		return
+	}
+
+	fmt.Fprintf(os.Stdout, format+"\n", args...)
+}
+
+// newCommandLogger creates a logger that writes to stderr so command output on stdout stays parseable
+func newCommandLogger(cfg *config.Config, sanitizer sanitization.ServiceInterface) (logging.Logger, error) {
+	factory, err := logging.NewFactory(&logging.FactoryConfig{
+		AppName:          cfg.App.Name,
+		Version:          version.Version,
+		Environment:      cfg.App.Environment,
+		LogLevel:         commandLogLevel,
+		OutputPaths:      []string{"stderr"},
+		ErrorOutputPaths: []string{"stderr"},
+	}, sanitizer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create logger factory: %w", err)
+	}
+
+	factory.RedactSecrets(cfg.SecretValues())
+
+	logger, err := factory.CreateLogger()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create logger: %w", err)
+	}
+
+	return logger, nil
+}
+
+// commandOptions is the fx graph shared by CLI subcommands: configuration,
+// logging, database and the domain layer, without the web server.
+func commandOptions(targets ...any) fx.Option {
+	return fx.Options(
+		fx.NopLogger,
+		config.Module,
+		domain.Module,
+		fx.Provide(
+			infrastructure.ProvideSanitizationService,
+			newCommandLogger,
+			infrastructure.ProvideDatabase,
+			infrastructure.ProvideMigrator,
+			infrastructure.ProvideFieldKeyring,
+			infrastructure.ProvideFieldEncryption,
+			infrastructure.ProvideEventBus,
+		),
+		fx.Populate(targets...),
+	)
+}
+
+// withDependencies builds the application's config, database and domain graph
+// without the web layer, populates targets from it and runs fn between start
+// and stop. Unlike the web server graph it does not register the startup schema check.
+func withDependencies(ctx context.Context, fn func(ctx context.Context) error, targets ...any) (err error) {
+	app := fx.New(commandOptions(targets...))
+
+	startCtx, cancelStart := context.WithTimeout(ctx, DefaultCommandTimeout)
+	defer cancelStart()
+
+	if startErr := app.Start(startCtx); startErr != nil {
+		return fmt.Errorf("failed to start: %w", startErr)
+	}
+
+	defer func() {
+		stopCtx, cancelStop := context.WithTimeout(context.Background(), DefaultCommandTimeout)
+		defer cancelStop()
+
+		if stopErr := app.Stop(stopCtx); stopErr != nil && err == nil {
+			err = fmt.Errorf("failed to stop: %w", stopErr)
+		}
+	}()
+
+	return fn(ctx)
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+
+	"github.com/goformx/goforms/internal/domain/common/events"
+	"github.com/goformx/goforms/internal/domain/eventstore"
+	formevents "github.com/goformx/goforms/internal/domain/form/events"
+)
+
+// Replay handlers
+const (
+	replayHandlerPrint = "print"
+	replayHandlerCount = "count"
+)
+
+// runEvents implements the events command group
+func runEvents(ctx context.Context, args []string) error {
+	return dispatch(ctx, "events", args, map[string]subcommand{
+		"replay": {
+			usage: "replay [-handler print|count] [-from SEQ] [-to SEQ] [-event NAMES] [-stream TYPE:ID] " +
+				"[-limit N] [-consumer NAME] [-follow] [-json]   feed stored domain events to a handler",
+			run: eventsReplay,
+		},
+	})
+}
+
+// replayCounter counts replayed events by name
+type replayCounter map[string]int
+
+func (c replayCounter) handle(_ context.Context, event events.Event) error {
+	c[event.Name()]++
+
+	return nil
+}
+
+// newReplayHandler returns the handler named by -handler. The print handler
+// writes one JSON object per event to w.
+func newReplayHandler(name string, w io.Writer, counts replayCounter) (eventstore.Handler, error) {
+	switch name {
+	case replayHandlerPrint:
+		encoder := json.NewEncoder(w)
+
+		return func(_ context.Context, event events.Event) error {
+			if err := encoder.Encode(event); err != nil {
+				return fmt.Errorf("print event: %w", err)
+			}
+
+			return nil
+		}, nil
+	case replayHandlerCount:
+		return counts.handle, nil
+	default:
+		return nil, fmt.Errorf("unknown handler %q, use %s or %s", name, replayHandlerPrint, replayHandlerCount)
+	}
+}
+
+// parseReplayFilter builds an event log filter from the replay flags
+func parseReplayFilter(from, to int64, names, stream string, limit int) (eventstore.Filter, error) {
+	filter := eventstore.Filter{After: from, Until: to, Limit: limit}
+
+	if from < 0 || to < 0 || limit < 0 {
+		return filter, errors.New("-from, -to and -limit must not be negative")
+	}
+
+	if to > 0 && to <= from {
+		return filter, errors.New("-to must be greater than -from")
+	}
+
+	known := formevents.PayloadTypes()
+
+	for name := range strings.SplitSeq(names, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		if _, ok := known[formevents.EventType(name)]; !ok {
+			return filter, fmt.Errorf("unknown event %q", name)
+		}
+
+		filter.Names = append(filter.Names, name)
+	}
+
+	if stream != "" {
+		streamType, streamID, ok := strings.Cut(stream, ":")
+		if !ok || streamID == "" ||
+			streamType != formevents.StreamForm && streamType != formevents.StreamSubmission {
+			return filter, fmt.Errorf("-stream must be %s:ID or %s:ID", formevents.StreamForm, formevents.StreamSubmission)
+		}
+
+		filter.StreamType, filter.StreamID = streamType, streamID
+	}
+
+	return filter, nil
+}
+
+func eventsReplay(ctx context.Context, args []string) error {
+	fs, asJSON := newFlagSet("events replay")
+	handlerName := fs.String("handler", replayHandlerPrint,
+		"print writes events as JSON lines; count tallies them by name")
+	from := fs.Int64("from", 0, "start after this sequence number")
+	to := fs.Int64("to", 0, "stop at this sequence number")
+	names := fs.String("event", "", "comma-separated event names to replay, e.g. form.submitted")
+	stream := fs.String("stream", "", "only replay one form's or submission's events, e.g. form:ID")
+	limit := fs.Int("limit", 0, "stop after this many events")
+	consumer := fs.String("consumer", "", "start after this consumer's checkpoint and save its progress")
+	follow := fs.Bool("follow", false, "wait for new events until interrupted")
+
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	filter, err := parseReplayFilter(*from, *to, *names, *stream, *limit)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+
+		return errUsage
+	}
+
+	counts := replayCounter{}
+
+	handler, err := newReplayHandler(*handlerName, os.Stdout, counts)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+
+		return errUsage
+	}
+
+	var store eventstore.Service
+
+	return withDependencies(ctx, func(ctx context.Context) error {
+		replay := store.Replay
+		if *follow {
+			replay = store.Subscribe
+		}
+
+		ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		result, replayErr := replay(ctx, *consumer, filter, handler)
+		if replayErr != nil {
+			return replayErr
+		}
+
+		printReplayResult(*asJSON, *handlerName, result, counts)
+
+		return nil
+	}, &store)
+}
+
+// printReplayResult reports what a replay handled. The summary goes to stderr
+// when the print handler is using stdout.
+func printReplayResult(asJSON bool, handlerName string, result *eventstore.ReplayResult, counts replayCounter) {
+	out := os.Stdout
+	if handlerName == replayHandlerPrint {
+		out = os.Stderr
+	}
+
+	if asJSON {
+		writeJSON(out, map[string]any{
+			"events":        result.Events,
+			"last_sequence": result.LastSequence,
+			"counts":        counts,
+		})
+
+		return
+	}
+
+	fmt.Fprintf(out, "replayed %d event(s) up to sequence %d\n", result.Events, result.LastSequence)
+
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Fprintf(out, "  %-20s %d\n", name, counts[name])
+	}
+}
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/goformx/goforms/internal/application/validation"
+	domainerrors "github.com/goformx/goforms/internal/domain/common/errors"
+	"github.com/goformx/goforms/internal/domain/entities"
+	"github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/domain/user"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+// stdioPath selects stdin or stdout instead of a file
+const stdioPath = "-"
+
+// runForm implements the form command group
+func runForm(ctx context.Context, args []string) error {
+	return dispatch(ctx, "form", args, map[string]subcommand{
+		"export": {
+			usage: "export -id ID [-o FILE] [-json]   write the form as a versioned bundle (stdout by default)",
+			run:   formExport,
+		},
+		"import": {
+			usage: "import -owner EMAIL|ID [-file FILE] [-on-conflict new_id|fail] [-json]   create a form from a bundle",
+			run:   formImport,
+		},
+		"transfer-ownership": {
+			usage: "transfer-ownership -id ID -to EMAIL|ID [-json]",
+			run:   formTransferOwnership,
+		},
+	})
+}
+
+func formExport(ctx context.Context, args []string) error {
+	fs, asJSON := newFlagSet("form export")
+	id := fs.String("id", "", "ID of the form to export")
+	output := fs.String("o", stdioPath, "output file, - for stdout")
+
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	if err := requireFlag(fs, "id", *id); err != nil {
+		return err
+	}
+
+	var formService form.Service
+
+	return withDependencies(ctx, func(ctx context.Context) error {
+		f, err := formService.GetForm(ctx, *id)
+		if err != nil {
+			return err
+		}
+
+		bundle := model.NewBundle(f)
+
+		if *output == stdioPath {
+			writeJSON(os.Stdout, bundle)
+
+			return nil
+		}
+
+		data, err := json.MarshalIndent(bundle, "", "  ")
+		if err != nil {
+			return fmt.Errorf("encode form bundle: %w", err)
+		}
+
+		if writeErr := os.WriteFile(*output, data, 0o600); writeErr != nil {
+			return fmt.Errorf("write %s: %w", *output, writeErr)
+		}
+
+		printResult(*asJSON, map[string]any{"id": f.ID, "file": *output}, "exported form %s to %s", f.ID, *output)
+
+		return nil
+	}, &formService)
+}
+
+func formImport(ctx context.Context, args []string) error {
+	fs, asJSON := newFlagSet("form import")
+	file := fs.String("file", stdioPath, "form bundle JSON, - for stdin")
+	owner := fs.String("owner", "", "email or ID of the user who will own the form")
+	onConflict := fs.String("on-conflict", model.ConflictNewID, "when the bundle's form ID exists: new_id or fail")
+
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	if err := requireFlag(fs, "owner", *owner); err != nil {
+		return err
+	}
+
+	if _, err := model.ValidateConflictPolicy(*onConflict); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+
+		return errUsage
+	}
+
+	bundle, err := readBundle(*file)
+	if err != nil {
+		return err
+	}
+
+	var (
+		formService form.Service
+		userService user.Service
+		logger      logging.Logger
+	)
+
+	return withDependencies(ctx, func(ctx context.Context) error {
+		if schemaErr := validation.NewFormValidator(logger).ValidateFormSchema(bundle.Form.Schema); schemaErr != nil {
+			return domainerrors.Wrap(schemaErr, domainerrors.ErrCodeFormInvalid, "invalid form schema")
+		}
+
+		ownerUser, findErr := findUserByRef(ctx, userService, *owner)
+		if findErr != nil {
+			return findErr
+		}
+
+		f, importErr := formService.ImportBundle(ctx, ownerUser.ID, bundle, *onConflict)
+		if importErr != nil {
+			return importErr
+		}
+
+		printResult(*asJSON, f, "imported form %q as %s owned by %s", f.Title, f.ID, ownerUser.Email)
+
+		return nil
+	}, &formService, &userService, &logger)
+}
+
+func formTransferOwnership(ctx context.Context, args []string) error {
+	fs, asJSON := newFlagSet("form transfer-ownership")
+	id := fs.String("id", "", "ID of the form")
+	to := fs.String("to", "", "email or ID of the new owner")
+
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	if err := requireFlag(fs, "id", *id); err != nil {
+		return err
+	}
+
+	if err := requireFlag(fs, "to", *to); err != nil {
+		return err
+	}
+
+	var (
+		formService form.Service
+		userService user.Service
+	)
+
+	return withDependencies(ctx, func(ctx context.Context) error {
+		f, err := formService.GetForm(ctx, *id)
+		if err != nil {
+			return err
+		}
+
+		newOwner, err := findUserByRef(ctx, userService, *to)
+		if err != nil {
+			return err
+		}
+
+		previousOwner := f.UserID
+		f.UserID = newOwner.ID
+
+		if updateErr := formService.UpdateForm(ctx, f); updateErr != nil {
+			return updateErr
+		}
+
+		result := map[string]any{"id": f.ID, "previous_owner": previousOwner, "owner": newOwner.ID}
+		printResult(*asJSON, result, "form %s transferred to %s", f.ID, newOwner.Email)
+
+		return nil
+	}, &formService, &userService)
+}
+
+// readBundle decodes a form bundle from a file or stdin
+func readBundle(path string) (*model.Bundle, error) {
+	var reader io.Reader = os.Stdin
+
+	if path != stdioPath {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("open %s: %w", path, err)
+		}
+		defer file.Close()
+
+		reader = file
+	}
+
+	var bundle model.Bundle
+	if err := json.NewDecoder(reader).Decode(&bundle); err != nil {
+		return nil, fmt.Errorf("decode form bundle: %w", err)
+	}
+
+	return &bundle, nil
+}
+
+// findUserByRef resolves a user from either an email address or an ID
+func findUserByRef(ctx context.Context, svc user.Service, ref string) (*entities.User, error) {
+	if strings.Contains(ref, "@") {
+		return findUser(ctx, svc, "", ref)
+	}
+
+	return findUser(ctx, svc, ref, "")
+}
@@ -0,0 +1,298 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/form/draft"
+	"github.com/goformx/goforms/internal/domain/form/encryption"
+	"github.com/goformx/goforms/internal/domain/form/reencrypt"
+	"github.com/goformx/goforms/internal/domain/form/retention"
+	"github.com/goformx/goforms/internal/domain/user"
+)
+
+// hoursPerDay converts the "d" duration suffix accepted by -older-than
+const hoursPerDay = 24
+
+// runSubmissions implements the submissions command group
+func runSubmissions(ctx context.Context, args []string) error {
+	return dispatch(ctx, "submissions", args, map[string]subcommand{
+		"purge": {
+			usage: "purge -older-than 90d [-form ID] [-dry-run] [-json]   permanently delete old submissions",
+			run:   submissionsPurge,
+		},
+		"purge-drafts": {
+			usage: "purge-drafts [-json]   delete save-and-resume drafts that have expired",
+			run:   submissionsPurgeDrafts,
+		},
+		"retention": {
+			usage: "retention [-json]   apply per-form retention rules and purge soft-deleted forms and submissions",
+			run:   submissionsRetention,
+		},
+		"reencrypt": {
+			usage: "reencrypt [-json]   encrypt newly sensitive answers and re-wrap answers under retired keys",
+			run:   submissionsReencrypt,
+		},
+		"erase": {
+			usage: "erase -user REF -identifier EMAIL|PHONE [-dry-run] [-json]   permanently delete a submitter's data",
+			run:   submissionsErase,
+		},
+	})
+}
+
+// runSessions implements the sessions command group
+func runSessions(ctx context.Context, args []string) error {
+	return dispatch(ctx, "sessions", args, map[string]subcommand{
+		"flush": {
+			usage: "flush [-user ID] [-json]   end the sessions of one user, or of everyone, on their next request",
+			run:   sessionsFlush,
+		},
+	})
+}
+
+func submissionsPurge(ctx context.Context, args []string) error {
+	fs, asJSON := newFlagSet("submissions purge")
+	olderThan := fs.String("older-than", "", "age threshold, e.g. 720h or 90d")
+	formID := fs.String("form", "", "only purge submissions of this form")
+	dryRun := fs.Bool("dry-run", false, "report how many submissions would be purged")
+
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	if err := requireFlag(fs, "older-than", *olderThan); err != nil {
+		return err
+	}
+
+	age, err := parseAge(*olderThan)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid -older-than: %v\n", err)
+
+		return errUsage
+	}
+
+	cutoff := time.Now().UTC().Add(-age)
+
+	var repo form.SubmissionRepository
+
+	return withDependencies(ctx, func(ctx context.Context) error {
+		var (
+			count  int64
+			runErr error
+		)
+
+		if *dryRun {
+			count, runErr = repo.CountOlderThan(ctx, cutoff, *formID)
+		} else {
+			count, runErr = repo.PurgeOlderThan(ctx, cutoff, *formID)
+		}
+
+		if runErr != nil {
+			return runErr
+		}
+
+		verb := "purged"
+		if *dryRun {
+			verb = "would purge"
+		}
+
+		result := map[string]any{"cutoff": cutoff, "dry_run": *dryRun, "count": count}
+		printResult(*asJSON, result, "%s %d submission(s) older than %s", verb, count, cutoff.Format(time.RFC3339))
+
+		return nil
+	}, &repo)
+}
+
+func submissionsPurgeDrafts(ctx context.Context, args []string) error {
+	fs, asJSON := newFlagSet("submissions purge-drafts")
+
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	var drafts draft.Service
+
+	return withDependencies(ctx, func(ctx context.Context) error {
+		count, err := drafts.PurgeExpired(ctx)
+		if err != nil {
+			return err
+		}
+
+		printResult(*asJSON, map[string]any{"count": count}, "purged %d expired draft(s)", count)
+
+		return nil
+	}, &drafts)
+}
+
+func submissionsRetention(ctx context.Context, args []string) error {
+	fs, asJSON := newFlagSet("submissions retention")
+
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	var retentionService retention.Service
+
+	return withDependencies(ctx, func(ctx context.Context) error {
+		report, err := retentionService.Enforce(ctx)
+		if err != nil {
+			return err
+		}
+
+		if report.Skipped {
+			printResult(*asJSON, report, "skipped: another instance is enforcing retention")
+
+			return nil
+		}
+
+		printResult(*asJSON, report,
+			"checked %d form(s): deleted %d and anonymized %d submission(s); "+
+				"purged %d deleted submission(s) and %d deleted form(s)",
+			report.FormsChecked, report.Deleted, report.Anonymized, report.PurgedSubmissions, report.PurgedForms)
+
+		return nil
+	}, &retentionService)
+}
+
+func submissionsReencrypt(ctx context.Context, args []string) error {
+	fs, asJSON := newFlagSet("submissions reencrypt")
+
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	var (
+		encryptionService encryption.Service
+		reencryptService  reencrypt.Service
+	)
+
+	return withDependencies(ctx, func(ctx context.Context) error {
+		if !encryptionService.Enabled() {
+			return encryption.ErrNotConfigured
+		}
+
+		report, err := reencryptService.Run(ctx)
+		if err != nil {
+			return err
+		}
+
+		printResult(*asJSON, report,
+			"checked %d form(s): re-encrypted %d submission(s), %d revision(s) and %d draft(s)",
+			report.FormsChecked, report.Submissions, report.Revisions, report.Drafts)
+
+		return nil
+	}, &encryptionService, &reencryptService)
+}
+
+func submissionsErase(ctx context.Context, args []string) error {
+	fs, asJSON := newFlagSet("submissions erase")
+	userRef := fs.String("user", "", "email or ID of the user whose forms are searched")
+	identifier := fs.String("identifier", "", "the submitter's email address or phone number")
+	dryRun := fs.Bool("dry-run", false, "list the matching submissions without deleting them")
+
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	if err := requireFlag(fs, "user", *userRef); err != nil {
+		return err
+	}
+
+	if err := requireFlag(fs, "identifier", *identifier); err != nil {
+		return err
+	}
+
+	var (
+		retentionService retention.Service
+		userService      user.Service
+	)
+
+	return withDependencies(ctx, func(ctx context.Context) error {
+		owner, err := findUserByRef(ctx, userService, *userRef)
+		if err != nil {
+			return err
+		}
+
+		var matches []*retention.Match
+
+		if *dryRun {
+			matches, err = retentionService.FindSubmitter(ctx, owner.ID, *identifier)
+		} else {
+			matches, err = retentionService.EraseSubmitter(ctx, owner.ID, *identifier, nil)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		verb := "erased"
+		if *dryRun {
+			verb = "would erase"
+		}
+
+		if !*asJSON {
+			for _, m := range matches {
+				fmt.Fprintf(os.Stdout, "%s  form %s  %s  fields %s\n",
+					m.SubmissionID, m.FormID, m.SubmittedAt.Format(time.RFC3339), strings.Join(m.Fields, ","))
+			}
+		}
+
+		result := map[string]any{"dry_run": *dryRun, "count": len(matches), "matches": matches}
+		printResult(*asJSON, result, "%s %d submission(s)", verb, len(matches))
+
+		return nil
+	}, &retentionService, &userService)
+}
+
+func sessionsFlush(ctx context.Context, args []string) error {
+	fs, asJSON := newFlagSet("sessions flush")
+	userID := fs.String("user", "", "only flush sessions of this user ID")
+
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	var userService user.Service
+
+	return withDependencies(ctx, func(ctx context.Context) error {
+		revoked, err := userService.RevokeSessions(ctx, *userID)
+		if err != nil {
+			return fmt.Errorf("flush sessions: %w", err)
+		}
+
+		printResult(*asJSON, map[string]any{"users": revoked},
+			"flushed the sessions of %d user(s); they end on their next request", revoked)
+
+		return nil
+	}, &userService)
+}
+
+// parseAge parses a Go duration, additionally accepting whole days such as "30d"
+func parseAge(value string) (time.Duration, error) {
+	var (
+		age time.Duration
+		err error
+	)
+
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, parseErr := strconv.Atoi(days)
+		if parseErr != nil {
+			return 0, fmt.Errorf("parse days %q: %w", value, parseErr)
+		}
+
+		age = time.Duration(n) * hoursPerDay * time.Hour
+	} else if age, err = time.ParseDuration(value); err != nil {
+		return 0, fmt.Errorf("parse duration %q: %w", value, err)
+	}
+
+	if age <= 0 {
+		return 0, fmt.Errorf("duration %q must be positive", value)
+	}
+
+	return age, nil
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/goformx/goforms/internal/infrastructure/database/migration"
+)
+
+// runMigrate implements the migrate command group
+func runMigrate(ctx context.Context, args []string) error {
+	return dispatch(ctx, "migrate", args, map[string]subcommand{
+		"up": {
+			usage: "up [-json]                     apply all pending migrations",
+			run:   migrateUp,
+		},
+		"down": {
+			usage: "down [-steps N|-all] [-json]   roll back the last N migrations (default 1) or all of them",
+			run:   migrateDown,
+		},
+		"status": {
+			usage: "status [-json]                 show the schema version and pending migrations",
+			run:   migrateStatus,
+		},
+		"force": {
+			usage: "force -version V [-json]       set the schema version and clear the dirty flag (-1 for none)",
+			run:   migrateForce,
+		},
+	})
+}
+
+func migrateUp(ctx context.Context, args []string) error {
+	fs, asJSON := newFlagSet("migrate up")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	var migrator *migration.Migrator
+
+	return withDependencies(ctx, func(ctx context.Context) error {
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			return err
+		}
+
+		printResult(*asJSON, map[string]any{"applied": applied}, "applied %d migration(s)", applied)
+
+		return nil
+	}, &migrator)
+}
+
+func migrateDown(ctx context.Context, args []string) error {
+	fs, asJSON := newFlagSet("migrate down")
+	steps := fs.Int("steps", 1, "number of migrations to roll back")
+	all := fs.Bool("all", false, "roll back all migrations")
+
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	if *all {
+		*steps = 0
+	} else if *steps <= 0 {
+		fmt.Fprintln(os.Stderr, "-steps must be positive; use -all to roll back everything")
+
+		return errUsage
+	}
+
+	var migrator *migration.Migrator
+
+	return withDependencies(ctx, func(ctx context.Context) error {
+		rolledBack, err := migrator.Down(ctx, *steps)
+		if err != nil {
+			return err
+		}
+
+		printResult(*asJSON, map[string]any{"rolled_back": rolledBack}, "rolled back %d migration(s)", rolledBack)
+
+		return nil
+	}, &migrator)
+}
+
+func migrateStatus(ctx context.Context, args []string) error {
+	fs, asJSON := newFlagSet("migrate status")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	var migrator *migration.Migrator
+
+	return withDependencies(ctx, func(ctx context.Context) error {
+		status, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+
+		if *asJSON {
+			writeJSON(os.Stdout, status)
+
+			return nil
+		}
+
+		fmt.Fprintf(os.Stdout, "version: %d\ndirty:   %t\npending: %d\n\n", status.Version, status.Dirty, status.Pending)
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED")
+
+		for _, entry := range status.Entries {
+			fmt.Fprintf(w, "%d\t%s\t%t\n", entry.Version, entry.Name, entry.Applied)
+		}
+
+		if flushErr := w.Flush(); flushErr != nil {
+			return fmt.Errorf("write status: %w", flushErr)
+		}
+
+		return nil
+	}, &migrator)
+}
+
+func migrateForce(ctx context.Context, args []string) error {
+	fs, asJSON := newFlagSet("migrate force")
+	versionFlag := fs.String("version", "", "schema version to record, or -1 for none")
+
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	if err := requireFlag(fs, "version", *versionFlag); err != nil {
+		return err
+	}
+
+	version, err := strconv.ParseInt(*versionFlag, 10, 64)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid version %q\n", *versionFlag)
+
+		return errUsage
+	}
+
+	var migrator *migration.Migrator
+
+	return withDependencies(ctx, func(ctx context.Context) error {
+		if forceErr := migrator.Force(ctx, version); forceErr != nil {
+			return forceErr
+		}
+
+		printResult(*asJSON, map[string]any{"version": version}, "schema version forced to %d", version)
+
+		return nil
+	}, &migrator)
+}
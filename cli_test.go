@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+	"go.uber.org/mock/gomock"
+
+	"github.com/goformx/goforms/internal/domain/audit"
+	domainerrors "github.com/goformx/goforms/internal/domain/common/errors"
+	"github.com/goformx/goforms/internal/domain/entities"
+	"github.com/goformx/goforms/internal/domain/eventstore"
+	"github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/domain/user"
+	"github.com/goformx/goforms/internal/infrastructure/database/migration"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+	mockaudit "github.com/goformx/goforms/test/mocks/audit"
+)
+
+func TestCommandOptions_GraphIsComplete(t *testing.T) {
+	var (
+		migrator       *migration.Migrator
+		userRepo       user.Repository
+		userService    user.Service
+		formService    form.Service
+		submissionRepo form.SubmissionRepository
+		eventStore     eventstore.Service
+		auditService   audit.Service
+	)
+
+	err := fx.ValidateApp(commandOptions(
+		&migrator, &userRepo, &userService, &formService, &submissionRepo, &eventStore, &auditService,
+	))
+	require.NoError(t, err)
+}
+
+func TestExitCodeFor(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"usage", fmt.Errorf("wrapped: %w", errUsage), exitUsage},
+		{"store not found", common.NewNotFoundError("get", "form", "1"), exitNotFound},
+		{"domain not found", domainerrors.ErrUserNotFound, exitNotFound},
+		{"conflict", user.ErrUserExists, exitConflict},
+		{"other", errors.New("boom"), exitFailure},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, exitCodeFor(tt.err))
+		})
+	}
+}
+
+func TestParseAge(t *testing.T) {
+	age, err := parseAge("30d")
+	require.NoError(t, err)
+	assert.Equal(t, 30*24*time.Hour, age)
+
+	age, err = parseAge("36h")
+	require.NoError(t, err)
+	assert.Equal(t, 36*time.Hour, age)
+
+	for _, invalid := range []string{"", "xd", "-1d", "0h", "soon"} {
+		_, err = parseAge(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestParseReplayFilter(t *testing.T) {
+	filter, err := parseReplayFilter(10, 20, "form.created, form.deleted", "form:form-1", 5)
+	require.NoError(t, err)
+	assert.Equal(t, eventstore.Filter{
+		After:      10,
+		Until:      20,
+		Names:      []string{"form.created", "form.deleted"},
+		StreamType: "form",
+		StreamID:   "form-1",
+		Limit:      5,
+	}, filter)
+
+	for name, args := range map[string]struct {
+		from, to      int64
+		names, stream string
+	}{
+		"negative":      {from: -1},
+		"empty range":   {from: 5, to: 5},
+		"unknown event": {names: "form.renamed"},
+		"bad stream":    {stream: "user:1"},
+		"no stream id":  {stream: "form:"},
+	} {
+		_, err = parseReplayFilter(args.from, args.to, args.names, args.stream, 0)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestAuditUserChange(t *testing.T) {
+	auditService := mockaudit.NewMockService(gomock.NewController(t))
+	before := &entities.User{ID: "user-1", Email: "a@example.com", Role: "user", Active: true}
+	after := *before
+	after.Role = "admin"
+	after.Active = false
+
+	var recorded []*audit.Record
+
+	auditService.EXPECT().Record(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, record *audit.Record) error {
+			recorded = append(recorded, record)
+
+			return nil
+		}).Times(2)
+
+	require.NoError(t, auditUserChange(t.Context(), auditService, before, &after))
+	require.Len(t, recorded, 2)
+
+	assert.Equal(t, audit.ActionUserRoleChanged, recorded[0].Action)
+	assert.Equal(t, model.JSON{"role": "user"}, recorded[0].Before)
+	assert.Equal(t, model.JSON{"role": "admin"}, recorded[0].After)
+	assert.Equal(t, audit.ActionUserDisabled, recorded[1].Action)
+	assert.Equal(t, false, recorded[1].After["active"])
+
+	for _, record := range recorded {
+		assert.Equal(t, audit.TargetUser, record.TargetType)
+		assert.Equal(t, "user-1", record.TargetID)
+		assert.True(t, strings.HasPrefix(record.ActorEmail, "cli"), "the CLI is the actor")
+	}
+
+	assert.NoError(t, auditUserChange(t.Context(), auditService, &after, &after), "no change, no record")
+}
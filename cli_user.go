@@ -0,0 +1,319 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	osuser "os/user"
+	"slices"
+
+	"github.com/goformx/goforms/internal/application/constants"
+	"github.com/goformx/goforms/internal/domain/audit"
+	domainerrors "github.com/goformx/goforms/internal/domain/common/errors"
+	"github.com/goformx/goforms/internal/domain/entities"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/domain/user"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+)
+
+// generatedPasswordBytes is the entropy used for generated passwords
+const generatedPasswordBytes = 18
+
+// validRoles lists the roles accepted by user create and promote
+var validRoles = []string{constants.UserRoleUser, constants.UserRoleModerator, constants.UserRoleAdmin}
+
+// runUser implements the user command group
+func runUser(ctx context.Context, args []string) error {
+	return dispatch(ctx, "user", args, map[string]subcommand{
+		"create": {
+			usage: "create -email E -password P [-first-name F] [-last-name L] [-role R] [-json]",
+			run:   userCreate,
+		},
+		"promote": {
+			usage: "promote (-email E | -id ID) [-role admin] [-json]",
+			run:   userPromote,
+		},
+		"reset-password": {
+			usage: "reset-password (-email E | -id ID) [-password P] [-json]   generates a password when -password is omitted",
+			run:   userResetPassword,
+		},
+		"disable": {
+			usage: "disable (-email E | -id ID) [-json]   also flushes the user's sessions",
+			run:   userDisable,
+		},
+	})
+}
+
+func userCreate(ctx context.Context, args []string) error {
+	fs, asJSON := newFlagSet("user create")
+	email := fs.String("email", "", "email address")
+	password := fs.String("password", "", "initial password")
+	firstName := fs.String("first-name", "", "first name")
+	lastName := fs.String("last-name", "", "last name")
+	role := fs.String("role", constants.UserRoleUser, "role: user, moderator or admin")
+
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	if err := requireFlag(fs, "email", *email); err != nil {
+		return err
+	}
+
+	if err := requireFlag(fs, "password", *password); err != nil {
+		return err
+	}
+
+	if err := validateRole(*role); err != nil {
+		return err
+	}
+
+	var repo user.Repository
+
+	return withDependencies(ctx, func(ctx context.Context) error {
+		if _, getErr := repo.GetByEmail(ctx, *email); getErr == nil {
+			return user.ErrUserExists
+		} else if !errors.Is(getErr, common.ErrNotFound) {
+			return fmt.Errorf("check existing user: %w", getErr)
+		}
+
+		newUser, err := entities.NewUser(*email, *password, *firstName, *lastName)
+		if err != nil {
+			return domainerrors.Wrap(err, domainerrors.ErrCodeValidation, "invalid user")
+		}
+
+		newUser.Role = *role
+
+		if createErr := repo.Create(ctx, newUser); createErr != nil {
+			return fmt.Errorf("create user: %w", createErr)
+		}
+
+		printResult(*asJSON, newUser, "created user %s (%s) with role %s", newUser.Email, newUser.ID, newUser.Role)
+
+		return nil
+	}, &repo)
+}
+
+func userPromote(ctx context.Context, args []string) error {
+	fs, asJSON := newFlagSet("user promote")
+	email := fs.String("email", "", "email address of the user")
+	id := fs.String("id", "", "ID of the user")
+	role := fs.String("role", constants.UserRoleAdmin, "role to grant: user, moderator or admin")
+
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	if err := validateRole(*role); err != nil {
+		return err
+	}
+
+	return updateUser(ctx, fs, *id, *email, func(u *entities.User) error {
+		u.Role = *role
+
+		return nil
+	}, func(u *entities.User) (any, string, error) {
+		return u, fmt.Sprintf("user %s now has role %s", u.Email, u.Role), nil
+	}, *asJSON)
+}
+
+func userResetPassword(ctx context.Context, args []string) error {
+	fs, asJSON := newFlagSet("user reset-password")
+	email := fs.String("email", "", "email address of the user")
+	id := fs.String("id", "", "ID of the user")
+	password := fs.String("password", "", "new password; generated when omitted")
+
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	newPassword := *password
+	generated := newPassword == ""
+
+	if generated {
+		var err error
+		if newPassword, err = generatePassword(); err != nil {
+			return err
+		}
+	}
+
+	return updateUser(ctx, fs, *id, *email, func(u *entities.User) error {
+		if err := u.SetPassword(newPassword); err != nil {
+			return domainerrors.Wrap(err, domainerrors.ErrCodeValidation, "invalid password")
+		}
+
+		return nil
+	}, func(u *entities.User) (any, string, error) {
+		result := map[string]any{"id": u.ID, "email": u.Email}
+		message := "password reset for " + u.Email
+
+		if generated {
+			result["password"] = newPassword
+			message += "; new password: " + newPassword
+		}
+
+		return result, message, nil
+	}, *asJSON)
+}
+
+func userDisable(ctx context.Context, args []string) error {
+	fs, asJSON := newFlagSet("user disable")
+	email := fs.String("email", "", "email address of the user")
+	id := fs.String("id", "", "ID of the user")
+
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	return updateUser(ctx, fs, *id, *email, func(u *entities.User) error {
+		u.Deactivate()
+
+		return nil
+	}, func(u *entities.User) (any, string, error) {
+		return u, fmt.Sprintf("disabled user %s; its sessions end on their next request", u.Email), nil
+	}, *asJSON)
+}
+
+// updateUser looks up a user by ID or email, applies change, saves the result
+// and audits a change of role or deactivation. report runs once the user is
+// saved and describes the outcome.
+func updateUser(
+	ctx context.Context,
+	fs *flag.FlagSet,
+	id, email string,
+	change func(u *entities.User) error,
+	report func(u *entities.User) (result any, message string, err error),
+	asJSON bool,
+	targets ...any,
+) error {
+	if (id == "") == (email == "") {
+		fmt.Fprintln(os.Stderr, "exactly one of -email or -id is required")
+		fs.Usage()
+
+		return errUsage
+	}
+
+	var (
+		svc          user.Service
+		auditService audit.Service
+	)
+
+	return withDependencies(ctx, func(ctx context.Context) error {
+		u, err := findUser(ctx, svc, id, email)
+		if err != nil {
+			return err
+		}
+
+		before := *u
+
+		if changeErr := change(u); changeErr != nil {
+			return changeErr
+		}
+
+		if updateErr := svc.UpdateUser(ctx, u); updateErr != nil {
+			return updateErr
+		}
+
+		if auditErr := auditUserChange(ctx, auditService, &before, u); auditErr != nil {
+			return auditErr
+		}
+
+		result, message, err := report(u)
+		if err != nil {
+			return err
+		}
+
+		printResult(asJSON, result, "%s", message)
+
+		return nil
+	}, append([]any{&svc, &auditService}, targets...)...)
+}
+
+// auditUserChange records the role change and deactivation between before and
+// after. The CLI is the actor, named with the operating system user running it.
+func auditUserChange(ctx context.Context, auditService audit.Service, before, after *entities.User) error {
+	var records []*audit.Record
+
+	if before.Role != after.Role {
+		records = append(records, &audit.Record{
+			Action: audit.ActionUserRoleChanged,
+			Before: model.JSON{"role": before.Role},
+			After:  model.JSON{"role": after.Role},
+		})
+	}
+
+	if before.Active && !after.Active {
+		records = append(records, &audit.Record{
+			Action: audit.ActionUserDisabled,
+			Before: model.JSON{"active": true, "role": before.Role},
+			After:  model.JSON{"active": false, "role": after.Role},
+		})
+	}
+
+	for _, record := range records {
+		record.ActorEmail = cliActor()
+		record.TargetType = audit.TargetUser
+		record.TargetID = after.ID
+		record.OwnerID = after.ID
+
+		if err := auditService.Record(ctx, record); err != nil {
+			return fmt.Errorf("audit %s of user %s: %w", record.Action, after.Email, err)
+		}
+	}
+
+	return nil
+}
+
+// cliActor names the CLI as the actor of an audited change
+func cliActor() string {
+	current, err := osuser.Current()
+	if err != nil {
+		return "cli"
+	}
+
+	return "cli:" + current.Username
+}
+
+// findUser resolves a user by ID or email
+func findUser(ctx context.Context, svc user.Service, id, email string) (*entities.User, error) {
+	if id != "" {
+		u, err := svc.GetUserByID(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("find user %s: %w", id, err)
+		}
+
+		return u, nil
+	}
+
+	u, err := svc.GetUserByEmail(ctx, email)
+	if err != nil {
+		return nil, fmt.Errorf("find user %s: %w", email, err)
+	}
+
+	return u, nil
+}
+
+// validateRole reports a usage error for unknown roles
+func validateRole(role string) error {
+	if !slices.Contains(validRoles, role) {
+		fmt.Fprintf(os.Stderr, "invalid role %q: must be one of %v\n", role, validRoles)
+
+		return errUsage
+	}
+
+	return nil
+}
+
+// generatePassword returns a random URL-safe password
+func generatePassword() (string, error) {
+	buf := make([]byte, generatedPasswordBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generate password: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
@@ -47,12 +47,15 @@ const (
 	PathForgotPassword = "/forgot-password"
 	PathResetPassword  = "/reset-password"
 	PathVerifyEmail    = "/verify-email"
+	PathPublicForms    = "/f"
+	PathLocale         = "/locale"
 
 	// Authenticated paths
 	PathDashboard = "/dashboard"
 	PathForms     = "/forms"
 	PathProfile   = "/profile"
 	PathSettings  = "/settings"
+	PathAudit     = "/audit"
 
 	// Admin paths
 	PathAdmin      = "/admin"
@@ -68,6 +71,12 @@ const (
 	PathAPIHealth           = "/api/v1/health"
 	PathAPIMetrics          = "/api/v1/metrics"
 	PathAPIForms            = "/api/v1/forms"
+	PathAPITemplates        = "/api/v1/templates"
+	PathAPIAudit            = "/api/v1/audit"
+	PathAPIErasure          = "/api/v1/erasure"
+	PathAPILive             = "/api/v1/live"
+	PathAPIOpenAPI          = "/api/openapi.json"
+	PathAPIDocs             = "/api/docs"
 	PathAPIAdmin            = "/api/v1/admin"
 	PathAPIAdminUsers       = "/api/v1/admin/users"
 	PathAPIAdminForms       = "/api/v1/admin/forms"
@@ -27,8 +27,12 @@ func NewPathManager() *PathManager {
 			PathForgotPassword,
 			PathResetPassword,
 			PathVerifyEmail,
+			PathPublicForms,
+			PathLocale,
 			PathAPIHealth,
 			PathAPIValidation,
+			PathAPIOpenAPI,
+			PathAPIDocs,
 		},
 		StaticPaths: []string{
 			PathStatic,
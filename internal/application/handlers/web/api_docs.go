@@ -0,0 +1,543 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/constants"
+	"github.com/goformx/goforms/internal/application/live"
+	"github.com/goformx/goforms/internal/application/middleware"
+	"github.com/goformx/goforms/internal/application/openapi"
+	"github.com/goformx/goforms/internal/domain/audit"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/domain/form/revision"
+	"github.com/goformx/goforms/internal/infrastructure/config"
+)
+
+// API tags, in the order the docs viewer lists them
+const (
+	tagForms       = "forms"
+	tagSubmissions = "submissions"
+	tagTemplates   = "templates"
+	tagPublic      = "public"
+	tagValidation  = "validation"
+	tagAudit       = "audit"
+	tagErasure     = "erasure"
+	tagLive        = "live"
+)
+
+// Default names of the session cookie and CSRF header, used when the
+// configuration leaves them empty
+const (
+	defaultSessionCookie = "session"
+	defaultCSRFHeader    = "X-Csrf-Token"
+)
+
+// pathParams describes the path parameters of the API routes
+var pathParams = map[string]string{
+	"id":     "Form ID",
+	"sid":    "Submission ID",
+	"number": "Revision number",
+	"token":  "Draft resume token",
+}
+
+// templatePathParams describes the path parameters of the template routes
+var templatePathParams = map[string]string{"id": "Template ID"}
+
+// Common error statuses of the form routes
+var (
+	ownedFormErrors  = []int{http.StatusForbidden, http.StatusNotFound, http.StatusInternalServerError}
+	publicFormErrors = []int{http.StatusNotFound, http.StatusInternalServerError}
+	typedFormErrors  = []int{
+		http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusInternalServerError,
+	}
+)
+
+// auditQuery lists the filters of the audit log routes
+var auditQuery = []*openapi.Parameter{
+	openapi.QueryParam("actor_id", "string", "Only records of this user"),
+	openapi.QueryParam("action", "string", "Only records of this action, such as form.updated"),
+	openapi.QueryParam("target_type", "string", "Only records about this kind of resource"),
+	openapi.QueryParam("target_id", "string", "Only records about this resource"),
+	openapi.QueryParam("since", "string", "Only records at or after this RFC 3339 time or date"),
+	openapi.QueryParam("until", "string", "Only records before this RFC 3339 time, or up to the end of this date"),
+	openapi.QueryParam("offset", "integer", "Number of records to skip"),
+	openapi.QueryParam("limit", "integer", "Maximum number of records to return"),
+}
+
+// APIRoutes describes every /api/v1 route. The OpenAPI document is generated
+// from it, and TestAPIRoutes checks it against the routes the handlers
+// register, so a route added without a description here fails the build.
+func APIRoutes() []openapi.Route {
+	return slices.Concat(formRoutes(), submissionRoutes(), templateRoutes(), publicRoutes(), otherRoutes())
+}
+
+// formRoutes describes the routes that manage forms
+func formRoutes() []openapi.Route {
+	return []openapi.Route{
+		{
+			Method: http.MethodGet, Path: "/api/v1/forms", Tag: tagForms, Auth: openapi.Session,
+			Summary:  "List your forms",
+			Response: FormListResponse{},
+			Errors:   []int{http.StatusForbidden, http.StatusInternalServerError},
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/forms", Tag: tagForms, Auth: openapi.Session,
+			Summary:      "Create a form",
+			Description:  "Starts from schema when given, otherwise from the template, otherwise from an empty form.",
+			Request:      FormCreateRequest{},
+			Response:     FormCreatedResponse{},
+			Status:       http.StatusCreated,
+			DomainErrors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/forms/:id", Tag: tagForms, Auth: openapi.Session,
+			Summary:  "Get a form",
+			Response: FormResponse{},
+			Errors:   ownedFormErrors,
+		},
+		{
+			Method: http.MethodPut, Path: "/api/v1/forms/:id", Tag: tagForms, Auth: openapi.Session,
+			Summary:      "Update a form's details and settings",
+			Description:  "Omitted settings keep their current value. The schema is updated separately.",
+			Request:      FormUpdateRequest{},
+			Response:     FormResponse{},
+			DomainErrors: typedFormErrors,
+		},
+		{
+			Method: http.MethodDelete, Path: "/api/v1/forms/:id", Tag: tagForms, Auth: openapi.Session,
+			Summary:      "Delete a form",
+			Status:       http.StatusNoContent,
+			Raw:          true,
+			DomainErrors: typedFormErrors,
+		},
+		{
+			Method: http.MethodPatch, Path: "/api/v1/forms/:id/status", Tag: tagForms, Auth: openapi.Session,
+			Summary:      "Publish, unpublish or archive a form",
+			Description:  "A form can only be published once it lists its allowed CORS origins.",
+			Request:      FormStatusRequest{},
+			Response:     FormResponse{},
+			DomainErrors: typedFormErrors,
+		},
+		{
+			Method: http.MethodPut, Path: "/api/v1/forms/:id/schema", Tag: tagForms, Auth: openapi.Session,
+			Summary:     "Replace a form's schema",
+			Description: "Translations saved with the form are kept unless the schema holds its own.",
+			Request:     model.JSON{},
+			Response:    model.JSON{},
+			Raw:         true,
+			Errors:      append([]int{http.StatusBadRequest}, ownedFormErrors...),
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/forms/:id/translations", Tag: tagForms, Auth: openapi.Session,
+			Summary:  "Get a form's translations",
+			Response: TranslationsResponse{},
+			Errors:   ownedFormErrors,
+		},
+		{
+			Method: http.MethodPut, Path: "/api/v1/forms/:id/translations", Tag: tagForms, Auth: openapi.Session,
+			Summary:  "Replace a form's translations",
+			Request:  map[string]model.Translation{},
+			Response: TranslationsResponse{},
+			Errors:   append([]int{http.StatusBadRequest}, ownedFormErrors...),
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/forms/:id/export", Tag: tagForms, Auth: openapi.Session,
+			Summary:  "Export a form as a bundle",
+			Response: model.Bundle{},
+			Raw:      true,
+			Errors:   ownedFormErrors,
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/forms/import", Tag: tagForms, Auth: openapi.Session,
+			Summary: "Import a form bundle",
+			Query: []*openapi.Parameter{
+				openapi.QueryParam("on_conflict", "string",
+					"What to do when the bundle's form ID is used by one of your forms: new_id (default) or fail"),
+			},
+			Request:  model.Bundle{},
+			Response: FormCreatedResponse{},
+			Status:   http.StatusCreated,
+			Errors:   []int{http.StatusBadRequest, http.StatusForbidden, http.StatusConflict, http.StatusInternalServerError},
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/forms/import/json-schema", Tag: tagForms, Auth: openapi.Session,
+			Summary: "Create a form from a JSON Schema",
+			Description: "Each property of the JSON Schema object becomes a form field, in the order the " +
+				"document lists them.",
+			Query: []*openapi.Parameter{
+				openapi.QueryParam("title", "string", "Form title, replacing the document's title"),
+			},
+			Request:      model.JSON{},
+			Response:     FormCreatedResponse{},
+			Status:       http.StatusCreated,
+			DomainErrors: []int{http.StatusBadRequest, http.StatusInternalServerError},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/forms/:id/json-schema", Tag: tagForms, Auth: openapi.Session,
+			Summary:     "Describe a form's submissions as JSON Schema",
+			Description: "JSON Schema draft 2020-12 document of the form's current or an earlier schema version.",
+			Query: []*openapi.Parameter{
+				openapi.QueryParam("version", "integer", "Schema version; the current one when left out"),
+				openapi.QueryParam("lang", "string", "Language to translate the field titles into"),
+			},
+			Response:     model.JSON{},
+			Raw:          true,
+			Produces:     []string{JSONSchemaContentType},
+			DomainErrors: typedFormErrors,
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/forms/:id/json-schema/versions", Tag: tagForms,
+			Auth:         openapi.Session,
+			Summary:      "List a form's schema versions",
+			Response:     []model.SchemaVersion{},
+			DomainErrors: typedFormErrors,
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/forms/:id/duplicate", Tag: tagForms, Auth: openapi.Session,
+			Summary:  "Duplicate a form",
+			Response: FormCreatedResponse{},
+			Status:   http.StatusCreated,
+			Errors:   ownedFormErrors,
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/forms/:id/embed", Tag: tagForms, Auth: openapi.Session,
+			Summary:  "Get a form's public link and embed snippets",
+			Response: EmbedCodeResponse{},
+			Errors:   ownedFormErrors,
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/forms/:id/template", Tag: tagTemplates, Auth: openapi.Session,
+			Summary:  "Save a form as a template",
+			Request:  SaveTemplateRequest{},
+			Response: TemplateResponse{},
+			Status:   http.StatusCreated,
+			Errors:   append([]int{http.StatusBadRequest}, ownedFormErrors...),
+		},
+	}
+}
+
+// submissionRoutes describes the routes that read and edit submissions
+func submissionRoutes() []openapi.Route {
+	return []openapi.Route{
+		{
+			Method: http.MethodGet, Path: "/api/v1/forms/:id/submissions", Tag: tagSubmissions, Auth: openapi.Session,
+			Summary: "List a form's submissions, a page at a time",
+			Query: []*openapi.Parameter{
+				openapi.QueryParam("page", "integer", "Page number, from 1"),
+				openapi.QueryParam("page_size", "integer", "Submissions per page, up to 100. Defaults to 20."),
+				openapi.QueryParam("sort", "string",
+					"Sort field: submitted_at, created_at, updated_at or status, prefixed with - for "+
+						"descending order. Defaults to -submitted_at."),
+				openapi.QueryParam("fields", "string",
+					"Comma-separated submission fields to return: "+strings.Join(SubmissionFields, ", ")),
+			},
+			Response:     SubmissionPageResponse{},
+			DomainErrors: typedFormErrors,
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/forms/:id/submissions/:sid", Tag: tagSubmissions,
+			Auth:     openapi.Session,
+			Summary:  "Get a submission",
+			Response: SubmissionSummary{},
+			Errors:   ownedFormErrors,
+		},
+		{
+			Method: http.MethodPut, Path: "/api/v1/forms/:id/submissions/:sid", Tag: tagSubmissions,
+			Auth:        openapi.Session,
+			Summary:     "Edit a submission",
+			Description: "Replaces the submission's answers. The previous answers stay in its history.",
+			Request:     model.JSON{},
+			Response:    revision.Revision{},
+			Errors:      append([]int{http.StatusBadRequest}, ownedFormErrors...),
+		},
+		{
+			Method: http.MethodDelete, Path: "/api/v1/forms/:id/submissions/:sid", Tag: tagSubmissions,
+			Auth:        openapi.Session,
+			Summary:     "Delete a submission",
+			Description: "Deletes the submission and its history.",
+			Status:      http.StatusNoContent,
+			Raw:         true,
+			Errors:      ownedFormErrors,
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/forms/:id/submissions/:sid/revisions", Tag: tagSubmissions,
+			Auth:     openapi.Session,
+			Summary:  "List a submission's revisions, oldest first",
+			Response: RevisionListResponse{},
+			Errors:   ownedFormErrors,
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/forms/:id/submissions/:sid/revisions/:number/restore",
+			Tag: tagSubmissions, Auth: openapi.Session,
+			Summary:     "Restore a submission revision",
+			Description: "Restoring adds a new revision with the restored answers.",
+			Response:    revision.Revision{},
+			Errors:      append([]int{http.StatusBadRequest}, ownedFormErrors...),
+		},
+	}
+}
+
+// templateRoutes describes the routes that manage templates
+func templateRoutes() []openapi.Route {
+	return []openapi.Route{
+		{
+			Method: http.MethodGet, Path: "/api/v1/templates", Tag: tagTemplates, Auth: openapi.Session,
+			Summary:  "List the built-in templates and yours",
+			Response: TemplateListResponse{},
+			Errors:   []int{http.StatusForbidden, http.StatusInternalServerError},
+		},
+		{
+			Method: http.MethodDelete, Path: "/api/v1/templates/:id", Tag: tagTemplates, Auth: openapi.Session,
+			Summary:    "Delete one of your templates",
+			PathParams: templatePathParams,
+			Status:     http.StatusNoContent,
+			Raw:        true,
+			Errors:     []int{http.StatusForbidden, http.StatusNotFound, http.StatusInternalServerError},
+		},
+	}
+}
+
+// publicRoutes describes the routes used by hosted and embedded forms
+func publicRoutes() []openapi.Route {
+	return []openapi.Route{
+		{
+			Method: http.MethodGet, Path: "/api/v1/forms/:id/schema", Tag: tagPublic,
+			Summary: "Get a form's schema",
+			Description: "Without lang the schema is returned as saved, translations included. " +
+				"With lang it is translated into that language.",
+			Query: []*openapi.Parameter{
+				openapi.QueryParam("lang", "string", "Language to translate the schema into, such as fr"),
+			},
+			Response: model.JSON{},
+			Raw:      true,
+			Errors:   publicFormErrors,
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/forms/:id/validation", Tag: tagPublic,
+			Summary:  "Get a form's client-side validation rules",
+			Response: model.JSON{},
+			Errors:   append([]int{http.StatusBadRequest}, publicFormErrors...),
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/forms/:id/submit", Tag: tagPublic,
+			Summary:  "Submit a form",
+			Request:  model.JSON{},
+			Response: SubmissionCreatedResponse{},
+			Errors:   append([]int{http.StatusBadRequest}, publicFormErrors...),
+		},
+		{
+			Method: http.MethodPatch, Path: "/api/v1/forms/:id/draft", Tag: tagPublic,
+			Summary:     "Start a draft",
+			Description: "Saves partial answers and returns the token that resumes them.",
+			Request:     model.JSON{},
+			Response:    DraftResponse{},
+			Errors:      append([]int{http.StatusBadRequest}, publicFormErrors...),
+		},
+		{
+			Method: http.MethodPatch, Path: "/api/v1/forms/:id/draft/:token", Tag: tagPublic,
+			Summary:     "Save a draft",
+			Description: "Merges the answers into the draft and extends its expiry.",
+			Request:     model.JSON{},
+			Response:    DraftResponse{},
+			Errors:      append([]int{http.StatusBadRequest}, publicFormErrors...),
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/forms/:id/draft/:token", Tag: tagPublic,
+			Summary:  "Resume a draft",
+			Response: DraftResponse{},
+			Errors:   publicFormErrors,
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/forms/:id/draft/:token/submit", Tag: tagPublic,
+			Summary:     "Submit a draft",
+			Description: "The body holds the answers not yet saved to the draft.",
+			Request:     model.JSON{},
+			Response:    SubmissionCreatedResponse{},
+			Errors:      append([]int{http.StatusBadRequest}, publicFormErrors...),
+		},
+	}
+}
+
+// otherRoutes describes the validation, audit, erasure and live feed routes
+func otherRoutes() []openapi.Route {
+	return []openapi.Route{
+		{
+			Method: http.MethodGet, Path: "/api/v1/validation/new-form", Tag: tagValidation,
+			Summary:  "Get the validation rules of the new form form",
+			Response: model.JSON{},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/validation/user-login", Tag: tagValidation,
+			Summary:  "Get the validation rules of the login form",
+			Response: model.JSON{},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/validation/user-signup", Tag: tagValidation,
+			Summary:  "Get the validation rules of the signup form",
+			Response: model.JSON{},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/audit", Tag: tagAudit, Auth: openapi.Session,
+			Summary:     "List audit records",
+			Description: "Lists the records about your resources, newest first. Admins see every record.",
+			Query:       auditQuery,
+			Response:    audit.Page{},
+			Errors:      []int{http.StatusBadRequest, http.StatusForbidden, http.StatusInternalServerError},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/audit/export", Tag: tagAudit, Auth: openapi.Session,
+			Summary: "Export audit records",
+			Query: append([]*openapi.Parameter{
+				openapi.QueryParam("format", "string", "csv (default) or json"),
+			}, auditQuery...),
+			Response: []*audit.Record{},
+			Raw:      true,
+			Produces: []string{openapi.JSONContentType, "text/csv"},
+			Errors:   []int{http.StatusBadRequest, http.StatusForbidden, http.StatusInternalServerError},
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/erasure/search", Tag: tagErasure, Auth: openapi.Session,
+			Summary:  "Find a submitter's submissions",
+			Request:  ErasureRequest{},
+			Response: ErasureResponse{},
+			Errors:   []int{http.StatusBadRequest, http.StatusInternalServerError},
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v1/erasure/erase", Tag: tagErasure, Auth: openapi.Session,
+			Summary:     "Erase a submitter's submissions",
+			Description: "Permanently deletes the matching submissions, or only the listed ones.",
+			Request:     ErasureRequest{},
+			Response:    ErasureResponse{},
+			Errors:      []int{http.StatusBadRequest, http.StatusInternalServerError},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/live/submissions", Tag: tagLive, Auth: openapi.Session,
+			Summary: "Follow new submissions",
+			Description: "Server-Sent Events stream of the submissions to your forms. It starts with a " +
+				"counts event holding each form's total, then sends a submission event for every new " +
+				"submission and a processed event once it passes validation. Reconnect with " +
+				"Last-Event-ID to receive the events you missed.",
+			Query: []*openapi.Parameter{
+				openapi.QueryParam("form_id", "string", "Only follow this form"),
+			},
+			Response: "",
+			Raw:      true,
+			Produces: []string{live.ContentType},
+			Errors: []int{
+				http.StatusNotFound, http.StatusTooManyRequests, http.StatusInternalServerError,
+				http.StatusServiceUnavailable,
+			},
+		},
+	}
+}
+
+// NewAPIDocument generates the OpenAPI document of the API
+func NewAPIDocument(sessionCookie, csrfHeader string) *openapi.Document {
+	routes := APIRoutes()
+	for i := range routes {
+		if routes[i].PathParams == nil {
+			routes[i].PathParams = pathParams
+		}
+	}
+
+	return openapi.Build(&openapi.Config{
+		Info: openapi.Info{
+			Title:   "GoFormX API",
+			Version: "v1",
+			Description: "JSON API for managing forms, templates and submissions, and for the " +
+				"hosted and embedded forms. Responses are wrapped in {success, message, data} " +
+				"unless noted otherwise.",
+		},
+		Tags: []openapi.Tag{
+			{Name: tagForms, Description: "Manage your forms"},
+			{Name: tagSubmissions, Description: "Read and edit the submissions to your forms"},
+			{Name: tagTemplates, Description: "Form templates"},
+			{Name: tagPublic, Description: "Used by hosted and embedded forms; no sign-in required"},
+			{Name: tagValidation, Description: "Client-side validation rules of the app's own forms"},
+			{Name: tagAudit, Description: "Audit log"},
+			{Name: tagErasure, Description: "Find and erase a submitter's data"},
+			{Name: tagLive, Description: "Live updates for the dashboard"},
+		},
+		SessionCookie: sessionCookie,
+		CSRFHeader:    csrfHeader,
+		RequiresCSRF:  middleware.RequiresCSRF,
+	}, routes)
+}
+
+// APIDocsHandler serves the OpenAPI document and the docs viewer
+type APIDocsHandler struct {
+	document *openapi.Document
+}
+
+// NewAPIDocsHandler creates a new APIDocsHandler, naming the session cookie and
+// CSRF header as configured
+func NewAPIDocsHandler(cfg *config.Config) *APIDocsHandler {
+	sessionCookie := defaultSessionCookie
+	csrfHeader := defaultCSRFHeader
+
+	if cfg != nil {
+		if cfg.Session.CookieName != "" {
+			sessionCookie = cfg.Session.CookieName
+		}
+
+		if header, ok := strings.CutPrefix(cfg.Security.CSRF.TokenLookup, "header:"); ok && header != "" {
+			csrfHeader = header
+		}
+	}
+
+	return &APIDocsHandler{document: NewAPIDocument(sessionCookie, csrfHeader)}
+}
+
+// RegisterRoutes registers the document and viewer routes
+func (h *APIDocsHandler) RegisterRoutes(e *echo.Echo) {
+	e.GET(constants.PathAPIOpenAPI, h.handleDocument)
+	e.GET(constants.PathAPIDocs, h.handleViewer("index.html", "text/html; charset=utf-8"))
+	e.GET(constants.PathAPIDocs+"/viewer.js", h.handleViewer("viewer.js", "text/javascript; charset=utf-8"))
+	e.GET(constants.PathAPIDocs+"/viewer.css", h.handleViewer("viewer.css", "text/css; charset=utf-8"))
+}
+
+// Register satisfies the Handler interface; routes are registered by RegisterHandlers
+func (h *APIDocsHandler) Register(_ *echo.Echo) {}
+
+// Start initializes the handler
+func (h *APIDocsHandler) Start(_ context.Context) error {
+	return nil
+}
+
+// Stop cleans up the handler
+func (h *APIDocsHandler) Stop(_ context.Context) error {
+	return nil
+}
+
+// GET /api/openapi.json
+func (h *APIDocsHandler) handleDocument(c echo.Context) error {
+	if err := c.JSON(http.StatusOK, h.document); err != nil {
+		return fmt.Errorf("send openapi document: %w", err)
+	}
+
+	return nil
+}
+
+// GET /api/docs
+//
+// handleViewer serves one file of the docs viewer
+func (h *APIDocsHandler) handleViewer(name, contentType string) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		data, err := openapi.Viewer.ReadFile("viewer/" + name)
+		if err != nil {
+			return echo.ErrNotFound
+		}
+
+		if blobErr := c.Blob(http.StatusOK, contentType, data); blobErr != nil {
+			return fmt.Errorf("send docs viewer: %w", blobErr)
+		}
+
+		return nil
+	}
+}
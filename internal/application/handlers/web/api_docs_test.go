@@ -0,0 +1,152 @@
+package web_test
+
+import (
+	"encoding/json"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/goformx/goforms/internal/application/handlers/web"
+	"github.com/goformx/goforms/internal/application/openapi"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+// apiSpecFile is the committed OpenAPI document
+const apiSpecFile = "../../../../docs/api/openapi.json"
+
+var updateAPISpec = flag.Bool("update", false, "rewrite docs/api/openapi.json from the handlers")
+
+// registeredAPIRoutes registers every handler's routes and returns the
+// /api/v1 ones as "METHOD path"
+func registeredAPIRoutes(t *testing.T) []string {
+	t.Helper()
+
+	logger := mocklogging.NewMockLogger(gomock.NewController(t))
+	logger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+	logger.EXPECT().Debug(gomock.Any(), gomock.Any()).AnyTimes()
+
+	base := &web.BaseHandler{}
+	formBase := &web.FormBaseHandler{BaseHandler: base}
+	handlers := []web.Handler{
+		&web.AuthHandler{BaseHandler: base},
+		&web.PageHandler{BaseHandler: base},
+		&web.FormWebHandler{FormBaseHandler: formBase},
+		&web.FormAPIHandler{FormBaseHandler: formBase},
+		&web.DashboardHandler{BaseHandler: base},
+		&web.PublicFormHandler{BaseHandler: base},
+		&web.AuditHandler{BaseHandler: base},
+		&web.ErasureHandler{BaseHandler: base},
+		&web.LiveHandler{BaseHandler: base},
+		web.NewAPIDocsHandler(nil),
+	}
+
+	e := echo.New()
+	web.RegisterHandlers(e, handlers, nil, logger)
+
+	seen := make(map[string]bool)
+
+	var routes []string
+
+	for _, route := range e.Routes() {
+		// Groups with middleware add a not-found route for their prefix
+		if route.Method == echo.RouteNotFound {
+			continue
+		}
+
+		op := route.Method + " " + route.Path
+		if strings.HasPrefix(route.Path, "/api/v1/") && !seen[op] {
+			seen[op] = true
+			routes = append(routes, op)
+		}
+	}
+
+	return routes
+}
+
+// Every /api/v1 route must be described, and every description must match a route
+func TestAPIRoutes_matchRegisteredRoutes(t *testing.T) {
+	assert.ElementsMatch(t, registeredAPIRoutes(t), openapi.Operations(web.APIRoutes()))
+}
+
+// The committed document must match the one generated from the handlers. Run
+// the test with -update after changing an API route or type.
+func TestAPIDocument_matchesCommittedSpec(t *testing.T) {
+	generated, err := json.MarshalIndent(web.NewAPIDocument("session", "X-Csrf-Token"), "", "  ")
+	require.NoError(t, err)
+
+	generated = append(generated, '\n')
+
+	if *updateAPISpec {
+		require.NoError(t, os.WriteFile(apiSpecFile, generated, 0o600))
+	}
+
+	committed, err := os.ReadFile(apiSpecFile)
+	require.NoError(t, err)
+	assert.Equal(t, string(committed), string(generated),
+		"docs/api/openapi.json is out of date; run go test ./internal/application/handlers/web -run APIDocument -update")
+}
+
+func TestAPIDocument(t *testing.T) {
+	doc := web.NewAPIDocument("session", "X-Csrf-Token")
+
+	operationIDs := make(map[string]bool)
+
+	for path, item := range doc.Paths {
+		for method, op := range *item {
+			assert.False(t, operationIDs[op.OperationID], "duplicate operation ID %s", op.OperationID)
+			operationIDs[op.OperationID] = true
+
+			assert.NotEmpty(t, op.Summary, "%s %s", method, path)
+		}
+	}
+
+	create := (*doc.Paths["/api/v1/forms"])["post"]
+	assert.Equal(t, []openapi.SecurityRequirement{
+		{openapi.SessionCookieScheme: {}, openapi.CSRFTokenScheme: {}},
+	}, create.Security)
+	assert.Contains(t, create.Responses, "201")
+	assert.Equal(t, openapi.SchemaRefPrefix+"APIErrorResponse",
+		create.Responses["400"].Content[openapi.JSONContentType].Schema.Ref)
+
+	submit := (*doc.Paths["/api/v1/forms/{id}/submit"])["post"]
+	assert.Empty(t, submit.Security)
+	assert.Equal(t, "id", submit.Parameters[0].Name)
+	assert.Equal(t, "path", submit.Parameters[0].In)
+
+	form := doc.Components.Schemas["FormSummary"]
+	require.NotNil(t, form)
+	assert.Equal(t, "date-time", form.Properties["created_at"].Format)
+	assert.Equal(t, []any{"draft", "published", "archived"}, form.Properties["status"].Enum)
+}
+
+func TestAPIDocsHandler(t *testing.T) {
+	e := echo.New()
+	web.NewAPIDocsHandler(nil).RegisterRoutes(e)
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/openapi.json", http.NoBody))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var doc openapi.Document
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &doc))
+	assert.Equal(t, openapi.Version, doc.OpenAPI)
+	assert.Equal(t, "session", doc.Components.SecuritySchemes[openapi.SessionCookieScheme].Name)
+
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/docs", http.NoBody))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Header().Get(echo.HeaderContentType), "text/html")
+	assert.Contains(t, rec.Body.String(), "/api/docs/viewer.js")
+
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/docs/viewer.js", http.NoBody))
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
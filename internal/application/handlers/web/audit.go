@@ -0,0 +1,323 @@
+package web
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/constants"
+	"github.com/goformx/goforms/internal/application/middleware/access"
+	"github.com/goformx/goforms/internal/application/middleware/auth"
+	mwcontext "github.com/goformx/goforms/internal/application/middleware/context"
+	"github.com/goformx/goforms/internal/application/response"
+	"github.com/goformx/goforms/internal/domain/audit"
+	domainerrors "github.com/goformx/goforms/internal/domain/common/errors"
+	"github.com/goformx/goforms/internal/presentation/templates/pages"
+	"github.com/goformx/goforms/internal/presentation/templates/shared"
+	"github.com/goformx/goforms/internal/presentation/view"
+)
+
+// auditDateLayout is the date-only format accepted by the since and until filters
+const auditDateLayout = "2006-01-02"
+
+// auditCSVHeader is the header row of a CSV audit export
+var auditCSVHeader = []string{
+	"id", "created_at", "action", "actor_id", "actor_email", "ip", "user_agent",
+	"request_id", "target_type", "target_id", "owner_id", "before", "after",
+}
+
+// errInvalidAuditFilter is returned when a query parameter can't be parsed
+var errInvalidAuditFilter = errors.New("invalid audit filter")
+
+// AuditHandler serves the audit log viewer, its query API and exports. Admins
+// see every record; other users see the records about their own forms and account.
+type AuditHandler struct {
+	*BaseHandler
+	AccessManager  *access.Manager
+	AuthMiddleware *auth.Middleware
+}
+
+// NewAuditHandler creates a new AuditHandler
+func NewAuditHandler(
+	base *BaseHandler,
+	accessManager *access.Manager,
+	authMiddleware *auth.Middleware,
+) *AuditHandler {
+	return &AuditHandler{
+		BaseHandler:    base,
+		AccessManager:  accessManager,
+		AuthMiddleware: authMiddleware,
+	}
+}
+
+// RegisterRoutes registers the audit log page and API routes
+func (h *AuditHandler) RegisterRoutes(e *echo.Echo) {
+	page := e.Group(constants.PathAudit)
+	page.Use(h.AuthMiddleware.RequireAuth)
+	page.GET("", h.handleAuditLog)
+
+	api := e.Group(constants.PathAPIAudit)
+	api.Use(access.Middleware(h.AccessManager, h.Logger))
+	api.GET("", h.handleListAudit)
+	api.GET("/export", h.handleExportAudit)
+}
+
+// GET /audit
+func (h *AuditHandler) handleAuditLog(c echo.Context) error {
+	user, ok := h.AuthMiddleware.GetUserFromContext(c)
+	if !ok {
+		return fmt.Errorf("redirect to login: %w", c.Redirect(http.StatusSeeOther, constants.PathLogin))
+	}
+
+	filter, err := parseAuditFilter(c)
+	if err != nil {
+		return h.HandleError(c, err, "Invalid audit log filter")
+	}
+
+	page, err := h.AuditService.List(c.Request().Context(), auditViewer(c), filter)
+	if err != nil {
+		return h.handleAuditError(c, err)
+	}
+
+	data := view.NewPageData(h.Config, h.AssetManager, c, "Audit Log")
+	data.SetUser(user)
+
+	if renderErr := h.Renderer.Render(c, pages.AuditLog(*data, page, c.QueryParams())); renderErr != nil {
+		return fmt.Errorf("render audit log: %w", renderErr)
+	}
+
+	return nil
+}
+
+// GET /api/v1/audit
+func (h *AuditHandler) handleListAudit(c echo.Context) error {
+	filter, err := parseAuditFilter(c)
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	page, err := h.AuditService.List(c.Request().Context(), auditViewer(c), filter)
+	if err != nil {
+		return h.handleAuditError(c, err)
+	}
+
+	return response.Success(c, page)
+}
+
+// GET /api/v1/audit/export?format=csv|json
+//
+// Exports the records matching the same filters as the list endpoint, up to
+// audit.MaxExportRecords. The export itself is recorded in the audit log.
+func (h *AuditHandler) handleExportAudit(c echo.Context) error {
+	filter, err := parseAuditFilter(c)
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	format := c.QueryParam("format")
+	if format == "" {
+		format = "csv"
+	}
+
+	if format != "csv" && format != "json" {
+		return response.ErrorResponse(c, http.StatusBadRequest, "format must be csv or json")
+	}
+
+	viewer := auditViewer(c)
+
+	records, err := h.AuditService.Export(c.Request().Context(), viewer, filter)
+	if err != nil {
+		return h.handleAuditError(c, err)
+	}
+
+	h.RecordAudit(c, &audit.Record{
+		Action:     audit.ActionAuditExported,
+		TargetType: audit.TargetUser,
+		TargetID:   viewer.UserID,
+		OwnerID:    viewer.UserID,
+		After: map[string]any{
+			"format":  format,
+			"records": len(records),
+			"query":   c.QueryString(),
+		},
+	})
+
+	filename := "audit-" + time.Now().UTC().Format("20060102-150405") + "." + format
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename))
+
+	if format == "json" {
+		return c.JSON(http.StatusOK, records)
+	}
+
+	return writeAuditCSV(c, records)
+}
+
+// handleAuditError maps audit service errors to responses
+func (h *AuditHandler) handleAuditError(c echo.Context, err error) error {
+	if domainerrors.IsForbiddenError(err) {
+		return response.ErrorResponse(c, http.StatusForbidden, "Sign in to view the audit log")
+	}
+
+	h.Logger.Error("failed to read audit log", "error", err)
+
+	return h.HandleError(c, err, "Failed to read audit log")
+}
+
+// Start initializes the audit handler
+func (h *AuditHandler) Start(_ context.Context) error {
+	return nil // No initialization needed
+}
+
+// Stop cleans up any resources used by the audit handler
+func (h *AuditHandler) Stop(_ context.Context) error {
+	return nil // No cleanup needed
+}
+
+// auditViewer returns the signed-in user reading the audit log
+func auditViewer(c echo.Context) audit.Viewer {
+	userID, _ := mwcontext.GetUserID(c)
+
+	return audit.Viewer{
+		UserID: userID,
+		Admin:  mwcontext.IsAdmin(c),
+	}
+}
+
+// parseAuditFilter reads the audit log filters from the query string. Since and
+// until accept RFC 3339 timestamps or dates; a date for until includes the whole day.
+func parseAuditFilter(c echo.Context) (audit.Filter, error) {
+	filter := audit.Filter{
+		ActorID:    c.QueryParam("actor_id"),
+		Action:     c.QueryParam("action"),
+		TargetType: c.QueryParam("target_type"),
+		TargetID:   c.QueryParam("target_id"),
+	}
+
+	var err error
+
+	if filter.Since, err = parseAuditTime(c.QueryParam("since"), false); err != nil {
+		return filter, fmt.Errorf("%w: since: %w", errInvalidAuditFilter, err)
+	}
+
+	if filter.Until, err = parseAuditTime(c.QueryParam("until"), true); err != nil {
+		return filter, fmt.Errorf("%w: until: %w", errInvalidAuditFilter, err)
+	}
+
+	if filter.Offset, err = parseAuditInt(c.QueryParam("offset")); err != nil {
+		return filter, fmt.Errorf("%w: offset: %w", errInvalidAuditFilter, err)
+	}
+
+	if filter.Limit, err = parseAuditInt(c.QueryParam("limit")); err != nil {
+		return filter, fmt.Errorf("%w: limit: %w", errInvalidAuditFilter, err)
+	}
+
+	return filter, nil
+}
+
+// parseAuditTime parses a timestamp or date. endOfDay moves a date to the start
+// of the following day so that it can be used as an exclusive upper bound.
+func parseAuditTime(value string, endOfDay bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	t, err := time.Parse(auditDateLayout, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("expected RFC 3339 time or %s date", auditDateLayout)
+	}
+
+	if endOfDay {
+		t = t.AddDate(0, 0, 1)
+	}
+
+	return t, nil
+}
+
+// parseAuditInt parses an optional non-negative integer
+func parseAuditInt(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return 0, errors.New("expected a non-negative integer")
+	}
+
+	return n, nil
+}
+
+// writeAuditCSV writes records as CSV, one row per record
+func writeAuditCSV(c echo.Context, records []*audit.Record) error {
+	c.Response().Header().Set(echo.HeaderContentType, "text/csv; charset=utf-8")
+	c.Response().WriteHeader(http.StatusOK)
+
+	w := csv.NewWriter(c.Response())
+	if err := w.Write(auditCSVHeader); err != nil {
+		return fmt.Errorf("write audit csv header: %w", err)
+	}
+
+	for _, r := range records {
+		row := []string{
+			r.ID,
+			r.CreatedAt.UTC().Format(time.RFC3339),
+			r.Action,
+			r.ActorID,
+			r.ActorEmail,
+			r.IP,
+			r.UserAgent,
+			r.RequestID,
+			r.TargetType,
+			r.TargetID,
+			r.OwnerID,
+			auditStateCSV(r.Before),
+			auditStateCSV(r.After),
+		}
+		for i, cell := range row {
+			row[i] = csvSafe(cell)
+		}
+
+		if err := w.Write(row); err != nil {
+			return fmt.Errorf("write audit csv row: %w", err)
+		}
+	}
+
+	w.Flush()
+
+	if err := w.Error(); err != nil {
+		return fmt.Errorf("flush audit csv: %w", err)
+	}
+
+	return nil
+}
+
+// csvSafe keeps a CSV cell from being read as a formula by spreadsheet
+// applications. Cells such as the user agent come from clients, so a cell that
+// starts like a formula is prefixed with a quote.
+func csvSafe(cell string) string {
+	if cell != "" && strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+		return "'" + cell
+	}
+
+	return cell
+}
+
+// auditStateCSV formats a before or after summary for a CSV cell
+func auditStateCSV(state map[string]any) string {
+	if state == nil {
+		return ""
+	}
+
+	return shared.FormatFieldValue(state)
+}
@@ -0,0 +1,75 @@
+package web_test
+
+import (
+	"encoding/csv"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/goformx/goforms/internal/application/handlers/web"
+	"github.com/goformx/goforms/internal/application/middleware/access"
+	mwcontext "github.com/goformx/goforms/internal/application/middleware/context"
+	"github.com/goformx/goforms/internal/domain/audit"
+	mockaudit "github.com/goformx/goforms/test/mocks/audit"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+func TestAuditHandler_exportNeutralizesFormulas(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	auditService := mockaudit.NewMockService(ctrl)
+	handler := web.NewAuditHandler(
+		&web.BaseHandler{AuditService: auditService, Logger: mocklogging.NewMockLogger(ctrl)},
+		access.NewManager(&access.Config{DefaultAccess: access.Public}, nil),
+		nil,
+	)
+
+	e := echo.New()
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			mwcontext.SetUserID(c, "admin-1")
+
+			return next(c)
+		}
+	})
+	handler.RegisterRoutes(e)
+
+	auditService.EXPECT().Export(gomock.Any(), audit.Viewer{UserID: "admin-1"}, gomock.Any()).Return([]*audit.Record{{
+		ID:         "rec-1",
+		Action:     audit.ActionUserLoginFailed,
+		ActorEmail: "=HYPERLINK(\"https://evil.example\")",
+		UserAgent:  "@SUM(1+1)",
+		IP:         "203.0.113.7",
+		CreatedAt:  time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC),
+		After:      map[string]any{"note": "-2+3"},
+	}}, nil)
+	auditService.EXPECT().Record(gomock.Any(), gomock.Any()).Return(nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/audit/export?format=csv", http.NoBody)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	rows, err := csv.NewReader(strings.NewReader(rec.Body.String())).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+
+	row := rows[1]
+	assert.Equal(t, "rec-1", row[0])
+	assert.Equal(t, "'=HYPERLINK(\"https://evil.example\")", row[4])
+	assert.Equal(t, "203.0.113.7", row[5])
+	assert.Equal(t, "'@SUM(1+1)", row[6])
+
+	for _, cell := range row {
+		if cell != "" {
+			assert.NotContains(t, "=+-@\t\r", cell[:1], cell)
+		}
+	}
+}
@@ -12,9 +12,13 @@ import (
 	"github.com/goformx/goforms/internal/application/constants"
 	"github.com/goformx/goforms/internal/application/middleware/auth"
 	mwcontext "github.com/goformx/goforms/internal/application/middleware/context"
+	"github.com/goformx/goforms/internal/application/middleware/locale"
 	"github.com/goformx/goforms/internal/application/middleware/request"
 	"github.com/goformx/goforms/internal/application/response"
 	"github.com/goformx/goforms/internal/application/validation"
+	"github.com/goformx/goforms/internal/domain/audit"
+	"github.com/goformx/goforms/internal/domain/entities"
+	"github.com/goformx/goforms/internal/domain/user"
 	"github.com/goformx/goforms/internal/infrastructure/sanitization"
 	"github.com/goformx/goforms/internal/presentation/templates/pages"
 	"github.com/goformx/goforms/internal/presentation/view"
@@ -119,6 +123,15 @@ func (h *AuthHandler) SignupPost(c echo.Context) error {
 
 // Logout handles POST /logout - processes the logout request
 func (h *AuthHandler) Logout(c echo.Context) error {
+	if userID, ok := mwcontext.GetUserID(c); ok {
+		h.RecordAudit(c, &audit.Record{
+			Action:     audit.ActionUserLoggedOut,
+			TargetType: audit.TargetUser,
+			TargetID:   userID,
+			OwnerID:    userID,
+		})
+	}
+
 	if err := h.clearUserSession(c); err != nil {
 		h.Logger.Error("failed to clear session", "error", err)
 	}
@@ -128,14 +141,14 @@ func (h *AuthHandler) Logout(c echo.Context) error {
 
 // LoginValidation handles the login form validation schema request
 func (h *AuthHandler) LoginValidation(c echo.Context) error {
-	schema := h.SchemaGenerator.GenerateLoginSchema()
+	schema := h.SchemaGenerator.GenerateLoginSchema(locale.Get(c))
 
 	return response.Success(c, schema)
 }
 
 // SignupValidation returns the validation schema for the signup form
 func (h *AuthHandler) SignupValidation(c echo.Context) error {
-	schema := h.SchemaGenerator.GenerateSignupSchema()
+	schema := h.SchemaGenerator.GenerateSignupSchema(locale.Get(c))
 
 	return response.Success(c, schema)
 }
@@ -192,14 +205,29 @@ func (h *AuthHandler) processLogin(c echo.Context) error {
 
 	email = h.Sanitizer.Email(email)
 
-	_, sessionID, err := h.AuthService.Login(c.Request().Context(), email, password, c.Request().UserAgent())
+	userEntity, sessionID, err := h.AuthService.Login(c.Request().Context(), email, password, c.Request().UserAgent())
 	if err != nil {
 		h.Logger.Error("login failed", "error", err)
+		h.RecordAudit(c, &audit.Record{
+			Action:     audit.ActionUserLoginFailed,
+			ActorEmail: email,
+			TargetType: audit.TargetUser,
+		})
+
+		if errors.Is(err, user.ErrLockedOut) {
+			h.RecordAudit(c, &audit.Record{
+				Action:     audit.ActionUserLockedOut,
+				ActorEmail: email,
+				TargetType: audit.TargetUser,
+			})
+		}
 
 		return fmt.Errorf("login: %w", err)
 	}
 
 	h.SessionManager.SetSessionCookie(c, sessionID)
+	locale.Remember(c, userEntity.Locale)
+	h.recordUserAudit(c, audit.ActionUserLoggedIn, userEntity)
 
 	return nil
 }
@@ -214,8 +242,9 @@ func (h *AuthHandler) processSignup(c echo.Context) error {
 	}
 
 	signup.Email = h.Sanitizer.Email(signup.Email)
+	signup.Locale = locale.Get(c)
 
-	_, sessionID, err := h.AuthService.Signup(c.Request().Context(), signup, c.Request().UserAgent())
+	userEntity, sessionID, err := h.AuthService.Signup(c.Request().Context(), signup, c.Request().UserAgent())
 	if err != nil {
 		h.Logger.Error("signup failed", "error", err)
 
@@ -223,10 +252,27 @@ func (h *AuthHandler) processSignup(c echo.Context) error {
 	}
 
 	h.SessionManager.SetSessionCookie(c, sessionID)
+	h.recordUserAudit(c, audit.ActionUserSignedUp, userEntity)
 
 	return nil
 }
 
+// recordUserAudit records an action a user took on their own account
+func (h *AuthHandler) recordUserAudit(c echo.Context, action string, userEntity *entities.User) {
+	if userEntity == nil {
+		return
+	}
+
+	h.RecordAudit(c, &audit.Record{
+		Action:     action,
+		ActorID:    userEntity.ID,
+		ActorEmail: userEntity.Email,
+		TargetType: audit.TargetUser,
+		TargetID:   userEntity.ID,
+		OwnerID:    userEntity.ID,
+	})
+}
+
 // handleAuthError handles authentication errors with appropriate response format
 func (h *AuthHandler) handleAuthError(c echo.Context, err error, pageTitle string) error {
 	if h.isAJAXRequest(c) {
@@ -0,0 +1,61 @@
+package web_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/goformx/goforms/internal/application/constants"
+	"github.com/goformx/goforms/internal/application/handlers/web"
+	"github.com/goformx/goforms/internal/domain/audit"
+	"github.com/goformx/goforms/internal/domain/user"
+	"github.com/goformx/goforms/internal/infrastructure/sanitization"
+	mockaudit "github.com/goformx/goforms/test/mocks/audit"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+	mockuser "github.com/goformx/goforms/test/mocks/user"
+)
+
+func TestAuthHandler_loginAuditsLockout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	userService := mockuser.NewMockService(ctrl)
+	auditService := mockaudit.NewMockService(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	handler := &web.AuthHandler{
+		BaseHandler:     &web.BaseHandler{Logger: logger, AuditService: auditService},
+		RequestParser:   web.NewAuthRequestParser(),
+		ResponseBuilder: web.NewAuthResponseBuilder(nil),
+		AuthService:     web.NewAuthService(userService, nil),
+		Sanitizer:       sanitization.NewService(),
+	}
+
+	userService.EXPECT().Login(gomock.Any(), &user.Login{Email: "ada@example.com", Password: "wrong"}).
+		Return(nil, user.ErrLockedOut)
+	logger.EXPECT().Error("login failed", "error", gomock.Any())
+
+	var actions []string
+
+	auditService.EXPECT().Record(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, r *audit.Record) error {
+			assert.Equal(t, "ada@example.com", r.ActorEmail)
+			actions = append(actions, r.Action)
+
+			return nil
+		}).Times(2)
+
+	req := httptest.NewRequest(http.MethodPost, "/login",
+		strings.NewReader(`{"email":"ada@example.com","password":"wrong"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set(constants.HeaderXRequestedWith, web.XMLHttpRequestHeader)
+	rec := httptest.NewRecorder()
+
+	require.NoError(t, handler.LoginPost(echo.New().NewContext(req, rec)))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, []string{audit.ActionUserLoginFailed, audit.ActionUserLockedOut}, actions)
+}
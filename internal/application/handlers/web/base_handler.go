@@ -10,6 +10,7 @@ import (
 	mwcontext "github.com/goformx/goforms/internal/application/middleware/context"
 	"github.com/goformx/goforms/internal/application/middleware/session"
 	"github.com/goformx/goforms/internal/application/response"
+	"github.com/goformx/goforms/internal/domain/audit"
 	domainerrors "github.com/goformx/goforms/internal/domain/common/errors"
 	"github.com/goformx/goforms/internal/domain/entities"
 	"github.com/goformx/goforms/internal/domain/form"
@@ -30,6 +31,7 @@ type BaseHandler struct {
 	SessionManager *session.Manager
 	ErrorHandler   response.ErrorHandlerInterface
 	AssetManager   web.AssetManagerInterface // Use interface instead of concrete type
+	AuditService   audit.Service
 }
 
 // NewBaseHandler creates a new base handler with common dependencies
@@ -42,6 +44,7 @@ func NewBaseHandler(
 	sessionManager *session.Manager,
 	errorHandler response.ErrorHandlerInterface,
 	assetManager web.AssetManagerInterface, // Use interface
+	auditService audit.Service,
 ) *BaseHandler {
 	return &BaseHandler{
 		Logger:         logger,
@@ -52,6 +55,7 @@ func NewBaseHandler(
 		SessionManager: sessionManager,
 		ErrorHandler:   errorHandler,
 		AssetManager:   assetManager,
+		AuditService:   auditService,
 	}
 }
 
@@ -107,6 +111,18 @@ func (h *BaseHandler) HandleForbidden(c echo.Context, message string) error {
 	return nil
 }
 
+// RecordAudit appends a record to the audit log for the current request. A
+// failure to record is logged but does not fail the request.
+func (h *BaseHandler) RecordAudit(c echo.Context, record *audit.Record) {
+	if h.AuditService == nil {
+		return
+	}
+
+	if err := h.AuditService.Record(c.Request().Context(), record); err != nil {
+		h.Logger.Warn("failed to record audit event", "action", record.Action, "error", err)
+	}
+}
+
 // GetAssetBaseURL returns the base URL for assets (convenience method)
 func (h *BaseHandler) GetAssetBaseURL() string {
 	return h.AssetManager.GetBaseURL()
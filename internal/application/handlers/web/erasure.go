@@ -0,0 +1,138 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/constants"
+	"github.com/goformx/goforms/internal/application/middleware/access"
+	mwcontext "github.com/goformx/goforms/internal/application/middleware/context"
+	"github.com/goformx/goforms/internal/application/response"
+	domainerrors "github.com/goformx/goforms/internal/domain/common/errors"
+	"github.com/goformx/goforms/internal/domain/form/retention"
+)
+
+// ErasureRequest is the body of the erasure search and erase endpoints
+type ErasureRequest struct {
+	// Identifier is the submitter's email address or phone number
+	Identifier string `json:"identifier"`
+	// SubmissionIDs limits an erase to the listed submissions. When empty every
+	// matching submission is erased.
+	SubmissionIDs []string `json:"submission_ids,omitempty"`
+}
+
+// ErasureResponse lists the submissions found or erased for an identifier
+type ErasureResponse struct {
+	Matches []*retention.Match `json:"matches"`
+	Count   int                `json:"count"`
+}
+
+// ErasureHandler serves the "find and erase by submitter" tool. It searches the
+// signed-in user's forms, including deleted ones, for submissions that contain
+// an email address or phone number, and permanently deletes them on request.
+type ErasureHandler struct {
+	*BaseHandler
+	RetentionService retention.Service
+	AccessManager    *access.Manager
+}
+
+// NewErasureHandler creates a new ErasureHandler
+func NewErasureHandler(
+	base *BaseHandler,
+	retentionService retention.Service,
+	accessManager *access.Manager,
+) *ErasureHandler {
+	return &ErasureHandler{
+		BaseHandler:      base,
+		RetentionService: retentionService,
+		AccessManager:    accessManager,
+	}
+}
+
+// RegisterRoutes registers the erasure API routes
+func (h *ErasureHandler) RegisterRoutes(e *echo.Echo) {
+	api := e.Group(constants.PathAPIErasure)
+	api.Use(access.Middleware(h.AccessManager, h.Logger))
+	api.POST("/search", h.handleSearch)
+	api.POST("/erase", h.handleErase)
+}
+
+// POST /api/v1/erasure/search
+//
+// Previews the submissions an erase would delete.
+func (h *ErasureHandler) handleSearch(c echo.Context) error {
+	userID, ok := mwcontext.GetUserID(c)
+	if !ok {
+		return response.ErrorResponse(c, http.StatusUnauthorized, "Sign in to erase submissions")
+	}
+
+	var req ErasureRequest
+	if err := json.NewDecoder(c.Request().Body).Decode(&req); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "Invalid erasure request")
+	}
+
+	matches, err := h.RetentionService.FindSubmitter(c.Request().Context(), userID, req.Identifier)
+	if err != nil {
+		return h.handleErasureError(c, err)
+	}
+
+	return response.Success(c, newErasureResponse(matches))
+}
+
+// POST /api/v1/erasure/erase
+//
+// Permanently deletes the matching submissions. Each deletion is recorded in
+// the audit log with a hash of the identifier rather than the identifier itself.
+func (h *ErasureHandler) handleErase(c echo.Context) error {
+	userID, ok := mwcontext.GetUserID(c)
+	if !ok {
+		return response.ErrorResponse(c, http.StatusUnauthorized, "Sign in to erase submissions")
+	}
+
+	var req ErasureRequest
+	if err := json.NewDecoder(c.Request().Body).Decode(&req); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, "Invalid erasure request")
+	}
+
+	matches, err := h.RetentionService.EraseSubmitter(c.Request().Context(), userID, req.Identifier, req.SubmissionIDs)
+	if err != nil {
+		return h.handleErasureError(c, err)
+	}
+
+	h.Logger.Info("submitter erased", "user_id", userID, "submissions", len(matches))
+
+	return response.Success(c, newErasureResponse(matches))
+}
+
+// handleErasureError maps retention service errors to responses
+func (h *ErasureHandler) handleErasureError(c echo.Context, err error) error {
+	if domainerrors.IsValidation(err) {
+		return response.ErrorResponse(c, http.StatusBadRequest, "Enter a valid email address or phone number")
+	}
+
+	h.Logger.Error("erasure request failed", "error", err)
+
+	return h.HandleError(c, err, "Failed to process erasure request")
+}
+
+// newErasureResponse builds the response for a list of matches
+func newErasureResponse(matches []*retention.Match) ErasureResponse {
+	if matches == nil {
+		matches = []*retention.Match{}
+	}
+
+	return ErasureResponse{Matches: matches, Count: len(matches)}
+}
+
+// Start initializes the erasure handler
+func (h *ErasureHandler) Start(_ context.Context) error {
+	return nil // No initialization needed
+}
+
+// Stop cleans up any resources used by the erasure handler
+func (h *ErasureHandler) Stop(_ context.Context) error {
+	return nil // No cleanup needed
+}
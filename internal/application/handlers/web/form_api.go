@@ -10,27 +10,42 @@ import (
 
 	"github.com/goformx/goforms/internal/application/constants"
 	"github.com/goformx/goforms/internal/application/middleware/access"
+	"github.com/goformx/goforms/internal/application/middleware/locale"
 	"github.com/goformx/goforms/internal/application/response"
 	"github.com/goformx/goforms/internal/application/validation"
+	"github.com/goformx/goforms/internal/domain/audit"
 	formdomain "github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/form/calculation"
+	"github.com/goformx/goforms/internal/domain/form/draft"
 	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/domain/form/revision"
+	"github.com/goformx/goforms/internal/domain/form/template"
 	"github.com/goformx/goforms/internal/infrastructure/sanitization"
+	"github.com/goformx/goforms/internal/presentation/view"
 )
 
 // FormAPIHandler handles API form operations
 type FormAPIHandler struct {
 	*FormBaseHandler
+	Forms                  *FormService
+	TemplateService        template.Service
+	DraftService           draft.Service
+	RevisionService        revision.Service
 	AccessManager          *access.Manager
 	RequestProcessor       FormRequestProcessor
 	ResponseBuilder        FormResponseBuilder
 	ErrorHandler           FormErrorHandler
 	ComprehensiveValidator *validation.ComprehensiveValidator
+	JSONSchemas            *validation.JSONSchemaConverter
 }
 
 // NewFormAPIHandler creates a new FormAPIHandler.
 func NewFormAPIHandler(
 	base *BaseHandler,
 	formService formdomain.Service,
+	templateService template.Service,
+	draftService draft.Service,
+	revisionService revision.Service,
 	accessManager *access.Manager,
 	formValidator *validation.FormValidator,
 	sanitizer sanitization.ServiceInterface,
@@ -43,11 +58,16 @@ func NewFormAPIHandler(
 
 	return &FormAPIHandler{
 		FormBaseHandler:        NewFormBaseHandler(base, formService, formValidator),
+		Forms:                  NewFormService(formService, templateService, base.Logger),
+		TemplateService:        templateService,
+		DraftService:           draftService,
+		RevisionService:        revisionService,
 		AccessManager:          accessManager,
 		RequestProcessor:       requestProcessor,
 		ResponseBuilder:        responseBuilder,
 		ErrorHandler:           errorHandler,
 		ComprehensiveValidator: comprehensiveValidator,
+		JSONSchemas:            validation.NewJSONSchemaConverter(),
 	}
 }
 
@@ -61,6 +81,9 @@ func (h *FormAPIHandler) RegisterRoutes(e *echo.Echo) {
 
 	// Register public routes
 	h.RegisterPublicRoutes(formsAPI)
+
+	// Register template routes
+	h.RegisterTemplateRoutes(api.Group("/templates"))
 }
 
 // RegisterAuthenticatedRoutes registers routes that require authentication
@@ -70,9 +93,37 @@ func (h *FormAPIHandler) RegisterAuthenticatedRoutes(formsAPI *echo.Group) {
 
 	// Authenticated routes
 	formsAPI.GET("", h.handleListForms)
+	formsAPI.POST("", h.handleCreateForm)
 	formsAPI.GET("/:id", h.handleGetForm)
+	formsAPI.PUT("/:id", h.handleUpdateForm)
+	formsAPI.DELETE("/:id", h.handleDeleteForm)
+	formsAPI.PATCH("/:id/status", h.handleUpdateFormStatus)
 	formsAPI.GET("/:id/schema", h.handleFormSchema)
 	formsAPI.PUT("/:id/schema", h.handleFormSchemaUpdate)
+	formsAPI.GET("/:id/translations", h.handleGetTranslations)
+	formsAPI.PUT("/:id/translations", h.handleUpdateTranslations)
+	formsAPI.GET("/:id/export", h.handleExportForm)
+	formsAPI.GET("/:id/embed", h.handleEmbedCode)
+	formsAPI.POST("/:id/duplicate", h.handleDuplicateForm)
+	formsAPI.POST("/import", h.handleImportForm)
+	formsAPI.POST("/import/json-schema", h.handleImportJSONSchema)
+	formsAPI.GET("/:id/json-schema", h.handleJSONSchema)
+	formsAPI.GET("/:id/json-schema/versions", h.handleJSONSchemaVersions)
+	formsAPI.POST("/:id/template", h.handleSaveAsTemplate)
+	formsAPI.GET("/:id/submissions", h.handleListSubmissions)
+	formsAPI.GET("/:id/submissions/:sid", h.handleGetSubmission)
+	formsAPI.PUT("/:id/submissions/:sid", h.handleUpdateSubmission)
+	formsAPI.DELETE("/:id/submissions/:sid", h.handleDeleteSubmission)
+	formsAPI.GET("/:id/submissions/:sid/revisions", h.handleListRevisions)
+	formsAPI.POST("/:id/submissions/:sid/revisions/:number/restore", h.handleRestoreRevision)
+}
+
+// RegisterTemplateRoutes registers routes for listing and deleting form templates
+func (h *FormAPIHandler) RegisterTemplateRoutes(templatesAPI *echo.Group) {
+	templatesAPI.Use(access.Middleware(h.AccessManager, h.Logger))
+
+	templatesAPI.GET("", h.handleListTemplates)
+	templatesAPI.DELETE("/:id", h.handleDeleteTemplate)
 }
 
 // RegisterPublicRoutes registers routes that don't require authentication
@@ -82,6 +133,12 @@ func (h *FormAPIHandler) RegisterPublicRoutes(formsAPI *echo.Group) {
 	formsAPI.GET("/:id/schema", h.handleFormSchema)
 	formsAPI.GET("/:id/validation", h.handleFormValidationSchema)
 	formsAPI.POST("/:id/submit", h.handleFormSubmit)
+
+	// Save-and-resume drafts, identified by their resume token
+	formsAPI.PATCH("/:id/draft", h.handleSaveDraft)
+	formsAPI.PATCH("/:id/draft/:token", h.handleSaveDraft)
+	formsAPI.GET("/:id/draft/:token", h.handleGetDraft)
+	formsAPI.POST("/:id/draft/:token/submit", h.handleSubmitDraft)
 }
 
 // Register registers the FormAPIHandler with the Echo instance.
@@ -136,15 +193,23 @@ func (h *FormAPIHandler) handleGetForm(c echo.Context) error {
 	return nil
 }
 
-// GET /api/v1/forms/:id/schema
+// GET /api/v1/forms/:id/schema?lang=
+//
+// The schema is returned as saved, translations included, for the form
+// builder. Embeds ask for a language with lang and get the schema translated.
 func (h *FormAPIHandler) handleFormSchema(c echo.Context) error {
 	form, err := h.getFormOrError(c)
 	if err != nil {
 		return err
 	}
 
+	schema := form.Schema
+	if c.QueryParam(locale.QueryParam) != "" {
+		schema = form.Localized(locale.Get(c)).Schema
+	}
+
 	// Build response with proper error checking
-	if respErr := h.ResponseBuilder.BuildSchemaResponse(c, form.Schema); respErr != nil {
+	if respErr := h.ResponseBuilder.BuildSchemaResponse(c, schema); respErr != nil {
 		h.Logger.Error("failed to build schema response", "error", respErr, "form_id", form.ID)
 
 		return h.HandleError(c, respErr, "Failed to build response")
@@ -193,6 +258,13 @@ func (h *FormAPIHandler) handleFormSchemaUpdate(c echo.Context) error {
 		return h.wrapError("handle schema error", h.ErrorHandler.HandleSchemaError(c, err))
 	}
 
+	// The builder does not edit translations, so keep them unless replaced
+	if _, hasTranslations := schema[model.TranslationsProperty]; !hasTranslations {
+		if translations, saved := form.Schema[model.TranslationsProperty]; saved {
+			schema[model.TranslationsProperty] = translations
+		}
+	}
+
 	// Update form schema
 	if updateErr := h.updateFormSchema(c, form, schema); updateErr != nil {
 		return updateErr
@@ -208,12 +280,177 @@ func (h *FormAPIHandler) handleFormSchemaUpdate(c echo.Context) error {
 	return nil
 }
 
+// GET /api/v1/forms/:id/export
+func (h *FormAPIHandler) handleExportForm(c echo.Context) error {
+	form, err := h.getFormWithOwnershipOrError(c)
+	if err != nil {
+		return err
+	}
+
+	if respErr := h.ResponseBuilder.BuildBundleResponse(c, model.NewBundle(form)); respErr != nil {
+		h.Logger.Error("failed to build bundle response", "error", respErr, "form_id", form.ID)
+
+		return h.HandleError(c, respErr, "Failed to build response")
+	}
+
+	h.RecordAudit(c, &audit.Record{
+		Action:     audit.ActionFormExported,
+		TargetType: audit.TargetForm,
+		TargetID:   form.ID,
+		OwnerID:    form.UserID,
+	})
+
+	return nil
+}
+
+// POST /api/v1/forms/import?on_conflict=new_id|fail
+func (h *FormAPIHandler) handleImportForm(c echo.Context) error {
+	userID, ok := c.Get("user_id").(string)
+	if !ok {
+		return h.HandleForbidden(c, "User not authenticated")
+	}
+
+	bundle, err := h.RequestProcessor.ProcessImportRequest(c)
+	if err != nil {
+		h.Logger.Warn("rejected form bundle", "error", err)
+
+		return h.wrapError("handle import error", h.ErrorHandler.HandleImportError(c, err))
+	}
+
+	form, err := h.FormService.ImportBundle(c.Request().Context(), userID, bundle, c.QueryParam("on_conflict"))
+	if err != nil {
+		h.Logger.Error("failed to import form bundle", "error", err)
+
+		return h.wrapError("handle import error", h.ErrorHandler.HandleImportError(c, err))
+	}
+
+	h.Logger.Info("form imported", "form_id", form.ID, "bundle_form_id", bundle.Form.ID)
+
+	if respErr := h.ResponseBuilder.BuildFormCreatedResponse(c, "Form imported successfully", form); respErr != nil {
+		h.Logger.Error("failed to build form created response", "error", respErr, "form_id", form.ID)
+
+		return h.HandleError(c, respErr, "Failed to build response")
+	}
+
+	return nil
+}
+
+// POST /api/v1/forms/:id/duplicate
+func (h *FormAPIHandler) handleDuplicateForm(c echo.Context) error {
+	source, err := h.getFormWithOwnershipOrError(c)
+	if err != nil {
+		return err
+	}
+
+	form, err := h.FormService.DuplicateForm(c.Request().Context(), source.ID)
+	if err != nil {
+		h.Logger.Error("failed to duplicate form", "error", err, "form_id", source.ID)
+
+		return h.wrapError("handle import error", h.ErrorHandler.HandleImportError(c, err))
+	}
+
+	h.Logger.Info("form duplicated", "form_id", form.ID, "source_form_id", source.ID)
+
+	if respErr := h.ResponseBuilder.BuildFormCreatedResponse(c, "Form duplicated successfully", form); respErr != nil {
+		h.Logger.Error("failed to build form created response", "error", respErr, "form_id", form.ID)
+
+		return h.HandleError(c, respErr, "Failed to build response")
+	}
+
+	return nil
+}
+
+// GET /api/v1/forms/:id/embed
+func (h *FormAPIHandler) handleEmbedCode(c echo.Context) error {
+	form, err := h.getFormWithOwnershipOrError(c)
+	if err != nil {
+		return err
+	}
+
+	code := view.NewEmbedCode(publicBaseURL(c, h.Config.App.URL), form.ID)
+
+	if respErr := h.ResponseBuilder.BuildEmbedCodeResponse(c, form, code); respErr != nil {
+		h.Logger.Error("failed to build embed code response", "error", respErr, "form_id", form.ID)
+
+		return h.HandleError(c, respErr, "Failed to build response")
+	}
+
+	return nil
+}
+
+// POST /api/v1/forms/:id/template
+func (h *FormAPIHandler) handleSaveAsTemplate(c echo.Context) error {
+	form, err := h.getFormWithOwnershipOrError(c)
+	if err != nil {
+		return err
+	}
+
+	req, err := h.RequestProcessor.ProcessSaveTemplateRequest(c)
+	if err != nil {
+		return h.wrapError("handle error", h.ErrorHandler.HandleError(c, err))
+	}
+
+	t, err := h.TemplateService.SaveFormAsTemplate(c.Request().Context(), form.UserID, form, req.Name, req.Description)
+	if err != nil {
+		h.Logger.Error("failed to save form as template", "error", err, "form_id", form.ID)
+
+		return h.wrapError("handle template error", h.ErrorHandler.HandleTemplateError(c, err))
+	}
+
+	if respErr := h.ResponseBuilder.BuildTemplateResponse(c, t); respErr != nil {
+		h.Logger.Error("failed to build template response", "error", respErr, "template_id", t.ID)
+
+		return h.HandleError(c, respErr, "Failed to build response")
+	}
+
+	return nil
+}
+
+// GET /api/v1/templates
+func (h *FormAPIHandler) handleListTemplates(c echo.Context) error {
+	userID, ok := c.Get("user_id").(string)
+	if !ok {
+		return h.HandleForbidden(c, "User not authenticated")
+	}
+
+	templates, err := h.TemplateService.ListTemplates(c.Request().Context(), userID)
+	if err != nil {
+		h.Logger.Error("failed to list templates", "error", err)
+
+		return h.HandleError(c, err, "Failed to list templates")
+	}
+
+	if respErr := h.ResponseBuilder.BuildTemplateListResponse(c, templates); respErr != nil {
+		h.Logger.Error("failed to build template list response", "error", respErr)
+
+		return h.HandleError(c, respErr, "Failed to build response")
+	}
+
+	return nil
+}
+
+// DELETE /api/v1/templates/:id
+func (h *FormAPIHandler) handleDeleteTemplate(c echo.Context) error {
+	userID, ok := c.Get("user_id").(string)
+	if !ok {
+		return h.HandleForbidden(c, "User not authenticated")
+	}
+
+	if err := h.TemplateService.DeleteTemplate(c.Request().Context(), userID, c.Param("id")); err != nil {
+		h.Logger.Warn("failed to delete template", "error", err)
+
+		return h.wrapError("handle template error", h.ErrorHandler.HandleTemplateError(c, err))
+	}
+
+	return fmt.Errorf("no content response: %w", c.NoContent(constants.StatusNoContent))
+}
+
 // POST /api/v1/forms/:id/submit
 func (h *FormAPIHandler) handleFormSubmit(c echo.Context) error {
 	formID := c.Param("id")
 	h.logFormSubmissionRequest(c, formID)
 
-	form, err := h.getFormOrError(c)
+	form, err := h.getPublishedFormOrError(c)
 	if err != nil {
 		return err
 	}
@@ -348,9 +585,15 @@ func (h *FormAPIHandler) processSubmissionRequest(c echo.Context, formID string)
 	return submissionData, nil
 }
 
-// validateSubmissionData validates submission data against form schema
+// validateSubmissionData validates submission data against form schema, with
+// messages in the request's language. Calculated fields are validated with the
+// values the server computes for them, which are the ones that get stored.
 func (h *FormAPIHandler) validateSubmissionData(c echo.Context, form *model.Form, submissionData model.JSON) error {
-	validationResult := h.ComprehensiveValidator.ValidateForm(form.Schema, submissionData)
+	calculated := calculation.Recalculate(form, submissionData, time.Now()).Data
+
+	selected := locale.Get(c)
+	validationResult := h.ComprehensiveValidator.ForLocale(selected).
+		ValidateForm(form.Localized(selected).Schema, calculated)
 	if !validationResult.IsValid {
 		h.Logger.Warn("Form validation failed", "form_id", form.ID, "error_count", len(validationResult.Errors))
 
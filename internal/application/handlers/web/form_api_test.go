@@ -0,0 +1,83 @@
+package web_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/goformx/goforms/internal/application/handlers/web"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	mockform "github.com/goformx/goforms/test/mocks/form"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+func TestFormAPIHandler_submitRejectsUnpublishedForm(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	formService := mockform.NewMockService(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	handler := web.NewFormAPIHandler(
+		&web.BaseHandler{FormService: formService, Logger: logger},
+		formService, nil, nil, nil, nil, nil, nil,
+	)
+
+	e := echo.New()
+	handler.RegisterPublicRoutes(e.Group("/api/v1/forms"))
+
+	logger.EXPECT().Debug("Form submission request received", gomock.Any())
+	formService.EXPECT().GetForm(gomock.Any(), "form-1").Return(&model.Form{
+		ID:     "form-1",
+		Active: true,
+		Status: "draft",
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/forms/form-1/submit", strings.NewReader(`{"name":"Ada"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestFormAPIHandler_submitValidatesCalculatedValues(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	formService := mockform.NewMockService(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	handler := web.NewFormAPIHandler(
+		&web.BaseHandler{FormService: formService, Logger: logger},
+		formService, nil, nil, nil, nil, nil, nil,
+	)
+
+	e := echo.New()
+	handler.RegisterPublicRoutes(e.Group("/api/v1/forms"))
+
+	logger.EXPECT().Debug(gomock.Any(), gomock.Any()).AnyTimes()
+	logger.EXPECT().Warn("Form validation failed", "form_id", "form-1", "error_count", 1)
+	formService.EXPECT().GetForm(gomock.Any(), "form-1").Return(&model.Form{
+		ID:     "form-1",
+		Active: true,
+		Status: "published",
+		Schema: model.JSON{"components": []any{
+			map[string]any{"type": "number", "key": "quantity", "input": true},
+			map[string]any{
+				"type": "number", "key": "total", "input": true,
+				"calculateValue": "value = data.quantity * 2.5",
+				"validate":       map[string]any{"max": 100},
+			},
+		}},
+	}, nil)
+
+	// The submitted total is within bounds, but the one the server computes is not
+	body := strings.NewReader(`{"quantity": 50, "total": 1}`)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/forms/form-1/submit", body)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"field":"total"`)
+}
@@ -0,0 +1,206 @@
+package web
+
+import (
+	"time"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/domain/form/revision"
+	"github.com/goformx/goforms/internal/domain/form/template"
+)
+
+// The types below are the data of the forms API responses. The response
+// builder sends them and the OpenAPI document is generated from them, so the
+// two cannot disagree.
+
+// FormSummary describes a form without its schema
+type FormSummary struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Status      string `json:"status" enum:"draft,published,archived"`
+	CreatedAt   string `json:"created_at" format:"date-time"`
+	UpdatedAt   string `json:"updated_at" format:"date-time"`
+}
+
+// FormDetail describes a form with its schema
+type FormDetail struct {
+	FormSummary
+	Schema model.JSON `json:"schema"`
+}
+
+// FormResponse is the data of a single form response
+type FormResponse struct {
+	Form FormDetail `json:"form"`
+}
+
+// FormListResponse is the data of a form list response
+type FormListResponse struct {
+	Forms []FormSummary `json:"forms"`
+	Count int           `json:"count"`
+}
+
+// FormCreatedResponse is the data of a response for a created, imported or
+// duplicated form
+type FormCreatedResponse struct {
+	FormID string `json:"form_id"`
+	Title  string `json:"title"`
+	Status string `json:"status" enum:"draft,published,archived"`
+}
+
+// EmbedCodeResponse holds a form's public link and embed snippets
+type EmbedCodeResponse struct {
+	FormID    string `json:"form_id"`
+	Published bool   `json:"published"`
+	URL       string `json:"url"`
+	Script    string `json:"script"`
+	IFrame    string `json:"iframe"`
+}
+
+// DraftResponse describes a saved or resumed draft
+type DraftResponse struct {
+	FormID      string     `json:"form_id"`
+	ResumeToken string     `json:"resume_token"`
+	ResumeURL   string     `json:"resume_url"`
+	ExpiresAt   string     `json:"expires_at" format:"date-time"`
+	Data        model.JSON `json:"data"`
+}
+
+// TemplateSummary describes a template without its schema
+type TemplateSummary struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Category    string   `json:"category"`
+	Preview     []string `json:"preview"`
+	BuiltIn     bool     `json:"built_in"`
+}
+
+// TemplateResponse is the data of a saved template response
+type TemplateResponse struct {
+	Template TemplateSummary `json:"template"`
+}
+
+// TemplateListResponse is the data of a template list response
+type TemplateListResponse struct {
+	Templates []TemplateSummary `json:"templates"`
+	Count     int               `json:"count"`
+}
+
+// SubmissionSummary describes a submission. Every field may be left out when
+// the client selected fields.
+type SubmissionSummary struct {
+	ID          string                 `json:"id,omitempty"`
+	FormID      string                 `json:"form_id,omitempty"`
+	Status      model.SubmissionStatus `json:"status,omitempty"`
+	SubmittedAt string                 `json:"submitted_at,omitempty" format:"date-time"`
+	Data        model.JSON             `json:"data,omitempty"`
+}
+
+// SubmissionListResponse is the data of a submission list response
+type SubmissionListResponse struct {
+	Submissions []SubmissionSummary `json:"submissions"`
+	Count       int                 `json:"count"`
+}
+
+// SubmissionPageResponse is the data of a page of submissions
+type SubmissionPageResponse struct {
+	Submissions []SubmissionSummary `json:"submissions"`
+	// Sort echoes the applied sort, such as "-submitted_at"
+	Sort       string     `json:"sort"`
+	Pagination Pagination `json:"pagination"`
+}
+
+// Pagination describes where a page sits in a list
+type Pagination struct {
+	Page       int `json:"page"`
+	PageSize   int `json:"page_size"`
+	TotalItems int `json:"total_items"`
+	TotalPages int `json:"total_pages"`
+}
+
+// SubmissionCreatedResponse is the data of a response for a new submission
+type SubmissionCreatedResponse struct {
+	SubmissionID string                 `json:"submission_id"`
+	Status       model.SubmissionStatus `json:"status"`
+	SubmittedAt  string                 `json:"submitted_at" format:"date-time"`
+}
+
+// RevisionListResponse is the data of a submission history response
+type RevisionListResponse struct {
+	Revisions []*revision.Revision `json:"revisions"`
+	Count     int                  `json:"count"`
+}
+
+// FieldError describes why one field failed validation
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+	Rule    string `json:"rule,omitempty"`
+}
+
+// ValidationErrorsResponse is the data of a response listing validation errors
+type ValidationErrorsResponse struct {
+	Errors []FieldError `json:"errors"`
+}
+
+// newFormSummary returns the summary of a form
+func newFormSummary(form *model.Form) FormSummary {
+	return FormSummary{
+		ID:          form.ID,
+		Title:       form.Title,
+		Description: form.Description,
+		Status:      form.Status,
+		CreatedAt:   form.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   form.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// newTemplateSummary returns the summary of a template
+func newTemplateSummary(t *template.Template) TemplateSummary {
+	return TemplateSummary{
+		ID:          t.ID,
+		Name:        t.Name,
+		Description: t.Description,
+		Category:    t.Category,
+		Preview:     t.Preview,
+		BuiltIn:     t.BuiltIn,
+	}
+}
+
+// newSubmissionSummary returns the summary of a submission
+func newSubmissionSummary(submission *model.FormSubmission) SubmissionSummary {
+	return SubmissionSummary{
+		ID:          submission.ID,
+		FormID:      submission.FormID,
+		Status:      submission.Status,
+		SubmittedAt: submission.SubmittedAt.Format(time.RFC3339),
+		Data:        submission.Data,
+	}
+}
+
+// selectFields returns the summary with only the given fields set, or all of
+// them when no fields are given
+func (s SubmissionSummary) selectFields(fields []string) SubmissionSummary {
+	if len(fields) == 0 {
+		return s
+	}
+
+	var selected SubmissionSummary
+
+	for _, field := range fields {
+		switch field {
+		case "id":
+			selected.ID = s.ID
+		case "form_id":
+			selected.FormID = s.FormID
+		case "status":
+			selected.Status = s.Status
+		case "submitted_at":
+			selected.SubmittedAt = s.SubmittedAt
+		case "data":
+			selected.Data = s.Data
+		}
+	}
+
+	return selected
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/goformx/goforms/internal/application/constants"
 	"github.com/goformx/goforms/internal/application/validation"
+	"github.com/goformx/goforms/internal/domain/audit"
 	formdomain "github.com/goformx/goforms/internal/domain/form"
 	"github.com/goformx/goforms/internal/domain/form/model"
 )
@@ -94,3 +95,14 @@ func (h *FormBaseHandler) GetFormWithOwnership(c echo.Context) (*model.Form, err
 
 	return form, nil
 }
+
+// RecordSubmissionViewed records that the form's owner opened one of its submissions
+func (h *FormBaseHandler) RecordSubmissionViewed(c echo.Context, form *model.Form, submissionID string) {
+	h.RecordAudit(c, &audit.Record{
+		Action:     audit.ActionSubmissionViewed,
+		TargetType: audit.TargetSubmission,
+		TargetID:   submissionID,
+		OwnerID:    form.UserID,
+		After:      model.JSON{"form_id": form.ID},
+	})
+}
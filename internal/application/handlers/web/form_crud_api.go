@@ -0,0 +1,204 @@
+package web
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/constants"
+	"github.com/goformx/goforms/internal/application/response"
+	domainerrors "github.com/goformx/goforms/internal/domain/common/errors"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+)
+
+// POST /api/v1/forms
+//
+// The body holds the title and optionally a description and either a
+// template_id or a schema.
+func (h *FormAPIHandler) handleCreateForm(c echo.Context) error {
+	userID, ok := c.Get("user_id").(string)
+	if !ok {
+		return h.respondError(c, domainerrors.New(domainerrors.ErrCodeUnauthorized, "Authentication required", nil))
+	}
+
+	req, err := h.RequestProcessor.ProcessJSONCreateRequest(c)
+	if err != nil {
+		return h.respondError(c, invalidRequestError(err))
+	}
+
+	form, err := h.Forms.CreateForm(c.Request().Context(), userID, req)
+	if err != nil {
+		return h.respondError(c, err)
+	}
+
+	h.Logger.Info("form created", "form_id", form.ID)
+
+	if respErr := h.ResponseBuilder.BuildFormCreatedResponse(c, "Form created successfully", form); respErr != nil {
+		return h.HandleError(c, respErr, "Failed to build response")
+	}
+
+	return nil
+}
+
+// PUT /api/v1/forms/:id
+//
+// Updates the form's title, description and settings. An empty status keeps
+// the form's current one.
+func (h *FormAPIHandler) handleUpdateForm(c echo.Context) error {
+	form, err := h.getOwnedForm(c)
+	if err != nil {
+		return err
+	}
+
+	req, err := h.RequestProcessor.ProcessJSONUpdateRequest(c)
+	if err != nil {
+		return h.respondError(c, invalidRequestError(err))
+	}
+
+	if req.Status == "" {
+		req.Status = form.Status
+	}
+
+	if updateErr := h.Forms.UpdateForm(c.Request().Context(), form, req); updateErr != nil {
+		return h.respondError(c, updateErr)
+	}
+
+	if respErr := h.ResponseBuilder.BuildFormResponse(c, form); respErr != nil {
+		return h.HandleError(c, respErr, "Failed to build response")
+	}
+
+	return nil
+}
+
+// PATCH /api/v1/forms/:id/status
+//
+// The form is saved as a whole rather than through UpdateFormState so the
+// change is validated and recorded in the audit log like any other update.
+func (h *FormAPIHandler) handleUpdateFormStatus(c echo.Context) error {
+	form, err := h.getOwnedForm(c)
+	if err != nil {
+		return err
+	}
+
+	status, err := h.RequestProcessor.ProcessStatusRequest(c)
+	if err != nil {
+		return h.respondError(c, invalidRequestError(err))
+	}
+
+	if origins, _, _ := form.GetCorsConfig(); status == "published" && len(origins) == 0 {
+		return h.respondError(c, domainerrors.New(
+			domainerrors.ErrCodeValidation, "CORS origins are required when publishing a form", nil))
+	}
+
+	form.Status = status
+	if updateErr := h.FormService.UpdateForm(c.Request().Context(), form); updateErr != nil {
+		return h.respondError(c, updateErr)
+	}
+
+	h.Logger.Info("form status changed", "form_id", form.ID, "status", status)
+
+	if respErr := h.ResponseBuilder.BuildFormResponse(c, form); respErr != nil {
+		return h.HandleError(c, respErr, "Failed to build response")
+	}
+
+	return nil
+}
+
+// DELETE /api/v1/forms/:id
+func (h *FormAPIHandler) handleDeleteForm(c echo.Context) error {
+	form, err := h.getOwnedForm(c)
+	if err != nil {
+		return err
+	}
+
+	if deleteErr := h.Forms.DeleteForm(c.Request().Context(), form.ID); deleteErr != nil {
+		return h.respondError(c, deleteErr)
+	}
+
+	h.Logger.Info("form deleted", "form_id", form.ID)
+
+	return fmt.Errorf("no content response: %w", c.NoContent(constants.StatusNoContent))
+}
+
+// getOwnedForm loads the form named in the path and checks that the current
+// user owns it, answering with a typed error body when either fails
+func (h *FormAPIHandler) getOwnedForm(c echo.Context) (*model.Form, error) {
+	userID, ok := c.Get("user_id").(string)
+	if !ok {
+		return nil, h.respondError(c, domainerrors.New(domainerrors.ErrCodeUnauthorized, "Authentication required", nil))
+	}
+
+	form, err := h.FormService.GetForm(c.Request().Context(), c.Param("id"))
+	if err != nil && !isMissingForm(err) {
+		return nil, h.respondError(c, err)
+	}
+
+	if form == nil {
+		return nil, h.respondError(c, domainerrors.New(domainerrors.ErrCodeFormNotFound, "Form not found", err))
+	}
+
+	if form.UserID != userID {
+		h.Logger.Warn("form API access denied", "form_id", form.ID)
+
+		return nil, h.respondError(c, domainerrors.New(
+			domainerrors.ErrCodeForbidden, "You don't have permission to access this resource", nil))
+	}
+
+	return form, nil
+}
+
+// isMissingForm reports whether err means the requested form does not exist.
+// Malformed form IDs cannot name a form, so they are reported the same way.
+func isMissingForm(err error) bool {
+	return errors.Is(err, common.ErrNotFound) ||
+		errors.Is(err, common.ErrInvalidInput) ||
+		errors.Is(err, model.ErrFormNotFound)
+}
+
+// respondError answers with the typed error body for err. Errors the client
+// did not cause are logged; their details never reach the response.
+func (h *FormAPIHandler) respondError(c echo.Context, err error) error {
+	apiErr := apiError(err)
+	if apiErr.HTTPStatus() >= http.StatusInternalServerError {
+		h.Logger.Error("form API request failed", "method", c.Request().Method, "path", c.Path(), "error", err)
+	}
+
+	return h.wrapError("send error response", response.DomainErrorResponse(c, apiErr))
+}
+
+// invalidRequestError reports a request the client needs to correct
+func invalidRequestError(err error) *domainerrors.DomainError {
+	return domainerrors.New(domainerrors.ErrCodeInvalidInput, err.Error(), err)
+}
+
+// apiError returns the domain error the API reports for err. Domain errors
+// keep their code and the form errors are given one; anything else is an
+// internal error.
+func apiError(err error) *domainerrors.DomainError {
+	var domainErr *domainerrors.DomainError
+	if errors.As(err, &domainErr) {
+		return domainErr
+	}
+
+	switch {
+	case errors.Is(err, model.ErrFormNotFound):
+		return domainerrors.New(domainerrors.ErrCodeFormNotFound, "Form not found", err)
+	case errors.Is(err, model.ErrSubmissionNotFound):
+		return domainerrors.New(domainerrors.ErrCodeNotFound, "Submission not found", err)
+	case errors.Is(err, common.ErrNotFound):
+		return domainerrors.New(domainerrors.ErrCodeNotFound, "Resource not found", err)
+	case errors.Is(err, model.ErrFormTitleRequired):
+		return domainerrors.New(domainerrors.ErrCodeRequired, "Form title is required", err)
+	case errors.Is(err, model.ErrFormSchemaRequired):
+		return domainerrors.New(domainerrors.ErrCodeRequired, "Form schema is required", err)
+	case errors.Is(err, model.ErrFormInvalid):
+		return domainerrors.New(domainerrors.ErrCodeFormInvalid, "Form validation failed", err)
+	case errors.Is(err, common.ErrInvalidInput):
+		return domainerrors.New(domainerrors.ErrCodeInvalidInput, "Invalid input", err)
+	default:
+		return domainerrors.New(domainerrors.ErrCodeServerError, "Internal server error", err)
+	}
+}
@@ -0,0 +1,132 @@
+package web
+
+import (
+	"github.com/labstack/echo/v4"
+
+	domainerrors "github.com/goformx/goforms/internal/domain/common/errors"
+	"github.com/goformx/goforms/internal/domain/form/draft"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/presentation/view"
+)
+
+// PATCH /api/v1/forms/:id/draft
+// PATCH /api/v1/forms/:id/draft/:token
+func (h *FormAPIHandler) handleSaveDraft(c echo.Context) error {
+	form, err := h.getPublishedFormOrError(c)
+	if err != nil {
+		return err
+	}
+
+	data, err := h.processSubmissionRequest(c, form.ID)
+	if err != nil {
+		return err
+	}
+
+	d, token, err := h.DraftService.Save(c.Request().Context(), form, c.Param("token"), data)
+	if err != nil {
+		return h.handleDraftError(c, form.ID, err)
+	}
+
+	return h.buildDraftResponse(c, d, token)
+}
+
+// GET /api/v1/forms/:id/draft/:token
+func (h *FormAPIHandler) handleGetDraft(c echo.Context) error {
+	form, err := h.getPublishedFormOrError(c)
+	if err != nil {
+		return err
+	}
+
+	token := c.Param("token")
+
+	d, err := h.DraftService.Get(c.Request().Context(), form, token)
+	if err != nil {
+		return h.handleDraftError(c, form.ID, err)
+	}
+
+	return h.buildDraftResponse(c, d, token)
+}
+
+// POST /api/v1/forms/:id/draft/:token/submit
+//
+// The request body holds the answers not yet saved to the draft. The merged data
+// is validated like a normal submission before the draft is finalized.
+func (h *FormAPIHandler) handleSubmitDraft(c echo.Context) error {
+	form, err := h.getPublishedFormOrError(c)
+	if err != nil {
+		return err
+	}
+
+	if validationErr := h.validateFormSchema(c, form); validationErr != nil {
+		return validationErr
+	}
+
+	d, err := h.DraftService.Get(c.Request().Context(), form, c.Param("token"))
+	if err != nil {
+		return h.handleDraftError(c, form.ID, err)
+	}
+
+	data, err := h.processSubmissionRequest(c, form.ID)
+	if err != nil {
+		return err
+	}
+
+	merged := d.Merged(data)
+	if validationDataErr := h.validateSubmissionData(c, form, merged); validationDataErr != nil {
+		return validationDataErr
+	}
+
+	submission, err := h.DraftService.Finalize(c.Request().Context(), d, merged)
+	if err != nil {
+		if domainerrors.IsNotFound(err) {
+			return h.handleDraftError(c, form.ID, err)
+		}
+
+		h.Logger.Error("Failed to submit draft", "form_id", form.ID, "draft_id", d.ID, "error", err)
+
+		return h.wrapError("handle submission error", h.ErrorHandler.HandleSubmissionError(c, err))
+	}
+
+	h.Logger.Info("Draft submitted successfully", "form_id", form.ID, "submission_id", submission.ID)
+
+	if respErr := h.ResponseBuilder.BuildSubmissionResponse(c, submission); respErr != nil {
+		return h.HandleError(c, respErr, "Failed to build response")
+	}
+
+	return nil
+}
+
+// getPublishedFormOrError retrieves a form that accepts submissions and drafts.
+// Unpublished forms are reported as missing.
+func (h *FormAPIHandler) getPublishedFormOrError(c echo.Context) (*model.Form, error) {
+	form, err := h.getFormOrError(c)
+	if err != nil {
+		return nil, err
+	}
+
+	if !form.IsPublished() {
+		return nil, h.wrapError("handle form not found", h.ErrorHandler.HandleFormNotFoundError(c, ""))
+	}
+
+	return form, nil
+}
+
+// handleDraftError logs unexpected draft errors and sends the error response
+func (h *FormAPIHandler) handleDraftError(c echo.Context, formID string, err error) error {
+	if !domainerrors.IsNotFound(err) && !domainerrors.IsValidation(err) {
+		h.Logger.Error("draft operation failed", "form_id", formID, "error", err)
+	}
+
+	return h.wrapError("handle draft error", h.ErrorHandler.HandleDraftError(c, err))
+}
+
+// buildDraftResponse sends a draft along with the link that resumes it
+func (h *FormAPIHandler) buildDraftResponse(c echo.Context, d *draft.Draft, token string) error {
+	resumeURL := view.PublicFormResumeURL(publicBaseURL(c, h.Config.App.URL), d.FormID, token)
+
+	if respErr := h.ResponseBuilder.BuildDraftResponse(c, d, token, resumeURL); respErr != nil {
+		return h.HandleError(c, respErr, "Failed to build response")
+	}
+
+	return nil
+}
@@ -104,3 +104,63 @@ func (h *FormErrorHandlerImpl) HandleFormAccessError(c echo.Context, err error)
 		return h.responseBuilder.BuildErrorResponse(c, http.StatusInternalServerError, "Failed to access form")
 	}
 }
+
+// HandleImportError handles form bundle import and duplication errors
+func (h *FormErrorHandlerImpl) HandleImportError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, model.ErrUnsupportedBundleVersion):
+		return h.responseBuilder.BuildErrorResponse(c, http.StatusBadRequest, "Unsupported form bundle version")
+	case errors.Is(err, model.ErrInvalidConflictPolicy):
+		return h.responseBuilder.BuildErrorResponse(c, http.StatusBadRequest, "Invalid on_conflict value")
+	case errors.Is(err, model.ErrFormTitleRequired):
+		return h.responseBuilder.BuildErrorResponse(c, http.StatusBadRequest, "Form title is required")
+	case errors.Is(err, model.ErrFormSchemaRequired):
+		return h.responseBuilder.BuildErrorResponse(c, http.StatusBadRequest, "Form schema is required")
+	case errors.Is(err, model.ErrFormInvalid), domainerrors.IsValidation(err):
+		return h.responseBuilder.BuildErrorResponse(c, http.StatusBadRequest, "Invalid form bundle")
+	case domainerrors.IsConflictError(err):
+		return h.responseBuilder.BuildErrorResponse(c, http.StatusConflict, "A form with this ID already exists")
+	case domainerrors.IsNotFound(err):
+		return h.HandleFormNotFoundError(c, "")
+	default:
+		return h.responseBuilder.BuildErrorResponse(c, http.StatusInternalServerError, "Failed to import form")
+	}
+}
+
+// HandleTemplateError handles form template errors
+func (h *FormErrorHandlerImpl) HandleTemplateError(c echo.Context, err error) error {
+	switch {
+	case domainerrors.IsNotFound(err):
+		return h.responseBuilder.BuildErrorResponse(c, http.StatusNotFound, "Template not found")
+	case domainerrors.IsForbiddenError(err):
+		return h.responseBuilder.BuildErrorResponse(c, http.StatusForbidden, "Built-in templates cannot be deleted")
+	case domainerrors.IsValidation(err):
+		return h.responseBuilder.BuildErrorResponse(c, http.StatusBadRequest, "Invalid template")
+	default:
+		return h.responseBuilder.BuildErrorResponse(c, http.StatusInternalServerError, "Failed to process template")
+	}
+}
+
+// HandleDraftError handles save-and-resume draft errors
+func (h *FormErrorHandlerImpl) HandleDraftError(c echo.Context, err error) error {
+	switch {
+	case domainerrors.IsNotFound(err):
+		return h.responseBuilder.BuildErrorResponse(c, http.StatusNotFound, "Draft not found or expired")
+	case domainerrors.IsValidation(err):
+		return h.responseBuilder.BuildErrorResponse(c, http.StatusBadRequest, "Invalid draft data")
+	default:
+		return h.responseBuilder.BuildErrorResponse(c, http.StatusInternalServerError, "Failed to save draft")
+	}
+}
+
+// HandleRevisionError handles submission edit and restore errors
+func (h *FormErrorHandlerImpl) HandleRevisionError(c echo.Context, err error) error {
+	switch {
+	case domainerrors.IsNotFound(err):
+		return h.responseBuilder.BuildErrorResponse(c, http.StatusNotFound, "Submission or revision not found")
+	case domainerrors.IsValidation(err):
+		return h.responseBuilder.BuildErrorResponse(c, http.StatusBadRequest, "Submission data is unchanged or invalid")
+	default:
+		return h.responseBuilder.BuildErrorResponse(c, http.StatusInternalServerError, "Failed to update submission")
+	}
+}
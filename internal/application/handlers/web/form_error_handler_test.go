@@ -338,6 +338,78 @@ func TestFormErrorHandler_HandleFormAccessError(t *testing.T) {
 	}
 }
 
+func TestFormErrorHandler_HandleDraftError(t *testing.T) {
+	handler, e := setupTestFormErrorHandler()
+
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+		expectedBody   string
+		description    string
+	}{
+		{
+			name:           "unknown or expired token",
+			err:            domainerrors.New(domainerrors.ErrCodeNotFound, "draft not found or expired", nil),
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   "Draft not found or expired",
+			description:    "Should return 404 for unknown, expired or finalized drafts",
+		},
+		{
+			name:           "missing data",
+			err:            domainerrors.New(domainerrors.ErrCodeValidation, "draft data is required", nil),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   "Invalid draft data",
+			description:    "Should return 400 for validation errors",
+		},
+		{
+			name:           "storage error",
+			err:            domainerrors.New(domainerrors.ErrCodeServerError, "database unavailable", nil),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   "Failed to save draft",
+			description:    "Should return 500 for other errors",
+		},
+	}
+
+	runErrorHandlerTest(t, e, tests, handler.HandleDraftError)
+}
+
+func TestFormErrorHandler_HandleRevisionError(t *testing.T) {
+	handler, e := setupTestFormErrorHandler()
+
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+		expectedBody   string
+		description    string
+	}{
+		{
+			name:           "unknown submission or revision",
+			err:            domainerrors.New(domainerrors.ErrCodeNotFound, "revision not found", nil),
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   "Submission or revision not found",
+			description:    "Should return 404 for submissions of other forms and unknown revisions",
+		},
+		{
+			name:           "no changes",
+			err:            domainerrors.New(domainerrors.ErrCodeValidation, "submission data is unchanged", nil),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   "Submission data is unchanged or invalid",
+			description:    "Should return 400 for validation errors",
+		},
+		{
+			name:           "storage error",
+			err:            domainerrors.New(domainerrors.ErrCodeServerError, "database unavailable", nil),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   "Failed to update submission",
+			description:    "Should return 500 for other errors",
+		},
+	}
+
+	runErrorHandlerTest(t, e, tests, handler.HandleRevisionError)
+}
+
 func TestFormErrorHandler_ErrorResponseFormat(t *testing.T) {
 	handler, e := setupTestFormErrorHandler()
 
@@ -10,7 +10,9 @@ import (
 	"github.com/labstack/echo/v4"
 
 	"github.com/goformx/goforms/internal/application/constants"
+	domainerrors "github.com/goformx/goforms/internal/domain/common/errors"
 	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/domain/form/template"
 	"github.com/goformx/goforms/internal/presentation/templates/pages"
 )
 
@@ -24,10 +26,17 @@ func (h *FormWebHandler) handleNew(c echo.Context) error {
 		return err
 	}
 
+	templates, err := h.TemplateService.ListTemplates(c.Request().Context(), user.ID)
+	if err != nil {
+		h.Logger.Error("failed to list form templates", "error", err)
+
+		return h.HandleError(c, err, "Failed to load form templates")
+	}
+
 	data := h.NewPageData(c, "New Form")
 	data.SetUser(user)
 
-	if renderErr := h.Renderer.Render(c, pages.NewForm(*data)); renderErr != nil {
+	if renderErr := h.Renderer.Render(c, pages.NewForm(*data, templates)); renderErr != nil {
 		return fmt.Errorf("failed to render new form page: %w", renderErr)
 	}
 
@@ -171,6 +180,48 @@ func (h *FormWebHandler) handleSubmissions(c echo.Context) error {
 	return nil
 }
 
+// handleSubmission displays a single submission with its edit history
+func (h *FormWebHandler) handleSubmission(c echo.Context) error {
+	user, err := h.AuthHelper.RequireAuthenticatedUser(c)
+	if err != nil {
+		return err
+	}
+
+	form, err := h.AuthHelper.GetFormWithOwnership(c)
+	if err != nil {
+		return err
+	}
+
+	submission, err := h.RevisionService.GetSubmission(c.Request().Context(), form.ID, c.Param("sid"))
+	if err != nil {
+		if domainerrors.IsNotFound(err) {
+			return h.HandleNotFound(c, "Submission not found")
+		}
+
+		h.Logger.Error("failed to get form submission", "error", err)
+
+		return h.HandleError(c, err, "Failed to get form submission")
+	}
+
+	revisions, err := h.RevisionService.ListRevisions(c.Request().Context(), form.ID, submission.ID)
+	if err != nil {
+		h.Logger.Error("failed to list submission revisions", "error", err)
+
+		return h.HandleError(c, err, "Failed to get submission history")
+	}
+
+	h.RecordSubmissionViewed(c, form, submission.ID)
+
+	data := h.NewPageData(c, "Submission").WithForm(form)
+	data.SetUser(user)
+
+	if renderErr := h.Renderer.Render(c, pages.FormSubmission(*data, submission, revisions)); renderErr != nil {
+		return fmt.Errorf("failed to render form submission page: %w", renderErr)
+	}
+
+	return nil
+}
+
 // handleFormCreationError handles form creation errors
 func (h *FormWebHandler) handleFormCreationError(c echo.Context, err error) error {
 	switch {
@@ -180,6 +231,9 @@ func (h *FormWebHandler) handleFormCreationError(c echo.Context, err error) erro
 	case errors.Is(err, model.ErrFormSchemaRequired):
 		return fmt.Errorf("build error response: %w",
 			h.ResponseBuilder.BuildErrorResponse(c, http.StatusBadRequest, "Form schema is required"))
+	case errors.Is(err, template.ErrTemplateNotFound):
+		return fmt.Errorf("build error response: %w",
+			h.ResponseBuilder.BuildErrorResponse(c, http.StatusBadRequest, "Template not found"))
 	default:
 		return fmt.Errorf("build error response: %w",
 			h.ResponseBuilder.BuildErrorResponse(c, http.StatusInternalServerError, "Failed to create form"))
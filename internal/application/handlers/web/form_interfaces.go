@@ -4,20 +4,58 @@ import (
 	"github.com/labstack/echo/v4"
 
 	"github.com/goformx/goforms/internal/application/validation"
+	"github.com/goformx/goforms/internal/domain/form/draft"
 	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/domain/form/revision"
+	"github.com/goformx/goforms/internal/domain/form/template"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+	"github.com/goformx/goforms/internal/presentation/view"
 )
 
 // FormCreateRequest represents the data needed to create a form
 type FormCreateRequest struct {
-	Title string `json:"title"`
+	Title       string `json:"title" validate:"required,min=3,max=100"`
+	Description string `json:"description,omitempty" validate:"max=500"`
+	TemplateID  string `json:"template_id,omitempty"`
+	// Schema is the form's initial schema; nil starts from an empty form or
+	// the template
+	Schema model.JSON `json:"schema,omitempty"`
 }
 
 // FormUpdateRequest represents the data needed to update a form
 type FormUpdateRequest struct {
 	Title       string `json:"title"`
-	Description string `json:"description"`
-	Status      string `json:"status"`
-	CorsOrigins string `json:"cors_origins"`
+	Description string `json:"description,omitempty"`
+	Status      string `json:"status,omitempty" enum:"draft,published,archived"`
+	CorsOrigins string `json:"cors_origins,omitempty"`
+	// DraftTTLHours is how long drafts stay resumable; zero keeps the form's current setting
+	DraftTTLHours int `json:"draft_ttl_hours,omitempty"`
+	// RetentionDays is how long submissions are kept, zero meaning forever; nil
+	// keeps the form's current setting
+	RetentionDays *int `json:"retention_days,omitempty"`
+	// RetentionAction is applied to expired submissions; empty keeps the current action
+	RetentionAction string `json:"retention_action,omitempty" enum:"delete,anonymize"`
+}
+
+// FormStatusRequest represents a request to change a form's status
+type FormStatusRequest struct {
+	Status string `json:"status" enum:"draft,published,archived"`
+}
+
+// SubmissionListRequest selects a page of a form's submissions
+type SubmissionListRequest struct {
+	Params common.PaginationParams
+	// Sort is the sort parameter as echoed back to the client, such as
+	// "-submitted_at" for newest first
+	Sort string
+	// Fields lists the submission fields to return; empty returns them all
+	Fields []string
+}
+
+// SaveTemplateRequest represents the data needed to save a form as a template
+type SaveTemplateRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
 }
 
 // FormRetriever interface for retrieving forms
@@ -35,8 +73,15 @@ type FormOwnershipValidator interface {
 type FormRequestProcessor interface {
 	ProcessCreateRequest(c echo.Context) (*FormCreateRequest, error)
 	ProcessUpdateRequest(c echo.Context) (*FormUpdateRequest, error)
+	ProcessJSONCreateRequest(c echo.Context) (*FormCreateRequest, error)
+	ProcessJSONUpdateRequest(c echo.Context) (*FormUpdateRequest, error)
+	ProcessStatusRequest(c echo.Context) (string, error)
+	ProcessSubmissionListRequest(c echo.Context) (*SubmissionListRequest, error)
 	ProcessSchemaUpdateRequest(c echo.Context) (model.JSON, error)
 	ProcessSubmissionRequest(c echo.Context) (model.JSON, error)
+	ProcessImportRequest(c echo.Context) (*model.Bundle, error)
+	ProcessJSONSchemaImportRequest(c echo.Context) (*FormCreateRequest, error)
+	ProcessSaveTemplateRequest(c echo.Context) (*SaveTemplateRequest, error)
 }
 
 // FormResponseBuilder interface for building standardized responses
@@ -47,6 +92,16 @@ type FormResponseBuilder interface {
 	BuildSubmissionResponse(c echo.Context, submission *model.FormSubmission) error
 	BuildFormResponse(c echo.Context, form *model.Form) error
 	BuildFormListResponse(c echo.Context, forms []*model.Form) error
+	BuildFormCreatedResponse(c echo.Context, message string, form *model.Form) error
+	BuildBundleResponse(c echo.Context, bundle *model.Bundle) error
+	BuildEmbedCodeResponse(c echo.Context, form *model.Form, code view.EmbedCode) error
+	BuildTemplateResponse(c echo.Context, t *template.Template) error
+	BuildTemplateListResponse(c echo.Context, templates []*template.Template) error
+	BuildDraftResponse(c echo.Context, d *draft.Draft, token, resumeURL string) error
+	BuildSubmissionDetailResponse(c echo.Context, submission *model.FormSubmission) error
+	BuildSubmissionPageResponse(c echo.Context, page *common.PaginationResult, req *SubmissionListRequest) error
+	BuildRevisionResponse(c echo.Context, rev *revision.Revision) error
+	BuildRevisionListResponse(c echo.Context, revisions []*revision.Revision) error
 	BuildValidationErrorResponse(c echo.Context, field, message string) error
 	BuildMultipleErrorResponse(c echo.Context, errors []validation.Error) error
 }
@@ -59,4 +114,8 @@ type FormErrorHandler interface {
 	HandleOwnershipError(c echo.Context, err error) error
 	HandleFormNotFoundError(c echo.Context, formID string) error
 	HandleFormAccessError(c echo.Context, err error) error
+	HandleImportError(c echo.Context, err error) error
+	HandleTemplateError(c echo.Context, err error) error
+	HandleDraftError(c echo.Context, err error) error
+	HandleRevisionError(c echo.Context, err error) error
 }
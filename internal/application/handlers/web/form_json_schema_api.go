@@ -0,0 +1,125 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/middleware/locale"
+	"github.com/goformx/goforms/internal/application/response"
+	"github.com/goformx/goforms/internal/application/validation"
+	domainerrors "github.com/goformx/goforms/internal/domain/common/errors"
+)
+
+// JSONSchemaContentType is the media type of JSON Schema documents
+const JSONSchemaContentType = "application/schema+json"
+
+// GET /api/v1/forms/:id/json-schema?version=&lang=
+//
+// Describes the form's submissions as a JSON Schema (draft 2020-12) document.
+// Without version the current schema is described; lang translates the titles.
+func (h *FormAPIHandler) handleJSONSchema(c echo.Context) error {
+	form, err := h.getOwnedForm(c)
+	if err != nil {
+		return err
+	}
+
+	versions, err := h.FormService.SchemaVersions(c.Request().Context(), form.ID)
+	if err != nil {
+		return h.respondError(c, err)
+	}
+
+	number := len(versions)
+
+	if param := c.QueryParam("version"); param != "" {
+		var convErr error
+		if number, convErr = strconv.Atoi(param); convErr != nil || number < 1 {
+			return h.respondError(c, domainerrors.New(
+				domainerrors.ErrCodeInvalidInput, "version must be a positive number", convErr))
+		}
+	}
+
+	if number < 1 || number > len(versions) {
+		return h.respondError(c, domainerrors.New(domainerrors.ErrCodeNotFound, "Schema version not found", nil))
+	}
+
+	selected := versions[number-1]
+
+	versioned := *form
+	versioned.Schema = selected.Schema
+
+	if c.QueryParam(locale.QueryParam) != "" {
+		versioned = *versioned.Localized(locale.Get(c))
+	}
+
+	document, err := h.JSONSchemas.ToJSONSchema(versioned.Schema, validation.JSONSchemaInfo{
+		ID:          jsonSchemaID(c, selected.Version),
+		Title:       versioned.Title,
+		Description: versioned.Description,
+	})
+	if err != nil {
+		return h.respondError(c, invalidRequestError(err))
+	}
+
+	data, err := json.Marshal(document)
+	if err != nil {
+		return h.HandleError(c, err, "Failed to build response")
+	}
+
+	return h.wrapError("send JSON Schema", c.Blob(http.StatusOK, JSONSchemaContentType, data))
+}
+
+// jsonSchemaID returns the URL of a schema version, used as its document's $id
+func jsonSchemaID(c echo.Context, version int) string {
+	return fmt.Sprintf("%s://%s%s?version=%d", c.Scheme(), c.Request().Host, c.Request().URL.Path, version)
+}
+
+// GET /api/v1/forms/:id/json-schema/versions
+//
+// Lists the versions of the form's schema, oldest first. Any of them can be
+// described with GET /api/v1/forms/:id/json-schema?version=.
+func (h *FormAPIHandler) handleJSONSchemaVersions(c echo.Context) error {
+	form, err := h.getOwnedForm(c)
+	if err != nil {
+		return err
+	}
+
+	versions, err := h.FormService.SchemaVersions(c.Request().Context(), form.ID)
+	if err != nil {
+		return h.respondError(c, err)
+	}
+
+	return response.Success(c, versions)
+}
+
+// POST /api/v1/forms/import/json-schema?title=
+//
+// Creates a form from a JSON Schema document describing an object, with a
+// component for each of its properties.
+func (h *FormAPIHandler) handleImportJSONSchema(c echo.Context) error {
+	userID, ok := c.Get("user_id").(string)
+	if !ok {
+		return h.respondError(c, domainerrors.New(domainerrors.ErrCodeUnauthorized, "Authentication required", nil))
+	}
+
+	req, err := h.RequestProcessor.ProcessJSONSchemaImportRequest(c)
+	if err != nil {
+		return h.respondError(c, invalidRequestError(err))
+	}
+
+	form, err := h.Forms.CreateForm(c.Request().Context(), userID, req)
+	if err != nil {
+		return h.respondError(c, err)
+	}
+
+	h.Logger.Info("form imported from JSON Schema", "form_id", form.ID)
+
+	if respErr := h.ResponseBuilder.BuildFormCreatedResponse(c, "Form created successfully", form); respErr != nil {
+		return h.HandleError(c, respErr, "Failed to build response")
+	}
+
+	return nil
+}
@@ -4,12 +4,17 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/labstack/echo/v4"
 
 	"github.com/goformx/goforms/internal/application/validation"
 	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/domain/form/template"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
 	"github.com/goformx/goforms/internal/infrastructure/sanitization"
 )
 
@@ -19,6 +24,22 @@ const (
 	MaxDescriptionLength = 1000
 )
 
+// Submission list constants
+const (
+	// DefaultSubmissionPageSize is the page size used when none is requested
+	DefaultSubmissionPageSize = 20
+	// MaxSubmissionPageSize is the largest page of submissions a client may request
+	MaxSubmissionPageSize = 100
+	// DefaultSubmissionSort lists the newest submissions first
+	DefaultSubmissionSort = "-submitted_at"
+)
+
+// formStatuses are the statuses a form may be given
+var formStatuses = []string{"draft", "published", "archived"}
+
+// SubmissionFields are the submission fields a client may select
+var SubmissionFields = []string{"id", "form_id", "status", "submitted_at", "data"}
+
 // FormRequestProcessorImpl implements FormRequestProcessor
 type FormRequestProcessorImpl struct {
 	sanitizer sanitization.ServiceInterface
@@ -39,7 +60,8 @@ func NewFormRequestProcessor(
 // ProcessCreateRequest processes form creation requests
 func (p *FormRequestProcessorImpl) ProcessCreateRequest(c echo.Context) (*FormCreateRequest, error) {
 	req := &FormCreateRequest{
-		Title: p.sanitizer.String(c.FormValue("title")),
+		Title:      p.sanitizer.String(c.FormValue("title")),
+		TemplateID: p.sanitizer.String(c.FormValue("template_id")),
 	}
 
 	if err := p.validateCreateRequest(req); err != nil {
@@ -58,6 +80,32 @@ func (p *FormRequestProcessorImpl) ProcessUpdateRequest(c echo.Context) (*FormUp
 		CorsOrigins: p.sanitizer.String(c.FormValue("cors_origins")),
 	}
 
+	if ttl := strings.TrimSpace(c.FormValue("draft_ttl_hours")); ttl != "" {
+		hours, err := strconv.Atoi(ttl)
+		if err != nil || hours < 1 || hours > model.MaxDraftTTLHours {
+			return nil, fmt.Errorf("draft expiry must be between 1 and %d hours", model.MaxDraftTTLHours)
+		}
+
+		req.DraftTTLHours = hours
+	}
+
+	if retention := strings.TrimSpace(c.FormValue("retention_days")); retention != "" {
+		days, err := strconv.Atoi(retention)
+		if err != nil || days < 0 || days > model.MaxRetentionDays {
+			return nil, fmt.Errorf("retention period must be between 0 and %d days", model.MaxRetentionDays)
+		}
+
+		req.RetentionDays = &days
+	}
+
+	if action := strings.TrimSpace(c.FormValue("retention_action")); action != "" {
+		if !model.IsRetentionAction(action) {
+			return nil, errors.New("retention action must be delete or anonymize")
+		}
+
+		req.RetentionAction = action
+	}
+
 	// Validate CORS origins when publishing
 	if req.Status == "published" && strings.TrimSpace(req.CorsOrigins) == "" {
 		return nil, errors.New("CORS origins are required when publishing a form")
@@ -70,6 +118,144 @@ func (p *FormRequestProcessorImpl) ProcessUpdateRequest(c echo.Context) (*FormUp
 	return req, nil
 }
 
+// ProcessJSONCreateRequest processes form creation requests sent as JSON by API
+// clients. A form may be created from a template or a schema, not both.
+func (p *FormRequestProcessorImpl) ProcessJSONCreateRequest(c echo.Context) (*FormCreateRequest, error) {
+	var req FormCreateRequest
+	if err := json.NewDecoder(c.Request().Body).Decode(&req); err != nil {
+		return nil, fmt.Errorf("failed to decode form: %w", err)
+	}
+
+	req.Title = p.sanitizer.String(req.Title)
+	req.Description = p.sanitizer.String(req.Description)
+	req.TemplateID = p.sanitizer.String(req.TemplateID)
+
+	if err := p.validateCreateRequest(&req); err != nil {
+		return nil, err
+	}
+
+	if req.Schema != nil {
+		if req.TemplateID != "" {
+			return nil, errors.New("schema and template_id cannot both be set")
+		}
+
+		if err := p.validator.ValidateFormSchema(req.Schema); err != nil {
+			return nil, fmt.Errorf("invalid schema: %w", err)
+		}
+	}
+
+	return &req, nil
+}
+
+// ProcessJSONUpdateRequest processes form update requests sent as JSON by API
+// clients. Settings left out keep their current value.
+func (p *FormRequestProcessorImpl) ProcessJSONUpdateRequest(c echo.Context) (*FormUpdateRequest, error) {
+	var req FormUpdateRequest
+	if err := json.NewDecoder(c.Request().Body).Decode(&req); err != nil {
+		return nil, fmt.Errorf("failed to decode form: %w", err)
+	}
+
+	req.Title = p.sanitizer.String(req.Title)
+	req.Description = p.sanitizer.String(req.Description)
+	req.Status = p.sanitizer.String(req.Status)
+	req.CorsOrigins = p.sanitizer.String(req.CorsOrigins)
+
+	if req.DraftTTLHours < 0 || req.DraftTTLHours > model.MaxDraftTTLHours {
+		return nil, fmt.Errorf("draft expiry must be between 1 and %d hours", model.MaxDraftTTLHours)
+	}
+
+	if req.RetentionDays != nil && (*req.RetentionDays < 0 || *req.RetentionDays > model.MaxRetentionDays) {
+		return nil, fmt.Errorf("retention period must be between 0 and %d days", model.MaxRetentionDays)
+	}
+
+	if req.RetentionAction != "" && !model.IsRetentionAction(req.RetentionAction) {
+		return nil, errors.New("retention action must be delete or anonymize")
+	}
+
+	if err := p.validateUpdateRequest(&req); err != nil {
+		return nil, err
+	}
+
+	return &req, nil
+}
+
+// ProcessStatusRequest processes requests to change a form's status
+func (p *FormRequestProcessorImpl) ProcessStatusRequest(c echo.Context) (string, error) {
+	var req FormStatusRequest
+
+	if err := json.NewDecoder(c.Request().Body).Decode(&req); err != nil {
+		return "", fmt.Errorf("failed to decode status: %w", err)
+	}
+
+	status := strings.TrimSpace(req.Status)
+	if !slices.Contains(formStatuses, status) {
+		return "", errors.New("invalid form status")
+	}
+
+	return status, nil
+}
+
+// ProcessSubmissionListRequest reads the page, page_size, sort and fields
+// query parameters of a submission list request. Sort names one of
+// model.SubmissionSortFields, prefixed with "-" for descending order; fields
+// is a comma-separated subset of SubmissionFields.
+func (p *FormRequestProcessorImpl) ProcessSubmissionListRequest(c echo.Context) (*SubmissionListRequest, error) {
+	page, err := queryInt(c, "page", 1)
+	if err != nil || page < 1 {
+		return nil, errors.New("page must be a positive number")
+	}
+
+	pageSize, err := queryInt(c, "page_size", DefaultSubmissionPageSize)
+	if err != nil || pageSize < 1 || pageSize > MaxSubmissionPageSize {
+		return nil, fmt.Errorf("page_size must be between 1 and %d", MaxSubmissionPageSize)
+	}
+
+	req := &SubmissionListRequest{
+		Params: common.PaginationParams{Page: page, PageSize: pageSize},
+		Sort:   strings.TrimSpace(c.QueryParam("sort")),
+	}
+
+	if req.Sort == "" {
+		req.Sort = DefaultSubmissionSort
+	}
+
+	sortBy, desc := strings.CutPrefix(req.Sort, "-")
+	if !slices.Contains(model.SubmissionSortFields, sortBy) {
+		return nil, fmt.Errorf("sort must be one of %s, optionally prefixed with -",
+			strings.Join(model.SubmissionSortFields, ", "))
+	}
+
+	req.Params.SortBy = sortBy
+	req.Params.SortDesc = desc
+
+	for _, field := range parseCSV(c.QueryParam("fields")) {
+		if !slices.Contains(SubmissionFields, field) {
+			return nil, fmt.Errorf("unknown submission field %q", field)
+		}
+
+		if !slices.Contains(req.Fields, field) {
+			req.Fields = append(req.Fields, field)
+		}
+	}
+
+	return req, nil
+}
+
+// queryInt reads an integer query parameter, returning fallback when it is absent
+func queryInt(c echo.Context, name string, fallback int) (int, error) {
+	value := strings.TrimSpace(c.QueryParam(name))
+	if value == "" {
+		return fallback, nil
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("parse %s: %w", name, err)
+	}
+
+	return n, nil
+}
+
 // ProcessSchemaUpdateRequest processes schema update requests
 func (p *FormRequestProcessorImpl) ProcessSchemaUpdateRequest(c echo.Context) (model.JSON, error) {
 	var schema model.JSON
@@ -112,6 +298,80 @@ func (p *FormRequestProcessorImpl) ProcessSubmissionRequest(c echo.Context) (mod
 	return submissionData, nil
 }
 
+// ProcessImportRequest decodes a form bundle and validates its version and schema
+func (p *FormRequestProcessorImpl) ProcessImportRequest(c echo.Context) (*model.Bundle, error) {
+	var bundle model.Bundle
+	if err := json.NewDecoder(c.Request().Body).Decode(&bundle); err != nil {
+		return nil, fmt.Errorf("%w: failed to decode form bundle: %w", model.ErrFormInvalid, err)
+	}
+
+	if err := bundle.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := p.validator.ValidateFormSchema(bundle.Form.Schema); err != nil {
+		return nil, fmt.Errorf("%w: %w", model.ErrFormInvalid, err)
+	}
+
+	return &bundle, nil
+}
+
+// ProcessJSONSchemaImportRequest processes requests to create a form from a
+// JSON Schema document. The title query parameter replaces the document's title.
+func (p *FormRequestProcessorImpl) ProcessJSONSchemaImportRequest(c echo.Context) (*FormCreateRequest, error) {
+	data, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JSON Schema: %w", err)
+	}
+
+	imported, err := validation.NewJSONSchemaConverter().FromJSONSchema(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to import JSON Schema: %w", err)
+	}
+
+	title := imported.Title
+	if override := c.QueryParam("title"); override != "" {
+		title = override
+	}
+
+	req := FormCreateRequest{
+		Title:       p.sanitizer.String(title),
+		Description: p.sanitizer.String(imported.Description),
+		Schema:      imported.Schema,
+	}
+
+	if validateErr := p.validateCreateRequest(&req); validateErr != nil {
+		return nil, validateErr
+	}
+
+	if validateErr := p.validator.ValidateFormSchema(req.Schema); validateErr != nil {
+		return nil, fmt.Errorf("invalid schema: %w", validateErr)
+	}
+
+	return &req, nil
+}
+
+// ProcessSaveTemplateRequest processes requests to save a form as a template
+func (p *FormRequestProcessorImpl) ProcessSaveTemplateRequest(c echo.Context) (*SaveTemplateRequest, error) {
+	var req SaveTemplateRequest
+	if err := json.NewDecoder(c.Request().Body).Decode(&req); err != nil {
+		return nil, fmt.Errorf("failed to decode template request: %w", err)
+	}
+
+	req.Name = p.sanitizer.String(req.Name)
+	req.Description = p.sanitizer.String(req.Description)
+
+	if len(req.Name) > template.MaxNameLength {
+		return nil, errors.New("template name too long")
+	}
+
+	if len(req.Description) > template.MaxDescriptionLength {
+		return nil, errors.New("template description too long")
+	}
+
+	return &req, nil
+}
+
 // validateCreateRequest validates form creation request
 func (p *FormRequestProcessorImpl) validateCreateRequest(req *FormCreateRequest) error {
 	if req.Title == "" {
@@ -122,6 +382,10 @@ func (p *FormRequestProcessorImpl) validateCreateRequest(req *FormCreateRequest)
 		return errors.New("title too long")
 	}
 
+	if len(req.Description) > MaxDescriptionLength {
+		return errors.New("description too long")
+	}
+
 	return nil
 }
 
@@ -141,18 +405,7 @@ func (p *FormRequestProcessorImpl) validateUpdateRequest(req *FormUpdateRequest)
 
 	// Validate status if provided
 	if req.Status != "" {
-		validStatuses := []string{"draft", "published", "archived"}
-		isValid := false
-
-		for _, status := range validStatuses {
-			if req.Status == status {
-				isValid = true
-
-				break
-			}
-		}
-
-		if !isValid {
+		if !slices.Contains(formStatuses, req.Status) {
 			return errors.New("invalid form status")
 		}
 
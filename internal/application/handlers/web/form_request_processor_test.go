@@ -0,0 +1,54 @@
+package web_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/application/handlers/web"
+	"github.com/goformx/goforms/internal/infrastructure/sanitization"
+)
+
+func submissionListContext(query string) echo.Context {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/forms/form-1/submissions?"+query, http.NoBody)
+
+	return echo.New().NewContext(req, httptest.NewRecorder())
+}
+
+func TestFormRequestProcessor_ProcessSubmissionListRequest(t *testing.T) {
+	processor := web.NewFormRequestProcessor(sanitization.NewService(), nil)
+
+	req, err := processor.ProcessSubmissionListRequest(submissionListContext(""))
+	require.NoError(t, err)
+	assert.Equal(t, 1, req.Params.Page)
+	assert.Equal(t, web.DefaultSubmissionPageSize, req.Params.PageSize)
+	assert.Equal(t, "submitted_at", req.Params.SortBy)
+	assert.True(t, req.Params.SortDesc)
+	assert.Equal(t, web.DefaultSubmissionSort, req.Sort)
+	assert.Empty(t, req.Fields)
+
+	req, err = processor.ProcessSubmissionListRequest(
+		submissionListContext("page=3&page_size=50&sort=status&fields=id,%20status,id"))
+	require.NoError(t, err)
+	assert.Equal(t, 3, req.Params.Page)
+	assert.Equal(t, 50, req.Params.PageSize)
+	assert.Equal(t, "status", req.Params.SortBy)
+	assert.False(t, req.Params.SortDesc)
+	assert.Equal(t, []string{"id", "status"}, req.Fields)
+
+	for _, query := range []string{
+		"page=0",
+		"page=first",
+		"page_size=101",
+		"sort=-data",
+		"sort=uuid",
+		"fields=id,metadata",
+	} {
+		_, listErr := processor.ProcessSubmissionListRequest(submissionListContext(query))
+		assert.Error(t, listErr, query)
+	}
+}
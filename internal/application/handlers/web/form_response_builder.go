@@ -1,6 +1,7 @@
 package web
 
 import (
+	"fmt"
 	"net/http"
 	"time"
 
@@ -8,7 +9,12 @@ import (
 
 	"github.com/goformx/goforms/internal/application/response"
 	"github.com/goformx/goforms/internal/application/validation"
+	"github.com/goformx/goforms/internal/domain/form/draft"
 	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/domain/form/revision"
+	"github.com/goformx/goforms/internal/domain/form/template"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+	"github.com/goformx/goforms/internal/presentation/view"
 )
 
 // FormResponseBuilderImpl implements FormResponseBuilder
@@ -31,8 +37,9 @@ func (b *FormResponseBuilderImpl) BuildSuccessResponse(c echo.Context, message s
 // BuildErrorResponse builds a standardized error response
 func (b *FormResponseBuilderImpl) BuildErrorResponse(c echo.Context, statusCode int, message string) error {
 	return c.JSON(statusCode, response.APIResponse{
-		Success: false,
-		Message: message,
+		Success:   false,
+		Message:   message,
+		RequestID: response.RequestID(c),
 	})
 }
 
@@ -49,10 +56,10 @@ func (b *FormResponseBuilderImpl) BuildSubmissionResponse(c echo.Context, submis
 	return c.JSON(http.StatusOK, response.APIResponse{
 		Success: true,
 		Message: "Form submitted successfully",
-		Data: map[string]any{
-			"submission_id": submission.ID,
-			"status":        submission.Status,
-			"submitted_at":  submission.SubmittedAt.Format(time.RFC3339),
+		Data: SubmissionCreatedResponse{
+			SubmissionID: submission.ID,
+			Status:       submission.Status,
+			SubmittedAt:  submission.SubmittedAt.Format(time.RFC3339),
 		},
 	})
 }
@@ -61,77 +68,189 @@ func (b *FormResponseBuilderImpl) BuildSubmissionResponse(c echo.Context, submis
 func (b *FormResponseBuilderImpl) BuildFormResponse(c echo.Context, form *model.Form) error {
 	return c.JSON(http.StatusOK, response.APIResponse{
 		Success: true,
-		Data: map[string]any{
-			"form": map[string]any{
-				"id":          form.ID,
-				"title":       form.Title,
-				"description": form.Description,
-				"status":      form.Status,
-				"schema":      form.Schema,
-				"created_at":  form.CreatedAt.Format(time.RFC3339),
-				"updated_at":  form.UpdatedAt.Format(time.RFC3339),
-			},
+		Data: FormResponse{
+			Form: FormDetail{FormSummary: newFormSummary(form), Schema: form.Schema},
 		},
 	})
 }
 
 // BuildFormListResponse builds a form list response
 func (b *FormResponseBuilderImpl) BuildFormListResponse(c echo.Context, forms []*model.Form) error {
-	formData := make([]map[string]any, len(forms))
+	formData := make([]FormSummary, len(forms))
 	for i, form := range forms {
-		formData[i] = map[string]any{
-			"id":          form.ID,
-			"title":       form.Title,
-			"description": form.Description,
-			"status":      form.Status,
-			"created_at":  form.CreatedAt.Format(time.RFC3339),
-			"updated_at":  form.UpdatedAt.Format(time.RFC3339),
-		}
+		formData[i] = newFormSummary(form)
 	}
 
 	return c.JSON(http.StatusOK, response.APIResponse{
 		Success: true,
-		Data: map[string]any{
-			"forms": formData,
-			"count": len(forms),
+		Data:    FormListResponse{Forms: formData, Count: len(forms)},
+	})
+}
+
+// BuildFormCreatedResponse builds a response for a newly created form
+func (b *FormResponseBuilderImpl) BuildFormCreatedResponse(c echo.Context, message string, form *model.Form) error {
+	return c.JSON(http.StatusCreated, response.APIResponse{
+		Success: true,
+		Message: message,
+		Data: FormCreatedResponse{
+			FormID: form.ID,
+			Title:  form.Title,
+			Status: form.Status,
+		},
+	})
+}
+
+// BuildBundleResponse writes a form bundle as a downloadable JSON file
+func (b *FormResponseBuilderImpl) BuildBundleResponse(c echo.Context, bundle *model.Bundle) error {
+	c.Response().Header().Set(
+		echo.HeaderContentDisposition,
+		fmt.Sprintf("attachment; filename=%q", "form-"+bundle.Form.ID+".json"),
+	)
+
+	return c.JSON(http.StatusOK, bundle)
+}
+
+// BuildEmbedCodeResponse builds a response with a form's public link and embed snippets
+func (b *FormResponseBuilderImpl) BuildEmbedCodeResponse(c echo.Context, form *model.Form, code view.EmbedCode) error {
+	return c.JSON(http.StatusOK, response.APIResponse{
+		Success: true,
+		Data: EmbedCodeResponse{
+			FormID:    form.ID,
+			Published: form.IsPublished(),
+			URL:       code.URL,
+			Script:    code.Script,
+			IFrame:    code.IFrame,
 		},
 	})
 }
 
+// BuildDraftResponse builds a response for a saved or resumed draft. The resume
+// token is only ever returned here; the server keeps just its hash.
+func (b *FormResponseBuilderImpl) BuildDraftResponse(c echo.Context, d *draft.Draft, token, resumeURL string) error {
+	return c.JSON(http.StatusOK, response.APIResponse{
+		Success: true,
+		Message: "Draft saved",
+		Data: DraftResponse{
+			FormID:      d.FormID,
+			ResumeToken: token,
+			ResumeURL:   resumeURL,
+			ExpiresAt:   d.ExpiresAt.UTC().Format(time.RFC3339),
+			Data:        d.Data,
+		},
+	})
+}
+
+// BuildTemplateResponse builds a response for a newly saved template
+func (b *FormResponseBuilderImpl) BuildTemplateResponse(c echo.Context, t *template.Template) error {
+	return c.JSON(http.StatusCreated, response.APIResponse{
+		Success: true,
+		Message: "Template saved successfully",
+		Data:    TemplateResponse{Template: newTemplateSummary(t)},
+	})
+}
+
+// BuildTemplateListResponse builds a template list response
+func (b *FormResponseBuilderImpl) BuildTemplateListResponse(c echo.Context, templates []*template.Template) error {
+	templateData := make([]TemplateSummary, len(templates))
+	for i, t := range templates {
+		templateData[i] = newTemplateSummary(t)
+	}
+
+	return c.JSON(http.StatusOK, response.APIResponse{
+		Success: true,
+		Data:    TemplateListResponse{Templates: templateData, Count: len(templates)},
+	})
+}
+
 // BuildSubmissionListResponse builds a response for form submission lists
 func (b *FormResponseBuilderImpl) BuildSubmissionListResponse(
 	c echo.Context,
 	submissions []*model.FormSubmission,
 ) error {
-	submissionData := make([]map[string]any, len(submissions))
+	submissionData := make([]SubmissionSummary, len(submissions))
+	for i, submission := range submissions {
+		submissionData[i] = newSubmissionSummary(submission)
+	}
+
+	return c.JSON(http.StatusOK, response.APIResponse{
+		Success: true,
+		Data:    SubmissionListResponse{Submissions: submissionData, Count: len(submissions)},
+	})
+}
+
+// BuildSubmissionDetailResponse builds a response for a single submission
+func (b *FormResponseBuilderImpl) BuildSubmissionDetailResponse(
+	c echo.Context,
+	submission *model.FormSubmission,
+) error {
+	return c.JSON(http.StatusOK, response.APIResponse{
+		Success: true,
+		Data:    newSubmissionSummary(submission),
+	})
+}
+
+// BuildSubmissionPageResponse builds a response for a page of submissions,
+// limited to the requested fields
+func (b *FormResponseBuilderImpl) BuildSubmissionPageResponse(
+	c echo.Context,
+	page *common.PaginationResult,
+	req *SubmissionListRequest,
+) error {
+	submissions, _ := page.Items.([]*model.FormSubmission)
+
+	submissionData := make([]SubmissionSummary, len(submissions))
 	for i, submission := range submissions {
-		submissionData[i] = map[string]any{
-			"id":           submission.ID,
-			"form_id":      submission.FormID,
-			"status":       submission.Status,
-			"submitted_at": submission.SubmittedAt.Format(time.RFC3339),
-			"data":         submission.Data,
-		}
+		submissionData[i] = newSubmissionSummary(submission).selectFields(req.Fields)
 	}
 
 	return c.JSON(http.StatusOK, response.APIResponse{
 		Success: true,
-		Data: map[string]any{
-			"submissions": submissionData,
-			"count":       len(submissions),
+		Data: SubmissionPageResponse{
+			Submissions: submissionData,
+			Sort:        req.Sort,
+			Pagination: Pagination{
+				Page:       page.Page,
+				PageSize:   page.PageSize,
+				TotalItems: page.TotalItems,
+				TotalPages: page.TotalPages,
+			},
 		},
 	})
 }
 
+// BuildRevisionResponse builds a response for the revision an edit or restore created
+func (b *FormResponseBuilderImpl) BuildRevisionResponse(c echo.Context, rev *revision.Revision) error {
+	message := "Submission updated"
+	if rev.Action == revision.ActionRestore {
+		message = fmt.Sprintf("Revision %d restored", rev.RestoredFrom)
+	}
+
+	return c.JSON(http.StatusOK, response.APIResponse{
+		Success: true,
+		Message: message,
+		Data:    rev,
+	})
+}
+
+// BuildRevisionListResponse builds a response for a submission's history
+func (b *FormResponseBuilderImpl) BuildRevisionListResponse(c echo.Context, revisions []*revision.Revision) error {
+	if revisions == nil {
+		revisions = []*revision.Revision{}
+	}
+
+	return c.JSON(http.StatusOK, response.APIResponse{
+		Success: true,
+		Data:    RevisionListResponse{Revisions: revisions, Count: len(revisions)},
+	})
+}
+
 // BuildValidationErrorResponse builds a validation error response
 func (b *FormResponseBuilderImpl) BuildValidationErrorResponse(c echo.Context, field, message string) error {
 	return c.JSON(http.StatusBadRequest, response.APIResponse{
-		Success: false,
-		Message: "Validation failed",
-		Data: map[string]any{
-			"field":   field,
-			"message": message,
-		},
+		Success:   false,
+		Message:   "Validation failed",
+		Data:      FieldError{Field: field, Message: message},
+		RequestID: response.RequestID(c),
 	})
 }
 
@@ -140,36 +259,33 @@ func (b *FormResponseBuilderImpl) BuildMultipleErrorResponse(
 	c echo.Context,
 	errors []validation.Error,
 ) error {
-	errorData := make([]map[string]any, len(errors))
+	errorData := make([]FieldError, len(errors))
 	for i, err := range errors {
-		errorData[i] = map[string]any{
-			"field":   err.Field,
-			"message": err.Message,
-			"rule":    err.Rule,
-		}
+		errorData[i] = FieldError{Field: err.Field, Message: err.Message, Rule: err.Rule}
 	}
 
 	return c.JSON(http.StatusBadRequest, response.APIResponse{
-		Success: false,
-		Message: "Validation failed",
-		Data: map[string]any{
-			"errors": errorData,
-		},
+		Success:   false,
+		Message:   "Validation failed",
+		Data:      ValidationErrorsResponse{Errors: errorData},
+		RequestID: response.RequestID(c),
 	})
 }
 
 // BuildNotFoundResponse builds a not found response
 func (b *FormResponseBuilderImpl) BuildNotFoundResponse(c echo.Context, resource string) error {
 	return c.JSON(http.StatusNotFound, response.APIResponse{
-		Success: false,
-		Message: resource + " not found",
+		Success:   false,
+		Message:   resource + " not found",
+		RequestID: response.RequestID(c),
 	})
 }
 
 // BuildForbiddenResponse builds a forbidden response
 func (b *FormResponseBuilderImpl) BuildForbiddenResponse(c echo.Context, message string) error {
 	return c.JSON(http.StatusForbidden, response.APIResponse{
-		Success: false,
-		Message: message,
+		Success:   false,
+		Message:   message,
+		RequestID: response.RequestID(c),
 	})
 }
@@ -0,0 +1,163 @@
+package web
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/constants"
+	"github.com/goformx/goforms/internal/domain/audit"
+	domainerrors "github.com/goformx/goforms/internal/domain/common/errors"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/domain/form/revision"
+)
+
+// GET /api/v1/forms/:id/submissions/:sid
+func (h *FormAPIHandler) handleGetSubmission(c echo.Context) error {
+	form, err := h.getFormWithOwnershipOrError(c)
+	if err != nil {
+		return err
+	}
+
+	submission, err := h.RevisionService.GetSubmission(c.Request().Context(), form.ID, c.Param("sid"))
+	if err != nil {
+		return h.handleRevisionError(c, form.ID, err)
+	}
+
+	h.RecordSubmissionViewed(c, form, submission.ID)
+
+	if respErr := h.ResponseBuilder.BuildSubmissionDetailResponse(c, submission); respErr != nil {
+		return h.HandleError(c, respErr, "Failed to build response")
+	}
+
+	return nil
+}
+
+// PUT /api/v1/forms/:id/submissions/:sid
+//
+// The request body replaces the submission's data and is validated like a new
+// submission. The previous data stays available in the submission's history.
+func (h *FormAPIHandler) handleUpdateSubmission(c echo.Context) error {
+	form, err := h.getFormWithOwnershipOrError(c)
+	if err != nil {
+		return err
+	}
+
+	if validationErr := h.validateFormSchema(c, form); validationErr != nil {
+		return validationErr
+	}
+
+	data, err := h.processSubmissionRequest(c, form.ID)
+	if err != nil {
+		return err
+	}
+
+	if validationDataErr := h.validateSubmissionData(c, form, data); validationDataErr != nil {
+		return validationDataErr
+	}
+
+	userID, _ := c.Get("user_id").(string)
+
+	rev, err := h.RevisionService.Edit(c.Request().Context(), form.ID, c.Param("sid"), userID, data)
+	if err != nil {
+		return h.handleRevisionError(c, form.ID, err)
+	}
+
+	h.Logger.Info("submission edited", "form_id", form.ID, "submission_id", rev.SubmissionID, "revision", rev.Number)
+
+	return h.buildRevisionResponse(c, rev)
+}
+
+// DELETE /api/v1/forms/:id/submissions/:sid
+//
+// The submission's history is deleted with it.
+func (h *FormAPIHandler) handleDeleteSubmission(c echo.Context) error {
+	form, err := h.getFormWithOwnershipOrError(c)
+	if err != nil {
+		return err
+	}
+
+	submission, err := h.RevisionService.Delete(c.Request().Context(), form.ID, c.Param("sid"))
+	if err != nil {
+		return h.handleRevisionError(c, form.ID, err)
+	}
+
+	h.RecordAudit(c, &audit.Record{
+		Action:     audit.ActionSubmissionDeleted,
+		TargetType: audit.TargetSubmission,
+		TargetID:   submission.ID,
+		OwnerID:    form.UserID,
+		Before: model.JSON{
+			"form_id":      form.ID,
+			"submitted_at": submission.SubmittedAt.Format(time.RFC3339),
+		},
+	})
+
+	h.Logger.Info("submission deleted", "form_id", form.ID, "submission_id", submission.ID)
+
+	return fmt.Errorf("no content response: %w", c.NoContent(constants.StatusNoContent))
+}
+
+// GET /api/v1/forms/:id/submissions/:sid/revisions
+func (h *FormAPIHandler) handleListRevisions(c echo.Context) error {
+	form, err := h.getFormWithOwnershipOrError(c)
+	if err != nil {
+		return err
+	}
+
+	revisions, err := h.RevisionService.ListRevisions(c.Request().Context(), form.ID, c.Param("sid"))
+	if err != nil {
+		return h.handleRevisionError(c, form.ID, err)
+	}
+
+	if respErr := h.ResponseBuilder.BuildRevisionListResponse(c, revisions); respErr != nil {
+		return h.HandleError(c, respErr, "Failed to build response")
+	}
+
+	return nil
+}
+
+// POST /api/v1/forms/:id/submissions/:sid/revisions/:number/restore
+func (h *FormAPIHandler) handleRestoreRevision(c echo.Context) error {
+	form, err := h.getFormWithOwnershipOrError(c)
+	if err != nil {
+		return err
+	}
+
+	number, err := strconv.Atoi(c.Param("number"))
+	if err != nil || number < 1 {
+		return h.handleRevisionError(c, form.ID, revision.ErrRevisionNotFound)
+	}
+
+	userID, _ := c.Get("user_id").(string)
+
+	rev, err := h.RevisionService.Restore(c.Request().Context(), form.ID, c.Param("sid"), userID, number)
+	if err != nil {
+		return h.handleRevisionError(c, form.ID, err)
+	}
+
+	h.Logger.Info("submission revision restored",
+		"form_id", form.ID, "submission_id", rev.SubmissionID, "revision", rev.Number, "restored_from", number)
+
+	return h.buildRevisionResponse(c, rev)
+}
+
+// handleRevisionError logs unexpected revision errors and sends the error response
+func (h *FormAPIHandler) handleRevisionError(c echo.Context, formID string, err error) error {
+	if !domainerrors.IsNotFound(err) && !domainerrors.IsValidation(err) {
+		h.Logger.Error("submission revision operation failed", "form_id", formID, "error", err)
+	}
+
+	return h.wrapError("handle revision error", h.ErrorHandler.HandleRevisionError(c, err))
+}
+
+// buildRevisionResponse sends the revision that an edit or restore created
+func (h *FormAPIHandler) buildRevisionResponse(c echo.Context, rev *revision.Revision) error {
+	if respErr := h.ResponseBuilder.BuildRevisionResponse(c, rev); respErr != nil {
+		return h.HandleError(c, respErr, "Failed to build response")
+	}
+
+	return nil
+}
@@ -9,38 +9,58 @@ import (
 
 	formdomain "github.com/goformx/goforms/internal/domain/form"
 	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/domain/form/template"
 	"github.com/goformx/goforms/internal/infrastructure/logging"
 )
 
 // FormService handles form-related business logic
 type FormService struct {
-	formService formdomain.Service
-	logger      logging.Logger
+	formService     formdomain.Service
+	templateService template.Service
+	logger          logging.Logger
 }
 
 // NewFormService creates a new FormService instance
-func NewFormService(formService formdomain.Service, logger logging.Logger) *FormService {
+func NewFormService(
+	formService formdomain.Service,
+	templateService template.Service,
+	logger logging.Logger,
+) *FormService {
 	return &FormService{
-		formService: formService,
-		logger:      logger,
+		formService:     formService,
+		templateService: templateService,
+		logger:          logger,
 	}
 }
 
-// CreateForm creates a new form with the given request data
+// CreateForm creates a new form with the given request data, starting from the
+// requested template, the given schema or an empty schema
 func (s *FormService) CreateForm(ctx context.Context, userID string, req *FormCreateRequest) (*model.Form, error) {
-	schema := model.JSON{
-		"type": "object",
-		"components": []any{
-			map[string]any{
-				"type":  "button",
-				"key":   "submit",
-				"label": "Submit",
-				"input": true,
+	if req.TemplateID != "" {
+		form, err := s.templateService.CreateFormFromTemplate(ctx, userID, req.TemplateID, req.Title)
+		if err != nil {
+			return nil, fmt.Errorf("create form from template: %w", err)
+		}
+
+		return form, nil
+	}
+
+	schema := req.Schema
+	if schema == nil {
+		schema = model.JSON{
+			"type": "object",
+			"components": []any{
+				map[string]any{
+					"type":  "button",
+					"key":   "submit",
+					"label": "Submit",
+					"input": true,
+				},
 			},
-		},
+		}
 	}
 
-	form := model.NewForm(userID, req.Title, "", schema)
+	form := model.NewForm(userID, req.Title, req.Description, schema)
 
 	if err := s.formService.CreateForm(ctx, form); err != nil {
 		return nil, fmt.Errorf("create form: %w", err)
@@ -59,6 +79,18 @@ func (s *FormService) UpdateForm(ctx context.Context, form *model.Form, req *For
 		form.CorsOrigins = model.JSON{"origins": parseCSV(req.CorsOrigins)}
 	}
 
+	if req.DraftTTLHours > 0 {
+		form.DraftTTLHours = req.DraftTTLHours
+	}
+
+	if req.RetentionDays != nil {
+		form.RetentionDays = *req.RetentionDays
+	}
+
+	if req.RetentionAction != "" {
+		form.RetentionAction = req.RetentionAction
+	}
+
 	if err := s.formService.UpdateForm(ctx, form); err != nil {
 		return fmt.Errorf("update form: %w", err)
 	}
@@ -0,0 +1,33 @@
+package web
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+// GET /api/v1/forms/:id/submissions?page=&page_size=&sort=&fields=
+//
+// Submissions are listed newest first unless sort names another field, such
+// as sort=status or sort=-updated_at. fields limits each submission to the
+// listed fields, for example fields=id,submitted_at.
+func (h *FormAPIHandler) handleListSubmissions(c echo.Context) error {
+	form, err := h.getOwnedForm(c)
+	if err != nil {
+		return err
+	}
+
+	req, err := h.RequestProcessor.ProcessSubmissionListRequest(c)
+	if err != nil {
+		return h.respondError(c, invalidRequestError(err))
+	}
+
+	page, err := h.FormService.ListFormSubmissionsPaginated(c.Request().Context(), form.ID, req.Params)
+	if err != nil {
+		return h.respondError(c, err)
+	}
+
+	if respErr := h.ResponseBuilder.BuildSubmissionPageResponse(c, page, req); respErr != nil {
+		return h.HandleError(c, respErr, "Failed to build response")
+	}
+
+	return nil
+}
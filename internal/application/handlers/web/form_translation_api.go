@@ -0,0 +1,78 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/response"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/i18n"
+)
+
+// TranslationsResponse lists a form's translations and the languages they
+// may be written in
+type TranslationsResponse struct {
+	Translations map[string]model.Translation `json:"translations"`
+	Supported    []string                     `json:"supported"`
+}
+
+// GET /api/v1/forms/:id/translations
+func (h *FormAPIHandler) handleGetTranslations(c echo.Context) error {
+	form, err := h.getFormWithOwnershipOrError(c)
+	if err != nil {
+		return err
+	}
+
+	return h.buildTranslationsResponse(c, form)
+}
+
+// PUT /api/v1/forms/:id/translations
+//
+// The request body replaces all of the form's translations, keyed by locale.
+func (h *FormAPIHandler) handleUpdateTranslations(c echo.Context) error {
+	form, err := h.getFormWithOwnershipOrError(c)
+	if err != nil {
+		return err
+	}
+
+	var translations map[string]model.Translation
+	if decodeErr := json.NewDecoder(c.Request().Body).Decode(&translations); decodeErr != nil {
+		return h.wrapError("handle schema error",
+			h.ErrorHandler.HandleSchemaError(c, fmt.Errorf("decode translations: %w: %w", model.ErrFormInvalid, decodeErr)))
+	}
+
+	for lang := range translations {
+		if !i18n.IsSupported(lang) {
+			unsupportedErr := fmt.Errorf("%w: unsupported translation language %q", model.ErrFormInvalid, lang)
+
+			return h.wrapError("handle schema error", h.ErrorHandler.HandleSchemaError(c, unsupportedErr))
+		}
+	}
+
+	if setErr := form.SetTranslations(translations); setErr != nil {
+		return h.wrapError("handle schema error", h.ErrorHandler.HandleSchemaError(c, setErr))
+	}
+
+	if updateErr := h.updateFormSchema(c, form, form.Schema); updateErr != nil {
+		return updateErr
+	}
+
+	h.Logger.Info("form translations updated", "form_id", form.ID, "languages", form.Languages())
+
+	return h.buildTranslationsResponse(c, form)
+}
+
+// buildTranslationsResponse responds with the form's translations
+func (h *FormAPIHandler) buildTranslationsResponse(c echo.Context, form *model.Form) error {
+	translations := form.Translations()
+	if translations == nil {
+		translations = map[string]model.Translation{}
+	}
+
+	return response.Success(c, TranslationsResponse{
+		Translations: translations,
+		Supported:    i18n.Supported,
+	})
+}
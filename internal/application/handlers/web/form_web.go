@@ -10,11 +10,15 @@ import (
 
 	"github.com/goformx/goforms/internal/application/constants"
 	"github.com/goformx/goforms/internal/application/middleware/access"
+	"github.com/goformx/goforms/internal/application/middleware/locale"
 	"github.com/goformx/goforms/internal/application/response"
 	"github.com/goformx/goforms/internal/application/validation"
 	formdomain "github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/form/revision"
+	"github.com/goformx/goforms/internal/domain/form/template"
 	"github.com/goformx/goforms/internal/infrastructure/sanitization"
 	"github.com/goformx/goforms/internal/presentation/templates/pages"
+	"github.com/goformx/goforms/internal/presentation/view"
 )
 
 // Default CORS settings for forms
@@ -31,14 +35,20 @@ type FormWebHandler struct {
 	ResponseBuilder  FormResponseBuilder
 	ErrorHandler     FormErrorHandler
 	FormService      *FormService
+	TemplateService  template.Service
+	RevisionService  revision.Service
 	AuthHelper       *AuthHelper
+	SchemaGenerator  *validation.SchemaGenerator
 }
 
 // NewFormWebHandler creates a new FormWebHandler instance
 func NewFormWebHandler(
 	base *BaseHandler,
 	formService formdomain.Service,
+	templateService template.Service,
+	revisionService revision.Service,
 	formValidator *validation.FormValidator,
+	schemaGenerator *validation.SchemaGenerator,
 	sanitizer sanitization.ServiceInterface,
 ) *FormWebHandler {
 	// Create base handler
@@ -48,7 +58,7 @@ func NewFormWebHandler(
 	requestProcessor := NewFormRequestProcessor(sanitizer, formValidator)
 	responseBuilder := NewFormResponseBuilder()
 	errorHandler := NewFormErrorHandler(responseBuilder)
-	formServiceHandler := NewFormService(formService, base.Logger)
+	formServiceHandler := NewFormService(formService, templateService, base.Logger)
 	authHelper := NewAuthHelper(formBaseHandler)
 
 	return &FormWebHandler{
@@ -58,7 +68,10 @@ func NewFormWebHandler(
 		ResponseBuilder:  responseBuilder,
 		ErrorHandler:     errorHandler,
 		FormService:      formServiceHandler,
+		TemplateService:  templateService,
+		RevisionService:  revisionService,
 		AuthHelper:       authHelper,
+		SchemaGenerator:  schemaGenerator,
 	}
 }
 
@@ -74,6 +87,7 @@ func (h *FormWebHandler) RegisterRoutes(e *echo.Echo, accessManager *access.Mana
 	forms.PUT("/:id", h.handleUpdate)
 	forms.DELETE("/:id", h.handleDelete)
 	forms.GET("/:id/submissions", h.handleSubmissions)
+	forms.GET("/:id/submissions/:sid", h.handleSubmission)
 	forms.GET("/:id/preview", h.handlePreview)
 
 	// API routes with validation
@@ -143,7 +157,9 @@ func (h *FormWebHandler) handlePreview(c echo.Context) error {
 		WithFormPreviewAssetPath(h.AssetManager.AssetPath("src/js/pages/form-preview.ts"))
 
 	// Render form preview template
-	if renderErr := h.Renderer.Render(c, pages.FormPreview(*data, form)); renderErr != nil {
+	embed := view.NewEmbedCode(publicBaseURL(c, h.Config.App.URL), form.ID)
+
+	if renderErr := h.Renderer.Render(c, pages.FormPreview(*data, form, embed)); renderErr != nil {
 		return fmt.Errorf("failed to render form preview page: %w", renderErr)
 	}
 
@@ -152,12 +168,7 @@ func (h *FormWebHandler) handlePreview(c echo.Context) error {
 
 // handleNewFormValidation returns the validation schema for the new form
 func (h *FormWebHandler) handleNewFormValidation(c echo.Context) error {
-	schema := map[string]any{
-		"title": map[string]any{
-			"type":    "required",
-			"message": "Form title is required",
-		},
-	}
+	schema := h.SchemaGenerator.GenerateValidationSchema(FormCreateRequest{}, locale.Get(c))
 
 	return response.Success(c, schema)
 }
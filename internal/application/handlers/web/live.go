@@ -0,0 +1,218 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/constants"
+	"github.com/goformx/goforms/internal/application/live"
+	"github.com/goformx/goforms/internal/application/middleware/access"
+	mwcontext "github.com/goformx/goforms/internal/application/middleware/context"
+	"github.com/goformx/goforms/internal/application/response"
+	"github.com/goformx/goforms/internal/domain/common/events"
+)
+
+// headerLastEventID is sent by a reconnecting EventSource with the ID of the
+// last message it received
+const headerLastEventID = "Last-Event-ID"
+
+// errLiveFormNotFound is returned when a stream asks for a form the user does not own
+var errLiveFormNotFound = errors.New("form not found")
+
+// LiveHandler streams new submissions and submission counts to the dashboard
+// and submissions pages over Server-Sent Events. A stream only carries the
+// activity of the signed-in user's own forms.
+type LiveHandler struct {
+	*BaseHandler
+	Hub           *live.Hub
+	EventBus      events.EventBus
+	AccessManager *access.Manager
+}
+
+// NewLiveHandler creates a new LiveHandler
+func NewLiveHandler(
+	base *BaseHandler,
+	hub *live.Hub,
+	eventBus events.EventBus,
+	accessManager *access.Manager,
+) *LiveHandler {
+	return &LiveHandler{
+		BaseHandler:   base,
+		Hub:           hub,
+		EventBus:      eventBus,
+		AccessManager: accessManager,
+	}
+}
+
+// RegisterRoutes registers the live feed routes
+func (h *LiveHandler) RegisterRoutes(e *echo.Echo) {
+	api := e.Group(constants.PathAPILive)
+	api.Use(access.Middleware(h.AccessManager, h.Logger))
+	api.GET("/submissions", h.handleSubmissions)
+}
+
+// GET /api/v1/live/submissions
+//
+// Streams the submissions to the user's forms, or to one form when form_id is
+// set. A new stream starts with the current counts; a reconnecting client that
+// sends Last-Event-ID receives the messages it missed instead.
+func (h *LiveHandler) handleSubmissions(c echo.Context) error {
+	if !h.Config.Live.Enabled {
+		return response.ErrorResponse(c, http.StatusNotFound, "Live updates are disabled")
+	}
+
+	userID, ok := mwcontext.GetUserID(c)
+	if !ok {
+		return response.ErrorResponse(c, http.StatusUnauthorized, "Sign in to follow submissions")
+	}
+
+	ctx := c.Request().Context()
+	formID := c.QueryParam("form_id")
+
+	formIDs, err := h.streamedForms(ctx, userID, formID)
+	switch {
+	case errors.Is(err, errLiveFormNotFound):
+		return response.ErrorResponse(c, http.StatusNotFound, "Form not found")
+	case err != nil:
+		h.Logger.Error("failed to list forms for live feed", "user_id", userID, "error", err)
+
+		return response.ErrorResponse(c, http.StatusInternalServerError, "Failed to open live updates")
+	}
+
+	stream, err := h.Hub.Open(userID, formID, c.Request().Header.Get(headerLastEventID))
+	switch {
+	case errors.Is(err, live.ErrTooManyStreams):
+		return response.ErrorResponse(c, http.StatusTooManyRequests, "Too many live update streams open")
+	case err != nil:
+		return response.ErrorResponse(c, http.StatusServiceUnavailable, "Live updates are unavailable")
+	}
+	defer stream.Close()
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, live.ContentType)
+	res.Header().Set(echo.HeaderCacheControl, "no-cache")
+	// Stop nginx from buffering the stream
+	res.Header().Set("X-Accel-Buffering", "no")
+	res.WriteHeader(http.StatusOK)
+
+	w := live.NewWriter(res, h.Config.Live.HeartbeatInterval+h.Config.App.WriteTimeout)
+
+	if err = h.stream(ctx, w, stream, formIDs); err != nil {
+		h.Logger.Debug("live stream ended", "user_id", userID, "error", err)
+	}
+
+	return nil
+}
+
+// stream writes to w until the client goes away or the stream is closed. It
+// starts with the reconnect delay, then either the current counts or, for a
+// resumed stream, the messages the client missed.
+func (h *LiveHandler) stream(ctx context.Context, w *live.Writer, stream *live.Stream, formIDs []string) error {
+	if err := h.startStream(ctx, w, stream, formIDs); err != nil {
+		return err
+	}
+
+	heartbeat := time.NewTicker(h.Config.Live.HeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		var err error
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-stream.Done():
+			// The hub stopped or the client fell behind; the browser reconnects
+			// and catches up from its Last-Event-ID
+			return nil
+		case msg := <-stream.Messages():
+			err = w.Send(&msg)
+		case <-heartbeat.C:
+			err = w.Ping()
+		}
+
+		if err != nil {
+			return fmt.Errorf("write live message: %w", err)
+		}
+	}
+}
+
+// startStream sends the first messages of a stream
+func (h *LiveHandler) startStream(ctx context.Context, w *live.Writer, stream *live.Stream, formIDs []string) error {
+	if err := w.Retry(h.Config.Live.RetryInterval); err != nil {
+		return fmt.Errorf("write retry interval: %w", err)
+	}
+
+	if !stream.Resumed {
+		counts, err := h.FormService.CountFormSubmissions(ctx, formIDs)
+		if err != nil {
+			return fmt.Errorf("count submissions: %w", err)
+		}
+
+		if err = w.Send(&live.Message{
+			ID:    stream.Cursor,
+			Event: live.EventCounts,
+			Data:  live.CountsData{Counts: counts},
+		}); err != nil {
+			return fmt.Errorf("write counts: %w", err)
+		}
+	}
+
+	for i := range stream.Backlog {
+		if err := w.Send(&stream.Backlog[i]); err != nil {
+			return fmt.Errorf("write missed message: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// streamedForms returns the IDs of the forms a stream covers: the one form
+// when formID is set, or every form of the user
+func (h *LiveHandler) streamedForms(ctx context.Context, userID, formID string) ([]string, error) {
+	if formID != "" {
+		form, err := h.FormService.GetForm(ctx, formID)
+		if err != nil || form == nil || form.UserID != userID {
+			return nil, errLiveFormNotFound
+		}
+
+		return []string{form.ID}, nil
+	}
+
+	forms, err := h.FormService.ListForms(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list forms: %w", err)
+	}
+
+	formIDs := make([]string, 0, len(forms))
+	for _, form := range forms {
+		formIDs = append(formIDs, form.ID)
+	}
+
+	return formIDs, nil
+}
+
+// Start subscribes the live hub to form events
+func (h *LiveHandler) Start(ctx context.Context) error {
+	if !h.Config.Live.Enabled {
+		return nil
+	}
+
+	if err := h.Hub.Subscribe(ctx, h.EventBus); err != nil {
+		return fmt.Errorf("subscribe live hub: %w", err)
+	}
+
+	return nil
+}
+
+// Stop closes the open live streams
+func (h *LiveHandler) Stop(_ context.Context) error {
+	h.Hub.Stop()
+
+	return nil
+}
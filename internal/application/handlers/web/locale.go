@@ -0,0 +1,62 @@
+package web
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/constants"
+	mwcontext "github.com/goformx/goforms/internal/application/middleware/context"
+	"github.com/goformx/goforms/internal/application/middleware/locale"
+	"github.com/goformx/goforms/internal/infrastructure/i18n"
+)
+
+// GET /locale/:locale?redirect=
+//
+// handleLocale switches the interface language. The choice is remembered in a
+// cookie and, for a signed-in user, saved as their preference so that it
+// follows them to other devices. The user is sent back to the redirect path,
+// which must be local to the site.
+func (h *PageHandler) handleLocale(c echo.Context) error {
+	selected := c.Param("locale")
+	if !i18n.IsSupported(selected) {
+		return h.HandleNotFound(c, "Unsupported language")
+	}
+
+	locale.Remember(c, selected)
+
+	if userID, ok := mwcontext.GetUserID(c); ok {
+		h.saveUserLocale(c, userID, selected)
+	}
+
+	return fmt.Errorf("redirect after language change: %w",
+		c.Redirect(http.StatusSeeOther, localRedirect(c.QueryParam("redirect"))))
+}
+
+// saveUserLocale stores the language as the user's preference. A failure is
+// logged, as the cookie already applies the choice.
+func (h *PageHandler) saveUserLocale(c echo.Context, userID, selected string) {
+	ctx := c.Request().Context()
+
+	userEntity, err := h.UserService.GetUserByID(ctx, userID)
+	if err != nil || userEntity == nil || userEntity.Locale == selected {
+		return
+	}
+
+	userEntity.Locale = selected
+	if updateErr := h.UserService.UpdateUser(ctx, userEntity); updateErr != nil {
+		h.Logger.Warn("failed to save language preference", "user_id", userID, "error", updateErr)
+	}
+}
+
+// localRedirect returns target when it is a path on this site, and the home
+// page otherwise, so the language switcher cannot be used as an open redirect
+func localRedirect(target string) string {
+	if !strings.HasPrefix(target, "/") || strings.HasPrefix(target, "//") || strings.ContainsAny(target, "\\\r\n") {
+		return constants.PathHome
+	}
+
+	return target
+}
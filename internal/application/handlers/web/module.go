@@ -9,17 +9,24 @@ import (
 
 	"github.com/labstack/echo/v4"
 
+	"github.com/goformx/goforms/internal/application/live"
 	"github.com/goformx/goforms/internal/application/middleware"
 	"github.com/goformx/goforms/internal/application/middleware/access"
 	"github.com/goformx/goforms/internal/application/middleware/auth"
 	"github.com/goformx/goforms/internal/application/middleware/request"
 	"github.com/goformx/goforms/internal/application/middleware/session"
 	"github.com/goformx/goforms/internal/application/validation"
+	"github.com/goformx/goforms/internal/domain/common/events"
 	"github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/form/draft"
+	"github.com/goformx/goforms/internal/domain/form/retention"
+	"github.com/goformx/goforms/internal/domain/form/revision"
+	"github.com/goformx/goforms/internal/domain/form/template"
 	"github.com/goformx/goforms/internal/domain/user"
 	"github.com/goformx/goforms/internal/infrastructure/config"
 	"github.com/goformx/goforms/internal/infrastructure/logging"
 	"github.com/goformx/goforms/internal/infrastructure/sanitization"
+	"github.com/goformx/goforms/internal/infrastructure/server"
 	"github.com/goformx/goforms/internal/presentation/view"
 
 	"github.com/goformx/goforms/internal/application/constants"
@@ -32,7 +39,7 @@ var Module = fx.Module("web-handlers",
 		// Base handler for common functionality
 		fx.Annotate(
 			NewBaseHandler,
-			fx.ParamTags(``, ``, ``, ``, ``, ``, ``, ``),
+			fx.ParamTags(``, ``, ``, ``, ``, ``, ``, ``, ``),
 		),
 
 		// Auth components for SRP compliance
@@ -43,6 +50,9 @@ var Module = fx.Module("web-handlers",
 		),
 		NewAuthService,
 
+		// Hub fanning form activity out to the live feed streams
+		live.NewHub,
+
 		// Legacy HandlerDeps for backward compatibility
 		fx.Annotate(
 			func(
@@ -97,15 +107,28 @@ var Module = fx.Module("web-handlers",
 			fx.ResultTags(`group:"handlers"`),
 		),
 
+		// Public form handler - public access
+		fx.Annotate(
+			func(base *BaseHandler) (Handler, error) {
+				return NewPublicFormHandler(base)
+			},
+			fx.ResultTags(`group:"handlers"`),
+		),
+
 		// Form Web handler - authenticated access
 		fx.Annotate(
 			func(
 				base *BaseHandler,
 				formService form.Service,
+				templateService template.Service,
+				revisionService revision.Service,
 				formValidator *validation.FormValidator,
+				schemaGenerator *validation.SchemaGenerator,
 				sanitizer sanitization.ServiceInterface,
 			) (Handler, error) {
-				return NewFormWebHandler(base, formService, formValidator, sanitizer), nil
+				return NewFormWebHandler(
+					base, formService, templateService, revisionService, formValidator, schemaGenerator, sanitizer,
+				), nil
 			},
 			fx.ResultTags(`group:"handlers"`),
 		),
@@ -115,11 +138,17 @@ var Module = fx.Module("web-handlers",
 			func(
 				base *BaseHandler,
 				formService form.Service,
+				templateService template.Service,
+				draftService draft.Service,
+				revisionService revision.Service,
 				accessManager *access.Manager,
 				formValidator *validation.FormValidator,
 				sanitizer sanitization.ServiceInterface,
 			) (Handler, error) {
-				return NewFormAPIHandler(base, formService, accessManager, formValidator, sanitizer), nil
+				return NewFormAPIHandler(
+					base, formService, templateService, draftService, revisionService, accessManager,
+					formValidator, sanitizer,
+				), nil
 			},
 			fx.ResultTags(`group:"handlers"`),
 		),
@@ -131,6 +160,43 @@ var Module = fx.Module("web-handlers",
 			},
 			fx.ResultTags(`group:"handlers"`),
 		),
+
+		// Audit log handler - authenticated access
+		fx.Annotate(
+			func(base *BaseHandler, accessManager *access.Manager, authMiddleware *auth.Middleware) (Handler, error) {
+				return NewAuditHandler(base, accessManager, authMiddleware), nil
+			},
+			fx.ResultTags(`group:"handlers"`),
+		),
+
+		// Submitter erasure handler - authenticated access
+		fx.Annotate(
+			func(base *BaseHandler, retentionService retention.Service, accessManager *access.Manager) (Handler, error) {
+				return NewErasureHandler(base, retentionService, accessManager), nil
+			},
+			fx.ResultTags(`group:"handlers"`),
+		),
+
+		// Live submissions feed handler - authenticated access
+		fx.Annotate(
+			func(
+				base *BaseHandler,
+				hub *live.Hub,
+				eventBus events.EventBus,
+				accessManager *access.Manager,
+			) (Handler, error) {
+				return NewLiveHandler(base, hub, eventBus, accessManager), nil
+			},
+			fx.ResultTags(`group:"handlers"`),
+		),
+
+		// API docs handler - public access
+		fx.Annotate(
+			func(cfg *config.Config) (Handler, error) {
+				return NewAPIDocsHandler(cfg), nil
+			},
+			fx.ResultTags(`group:"handlers"`),
+		),
 	),
 
 	// Lifecycle hooks
@@ -163,6 +229,11 @@ var Module = fx.Module("web-handlers",
 		},
 		fx.ParamTags(``, `group:"handlers"`),
 	)),
+
+	// End the live streams when the server shuts down, so they don't hold it up
+	fx.Invoke(func(srv *server.Server, hub *live.Hub) {
+		srv.RegisterOnShutdown(hub.Stop)
+	}),
 )
 
 // RouteRegistrar handles route registration for all handlers
@@ -208,6 +279,16 @@ func (rr *RouteRegistrar) registerHandlerRoutes(e *echo.Echo, handler Handler) {
 		rr.registerFormAPIRoutes(e, h)
 	case *DashboardHandler:
 		rr.registerDashboardRoutes(e, h)
+	case *PublicFormHandler:
+		rr.registerPublicFormRoutes(e, h)
+	case *AuditHandler:
+		rr.registerAuditRoutes(e, h)
+	case *ErasureHandler:
+		rr.registerErasureRoutes(e, h)
+	case *LiveHandler:
+		rr.registerLiveRoutes(e, h)
+	case *APIDocsHandler:
+		rr.registerAPIDocsRoutes(e, h)
 	}
 }
 
@@ -231,6 +312,7 @@ func (rr *RouteRegistrar) registerAuthRoutes(e *echo.Echo, h *AuthHandler) {
 func (rr *RouteRegistrar) registerWebRoutes(e *echo.Echo, h *PageHandler) {
 	e.GET(constants.PathHome, h.handleHome)
 	e.GET(constants.PathDemo, h.handleDemo)
+	e.GET(constants.PathLocale+"/:locale", h.handleLocale)
 }
 
 // registerFormWebRoutes registers form web UI routes
@@ -243,6 +325,31 @@ func (rr *RouteRegistrar) registerFormAPIRoutes(e *echo.Echo, h *FormAPIHandler)
 	h.RegisterRoutes(e)
 }
 
+// registerPublicFormRoutes registers the hosted and embedded form routes
+func (rr *RouteRegistrar) registerPublicFormRoutes(e *echo.Echo, h *PublicFormHandler) {
+	h.RegisterRoutes(e)
+}
+
+// registerAuditRoutes registers the audit log viewer and API routes
+func (rr *RouteRegistrar) registerAuditRoutes(e *echo.Echo, h *AuditHandler) {
+	h.RegisterRoutes(e)
+}
+
+// registerErasureRoutes registers the submitter erasure API routes
+func (rr *RouteRegistrar) registerErasureRoutes(e *echo.Echo, h *ErasureHandler) {
+	h.RegisterRoutes(e)
+}
+
+// registerLiveRoutes registers the live submissions feed routes
+func (rr *RouteRegistrar) registerLiveRoutes(e *echo.Echo, h *LiveHandler) {
+	h.RegisterRoutes(e)
+}
+
+// registerAPIDocsRoutes registers the OpenAPI document and docs viewer routes
+func (rr *RouteRegistrar) registerAPIDocsRoutes(e *echo.Echo, h *APIDocsHandler) {
+	h.RegisterRoutes(e)
+}
+
 // registerDashboardRoutes registers dashboard routes
 func (rr *RouteRegistrar) registerDashboardRoutes(e *echo.Echo, h *DashboardHandler) {
 	dashboard := e.Group(constants.PathDashboard)
@@ -0,0 +1,218 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/constants"
+	"github.com/goformx/goforms/internal/application/middleware/locale"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/presentation/templates/pages"
+	"github.com/goformx/goforms/internal/presentation/view"
+)
+
+// embedScriptCacheControl lets browsers and CDNs briefly cache the embed script
+const embedScriptCacheControl = "public, max-age=300"
+
+// embedScriptTemplate inserts an iframe showing the form after the script tag and
+// resizes it whenever the embedded page reports a new height. A data-lang
+// attribute on the script tag picks the form's language.
+const embedScriptTemplate = `(function () {
+  var formURL = %s;
+  var formID = %s;
+  var script = document.currentScript;
+  if (!script || !script.parentNode) {
+    return;
+  }
+  var frame = document.createElement("iframe");
+  var lang = script.getAttribute("data-lang");
+  frame.src = formURL + "?embed=1" + (lang ? "&lang=" + encodeURIComponent(lang) : "");
+  frame.title = %s;
+  frame.setAttribute("scrolling", "no");
+  frame.style.width = "100%%";
+  frame.style.height = "600px";
+  frame.style.border = "0";
+  script.parentNode.insertBefore(frame, script.nextSibling);
+  var origin = new URL(formURL).origin;
+  window.addEventListener("message", function (event) {
+    if (event.origin !== origin || event.source !== frame.contentWindow) {
+      return;
+    }
+    var data = event.data || {};
+    if (data.type === "goforms:resize" && data.formId === formID && data.height > 0) {
+      frame.style.height = Math.ceil(data.height) + "px";
+    }
+  });
+})();
+`
+
+// PublicFormHandler serves published forms to anonymous visitors, either as a
+// hosted page or embedded in another site
+type PublicFormHandler struct {
+	*BaseHandler
+}
+
+// NewPublicFormHandler creates a new public form handler
+func NewPublicFormHandler(base *BaseHandler) (*PublicFormHandler, error) {
+	if base == nil {
+		return nil, errors.New("base handler cannot be nil")
+	}
+
+	return &PublicFormHandler{BaseHandler: base}, nil
+}
+
+// RegisterRoutes registers the public form routes
+func (h *PublicFormHandler) RegisterRoutes(e *echo.Echo) {
+	forms := e.Group(constants.PathPublicForms)
+	forms.GET("/:id", h.handlePublicForm)
+	forms.GET("/:id/embed.js", h.handleEmbedScript)
+}
+
+// Start initializes the public form handler.
+func (h *PublicFormHandler) Start(_ context.Context) error {
+	return nil // No initialization needed
+}
+
+// Stop cleans up any resources used by the public form handler.
+func (h *PublicFormHandler) Stop(_ context.Context) error {
+	return nil // No cleanup needed
+}
+
+// GET /f/:id
+func (h *PublicFormHandler) handlePublicForm(c echo.Context) error {
+	form := h.getPublishedForm(c)
+	if form == nil {
+		return h.HandleNotFound(c, "Form not found")
+	}
+
+	embedded := c.QueryParam("embed") == "1"
+	if embedded {
+		allowFraming(c.Response().Header(), form)
+	}
+
+	localized := form.Localized(locale.Get(c))
+	data := h.NewPageData(c, localized.Title).WithForm(localized)
+
+	if renderErr := h.Renderer.Render(c, pages.PublicForm(*data, localized, embedded)); renderErr != nil {
+		return fmt.Errorf("failed to render public form page: %w", renderErr)
+	}
+
+	return nil
+}
+
+// GET /f/:id/embed.js
+func (h *PublicFormHandler) handleEmbedScript(c echo.Context) error {
+	form := h.getPublishedForm(c)
+	if form == nil {
+		if blobErr := c.Blob(http.StatusNotFound, "application/javascript; charset=utf-8",
+			[]byte("// form not available\n")); blobErr != nil {
+			return fmt.Errorf("send embed script not found: %w", blobErr)
+		}
+
+		return nil
+	}
+
+	script, err := embedScript(publicBaseURL(c, h.Config.App.URL), form)
+	if err != nil {
+		return h.HandleError(c, err, "Failed to build embed script")
+	}
+
+	c.Response().Header().Set("Cache-Control", embedScriptCacheControl)
+
+	if blobErr := c.Blob(http.StatusOK, "application/javascript; charset=utf-8", script); blobErr != nil {
+		return fmt.Errorf("send embed script: %w", blobErr)
+	}
+
+	return nil
+}
+
+// getPublishedForm returns the requested form, or nil when it does not exist or
+// is not published. Drafts and archived forms look the same as missing ones.
+func (h *PublicFormHandler) getPublishedForm(c echo.Context) *model.Form {
+	formID := c.Param("id")
+
+	form, err := h.FormService.GetForm(c.Request().Context(), formID)
+	if err != nil || form == nil {
+		h.Logger.Debug("public form not found", "form_id", h.Logger.SanitizeField("form_id", formID))
+
+		return nil
+	}
+
+	if !form.IsPublished() {
+		h.Logger.Debug("public form is not published", "form_id", form.ID, "status", form.Status)
+
+		return nil
+	}
+
+	return form
+}
+
+// embedScript renders the embed script for a form
+func embedScript(baseURL string, form *model.Form) ([]byte, error) {
+	formURL, err := json.Marshal(view.PublicFormURL(baseURL, form.ID))
+	if err != nil {
+		return nil, fmt.Errorf("encode form URL: %w", err)
+	}
+
+	formID, err := json.Marshal(form.ID)
+	if err != nil {
+		return nil, fmt.Errorf("encode form ID: %w", err)
+	}
+
+	title, err := json.Marshal(form.Title)
+	if err != nil {
+		return nil, fmt.Errorf("encode form title: %w", err)
+	}
+
+	return fmt.Appendf(nil, embedScriptTemplate, formURL, formID, title), nil
+}
+
+// publicBaseURL returns the configured application URL, falling back to the
+// scheme and host of the current request
+func publicBaseURL(c echo.Context, configured string) string {
+	if configured != "" {
+		return strings.TrimSuffix(configured, "/")
+	}
+
+	return c.Scheme() + "://" + c.Request().Host
+}
+
+// allowFraming lets the sites listed in the form's CORS origins show the page in
+// an iframe. It replaces X-Frame-Options with a CSP frame-ancestors directive.
+func allowFraming(header http.Header, form *model.Form) {
+	header.Del(echo.HeaderXFrameOptions)
+
+	directive := "frame-ancestors " + strings.Join(frameAncestors(form), " ")
+	if csp := header.Get(echo.HeaderContentSecurityPolicy); csp != "" {
+		directive = csp + "; " + directive
+	}
+
+	header.Set(echo.HeaderContentSecurityPolicy, directive)
+}
+
+// frameAncestors lists the sources allowed to frame a form. Origins that could
+// break out of the directive are skipped.
+func frameAncestors(form *model.Form) []string {
+	sources := []string{"'self'"}
+
+	origins, _, _ := form.GetCorsConfig()
+	for _, origin := range origins {
+		if origin == "*" {
+			return []string{"*"}
+		}
+
+		if origin == "" || strings.ContainsAny(origin, " \t\r\n;,'\"") {
+			continue
+		}
+
+		sources = append(sources, strings.TrimSuffix(origin, "/"))
+	}
+
+	return sources
+}
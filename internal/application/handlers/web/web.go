@@ -45,6 +45,7 @@ func NewPageHandler(base *BaseHandler, authMiddleware *auth.Middleware) (*PageHa
 func (h *PageHandler) Register(e *echo.Echo) {
 	e.GET("/", h.handleHome)
 	e.GET("/demo", h.handleDemo)
+	e.GET(constants.PathLocale+"/:locale", h.handleLocale)
 }
 
 // handleHome handles the home page request
@@ -0,0 +1,362 @@
+// Package live streams form activity to the signed-in user's dashboard as it
+// happens. The Hub turns form domain events into messages, keeps a short
+// history so reconnecting clients can catch up, and fans each message out to
+// the streams of the user who owns the form.
+package live
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goformx/goforms/internal/domain/common/events"
+	"github.com/goformx/goforms/internal/domain/form"
+	formevents "github.com/goformx/goforms/internal/domain/form/events"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+// Message event names, sent as the SSE "event" field
+const (
+	// EventSubmission announces a new submission and the form's new total
+	EventSubmission = "submission"
+	// EventProcessed announces that a submission passed validation
+	EventProcessed = "processed"
+	// EventCounts carries the submission totals of the streamed forms
+	EventCounts = "counts"
+)
+
+var (
+	// ErrTooManyStreams is returned when a user already has the maximum number of open streams
+	ErrTooManyStreams = errors.New("too many live streams open")
+	// ErrHubStopped is returned when a stream is opened after the hub stopped
+	ErrHubStopped = errors.New("live hub stopped")
+)
+
+// Message is one update sent to a stream
+type Message struct {
+	// ID identifies the message for Last-Event-ID. It is empty for snapshots.
+	ID    string
+	Event string
+	Data  any
+
+	seq    uint64
+	userID string
+	formID string
+}
+
+// SubmissionData is the data of an EventSubmission message. It carries no
+// answers, only what the dashboard lists.
+type SubmissionData struct {
+	FormID      string                 `json:"form_id"`
+	ID          string                 `json:"id"`
+	Status      model.SubmissionStatus `json:"status"`
+	SubmittedAt time.Time              `json:"submitted_at"`
+	Count       int64                  `json:"count"`
+}
+
+// ProcessedData is the data of an EventProcessed message
+type ProcessedData struct {
+	FormID       string `json:"form_id"`
+	SubmissionID string `json:"submission_id"`
+}
+
+// CountsData is the data of an EventCounts message, keyed by form ID
+type CountsData struct {
+	Counts map[string]int64 `json:"counts"`
+}
+
+// Stream is one client's subscription to the hub
+type Stream struct {
+	// Backlog holds the messages published since the client's Last-Event-ID
+	Backlog []Message
+	// Resumed reports whether Backlog covers everything the client missed.
+	// When it is false the client needs a fresh snapshot.
+	Resumed bool
+	// Cursor is the ID of the last message published before the stream opened
+	Cursor string
+
+	hub      *Hub
+	userID   string
+	formID   string
+	messages chan Message
+	done     chan struct{}
+}
+
+// Messages returns the channel new messages arrive on
+func (s *Stream) Messages() <-chan Message {
+	return s.messages
+}
+
+// Done is closed when the stream is closed, either by the client going away,
+// by the hub stopping, or because the client fell too far behind
+func (s *Stream) Done() <-chan struct{} {
+	return s.done
+}
+
+// Close removes the stream from the hub
+func (s *Stream) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+
+	s.hub.remove(s)
+}
+
+// matches reports whether msg is meant for the stream
+func (s *Stream) matches(msg *Message) bool {
+	return msg.userID == s.userID && (s.formID == "" || msg.formID == s.formID)
+}
+
+// Hub fans live messages out to the open streams
+type Hub struct {
+	cfg    config.LiveConfig
+	forms  form.Service
+	logger logging.Logger
+
+	// epoch changes on every start, so IDs from an earlier process or another
+	// instance are never mistaken for positions in this history
+	epoch string
+
+	mu      sync.Mutex
+	seq     uint64
+	history []Message
+	streams map[*Stream]struct{}
+	perUser map[string]int
+	stopped bool
+}
+
+// NewHub creates a new live hub
+func NewHub(cfg config.LiveConfig, forms form.Service, logger logging.Logger) *Hub {
+	return &Hub{
+		cfg:     cfg,
+		forms:   forms,
+		logger:  logger,
+		epoch:   strconv.FormatInt(time.Now().UnixNano(), 36),
+		streams: make(map[*Stream]struct{}),
+		perUser: make(map[string]int),
+	}
+}
+
+// Subscribe registers the hub's handlers with bus. When the bus fans events out
+// across instances the hub receives the submissions made on every instance.
+func (h *Hub) Subscribe(ctx context.Context, bus events.Subscriber) error {
+	subscribe := bus.Subscribe
+	if cluster, ok := bus.(events.ClusterSubscriber); ok {
+		subscribe = cluster.SubscribeCluster
+	}
+
+	handlers := map[formevents.EventType]func(context.Context, events.Event) error{
+		formevents.FormSubmittedEventType: h.handleSubmitted,
+		formevents.FormProcessedEventType: h.handleProcessed,
+	}
+
+	for eventType, handler := range handlers {
+		if err := subscribe(ctx, string(eventType), handler); err != nil {
+			return fmt.Errorf("subscribe to %s: %w", eventType, err)
+		}
+	}
+
+	return nil
+}
+
+// handleSubmitted publishes a new submission with its form's new total
+func (h *Hub) handleSubmitted(ctx context.Context, event events.Event) error {
+	submission, ok := event.Payload().(*model.FormSubmission)
+	if !ok || submission == nil {
+		return nil
+	}
+
+	ownerID, ok := h.owner(ctx, submission.FormID)
+	if !ok {
+		return nil
+	}
+
+	counts, err := h.forms.CountFormSubmissions(ctx, []string{submission.FormID})
+	if err != nil {
+		h.logger.Warn("failed to count submissions for live feed", "form_id", submission.FormID, "error", err)
+	}
+
+	h.publish(&Message{
+		Event: EventSubmission,
+		Data: SubmissionData{
+			FormID:      submission.FormID,
+			ID:          submission.ID,
+			Status:      submission.Status,
+			SubmittedAt: submission.SubmittedAt,
+			Count:       counts[submission.FormID],
+		},
+		userID: ownerID,
+		formID: submission.FormID,
+	})
+
+	return nil
+}
+
+// handleProcessed publishes that a submission passed validation
+func (h *Hub) handleProcessed(ctx context.Context, event events.Event) error {
+	payload, ok := event.Payload().(*formevents.FormProcessedPayload)
+	if !ok || payload == nil {
+		return nil
+	}
+
+	ownerID, ok := h.owner(ctx, payload.FormID)
+	if !ok {
+		return nil
+	}
+
+	h.publish(&Message{
+		Event:  EventProcessed,
+		Data:   ProcessedData{FormID: payload.FormID, SubmissionID: payload.ProcessingID},
+		userID: ownerID,
+		formID: payload.FormID,
+	})
+
+	return nil
+}
+
+// owner returns the ID of the user who owns a form
+func (h *Hub) owner(ctx context.Context, formID string) (string, bool) {
+	f, err := h.forms.GetForm(ctx, formID)
+	if err != nil || f == nil {
+		h.logger.Debug("live feed skipped event for unknown form", "form_id", formID, "error", err)
+
+		return "", false
+	}
+
+	return f.UserID, true
+}
+
+// Open opens a stream of the messages for a user's forms, or for one form when
+// formID is set. lastEventID is the ID of the last message the client received
+// on an earlier stream; the messages it missed since are returned in the
+// stream's Backlog when the history still holds them.
+func (h *Hub) Open(userID, formID, lastEventID string) (*Stream, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.stopped {
+		return nil, ErrHubStopped
+	}
+
+	if h.cfg.MaxStreamsPerUser > 0 && h.perUser[userID] >= h.cfg.MaxStreamsPerUser {
+		return nil, ErrTooManyStreams
+	}
+
+	stream := &Stream{
+		Cursor:   h.id(h.seq),
+		hub:      h,
+		userID:   userID,
+		formID:   formID,
+		messages: make(chan Message, h.cfg.ClientBuffer),
+		done:     make(chan struct{}),
+	}
+
+	if lastSeq, ok := h.resumeFrom(lastEventID); ok {
+		stream.Resumed = true
+
+		for i := range h.history {
+			if h.history[i].seq > lastSeq && stream.matches(&h.history[i]) {
+				stream.Backlog = append(stream.Backlog, h.history[i])
+			}
+		}
+	}
+
+	h.streams[stream] = struct{}{}
+	h.perUser[userID]++
+
+	return stream, nil
+}
+
+// resumeFrom returns the sequence number of lastEventID when the history holds
+// every message published after it
+func (h *Hub) resumeFrom(lastEventID string) (uint64, bool) {
+	epoch, seqText, ok := strings.Cut(lastEventID, "-")
+	if !ok || epoch != h.epoch {
+		return 0, false
+	}
+
+	seq, err := strconv.ParseUint(seqText, 10, 64)
+	if err != nil || seq > h.seq {
+		return 0, false
+	}
+
+	if len(h.history) > 0 && h.history[0].seq > seq+1 {
+		return 0, false
+	}
+
+	return seq, true
+}
+
+// publish records msg in the history and sends it to the matching streams. A
+// stream whose buffer is full is closed rather than waited for, so one slow
+// client cannot hold up the others; it resumes from the history when the
+// client reconnects.
+func (h *Hub) publish(msg *Message) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.stopped {
+		return
+	}
+
+	h.seq++
+	msg.seq = h.seq
+	msg.ID = h.id(h.seq)
+
+	if h.cfg.ReplayBuffer > 0 {
+		h.history = append(h.history, *msg)
+		if len(h.history) > h.cfg.ReplayBuffer {
+			h.history = h.history[len(h.history)-h.cfg.ReplayBuffer:]
+		}
+	}
+
+	for stream := range h.streams {
+		if !stream.matches(msg) {
+			continue
+		}
+
+		select {
+		case stream.messages <- *msg:
+		default:
+			h.logger.Warn("live stream fell behind, closing it", "user_id", stream.userID)
+			h.remove(stream)
+		}
+	}
+}
+
+// id formats the ID of the message with sequence number seq
+func (h *Hub) id(seq uint64) string {
+	return h.epoch + "-" + strconv.FormatUint(seq, 10)
+}
+
+// remove closes a stream. The caller must hold h.mu.
+func (h *Hub) remove(stream *Stream) {
+	if _, ok := h.streams[stream]; !ok {
+		return
+	}
+
+	delete(h.streams, stream)
+	close(stream.done)
+
+	h.perUser[stream.userID]--
+	if h.perUser[stream.userID] == 0 {
+		delete(h.perUser, stream.userID)
+	}
+}
+
+// Stop closes every open stream and refuses new ones
+func (h *Hub) Stop() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.stopped = true
+
+	for stream := range h.streams {
+		h.remove(stream)
+	}
+}
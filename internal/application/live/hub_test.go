@@ -0,0 +1,296 @@
+package live_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/goformx/goforms/internal/application/live"
+	"github.com/goformx/goforms/internal/domain/common/events"
+	formevents "github.com/goformx/goforms/internal/domain/form/events"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	mockevents "github.com/goformx/goforms/test/mocks/events"
+	mockform "github.com/goformx/goforms/test/mocks/form"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+// owners maps the forms used in the tests to the users who own them
+var owners = map[string]string{"form-1": "user-1", "form-2": "user-1", "form-3": "user-2"}
+
+// ownedForm returns a form owned by the user listed in owners
+func ownedForm(_ context.Context, formID string) (*model.Form, error) {
+	return &model.Form{ID: formID, UserID: owners[formID]}, nil
+}
+
+func submit(t *testing.T, handlers map[string]func(context.Context, events.Event) error, formID, submissionID string) {
+	t.Helper()
+
+	event := formevents.NewFormSubmittedEvent(&model.FormSubmission{
+		ID: submissionID, FormID: formID, Status: model.SubmissionStatusPending,
+	})
+	require.NoError(t, handlers[event.Name()](t.Context(), event))
+}
+
+func defaultConfig() config.LiveConfig {
+	return config.LiveConfig{Enabled: true, ClientBuffer: 8, ReplayBuffer: 16, MaxStreamsPerUser: 3}
+}
+
+// received drains the messages waiting on a stream
+func received(stream *live.Stream) []live.Message {
+	var messages []live.Message
+
+	for {
+		select {
+		case msg := <-stream.Messages():
+			messages = append(messages, msg)
+		default:
+			return messages
+		}
+	}
+}
+
+func submissionIDs(messages []live.Message) []string {
+	ids := make([]string, 0, len(messages))
+
+	for _, msg := range messages {
+		if data, ok := msg.Data.(live.SubmissionData); ok {
+			ids = append(ids, data.ID)
+		}
+	}
+
+	return ids
+}
+
+func TestHub_sendsOnlyTheOwnersForms(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	forms := mockform.NewMockService(ctrl)
+	forms.EXPECT().GetForm(gomock.Any(), gomock.Any()).DoAndReturn(ownedForm).AnyTimes()
+	forms.EXPECT().CountFormSubmissions(gomock.Any(), gomock.Any()).
+		Return(map[string]int64{"form-1": 7}, nil).AnyTimes()
+	hub := live.NewHub(defaultConfig(), forms, mocklogging.NewMockLogger(ctrl))
+
+	handlers := map[string]func(context.Context, events.Event) error{}
+	bus := mockevents.NewMockSubscriber(ctrl)
+	bus.EXPECT().Subscribe(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, name string, handler func(context.Context, events.Event) error) error {
+			handlers[name] = handler
+
+			return nil
+		}).AnyTimes()
+	require.NoError(t, hub.Subscribe(t.Context(), bus))
+
+	all, err := hub.Open("user-1", "", "")
+	require.NoError(t, err)
+
+	one, err := hub.Open("user-1", "form-2", "")
+	require.NoError(t, err)
+
+	other, err := hub.Open("user-2", "", "")
+	require.NoError(t, err)
+
+	submit(t, handlers, "form-1", "sub-1")
+	submit(t, handlers, "form-2", "sub-2")
+	submit(t, handlers, "form-3", "sub-3")
+
+	messages := received(all)
+	assert.Equal(t, []string{"sub-1", "sub-2"}, submissionIDs(messages))
+	assert.Equal(t, live.EventSubmission, messages[0].Event)
+	assert.Equal(t, int64(7), messages[0].Data.(live.SubmissionData).Count) //nolint:forcetypeassert // checked above
+	assert.Equal(t, []string{"sub-2"}, submissionIDs(received(one)))
+	assert.Equal(t, []string{"sub-3"}, submissionIDs(received(other)))
+
+	processed := formevents.NewFormProcessedEvent("form-1", "sub-1")
+	require.NoError(t, handlers[processed.Name()](t.Context(), processed))
+
+	messages = received(all)
+	require.Len(t, messages, 1)
+	assert.Equal(t, live.EventProcessed, messages[0].Event)
+	assert.Equal(t, live.ProcessedData{FormID: "form-1", SubmissionID: "sub-1"}, messages[0].Data)
+	assert.Empty(t, received(one))
+}
+
+func TestHub_resumesFromLastEventID(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	forms := mockform.NewMockService(ctrl)
+	forms.EXPECT().GetForm(gomock.Any(), gomock.Any()).DoAndReturn(ownedForm).AnyTimes()
+	forms.EXPECT().CountFormSubmissions(gomock.Any(), gomock.Any()).
+		Return(map[string]int64{"form-1": 7}, nil).AnyTimes()
+	cfg := config.LiveConfig{Enabled: true, ClientBuffer: 8, ReplayBuffer: 3}
+	hub := live.NewHub(cfg, forms, mocklogging.NewMockLogger(ctrl))
+
+	handlers := map[string]func(context.Context, events.Event) error{}
+	bus := mockevents.NewMockSubscriber(ctrl)
+	bus.EXPECT().Subscribe(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, name string, handler func(context.Context, events.Event) error) error {
+			handlers[name] = handler
+
+			return nil
+		}).AnyTimes()
+	require.NoError(t, hub.Subscribe(t.Context(), bus))
+
+	first, err := hub.Open("user-1", "", "")
+	require.NoError(t, err)
+	assert.False(t, first.Resumed)
+
+	submit(t, handlers, "form-1", "sub-1")
+
+	lastID := received(first)[0].ID
+	first.Close()
+
+	submit(t, handlers, "form-3", "sub-2")
+	submit(t, handlers, "form-2", "sub-3")
+
+	resumed, err := hub.Open("user-1", "", lastID)
+	require.NoError(t, err)
+	assert.True(t, resumed.Resumed)
+	assert.Equal(t, []string{"sub-3"}, submissionIDs(resumed.Backlog), "missed messages of the user's forms")
+
+	// The history only holds the last three messages
+	submit(t, handlers, "form-1", "sub-4")
+	submit(t, handlers, "form-1", "sub-5")
+
+	stale, err := hub.Open("user-1", "", lastID)
+	require.NoError(t, err)
+	assert.False(t, stale.Resumed)
+	assert.Empty(t, stale.Backlog)
+
+	for _, id := range []string{"other-epoch-1", "garbage", resumed.Cursor + "0"} {
+		stream, openErr := hub.Open("user-2", "", id)
+		require.NoError(t, openErr)
+		assert.False(t, stream.Resumed, id)
+		stream.Close()
+	}
+}
+
+func TestHub_closesSlowStreams(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	forms := mockform.NewMockService(ctrl)
+	forms.EXPECT().GetForm(gomock.Any(), gomock.Any()).DoAndReturn(ownedForm).AnyTimes()
+	forms.EXPECT().CountFormSubmissions(gomock.Any(), gomock.Any()).
+		Return(map[string]int64{"form-1": 7}, nil).AnyTimes()
+	logger := mocklogging.NewMockLogger(ctrl)
+	cfg := config.LiveConfig{Enabled: true, ClientBuffer: 2, ReplayBuffer: 16}
+	hub := live.NewHub(cfg, forms, logger)
+
+	handlers := map[string]func(context.Context, events.Event) error{}
+	bus := mockevents.NewMockSubscriber(ctrl)
+	bus.EXPECT().Subscribe(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, name string, handler func(context.Context, events.Event) error) error {
+			handlers[name] = handler
+
+			return nil
+		}).AnyTimes()
+	require.NoError(t, hub.Subscribe(t.Context(), bus))
+
+	slow, err := hub.Open("user-1", "", "")
+	require.NoError(t, err)
+
+	fast, err := hub.Open("user-1", "", "")
+	require.NoError(t, err)
+
+	logger.EXPECT().Warn("live stream fell behind, closing it", "user_id", "user-1")
+
+	for _, id := range []string{"sub-1", "sub-2", "sub-3"} {
+		submit(t, handlers, "form-1", id)
+		assert.Len(t, received(fast), 1)
+	}
+
+	select {
+	case <-slow.Done():
+	default:
+		t.Fatal("a stream with a full buffer should be closed")
+	}
+
+	select {
+	case <-fast.Done():
+		t.Fatal("a stream that keeps up should stay open")
+	default:
+	}
+
+	// The slow client reconnects and catches up from the history
+	delivered := received(slow)
+	resumed, err := hub.Open("user-1", "", delivered[len(delivered)-1].ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"sub-3"}, submissionIDs(resumed.Backlog))
+}
+
+func TestHub_limitsStreamsPerUser(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	forms := mockform.NewMockService(ctrl)
+	forms.EXPECT().GetForm(gomock.Any(), gomock.Any()).DoAndReturn(ownedForm).AnyTimes()
+	forms.EXPECT().CountFormSubmissions(gomock.Any(), gomock.Any()).
+		Return(map[string]int64{"form-1": 7}, nil).AnyTimes()
+	hub := live.NewHub(defaultConfig(), forms, mocklogging.NewMockLogger(ctrl))
+
+	handlers := map[string]func(context.Context, events.Event) error{}
+	bus := mockevents.NewMockSubscriber(ctrl)
+	bus.EXPECT().Subscribe(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, name string, handler func(context.Context, events.Event) error) error {
+			handlers[name] = handler
+
+			return nil
+		}).AnyTimes()
+	require.NoError(t, hub.Subscribe(t.Context(), bus))
+
+	streams := make([]*live.Stream, 0, 3)
+
+	for range 3 {
+		stream, err := hub.Open("user-1", "", "")
+		require.NoError(t, err)
+
+		streams = append(streams, stream)
+	}
+
+	_, err := hub.Open("user-1", "", "")
+	require.ErrorIs(t, err, live.ErrTooManyStreams)
+
+	_, err = hub.Open("user-2", "", "")
+	require.NoError(t, err, "the limit is per user")
+
+	streams[0].Close()
+	streams[0].Close()
+
+	_, err = hub.Open("user-1", "", "")
+	require.NoError(t, err)
+
+	hub.Stop()
+
+	<-streams[1].Done()
+
+	_, err = hub.Open("user-1", "", "")
+	require.ErrorIs(t, err, live.ErrHubStopped)
+}
+
+func TestWriter(t *testing.T) {
+	t.Parallel()
+
+	rec := httptest.NewRecorder()
+	w := live.NewWriter(rec, time.Second)
+
+	require.NoError(t, w.Retry(3*time.Second))
+	require.NoError(t, w.Send(&live.Message{
+		ID:    "abc-1",
+		Event: live.EventCounts,
+		Data:  live.CountsData{Counts: map[string]int64{"form-1": 2}},
+	}))
+	require.NoError(t, w.Ping())
+
+	assert.Equal(t, "retry: 3000\n\n"+
+		"id: abc-1\nevent: counts\ndata: {\"counts\":{\"form-1\":2}}\n\n"+
+		": ping\n\n", rec.Body.String())
+	assert.True(t, rec.Flushed)
+}
@@ -0,0 +1,79 @@
+package live
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ContentType is the media type of a Server-Sent Events response
+const ContentType = "text/event-stream"
+
+// Writer writes messages to a Server-Sent Events response and flushes each one
+// to the client
+type Writer struct {
+	w          io.Writer
+	controller *http.ResponseController
+	timeout    time.Duration
+}
+
+// NewWriter creates a Writer for w. Each write pushes the connection's write
+// deadline timeout into the future, so a long-lived stream outlives the
+// server's write timeout while a client that stops reading is still cut off.
+func NewWriter(w http.ResponseWriter, timeout time.Duration) *Writer {
+	return &Writer{
+		w:          w,
+		controller: http.NewResponseController(w),
+		timeout:    timeout,
+	}
+}
+
+// Retry tells the client how long to wait before reconnecting
+func (w *Writer) Retry(interval time.Duration) error {
+	return w.write(fmt.Sprintf("retry: %d\n\n", interval.Milliseconds()))
+}
+
+// Send writes a message as an SSE event
+func (w *Writer) Send(msg *Message) error {
+	data, err := json.Marshal(msg.Data)
+	if err != nil {
+		return fmt.Errorf("encode %s message: %w", msg.Event, err)
+	}
+
+	var b strings.Builder
+	if msg.ID != "" {
+		fmt.Fprintf(&b, "id: %s\n", msg.ID)
+	}
+
+	fmt.Fprintf(&b, "event: %s\ndata: %s\n\n", msg.Event, data)
+
+	return w.write(b.String())
+}
+
+// Ping writes a comment that keeps idle connections and proxies from timing out
+func (w *Writer) Ping() error {
+	return w.write(": ping\n\n")
+}
+
+func (w *Writer) write(s string) error {
+	if w.timeout > 0 {
+		err := w.controller.SetWriteDeadline(time.Now().Add(w.timeout))
+		if err != nil && !errors.Is(err, http.ErrNotSupported) {
+			return fmt.Errorf("set write deadline: %w", err)
+		}
+	}
+
+	if _, err := io.WriteString(w.w, s); err != nil {
+		return fmt.Errorf("write event: %w", err)
+	}
+
+	if err := w.controller.Flush(); err != nil {
+		return fmt.Errorf("flush event: %w", err)
+	}
+
+	return nil
+}
@@ -219,6 +219,7 @@ func DefaultRules() []Rule {
 		{Path: constants.PathForms, AccessLevel: Authenticated, Methods: []string{}},
 		{Path: constants.PathProfile, AccessLevel: Authenticated, Methods: []string{}},
 		{Path: constants.PathSettings, AccessLevel: Authenticated, Methods: []string{}},
+		{Path: constants.PathAudit, AccessLevel: Authenticated, Methods: []string{}},
 
 		// Admin paths
 		{Path: constants.PathAdmin, AccessLevel: Admin, Methods: []string{}},
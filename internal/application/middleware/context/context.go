@@ -8,6 +8,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 
+	"github.com/goformx/goforms/internal/domain/audit"
 	"github.com/goformx/goforms/internal/infrastructure/logging"
 )
 
@@ -15,8 +16,12 @@ import (
 type Key string
 
 const (
-	// RequestIDHeader is the HTTP header name for request ID
-	RequestIDHeader = "X-Trace-Id"
+	// RequestIDHeader is the HTTP header carrying the request ID, both on
+	// requests and responses
+	RequestIDHeader = echo.HeaderXRequestID
+	// maxRequestIDLength is the longest request ID accepted from a client. It
+	// matches the request_id column of the audit log.
+	maxRequestIDLength = 64
 	// RequestTimeout is the default timeout for request context
 	RequestTimeout = 30 * time.Second
 
@@ -40,13 +45,18 @@ const (
 type Middleware struct {
 	logger         logging.Logger
 	requestTimeout time.Duration
+	// untimed reports whether a path serves long-lived responses, such as
+	// event streams, that are not subject to the request timeout
+	untimed func(path string) bool
 }
 
-// NewMiddleware creates a new context middleware
-func NewMiddleware(logger logging.Logger, requestTimeout time.Duration) *Middleware {
+// NewMiddleware creates a new context middleware. Requests to paths for which
+// untimed returns true get no timeout; untimed may be nil.
+func NewMiddleware(logger logging.Logger, requestTimeout time.Duration, untimed func(path string) bool) *Middleware {
 	return &Middleware{
 		logger:         logger,
 		requestTimeout: requestTimeout,
+		untimed:        untimed,
 	}
 }
 
@@ -54,19 +64,31 @@ func NewMiddleware(logger logging.Logger, requestTimeout time.Duration) *Middlew
 func (m *Middleware) WithContext() echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			// Get or generate request ID
+			// Use the client's request ID, or generate one, and return it
 			requestID := c.Request().Header.Get(RequestIDHeader)
-			if requestID == "" {
+			if !validRequestID(requestID) {
 				requestID = uuid.New().String()
 				c.Request().Header.Set(RequestIDHeader, requestID)
 			}
 
+			c.Response().Header().Set(RequestIDHeader, requestID)
+			c.Set(string(RequestIDKey), requestID)
+
 			// Create request context with timeout
-			ctx, cancel := context.WithTimeout(c.Request().Context(), m.requestTimeout)
+			var (
+				ctx    context.Context
+				cancel context.CancelFunc
+			)
+
+			if m.untimed != nil && m.untimed(c.Request().URL.Path) {
+				ctx, cancel = context.WithCancel(c.Request().Context())
+			} else {
+				ctx, cancel = context.WithTimeout(c.Request().Context(), m.requestTimeout)
+			}
 			defer cancel()
 
 			// Add request ID and logger to context
-			ctx = context.WithValue(ctx, RequestIDKey, requestID)
+			ctx = logging.ContextWithRequestID(ctx, requestID)
 			ctx = context.WithValue(ctx, LoggerKey, m.logger)
 
 			// Update request context
@@ -77,6 +99,50 @@ func (m *Middleware) WithContext() echo.MiddlewareFunc {
 	}
 }
 
+// WithActor attributes the request's audited actions and log lines to the
+// signed-in user, client IP and user agent. It must run after the session
+// middleware.
+func (m *Middleware) WithActor() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			userID, _ := GetUserID(c)
+			email, _ := GetEmail(c)
+
+			ctx := logging.ContextWithUserID(req.Context(), userID)
+			ctx = audit.WithActor(ctx, audit.Actor{
+				UserID:    userID,
+				Email:     email,
+				IP:        c.RealIP(),
+				UserAgent: req.UserAgent(),
+				RequestID: GetRequestID(req.Context()),
+			})
+			c.SetRequest(req.WithContext(ctx))
+
+			return next(c)
+		}
+	}
+}
+
+// validRequestID reports whether a client's request ID can be used as is. IDs
+// are limited to characters that are safe in logs and headers.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+
+	for _, r := range id {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-', r == '_', r == '.', r == ':':
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
 // Request Context Helpers
 
 // GetLogger retrieves the logger from context
@@ -90,11 +156,7 @@ func GetLogger(ctx context.Context) logging.Logger {
 
 // GetRequestID retrieves the request ID from context
 func GetRequestID(ctx context.Context) string {
-	if id, ok := ctx.Value(RequestIDKey).(string); ok {
-		return id
-	}
-
-	return ""
+	return logging.RequestIDFromContext(ctx)
 }
 
 // GetCorrelationID retrieves the correlation ID from context
@@ -0,0 +1,59 @@
+package context_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mwcontext "github.com/goformx/goforms/internal/application/middleware/context"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+func TestMiddleware_WithContextRequestID(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		keep   bool
+	}{
+		{name: "client ID", header: "client-req_1.2:3", keep: true},
+		{name: "longest ID", header: strings.Repeat("a", 64), keep: true},
+		{name: "missing ID", header: ""},
+		{name: "unsafe ID", header: "bad id\r\n"},
+		{name: "long ID", header: strings.Repeat("a", 65)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+			req.Header.Set(mwcontext.RequestIDHeader, tt.header)
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(req, rec)
+
+			var fromContext string
+
+			handler := mwcontext.NewMiddleware(nil, time.Second, nil).WithContext()(func(c echo.Context) error {
+				fromContext = logging.RequestIDFromContext(c.Request().Context())
+
+				return nil
+			})
+			require.NoError(t, handler(c))
+
+			requestID := rec.Header().Get(mwcontext.RequestIDHeader)
+			require.NotEmpty(t, requestID)
+			assert.Equal(t, requestID, fromContext)
+			assert.Equal(t, requestID, c.Get(string(mwcontext.RequestIDKey)))
+
+			if tt.keep {
+				assert.Equal(t, tt.header, requestID)
+			} else {
+				assert.NotEqual(t, tt.header, requestID)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package cors
+
+import (
+	"sync"
+	"time"
+)
+
+// maxCachedPolicies bounds the cache, since form IDs come from request paths
+const maxCachedPolicies = 10000
+
+// cachedPolicy is a policy with its expiry time
+type cachedPolicy struct {
+	policy  *policy
+	expires time.Time
+}
+
+// policyCache is a small TTL cache of form CORS policies keyed by form ID
+type policyCache struct {
+	mu      sync.RWMutex
+	entries map[string]cachedPolicy
+	ttl     time.Duration
+	now     func() time.Time
+}
+
+// newPolicyCache creates a policy cache
+func newPolicyCache(ttl time.Duration, now func() time.Time) *policyCache {
+	return &policyCache{
+		entries: make(map[string]cachedPolicy),
+		ttl:     ttl,
+		now:     now,
+	}
+}
+
+// get returns the cached policy for a form if it has not expired
+func (c *policyCache) get(formID string) (*policy, bool) {
+	c.mu.RLock()
+	entry, ok := c.entries[formID]
+	c.mu.RUnlock()
+
+	if !ok || c.now().After(entry.expires) {
+		return nil, false
+	}
+
+	return entry.policy, true
+}
+
+// set caches a form's policy. When the cache is full, expired entries are
+// dropped first and everything else if that is not enough.
+func (c *policyCache) set(formID string, p *policy) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+
+	if len(c.entries) >= maxCachedPolicies {
+		for id, entry := range c.entries {
+			if now.After(entry.expires) {
+				delete(c.entries, id)
+			}
+		}
+
+		if len(c.entries) >= maxCachedPolicies {
+			clear(c.entries)
+		}
+	}
+
+	c.entries[formID] = cachedPolicy{policy: p, expires: now.Add(c.ttl)}
+}
@@ -0,0 +1,238 @@
+// Package cors applies each form's own CORS settings to the public form API, so
+// that a form can be embedded on the sites its owner lists and nowhere else.
+package cors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/application/constants"
+	"github.com/goformx/goforms/internal/application/response"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+)
+
+const (
+	// DefaultCacheTTL is how long a form's CORS policy is cached
+	DefaultCacheTTL = time.Minute
+	// DefaultMaxAge is how long browsers may cache a preflight response, in seconds
+	DefaultMaxAge = 600
+)
+
+// Defaults applied when a form does not configure methods or headers
+var (
+	DefaultMethods = []string{http.MethodGet, http.MethodPost, http.MethodPatch, http.MethodOptions}
+	DefaultHeaders = []string{"Content-Type", "Accept", "Origin", "X-Requested-With"}
+)
+
+// publicFormEndpoints are the form API endpoints that other sites may call. The
+// value reports whether the endpoint has sub-paths, such as /draft/:token/submit.
+var publicFormEndpoints = map[string]bool{
+	"schema":     false,
+	"validation": false,
+	"submit":     false,
+	"draft":      true,
+}
+
+// FormGetter loads forms by ID
+type FormGetter interface {
+	GetForm(ctx context.Context, formID string) (*model.Form, error)
+}
+
+// FormMiddleware enforces per-form CORS policies on the public form API
+type FormMiddleware struct {
+	forms  FormGetter
+	logger logging.Logger
+	cache  *policyCache
+	maxAge int
+}
+
+// NewFormMiddleware creates a per-form CORS middleware that caches each form's
+// policy for ttl. A ttl of zero or less uses DefaultCacheTTL.
+func NewFormMiddleware(forms FormGetter, logger logging.Logger, ttl time.Duration) *FormMiddleware {
+	if ttl <= 0 {
+		ttl = DefaultCacheTTL
+	}
+
+	return &FormMiddleware{
+		forms:  forms,
+		logger: logger,
+		cache:  newPolicyCache(ttl, time.Now),
+		maxAge: DefaultMaxAge,
+	}
+}
+
+// FormIDFromPath returns the form ID when path is a public form API endpoint,
+// i.e. /api/v1/forms/:id/{schema,validation,submit} or /api/v1/forms/:id/draft/...
+func FormIDFromPath(path string) (string, bool) {
+	rest, ok := strings.CutPrefix(path, constants.PathAPIForms+"/")
+	if !ok {
+		return "", false
+	}
+
+	formID, endpoint, ok := strings.Cut(rest, "/")
+	if !ok || formID == "" {
+		return "", false
+	}
+
+	name, _, nested := strings.Cut(endpoint, "/")
+
+	hasSubPaths, public := publicFormEndpoints[name]
+	if !public || (nested && !hasSubPaths) {
+		return "", false
+	}
+
+	return formID, true
+}
+
+// Handler returns the echo middleware. Requests to other paths, requests
+// without an Origin header and same-origin requests pass through untouched.
+func (m *FormMiddleware) Handler() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			formID, ok := FormIDFromPath(c.Request().URL.Path)
+			if !ok {
+				return next(c)
+			}
+
+			c.Response().Header().Add(echo.HeaderVary, echo.HeaderOrigin)
+
+			origin := c.Request().Header.Get(echo.HeaderOrigin)
+			if origin == "" || isSameOrigin(c, origin) {
+				return next(c)
+			}
+
+			return m.handleCrossOrigin(c, next, formID, origin)
+		}
+	}
+}
+
+// handleCrossOrigin answers preflight requests and checks actual requests
+// against the form's policy
+func (m *FormMiddleware) handleCrossOrigin(c echo.Context, next echo.HandlerFunc, formID, origin string) error {
+	req := c.Request()
+	preflight := req.Method == http.MethodOptions && req.Header.Get(echo.HeaderAccessControlRequestMethod) != ""
+
+	p, err := m.policy(req.Context(), formID)
+	if err != nil {
+		m.logger.Error("failed to load form CORS policy", "error", err, "form_id", formID)
+
+		return response.ErrorResponse(c, http.StatusInternalServerError, "Failed to check request origin")
+	}
+
+	method := req.Method
+	if preflight {
+		method = req.Header.Get(echo.HeaderAccessControlRequestMethod)
+	}
+
+	if !p.allows(origin, method) {
+		m.logger.Warn("cross-origin form request rejected",
+			"form_id", formID,
+			"origin", m.logger.SanitizeField("origin", origin),
+			"method", method)
+
+		if preflight {
+			return noContent(c, http.StatusForbidden)
+		}
+
+		return response.ErrorResponse(c, http.StatusForbidden, "Origin not allowed for this form")
+	}
+
+	header := c.Response().Header()
+	header.Set(echo.HeaderAccessControlAllowOrigin, origin)
+
+	if !preflight {
+		return next(c)
+	}
+
+	header.Add(echo.HeaderVary, echo.HeaderAccessControlRequestMethod)
+	header.Add(echo.HeaderVary, echo.HeaderAccessControlRequestHeaders)
+	header.Set(echo.HeaderAccessControlAllowMethods, strings.Join(p.methods, ","))
+	header.Set(echo.HeaderAccessControlAllowHeaders, strings.Join(p.headers, ","))
+	header.Set(echo.HeaderAccessControlMaxAge, strconv.Itoa(m.maxAge))
+
+	return noContent(c, http.StatusNoContent)
+}
+
+// policy returns the cached policy for a form, loading it on a miss. Missing
+// forms get an empty policy so that every cross-origin request is rejected.
+func (m *FormMiddleware) policy(ctx context.Context, formID string) (*policy, error) {
+	if p, ok := m.cache.get(formID); ok {
+		return p, nil
+	}
+
+	form, err := m.forms.GetForm(ctx, formID)
+	if err != nil && !errors.Is(err, common.ErrNotFound) && !errors.Is(err, common.ErrInvalidInput) {
+		return nil, fmt.Errorf("get form: %w", err)
+	}
+
+	p := &policy{}
+	if err == nil && form != nil {
+		p = newPolicy(form)
+	}
+
+	m.cache.set(formID, p)
+
+	return p, nil
+}
+
+// noContent ends a preflight request with an empty response
+func noContent(c echo.Context, status int) error {
+	if err := c.NoContent(status); err != nil {
+		return fmt.Errorf("send preflight response: %w", err)
+	}
+
+	return nil
+}
+
+// isSameOrigin reports whether origin is the server's own origin
+func isSameOrigin(c echo.Context, origin string) bool {
+	return strings.EqualFold(origin, c.Scheme()+"://"+c.Request().Host)
+}
+
+// policy is the CORS policy of one form
+type policy struct {
+	origins []string
+	methods []string
+	headers []string
+}
+
+// newPolicy builds a policy from a form's CORS settings
+func newPolicy(form *model.Form) *policy {
+	origins, methods, headers := form.GetCorsConfig()
+	if len(methods) == 0 {
+		methods = DefaultMethods
+	}
+
+	if len(headers) == 0 {
+		headers = DefaultHeaders
+	}
+
+	return &policy{origins: origins, methods: methods, headers: headers}
+}
+
+// allows reports whether origin may call the form API with method
+func (p *policy) allows(origin, method string) bool {
+	if !slices.ContainsFunc(p.origins, func(pattern string) bool {
+		return model.MatchOrigin(pattern, origin)
+	}) {
+		return false
+	}
+
+	for _, allowed := range p.methods {
+		if strings.EqualFold(allowed, method) {
+			return true
+		}
+	}
+
+	return false
+}
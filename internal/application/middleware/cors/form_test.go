@@ -0,0 +1,185 @@
+package cors_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/goformx/goforms/internal/application/middleware/cors"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+	mockform "github.com/goformx/goforms/test/mocks/form"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+const testFormID = "5f1c3a2e-8d4b-4c6a-9e2f-1a2b3c4d5e6f"
+
+func TestFormIDFromPath(t *testing.T) {
+	tests := []struct {
+		path   string
+		wantID string
+		wantOK bool
+	}{
+		{"/api/v1/forms/abc/schema", "abc", true},
+		{"/api/v1/forms/abc/validation", "abc", true},
+		{"/api/v1/forms/abc/submit", "abc", true},
+		{"/api/v1/forms/abc/draft", "abc", true},
+		{"/api/v1/forms/abc/draft/tok", "abc", true},
+		{"/api/v1/forms/abc/draft/tok/submit", "abc", true},
+		{"/api/v1/forms/abc/submit/extra", "", false},
+		{"/api/v1/forms/abc/export", "", false},
+		{"/api/v1/forms/abc", "", false},
+		{"/api/v1/forms//submit", "", false},
+		{"/forms/abc/submit", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			id, ok := cors.FormIDFromPath(tt.path)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantID, id)
+		})
+	}
+}
+
+// serve runs req through the middleware and returns the response
+func serve(t *testing.T, handler echo.HandlerFunc, req *http.Request) *httptest.ResponseRecorder {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	require.NoError(t, handler(echo.New().NewContext(req, rec)))
+
+	return rec
+}
+
+func publishedForm(origins ...string) *model.Form {
+	form := model.NewForm("owner", "Contact", "", model.JSON{"components": []any{}})
+	form.ID = testFormID
+	form.SetCorsConfig(origins, nil, nil)
+
+	return form
+}
+
+func preflight(origin, method string) *http.Request {
+	req := httptest.NewRequest(http.MethodOptions, "/api/v1/forms/"+testFormID+"/submit", http.NoBody)
+	req.Header.Set(echo.HeaderOrigin, origin)
+	req.Header.Set(echo.HeaderAccessControlRequestMethod, method)
+
+	return req
+}
+
+func submit(origin string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/forms/"+testFormID+"/submit", http.NoBody)
+	if origin != "" {
+		req.Header.Set(echo.HeaderOrigin, origin)
+	}
+
+	return req
+}
+
+func TestFormMiddleware_Preflight(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	forms := mockform.NewMockService(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+
+	called := false
+	handler := cors.NewFormMiddleware(forms, logger, 0).Handler()(func(c echo.Context) error {
+		called = true
+
+		return c.NoContent(http.StatusOK)
+	})
+
+	forms.EXPECT().GetForm(gomock.Any(), testFormID).Return(publishedForm("https://*.example.com"), nil)
+
+	rec := serve(t, handler, preflight("https://shop.example.com", http.MethodPost))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.False(t, called)
+	assert.Equal(t, "https://shop.example.com", rec.Header().Get(echo.HeaderAccessControlAllowOrigin))
+	assert.Equal(t, "GET,POST,PATCH,OPTIONS", rec.Header().Get(echo.HeaderAccessControlAllowMethods))
+	assert.NotEmpty(t, rec.Header().Get(echo.HeaderAccessControlAllowHeaders))
+
+	// The policy is cached, so the rejected preflight does not load the form again
+	logger.EXPECT().SanitizeField("origin", "https://evil.test").Return("https://evil.test")
+	logger.EXPECT().Warn("cross-origin form request rejected", gomock.Any())
+
+	rec = serve(t, handler, preflight("https://evil.test", http.MethodPost))
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Empty(t, rec.Header().Get(echo.HeaderAccessControlAllowOrigin))
+}
+
+func TestFormMiddleware_Submit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	forms := mockform.NewMockService(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+
+	called := false
+	handler := cors.NewFormMiddleware(forms, logger, 0).Handler()(func(c echo.Context) error {
+		called = true
+
+		return c.NoContent(http.StatusOK)
+	})
+
+	forms.EXPECT().GetForm(gomock.Any(), testFormID).Return(publishedForm("https://example.com"), nil)
+
+	rec := serve(t, handler, submit("https://example.com"))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.True(t, called)
+	assert.Equal(t, "https://example.com", rec.Header().Get(echo.HeaderAccessControlAllowOrigin))
+
+	logger.EXPECT().SanitizeField("origin", "https://evil.test").Return("https://evil.test")
+	logger.EXPECT().Warn("cross-origin form request rejected", gomock.Any())
+
+	called = false
+	rec = serve(t, handler, submit("https://evil.test"))
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.False(t, called)
+}
+
+func TestFormMiddleware_SameOriginAndNonBrowser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	forms := mockform.NewMockService(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+
+	called := false
+	handler := cors.NewFormMiddleware(forms, logger, 0).Handler()(func(c echo.Context) error {
+		called = true
+
+		return c.NoContent(http.StatusOK)
+	})
+
+	rec := serve(t, handler, submit(""))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.True(t, called)
+
+	called = false
+	rec = serve(t, handler, submit("http://example.com"))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.True(t, called)
+}
+
+func TestFormMiddleware_UnknownForm(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	forms := mockform.NewMockService(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+
+	called := false
+	handler := cors.NewFormMiddleware(forms, logger, 0).Handler()(func(c echo.Context) error {
+		called = true
+
+		return c.NoContent(http.StatusOK)
+	})
+
+	forms.EXPECT().GetForm(gomock.Any(), testFormID).
+		Return(nil, common.NewNotFoundError("get", "form", testFormID))
+	logger.EXPECT().SanitizeField("origin", "https://example.com").Return("https://example.com")
+	logger.EXPECT().Warn("cross-origin form request rejected", gomock.Any())
+
+	rec := serve(t, handler, submit("https://example.com"))
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.False(t, called)
+}
@@ -0,0 +1,93 @@
+// Package locale selects the language each request is served in.
+package locale
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/goformx/goforms/internal/infrastructure/i18n"
+)
+
+const (
+	// QueryParam overrides the locale for one request, such as a link to a
+	// form in a given language
+	QueryParam = "lang"
+	// CookieName is the cookie remembering the language the user picked
+	CookieName = "goforms_lang"
+	// CookieMaxAge is how long the language choice is remembered
+	CookieMaxAge = 365 * 24 * time.Hour
+	// contextKey is the echo context key of the selected locale
+	contextKey = "locale"
+)
+
+// Middleware selects the locale of each request, in order of preference,
+// from the lang query parameter, the language cookie (set from the user's
+// saved preference on login and by the language switcher) and the
+// Accept-Language header. Handlers read it with Get and services with
+// i18n.FromContext.
+func Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			selected := Resolve(c.Request())
+
+			c.Set(contextKey, selected)
+			c.SetRequest(c.Request().WithContext(i18n.WithLocale(c.Request().Context(), selected)))
+			c.Response().Header().Set("Content-Language", selected)
+			c.Response().Header().Add(echo.HeaderVary, "Accept-Language")
+
+			return next(c)
+		}
+	}
+}
+
+// Resolve returns the locale a request asks for
+func Resolve(r *http.Request) string {
+	if lang := r.URL.Query().Get(QueryParam); i18n.IsSupported(lang) {
+		return lang
+	}
+
+	if cookie, err := r.Cookie(CookieName); err == nil && i18n.IsSupported(cookie.Value) {
+		return cookie.Value
+	}
+
+	if negotiated, ok := i18n.Negotiate(r.Header.Get("Accept-Language")); ok {
+		return negotiated
+	}
+
+	return i18n.DefaultLocale
+}
+
+// Get returns the locale selected for the request
+func Get(c echo.Context) string {
+	if selected, ok := c.Get(contextKey).(string); ok {
+		return selected
+	}
+
+	return Resolve(c.Request())
+}
+
+// Localizer returns a localizer for the request's locale
+func Localizer(c echo.Context) i18n.Localizer {
+	return i18n.For(Get(c))
+}
+
+// Remember stores the user's language choice in a cookie. Unsupported
+// locales are ignored.
+func Remember(c echo.Context, selected string) {
+	if !i18n.IsSupported(selected) {
+		return
+	}
+
+	c.SetCookie(&http.Cookie{
+		Name:     CookieName,
+		Value:    selected,
+		Path:     "/",
+		MaxAge:   int(CookieMaxAge.Seconds()),
+		HttpOnly: true,
+		Secure:   c.Scheme() == "https",
+		SameSite: http.SameSiteLaxMode,
+	})
+	c.Set(contextKey, selected)
+}
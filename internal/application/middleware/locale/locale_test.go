@@ -0,0 +1,77 @@
+package locale_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/goformx/goforms/internal/application/middleware/locale"
+	"github.com/goformx/goforms/internal/infrastructure/i18n"
+)
+
+func TestMiddleware(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		cookie string
+		accept string
+		want   string
+	}{
+		{name: "default", target: "/", want: i18n.English},
+		{name: "accept language", target: "/", accept: "fr-CA,en;q=0.5", want: i18n.French},
+		{name: "cookie over header", target: "/", cookie: "en", accept: "fr", want: i18n.English},
+		{name: "query over cookie", target: "/?lang=fr", cookie: "en", want: i18n.French},
+		{name: "unsupported query", target: "/?lang=de", accept: "fr", want: i18n.French},
+		{name: "unsupported cookie", target: "/", cookie: "xx", want: i18n.English},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, tt.target, http.NoBody)
+			req.Header.Set("Accept-Language", tt.accept)
+
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: locale.CookieName, Value: tt.cookie})
+			}
+
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			var fromContext string
+
+			err := locale.Middleware()(func(c echo.Context) error {
+				fromContext = i18n.FromContext(c.Request().Context())
+
+				return nil
+			})(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, locale.Get(c))
+			assert.Equal(t, tt.want, fromContext)
+			assert.Equal(t, tt.want, rec.Header().Get("Content-Language"))
+		})
+	}
+}
+
+func TestRemember(t *testing.T) {
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/", http.NoBody), rec)
+
+	locale.Remember(c, "de")
+	assert.Empty(t, rec.Header().Values("Set-Cookie"))
+
+	locale.Remember(c, i18n.French)
+
+	cookies := rec.Result().Cookies()
+	if assert.Len(t, cookies, 1) {
+		assert.Equal(t, locale.CookieName, cookies[0].Name)
+		assert.Equal(t, i18n.French, cookies[0].Value)
+	}
+
+	assert.Equal(t, i18n.French, locale.Get(c))
+}
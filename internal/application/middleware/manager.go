@@ -6,6 +6,7 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"reflect"
 	"strings"
 	"time"
 
@@ -17,6 +18,8 @@ import (
 	"github.com/goformx/goforms/internal/application/constants"
 	"github.com/goformx/goforms/internal/application/middleware/access"
 	contextmw "github.com/goformx/goforms/internal/application/middleware/context"
+	"github.com/goformx/goforms/internal/application/middleware/cors"
+	"github.com/goformx/goforms/internal/application/middleware/locale"
 	"github.com/goformx/goforms/internal/application/middleware/session"
 	formdomain "github.com/goformx/goforms/internal/domain/form"
 	"github.com/goformx/goforms/internal/domain/user"
@@ -44,6 +47,7 @@ type PathChecker struct {
 	staticPaths []string
 	apiPaths    []string
 	healthPaths []string
+	streamPaths []string
 }
 
 // NewPathChecker creates a new path checker with default paths
@@ -54,6 +58,7 @@ func NewPathChecker() *PathChecker {
 		staticPaths: []string{"/assets/", "/static/", "/public/", "/favicon.ico"},
 		apiPaths:    []string{"/api/"},
 		healthPaths: []string{"/health", "/health/", "/healthz", "/healthz/"},
+		streamPaths: []string{constants.PathAPILive},
 	}
 }
 
@@ -82,6 +87,17 @@ func (pc *PathChecker) IsHealthPath(path string) bool {
 	return pc.containsPath(path, pc.healthPaths)
 }
 
+// IsStreamPath checks if the path serves a long-lived event stream
+func (pc *PathChecker) IsStreamPath(path string) bool {
+	for _, p := range pc.streamPaths {
+		if strings.HasPrefix(path, p) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // containsPath checks if the path contains any of the given paths
 func (pc *PathChecker) containsPath(path string, paths []string) bool {
 	for _, p := range paths {
@@ -110,6 +126,9 @@ type ManagerConfig struct {
 	SessionManager *session.Manager
 	AccessManager  *access.Manager
 	Sanitizer      sanitization.ServiceInterface
+	// Reloader, when set, replaces the CORS, CSP and rate limit middleware
+	// when their settings are reloaded
+	Reloader *appconfig.Reloader
 }
 
 // Validate ensures all required configuration is present
@@ -138,11 +157,13 @@ func NewManager(cfg *ManagerConfig) *Manager {
 		panic(fmt.Sprintf("invalid config: %v", err))
 	}
 
+	pathChecker := NewPathChecker()
+
 	return &Manager{
 		logger:            cfg.Logger,
 		config:            cfg,
-		contextMiddleware: contextmw.NewMiddleware(cfg.Logger, cfg.Config.App.RequestTimeout),
-		pathChecker:       NewPathChecker(),
+		contextMiddleware: contextmw.NewMiddleware(cfg.Logger, cfg.Config.App.RequestTimeout, pathChecker.IsStreamPath),
+		pathChecker:       pathChecker,
 	}
 }
 
@@ -187,8 +208,12 @@ func (m *Manager) setupBasicMiddleware(e *echo.Echo) {
 	// Add recovery middleware first to catch panics
 	e.Use(Recovery(m.logger, m.config.Sanitizer))
 
-	// Add timeout middleware
+	// Add timeout middleware. Event streams stay open and flush as they go, so
+	// they are left out.
 	e.Use(echomw.TimeoutWithConfig(echomw.TimeoutConfig{
+		Skipper: func(c echo.Context) bool {
+			return m.pathChecker.IsStreamPath(c.Request().URL.Path)
+		},
 		Timeout:      m.config.Config.App.RequestTimeout,
 		ErrorMessage: "Request timeout",
 	}))
@@ -196,11 +221,14 @@ func (m *Manager) setupBasicMiddleware(e *echo.Echo) {
 	// Add context middleware to handle request context
 	e.Use(m.contextMiddleware.WithContext())
 
+	// Select the language of the request
+	e.Use(locale.Middleware())
+
 	// Register basic middleware with custom skipper to suppress noise paths
 	if m.config.Config.App.IsDevelopment() {
 		// Use console format in development
 		e.Use(echomw.LoggerWithConfig(echomw.LoggerConfig{
-			Format: "${time_rfc3339} ${status} ${method} ${uri} ${latency_human}\n",
+			Format: "${time_rfc3339} ${id} ${status} ${method} ${uri} ${latency_human}\n",
 			Output: os.Stdout,
 			Skipper: func(c echo.Context) bool {
 				path := c.Request().URL.Path
@@ -222,26 +250,23 @@ func (m *Manager) setupBasicMiddleware(e *echo.Echo) {
 
 // setupSecurityMiddleware sets up security-related middleware
 func (m *Manager) setupSecurityMiddleware(e *echo.Echo) {
-	// Use Echo's built-in CORS middleware - simple and reliable
-	if m.config.Config.Security.CORS.Enabled {
-		e.Use(echomw.CORSWithConfig(echomw.CORSConfig{
-			AllowOrigins:     m.config.Config.Security.CORS.AllowedOrigins,
-			AllowMethods:     m.config.Config.Security.CORS.AllowedMethods,
-			AllowHeaders:     m.config.Config.Security.CORS.AllowedHeaders,
-			AllowCredentials: m.config.Config.Security.CORS.AllowCredentials,
-			MaxAge:           m.config.Config.Security.CORS.MaxAge,
-		}))
+	// The public form API follows each form's own CORS settings instead of the global policy
+	formCORS := m.config.FormService != nil
+	if formCORS {
+		e.Use(cors.NewFormMiddleware(m.config.FormService, m.logger, cors.DefaultCacheTTL).Handler())
+	}
+
+	settings := m.config.Config.Reloadable()
+	if m.config.Reloader != nil {
+		settings = m.config.Reloader.Current()
 	}
 
+	corsMiddleware := newReloadableMiddleware(m.corsMiddleware(settings.CORS, formCORS))
+	e.Use(corsMiddleware.Handler())
+
 	// Register security middleware
-	e.Use(echomw.SecureWithConfig(echomw.SecureConfig{
-		XSSProtection:         m.config.Config.Security.SecurityHeaders.XXSSProtection,
-		ContentTypeNosniff:    m.config.Config.Security.SecurityHeaders.XContentTypeOptions,
-		XFrameOptions:         m.config.Config.Security.SecurityHeaders.XFrameOptions,
-		HSTSMaxAge:            constants.HSTSOneYear,
-		HSTSExcludeSubdomains: false,
-		ContentSecurityPolicy: m.config.Config.Security.GetCSPDirectives(&m.config.Config.App),
-	}))
+	secureMiddleware := newReloadableMiddleware(m.secureMiddleware(settings.CSP))
+	e.Use(secureMiddleware.Handler())
 
 	// Set security config in context for other middleware
 	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
@@ -261,15 +286,80 @@ func (m *Manager) setupSecurityMiddleware(e *echo.Echo) {
 	}
 
 	// Setup rate limiting using infrastructure config with graceful degradation
-	if m.config.Config.Security.RateLimit.Enabled {
-		m.trySetupRateLimit(e)
+	rateLimitMiddleware := newReloadableMiddleware(m.rateLimitMiddleware(settings.RateLimit))
+	e.Use(rateLimitMiddleware.Handler())
+
+	if m.config.Reloader == nil {
+		return
+	}
+
+	// Rebuild only the middleware whose settings changed, so that a CORS
+	// change does not reset the rate limiter's counters
+	applied := settings
+
+	m.config.Reloader.Subscribe(func(settings appconfig.ReloadableConfig) error {
+		if !reflect.DeepEqual(settings.CORS, applied.CORS) {
+			corsMiddleware.Store(m.corsMiddleware(settings.CORS, formCORS))
+		}
+
+		if !reflect.DeepEqual(settings.CSP, applied.CSP) {
+			secureMiddleware.Store(m.secureMiddleware(settings.CSP))
+		}
+
+		if !reflect.DeepEqual(settings.RateLimit, applied.RateLimit) {
+			rateLimitMiddleware.Store(m.rateLimitMiddleware(settings.RateLimit))
+		}
+
+		applied = settings
+
+		return nil
+	})
+}
+
+// corsMiddleware returns Echo's CORS middleware for the global policy, or
+// middleware that does nothing when CORS is disabled
+func (m *Manager) corsMiddleware(settings appconfig.CORSConfig, formCORS bool) echo.MiddlewareFunc {
+	if !settings.Enabled {
+		return passThrough
 	}
+
+	return echomw.CORSWithConfig(echomw.CORSConfig{
+		Skipper: func(c echo.Context) bool {
+			_, isFormAPI := cors.FormIDFromPath(c.Request().URL.Path)
+
+			return formCORS && isFormAPI
+		},
+		AllowOrigins:     settings.AllowedOrigins,
+		AllowMethods:     settings.AllowedMethods,
+		AllowHeaders:     settings.AllowedHeaders,
+		AllowCredentials: settings.AllowCredentials,
+		MaxAge:           settings.MaxAge,
+	})
 }
 
-// trySetupRateLimit attempts to setup rate limiting
-func (m *Manager) trySetupRateLimit(e *echo.Echo) {
-	rateLimitMiddleware := m.setupRateLimiting()
-	e.Use(rateLimitMiddleware)
+// secureMiddleware returns Echo's Secure middleware with the given CSP
+func (m *Manager) secureMiddleware(csp appconfig.CSPConfig) echo.MiddlewareFunc {
+	security := m.config.Config.Security
+	security.CSP = csp
+
+	return echomw.SecureWithConfig(echomw.SecureConfig{
+		XSSProtection:         security.SecurityHeaders.XXSSProtection,
+		ContentTypeNosniff:    security.SecurityHeaders.XContentTypeOptions,
+		XFrameOptions:         security.SecurityHeaders.XFrameOptions,
+		HSTSMaxAge:            constants.HSTSOneYear,
+		HSTSExcludeSubdomains: false,
+		ContentSecurityPolicy: security.GetCSPDirectives(&m.config.Config.App),
+	})
+}
+
+// rateLimitMiddleware returns the rate limiting middleware, or middleware that
+// does nothing when rate limiting is disabled
+func (m *Manager) rateLimitMiddleware(settings appconfig.RateLimitConfig) echo.MiddlewareFunc {
+	if !settings.Enabled {
+		return passThrough
+	}
+
+	return m.setupRateLimiting(settings)
 }
 
 // setupAuthMiddleware sets up authentication-related middleware
@@ -279,6 +369,9 @@ func (m *Manager) setupAuthMiddleware(e *echo.Echo) {
 		e.Use(m.config.SessionManager.Middleware())
 	}
 
+	// Attribute audited actions to the session's user
+	e.Use(m.contextMiddleware.WithActor())
+
 	// Register access control middleware
 	e.Use(access.Middleware(m.config.AccessManager, m.logger))
 }
@@ -362,6 +455,16 @@ func createCSRFSkipper(isDevelopment bool) func(c echo.Context) bool {
 	}
 }
 
+// RequiresCSRF reports whether a request must carry a CSRF token outside
+// development, following the rules of the CSRF skipper
+func RequiresCSRF(method, path string) bool {
+	if isSafeMethod(method) {
+		return false
+	}
+
+	return !shouldSkipCSRFForRoute(path, false)
+}
+
 // logCSRFSkipperDebug logs debug information for CSRF skipper
 func logCSRFSkipperDebug(c echo.Context, path, method string) {
 	c.Logger().Debug("CSRF skipper check",
@@ -583,8 +686,8 @@ func setupAdditionalSecurityHeadersMiddleware() echo.MiddlewareFunc {
 			// Add exposed headers for CORS (since Echo's CORS doesn't support ExposeHeaders)
 			origin := c.Request().Header.Get("Origin")
 			if origin != "" {
-				// Expose CSRF token header for cross-origin requests
-				c.Response().Header().Set("Access-Control-Expose-Headers", "X-Csrf-Token")
+				// Expose the CSRF token and request ID headers for cross-origin requests
+				c.Response().Header().Set("Access-Control-Expose-Headers", "X-Csrf-Token, "+echo.HeaderXRequestID)
 			}
 
 			return next(c)
@@ -813,9 +916,7 @@ func (l *EchoLogger) Output() io.Writer {
 }
 
 // setupRateLimiting creates and configures rate limiting middleware using infrastructure config
-func (m *Manager) setupRateLimiting() echo.MiddlewareFunc {
-	rateLimitConfig := m.config.Config.Security.RateLimit
-
+func (m *Manager) setupRateLimiting(rateLimitConfig appconfig.RateLimitConfig) echo.MiddlewareFunc {
 	// Validate configuration
 	if err := m.validateRateLimitConfig(rateLimitConfig); err != nil {
 		m.logger.Error("Invalid rate limit configuration", "error", err)
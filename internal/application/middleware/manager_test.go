@@ -184,3 +184,23 @@ func TestManager_RateLimiter_Scenarios(t *testing.T) {
 		})
 	}
 }
+
+func TestRequiresCSRF(t *testing.T) {
+	tests := []struct {
+		method string
+		path   string
+		want   bool
+	}{
+		{http.MethodGet, "/api/v1/forms", false},
+		{http.MethodPost, "/api/v1/forms", true},
+		{http.MethodPut, "/api/v1/forms/:id", false},
+		{http.MethodDelete, "/api/v1/templates/:id", true},
+		{http.MethodPost, "/api/v1/public/forms/:id/submit", false},
+		{http.MethodPost, "/api/v1/erasure/erase", true},
+		{http.MethodPost, "/login", false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, middleware.RequiresCSRF(tt.method, tt.path), tt.method+" "+tt.path)
+	}
+}
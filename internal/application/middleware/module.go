@@ -50,6 +50,7 @@ var Module = fx.Module("middleware",
 				lc fx.Lifecycle,
 				accessManager *access.Manager,
 				pathManager *constants.PathManager,
+				userService user.Service,
 			) *session.Manager {
 				sessionConfig := &session.Config{
 					SessionConfig: &cfg.Session,
@@ -58,7 +59,7 @@ var Module = fx.Module("middleware",
 					StaticPaths:   pathManager.StaticPaths,
 				}
 
-				return session.NewManager(logger, sessionConfig, lc, accessManager)
+				return session.NewManager(logger, sessionConfig, lc, accessManager, userService)
 			},
 		),
 
@@ -106,6 +107,7 @@ var Module = fx.Module("middleware",
 				sessionManager *session.Manager,
 				accessManager *access.Manager,
 				sanitizer sanitization.ServiceInterface,
+				reloader *config.Reloader,
 			) *Manager {
 				return NewManager(&ManagerConfig{
 					Logger:         logger,
@@ -115,6 +117,7 @@ var Module = fx.Module("middleware",
 					SessionManager: sessionManager,
 					AccessManager:  accessManager,
 					Sanitizer:      sanitizer,
+					Reloader:       reloader,
 				})
 			},
 		),
@@ -254,6 +257,10 @@ func generateAccessRules(pathManager *constants.PathManager) []access.Rule {
 	apiPaths := []string{
 		constants.PathAPIv1,
 		constants.PathAPIForms,
+		constants.PathAPITemplates,
+		constants.PathAPIAudit,
+		constants.PathAPIErasure,
+		constants.PathAPILive,
 		constants.PathAPIAdmin,
 		constants.PathAPIAdminUsers,
 		constants.PathAPIAdminForms,
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"sync/atomic"
+
+	"github.com/labstack/echo/v4"
+)
+
+// passThrough is middleware that does nothing, standing in for middleware
+// that is disabled
+func passThrough(next echo.HandlerFunc) echo.HandlerFunc {
+	return next
+}
+
+// reloadableMiddleware is middleware that can be replaced while the server
+// runs, for middleware built from settings that are reloaded. Each request
+// goes through the middleware in place when it arrives.
+type reloadableMiddleware struct {
+	current atomic.Pointer[echo.MiddlewareFunc]
+}
+
+// newReloadableMiddleware creates reloadable middleware starting with mw
+func newReloadableMiddleware(mw echo.MiddlewareFunc) *reloadableMiddleware {
+	r := &reloadableMiddleware{}
+	r.Store(mw)
+
+	return r
+}
+
+// Store replaces the middleware
+func (r *reloadableMiddleware) Store(mw echo.MiddlewareFunc) {
+	r.current.Store(&mw)
+}
+
+// Handler returns the middleware to register with Echo
+func (r *reloadableMiddleware) Handler() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			return (*r.current.Load())(next)(c)
+		}
+	}
+}
@@ -22,6 +22,7 @@ func NewManager(
 	cfg *Config,
 	lc fx.Lifecycle,
 	accessManager *access.Manager,
+	users UserStore,
 ) *Manager {
 	// Create tmp directory if it doesn't exist
 	if err := os.MkdirAll(filepath.Dir(cfg.StoreFile), 0o750); err != nil {
@@ -40,6 +41,7 @@ func NewManager(
 		stopChan:      make(chan struct{}),
 		config:        cfg,
 		accessManager: accessManager,
+		users:         users,
 	}
 
 	// Register lifecycle hooks
@@ -1,6 +1,7 @@
 package session
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
@@ -12,6 +13,7 @@ import (
 	"github.com/goformx/goforms/internal/application/middleware/access"
 	"github.com/goformx/goforms/internal/application/middleware/context"
 	"github.com/goformx/goforms/internal/application/response"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
 )
 
 // Middleware creates a new session middleware
@@ -88,6 +90,24 @@ func (sm *Manager) processSession(c echo.Context, path string, next echo.Handler
 		return sm.handleAuthError(c, "session expired")
 	}
 
+	// Sessions of disabled, deleted or revoked users end on their next request
+	valid, err := sm.sessionValid(c, session)
+	if err != nil {
+		sm.logger.Error("failed to look up session user", "error", err)
+
+		return sm.handleAuthError(c, "session user unavailable")
+	}
+
+	if !valid {
+		sm.DeleteSession(cookie.Value)
+
+		if sm.isPublicPath(path) {
+			return next(c)
+		}
+
+		return sm.handleAuthError(c, "session revoked")
+	}
+
 	// Store session in context (always do this if we have a valid session)
 	c.Set(string(context.SessionKey), session)
 	context.SetUserID(c, session.UserID)
@@ -97,6 +117,21 @@ func (sm *Manager) processSession(c echo.Context, path string, next echo.Handler
 	return next(c)
 }
 
+// sessionValid reports whether the user a session belongs to still exists, is
+// active and has not revoked the session
+func (sm *Manager) sessionValid(c echo.Context, session *Session) (bool, error) {
+	u, err := sm.users.GetUserByID(c.Request().Context(), session.UserID)
+	if errors.Is(err, common.ErrNotFound) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("get session user: %w", err)
+	}
+
+	return u.SessionValid(session.CreatedAt), nil
+}
+
 // shouldSkipSession checks if a path should skip session processing entirely
 func (sm *Manager) shouldSkipSession(path string) bool {
 	// Early returns for different path types
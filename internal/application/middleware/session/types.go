@@ -1,12 +1,14 @@
 package session
 
 import (
+	"context"
 	"sync"
 	"time"
 
 	"github.com/labstack/echo/v4"
 
 	"github.com/goformx/goforms/internal/application/middleware/access"
+	"github.com/goformx/goforms/internal/domain/entities"
 	"github.com/goformx/goforms/internal/infrastructure/config"
 	"github.com/goformx/goforms/internal/infrastructure/logging"
 )
@@ -39,6 +41,11 @@ type Storage interface {
 	Delete(sessionID string) error
 }
 
+// UserStore looks up the user a session belongs to
+type UserStore interface {
+	GetUserByID(ctx context.Context, id string) (*entities.User, error)
+}
+
 // Config extends the base config with additional session-specific settings
 type Config struct {
 	*config.SessionConfig
@@ -61,4 +68,5 @@ type Manager struct {
 	stopChan      chan struct{}
 	config        *Config
 	accessManager *access.Manager
+	users         UserStore
 }
@@ -18,6 +18,8 @@ import (
 	"github.com/goformx/goforms/internal/application/response"
 	"github.com/goformx/goforms/internal/application/validation"
 	"github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/form/reencrypt"
+	"github.com/goformx/goforms/internal/domain/form/retention"
 	"github.com/goformx/goforms/internal/domain/user"
 	"github.com/goformx/goforms/internal/infrastructure/config"
 	"github.com/goformx/goforms/internal/infrastructure/logging"
@@ -104,8 +106,55 @@ var Module = fx.Module("application",
 		provideRecoveryMiddleware,
 	),
 	validation.Module,
+	fx.Invoke(RegisterRetentionJob),
+	fx.Invoke(RegisterReencryptionJob),
 )
 
+// RegisterRetentionJob runs the submission retention job alongside the server
+// unless it is disabled in the form configuration
+func RegisterRetentionJob(
+	lc fx.Lifecycle,
+	cfg *config.Config,
+	service retention.Service,
+	logger logging.Logger,
+) {
+	if !cfg.Form.Retention.Enabled {
+		logger.Info("retention job disabled")
+
+		return
+	}
+
+	job := retention.NewJob(service, logger, cfg.Form.Retention.Interval)
+
+	lc.Append(fx.Hook{
+		OnStart: job.Start,
+		OnStop:  job.Stop,
+	})
+}
+
+// RegisterReencryptionJob runs the job that re-encrypts stored answers after
+// key rotation alongside the server, unless field encryption is not configured
+// or the job is disabled in the form configuration
+func RegisterReencryptionJob(
+	lc fx.Lifecycle,
+	cfg *config.Config,
+	service reencrypt.Service,
+	logger logging.Logger,
+) {
+	if !cfg.Security.Encryption.Enabled() || !cfg.Form.Reencryption.Enabled {
+		logger.Info("re-encryption job disabled")
+
+		return
+	}
+
+	job := reencrypt.NewJob(service, logger, cfg.Form.Reencryption.Interval)
+
+	lc.Append(fx.Hook{
+		OnStart: job.Start,
+		OnStop:  job.Stop,
+	})
+}
+
 // provideRequestUtils creates a new request utils instance with sanitization service
 func provideRequestUtils(sanitizer sanitization.ServiceInterface) *request.Utils {
 	return request.NewUtils(sanitizer)
@@ -0,0 +1,268 @@
+package openapi
+
+import (
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/goformx/goforms/internal/application/response"
+)
+
+// Auth is how a route authenticates its caller
+type Auth int
+
+const (
+	// Public routes need no authentication
+	Public Auth = iota
+	// Session routes need the session cookie of a signed-in user
+	Session
+)
+
+// Security scheme names used in the generated documents
+const (
+	SessionCookieScheme = "sessionCookie"
+	CSRFTokenScheme     = "csrfToken"
+)
+
+// JSONContentType is the content type of JSON bodies
+const JSONContentType = "application/json"
+
+// Route describes one API route
+type Route struct {
+	Method string
+	// Path is the route as registered with echo, with :name parameters
+	Path        string
+	Summary     string
+	Description string
+	Tag         string
+	Auth        Auth
+	// PathParams describes the path parameters, keyed by name
+	PathParams map[string]string
+	Query      []*Parameter
+	// Request is a value of the request body type, or nil when the route
+	// reads no body
+	Request any
+	// Response is a value of the response payload type. It is sent as the
+	// data of the response envelope unless Raw is set; nil means the envelope
+	// carries no data.
+	Response any
+	// Raw routes send Response as the whole body; a raw route without a
+	// Response has no body
+	Raw bool
+	// Produces lists the content types of a raw response. JSON responses use
+	// Response's schema, other types are described as text. Defaults to JSON.
+	Produces []string
+	// Status is the success status. Defaults to 200.
+	Status int
+	// Errors lists the error statuses answered with the response envelope
+	Errors []int
+	// DomainErrors lists the error statuses answered with the typed error body
+	DomainErrors []int
+}
+
+// QueryParam describes an optional query parameter of the given JSON type
+func QueryParam(name, typ, description string) *Parameter {
+	return &Parameter{Name: name, In: "query", Description: description, Schema: &Schema{Type: typ}}
+}
+
+// Config holds the document-wide settings
+type Config struct {
+	Info Info
+	Tags []Tag
+	// SessionCookie is the name of the session cookie
+	SessionCookie string
+	// CSRFHeader is the header carrying the CSRF token
+	CSRFHeader string
+	// RequiresCSRF reports whether a route needs a CSRF token
+	RequiresCSRF func(method, path string) bool
+}
+
+var pathParam = regexp.MustCompile(`:(\w+)`)
+
+// Build generates the document describing routes
+func Build(cfg *Config, routes []Route) *Document {
+	schemas := NewSchemas()
+	doc := &Document{
+		OpenAPI: Version,
+		Info:    cfg.Info,
+		Tags:    cfg.Tags,
+		Paths:   make(map[string]*PathItem),
+	}
+
+	for i := range routes {
+		route := &routes[i]
+		path := pathParam.ReplaceAllString(route.Path, "{$1}")
+
+		item, ok := doc.Paths[path]
+		if !ok {
+			item = &PathItem{}
+			doc.Paths[path] = item
+		}
+
+		(*item)[strings.ToLower(route.Method)] = operation(cfg, schemas, route)
+	}
+
+	doc.Components = Components{
+		Schemas: schemas.Components(),
+		SecuritySchemes: map[string]*SecurityScheme{
+			SessionCookieScheme: {
+				Type:        "apiKey",
+				In:          "cookie",
+				Name:        cfg.SessionCookie,
+				Description: "Session cookie set by signing in at /login",
+			},
+			CSRFTokenScheme: {
+				Type: "apiKey",
+				In:   "header",
+				Name: cfg.CSRFHeader,
+				Description: "CSRF token, read from the csrf-token meta tag of any page. " +
+					"Only required outside development.",
+			},
+		},
+	}
+
+	return doc
+}
+
+// operation builds the operation of one route
+func operation(cfg *Config, schemas *Schemas, route *Route) *Operation {
+	op := &Operation{
+		OperationID: operationID(route.Method, route.Path),
+		Summary:     route.Summary,
+		Description: route.Description,
+		Responses:   make(map[string]*Response),
+	}
+
+	if route.Tag != "" {
+		op.Tags = []string{route.Tag}
+	}
+
+	for _, match := range pathParam.FindAllStringSubmatch(route.Path, -1) {
+		op.Parameters = append(op.Parameters, &Parameter{
+			Name:        match[1],
+			In:          "path",
+			Description: route.PathParams[match[1]],
+			Required:    true,
+			Schema:      &Schema{Type: "string"},
+		})
+	}
+
+	op.Parameters = append(op.Parameters, route.Query...)
+
+	if route.Request != nil {
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]*MediaType{JSONContentType: {Schema: schemas.For(route.Request)}},
+		}
+	}
+
+	status := route.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	op.Responses[strconv.Itoa(status)] = &Response{
+		Description: http.StatusText(status),
+		Content:     successContent(schemas, route),
+	}
+
+	errorStatuses := route.Errors
+	if route.Auth == Session {
+		requirement := SecurityRequirement{SessionCookieScheme: {}}
+		if cfg.RequiresCSRF != nil && cfg.RequiresCSRF(route.Method, route.Path) {
+			requirement[CSRFTokenScheme] = []string{}
+		}
+
+		op.Security = []SecurityRequirement{requirement}
+		errorStatuses = append([]int{http.StatusUnauthorized}, errorStatuses...)
+	}
+
+	for _, code := range errorStatuses {
+		op.Responses[strconv.Itoa(code)] = errorResponse(schemas.For(response.APIResponse{}), code)
+	}
+
+	for _, code := range route.DomainErrors {
+		op.Responses[strconv.Itoa(code)] = errorResponse(schemas.For(response.APIErrorResponse{}), code)
+	}
+
+	return op
+}
+
+// successContent returns the content of a route's success response
+func successContent(schemas *Schemas, route *Route) map[string]*MediaType {
+	if !route.Raw {
+		envelope := &Schema{
+			Type:       "object",
+			Properties: map[string]*Schema{"success": {Const: true}},
+		}
+		if route.Response != nil {
+			envelope.Properties["data"] = schemas.For(route.Response)
+			envelope.Required = []string{"data"}
+		}
+
+		return map[string]*MediaType{JSONContentType: {
+			Schema: &Schema{AllOf: []*Schema{schemas.For(response.APIResponse{}), envelope}},
+		}}
+	}
+
+	if route.Response == nil {
+		return nil
+	}
+
+	produces := route.Produces
+	if len(produces) == 0 {
+		produces = []string{JSONContentType}
+	}
+
+	content := make(map[string]*MediaType, len(produces))
+	for _, contentType := range produces {
+		if contentType == JSONContentType {
+			content[contentType] = &MediaType{Schema: schemas.For(route.Response)}
+		} else {
+			content[contentType] = &MediaType{Schema: &Schema{Type: "string"}}
+		}
+	}
+
+	return content
+}
+
+// errorResponse describes an error status answered with the given body
+func errorResponse(body *Schema, code int) *Response {
+	return &Response{
+		Description: http.StatusText(code),
+		Content:     map[string]*MediaType{JSONContentType: {Schema: body}},
+	}
+}
+
+// operationID derives a stable operation ID from a route, such as
+// "getApiV1FormsIdSubmissions" for GET /api/v1/forms/:id/submissions
+func operationID(method, path string) string {
+	var b strings.Builder
+
+	b.WriteString(strings.ToLower(method))
+
+	words := strings.FieldsFunc(path, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, word := range words {
+		b.WriteString(exportedName(word))
+	}
+
+	return b.String()
+}
+
+// Operations lists the method and path of every route, as "METHOD path", for
+// comparison with the routes a router has registered
+func Operations(routes []Route) []string {
+	ops := make([]string, 0, len(routes))
+	for i := range routes {
+		ops = append(ops, routes[i].Method+" "+routes[i].Path)
+	}
+
+	slices.Sort(ops)
+
+	return ops
+}
@@ -0,0 +1,214 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// SchemaRefPrefix is the prefix of references to component schemas
+const SchemaRefPrefix = "#/components/schemas/"
+
+var (
+	timeType       = reflect.TypeFor[time.Time]()
+	rawMessageType = reflect.TypeFor[json.RawMessage]()
+)
+
+// Schemas turns Go types into JSON Schemas the way encoding/json would encode
+// them. Named struct types become component schemas referenced with $ref.
+//
+// Struct fields follow their json tags: fields tagged "-" are left out and
+// fields without omitempty are required. Two extra tags refine a field's
+// schema: format sets its format, as in format:"date-time" on a string that
+// holds a timestamp, and enum lists its allowed values separated by commas.
+type Schemas struct {
+	components map[string]*Schema
+	names      map[reflect.Type]string
+}
+
+// NewSchemas creates an empty schema registry
+func NewSchemas() *Schemas {
+	return &Schemas{
+		components: make(map[string]*Schema),
+		names:      make(map[reflect.Type]string),
+	}
+}
+
+// Components returns the component schemas registered so far, keyed by name
+func (s *Schemas) Components() map[string]*Schema {
+	return s.components
+}
+
+// For returns the schema of v's type. A nil v gives the empty schema, which
+// accepts any value.
+func (s *Schemas) For(v any) *Schema {
+	if v == nil {
+		return &Schema{}
+	}
+
+	return s.schema(reflect.TypeOf(v))
+}
+
+// schema returns the schema of t
+func (s *Schemas) schema(t reflect.Type) *Schema {
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t == rawMessageType:
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		elem := s.schema(t.Elem())
+		if typ, ok := elem.Type.(string); ok && elem.Ref == "" {
+			elem.Type = []string{typ, "null"}
+		}
+
+		return elem
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+
+		return &Schema{Type: "array", Items: s.schema(t.Elem())}
+	case reflect.Map:
+		schema := &Schema{Type: "object"}
+		if values := s.schema(t.Elem()); !isEmpty(values) {
+			schema.AdditionalProperties = values
+		}
+
+		return schema
+	case reflect.Struct:
+		if t.Name() == "" {
+			return s.object(t)
+		}
+
+		return &Schema{Ref: SchemaRefPrefix + s.component(t)}
+	default:
+		return &Schema{}
+	}
+}
+
+// component registers a named struct type and returns its component name
+func (s *Schemas) component(t reflect.Type) string {
+	if name, ok := s.names[t]; ok {
+		return name
+	}
+
+	name := t.Name()
+	if _, taken := s.components[name]; taken {
+		name = exportedName(pkgName(t)) + name
+	}
+
+	s.names[t] = name
+	// Reserve the name before building the schema so that recursive types
+	// refer to it instead of recursing forever
+	s.components[name] = &Schema{}
+	*s.components[name] = *s.object(t)
+
+	return name
+}
+
+// object returns the inline schema of a struct type
+func (s *Schemas) object(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	s.addFields(schema, t)
+
+	return schema
+}
+
+// addFields adds the fields of a struct type to an object schema, promoting
+// the fields of embedded structs as encoding/json does
+func (s *Schemas) addFields(schema *Schema, t reflect.Type) {
+	for i := range t.NumField() {
+		field := t.Field(i)
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+
+			if embedded.Kind() == reflect.Struct {
+				s.addFields(schema, embedded)
+
+				continue
+			}
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		property := s.schema(field.Type)
+		if format := field.Tag.Get("format"); format != "" {
+			property.Format = format
+		}
+
+		if enum := field.Tag.Get("enum"); enum != "" {
+			for _, value := range strings.Split(enum, ",") {
+				property.Enum = append(property.Enum, value)
+			}
+		}
+
+		schema.Properties[name] = property
+
+		if !hasOption(opts, "omitempty") {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+}
+
+// hasOption reports whether a json tag's options include option
+func hasOption(opts, option string) bool {
+	for _, o := range strings.Split(opts, ",") {
+		if o == option {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isEmpty reports whether a schema accepts any value
+func isEmpty(schema *Schema) bool {
+	return reflect.DeepEqual(schema, &Schema{})
+}
+
+// pkgName returns the last element of a type's package path
+func pkgName(t reflect.Type) string {
+	path := t.PkgPath()
+
+	return path[strings.LastIndex(path, "/")+1:]
+}
+
+// exportedName upper-cases the first letter of name
+func exportedName(name string) string {
+	if name == "" {
+		return name
+	}
+
+	return strings.ToUpper(name[:1]) + name[1:]
+}
@@ -0,0 +1,115 @@
+package openapi_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/application/openapi"
+)
+
+type base struct {
+	ID string `json:"id"`
+}
+
+type node struct {
+	base
+	Name      string            `json:"name"`
+	Note      string            `json:"note,omitempty"`
+	Secret    string            `json:"-"`
+	Count     *int              `json:"count"`
+	Created   time.Time         `json:"created"`
+	Day       string            `json:"day" format:"date"`
+	Kind      string            `json:"kind" enum:"a,b"`
+	Labels    map[string]string `json:"labels"`
+	Extra     map[string]any    `json:"extra"`
+	Children  []*node           `json:"children"`
+	Raw       []byte            `json:"raw"`
+	NoTag     bool
+	unexposed int
+}
+
+func TestSchemas_For(t *testing.T) {
+	schemas := openapi.NewSchemas()
+
+	ref := schemas.For([]node{})
+	assert.Equal(t, "array", ref.Type)
+	assert.Equal(t, openapi.SchemaRefPrefix+"node", ref.Items.Ref)
+
+	schema := schemas.Components()["node"]
+	require.NotNil(t, schema)
+	assert.Equal(t, []string{
+		"id", "name", "count", "created", "day", "kind", "labels", "extra", "children", "raw", "NoTag",
+	}, schema.Required)
+	assert.NotContains(t, schema.Properties, "Secret")
+	assert.NotContains(t, schema.Properties, "unexposed")
+	assert.Contains(t, schema.Properties, "note")
+
+	props := schema.Properties
+	assert.Equal(t, "string", props["id"].Type, "embedded fields are promoted")
+	assert.Equal(t, []string{"integer", "null"}, props["count"].Type)
+	assert.Equal(t, &openapi.Schema{Type: "string", Format: "date-time"}, props["created"])
+	assert.Equal(t, "date", props["day"].Format)
+	assert.Equal(t, []any{"a", "b"}, props["kind"].Enum)
+	assert.Equal(t, &openapi.Schema{Type: "string"}, props["labels"].AdditionalProperties)
+	assert.Nil(t, props["extra"].AdditionalProperties)
+	assert.Equal(t, openapi.SchemaRefPrefix+"node", props["children"].Items.Ref, "recursive types use $ref")
+	assert.Equal(t, "byte", props["raw"].Format)
+	assert.Equal(t, "boolean", props["NoTag"].Type)
+
+	assert.Equal(t, &openapi.Schema{}, schemas.For(nil))
+}
+
+func TestBuild(t *testing.T) {
+	doc := openapi.Build(&openapi.Config{
+		Info:          openapi.Info{Title: "Test", Version: "v1"},
+		SessionCookie: "session",
+		CSRFHeader:    "X-Csrf-Token",
+		RequiresCSRF: func(method, _ string) bool {
+			return method != http.MethodGet
+		},
+	}, []openapi.Route{
+		{
+			Method: http.MethodGet, Path: "/items/:id", Summary: "Get an item", Auth: openapi.Public,
+			PathParams: map[string]string{"id": "Item ID"},
+			Response:   base{},
+			Errors:     []int{http.StatusNotFound},
+		},
+		{
+			Method: http.MethodDelete, Path: "/items/:id", Summary: "Delete an item", Auth: openapi.Session,
+			Status: http.StatusNoContent, Raw: true,
+		},
+	})
+
+	assert.Equal(t, openapi.Version, doc.OpenAPI)
+
+	item := *doc.Paths["/items/{id}"]
+	get := item["get"]
+	assert.Equal(t, "getItemsId", get.OperationID)
+	assert.Empty(t, get.Security)
+	assert.Equal(t, &openapi.Parameter{
+		Name: "id", In: "path", Description: "Item ID", Required: true, Schema: &openapi.Schema{Type: "string"},
+	}, get.Parameters[0])
+
+	envelope := get.Responses["200"].Content[openapi.JSONContentType].Schema
+	require.Len(t, envelope.AllOf, 2)
+	assert.Equal(t, openapi.SchemaRefPrefix+"APIResponse", envelope.AllOf[0].Ref)
+	assert.Equal(t, openapi.SchemaRefPrefix+"base", envelope.AllOf[1].Properties["data"].Ref)
+	assert.Contains(t, get.Responses, "404")
+
+	del := item["delete"]
+	assert.Equal(t, []openapi.SecurityRequirement{
+		{openapi.SessionCookieScheme: {}, openapi.CSRFTokenScheme: {}},
+	}, del.Security)
+	assert.Contains(t, del.Responses, "401")
+	assert.Nil(t, del.Responses["204"].Content)
+
+	assert.Equal(t, []string{"DELETE /items/:id", "GET /items/:id"}, openapi.Operations([]openapi.Route{
+		{Method: http.MethodGet, Path: "/items/:id"},
+		{Method: http.MethodDelete, Path: "/items/:id"},
+	}))
+	assert.Equal(t, "X-Csrf-Token", doc.Components.SecuritySchemes[openapi.CSRFTokenScheme].Name)
+}
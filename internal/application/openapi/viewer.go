@@ -0,0 +1,11 @@
+package openapi
+
+import "embed"
+
+// Viewer holds the static docs viewer: index.html, viewer.js and viewer.css.
+// The page loads the document from /api/openapi.json and expects the script
+// and stylesheet under /api/docs/. It is self-contained so that it works
+// under a same-origin content security policy.
+//
+//go:embed viewer/*
+var Viewer embed.FS
@@ -0,0 +1,149 @@
+// Renders the OpenAPI document named by the script's data-spec attribute.
+// Schemas are shown as example JSON with their component references resolved.
+(function () {
+  "use strict";
+
+  var specURL = document.currentScript.getAttribute("data-spec");
+
+  function el(tag, attrs, children) {
+    var node = document.createElement(tag);
+    Object.keys(attrs || {}).forEach(function (name) {
+      node.setAttribute(name, attrs[name]);
+    });
+    (children || []).forEach(function (child) {
+      node.append(child);
+    });
+    return node;
+  }
+
+  function example(spec, schema, seen) {
+    if (!schema) return null;
+    if (schema.$ref) {
+      var name = schema.$ref.split("/").pop();
+      if (seen.indexOf(name) >= 0) return "<" + name + ">";
+      return example(spec, spec.components.schemas[name], seen.concat(name));
+    }
+    if (schema.allOf) {
+      return schema.allOf.reduce(function (merged, part) {
+        return Object.assign(merged, example(spec, part, seen));
+      }, {});
+    }
+    if (schema.const !== undefined) return schema.const;
+    if (schema.enum) return schema.enum.join(" | ");
+    var type = Array.isArray(schema.type) ? schema.type[0] : schema.type;
+    switch (type) {
+      case "object":
+        var obj = {};
+        Object.keys(schema.properties || {}).forEach(function (key) {
+          obj[key] = example(spec, schema.properties[key], seen);
+        });
+        if (schema.additionalProperties) {
+          obj["<key>"] = example(spec, schema.additionalProperties, seen);
+        }
+        return obj;
+      case "array":
+        return [example(spec, schema.items, seen)];
+      case "string":
+        return schema.format ? "<" + schema.format + ">" : "string";
+      case "integer":
+        return 0;
+      case "number":
+        return 0.0;
+      case "boolean":
+        return true;
+      default:
+        return "any";
+    }
+  }
+
+  function jsonBlock(spec, content) {
+    var types = Object.keys(content || {});
+    return types.map(function (type) {
+      var body = JSON.stringify(example(spec, content[type].schema, []), null, 2);
+      return el("div", {}, [el("code", {}, [type]), el("pre", {}, [body])]);
+    });
+  }
+
+  function renderOperation(spec, path, method, op) {
+    var body = el("div", { class: "operation-body" });
+    if (op.description) body.append(el("p", {}, [op.description]));
+
+    var security = (op.security || []).map(function (req) {
+      return Object.keys(req).join(" + ");
+    });
+    body.append(el("p", {}, ["Authentication: " + (security.join(" or ") || "none")]));
+
+    if (op.parameters && op.parameters.length) {
+      var rows = op.parameters.map(function (p) {
+        var type = p.schema && p.schema.type ? p.schema.type : "";
+        return el("tr", {}, [
+          el("td", {}, [el("code", {}, [p.name])]),
+          el("td", {}, [p.in]),
+          el("td", {}, [String(type)]),
+          el("td", {}, [p.description || ""]),
+        ]);
+      });
+      body.append(el("h4", {}, ["Parameters"]), el("table", {}, rows));
+    }
+
+    if (op.requestBody) {
+      body.append(el("h4", {}, ["Request body"]));
+      jsonBlock(spec, op.requestBody.content).forEach(function (n) { body.append(n); });
+    }
+
+    body.append(el("h4", {}, ["Responses"]));
+    Object.keys(op.responses).sort().forEach(function (status) {
+      var res = op.responses[status];
+      body.append(el("p", {}, [el("strong", {}, [status]), " " + res.description]));
+      jsonBlock(spec, res.content).forEach(function (n) { body.append(n); });
+    });
+
+    var summary = el("summary", {}, [
+      el("span", { class: "method method-" + method }, [method]),
+      el("code", {}, [path]),
+      " " + op.summary,
+    ]);
+    if (security.some(function (s) { return s.indexOf("csrfToken") >= 0; })) {
+      summary.append(el("span", { class: "badge" }, ["CSRF"]));
+    }
+
+    return el("details", { class: "operation", id: op.operationId }, [summary, body]);
+  }
+
+  function render(spec) {
+    document.title = spec.info.title;
+    document.getElementById("title").textContent = spec.info.title + " " + spec.info.version;
+    document.getElementById("description").textContent = spec.info.description || "";
+
+    var groups = {};
+    Object.keys(spec.paths).sort().forEach(function (path) {
+      Object.keys(spec.paths[path]).forEach(function (method) {
+        var op = spec.paths[path][method];
+        var tag = (op.tags || ["other"])[0];
+        (groups[tag] = groups[tag] || []).push(renderOperation(spec, path, method, op));
+      });
+    });
+
+    var nav = document.getElementById("tags");
+    var main = document.getElementById("operations");
+    main.replaceChildren();
+    (spec.tags || []).map(function (t) { return t.name; })
+      .concat(Object.keys(groups).sort())
+      .filter(function (tag, i, all) { return groups[tag] && all.indexOf(tag) === i; })
+      .forEach(function (tag) {
+        nav.append(el("a", { href: "#tag-" + tag }, [tag]));
+        main.append(el("h2", { id: "tag-" + tag }, [tag]));
+        groups[tag].forEach(function (n) { main.append(n); });
+      });
+  }
+
+  fetch(specURL, { credentials: "same-origin" })
+    .then(function (res) {
+      if (!res.ok) throw new Error(res.status + " " + res.statusText);
+      return res.json();
+    })
+    .then(render)
+    .catch(function (err) {
+      document.getElementById("operations").textContent = "Could not load " + specURL + ": " + err.message;
+    });
+})();
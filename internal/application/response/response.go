@@ -6,13 +6,27 @@ import (
 	"net/http"
 
 	"github.com/labstack/echo/v4"
+
+	domainerrors "github.com/goformx/goforms/internal/domain/common/errors"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
 )
 
-// APIResponse represents a standardized API response structure
+// APIResponse represents a standardized API response structure. Error
+// responses carry the request ID, which support can look up in the logs.
 type APIResponse struct {
-	Success bool   `json:"success"`
-	Message string `json:"message,omitempty"`
-	Data    any    `json:"data,omitempty"`
+	Success   bool   `json:"success"`
+	Message   string `json:"message,omitempty"`
+	Data      any    `json:"data,omitempty"`
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// APIErrorResponse is the typed error body of the REST API. Message repeats
+// the error's message for clients that only read APIResponse.
+type APIErrorResponse struct {
+	Success   bool                       `json:"success"`
+	Message   string                     `json:"message"`
+	Error     domainerrors.ErrorResponse `json:"error"`
+	RequestID string                     `json:"request_id,omitempty"`
 }
 
 // Success sends a successful response with the given data
@@ -27,8 +41,25 @@ func Success(c echo.Context, data any) error {
 // ErrorResponse sends an error response with a custom status code
 func ErrorResponse(c echo.Context, statusCode int, message string) error {
 	return c.JSON(statusCode, APIResponse{
-		Success: false,
-		Message: message,
-		Data:    nil,
+		Success:   false,
+		Message:   message,
+		Data:      nil,
+		RequestID: RequestID(c),
+	})
+}
+
+// DomainErrorResponse sends a domain error as a typed error body, with the
+// HTTP status its error code maps to
+func DomainErrorResponse(c echo.Context, err *domainerrors.DomainError) error {
+	return c.JSON(err.HTTPStatus(), APIErrorResponse{
+		Success:   false,
+		Message:   err.Message,
+		Error:     err.ToResponse(),
+		RequestID: RequestID(c),
 	})
 }
+
+// RequestID returns the ID of the request being answered
+func RequestID(c echo.Context) string {
+	return logging.RequestIDFromContext(c.Request().Context())
+}
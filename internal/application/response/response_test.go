@@ -0,0 +1,67 @@
+package response_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/application/response"
+	domainerrors "github.com/goformx/goforms/internal/domain/common/errors"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+func TestDomainErrorResponse(t *testing.T) {
+	tests := []struct {
+		code   domainerrors.ErrorCode
+		status int
+	}{
+		{domainerrors.ErrCodeInvalidInput, http.StatusBadRequest},
+		{domainerrors.ErrCodeUnauthorized, http.StatusUnauthorized},
+		{domainerrors.ErrCodeForbidden, http.StatusForbidden},
+		{domainerrors.ErrCodeFormNotFound, http.StatusNotFound},
+		{domainerrors.ErrCodeConflict, http.StatusConflict},
+		{domainerrors.ErrCodeServerError, http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/", http.NoBody), rec)
+
+		err := domainerrors.New(tt.code, "went wrong", nil).WithContext("field", "title")
+		require.NoError(t, response.DomainErrorResponse(c, err))
+		assert.Equal(t, tt.status, rec.Code, tt.code)
+
+		var body response.APIErrorResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.False(t, body.Success)
+		assert.Equal(t, "went wrong", body.Message)
+		assert.Equal(t, string(tt.code), body.Error.Code)
+		assert.Equal(t, "went wrong", body.Error.Message)
+		assert.Equal(t, map[string]any{"field": "title"}, body.Error.Details)
+	}
+}
+
+func TestErrorResponse_RequestID(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	req = req.WithContext(logging.ContextWithRequestID(req.Context(), "req-1"))
+	rec := httptest.NewRecorder()
+
+	require.NoError(t, response.ErrorResponse(echo.New().NewContext(req, rec), http.StatusBadRequest, "bad"))
+
+	var body response.APIResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, "req-1", body.RequestID)
+
+	rec = httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	require.NoError(t, response.DomainErrorResponse(c, domainerrors.New(domainerrors.ErrCodeNotFound, "gone", nil)))
+
+	var errorBody response.APIErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &errorBody))
+	assert.Equal(t, "req-1", errorBody.RequestID)
+}
@@ -22,6 +22,25 @@ func NewComprehensiveValidator() *ComprehensiveValidator {
 	}
 }
 
+// ForLocale returns a copy of the validator that reports messages in locale.
+// Messages configured on the form's components are used as they are, so pass
+// the schema translated into the same locale to ValidateForm.
+func (v *ComprehensiveValidator) ForLocale(locale string) *ComprehensiveValidator {
+	return &ComprehensiveValidator{
+		fieldValidator: v.fieldValidator.WithLocale(locale),
+		schemaParser:   v.schemaParser,
+	}
+}
+
+// WithRules returns a copy of the validator that looks custom rules up in
+// registry instead of the default one
+func (v *ComprehensiveValidator) WithRules(registry *RuleRegistry) *ComprehensiveValidator {
+	return &ComprehensiveValidator{
+		fieldValidator: v.fieldValidator.WithRules(registry),
+		schemaParser:   v.schemaParser,
+	}
+}
+
 // ValidateForm validates a form submission against its schema
 func (v *ComprehensiveValidator) ValidateForm(schema, submission model.JSON) Result {
 	result := Result{
@@ -35,7 +54,7 @@ func (v *ComprehensiveValidator) ValidateForm(schema, submission model.JSON) Res
 		result.IsValid = false
 		result.Errors = append(result.Errors, Error{
 			Field:   "schema",
-			Message: "Invalid form schema: missing components",
+			Message: v.fieldValidator.localizer.T("validation.schema_missing_components"),
 			Rule:    "",
 		})
 
@@ -66,17 +85,12 @@ func (v *ComprehensiveValidator) validateComponent(component map[string]any, sub
 		return []Error{}
 	}
 
-	// Get field value from submission
-	fieldValue, exists := submission[key]
-	if !exists {
-		fieldValue = nil
-	}
-
 	// Extract validation rules
 	validation := v.schemaParser.ExtractValidationRules(component)
 
-	// Validate field using field validator
-	return v.fieldValidator.ValidateField(key, fieldValue, &validation)
+	// Validate field using field validator, which sees the whole submission
+	// so that rules can compare fields
+	return v.fieldValidator.ValidateSubmissionField(key, submission, &validation)
 }
 
 // GenerateClientValidation generates client-side validation rules from schema
@@ -96,7 +110,13 @@ func (v *ComprehensiveValidator) GenerateClientValidation(schema model.JSON) (ma
 			}
 
 			validation := v.schemaParser.ExtractValidationRules(componentMap)
-			clientRules[key] = v.schemaParser.ConvertToClientRules(&validation)
+			fieldRules := v.schemaParser.ConvertToClientRules(&validation)
+
+			if rules := v.fieldValidator.ClientRules(&validation); len(rules) > 0 {
+				fieldRules["rules"] = rules
+			}
+
+			clientRules[key] = fieldRules
 		}
 	}
 
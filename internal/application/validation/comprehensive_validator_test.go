@@ -294,3 +294,50 @@ func TestComprehensiveValidator_Integration(t *testing.T) {
 	require.False(t, result.IsValid)
 	assert.NotEmpty(t, result.Errors)
 }
+
+func TestComprehensiveValidator_ForLocale(t *testing.T) {
+	schema := model.JSON{
+		"components": []any{
+			map[string]any{
+				"key": "name", "type": "textfield",
+				"validate": map[string]any{"required": true, "minLength": float64(3)},
+			},
+			map[string]any{
+				"key": "nickname", "type": "textfield",
+				"validate": map[string]any{"required": true},
+				"errors":   map[string]any{"required": "Dites-nous comment vous appeler"},
+			},
+			map[string]any{
+				"key": "contact", "type": "email",
+				"validate": map[string]any{"customMessage": "Adresse invalide"},
+			},
+			map[string]any{
+				"key": "topic", "type": "select",
+				"data": map[string]any{"values": []any{
+					map[string]any{"label": "Facturation", "value": "billing"},
+				}},
+			},
+		},
+	}
+
+	validator := setupTestComprehensiveValidator()
+	fr := validator.ForLocale("fr")
+
+	result := fr.ValidateForm(schema, model.JSON{"name": "Al", "contact": "nope", "topic": "billing"})
+	require.False(t, result.IsValid)
+	assert.Equal(t, []validation.Error{
+		{Field: "name", Message: "La longueur minimale est de 3 caractères", Rule: "minLength"},
+		{Field: "nickname", Message: "Dites-nous comment vous appeler", Rule: "required"},
+		{Field: "contact", Message: "Adresse invalide", Rule: "email"},
+	}, result.Errors)
+
+	// Options are checked by value, whatever their label
+	result = fr.ValidateForm(schema, model.JSON{"name": "Ada", "nickname": "A", "topic": "Facturation"})
+	assert.Equal(t, []validation.Error{
+		{Field: "topic", Message: "Option sélectionnée invalide", Rule: "options"},
+	}, result.Errors)
+
+	// The original validator keeps reporting in English
+	result = validator.ValidateForm(schema, model.JSON{"nickname": "A"})
+	assert.Equal(t, "This field is required", result.Errors[0].Message)
+}
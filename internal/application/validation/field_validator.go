@@ -1,22 +1,26 @@
 package validation
 
 import (
-	"fmt"
 	"regexp"
 	"strconv"
+
+	"github.com/goformx/goforms/internal/infrastructure/i18n"
 )
 
 // FieldValidator handles field-specific validation logic
 type FieldValidator struct {
+	localizer  i18n.Localizer
+	rules      *RuleRegistry
 	emailRegex *regexp.Regexp
 	urlRegex   *regexp.Regexp
 	phoneRegex *regexp.Regexp
 	dateRegex  *regexp.Regexp
 }
 
-// NewFieldValidator creates a new field validator
+// NewFieldValidator creates a new field validator with messages in the default locale
 func NewFieldValidator() *FieldValidator {
 	return &FieldValidator{
+		rules:      DefaultRules(),
 		emailRegex: regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`),
 		urlRegex:   regexp.MustCompile(`^https?://[^\s/$.?#].\S*$`),
 		phoneRegex: regexp.MustCompile(`^\+?[1-9]\d{0,15}$`),
@@ -24,23 +28,54 @@ func NewFieldValidator() *FieldValidator {
 	}
 }
 
-// ValidateField validates a single field against its rules
+// WithLocale returns a copy of the validator that reports messages in locale
+func (v *FieldValidator) WithLocale(locale string) *FieldValidator {
+	localized := *v
+	localized.localizer = i18n.For(locale)
+
+	return &localized
+}
+
+// WithRules returns a copy of the validator that looks custom rules up in registry
+func (v *FieldValidator) WithRules(registry *RuleRegistry) *FieldValidator {
+	withRules := *v
+	withRules.rules = registry
+
+	return &withRules
+}
+
+// ValidateField validates a single field against its rules. Rules that refer
+// to other fields only see this one; use ValidateSubmissionField for those.
 func (v *FieldValidator) ValidateField(fieldName string, value any, rules *FieldValidation) []Error {
+	return v.ValidateSubmissionField(fieldName, map[string]any{fieldName: value}, rules)
+}
+
+// ValidateSubmissionField validates a field of a submission against its
+// rules. Custom rules can compare the field with the rest of the submission.
+func (v *FieldValidator) ValidateSubmissionField(
+	fieldName string,
+	submission map[string]any,
+	rules *FieldValidation,
+) []Error {
 	var errors []Error
 
+	value := submission[fieldName]
+
 	// Required field validation
 	if requiredErrors := v.validateRequired(fieldName, value, rules); len(requiredErrors) > 0 {
 		errors = append(errors, requiredErrors...)
 	}
 
-	// Skip further validation if field is empty and not required
+	// Skip further validation if field is empty and not required, except for
+	// the custom rules that check empty fields
 	if value == nil || value == "" {
-		return errors
+		return append(errors, v.validateCustomRules(fieldName, submission, rules, true)...)
 	}
 
 	// Type validation
-	if typeErrors := v.ValidateFieldType(fieldName, value, rules.Type); typeErrors != nil {
-		errors = append(errors, *typeErrors)
+	if typeError := v.ValidateFieldType(fieldName, value, rules.Type); typeError != nil {
+		typeError.Message = rules.getMessage(typeError.Rule, typeError.Message)
+		errors = append(errors, *typeError)
 	}
 
 	// String-specific validations
@@ -54,17 +89,17 @@ func (v *FieldValidator) ValidateField(fieldName string, value any, rules *Field
 	}
 
 	// Pattern validation
-	if patternErrors := v.validatePattern(fieldName, value, rules.Pattern); len(patternErrors) > 0 {
+	if patternErrors := v.validatePattern(fieldName, value, rules); len(patternErrors) > 0 {
 		errors = append(errors, patternErrors...)
 	}
 
 	// Options validation
-	if optionsErrors := v.validateOptions(fieldName, value, rules.Options); len(optionsErrors) > 0 {
+	if optionsErrors := v.validateOptions(fieldName, value, rules); len(optionsErrors) > 0 {
 		errors = append(errors, optionsErrors...)
 	}
 
 	// Custom rules validation
-	if customErrors := v.validateCustomRules(fieldName, value, rules.CustomRules); len(customErrors) > 0 {
+	if customErrors := v.validateCustomRules(fieldName, submission, rules, false); len(customErrors) > 0 {
 		errors = append(errors, customErrors...)
 	}
 
@@ -76,7 +111,7 @@ func (v *FieldValidator) validateRequired(fieldName string, value any, rules *Fi
 	if rules.Required && (value == nil || value == "") {
 		return []Error{{
 			Field:   fieldName,
-			Message: rules.getMessage("required", "This field is required"),
+			Message: rules.getMessage("required", v.localizer.T("validation.required")),
 			Rule:    "required",
 		}}
 	}
@@ -92,7 +127,7 @@ func (v *FieldValidator) validateStringField(fieldName string, value any, rules
 		if rules.MinLength > 0 && len(strValue) < rules.MinLength {
 			errors = append(errors, Error{
 				Field:   fieldName,
-				Message: rules.getMessage("minLength", fmt.Sprintf("Minimum length is %d characters", rules.MinLength)),
+				Message: rules.getMessage("minLength", v.localizer.T("validation.min_length", "min", rules.MinLength)),
 				Rule:    "minLength",
 			})
 		}
@@ -100,7 +135,7 @@ func (v *FieldValidator) validateStringField(fieldName string, value any, rules
 		if rules.MaxLength > 0 && len(strValue) > rules.MaxLength {
 			errors = append(errors, Error{
 				Field:   fieldName,
-				Message: rules.getMessage("maxLength", fmt.Sprintf("Maximum length is %d characters", rules.MaxLength)),
+				Message: rules.getMessage("maxLength", v.localizer.T("validation.max_length", "max", rules.MaxLength)),
 				Rule:    "maxLength",
 			})
 		}
@@ -117,7 +152,7 @@ func (v *FieldValidator) validateNumericField(fieldName string, value any, rules
 		if rules.Min != 0 && numValue < rules.Min {
 			errors = append(errors, Error{
 				Field:   fieldName,
-				Message: rules.getMessage("min", fmt.Sprintf("Minimum value is %g", rules.Min)),
+				Message: rules.getMessage("min", v.localizer.T("validation.min", "min", rules.Min)),
 				Rule:    "min",
 			})
 		}
@@ -125,7 +160,7 @@ func (v *FieldValidator) validateNumericField(fieldName string, value any, rules
 		if rules.Max != 0 && numValue > rules.Max {
 			errors = append(errors, Error{
 				Field:   fieldName,
-				Message: rules.getMessage("max", fmt.Sprintf("Maximum value is %g", rules.Max)),
+				Message: rules.getMessage("max", v.localizer.T("validation.max", "max", rules.Max)),
 				Rule:    "max",
 			})
 		}
@@ -135,17 +170,17 @@ func (v *FieldValidator) validateNumericField(fieldName string, value any, rules
 }
 
 // validatePattern validates that a value matches a regex pattern
-func (v *FieldValidator) validatePattern(fieldName string, value any, pattern string) []Error {
-	if pattern == "" {
+func (v *FieldValidator) validatePattern(fieldName string, value any, rules *FieldValidation) []Error {
+	if rules.Pattern == "" {
 		return nil
 	}
 
 	if strValue, ok := value.(string); ok {
-		matched, err := regexp.MatchString(pattern, strValue)
+		matched, err := regexp.MatchString(rules.Pattern, strValue)
 		if err != nil {
 			return []Error{{
 				Field:   fieldName,
-				Message: fmt.Sprintf("Invalid regex pattern: %v", err),
+				Message: v.localizer.T("validation.invalid_pattern", "error", err),
 				Rule:    "pattern",
 			}}
 		}
@@ -153,7 +188,7 @@ func (v *FieldValidator) validatePattern(fieldName string, value any, pattern st
 		if !matched {
 			return []Error{{
 				Field:   fieldName,
-				Message: "Value does not match required pattern",
+				Message: rules.getMessage("pattern", v.localizer.T("validation.pattern")),
 				Rule:    "pattern",
 			}}
 		}
@@ -163,13 +198,13 @@ func (v *FieldValidator) validatePattern(fieldName string, value any, pattern st
 }
 
 // validateOptions validates that a value is in the allowed options
-func (v *FieldValidator) validateOptions(fieldName string, value any, options []string) []Error {
-	if len(options) == 0 {
+func (v *FieldValidator) validateOptions(fieldName string, value any, rules *FieldValidation) []Error {
+	if len(rules.Options) == 0 {
 		return nil
 	}
 
 	if strValue, ok := value.(string); ok {
-		for _, option := range options {
+		for _, option := range rules.Options {
 			if strValue == option {
 				return nil
 			}
@@ -177,7 +212,7 @@ func (v *FieldValidator) validateOptions(fieldName string, value any, options []
 
 		return []Error{{
 			Field:   fieldName,
-			Message: "Invalid option selected",
+			Message: rules.getMessage("options", v.localizer.T("validation.options")),
 			Rule:    "options",
 		}}
 	}
@@ -185,12 +220,22 @@ func (v *FieldValidator) validateOptions(fieldName string, value any, options []
 	return nil
 }
 
-// validateCustomRules validates custom validation rules
-func (v *FieldValidator) validateCustomRules(fieldName string, value any, rules []Rule) []Error {
+// validateCustomRules validates the custom rules whose condition holds. When
+// onlyEmpty is set the field is empty and only rules that check empty fields run.
+func (v *FieldValidator) validateCustomRules(
+	fieldName string,
+	submission map[string]any,
+	rules *FieldValidation,
+	onlyEmpty bool,
+) []Error {
 	var errors []Error
 
-	for _, rule := range rules {
-		if ruleError := v.validateCustomRule(fieldName, value, rule); ruleError != nil {
+	for _, rule := range rules.CustomRules {
+		if !conditionMet(rule.Condition, submission) {
+			continue
+		}
+
+		if ruleError := v.validateCustomRule(fieldName, submission, rule, rules, onlyEmpty); ruleError != nil {
 			errors = append(errors, *ruleError)
 		}
 	}
@@ -224,7 +269,7 @@ func (v *FieldValidator) validateEmail(fieldName string, value any) *Error {
 		if !v.emailRegex.MatchString(strValue) {
 			return &Error{
 				Field:   fieldName,
-				Message: "Invalid email format",
+				Message: v.localizer.T("validation.email"),
 				Rule:    "email",
 			}
 		}
@@ -239,7 +284,7 @@ func (v *FieldValidator) validateURL(fieldName string, value any) *Error {
 		if !v.urlRegex.MatchString(strValue) {
 			return &Error{
 				Field:   fieldName,
-				Message: "Invalid URL format",
+				Message: v.localizer.T("validation.url"),
 				Rule:    "url",
 			}
 		}
@@ -254,7 +299,7 @@ func (v *FieldValidator) validatePhoneNumber(fieldName string, value any) *Error
 		if !v.phoneRegex.MatchString(strValue) {
 			return &Error{
 				Field:   fieldName,
-				Message: "Invalid phone number format",
+				Message: v.localizer.T("validation.phone"),
 				Rule:    "phoneNumber",
 			}
 		}
@@ -269,7 +314,7 @@ func (v *FieldValidator) validateDate(fieldName string, value any) *Error {
 		if !v.dateRegex.MatchString(strValue) {
 			return &Error{
 				Field:   fieldName,
-				Message: "Invalid date format (YYYY-MM-DD)",
+				Message: v.localizer.T("validation.date"),
 				Rule:    "date",
 			}
 		}
@@ -283,7 +328,7 @@ func (v *FieldValidator) validateNumber(fieldName string, value any) *Error {
 	if _, ok := v.toFloat64(value); !ok {
 		return &Error{
 			Field:   fieldName,
-			Message: "Value must be a number",
+			Message: v.localizer.T("validation.number"),
 			Rule:    "number",
 		}
 	}
@@ -297,14 +342,14 @@ func (v *FieldValidator) validateInteger(fieldName string, value any) *Error {
 		if floatValue != float64(int(floatValue)) {
 			return &Error{
 				Field:   fieldName,
-				Message: "Value must be an integer",
+				Message: v.localizer.T("validation.integer"),
 				Rule:    "integer",
 			}
 		}
 	} else {
 		return &Error{
 			Field:   fieldName,
-			Message: "Value must be an integer",
+			Message: v.localizer.T("validation.integer"),
 			Rule:    "integer",
 		}
 	}
@@ -312,17 +357,89 @@ func (v *FieldValidator) validateInteger(fieldName string, value any) *Error {
 	return nil
 }
 
-// validateCustomRule validates a custom validation rule
-func (v *FieldValidator) validateCustomRule(fieldName string, value any, rule Rule) *Error {
+// validateCustomRule validates a custom validation rule. Rule types other
+// than regex and custom are looked up in the rule registry; unknown ones pass.
+func (v *FieldValidator) validateCustomRule(
+	fieldName string,
+	submission map[string]any,
+	rule Rule,
+	rules *FieldValidation,
+	onlyEmpty bool,
+) *Error {
+	value := submission[fieldName]
+
 	switch rule.Type {
-	case "regex":
+	case ruleRegex:
+		if onlyEmpty {
+			return nil
+		}
+
 		return v.validateRegexRule(fieldName, value, rule)
-	case "custom":
+	case ruleCustom:
 		// Custom validation logic can be extended here
 		return nil
 	}
 
-	return nil
+	def, ok := v.registry().Lookup(rule.Type)
+	if !ok || (onlyEmpty && !def.CheckEmpty) {
+		return nil
+	}
+
+	if def.Check(&RuleContext{Field: fieldName, Value: value, Rule: rule, Submission: submission}) {
+		return nil
+	}
+
+	return &Error{
+		Field:   fieldName,
+		Message: v.ruleMessage(rule, def, rules),
+		Rule:    rule.Type,
+	}
+}
+
+// ruleMessage returns the message of a failed registered rule: the rule's
+// own, then the field's, then the rule's default
+func (v *FieldValidator) ruleMessage(rule Rule, def RuleDefinition, rules *FieldValidation) string {
+	if rule.Message != "" {
+		return rule.Message
+	}
+
+	return rules.getMessage(rule.Type, v.localizer.T(def.MessageKey, "value", rule.Value))
+}
+
+// ClientRules returns the field's custom rules for client-side validation,
+// with the messages the server would report so both sides agree. Rules the
+// server doesn't know are left out.
+func (v *FieldValidator) ClientRules(rules *FieldValidation) []Rule {
+	clientRules := make([]Rule, 0, len(rules.CustomRules))
+
+	for _, rule := range rules.CustomRules {
+		switch rule.Type {
+		case ruleRegex:
+		case ruleCustom:
+			continue
+		default:
+			def, ok := v.registry().Lookup(rule.Type)
+			if !ok {
+				continue
+			}
+
+			rule.Message = v.ruleMessage(rule, def, rules)
+		}
+
+		clientRules = append(clientRules, rule)
+	}
+
+	return clientRules
+}
+
+// registry returns the rule registry, falling back to the default one for
+// validators that weren't created with NewFieldValidator
+func (v *FieldValidator) registry() *RuleRegistry {
+	if v.rules == nil {
+		return DefaultRules()
+	}
+
+	return v.rules
 }
 
 // validateRegexRule validates a regex rule
@@ -341,7 +458,7 @@ func (v *FieldValidator) validateRegexRule(fieldName string, value any, rule Rul
 	if err != nil {
 		return &Error{
 			Field:   fieldName,
-			Message: fmt.Sprintf("Invalid regex pattern: %v", err),
+			Message: v.localizer.T("validation.invalid_pattern", "error", err),
 			Rule:    rule.Type,
 		}
 	}
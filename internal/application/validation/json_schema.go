@@ -0,0 +1,531 @@
+package validation
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+// JSONSchemaDialect is the JSON Schema draft the converter reads and writes
+const JSONSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// Component types that hold no submitted value
+var layoutComponents = map[string]bool{
+	"button":      true,
+	"htmlelement": true,
+	"content":     true,
+}
+
+var (
+	// ErrInvalidJSONSchema is returned when an imported document is not a JSON
+	// Schema describing an object
+	ErrInvalidJSONSchema = errors.New("invalid JSON Schema")
+	// ErrUnsupportedJSONSchema is returned when an imported property has no
+	// matching form component
+	ErrUnsupportedJSONSchema = errors.New("unsupported JSON Schema")
+)
+
+// JSONSchemaInfo describes the document a form schema is converted into
+type JSONSchemaInfo struct {
+	// ID is the document's $id; left out when empty
+	ID          string
+	Title       string
+	Description string
+}
+
+// ImportedForm is a form definition built from a JSON Schema
+type ImportedForm struct {
+	Title       string
+	Description string
+	Schema      model.JSON
+}
+
+// JSONSchemaConverter converts form schemas to and from JSON Schema. Fields
+// are described by the same rules the server validates submissions with.
+type JSONSchemaConverter struct {
+	schemaParser *SchemaParser
+}
+
+// NewJSONSchemaConverter creates a new JSON Schema converter
+func NewJSONSchemaConverter() *JSONSchemaConverter {
+	return &JSONSchemaConverter{
+		schemaParser: NewSchemaParser(),
+	}
+}
+
+// ToJSONSchema describes the submissions of a form schema as a JSON Schema
+// document. Custom rules have no JSON Schema equivalent and are left out.
+func (c *JSONSchemaConverter) ToJSONSchema(schema model.JSON, info JSONSchemaInfo) (map[string]any, error) {
+	components, ok := c.schemaParser.ExtractComponents(schema)
+	if !ok {
+		return nil, errors.New("invalid schema: missing components")
+	}
+
+	properties := make(map[string]any)
+	required := []string{}
+
+	for _, component := range components {
+		componentMap, componentOk := component.(map[string]any)
+		if !componentOk || !hasValue(componentMap) {
+			continue
+		}
+
+		key, keyOk := c.schemaParser.ExtractComponentKey(componentMap)
+		if !keyOk {
+			continue
+		}
+
+		validation := c.schemaParser.ExtractValidationRules(componentMap)
+		properties[key] = propertySchema(componentMap, &validation)
+
+		if validation.Required {
+			required = append(required, key)
+		}
+	}
+
+	document := map[string]any{
+		"$schema":    JSONSchemaDialect,
+		"type":       "object",
+		"properties": properties,
+		"required":   required,
+	}
+
+	if info.ID != "" {
+		document["$id"] = info.ID
+	}
+
+	if info.Title != "" {
+		document["title"] = info.Title
+	}
+
+	if info.Description != "" {
+		document["description"] = info.Description
+	}
+
+	return document, nil
+}
+
+// hasValue reports whether a component is submitted with the form
+func hasValue(component map[string]any) bool {
+	if input, ok := component["input"].(bool); ok && !input {
+		return false
+	}
+
+	componentType, _ := component["type"].(string)
+
+	return !layoutComponents[componentType]
+}
+
+// propertySchema describes the value of one component
+func propertySchema(component map[string]any, validation *FieldValidation) map[string]any {
+	property := valueSchema(component, validation)
+
+	if label, ok := component["label"].(string); ok && label != "" {
+		property["title"] = label
+	}
+
+	if description, ok := component["description"].(string); ok && description != "" {
+		property["description"] = description
+	}
+
+	return property
+}
+
+// valueSchema returns the type of a component's value with its constraints
+func valueSchema(component map[string]any, validation *FieldValidation) map[string]any {
+	switch validation.Type {
+	case "checkbox":
+		return map[string]any{"type": "boolean"}
+	case "selectboxes":
+		boxes := make(map[string]any, len(validation.Options))
+		for _, option := range validation.Options {
+			boxes[option] = map[string]any{"type": "boolean"}
+		}
+
+		return map[string]any{"type": "object", "properties": boxes, "additionalProperties": false}
+	case "select", "radio":
+		property := map[string]any{"type": "string"}
+		if len(validation.Options) > 0 {
+			property["enum"] = validation.Options
+		}
+
+		if multiple, _ := component["multiple"].(bool); multiple {
+			return map[string]any{"type": "array", "items": property}
+		}
+
+		return property
+	case "number", "integer":
+		return numberSchema(component, validation)
+	}
+
+	property := map[string]any{"type": "string"}
+
+	switch validation.Type {
+	case "email":
+		property["format"] = "email"
+	case "url":
+		property["format"] = "uri"
+	case "date":
+		property["format"] = "date"
+	case "datetime":
+		// Form.io submits a date and time even when only the date is picked
+		property["format"] = "date-time"
+	}
+
+	if validation.MinLength > 0 {
+		property["minLength"] = validation.MinLength
+	}
+
+	if validation.MaxLength > 0 {
+		property["maxLength"] = validation.MaxLength
+	}
+
+	if validation.Pattern != "" {
+		property["pattern"] = validation.Pattern
+	}
+
+	return property
+}
+
+// numberSchema describes a number component. Numbers without decimals are
+// integers.
+func numberSchema(component map[string]any, validation *FieldValidation) map[string]any {
+	property := map[string]any{"type": "number"}
+
+	if decimals, ok := component["decimalLimit"].(float64); validation.Type == "integer" || (ok && decimals == 0) {
+		property["type"] = "integer"
+	}
+
+	// Zero means no limit, as in the server-side checks
+	if validation.Min != 0 {
+		property["minimum"] = validation.Min
+	}
+
+	if validation.Max != 0 {
+		property["maximum"] = validation.Max
+	}
+
+	return property
+}
+
+// FromJSONSchema builds a form from a JSON Schema document describing an
+// object. Each property becomes a component, in the order the document lists
+// them, followed by a submit button.
+func (c *JSONSchemaConverter) FromJSONSchema(data []byte) (*ImportedForm, error) {
+	var document struct {
+		Type        any                        `json:"type"`
+		Title       string                     `json:"title"`
+		Description string                     `json:"description"`
+		Properties  map[string]json.RawMessage `json:"properties"`
+		Required    []string                   `json:"required"`
+	}
+
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidJSONSchema, err)
+	}
+
+	if document.Type != nil && document.Type != "object" {
+		return nil, fmt.Errorf("%w: the document must describe an object", ErrInvalidJSONSchema)
+	}
+
+	if len(document.Properties) == 0 {
+		return nil, fmt.Errorf("%w: the document has no properties", ErrInvalidJSONSchema)
+	}
+
+	order, err := propertyOrder(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidJSONSchema, err)
+	}
+
+	required := make(map[string]bool, len(document.Required))
+	for _, key := range document.Required {
+		required[key] = true
+	}
+
+	components := make([]any, 0, len(order)+1)
+
+	for _, key := range order {
+		var property jsonSchemaProperty
+		if unmarshalErr := json.Unmarshal(document.Properties[key], &property); unmarshalErr != nil {
+			return nil, fmt.Errorf("%w: property %q: %w", ErrInvalidJSONSchema, key, unmarshalErr)
+		}
+
+		component, componentErr := property.component(key, required[key])
+		if componentErr != nil {
+			return nil, componentErr
+		}
+
+		components = append(components, component)
+	}
+
+	components = append(components, map[string]any{
+		"type":   "button",
+		"key":    "submit",
+		"label":  "Submit",
+		"input":  true,
+		"action": "submit",
+	})
+
+	return &ImportedForm{
+		Title:       document.Title,
+		Description: document.Description,
+		Schema:      model.JSON{"type": "object", "components": components},
+	}, nil
+}
+
+// jsonSchemaProperty holds the keywords of a property the import understands
+type jsonSchemaProperty struct {
+	Type        any                           `json:"type"`
+	Title       string                        `json:"title"`
+	Description string                        `json:"description"`
+	Format      string                        `json:"format"`
+	Enum        []any                         `json:"enum"`
+	MinLength   *float64                      `json:"minLength"`
+	MaxLength   *float64                      `json:"maxLength"`
+	Minimum     *float64                      `json:"minimum"`
+	Maximum     *float64                      `json:"maximum"`
+	Pattern     string                        `json:"pattern"`
+	Items       *jsonSchemaProperty           `json:"items"`
+	Properties  map[string]jsonSchemaProperty `json:"properties"`
+}
+
+// component builds the form component for a property
+func (p *jsonSchemaProperty) component(key string, required bool) (map[string]any, error) {
+	label := p.Title
+	if label == "" {
+		label = key
+	}
+
+	component := map[string]any{"key": key, "label": label, "input": true}
+	if p.Description != "" {
+		component["description"] = p.Description
+	}
+
+	validate := map[string]any{}
+	if required {
+		validate["required"] = true
+	}
+
+	switch p.typeName() {
+	case "string":
+		p.stringComponent(component, validate)
+	case "number", "integer":
+		p.numberComponent(component, validate)
+	case "boolean":
+		component["type"] = "checkbox"
+	case "array":
+		if p.Items == nil || len(p.Items.Enum) == 0 {
+			return nil, fmt.Errorf("%w: array property %q needs an enum of items", ErrUnsupportedJSONSchema, key)
+		}
+
+		component["type"] = "select"
+		component["multiple"] = true
+		component["data"] = map[string]any{"values": enumValues(p.Items.Enum)}
+	case "object":
+		values, ok := p.checkboxValues()
+		if !ok {
+			return nil, fmt.Errorf("%w: object property %q must only hold booleans", ErrUnsupportedJSONSchema, key)
+		}
+
+		component["type"] = "selectboxes"
+		component["values"] = values
+	default:
+		return nil, fmt.Errorf("%w: property %q has no supported type", ErrUnsupportedJSONSchema, key)
+	}
+
+	if len(validate) > 0 {
+		component["validate"] = validate
+	}
+
+	return component, nil
+}
+
+// typeName returns the property's type. Of a list of types only the first one
+// other than null is used; enums without a type are strings.
+func (p *jsonSchemaProperty) typeName() string {
+	switch t := p.Type.(type) {
+	case string:
+		return t
+	case []any:
+		for _, item := range t {
+			if name, ok := item.(string); ok && name != "null" {
+				return name
+			}
+		}
+	case nil:
+		if len(p.Enum) > 0 {
+			return "string"
+		}
+	}
+
+	return ""
+}
+
+// stringComponent fills in a text, email, URL, date or select component
+func (p *jsonSchemaProperty) stringComponent(component, validate map[string]any) {
+	if len(p.Enum) > 0 {
+		component["type"] = "select"
+		component["data"] = map[string]any{"values": enumValues(p.Enum)}
+
+		return
+	}
+
+	switch p.Format {
+	case "email":
+		component["type"] = "email"
+	case "uri", "url":
+		component["type"] = "url"
+	case "date":
+		component["type"] = "datetime"
+		component["enableTime"] = false
+	case "date-time":
+		component["type"] = "datetime"
+	default:
+		component["type"] = "textfield"
+	}
+
+	if p.MinLength != nil {
+		validate["minLength"] = *p.MinLength
+	}
+
+	if p.MaxLength != nil {
+		validate["maxLength"] = *p.MaxLength
+	}
+
+	if p.Pattern != "" {
+		validate["pattern"] = p.Pattern
+	}
+}
+
+// numberComponent fills in a number component
+func (p *jsonSchemaProperty) numberComponent(component, validate map[string]any) {
+	component["type"] = "number"
+	if p.typeName() == "integer" {
+		component["decimalLimit"] = float64(0)
+	}
+
+	if p.Minimum != nil {
+		validate["min"] = *p.Minimum
+	}
+
+	if p.Maximum != nil {
+		validate["max"] = *p.Maximum
+	}
+}
+
+// checkboxValues returns the options of an object of booleans, in key order
+func (p *jsonSchemaProperty) checkboxValues() ([]any, bool) {
+	if len(p.Properties) == 0 {
+		return nil, false
+	}
+
+	keys := make([]string, 0, len(p.Properties))
+
+	for key, box := range p.Properties {
+		if box.typeName() != "boolean" {
+			return nil, false
+		}
+
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	options := make([]any, 0, len(keys))
+	for _, key := range keys {
+		options = append(options, key)
+	}
+
+	return enumValues(options), true
+}
+
+// enumValues returns the select options for the values of an enum
+func enumValues(enum []any) []any {
+	values := make([]any, 0, len(enum))
+
+	for _, item := range enum {
+		value := fmt.Sprint(item)
+		values = append(values, map[string]any{"label": value, "value": value})
+	}
+
+	return values
+}
+
+// propertyOrder returns the keys of the document's properties in the order
+// they are written, which decoding into a map loses
+func propertyOrder(data []byte) ([]string, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	if err := expectDelim(dec, '{'); err != nil {
+		return nil, err
+	}
+
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("read document: %w", err)
+		}
+
+		if key, _ := token.(string); key != "properties" {
+			var skip json.RawMessage
+			if skipErr := dec.Decode(&skip); skipErr != nil {
+				return nil, fmt.Errorf("read document: %w", skipErr)
+			}
+
+			continue
+		}
+
+		return objectKeys(dec)
+	}
+
+	return nil, nil
+}
+
+// objectKeys reads an object from dec and returns its keys in order, each once
+func objectKeys(dec *json.Decoder) ([]string, error) {
+	if err := expectDelim(dec, '{'); err != nil {
+		return nil, err
+	}
+
+	var keys []string
+
+	seen := make(map[string]bool)
+
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("read properties: %w", err)
+		}
+
+		key, _ := token.(string)
+		if !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+
+		var skip json.RawMessage
+		if skipErr := dec.Decode(&skip); skipErr != nil {
+			return nil, fmt.Errorf("read property %q: %w", key, skipErr)
+		}
+	}
+
+	return keys, nil
+}
+
+// expectDelim reads the next token from dec and checks that it is delim
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	token, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("read document: %w", err)
+	}
+
+	if token != delim {
+		return fmt.Errorf("expected %q", delim)
+	}
+
+	return nil
+}
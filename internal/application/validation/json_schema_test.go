@@ -0,0 +1,126 @@
+package validation_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/application/validation"
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+func TestJSONSchemaConverter_ToJSONSchema(t *testing.T) {
+	converter := validation.NewJSONSchemaConverter()
+
+	schema := model.JSON{
+		"type": "object",
+		"components": []any{
+			map[string]any{
+				"key": "name", "type": "textfield", "label": "Name", "input": true,
+				"validate": map[string]any{"required": true, "minLength": float64(2), "pattern": "^[A-Z]"},
+			},
+			map[string]any{"key": "email", "type": "email", "label": "Email", "input": true},
+			map[string]any{
+				"key": "age", "type": "number", "input": true, "decimalLimit": float64(0),
+				"validate": map[string]any{"min": float64(18)},
+			},
+			map[string]any{
+				"key": "size", "type": "select", "input": true,
+				"data": map[string]any{"values": []any{
+					map[string]any{"label": "Small", "value": "s"},
+					map[string]any{"label": "Large", "value": "l"},
+				}},
+			},
+			map[string]any{
+				"key": "score", "type": "radio", "input": true,
+				"values": []any{map[string]any{"label": "1", "value": "1"}},
+			},
+			map[string]any{"key": "terms", "type": "checkbox", "input": true},
+			map[string]any{"key": "intro", "type": "htmlelement"},
+			map[string]any{"key": "submit", "type": "button", "input": true},
+		},
+	}
+
+	info := validation.JSONSchemaInfo{ID: "https://example.com/s", Title: "Signup"}
+
+	document, err := converter.ToJSONSchema(schema, info)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]any{
+		"$schema": validation.JSONSchemaDialect,
+		"$id":     "https://example.com/s",
+		"title":   "Signup",
+		"type":    "object",
+		"properties": map[string]any{
+			"name":  map[string]any{"type": "string", "title": "Name", "minLength": 2, "pattern": "^[A-Z]"},
+			"email": map[string]any{"type": "string", "format": "email", "title": "Email"},
+			"age":   map[string]any{"type": "integer", "minimum": float64(18)},
+			"size":  map[string]any{"type": "string", "enum": []string{"s", "l"}},
+			"score": map[string]any{"type": "string", "enum": []string{"1"}},
+			"terms": map[string]any{"type": "boolean"},
+		},
+		"required": []string{"name"},
+	}, document)
+
+	_, err = converter.ToJSONSchema(model.JSON{}, validation.JSONSchemaInfo{})
+	require.Error(t, err)
+}
+
+func TestJSONSchemaConverter_FromJSONSchema(t *testing.T) {
+	converter := validation.NewJSONSchemaConverter()
+
+	imported, err := converter.FromJSONSchema([]byte(`{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"title": "Contact",
+		"type": "object",
+		"properties": {
+			"name": {"type": "string", "title": "Full name", "maxLength": 50},
+			"email": {"type": ["string", "null"], "format": "email"},
+			"count": {"type": "integer", "minimum": 1},
+			"topic": {"enum": ["sales", "support"]},
+			"agree": {"type": "boolean"}
+		},
+		"required": ["name", "agree"]
+	}`))
+	require.NoError(t, err)
+	assert.Equal(t, "Contact", imported.Title)
+
+	components, ok := imported.Schema["components"].([]any)
+	require.True(t, ok)
+
+	keys := make([]string, 0, len(components))
+	for _, component := range components {
+		keys = append(keys, component.(map[string]any)["key"].(string)) //nolint:forcetypeassert // built by the import
+	}
+
+	assert.Equal(t, []string{"name", "email", "count", "topic", "agree", "submit"}, keys, "properties keep their order")
+	assert.Equal(t, map[string]any{
+		"key": "name", "label": "Full name", "input": true, "type": "textfield",
+		"validate": map[string]any{"required": true, "maxLength": float64(50)},
+	}, components[0])
+	assert.Equal(t, "email", components[1].(map[string]any)["type"]) //nolint:forcetypeassert // built by the import
+
+	// The imported form converts back to the same rules
+	document, err := converter.ToJSONSchema(imported.Schema, validation.JSONSchemaInfo{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"name", "agree"}, document["required"])
+	assert.Equal(t, map[string]any{
+		"name":  map[string]any{"type": "string", "title": "Full name", "maxLength": 50},
+		"email": map[string]any{"type": "string", "format": "email", "title": "email"},
+		"count": map[string]any{"type": "integer", "title": "count", "minimum": float64(1)},
+		"topic": map[string]any{"type": "string", "title": "topic", "enum": []string{"sales", "support"}},
+		"agree": map[string]any{"type": "boolean", "title": "agree"},
+	}, document["properties"])
+
+	for name, data := range map[string]string{
+		"not JSON":      `{`,
+		"not an object": `{"type": "array", "items": {"type": "string"}}`,
+		"no properties": `{"type": "object"}`,
+		"nested object": `{"properties": {"address": {"type": "object", "properties": {"city": {"type": "string"}}}}}`,
+		"unknown type":  `{"properties": {"x": {}}}`,
+	} {
+		_, err = converter.FromJSONSchema([]byte(data))
+		require.Error(t, err, name)
+	}
+}
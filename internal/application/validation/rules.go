@@ -0,0 +1,333 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Built-in rule types. Fields list them in their component's validate.rules.
+const (
+	// RuleRequired fails when the field is empty. With a condition it makes a
+	// field required only when another field is set.
+	RuleRequired = "required"
+	// RuleEqualTo fails unless the field equals the field named by the rule's value
+	RuleEqualTo = "equal_to"
+	// RuleAfter fails unless the field's date or number is after the field named
+	// by the rule's value
+	RuleAfter = "after"
+	// RuleBefore fails unless the field's date or number is before the field
+	// named by the rule's value
+	RuleBefore = "before"
+	// RuleAtLeastOne fails when the field and every field listed in the rule's
+	// value are all empty
+	RuleAtLeastOne = "at_least_one"
+)
+
+// Rule types handled by the field validator itself
+const (
+	ruleRegex  = "regex"
+	ruleCustom = "custom"
+)
+
+var (
+	// ErrRuleExists is returned when a rule is registered under a name already in use
+	ErrRuleExists = errors.New("validation rule already registered")
+	// ErrInvalidRule is returned when a rule has no name or no check
+	ErrInvalidRule = errors.New("invalid validation rule")
+)
+
+// RuleContext is what a rule sees when it checks a field
+type RuleContext struct {
+	// Field is the key of the field the rule is attached to
+	Field string
+	// Value is the field's submitted value
+	Value any
+	// Rule is the rule as configured on the field
+	Rule Rule
+	// Submission holds every submitted value, so rules can compare fields
+	Submission map[string]any
+}
+
+// Ref returns the value of the field named by the rule's value
+func (rc *RuleContext) Ref() any {
+	key, _ := rc.Rule.Value.(string)
+
+	return rc.Submission[key]
+}
+
+// RuleDefinition describes a named rule
+type RuleDefinition struct {
+	// Check reports whether the field satisfies the rule
+	Check func(rc *RuleContext) bool
+	// MessageKey is the i18n key of the message reported when the rule fails
+	// and neither the rule nor the field configures one. The message can use
+	// {value} for the rule's value.
+	MessageKey string
+	// CheckEmpty also runs the rule on empty fields. Other rules pass empty
+	// fields, leaving them to required.
+	CheckEmpty bool
+}
+
+// RuleRegistry holds the named rules form fields can use besides the
+// built-in per-field checks
+type RuleRegistry struct {
+	mu    sync.RWMutex
+	rules map[string]RuleDefinition
+}
+
+// NewRuleRegistry creates a registry holding the built-in rules
+func NewRuleRegistry() *RuleRegistry {
+	r := &RuleRegistry{rules: make(map[string]RuleDefinition)}
+
+	for name, def := range builtinRules() {
+		r.rules[name] = def
+	}
+
+	return r
+}
+
+// Register adds a rule under name
+func (r *RuleRegistry) Register(name string, def RuleDefinition) error {
+	if name == "" || def.Check == nil {
+		return fmt.Errorf("%w: %q needs a name and a check", ErrInvalidRule, name)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.rules[name]; exists || name == ruleRegex || name == ruleCustom {
+		return fmt.Errorf("%w: %s", ErrRuleExists, name)
+	}
+
+	r.rules[name] = def
+
+	return nil
+}
+
+// Lookup returns the rule registered under name
+func (r *RuleRegistry) Lookup(name string) (RuleDefinition, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	def, ok := r.rules[name]
+
+	return def, ok
+}
+
+// Names returns the names of the registered rules in order
+func (r *RuleRegistry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.rules))
+	for name := range r.rules {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// defaultRules is the registry validators use unless given another
+var defaultRules = NewRuleRegistry()
+
+// DefaultRules returns the registry validators use by default
+func DefaultRules() *RuleRegistry {
+	return defaultRules
+}
+
+// RegisterRule adds a rule to the default registry. Call it during
+// initialization, before forms are validated.
+func RegisterRule(name string, def RuleDefinition) error {
+	return defaultRules.Register(name, def)
+}
+
+// builtinRules returns the rules every registry starts with
+func builtinRules() map[string]RuleDefinition {
+	return map[string]RuleDefinition{
+		RuleRequired: {
+			Check:      func(rc *RuleContext) bool { return !isEmpty(rc.Value) },
+			MessageKey: "validation.required",
+			CheckEmpty: true,
+		},
+		RuleEqualTo: {
+			Check: func(rc *RuleContext) bool {
+				return fmt.Sprint(rc.Value) == fmt.Sprint(rc.Ref())
+			},
+			MessageKey: "validation.equal_to",
+		},
+		RuleAfter: {
+			Check: func(rc *RuleContext) bool {
+				order := compareValues(rc.Value, rc.Ref())
+
+				return order != -1 && order != 0
+			},
+			MessageKey: "validation.after",
+		},
+		RuleBefore: {
+			Check: func(rc *RuleContext) bool {
+				order := compareValues(rc.Value, rc.Ref())
+
+				return order != 1 && order != 0
+			},
+			MessageKey: "validation.before",
+		},
+		RuleAtLeastOne: {
+			Check:      checkAtLeastOne,
+			MessageKey: "validation.at_least_one",
+			CheckEmpty: true,
+		},
+	}
+}
+
+// checkAtLeastOne passes when the field or any of the listed fields is filled in
+func checkAtLeastOne(rc *RuleContext) bool {
+	if !isEmpty(rc.Value) {
+		return true
+	}
+
+	for _, key := range ruleFields(rc.Rule.Value) {
+		if !isEmpty(rc.Submission[key]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ruleFields returns the field keys listed in a rule's value: a single key, a
+// comma-separated list, or a list of keys
+func ruleFields(value any) []string {
+	var keys []string
+
+	switch v := value.(type) {
+	case string:
+		for key := range strings.SplitSeq(v, ",") {
+			if key = strings.TrimSpace(key); key != "" {
+				keys = append(keys, key)
+			}
+		}
+	case []string:
+		keys = v
+	case []any:
+		for _, item := range v {
+			if key, ok := item.(string); ok {
+				keys = append(keys, key)
+			}
+		}
+	}
+
+	return keys
+}
+
+// compareValues compares two dates or two numbers, returning -1, 0 or 1. It
+// returns 2 when the values can't be compared, such as when the other field is
+// empty, so that the rule passes and type checks report bad input.
+func compareValues(a, b any) int {
+	const incomparable = 2
+
+	if ta, ok := toTime(a); ok {
+		if tb, okB := toTime(b); okB {
+			return ta.Compare(tb)
+		}
+
+		return incomparable
+	}
+
+	fa, okA := toNumber(a)
+	fb, okB := toNumber(b)
+
+	switch {
+	case !okA || !okB:
+		return incomparable
+	case fa < fb:
+		return -1
+	case fa > fb:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// dateLayouts are the date formats the comparison rules understand
+var dateLayouts = []string{time.RFC3339, "2006-01-02T15:04", "2006-01-02"}
+
+// toTime parses a date value
+func toTime(value any) (time.Time, bool) {
+	s, ok := value.(string)
+	if !ok {
+		return time.Time{}, false
+	}
+
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// toNumber converts a number or numeric string
+func toNumber(value any) (float64, bool) {
+	return (&FieldValidator{}).toFloat64(value)
+}
+
+// isEmpty reports whether a field was left empty. Unticked checkboxes and
+// select boxes with nothing selected count as empty.
+func isEmpty(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return strings.TrimSpace(v) == ""
+	case bool:
+		return !v
+	case map[string]any:
+		for _, item := range v {
+			if !isEmpty(item) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Kind() == reflect.Slice || rv.Kind() == reflect.Map {
+		return rv.Len() == 0
+	}
+
+	return false
+}
+
+// conditionMet reports whether a rule's condition holds for a submission. An
+// empty condition always holds; "field" holds when the field is filled in or
+// ticked, "!field" when it is not, and "field=value" or "field!=value" compare
+// the field's value.
+func conditionMet(condition string, submission map[string]any) bool {
+	condition = strings.TrimSpace(condition)
+
+	switch {
+	case condition == "":
+		return true
+	case strings.Contains(condition, "!="):
+		key, want, _ := strings.Cut(condition, "!=")
+
+		return fmt.Sprint(submission[strings.TrimSpace(key)]) != strings.TrimSpace(want)
+	case strings.Contains(condition, "="):
+		key, want, _ := strings.Cut(condition, "=")
+
+		return fmt.Sprint(submission[strings.TrimSpace(key)]) == strings.TrimSpace(want)
+	case strings.HasPrefix(condition, "!"):
+		return isEmpty(submission[strings.TrimSpace(condition[1:])])
+	default:
+		return !isEmpty(submission[condition])
+	}
+}
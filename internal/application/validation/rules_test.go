@@ -0,0 +1,159 @@
+package validation_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/application/validation"
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+// ruleSchema returns a schema of text fields, with rules on some of them
+func ruleSchema(rules map[string][]any) model.JSON {
+	components := make([]any, 0, len(rules))
+
+	for _, key := range []string{"password", "confirm", "start", "end", "contact", "email", "phone"} {
+		component := map[string]any{"key": key, "type": "textfield"}
+		if fieldRules, ok := rules[key]; ok {
+			component["validate"] = map[string]any{"rules": fieldRules}
+		}
+
+		components = append(components, component)
+	}
+
+	return model.JSON{"components": components}
+}
+
+func failedRules(result validation.Result) []string {
+	failed := make([]string, 0, len(result.Errors))
+	for _, err := range result.Errors {
+		failed = append(failed, err.Field+":"+err.Rule)
+	}
+
+	return failed
+}
+
+func TestComprehensiveValidator_CrossFieldRules(t *testing.T) {
+	validator := validation.NewComprehensiveValidator()
+
+	schema := ruleSchema(map[string][]any{
+		"confirm": {map[string]any{"type": "equal_to", "value": "password"}},
+		"end":     {map[string]any{"type": "after", "value": "start", "message": "Ends before it starts"}},
+		"email":   {map[string]any{"type": "required", "condition": "contact=email"}},
+		"phone":   {map[string]any{"type": "at_least_one", "value": []any{"email"}}},
+	})
+
+	tests := []struct {
+		name       string
+		submission model.JSON
+		want       []string
+	}{
+		{
+			name: "valid",
+			submission: model.JSON{
+				"password": "secret", "confirm": "secret",
+				"start": "2026-01-01", "end": "2026-01-02",
+				"contact": "email", "email": "a@example.com",
+			},
+			want: []string{},
+		},
+		{
+			name: "fields disagree",
+			submission: model.JSON{
+				"password": "secret", "confirm": "other",
+				"start": "2026-01-02", "end": "2026-01-01",
+				"phone": "123",
+			},
+			want: []string{"confirm:equal_to", "end:after"},
+		},
+		{
+			name:       "same date is not after",
+			submission: model.JSON{"start": "2026-01-02", "end": "2026-01-02", "phone": "123"},
+			want:       []string{"end:after"},
+		},
+		{
+			name:       "condition holds",
+			submission: model.JSON{"contact": "email", "phone": "123"},
+			want:       []string{"email:required"},
+		},
+		{
+			name:       "condition does not hold",
+			submission: model.JSON{"contact": "phone", "phone": "123"},
+			want:       []string{},
+		},
+		{
+			name:       "nothing to contact",
+			submission: model.JSON{},
+			want:       []string{"phone:at_least_one"},
+		},
+		{
+			name:       "other field empty",
+			submission: model.JSON{"end": "2026-01-01", "confirm": "", "phone": "123"},
+			want:       []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := validator.ValidateForm(schema, tt.submission)
+			assert.Equal(t, tt.want, failedRules(result))
+			assert.Equal(t, len(tt.want) == 0, result.IsValid)
+		})
+	}
+
+	result := validator.ValidateForm(schema, model.JSON{"start": "2026-01-02", "end": "2026-01-01", "phone": "1"})
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, "Ends before it starts", result.Errors[0].Message)
+
+	result = validator.ForLocale("fr").ValidateForm(schema, model.JSON{"password": "a", "confirm": "b", "phone": "1"})
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, "Les valeurs ne correspondent pas", result.Errors[0].Message)
+}
+
+func TestRuleRegistry(t *testing.T) {
+	registry := validation.NewRuleRegistry()
+
+	err := registry.Register("uppercase", validation.RuleDefinition{
+		Check: func(rc *validation.RuleContext) bool {
+			s, _ := rc.Value.(string)
+
+			return s == strings.ToUpper(s)
+		},
+		MessageKey: "Use capital letters",
+	})
+	require.NoError(t, err)
+
+	require.ErrorIs(t, registry.Register("uppercase", validation.RuleDefinition{
+		Check: func(*validation.RuleContext) bool { return true },
+	}), validation.ErrRuleExists)
+	require.ErrorIs(t, registry.Register("regex", validation.RuleDefinition{
+		Check: func(*validation.RuleContext) bool { return true },
+	}), validation.ErrRuleExists)
+	require.ErrorIs(t, registry.Register("noop", validation.RuleDefinition{}), validation.ErrInvalidRule)
+
+	assert.Contains(t, registry.Names(), "uppercase")
+	assert.NotContains(t, validation.DefaultRules().Names(), "uppercase")
+
+	schema := ruleSchema(map[string][]any{
+		"password": {map[string]any{"type": "uppercase"}, map[string]any{"type": "unknown"}},
+	})
+
+	validator := validation.NewComprehensiveValidator()
+	assert.True(t, validator.ValidateForm(schema, model.JSON{"password": "abc"}).IsValid,
+		"the default registry doesn't know the rule")
+
+	result := validator.WithRules(registry).ValidateForm(schema, model.JSON{"password": "abc"})
+	assert.Equal(t, []validation.Error{{Field: "password", Message: "Use capital letters", Rule: "uppercase"}},
+		result.Errors)
+
+	clientRules, err := validator.WithRules(registry).GenerateClientValidation(schema)
+	require.NoError(t, err)
+
+	password, ok := clientRules["password"].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, []validation.Rule{{Type: "uppercase", Message: "Use capital letters"}}, password["rules"],
+		"client rules carry the server's message and leave out unknown rules")
+}
@@ -2,11 +2,66 @@ package validation
 
 import (
 	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/goformx/goforms/internal/domain/user"
+	"github.com/goformx/goforms/internal/infrastructure/i18n"
 )
 
-// SchemaGenerator provides functionality to generate validation schemas from struct tags
+// Struct tags read by the schema generator besides validate
+const (
+	// tagMessage replaces rule messages: "tag=key" for one rule, separated by
+	// commas, or a single key for every rule of the field. A key without a dot
+	// names a validation message, so password_match is validation.password_match.
+	tagMessage = "message"
+)
+
+// Patterns the client checks for validator tags that have no rule of their own
+var tagPatterns = map[string]string{
+	"alpha":       `^[a-zA-Z]+$`,
+	"alphanum":    `^[a-zA-Z0-9]+$`,
+	"numeric":     `^[-+]?[0-9]+(?:\.[0-9]+)?$`,
+	"number":      `^[0-9]+$`,
+	"hexadecimal": `^(0[xX])?[0-9a-fA-F]+$`,
+	"lowercase":   `^[^A-Z]*$`,
+	"uppercase":   `^[^a-z]*$`,
+	"uuid":        `^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`,
+	"phone":       `^\+?[1-9]\d{1,14}$`,
+}
+
+// Format tags: the field type they give a string and the key of their message
+var formatTags = map[string][2]string{
+	"email":    {"email", "validation.valid_email"},
+	"url":      {"url", "validation.url"},
+	"uri":      {"url", "validation.url"},
+	"http_url": {"url", "validation.url"},
+	"uuid":     {"uuid", "validation.uuid"},
+	"uuid3":    {"uuid", "validation.uuid"},
+	"uuid4":    {"uuid", "validation.uuid"},
+	"uuid5":    {"uuid", "validation.uuid"},
+	"phone":    {"phone", "validation.phone"},
+	"e164":     {"phone", "validation.phone"},
+	"date":     {"date", "validation.date"},
+	"datetime": {"datetime", "validation.datetime"},
+	"password": {"password", "validation.password"},
+}
+
+// Cross-field tags and the rules they become
+var fieldTags = map[string]string{
+	"eqfield": RuleEqualTo,
+	"gtfield": RuleAfter,
+	"ltfield": RuleBefore,
+}
+
+// timeType is the type of time.Time fields, which are sent as strings
+var timeType = reflect.TypeFor[time.Time]()
+
+// SchemaGenerator generates client-side validation schemas from the validate
+// struct tags of request types, so that forms rendered by the server are
+// checked in the browser by the same rules the server applies
 type SchemaGenerator struct{}
 
 // NewSchemaGenerator creates a new schema generator
@@ -14,84 +69,468 @@ func NewSchemaGenerator() *SchemaGenerator {
 	return &SchemaGenerator{}
 }
 
-// getFieldSchema extracts validation schema from struct field tags
-func (sg *SchemaGenerator) getFieldSchema(field *reflect.StructField) map[string]any {
-	fieldSchema := make(map[string]any)
-
-	// Get validation tags
-	validate := field.Tag.Get("validate")
-	if validate != "" {
-		fieldSchema["validate"] = validate
+// GenerateValidationSchema generates a validation schema from a struct, with
+// messages in the given locale. Each field is described by its type, its
+// constraints (required, minLength, maxLength, min, max, options, pattern and
+// so on) and its rules with their messages; nested structs are described
+// under fields and the elements of a field that dives under items. Fields not
+// bound from JSON are skipped.
+func (sg *SchemaGenerator) GenerateValidationSchema(s any, locale string) map[string]any {
+	t := reflect.TypeOf(s)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
 	}
 
-	// Get min/max length
-	minLen := field.Tag.Get("minlen")
-	if minLen != "" {
-		fieldSchema["minLength"] = minLen
+	if t == nil || t.Kind() != reflect.Struct {
+		return map[string]any{}
 	}
 
-	maxLen := field.Tag.Get("maxlen")
-	if maxLen != "" {
-		fieldSchema["maxLength"] = maxLen
+	return sg.structSchema(t, i18n.For(locale), map[reflect.Type]bool{})
+}
+
+// structSchema describes the fields of a struct. Embedded structs add their
+// fields to it; a type nested in itself is described once.
+func (sg *SchemaGenerator) structSchema(
+	t reflect.Type,
+	localizer i18n.Localizer,
+	visiting map[reflect.Type]bool,
+) map[string]any {
+	schema := make(map[string]any)
+	if visiting[t] {
+		return schema
 	}
 
-	// Set type and message based on validation rules
-	if validate != "" {
-		switch validate {
-		case "required,email":
-			fieldSchema["type"] = "email"
-			fieldSchema["message"] = "Please enter a valid email address"
-		case "required":
-			fieldSchema["type"] = "string"
-			fieldSchema["message"] = "This field is required"
-		case "required,min=8":
-			fieldSchema["type"] = "password"
-			fieldSchema["min"] = "8"
-			fieldSchema["message"] = "Password must be at least 8 characters long and include " +
-				"uppercase, lowercase, number, and special characters"
-		case "required,eqfield=password":
-			fieldSchema["type"] = "match"
-			fieldSchema["matchField"] = "password"
-			fieldSchema["message"] = "Passwords don't match"
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	for i := range t.NumField() {
+		field := t.Field(i)
+
+		// Like encoding/json, the fields of embedded structs are promoted,
+		// even when the struct type is unexported
+		if embedded := indirect(field.Type); field.Anonymous && embedded.Kind() == reflect.Struct &&
+			field.Tag.Get("json") == "" {
+			for key, value := range sg.structSchema(embedded, localizer, visiting) {
+				schema[key] = value
+			}
+
+			continue
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		name, bound := fieldName(&field)
+		if !bound {
+			continue
+		}
+
+		fieldSchema := sg.valueSchema(&fieldContext{
+			owner:     t,
+			localizer: localizer,
+			messages:  parseMessageTag(field.Tag.Get(tagMessage)),
+			visiting:  visiting,
+		}, field.Type, ParseValidateTag(field.Tag.Get("validate")))
+
+		if validate := field.Tag.Get("validate"); validate != "" {
+			fieldSchema["validate"] = validate
 		}
+
+		schema[name] = fieldSchema
 	}
 
-	return fieldSchema
+	return schema
 }
 
-// GenerateValidationSchema generates a validation schema from a struct
-func (sg *SchemaGenerator) GenerateValidationSchema(s any) map[string]any {
-	t := reflect.TypeOf(s)
+// fieldContext holds what describing a field's rules needs
+type fieldContext struct {
+	owner     reflect.Type
+	localizer i18n.Localizer
+	messages  map[string]string
+	visiting  map[reflect.Type]bool
+}
+
+// valueSchema describes a value of type t checked by the rules of tag
+func (sg *SchemaGenerator) valueSchema(fc *fieldContext, t reflect.Type, tag *ParsedTag) map[string]any {
+	t = indirect(t)
 	schema := make(map[string]any)
 
-	for i := range t.NumField() {
-		field := t.Field(i)
-		fieldName := field.Tag.Get("json")
+	if typeName := jsonType(t); typeName != "" {
+		schema["type"] = typeName
+	}
+
+	rules := make([]Rule, 0, len(tag.Rules))
 
-		if fieldName == "" {
-			fieldName = field.Name
+	for _, tagRule := range tag.Rules {
+		rule, ok := applyTagRule(fc, schema, t, tagRule)
+		if !ok {
+			continue
 		}
 
-		fieldSchema := sg.getFieldSchema(&field)
-		schema[fieldName] = fieldSchema
+		if key, overridden := fc.messages[tagRule.Tag]; overridden {
+			rule.Message = fc.localizer.T(key)
+		} else if key, all := fc.messages[""]; all {
+			rule.Message = fc.localizer.T(key)
+		}
+
+		rules = append(rules, rule)
+	}
+
+	if len(rules) > 0 {
+		schema["rules"] = rules
+		schema["message"] = fieldMessage(rules)
+	}
+
+	switch {
+	case t.Kind() == reflect.Struct && t != timeType:
+		schema["fields"] = sg.structSchema(t, fc.localizer, fc.visiting)
+	case tag.Dive != nil && (t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map):
+		schema["items"] = sg.valueSchema(&fieldContext{
+			owner:     fc.owner,
+			localizer: fc.localizer,
+			visiting:  fc.visiting,
+		}, t.Elem(), tag.Dive)
 	}
 
 	return schema
 }
 
+// applyTagRule adds what a validator rule requires of a value of type t to
+// schema and returns the rule with its message. Rules with no client-side
+// counterpart, alternatives among them, are left to the server.
+func applyTagRule(fc *fieldContext, schema map[string]any, t reflect.Type, tagRule TagRule) (Rule, bool) {
+	l := fc.localizer
+
+	if len(tagRule.Or) > 0 {
+		return Rule{}, false
+	}
+
+	switch tagRule.Tag {
+	case "required":
+		schema["required"] = true
+
+		return Rule{Type: RuleRequired, Message: l.T("validation.required")}, true
+	case "required_if", "required_unless", "required_with", "required_without":
+		return conditionalRequired(fc, tagRule)
+	case "min", "max", "len", "gt", "gte", "lt", "lte":
+		return applyLimit(l, schema, t, tagRule)
+	case "oneof":
+		options := oneOfValues(tagRule.Param)
+		schema["options"] = options
+
+		return Rule{Type: "options", Value: options, Message: l.T("validation.options")}, true
+	case "startswith", "endswith", "contains":
+		pattern := regexp.QuoteMeta(tagRule.Param)
+
+		switch tagRule.Tag {
+		case "startswith":
+			pattern = "^" + pattern
+		case "endswith":
+			pattern += "$"
+		}
+
+		schema["pattern"] = pattern
+
+		return Rule{Type: "pattern", Value: pattern, Message: l.T("validation.pattern")}, true
+	}
+
+	if format, ok := formatTags[tagRule.Tag]; ok {
+		schema["type"] = format[0]
+		if pattern, hasPattern := tagPatterns[format[0]]; hasPattern {
+			schema["pattern"] = pattern
+		}
+
+		if format[0] == "password" {
+			schema["minLength"] = minPasswordLength
+		}
+
+		return Rule{Type: format[0], Message: l.T(format[1])}, true
+	}
+
+	if pattern, ok := tagPatterns[tagRule.Tag]; ok {
+		schema["pattern"] = pattern
+
+		return Rule{Type: "pattern", Value: pattern, Message: l.T("validation.pattern")}, true
+	}
+
+	if ruleType, ok := fieldTags[tagRule.Tag]; ok {
+		other := referencedField(fc.owner, tagRule.Param)
+		if ruleType == RuleEqualTo {
+			schema["matchField"] = other
+		}
+
+		return Rule{Type: ruleType, Value: other, Message: l.T("validation." + ruleType)}, true
+	}
+
+	return Rule{}, false
+}
+
+// fieldMessage returns the message shown for a field as a whole: that of its
+// first rule other than required, which says more about what is expected
+func fieldMessage(rules []Rule) string {
+	for _, rule := range rules {
+		if rule.Type != RuleRequired || rule.Condition != "" {
+			return rule.Message
+		}
+	}
+
+	return rules[0].Message
+}
+
+// minPasswordLength is the shortest password the password rule accepts
+const minPasswordLength = 8
+
+// conditionalRequired turns a required_if, required_unless, required_with or
+// required_without rule into a required rule with a condition. Of several
+// fields only the first is used.
+func conditionalRequired(fc *fieldContext, tagRule TagRule) (Rule, bool) {
+	params := strings.Fields(tagRule.Param)
+	if len(params) == 0 {
+		return Rule{}, false
+	}
+
+	other := referencedField(fc.owner, params[0])
+
+	var condition string
+
+	switch tagRule.Tag {
+	case "required_if", "required_unless":
+		if len(params) < 2 {
+			return Rule{}, false
+		}
+
+		operator := "="
+		if tagRule.Tag == "required_unless" {
+			operator = "!="
+		}
+
+		condition = other + operator + params[1]
+	case "required_with":
+		condition = other
+	default:
+		condition = "!" + other
+	}
+
+	return Rule{Type: RuleRequired, Condition: condition, Message: fc.localizer.T("validation.required")}, true
+}
+
+// applyLimit adds a size limit: a length for strings, a value for numbers and
+// a number of items for slices and maps
+func applyLimit(l i18n.Localizer, schema map[string]any, t reflect.Type, tagRule TagRule) (Rule, bool) {
+	limit, err := strconv.ParseFloat(tagRule.Param, 64)
+	if err != nil {
+		return Rule{}, false
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return applyLength(l, schema, int(limit), tagRule.Tag)
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return applyItems(l, schema, int(limit), tagRule.Tag)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return applyValueLimit(l, schema, limit, tagRule.Tag)
+	default:
+		return Rule{}, false
+	}
+}
+
+// applyLength adds a string length limit
+func applyLength(l i18n.Localizer, schema map[string]any, length int, tag string) (Rule, bool) {
+	switch tag {
+	case "min", "gte", "gt":
+		if tag == "gt" {
+			length++
+		}
+
+		schema["minLength"] = length
+
+		return Rule{Type: "minLength", Value: length, Message: l.T("validation.min_length", "min", length)}, true
+	case "max", "lte", "lt":
+		if tag == "lt" {
+			length--
+		}
+
+		schema["maxLength"] = length
+
+		return Rule{Type: "maxLength", Value: length, Message: l.T("validation.max_length", "max", length)}, true
+	default:
+		schema["minLength"] = length
+		schema["maxLength"] = length
+
+		return Rule{Type: "length", Value: length, Message: l.T("validation.length", "length", length)}, true
+	}
+}
+
+// applyItems adds a limit on the number of items
+func applyItems(l i18n.Localizer, schema map[string]any, count int, tag string) (Rule, bool) {
+	switch tag {
+	case "min", "gte", "gt":
+		if tag == "gt" {
+			count++
+		}
+
+		schema["minItems"] = count
+
+		return Rule{Type: "minItems", Value: count, Message: l.T("validation.min_items", "min", count)}, true
+	case "max", "lte", "lt":
+		if tag == "lt" {
+			count--
+		}
+
+		schema["maxItems"] = count
+
+		return Rule{Type: "maxItems", Value: count, Message: l.T("validation.max_items", "max", count)}, true
+	default:
+		schema["minItems"] = count
+		schema["maxItems"] = count
+
+		return Rule{Type: "items", Value: count, Message: l.T("validation.items", "count", count)}, true
+	}
+}
+
+// applyValueLimit adds a limit on a number
+func applyValueLimit(l i18n.Localizer, schema map[string]any, limit float64, tag string) (Rule, bool) {
+	switch tag {
+	case "min", "gte":
+		schema["min"] = limit
+
+		return Rule{Type: "min", Value: limit, Message: l.T("validation.min", "min", limit)}, true
+	case "max", "lte":
+		schema["max"] = limit
+
+		return Rule{Type: "max", Value: limit, Message: l.T("validation.max", "max", limit)}, true
+	case "gt":
+		schema["exclusiveMin"] = limit
+
+		return Rule{Type: "exclusiveMin", Value: limit, Message: l.T("validation.greater_than", "value", limit)}, true
+	case "lt":
+		schema["exclusiveMax"] = limit
+
+		return Rule{Type: "exclusiveMax", Value: limit, Message: l.T("validation.less_than", "value", limit)}, true
+	default:
+		schema["min"] = limit
+		schema["max"] = limit
+
+		return Rule{Type: "equal", Value: limit, Message: l.T("validation.equal", "value", limit)}, true
+	}
+}
+
+// jsonType returns the type a value of t is sent as
+func jsonType(t reflect.Type) string {
+	if t == timeType {
+		return "datetime"
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Map, reflect.Struct:
+		return "object"
+	default:
+		return ""
+	}
+}
+
+// fieldName returns the name a field is bound from: its JSON name, else its
+// form name, else its Go name. Fields left out of JSON are not bound.
+func fieldName(field *reflect.StructField) (string, bool) {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "-" {
+		return "", false
+	}
+
+	if name == "" {
+		name, _, _ = strings.Cut(field.Tag.Get("form"), ",")
+	}
+
+	if name == "" {
+		name = field.Name
+	}
+
+	return name, true
+}
+
+// referencedField returns the bound name of the field a cross-field rule
+// refers to. The validator names fields by their Go name; a name that matches
+// no field is returned as it is.
+func referencedField(owner reflect.Type, name string) string {
+	field, found := owner.FieldByName(name)
+	if !found {
+		field, found = owner.FieldByNameFunc(func(candidate string) bool {
+			return strings.EqualFold(candidate, name)
+		})
+	}
+
+	if !found {
+		return name
+	}
+
+	if bound, ok := fieldName(&field); ok {
+		return bound
+	}
+
+	return name
+}
+
+// parseMessageTag parses a message tag into message keys by rule tag. A key
+// for every rule is stored under the empty tag.
+func parseMessageTag(tag string) map[string]string {
+	if tag == "" {
+		return nil
+	}
+
+	messages := make(map[string]string)
+
+	for part := range strings.SplitSeq(tag, ",") {
+		ruleTag, key, found := strings.Cut(strings.TrimSpace(part), "=")
+		if !found {
+			messages[""] = messageKey(ruleTag)
+
+			continue
+		}
+
+		messages[ruleTag] = messageKey(key)
+	}
+
+	return messages
+}
+
+// messageKey returns the catalog key of a message named in a message tag
+func messageKey(key string) string {
+	if strings.Contains(key, ".") {
+		return key
+	}
+
+	return "validation." + key
+}
+
+// indirect returns the type a pointer type points to
+func indirect(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	return t
+}
+
 // GenerateLoginSchema generates the validation schema for login forms
-func (sg *SchemaGenerator) GenerateLoginSchema() map[string]any {
-	return sg.GenerateValidationSchema(user.Login{
-		Email:    "",
-		Password: "",
-	})
+func (sg *SchemaGenerator) GenerateLoginSchema(locale string) map[string]any {
+	return sg.GenerateValidationSchema(user.Login{}, locale)
 }
 
 // GenerateSignupSchema generates the validation schema for signup forms
-func (sg *SchemaGenerator) GenerateSignupSchema() map[string]any {
-	return sg.GenerateValidationSchema(user.Signup{
-		Email:           "",
-		Password:        "",
-		ConfirmPassword: "",
-	})
+func (sg *SchemaGenerator) GenerateSignupSchema(locale string) map[string]any {
+	return sg.GenerateValidationSchema(user.Signup{}, locale)
 }
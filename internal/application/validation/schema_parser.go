@@ -1,5 +1,14 @@
 package validation
 
+// errorKeys maps the keys of a Form.io component's errors property to the
+// rule types they override, where the names differ
+var errorKeys = map[string]string{
+	"invalid_email": "email",
+	"invalid_url":   "url",
+	"invalid_date":  "date",
+	"select":        "options",
+}
+
 // SchemaParser handles parsing and extracting validation rules from form schemas
 type SchemaParser struct{}
 
@@ -32,6 +41,9 @@ func (p *SchemaParser) ExtractValidationRules(component map[string]any) FieldVal
 	// Extract options for select/radio/checkbox components
 	p.extractComponentOptions(component, &validation)
 
+	// Extract the messages the form configured for failed rules
+	p.extractMessages(component, &validation)
+
 	return validation
 }
 
@@ -46,6 +58,65 @@ func (p *SchemaParser) extractBasicValidation(component map[string]any, validati
 	p.extractLengthValidation(validate, validation)
 	p.extractNumericValidation(validate, validation)
 	p.extractPattern(validate, validation)
+	p.extractCustomRules(validate, validation)
+}
+
+// extractCustomRules extracts the rules listed in validate.rules, each with a
+// type and optionally a value, message and condition
+func (p *SchemaParser) extractCustomRules(validate map[string]any, validation *FieldValidation) {
+	rules, ok := validate["rules"].([]any)
+	if !ok {
+		return
+	}
+
+	for _, item := range rules {
+		ruleMap, ruleOk := item.(map[string]any)
+		if !ruleOk {
+			continue
+		}
+
+		ruleType, typeOk := ruleMap["type"].(string)
+		if !typeOk || ruleType == "" {
+			continue
+		}
+
+		rule := Rule{Type: ruleType, Value: ruleMap["value"]}
+		rule.Message, _ = ruleMap["message"].(string)
+		rule.Condition, _ = ruleMap["condition"].(string)
+
+		validation.CustomRules = append(validation.CustomRules, rule)
+	}
+}
+
+// extractMessages extracts the component's custom error messages: one
+// message for every rule in validate.customMessage, and per-rule messages in
+// the errors property
+func (p *SchemaParser) extractMessages(component map[string]any, validation *FieldValidation) {
+	if validate, ok := component["validate"].(map[string]any); ok {
+		if message, messageOk := validate["customMessage"].(string); messageOk {
+			validation.CustomMessage = message
+		}
+	}
+
+	errorMessages, ok := component["errors"].(map[string]any)
+	if !ok {
+		return
+	}
+
+	validation.Messages = make(map[string]string, len(errorMessages))
+
+	for key, value := range errorMessages {
+		message, messageOk := value.(string)
+		if !messageOk || message == "" {
+			continue
+		}
+
+		if rule, renamed := errorKeys[key]; renamed {
+			key = rule
+		}
+
+		validation.Messages[key] = message
+	}
 }
 
 // extractRequired extracts required field validation
@@ -91,14 +162,15 @@ func (p *SchemaParser) extractComponentType(component map[string]any, validation
 	}
 }
 
-// extractComponentOptions extracts options for select/radio/checkbox components
+// extractComponentOptions extracts options for select/radio/checkbox
+// components. Select components list them in data.values; radio and select
+// boxes components in values.
 func (p *SchemaParser) extractComponentOptions(component map[string]any, validation *FieldValidation) {
-	data, dataOk := component["data"].(map[string]any)
-	if !dataOk {
-		return
+	values, valuesOk := component["values"].([]any)
+	if data, dataOk := component["data"].(map[string]any); dataOk {
+		values, valuesOk = data["values"].([]any)
 	}
 
-	values, valuesOk := data["values"].([]any)
 	if !valuesOk {
 		return
 	}
@@ -108,13 +180,21 @@ func (p *SchemaParser) extractComponentOptions(component map[string]any, validat
 	}
 }
 
-// extractOptionValue extracts a single option value
+// extractOptionValue extracts a single option value. Form.io submits an
+// option's value, which stays the same when its label is translated; options
+// without one are submitted by label.
 func (p *SchemaParser) extractOptionValue(value any, validation *FieldValidation) {
 	valueMap, valueMapOk := value.(map[string]any)
 	if !valueMapOk {
 		return
 	}
 
+	if optionValue, optionValueOk := valueMap["value"].(string); optionValueOk && optionValue != "" {
+		validation.Options = append(validation.Options, optionValue)
+
+		return
+	}
+
 	if label, labelOk := valueMap["label"].(string); labelOk {
 		validation.Options = append(validation.Options, label)
 	}
@@ -0,0 +1,149 @@
+package validation_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/application/validation"
+)
+
+func TestParseValidateTag(t *testing.T) {
+	parsed := validation.ParseValidateTag("required,min=2,oneof='a b' c,email|url,dive,keys,max=3,endkeys,len=4")
+
+	assert.Equal(t, []validation.TagRule{
+		{Tag: "required"},
+		{Tag: "min", Param: "2"},
+		{Tag: "oneof", Param: "'a b' c"},
+		{Tag: "email", Or: []validation.TagRule{{Tag: "email"}, {Tag: "url"}}},
+	}, parsed.Rules)
+	require.NotNil(t, parsed.Dive)
+	assert.Equal(t, []validation.TagRule{{Tag: "len", Param: "4"}}, parsed.Dive.Rules, "key rules are skipped")
+	assert.True(t, parsed.Has("required"))
+	assert.False(t, parsed.Has("max"))
+
+	escaped := validation.ParseValidateTag("contains=a0x2Cb0x7Cc")
+	assert.Equal(t, "a,b|c", escaped.Rules[0].Param)
+	assert.Empty(t, validation.ParseValidateTag("-").Rules)
+}
+
+type address struct {
+	City    string `json:"city" validate:"required"`
+	Country string `json:"country" validate:"oneof=FR 'United Kingdom'"`
+}
+
+type contact struct {
+	Phone string `json:"phone" validate:"e164"`
+}
+
+type profileRequest struct {
+	contact
+
+	Name     string    `json:"name" validate:"required,min=2,max=50"`
+	Code     string    `json:"code" validate:"len=6,alphanum"`
+	ID       string    `json:"id" validate:"uuid4"`
+	Website  string    `json:"website,omitempty" validate:"omitempty,url"`
+	Age      int       `json:"age" validate:"gte=18,lt=130"`
+	Email    string    `json:"email" validate:"required_without=Phone,omitempty,email"`
+	Confirm  string    `json:"confirm" validate:"eqfield=Email" message:"eqfield=password_match"`
+	Tags     []string  `json:"tags" validate:"min=1,dive,max=10"`
+	Address  *address  `json:"address" validate:"required"`
+	Previous []address `json:"previous"`
+	Secret   string    `json:"-" validate:"required"`
+	ignored  string    //nolint:unused // unexported fields are not described
+}
+
+func TestSchemaGenerator_GenerateValidationSchema(t *testing.T) {
+	schema := validation.NewSchemaGenerator().GenerateValidationSchema(&profileRequest{}, "en")
+
+	assert.ElementsMatch(t, []string{
+		"phone", "name", "code", "id", "website", "age", "email", "confirm", "tags", "address", "previous",
+	}, keys(schema))
+
+	name := field(t, schema, "name")
+	assert.Equal(t, "string", name["type"])
+	assert.Equal(t, true, name["required"])
+	assert.Equal(t, 2, name["minLength"])
+	assert.Equal(t, 50, name["maxLength"])
+	assert.Equal(t, "Minimum length is 2 characters", name["message"])
+	assert.Equal(t, "required,min=2,max=50", name["validate"])
+
+	code := field(t, schema, "code")
+	assert.Equal(t, 6, code["minLength"])
+	assert.Equal(t, 6, code["maxLength"])
+	assert.Equal(t, "^[a-zA-Z0-9]+$", code["pattern"])
+
+	assert.Equal(t, "uuid", field(t, schema, "id")["type"])
+	assert.Equal(t, "url", field(t, schema, "website")["type"])
+	assert.Equal(t, "phone", field(t, schema, "phone")["type"])
+
+	age := field(t, schema, "age")
+	assert.Equal(t, "integer", age["type"])
+	assert.InDelta(t, 18, age["min"], 0)
+	assert.InDelta(t, 130, age["exclusiveMax"], 0)
+
+	email := field(t, schema, "email")
+	assert.Equal(t, "email", email["type"])
+	assert.Nil(t, email["required"], "email is only required without a phone number")
+	assert.Contains(t, email["rules"], validation.Rule{
+		Type: validation.RuleRequired, Condition: "!phone", Message: "This field is required",
+	})
+
+	confirm := field(t, schema, "confirm")
+	assert.Equal(t, "email", confirm["matchField"])
+	assert.Equal(t, "Passwords don't match", confirm["message"])
+
+	tags := field(t, schema, "tags")
+	assert.Equal(t, "array", tags["type"])
+	assert.Equal(t, 1, tags["minItems"])
+	assert.Equal(t, map[string]any{
+		"type": "string", "maxLength": 10,
+		"rules":   []validation.Rule{{Type: "maxLength", Value: 10, Message: "Maximum length is 10 characters"}},
+		"message": "Maximum length is 10 characters",
+	}, tags["items"])
+
+	nested := field(t, schema, "address")
+	assert.Equal(t, "object", nested["type"])
+	assert.Equal(t, true, nested["required"])
+
+	fields, ok := nested["fields"].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, true, field(t, fields, "city")["required"])
+	assert.Equal(t, []string{"FR", "United Kingdom"}, field(t, fields, "country")["options"])
+
+	assert.Equal(t, "array", field(t, schema, "previous")["type"])
+	assert.NotContains(t, field(t, schema, "previous"), "items", "elements are only described when the field dives")
+}
+
+func TestSchemaGenerator_Locales(t *testing.T) {
+	generator := validation.NewSchemaGenerator()
+
+	signup := generator.GenerateSignupSchema("fr")
+	assert.Equal(t, "Veuillez saisir une adresse courriel valide", field(t, signup, "email")["message"])
+	assert.Equal(t, "password", field(t, signup, "confirm_password")["matchField"])
+	assert.Equal(t, "Les mots de passe ne correspondent pas", field(t, signup, "confirm_password")["message"])
+	assert.NotContains(t, signup, "Locale")
+
+	login := generator.GenerateLoginSchema("en")
+	assert.Equal(t, "This field is required", field(t, login, "password")["message"])
+	assert.Empty(t, generator.GenerateValidationSchema("not a struct", "en"))
+}
+
+func keys(schema map[string]any) []string {
+	names := make([]string, 0, len(schema))
+	for name := range schema {
+		names = append(names, name)
+	}
+
+	return names
+}
+
+func field(t *testing.T, schema map[string]any, name string) map[string]any {
+	t.Helper()
+
+	fieldSchema, ok := schema[name].(map[string]any)
+	require.True(t, ok, name)
+
+	return fieldSchema
+}
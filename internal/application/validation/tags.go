@@ -0,0 +1,129 @@
+package validation
+
+import (
+	"strings"
+)
+
+// Tags that change how the validator treats the rules after them
+const (
+	tagDive    = "dive"
+	tagKeys    = "keys"
+	tagEndKeys = "endkeys"
+)
+
+// TagRule is one rule of a go-playground validator struct tag, such as
+// min=8 or required
+type TagRule struct {
+	Tag   string
+	Param string
+	// Or holds the alternatives of a rule written as email|url. Tag and Param
+	// then hold the first alternative.
+	Or []TagRule
+}
+
+// ParsedTag is a parsed validate struct tag
+type ParsedTag struct {
+	// Rules apply to the field itself
+	Rules []TagRule
+	// Dive holds the rules after dive, which apply to each element of a slice
+	// or map. It is nil when the tag does not dive.
+	Dive *ParsedTag
+}
+
+// ParseValidateTag parses a go-playground validator tag. Rules are separated
+// by commas, a rule's parameter follows an equals sign, and alternatives are
+// separated by pipes; 0x2C and 0x7C stand for commas and pipes in parameters.
+// The map key rules between keys and endkeys are skipped.
+func ParseValidateTag(tag string) *ParsedTag {
+	parsed := &ParsedTag{}
+	current := parsed
+	inKeys := false
+
+	for part := range strings.SplitSeq(tag, ",") {
+		part = strings.TrimSpace(part)
+
+		switch {
+		case part == "" || part == "-":
+			continue
+		case part == tagDive:
+			current.Dive = &ParsedTag{}
+			current = current.Dive
+
+			continue
+		case part == tagKeys:
+			inKeys = true
+
+			continue
+		case part == tagEndKeys:
+			inKeys = false
+
+			continue
+		case inKeys:
+			continue
+		}
+
+		current.Rules = append(current.Rules, parseTagRule(part))
+	}
+
+	return parsed
+}
+
+// parseTagRule parses one rule and its alternatives
+func parseTagRule(part string) TagRule {
+	alternatives := strings.Split(part, "|")
+
+	rule := parseTagAlternative(alternatives[0])
+	if len(alternatives) > 1 {
+		for _, alternative := range alternatives {
+			rule.Or = append(rule.Or, parseTagAlternative(alternative))
+		}
+	}
+
+	return rule
+}
+
+// parseTagAlternative parses a single rule without alternatives
+func parseTagAlternative(part string) TagRule {
+	name, param, _ := strings.Cut(strings.TrimSpace(part), "=")
+
+	return TagRule{Tag: name, Param: unescapeTagParam(param)}
+}
+
+// unescapeTagParam restores the commas and pipes escaped in a parameter
+func unescapeTagParam(param string) string {
+	return strings.NewReplacer("0x2C", ",", "0x7C", "|").Replace(param)
+}
+
+// Has reports whether the tag has a rule
+func (t *ParsedTag) Has(tag string) bool {
+	for _, rule := range t.Rules {
+		if rule.Tag == tag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// oneOfValues splits the parameter of a oneof rule into its values. Values
+// holding spaces are quoted with single quotes.
+func oneOfValues(param string) []string {
+	var values []string
+
+	for param = strings.TrimSpace(param); param != ""; param = strings.TrimSpace(param) {
+		if strings.HasPrefix(param, "'") {
+			if end := strings.Index(param[1:], "'"); end >= 0 {
+				values = append(values, param[1:end+1])
+				param = param[end+2:]
+
+				continue
+			}
+		}
+
+		value, rest, _ := strings.Cut(param, " ")
+		values = append(values, value)
+		param = rest
+	}
+
+	return values
+}
@@ -20,6 +20,11 @@ type FieldValidation struct {
 	Options     []string       `json:"options,omitempty"`
 	CustomRules []Rule         `json:"custom_rules,omitempty"`
 	Conditional map[string]any `json:"conditional,omitempty"`
+	// CustomMessage replaces the message of every failed rule
+	CustomMessage string `json:"custom_message,omitempty"`
+	// Messages replace the message of a failed rule, keyed by rule type. They
+	// take precedence over CustomMessage.
+	Messages map[string]string `json:"messages,omitempty"`
 }
 
 // Error represents a validation error for a specific field
@@ -47,11 +52,16 @@ type FieldValidatorInterface interface {
 	ValidateFieldType(fieldName string, value any, fieldType string) *Error
 }
 
-// getMessage returns a custom message or default message
+// getMessage returns the message the form configured for a rule, or
+// defaultMessage when there is none
 func (fv *FieldValidation) getMessage(ruleType, defaultMessage string) string {
-	// TODO: Implement custom message lookup based on ruleType
-	// For now, return the default message
-	_ = ruleType // Suppress unused parameter warning
+	if message := fv.Messages[ruleType]; message != "" {
+		return message
+	}
+
+	if fv.CustomMessage != "" {
+		return fv.CustomMessage
+	}
 
 	return defaultMessage
 }
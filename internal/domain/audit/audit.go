@@ -0,0 +1,255 @@
+// Package audit keeps an append-only record of who did what: changes to forms,
+// access to submissions and sign-in activity. Records are written by the domain
+// event subscriber and by the handlers that own actions without domain events.
+package audit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+	"unicode/utf8"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+// Audited actions
+const (
+	// ActionFormCreated records that a form was created
+	ActionFormCreated = "form.created"
+	// ActionFormUpdated records a change to a form's details or status
+	ActionFormUpdated = "form.updated"
+	// ActionFormSchemaUpdated records a change to a form's fields
+	ActionFormSchemaUpdated = "form.schema_updated"
+	// ActionFormCORSUpdated records a change to a form's CORS settings
+	ActionFormCORSUpdated = "form.cors_updated"
+	// ActionFormDeleted records that a form was deleted
+	ActionFormDeleted = "form.deleted"
+	// ActionFormExported records that a form was exported as a bundle
+	ActionFormExported = "form.exported"
+	// ActionFormPurged records that a soft-deleted form was permanently removed
+	ActionFormPurged = "form.purged"
+	// ActionSubmissionViewed records that an owner opened a submission
+	ActionSubmissionViewed = "submission.viewed"
+	// ActionSubmissionUpdated records an edit or restore of a submission
+	ActionSubmissionUpdated = "submission.updated"
+	// ActionSubmissionDeleted records that an owner deleted a submission
+	ActionSubmissionDeleted = "submission.deleted"
+	// ActionSubmissionErased records that a submission was deleted on a
+	// submitter's erasure request
+	ActionSubmissionErased = "submission.erased"
+	// ActionRetentionApplied records that a form's retention rule deleted or
+	// anonymized old submissions
+	ActionRetentionApplied = "submission.retention_applied"
+	// ActionAuditExported records that audit records were exported
+	ActionAuditExported = "audit.exported"
+	// ActionUserSignedUp records a new account
+	ActionUserSignedUp = "user.signed_up"
+	// ActionUserLoggedIn records a successful login
+	ActionUserLoggedIn = "user.logged_in"
+	// ActionUserLoginFailed records a failed login attempt
+	ActionUserLoginFailed = "user.login_failed"
+	// ActionUserLockedOut records that failed login attempts locked an account
+	ActionUserLockedOut = "user.locked_out"
+	// ActionUserLoggedOut records a logout
+	ActionUserLoggedOut = "user.logged_out"
+	// ActionUserRoleChanged records that a user was given another role
+	ActionUserRoleChanged = "user.role_changed"
+	// ActionUserDisabled records that a user account was deactivated
+	ActionUserDisabled = "user.disabled"
+	// ActionConfigReloaded records that configuration changed while the
+	// application was running
+	ActionConfigReloaded = "config.reloaded"
+	// ActionConfigReloadFailed records a configuration change that was
+	// rejected, leaving the previous configuration in effect
+	ActionConfigReloadFailed = "config.reload_failed"
+)
+
+// Target types
+const (
+	// TargetForm is a form
+	TargetForm = "form"
+	// TargetSubmission is a form submission
+	TargetSubmission = "submission"
+	// TargetUser is a user account
+	TargetUser = "user"
+	// TargetConfig is the application's configuration
+	TargetConfig = "config"
+)
+
+// Record is one entry in the audit log. Records are never changed or removed
+// once written.
+type Record struct {
+	ID         string     `json:"id" gorm:"column:uuid;primaryKey"`
+	Action     string     `json:"action" gorm:"not null;size:64;index"`
+	ActorID    string     `json:"actor_id" gorm:"size:36;index"`
+	ActorEmail string     `json:"actor_email" gorm:"size:255"`
+	IP         string     `json:"ip" gorm:"column:ip;size:45"`
+	UserAgent  string     `json:"user_agent" gorm:"size:512"`
+	RequestID  string     `json:"request_id" gorm:"size:64"`
+	TargetType string     `json:"target_type" gorm:"size:32"`
+	TargetID   string     `json:"target_id" gorm:"size:36"`
+	OwnerID    string     `json:"owner_id" gorm:"size:36;index"`
+	Before     model.JSON `json:"before" gorm:"column:before_state;type:json"`
+	After      model.JSON `json:"after" gorm:"column:after_state;type:json"`
+	CreatedAt  time.Time  `json:"created_at" gorm:"not null;index"`
+}
+
+// TableName specifies the table name for the Record model
+func (r *Record) TableName() string {
+	return "audit_records"
+}
+
+// fit truncates the text fields of a record to the size of their columns.
+// Several of them come from the request, and a value that does not fit would
+// make the insert fail and the action go unrecorded.
+func (r *Record) fit() {
+	for _, field := range []struct {
+		value *string
+		size  int
+	}{
+		{&r.Action, 64},
+		{&r.ActorID, 36},
+		{&r.ActorEmail, 255},
+		{&r.IP, 45},
+		{&r.UserAgent, 512},
+		{&r.RequestID, 64},
+		{&r.TargetType, 32},
+		{&r.TargetID, 36},
+		{&r.OwnerID, 36},
+	} {
+		*field.value = truncate(*field.value, field.size)
+	}
+}
+
+// truncate shortens s to at most size characters
+func truncate(s string, size int) string {
+	if utf8.RuneCountInString(s) <= size {
+		return s
+	}
+
+	return string([]rune(s)[:size])
+}
+
+// Actor identifies who made a request
+type Actor struct {
+	UserID    string
+	Email     string
+	IP        string
+	UserAgent string
+	RequestID string
+}
+
+// actorKey is the context key for the request's actor
+type actorKey struct{}
+
+// WithActor returns a context that attributes audited actions to actor
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns the actor stored in ctx, or the zero Actor for work
+// that is not done on behalf of a request
+func ActorFromContext(ctx context.Context) Actor {
+	actor, _ := ctx.Value(actorKey{}).(Actor)
+
+	return actor
+}
+
+// Filter narrows down a query of the audit log. Zero values match everything.
+type Filter struct {
+	ActorID    string
+	OwnerID    string
+	Action     string
+	TargetType string
+	TargetID   string
+	Since      time.Time
+	Until      time.Time
+	Offset     int
+	Limit      int
+}
+
+// Page is one page of audit records, newest first
+type Page struct {
+	Records []*Record `json:"records"`
+	Total   int64     `json:"total"`
+	Offset  int       `json:"offset"`
+	Limit   int       `json:"limit"`
+}
+
+// FormSummary describes the audited state of a form. The schema is reduced to
+// its field keys and a hash so that label-only edits are still visible.
+func FormSummary(form *model.Form) model.JSON {
+	schema, _ := json.Marshal(form.Schema)
+	hash := sha256.Sum256(schema)
+
+	summary := model.JSON{
+		"title":        form.Title,
+		"description":  form.Description,
+		"status":       form.Status,
+		"fields":       fieldKeys(form.Schema),
+		"schema_hash":  hex.EncodeToString(hash[:])[:16],
+		"cors_origins": form.CorsOrigins["origins"],
+		"cors_methods": form.CorsMethods["methods"],
+		"cors_headers": form.CorsHeaders["headers"],
+	}
+
+	return normalize(summary)
+}
+
+// fieldKeys returns the keys of the input components in a Form.io schema,
+// including components nested in layout components
+func fieldKeys(schema map[string]any) []string {
+	keys := []string{}
+
+	var walk func(components any)
+
+	walk = func(components any) {
+		list, ok := components.([]any)
+		if !ok {
+			return
+		}
+
+		for _, c := range list {
+			component, isMap := c.(map[string]any)
+			if !isMap {
+				continue
+			}
+
+			if key, hasKey := component["key"].(string); hasKey && component["input"] != false {
+				keys = append(keys, key)
+			}
+
+			walk(component["components"])
+
+			if columns, hasColumns := component["columns"].([]any); hasColumns {
+				for _, column := range columns {
+					if col, isCol := column.(map[string]any); isCol {
+						walk(col["components"])
+					}
+				}
+			}
+		}
+	}
+
+	walk(schema["components"])
+
+	return keys
+}
+
+// normalize converts a summary to the shape it has after a round trip through
+// the database, so that stored and fresh summaries compare equal
+func normalize(summary model.JSON) model.JSON {
+	data, err := json.Marshal(summary)
+	if err != nil {
+		return summary
+	}
+
+	var normalized model.JSON
+	if err = json.Unmarshal(data, &normalized); err != nil {
+		return summary
+	}
+
+	return normalized
+}
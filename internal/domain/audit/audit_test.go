@@ -0,0 +1,46 @@
+package audit_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/goformx/goforms/internal/domain/audit"
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+func TestFormSummary_nestedFields(t *testing.T) {
+	form := &model.Form{
+		Title: "Signup",
+		Schema: model.JSON{"components": []any{
+			map[string]any{"key": "email", "input": true},
+			map[string]any{"key": "panel", "input": false, "components": []any{
+				map[string]any{"key": "first", "input": true},
+			}},
+			map[string]any{"key": "columns", "input": false, "columns": []any{
+				map[string]any{"components": []any{map[string]any{"key": "last", "input": true}}},
+			}},
+			map[string]any{"key": "submit", "type": "button"},
+		}},
+	}
+
+	summary := audit.FormSummary(form)
+
+	assert.Equal(t, []any{"email", "first", "last", "submit"}, summary["fields"])
+	assert.Len(t, summary["schema_hash"], 16)
+	assert.Nil(t, summary["cors_origins"])
+}
+
+func TestFormSummary_labelChangeChangesHash(t *testing.T) {
+	form := &model.Form{Schema: model.JSON{"components": []any{
+		map[string]any{"key": "name", "label": "Name", "input": true},
+	}}}
+	relabelled := &model.Form{Schema: model.JSON{"components": []any{
+		map[string]any{"key": "name", "label": "Full name", "input": true},
+	}}}
+
+	before, after := audit.FormSummary(form), audit.FormSummary(relabelled)
+
+	assert.Equal(t, before["fields"], after["fields"])
+	assert.NotEqual(t, before["schema_hash"], after["schema_hash"])
+}
@@ -0,0 +1,22 @@
+//go:generate mockgen -typed -source=repository.go -destination=../../../test/mocks/audit/mock_repository.go -package=audit
+
+package audit
+
+import (
+	"context"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+// Repository stores audit records. It is append-only: there is no way to change
+// or remove a record.
+type Repository interface {
+	// Append stores a new record
+	Append(ctx context.Context, record *Record) error
+	// List returns the records matching filter, newest first, and the total
+	// number of matches
+	List(ctx context.Context, filter Filter) ([]*Record, int64, error)
+	// LatestState returns the most recent After summary recorded for a target by
+	// one of the given actions, or nil if there is none
+	LatestState(ctx context.Context, targetType, targetID string, actions ...string) (model.JSON, error)
+}
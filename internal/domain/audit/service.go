@@ -0,0 +1,179 @@
+//go:generate mockgen -typed -source=service.go -destination=../../../test/mocks/audit/mock_service.go -package=audit -mock_names=Service=MockService
+
+package audit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	domainerrors "github.com/goformx/goforms/internal/domain/common/errors"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+// ErrViewerRequired is returned when the audit log is read without a signed-in user
+var ErrViewerRequired = domainerrors.New(domainerrors.ErrCodeForbidden, "audit log requires a signed-in user", nil)
+
+const (
+	// DefaultPageSize is the number of records listed when no limit is given
+	DefaultPageSize = 50
+	// MaxPageSize is the largest page that can be listed
+	MaxPageSize = 200
+	// MaxExportRecords is the largest number of records a single export returns
+	MaxExportRecords = 10000
+)
+
+// formStateActions are the actions whose After summary is a form's full state
+var formStateActions = []string{
+	ActionFormCreated,
+	ActionFormUpdated,
+	ActionFormSchemaUpdated,
+	ActionFormCORSUpdated,
+}
+
+// Viewer is the user reading the audit log. Admins see every record; everyone
+// else only sees records about their own forms and account.
+type Viewer struct {
+	UserID string
+	Admin  bool
+}
+
+// Service defines the interface for writing and reading the audit log
+type Service interface {
+	// Record appends a record. Actor fields that are not set are taken from the
+	// actor stored in ctx.
+	Record(ctx context.Context, record *Record) error
+	// List returns a page of the records visible to viewer
+	List(ctx context.Context, viewer Viewer, filter Filter) (*Page, error)
+	// Export returns up to MaxExportRecords records visible to viewer
+	Export(ctx context.Context, viewer Viewer, filter Filter) ([]*Record, error)
+	// PreviousFormState returns the last recorded summary of a form, or nil if
+	// the form has no recorded state
+	PreviousFormState(ctx context.Context, formID string) (model.JSON, error)
+}
+
+// service implements Service
+type service struct {
+	repository Repository
+	logger     logging.Logger
+	now        func() time.Time
+}
+
+// NewService creates a new audit service
+func NewService(repository Repository, logger logging.Logger) Service {
+	return &service{
+		repository: repository,
+		logger:     logger,
+		now:        time.Now,
+	}
+}
+
+// Record appends a record
+func (s *service) Record(ctx context.Context, record *Record) error {
+	actor := ActorFromContext(ctx)
+
+	if record.ActorID == "" {
+		record.ActorID = actor.UserID
+	}
+
+	if record.ActorEmail == "" {
+		record.ActorEmail = actor.Email
+	}
+
+	if record.IP == "" {
+		record.IP = actor.IP
+	}
+
+	if record.UserAgent == "" {
+		record.UserAgent = actor.UserAgent
+	}
+
+	if record.RequestID == "" {
+		record.RequestID = actor.RequestID
+	}
+
+	record.fit()
+	record.ID = uuid.New().String()
+	record.CreatedAt = s.now()
+
+	if err := s.repository.Append(ctx, record); err != nil {
+		s.logger.Error("failed to append audit record", "action", record.Action, "error", err)
+
+		return fmt.Errorf("append audit record: %w", err)
+	}
+
+	return nil
+}
+
+// List returns a page of the records visible to viewer
+func (s *service) List(ctx context.Context, viewer Viewer, filter Filter) (*Page, error) {
+	filter, err := scope(viewer, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = DefaultPageSize
+	}
+
+	filter.Limit = min(filter.Limit, MaxPageSize)
+	filter.Offset = max(filter.Offset, 0)
+
+	records, total, err := s.repository.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("list audit records: %w", err)
+	}
+
+	return &Page{
+		Records: records,
+		Total:   total,
+		Offset:  filter.Offset,
+		Limit:   filter.Limit,
+	}, nil
+}
+
+// Export returns up to MaxExportRecords records visible to viewer
+func (s *service) Export(ctx context.Context, viewer Viewer, filter Filter) ([]*Record, error) {
+	filter, err := scope(viewer, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	filter.Offset = 0
+	filter.Limit = MaxExportRecords
+
+	records, _, err := s.repository.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("export audit records: %w", err)
+	}
+
+	return records, nil
+}
+
+// PreviousFormState returns the last recorded summary of a form
+func (s *service) PreviousFormState(ctx context.Context, formID string) (model.JSON, error) {
+	state, err := s.repository.LatestState(ctx, TargetForm, formID, formStateActions...)
+	if err != nil {
+		return nil, fmt.Errorf("get previous form state: %w", err)
+	}
+
+	return state, nil
+}
+
+// scope restricts filter to the records viewer may see
+func scope(viewer Viewer, filter Filter) (Filter, error) {
+	if viewer.Admin {
+		return filter, nil
+	}
+
+	if viewer.UserID == "" {
+		return filter, ErrViewerRequired
+	}
+
+	filter.OwnerID = viewer.UserID
+
+	return filter, nil
+}
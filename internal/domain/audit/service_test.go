@@ -0,0 +1,146 @@
+package audit_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/goformx/goforms/internal/domain/audit"
+	domainerrors "github.com/goformx/goforms/internal/domain/common/errors"
+	mockaudit "github.com/goformx/goforms/test/mocks/audit"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+func TestService_Record_fillsActorFromContext(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mockaudit.NewMockRepository(ctrl)
+	svc := audit.NewService(repo, mocklogging.NewMockLogger(ctrl))
+	ctx := audit.WithActor(t.Context(), audit.Actor{
+		UserID:    "user-1",
+		Email:     "owner@example.com",
+		IP:        "203.0.113.7",
+		UserAgent: "test-agent",
+		RequestID: "req-1",
+	})
+
+	var stored *audit.Record
+
+	repo.EXPECT().Append(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, r *audit.Record) error {
+			stored = r
+
+			return nil
+		})
+
+	require.NoError(t, svc.Record(ctx, &audit.Record{
+		Action:     audit.ActionSubmissionViewed,
+		ActorEmail: "explicit@example.com",
+		TargetType: audit.TargetSubmission,
+		TargetID:   "sub-1",
+	}))
+
+	require.NotNil(t, stored)
+	assert.NotEmpty(t, stored.ID)
+	assert.False(t, stored.CreatedAt.IsZero())
+	assert.Equal(t, "user-1", stored.ActorID)
+	assert.Equal(t, "explicit@example.com", stored.ActorEmail, "explicit fields are kept")
+	assert.Equal(t, "203.0.113.7", stored.IP)
+	assert.Equal(t, "test-agent", stored.UserAgent)
+	assert.Equal(t, "req-1", stored.RequestID)
+}
+
+func TestService_Record_truncatesOversizedFields(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mockaudit.NewMockRepository(ctrl)
+	svc := audit.NewService(repo, mocklogging.NewMockLogger(ctrl))
+	ctx := audit.WithActor(t.Context(), audit.Actor{
+		IP:        "203.0.113.7",
+		UserAgent: strings.Repeat("é", 2000),
+	})
+
+	var stored *audit.Record
+
+	repo.EXPECT().Append(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, r *audit.Record) error {
+			stored = r
+
+			return nil
+		})
+
+	require.NoError(t, svc.Record(ctx, &audit.Record{
+		Action:     audit.ActionUserLoginFailed,
+		ActorEmail: strings.Repeat("a", 300) + "@example.com",
+	}))
+
+	require.NotNil(t, stored)
+	assert.Equal(t, strings.Repeat("é", 512), stored.UserAgent)
+	assert.Equal(t, strings.Repeat("a", 255), stored.ActorEmail)
+	assert.Equal(t, "203.0.113.7", stored.IP)
+}
+
+func TestService_List_scopesNonAdminsToTheirOwnRecords(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mockaudit.NewMockRepository(ctrl)
+	svc := audit.NewService(repo, mocklogging.NewMockLogger(ctrl))
+
+	repo.EXPECT().List(gomock.Any(), audit.Filter{
+		OwnerID: "user-1",
+		Action:  audit.ActionFormDeleted,
+		Limit:   audit.DefaultPageSize,
+	}).Return([]*audit.Record{{ID: "rec-1"}}, int64(1), nil)
+
+	page, err := svc.List(t.Context(), audit.Viewer{UserID: "user-1"}, audit.Filter{
+		OwnerID: "someone-else",
+		Action:  audit.ActionFormDeleted,
+		Offset:  -5,
+	})
+	require.NoError(t, err)
+	assert.Len(t, page.Records, 1)
+	assert.Equal(t, int64(1), page.Total)
+	assert.Equal(t, audit.DefaultPageSize, page.Limit)
+}
+
+func TestService_List_adminSeesEverything(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mockaudit.NewMockRepository(ctrl)
+	svc := audit.NewService(repo, mocklogging.NewMockLogger(ctrl))
+
+	repo.EXPECT().List(gomock.Any(), audit.Filter{
+		OwnerID: "someone-else",
+		Limit:   audit.MaxPageSize,
+	}).Return(nil, int64(0), nil)
+
+	_, err := svc.List(t.Context(), audit.Viewer{UserID: "admin-1", Admin: true}, audit.Filter{
+		OwnerID: "someone-else",
+		Limit:   audit.MaxPageSize * 10,
+	})
+	require.NoError(t, err)
+}
+
+func TestService_List_requiresViewer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	svc := audit.NewService(mockaudit.NewMockRepository(ctrl), mocklogging.NewMockLogger(ctrl))
+
+	_, err := svc.List(t.Context(), audit.Viewer{}, audit.Filter{})
+	require.ErrorIs(t, err, audit.ErrViewerRequired)
+	assert.True(t, domainerrors.IsForbiddenError(err))
+}
+
+func TestService_Export_ignoresPaging(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mockaudit.NewMockRepository(ctrl)
+	svc := audit.NewService(repo, mocklogging.NewMockLogger(ctrl))
+
+	repo.EXPECT().List(gomock.Any(), audit.Filter{
+		OwnerID: "user-1",
+		Limit:   audit.MaxExportRecords,
+	}).Return([]*audit.Record{{ID: "rec-1"}, {ID: "rec-2"}}, int64(2), nil)
+
+	records, err := svc.Export(t.Context(), audit.Viewer{UserID: "user-1"}, audit.Filter{Offset: 100, Limit: 10})
+	require.NoError(t, err)
+	assert.Len(t, records, 2)
+}
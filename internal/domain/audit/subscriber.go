@@ -0,0 +1,196 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/goformx/goforms/internal/domain/common/events"
+	"github.com/goformx/goforms/internal/domain/form"
+	formevents "github.com/goformx/goforms/internal/domain/form/events"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+// Summary keys that belong to a form's schema and CORS settings
+var (
+	schemaKeys = []string{"fields", "schema_hash"}
+	corsKeys   = []string{"cors_origins", "cors_methods", "cors_headers"}
+)
+
+// Subscriber records form and submission domain events in the audit log. The
+// actor is taken from the context the event was published with.
+type Subscriber struct {
+	service     Service
+	formService form.Service
+	logger      logging.Logger
+}
+
+// NewSubscriber creates a new audit event subscriber
+func NewSubscriber(service Service, formService form.Service, logger logging.Logger) *Subscriber {
+	return &Subscriber{
+		service:     service,
+		formService: formService,
+		logger:      logger,
+	}
+}
+
+// Subscribe registers the subscriber's handlers with bus
+func (s *Subscriber) Subscribe(ctx context.Context, bus events.Subscriber) error {
+	handlers := map[formevents.EventType]func(context.Context, events.Event) error{
+		formevents.FormCreatedEventType:       s.handleFormCreated,
+		formevents.FormUpdatedEventType:       s.handleFormUpdated,
+		formevents.FormDeletedEventType:       s.handleFormDeleted,
+		formevents.SubmissionUpdatedEventType: s.handleSubmissionUpdated,
+	}
+
+	for eventType, handler := range handlers {
+		if err := bus.Subscribe(ctx, string(eventType), handler); err != nil {
+			return fmt.Errorf("subscribe to %s: %w", eventType, err)
+		}
+	}
+
+	return nil
+}
+
+// handleFormCreated records a new form
+func (s *Subscriber) handleFormCreated(ctx context.Context, event events.Event) error {
+	f, ok := event.Payload().(*model.Form)
+	if !ok {
+		return formevents.ErrInvalidEventPayload
+	}
+
+	return s.record(ctx, &Record{
+		Action:     ActionFormCreated,
+		TargetType: TargetForm,
+		TargetID:   f.ID,
+		OwnerID:    f.UserID,
+		After:      FormSummary(f),
+	})
+}
+
+// handleFormUpdated records a form change. The form's previous state is the
+// last state recorded for it, and the action says which part of it changed.
+func (s *Subscriber) handleFormUpdated(ctx context.Context, event events.Event) error {
+	f, ok := event.Payload().(*model.Form)
+	if !ok {
+		return formevents.ErrInvalidEventPayload
+	}
+
+	before, err := s.service.PreviousFormState(ctx, f.ID)
+	if err != nil {
+		return fmt.Errorf("record form update: %w", err)
+	}
+
+	after := FormSummary(f)
+
+	return s.record(ctx, &Record{
+		Action:     formUpdateAction(before, after),
+		TargetType: TargetForm,
+		TargetID:   f.ID,
+		OwnerID:    f.UserID,
+		Before:     before,
+		After:      after,
+	})
+}
+
+// handleFormDeleted records a deleted form. Only owners can delete forms, so the
+// actor is the owner.
+func (s *Subscriber) handleFormDeleted(ctx context.Context, event events.Event) error {
+	formID, ok := event.Payload().(string)
+	if !ok {
+		return formevents.ErrInvalidEventPayload
+	}
+
+	before, err := s.service.PreviousFormState(ctx, formID)
+	if err != nil {
+		return fmt.Errorf("record form deletion: %w", err)
+	}
+
+	return s.record(ctx, &Record{
+		Action:     ActionFormDeleted,
+		TargetType: TargetForm,
+		TargetID:   formID,
+		OwnerID:    ActorFromContext(ctx).UserID,
+		Before:     before,
+	})
+}
+
+// handleSubmissionUpdated records an owner's edit or restore of a submission
+func (s *Subscriber) handleSubmissionUpdated(ctx context.Context, event events.Event) error {
+	payload, ok := event.Payload().(*formevents.SubmissionUpdatedPayload)
+	if !ok || payload.Submission == nil {
+		return formevents.ErrInvalidEventPayload
+	}
+
+	submission := payload.Submission
+
+	f, err := s.formService.GetForm(ctx, submission.FormID)
+	if err != nil {
+		return fmt.Errorf("record submission update: %w", err)
+	}
+
+	return s.record(ctx, &Record{
+		Action:     ActionSubmissionUpdated,
+		ActorID:    payload.EditorID,
+		TargetType: TargetSubmission,
+		TargetID:   submission.ID,
+		OwnerID:    f.UserID,
+		After: model.JSON{
+			"form_id":  submission.FormID,
+			"revision": payload.Revision,
+			"fields":   payload.Fields,
+		},
+	})
+}
+
+// record appends a record, logging which action failed to be recorded
+func (s *Subscriber) record(ctx context.Context, record *Record) error {
+	if err := s.service.Record(ctx, record); err != nil {
+		s.logger.Error("failed to record audit event",
+			"action", record.Action, "target_id", record.TargetID, "error", err)
+
+		return fmt.Errorf("record %s: %w", record.Action, err)
+	}
+
+	return nil
+}
+
+// formUpdateAction names the part of a form that changed between two summaries
+func formUpdateAction(before, after model.JSON) string {
+	if before == nil {
+		return ActionFormUpdated
+	}
+
+	schemaChanged := changed(before, after, schemaKeys)
+	corsChanged := changed(before, after, corsKeys)
+	otherChanged := false
+
+	for key := range after {
+		if !slices.Contains(schemaKeys, key) && !slices.Contains(corsKeys, key) &&
+			changed(before, after, []string{key}) {
+			otherChanged = true
+		}
+	}
+
+	switch {
+	case schemaChanged && !corsChanged && !otherChanged:
+		return ActionFormSchemaUpdated
+	case corsChanged && !schemaChanged && !otherChanged:
+		return ActionFormCORSUpdated
+	default:
+		return ActionFormUpdated
+	}
+}
+
+// changed reports whether any of keys differ between two summaries
+func changed(before, after model.JSON, keys []string) bool {
+	for _, key := range keys {
+		if !reflect.DeepEqual(before[key], after[key]) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,211 @@
+package audit_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/goformx/goforms/internal/domain/audit"
+	"github.com/goformx/goforms/internal/domain/common/events"
+	formevents "github.com/goformx/goforms/internal/domain/form/events"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	mockaudit "github.com/goformx/goforms/test/mocks/audit"
+	mockevents "github.com/goformx/goforms/test/mocks/events"
+	mockform "github.com/goformx/goforms/test/mocks/form"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+func auditedForm() *model.Form {
+	return &model.Form{
+		ID:     "form-1",
+		UserID: "owner-1",
+		Title:  "Contact",
+		Status: "draft",
+		Schema: model.JSON{"components": []any{
+			map[string]any{"key": "name", "label": "Name", "input": true},
+		}},
+		CorsOrigins: model.JSON{"origins": []any{"https://example.com"}},
+	}
+}
+
+// expectRecord captures the record appended by the subscriber
+func expectRecord(service *mockaudit.MockService) *audit.Record {
+	record := &audit.Record{}
+
+	service.EXPECT().Record(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, r *audit.Record) error {
+			*record = *r
+
+			return nil
+		})
+
+	return record
+}
+
+func TestSubscriber_formCreated(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	service := mockaudit.NewMockService(ctrl)
+
+	handlers := map[string]func(context.Context, events.Event) error{}
+	bus := mockevents.NewMockSubscriber(ctrl)
+	bus.EXPECT().Subscribe(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, name string, handler func(context.Context, events.Event) error) error {
+			handlers[name] = handler
+
+			return nil
+		}).AnyTimes()
+	sub := audit.NewSubscriber(service, mockform.NewMockService(ctrl), mocklogging.NewMockLogger(ctrl))
+	require.NoError(t, sub.Subscribe(t.Context(), bus))
+
+	record := expectRecord(service)
+
+	event := formevents.NewFormCreatedEvent(auditedForm())
+	require.NoError(t, handlers[event.Name()](t.Context(), event))
+
+	assert.Equal(t, audit.ActionFormCreated, record.Action)
+	assert.Equal(t, "form-1", record.TargetID)
+	assert.Equal(t, "owner-1", record.OwnerID)
+	assert.Nil(t, record.Before)
+	assert.Equal(t, "Contact", record.After["title"])
+	assert.Equal(t, []any{"name"}, record.After["fields"])
+}
+
+func TestSubscriber_formUpdated_namesChangedPart(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(*model.Form)
+		action string
+	}{
+		{
+			name: "schema only",
+			change: func(form *model.Form) {
+				form.Schema = model.JSON{"components": []any{
+					map[string]any{"key": "name", "label": "Full name", "input": true},
+				}}
+			},
+			action: audit.ActionFormSchemaUpdated,
+		},
+		{
+			name: "cors only",
+			change: func(form *model.Form) {
+				form.CorsOrigins = model.JSON{"origins": []any{"https://example.org"}}
+			},
+			action: audit.ActionFormCORSUpdated,
+		},
+		{
+			name: "details and schema",
+			change: func(form *model.Form) {
+				form.Title = "Contact us"
+				form.Schema = model.JSON{}
+			},
+			action: audit.ActionFormUpdated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			service := mockaudit.NewMockService(ctrl)
+
+			handlers := map[string]func(context.Context, events.Event) error{}
+			bus := mockevents.NewMockSubscriber(ctrl)
+			bus.EXPECT().Subscribe(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, name string, handler func(context.Context, events.Event) error) error {
+					handlers[name] = handler
+
+					return nil
+				}).AnyTimes()
+			sub := audit.NewSubscriber(service, mockform.NewMockService(ctrl), mocklogging.NewMockLogger(ctrl))
+			require.NoError(t, sub.Subscribe(t.Context(), bus))
+
+			before := audit.FormSummary(auditedForm())
+			service.EXPECT().PreviousFormState(gomock.Any(), "form-1").Return(before, nil)
+			record := expectRecord(service)
+
+			updated := auditedForm()
+			tt.change(updated)
+
+			event := formevents.NewFormUpdatedEvent(updated)
+			require.NoError(t, handlers[event.Name()](t.Context(), event))
+			assert.Equal(t, tt.action, record.Action)
+			assert.Equal(t, before, record.Before)
+			assert.Equal(t, audit.FormSummary(updated), record.After)
+		})
+	}
+}
+
+func TestSubscriber_formUpdated_withoutHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	service := mockaudit.NewMockService(ctrl)
+
+	handlers := map[string]func(context.Context, events.Event) error{}
+	bus := mockevents.NewMockSubscriber(ctrl)
+	bus.EXPECT().Subscribe(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, name string, handler func(context.Context, events.Event) error) error {
+			handlers[name] = handler
+
+			return nil
+		}).AnyTimes()
+	sub := audit.NewSubscriber(service, mockform.NewMockService(ctrl), mocklogging.NewMockLogger(ctrl))
+	require.NoError(t, sub.Subscribe(t.Context(), bus))
+
+	service.EXPECT().PreviousFormState(gomock.Any(), "form-1").Return(nil, nil)
+	record := expectRecord(service)
+
+	event := formevents.NewFormUpdatedEvent(auditedForm())
+	require.NoError(t, handlers[event.Name()](t.Context(), event))
+	assert.Equal(t, audit.ActionFormUpdated, record.Action)
+	assert.Nil(t, record.Before)
+}
+
+func TestSubscriber_formDeleted_ownerIsActor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	service := mockaudit.NewMockService(ctrl)
+
+	handlers := map[string]func(context.Context, events.Event) error{}
+	bus := mockevents.NewMockSubscriber(ctrl)
+	bus.EXPECT().Subscribe(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, name string, handler func(context.Context, events.Event) error) error {
+			handlers[name] = handler
+
+			return nil
+		}).AnyTimes()
+	sub := audit.NewSubscriber(service, mockform.NewMockService(ctrl), mocklogging.NewMockLogger(ctrl))
+	require.NoError(t, sub.Subscribe(t.Context(), bus))
+
+	before := audit.FormSummary(auditedForm())
+	service.EXPECT().PreviousFormState(gomock.Any(), "form-1").Return(before, nil)
+	record := expectRecord(service)
+
+	ctx := audit.WithActor(t.Context(), audit.Actor{UserID: "owner-1"})
+	event := formevents.NewFormDeletedEvent("form-1")
+	require.NoError(t, handlers[event.Name()](ctx, event))
+
+	assert.Equal(t, audit.ActionFormDeleted, record.Action)
+	assert.Equal(t, "owner-1", record.OwnerID)
+	assert.Equal(t, before, record.Before)
+	assert.Nil(t, record.After)
+}
+
+func TestSubscriber_invalidPayload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	service := mockaudit.NewMockService(ctrl)
+
+	handlers := map[string]func(context.Context, events.Event) error{}
+	bus := mockevents.NewMockSubscriber(ctrl)
+	bus.EXPECT().Subscribe(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, name string, handler func(context.Context, events.Event) error) error {
+			handlers[name] = handler
+
+			return nil
+		}).AnyTimes()
+	sub := audit.NewSubscriber(service, mockform.NewMockService(ctrl), mocklogging.NewMockLogger(ctrl))
+	require.NoError(t, sub.Subscribe(t.Context(), bus))
+
+	event := formevents.NewEvent(formevents.FormCreatedEventType, "form-1")
+	err := handlers[event.Name()](t.Context(), event)
+	require.ErrorIs(t, err, formevents.ErrInvalidEventPayload)
+}
@@ -19,6 +19,14 @@ type Event interface {
 	Metadata() map[string]any
 }
 
+// Streamed is implemented by events that belong to a stream, such as the events
+// of one form. Asynchronous buses hand the events of a stream to each handler in
+// the order they were published.
+type Streamed interface {
+	// StreamKey identifies the event's stream, or is empty for none
+	StreamKey() string
+}
+
 // BaseEvent provides common event functionality
 type BaseEvent struct {
 	eventName string
@@ -66,6 +74,16 @@ type Subscriber interface {
 	Unsubscribe(ctx context.Context, eventName string) error
 }
 
+// ClusterSubscriber is implemented by event buses that fan events out across
+// application instances. Handlers subscribed through Subscribe only receive
+// events published on their own instance, so side effects such as audit records
+// happen once. Handlers subscribed through SubscribeCluster receive the events
+// of every instance, for example to invalidate caches or update live views.
+type ClusterSubscriber interface {
+	// SubscribeCluster subscribes to an event published on any instance
+	SubscribeCluster(ctx context.Context, eventName string, handler func(ctx context.Context, event Event) error) error
+}
+
 // EventHandler defines the interface for handling events
 type EventHandler interface {
 	// Handle handles an event
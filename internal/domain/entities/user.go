@@ -33,9 +33,17 @@ type User struct {
 	LastName       string         `json:"last_name" gorm:"not null;size:100"`
 	Role           string         `json:"role" gorm:"not null;size:50;default:user"`
 	Active         bool           `json:"active" gorm:"not null;default:true"`
+	Locale         string         `json:"locale" gorm:"not null;size:10;default:''"`
 	CreatedAt      time.Time      `json:"created_at" gorm:"not null;autoCreateTime"`
 	UpdatedAt      time.Time      `json:"updated_at" gorm:"not null;autoUpdateTime"`
 	DeletedAt      gorm.DeletedAt `json:"-" gorm:"index"`
+	// SessionsRevokedAt ends every session started at or before it
+	SessionsRevokedAt *time.Time `json:"-" gorm:"column:sessions_revoked_at"`
+	// FailedLoginAttempts counts the failed logins since the last successful
+	// one or the last lockout
+	FailedLoginAttempts int `json:"-" gorm:"not null;default:0"`
+	// LockedUntil refuses logins until it has passed
+	LockedUntil *time.Time `json:"-"`
 }
 
 // TableName specifies the table name for the User model
@@ -161,6 +169,43 @@ func (u *User) Activate() {
 	u.UpdatedAt = time.Now()
 }
 
+// SessionValid reports whether a session the user started at startedAt may
+// still be used
+func (u *User) SessionValid(startedAt time.Time) bool {
+	if !u.Active {
+		return false
+	}
+
+	return u.SessionsRevokedAt == nil || startedAt.After(*u.SessionsRevokedAt)
+}
+
+// Locked reports whether logins are refused at now after too many failed attempts
+func (u *User) Locked(now time.Time) bool {
+	return u.LockedUntil != nil && now.Before(*u.LockedUntil)
+}
+
+// RecordFailedLogin counts a failed login attempt and, once maxAttempts have
+// failed in a row, locks the account for lockout. It reports whether this
+// attempt locked the account.
+func (u *User) RecordFailedLogin(now time.Time, maxAttempts int, lockout time.Duration) bool {
+	u.FailedLoginAttempts++
+	if u.FailedLoginAttempts < maxAttempts {
+		return false
+	}
+
+	until := now.Add(lockout)
+	u.LockedUntil = &until
+	u.FailedLoginAttempts = 0
+
+	return true
+}
+
+// ResetFailedLogins forgets the failed login attempts after a successful login
+func (u *User) ResetFailedLogins() {
+	u.FailedLoginAttempts = 0
+	u.LockedUntil = nil
+}
+
 // UpdateProfile updates the user's profile information
 func (u *User) UpdateProfile(firstName, lastName string) {
 	u.FirstName = firstName
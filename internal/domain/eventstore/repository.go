@@ -0,0 +1,19 @@
+//go:generate mockgen -typed -source=repository.go -destination=../../../test/mocks/eventstore/mock_repository.go -package=eventstore
+
+package eventstore
+
+import "context"
+
+// Repository stores events. It is append-only: there is no way to change or
+// remove a record.
+type Repository interface {
+	// Append stores a new record and sets its sequence number
+	Append(ctx context.Context, record *Record) error
+	// List returns the records matching filter in sequence order
+	List(ctx context.Context, filter Filter) ([]*Record, error)
+	// Checkpoint returns the last sequence number a consumer handled, or 0 if
+	// it has not handled any
+	Checkpoint(ctx context.Context, consumer string) (int64, error)
+	// SaveCheckpoint stores the last sequence number a consumer handled
+	SaveCheckpoint(ctx context.Context, consumer string, sequence int64) error
+}
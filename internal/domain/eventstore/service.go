@@ -0,0 +1,312 @@
+//go:generate mockgen -typed -source=service.go -destination=../../../test/mocks/eventstore/mock_service.go -package=eventstore -mock_names=Service=MockService
+
+package eventstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/goformx/goforms/internal/domain/common/events"
+	formevents "github.com/goformx/goforms/internal/domain/form/events"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+const (
+	// BatchSize is the number of records read from the log at a time
+	BatchSize = 500
+	// PollInterval is how often Subscribe checks for new events once it has
+	// caught up
+	PollInterval = time.Second
+	// SettleDelay is how long a consumer waits before handling a new record.
+	// Sequence numbers are assigned when an insert starts, so a record can become
+	// visible after one with a greater number; waiting for concurrent inserts to
+	// commit keeps a subscriber from moving its checkpoint past them.
+	SettleDelay = 2 * time.Second
+)
+
+// Handler handles a replayed event
+type Handler func(ctx context.Context, event events.Event) error
+
+// Service defines the interface for writing and replaying the event log
+type Service interface {
+	// Append stores an event and returns its record
+	Append(ctx context.Context, event events.Event) (*Record, error)
+	// Replay passes the stored events matching filter to handler in sequence
+	// order, stopping at the first handler error. A named consumer starts after
+	// its checkpoint and saves its progress; without one, progress is not kept.
+	Replay(ctx context.Context, consumer string, filter Filter, handler Handler) (*ReplayResult, error)
+	// Subscribe replays like Replay and then waits for new events until ctx
+	// ends or handler fails
+	Subscribe(ctx context.Context, consumer string, filter Filter, handler Handler) (*ReplayResult, error)
+	// Checkpoint returns the last sequence number a consumer handled
+	Checkpoint(ctx context.Context, consumer string) (int64, error)
+}
+
+// service implements Service
+type service struct {
+	repository   Repository
+	logger       logging.Logger
+	payloadTypes map[string]reflect.Type
+	now          func() time.Time
+	pollInterval time.Duration
+	settleDelay  time.Duration
+}
+
+// NewService creates a new event store service for the form domain events
+func NewService(repository Repository, logger logging.Logger) Service {
+	payloadTypes := make(map[string]reflect.Type)
+	for eventType, payloadType := range formevents.PayloadTypes() {
+		payloadTypes[string(eventType)] = payloadType
+	}
+
+	return &service{
+		repository:   repository,
+		logger:       logger,
+		payloadTypes: payloadTypes,
+		now:          time.Now,
+		pollInterval: PollInterval,
+		settleDelay:  SettleDelay,
+	}
+}
+
+// Append stores an event
+func (s *service) Append(ctx context.Context, event events.Event) (*Record, error) {
+	payload, err := json.Marshal(storedPayload(event.Payload()))
+	if err != nil {
+		return nil, fmt.Errorf("encode %s payload: %w", event.Name(), err)
+	}
+
+	streamType, streamID := formevents.StreamOf(event.Payload())
+
+	record := &Record{
+		ID:         uuid.New().String(),
+		Name:       event.Name(),
+		StreamType: streamType,
+		StreamID:   streamID,
+		Payload:    payload,
+		OccurredAt: event.Timestamp().UTC(),
+		RecordedAt: s.now().UTC(),
+	}
+
+	if metadata := event.Metadata(); len(metadata) > 0 {
+		record.Metadata = model.JSON(metadata)
+	}
+
+	if appendErr := s.repository.Append(ctx, record); appendErr != nil {
+		return nil, fmt.Errorf("store %s event: %w", event.Name(), appendErr)
+	}
+
+	return record, nil
+}
+
+// storedPayload returns a payload as it is written to the log. The log is never
+// changed, so submissions are stored without their answers and metadata, which
+// erasure and retention must be able to remove: only their IDs, status, times
+// and the names of the answered fields are kept.
+func storedPayload(payload any) any {
+	switch p := payload.(type) {
+	case *model.FormSubmission:
+		return withoutAnswers(p)
+	case *formevents.SubmissionUpdatedPayload:
+		stored := *p
+		stored.Submission = withoutAnswers(p.Submission)
+
+		return &stored
+	default:
+		return payload
+	}
+}
+
+// withoutAnswers returns a copy of a submission whose answers are all null
+func withoutAnswers(submission *model.FormSubmission) *model.FormSubmission {
+	if submission == nil {
+		return nil
+	}
+
+	stored := *submission
+	stored.Data = make(model.JSON, len(submission.Data))
+	stored.Metadata = nil
+
+	for field := range submission.Data {
+		stored.Data[field] = nil
+	}
+
+	return &stored
+}
+
+// Replay passes the stored events matching filter to handler
+func (s *service) Replay(
+	ctx context.Context,
+	consumer string,
+	filter Filter,
+	handler Handler,
+) (*ReplayResult, error) {
+	return s.run(ctx, consumer, filter, handler, false)
+}
+
+// Subscribe replays the stored events and then polls for new ones
+func (s *service) Subscribe(
+	ctx context.Context,
+	consumer string,
+	filter Filter,
+	handler Handler,
+) (*ReplayResult, error) {
+	return s.run(ctx, consumer, filter, handler, true)
+}
+
+// run replays the events matching filter, saving a named consumer's progress
+// after each read, and polls for new events when follow is set
+func (s *service) run(
+	ctx context.Context,
+	consumer string,
+	filter Filter,
+	handler Handler,
+	follow bool,
+) (*ReplayResult, error) {
+	if consumer != "" {
+		checkpoint, err := s.repository.Checkpoint(ctx, consumer)
+		if err != nil {
+			return nil, fmt.Errorf("load checkpoint of %s: %w", consumer, err)
+		}
+
+		filter.After = max(filter.After, checkpoint)
+	}
+
+	result := &ReplayResult{LastSequence: filter.After}
+	limit := filter.Limit
+	ticker := time.NewTicker(s.pollInterval)
+
+	defer ticker.Stop()
+
+	for {
+		before := result.LastSequence
+		filter.After = result.LastSequence
+
+		if follow || consumer != "" {
+			filter.RecordedBefore = s.now().UTC().Add(-s.settleDelay)
+		}
+
+		if limit > 0 {
+			filter.Limit = limit - result.Events
+		}
+
+		done, err := s.drain(ctx, filter, handler, result)
+
+		if consumer != "" && result.LastSequence > before {
+			if saveErr := s.repository.SaveCheckpoint(
+				context.WithoutCancel(ctx), consumer, result.LastSequence,
+			); saveErr != nil {
+				return result, fmt.Errorf("save checkpoint of %s: %w", consumer, saveErr)
+			}
+
+			s.logger.Debug("saved event log checkpoint", "consumer", consumer, "sequence", result.LastSequence)
+		}
+
+		if err != nil || done || !follow {
+			return result, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return result, nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Checkpoint returns the last sequence number a consumer handled
+func (s *service) Checkpoint(ctx context.Context, consumer string) (int64, error) {
+	checkpoint, err := s.repository.Checkpoint(ctx, consumer)
+	if err != nil {
+		return 0, fmt.Errorf("load checkpoint of %s: %w", consumer, err)
+	}
+
+	return checkpoint, nil
+}
+
+// drain reads the records matching filter in batches and passes them to
+// handler, updating result as it goes. It reports whether filter.Until or the
+// limit was reached, after which there is nothing left to wait for.
+func (s *service) drain(ctx context.Context, filter Filter, handler Handler, result *ReplayResult) (bool, error) {
+	limit := filter.Limit
+	handled := 0
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return false, nil
+		}
+
+		filter.Limit = BatchSize
+		if limit > 0 {
+			filter.Limit = min(BatchSize, limit-handled)
+		}
+
+		records, err := s.repository.List(ctx, filter)
+		if err != nil {
+			return false, fmt.Errorf("read event log: %w", err)
+		}
+
+		for _, record := range records {
+			event, decodeErr := s.decode(record)
+			if decodeErr != nil {
+				return false, decodeErr
+			}
+
+			if handleErr := handler(ctx, event); handleErr != nil {
+				return false, fmt.Errorf("handle event %d (%s): %w", record.Sequence, record.Name, handleErr)
+			}
+
+			result.Events++
+			result.LastSequence = record.Sequence
+			filter.After = record.Sequence
+			handled++
+		}
+
+		if filter.Until > 0 && result.LastSequence >= filter.Until || limit > 0 && handled >= limit {
+			return true, nil
+		}
+
+		if len(records) < filter.Limit {
+			return false, nil
+		}
+	}
+}
+
+// decode converts a record into an event with a typed payload. Payloads of
+// events without a registered type are left as raw JSON.
+func (s *service) decode(record *Record) (*Event, error) {
+	event := &Event{
+		Sequence:   record.Sequence,
+		ID:         record.ID,
+		StreamType: record.StreamType,
+		StreamID:   record.StreamID,
+		name:       record.Name,
+		timestamp:  record.OccurredAt,
+		payload:    record.Payload,
+		metadata:   record.Metadata,
+	}
+
+	if event.metadata == nil {
+		event.metadata = make(map[string]any)
+	}
+
+	payloadType, ok := s.payloadTypes[record.Name]
+	if !ok {
+		return event, nil
+	}
+
+	payload := reflect.New(payloadType)
+	if err := json.Unmarshal(record.Payload, payload.Interface()); err != nil {
+		return nil, fmt.Errorf("decode payload of event %d (%s): %w", record.Sequence, record.Name, err)
+	}
+
+	event.payload = payload.Elem().Interface()
+
+	return event, nil
+}
@@ -0,0 +1,336 @@
+package eventstore_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/goformx/goforms/internal/domain/common/events"
+	"github.com/goformx/goforms/internal/domain/eventstore"
+	formevents "github.com/goformx/goforms/internal/domain/form/events"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	mockeventstore "github.com/goformx/goforms/test/mocks/eventstore"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+// collect returns a handler that appends the events it handles to list
+func collect(list *[]events.Event) eventstore.Handler {
+	return func(_ context.Context, event events.Event) error {
+		*list = append(*list, event)
+
+		return nil
+	}
+}
+
+// recordOf returns event as it is read back from the log
+func recordOf(t *testing.T, sequence int64, event events.Event) *eventstore.Record {
+	t.Helper()
+
+	payload, err := json.Marshal(event.Payload())
+	require.NoError(t, err)
+
+	return &eventstore.Record{Sequence: sequence, Name: event.Name(), Payload: payload, OccurredAt: event.Timestamp()}
+}
+
+// settled matches the read of a consumer after sequence, which leaves out the
+// records still within the settle delay
+func settled(after int64) gomock.Matcher {
+	return gomock.Cond(func(filter eventstore.Filter) bool {
+		age := time.Since(filter.RecordedBefore)
+
+		return filter.After == after && filter.Limit == eventstore.BatchSize &&
+			age >= eventstore.SettleDelay && age < eventstore.SettleDelay+time.Minute
+	})
+}
+
+func TestService_AppendAndReplay(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mockeventstore.NewMockRepository(ctrl)
+	svc := eventstore.NewService(repo, mocklogging.NewMockLogger(ctrl))
+
+	var records []*eventstore.Record
+
+	repo.EXPECT().Append(gomock.Any(), gomock.Any()).Times(4).
+		DoAndReturn(func(_ context.Context, record *eventstore.Record) error {
+			record.Sequence = int64(len(records) + 1)
+			records = append(records, record)
+
+			return nil
+		})
+
+	submission := &model.FormSubmission{ID: "sub-1", FormID: "form-1", Data: model.JSON{"email": "a@example.com"}}
+	updated := formevents.NewSubmissionUpdatedEvent(submission, "owner-1", 2, []string{"email"})
+	updated.Metadata()["request_id"] = "req-1"
+
+	for _, event := range []events.Event{
+		formevents.NewFormCreatedEvent(&model.Form{ID: "form-1", Title: "Contact"}),
+		formevents.NewFormSubmittedEvent(submission),
+		updated,
+		formevents.NewFormDeletedEvent("form-1"),
+	} {
+		_, err := svc.Append(t.Context(), event)
+		require.NoError(t, err)
+	}
+
+	require.Len(t, records, 4)
+	assert.Equal(t, formevents.StreamSubmission, records[2].StreamType)
+	assert.Equal(t, "sub-1", records[2].StreamID)
+	assert.Equal(t, formevents.StreamForm, records[3].StreamType)
+	assert.Equal(t, "form-1", records[3].StreamID)
+
+	repo.EXPECT().List(gomock.Any(), eventstore.Filter{Limit: eventstore.BatchSize}).Return(records, nil)
+
+	var replayed []events.Event
+
+	result, err := svc.Replay(t.Context(), "", eventstore.Filter{}, collect(&replayed))
+	require.NoError(t, err)
+	assert.Equal(t, &eventstore.ReplayResult{Events: 4, LastSequence: 4}, result)
+	require.Len(t, replayed, 4)
+
+	form, ok := replayed[0].Payload().(*model.Form)
+	require.True(t, ok, "payload is %T", replayed[0].Payload())
+	assert.Equal(t, "Contact", form.Title)
+
+	payload, ok := replayed[2].Payload().(*formevents.SubmissionUpdatedPayload)
+	require.True(t, ok, "payload is %T", replayed[2].Payload())
+	assert.Equal(t, "owner-1", payload.EditorID)
+	assert.Equal(t, 2, payload.Revision)
+	assert.Equal(t, model.JSON{"email": nil}, payload.Submission.Data)
+	assert.True(t, updated.Timestamp().Equal(replayed[2].Timestamp()))
+	assert.Equal(t, "req-1", replayed[2].Metadata()["request_id"])
+
+	stored, ok := replayed[3].(*eventstore.Event)
+	require.True(t, ok)
+	assert.Equal(t, int64(4), stored.Sequence)
+	assert.Equal(t, "form-1", stored.Payload())
+}
+
+func TestService_Append_withoutAnswers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mockeventstore.NewMockRepository(ctrl)
+	svc := eventstore.NewService(repo, mocklogging.NewMockLogger(ctrl))
+
+	var records []*eventstore.Record
+
+	repo.EXPECT().Append(gomock.Any(), gomock.Any()).Times(2).
+		DoAndReturn(func(_ context.Context, record *eventstore.Record) error {
+			record.Sequence = int64(len(records) + 1)
+			records = append(records, record)
+
+			return nil
+		})
+
+	submission := &model.FormSubmission{
+		ID:       "sub-1",
+		FormID:   "form-1",
+		Data:     model.JSON{"email": "ada@example.com", "name": "Ada"},
+		Metadata: model.JSON{"calculation_mismatches": []any{map[string]any{"submitted": "Ada"}}},
+		Status:   model.SubmissionStatusPending,
+	}
+
+	for _, event := range []events.Event{
+		formevents.NewFormSubmittedEvent(submission),
+		formevents.NewSubmissionUpdatedEvent(submission, "owner-1", 2, []string{"name"}),
+	} {
+		_, err := svc.Append(t.Context(), event)
+		require.NoError(t, err)
+	}
+
+	require.Len(t, records, 2)
+
+	for _, record := range records {
+		assert.NotContains(t, string(record.Payload), "ada@example.com")
+		assert.NotContains(t, string(record.Payload), "Ada")
+		assert.Equal(t, "sub-1", record.StreamID)
+	}
+
+	repo.EXPECT().List(gomock.Any(), eventstore.Filter{Limit: eventstore.BatchSize}).Return(records, nil)
+
+	var replayed []events.Event
+
+	_, err := svc.Replay(t.Context(), "", eventstore.Filter{}, collect(&replayed))
+	require.NoError(t, err)
+
+	stored, ok := replayed[0].Payload().(*model.FormSubmission)
+	require.True(t, ok, "payload is %T", replayed[0].Payload())
+	assert.Equal(t, "form-1", stored.FormID)
+	assert.Equal(t, model.SubmissionStatusPending, stored.Status)
+	assert.Equal(t, model.JSON{"email": nil, "name": nil}, stored.Data)
+	assert.Nil(t, stored.Metadata)
+
+	assert.Equal(t, "ada@example.com", submission.Data["email"], "the published submission is left as is")
+}
+
+func TestService_Replay_filters(t *testing.T) {
+	names := []string{"form.created", "form.deleted"}
+
+	tests := []struct {
+		name   string
+		filter eventstore.Filter
+		read   eventstore.Filter
+	}{
+		{"everything", eventstore.Filter{}, eventstore.Filter{Limit: eventstore.BatchSize}},
+		{
+			"range",
+			eventstore.Filter{After: 1, Until: 3},
+			eventstore.Filter{After: 1, Until: 3, Limit: eventstore.BatchSize},
+		},
+		{"names", eventstore.Filter{Names: names}, eventstore.Filter{Names: names, Limit: eventstore.BatchSize}},
+		{
+			"stream",
+			eventstore.Filter{StreamType: formevents.StreamForm, StreamID: "form-1"},
+			eventstore.Filter{StreamType: formevents.StreamForm, StreamID: "form-1", Limit: eventstore.BatchSize},
+		},
+		{"limit", eventstore.Filter{After: 2, Limit: 2}, eventstore.Filter{After: 2, Limit: 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mockeventstore.NewMockRepository(ctrl)
+			svc := eventstore.NewService(repo, mocklogging.NewMockLogger(ctrl))
+
+			repo.EXPECT().List(gomock.Any(), tt.read).Return([]*eventstore.Record{
+				recordOf(t, tt.filter.After+1, formevents.NewFormDeletedEvent("form-1")),
+				recordOf(t, tt.filter.After+2, formevents.NewFormDeletedEvent("form-2")),
+			}, nil)
+
+			var replayed []events.Event
+
+			result, err := svc.Replay(t.Context(), "", tt.filter, collect(&replayed))
+			require.NoError(t, err)
+			assert.Equal(t, &eventstore.ReplayResult{Events: 2, LastSequence: tt.filter.After + 2}, result)
+			assert.Len(t, replayed, 2)
+		})
+	}
+}
+
+func TestService_Replay_readsInBatches(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mockeventstore.NewMockRepository(ctrl)
+	svc := eventstore.NewService(repo, mocklogging.NewMockLogger(ctrl))
+
+	records := make([]*eventstore.Record, 0, eventstore.BatchSize+10)
+	for i := range eventstore.BatchSize + 10 {
+		records = append(records, recordOf(t, int64(i+1), formevents.NewAnalyticsEvent("form-1", "view")))
+	}
+
+	gomock.InOrder(
+		repo.EXPECT().List(gomock.Any(), eventstore.Filter{Limit: eventstore.BatchSize}).
+			Return(records[:eventstore.BatchSize], nil),
+		repo.EXPECT().List(gomock.Any(), eventstore.Filter{After: eventstore.BatchSize, Limit: eventstore.BatchSize}).
+			Return(records[eventstore.BatchSize:], nil),
+	)
+
+	result, err := svc.Replay(t.Context(), "", eventstore.Filter{}, func(context.Context, events.Event) error {
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, eventstore.BatchSize+10, result.Events)
+	assert.Equal(t, int64(eventstore.BatchSize+10), result.LastSequence)
+}
+
+func TestService_Replay_resumesFromCheckpoint(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mockeventstore.NewMockRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	svc := eventstore.NewService(repo, logger)
+
+	// Records still within the settle delay are left for the next run
+	repo.EXPECT().Checkpoint(gomock.Any(), "rebuild").Return(int64(2), nil)
+	repo.EXPECT().List(gomock.Any(), settled(2)).Return(nil, nil)
+
+	var replayed []events.Event
+
+	result, err := svc.Replay(t.Context(), "rebuild", eventstore.Filter{}, collect(&replayed))
+	require.NoError(t, err)
+	assert.Equal(t, &eventstore.ReplayResult{LastSequence: 2}, result)
+
+	repo.EXPECT().Checkpoint(gomock.Any(), "rebuild").Return(int64(2), nil)
+	repo.EXPECT().List(gomock.Any(), settled(2)).Return([]*eventstore.Record{
+		recordOf(t, 3, formevents.NewFormDeletedEvent("form-1")),
+	}, nil)
+	repo.EXPECT().SaveCheckpoint(gomock.Any(), "rebuild", int64(3)).Return(nil)
+	logger.EXPECT().Debug("saved event log checkpoint", "consumer", "rebuild", "sequence", int64(3))
+
+	result, err = svc.Replay(t.Context(), "rebuild", eventstore.Filter{}, collect(&replayed))
+	require.NoError(t, err)
+	assert.Equal(t, &eventstore.ReplayResult{Events: 1, LastSequence: 3}, result)
+	require.Len(t, replayed, 1)
+	assert.Equal(t, string(formevents.FormDeletedEventType), replayed[0].Name())
+}
+
+func TestService_Replay_handlerErrorKeepsProgress(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mockeventstore.NewMockRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	svc := eventstore.NewService(repo, logger)
+
+	repo.EXPECT().Checkpoint(gomock.Any(), "rebuild").Return(int64(0), nil)
+	repo.EXPECT().List(gomock.Any(), settled(0)).Return([]*eventstore.Record{
+		recordOf(t, 1, formevents.NewFormCreatedEvent(&model.Form{ID: "form-1"})),
+		recordOf(t, 2, formevents.NewFormDeletedEvent("form-1")),
+		recordOf(t, 3, formevents.NewFormCreatedEvent(&model.Form{ID: "form-2"})),
+	}, nil)
+
+	// The failed event is handled again on the next run
+	repo.EXPECT().SaveCheckpoint(gomock.Any(), "rebuild", int64(1)).Return(nil)
+	logger.EXPECT().Debug("saved event log checkpoint", "consumer", "rebuild", "sequence", int64(1))
+
+	errHandler := errors.New("handler failed")
+
+	result, err := svc.Replay(t.Context(), "rebuild", eventstore.Filter{},
+		func(_ context.Context, event events.Event) error {
+			if event.Name() == string(formevents.FormDeletedEventType) {
+				return errHandler
+			}
+
+			return nil
+		})
+	require.ErrorIs(t, err, errHandler)
+	assert.Equal(t, int64(1), result.LastSequence)
+}
+
+func TestService_Subscribe_followsNewEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mockeventstore.NewMockRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	svc := eventstore.NewService(repo, logger)
+
+	// The stored event is handled first, the one recorded later on the next poll
+	repo.EXPECT().Checkpoint(gomock.Any(), "live").Return(int64(0), nil)
+	gomock.InOrder(
+		repo.EXPECT().List(gomock.Any(), settled(0)).Return([]*eventstore.Record{
+			recordOf(t, 1, formevents.NewFormCreatedEvent(&model.Form{ID: "form-1"})),
+		}, nil),
+		repo.EXPECT().SaveCheckpoint(gomock.Any(), "live", int64(1)).Return(nil),
+		repo.EXPECT().List(gomock.Any(), settled(1)).Return([]*eventstore.Record{
+			recordOf(t, 2, formevents.NewFormDeletedEvent("form-1")),
+		}, nil),
+		repo.EXPECT().SaveCheckpoint(gomock.Any(), "live", int64(2)).Return(nil),
+	)
+	logger.EXPECT().Debug("saved event log checkpoint", "consumer", "live", "sequence", gomock.Any()).Times(2)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+	defer cancel()
+
+	handled := make(chan events.Event, 10)
+
+	result, err := svc.Subscribe(ctx, "live", eventstore.Filter{}, func(_ context.Context, event events.Event) error {
+		handled <- event
+
+		if len(handled) == 2 {
+			cancel()
+		}
+
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &eventstore.ReplayResult{Events: 2, LastSequence: 2}, result)
+}
@@ -0,0 +1,47 @@
+package eventstore
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/goformx/goforms/internal/domain/common/events"
+	formevents "github.com/goformx/goforms/internal/domain/form/events"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+// Subscriber appends the form domain events published on this instance to the
+// event log. Each instance records its own events, so every event is stored once.
+type Subscriber struct {
+	service Service
+	logger  logging.Logger
+}
+
+// NewSubscriber creates a new event log subscriber
+func NewSubscriber(service Service, logger logging.Logger) *Subscriber {
+	return &Subscriber{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// Subscribe registers the subscriber's handler for every form event type with bus
+func (s *Subscriber) Subscribe(ctx context.Context, bus events.Subscriber) error {
+	for eventType := range formevents.PayloadTypes() {
+		if err := bus.Subscribe(ctx, string(eventType), s.handle); err != nil {
+			return fmt.Errorf("subscribe to %s: %w", eventType, err)
+		}
+	}
+
+	return nil
+}
+
+// handle appends an event to the log
+func (s *Subscriber) handle(ctx context.Context, event events.Event) error {
+	if _, err := s.service.Append(ctx, event); err != nil {
+		s.logger.Error("failed to store event", "event", event.Name(), "error", err)
+
+		return err
+	}
+
+	return nil
+}
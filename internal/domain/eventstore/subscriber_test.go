@@ -0,0 +1,49 @@
+package eventstore_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/goformx/goforms/internal/domain/common/events"
+	"github.com/goformx/goforms/internal/domain/eventstore"
+	formevents "github.com/goformx/goforms/internal/domain/form/events"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	mockevents "github.com/goformx/goforms/test/mocks/events"
+	mockeventstore "github.com/goformx/goforms/test/mocks/eventstore"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+func TestSubscriber_recordsEveryFormEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	service := mockeventstore.NewMockService(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+
+	handlers := map[string]func(context.Context, events.Event) error{}
+	bus := mockevents.NewMockSubscriber(ctrl)
+	bus.EXPECT().Subscribe(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, name string, handler func(context.Context, events.Event) error) error {
+			handlers[name] = handler
+
+			return nil
+		}).AnyTimes()
+
+	require.NoError(t, eventstore.NewSubscriber(service, logger).Subscribe(t.Context(), bus))
+
+	for eventType := range formevents.PayloadTypes() {
+		assert.Contains(t, handlers, string(eventType))
+	}
+
+	event := formevents.NewFormCreatedEvent(&model.Form{ID: "form-1"})
+	service.EXPECT().Append(gomock.Any(), event).Return(&eventstore.Record{Sequence: 1}, nil)
+
+	require.NoError(t, handlers[event.Name()](t.Context(), event))
+
+	service.EXPECT().Append(gomock.Any(), event).Return(nil, assert.AnError)
+	logger.EXPECT().Error(gomock.Any(), gomock.Any()).AnyTimes()
+
+	require.ErrorIs(t, handlers[event.Name()](t.Context(), event), assert.AnError)
+}
@@ -0,0 +1,195 @@
+// Package calculation recomputes Form.io calculated fields on the server.
+//
+// Components with a calculateValue expression are filled in by the browser,
+// so a submitted value cannot be trusted. Recalculate evaluates the expressions
+// again over the submitted data, in a sandboxed subset of JavaScript (see
+// Expression), and replaces or flags the values that do not match.
+package calculation
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+const (
+	// calculateValueProperty holds a component's calculation
+	calculateValueProperty = "calculateValue"
+	// allowOverrideProperty lets the submitter change a calculated value
+	allowOverrideProperty = "allowCalculateOverride"
+	// tolerance is the relative difference below which numbers are equal, so
+	// that rounding differences between browsers are not reported
+	tolerance = 1e-9
+)
+
+// Mismatch is a calculated field whose submitted value differs from the
+// server's result
+type Mismatch struct {
+	Field      string `json:"field"`
+	Submitted  any    `json:"submitted"`
+	Calculated any    `json:"calculated"`
+	// Overridden is set when the submitted value was replaced. Fields that
+	// allow calculate override keep the submitted value and are only flagged.
+	Overridden bool `json:"overridden"`
+}
+
+// Result is the outcome of recalculating a submission
+type Result struct {
+	// Data is the submission data with the calculated values applied
+	Data model.JSON
+	// Mismatches lists the fields whose submitted value was wrong
+	Mismatches []Mismatch
+	// Unevaluated lists the calculated fields that could not be checked, such
+	// as JSONLogic calculations or expressions outside the supported subset
+	Unevaluated []string
+}
+
+// calculated is a component with a calculateValue expression
+type calculated struct {
+	key           string
+	source        any
+	allowOverride bool
+}
+
+// Recalculate evaluates the calculated components of form over data, in
+// schema order so that a calculation can use the result of an earlier one.
+// data is not modified.
+func Recalculate(form *model.Form, data model.JSON, now time.Time) *Result {
+	result := &Result{Data: make(model.JSON, len(data))}
+
+	for k, v := range data {
+		result.Data[k] = v
+	}
+
+	for _, c := range calculatedComponents(form) {
+		src, ok := c.source.(string)
+		if !ok || strings.TrimSpace(src) == "" {
+			result.Unevaluated = append(result.Unevaluated, c.key)
+
+			continue
+		}
+
+		submitted := result.Data[c.key]
+
+		value, err := evaluate(src, result.Data, submitted, now)
+		if err != nil {
+			result.Unevaluated = append(result.Unevaluated, c.key)
+
+			continue
+		}
+
+		if equal(value, submitted) {
+			continue
+		}
+
+		// An overridable field left empty was not overridden
+		if c.allowOverride && submitted == nil {
+			result.Data[c.key] = value
+
+			continue
+		}
+
+		mismatch := Mismatch{Field: c.key, Submitted: submitted, Calculated: value, Overridden: !c.allowOverride}
+		if mismatch.Overridden {
+			result.Data[c.key] = value
+		}
+
+		result.Mismatches = append(result.Mismatches, mismatch)
+	}
+
+	return result
+}
+
+// evaluate compiles and runs one calculation
+func evaluate(src string, data model.JSON, submitted any, now time.Time) (any, error) {
+	expr, err := Compile(src)
+	if err != nil {
+		return nil, err
+	}
+
+	return expr.Evaluate(Env{Data: data, Value: normalize(submitted), Now: now.UTC()})
+}
+
+// calculatedComponents returns the input components of the form's schema
+// that have a calculation. Layout components are searched the same way as
+// for model.Form.SensitiveFields.
+func calculatedComponents(form *model.Form) []calculated {
+	var components []calculated
+
+	var walk func(list any)
+
+	walk = func(list any) {
+		items, ok := list.([]any)
+		if !ok {
+			return
+		}
+
+		for _, item := range items {
+			component, isMap := item.(map[string]any)
+			if !isMap {
+				continue
+			}
+
+			if input, _ := component["input"].(bool); input {
+				key, _ := component["key"].(string)
+				if source, has := component[calculateValueProperty]; has && source != nil && key != "" {
+					allow, _ := component[allowOverrideProperty].(bool)
+					components = append(components, calculated{key: key, source: source, allowOverride: allow})
+				}
+
+				continue
+			}
+
+			walk(component["components"])
+
+			if columns, hasColumns := component["columns"].([]any); hasColumns {
+				for _, column := range columns {
+					if col, isCol := column.(map[string]any); isCol {
+						walk(col["components"])
+					}
+				}
+			}
+		}
+	}
+
+	walk(form.Schema["components"])
+
+	return components
+}
+
+// equal reports whether a submitted value matches the calculated one. Numbers
+// may be submitted as strings, and empty answers match an empty result.
+func equal(calculated, submitted any) bool {
+	submitted = normalize(submitted)
+
+	switch c := calculated.(type) {
+	case float64:
+		switch s := submitted.(type) {
+		case float64:
+			return closeTo(c, s)
+		case string:
+			f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+
+			return err == nil && closeTo(c, f)
+		default:
+			return false
+		}
+	case nil:
+		return submitted == nil || submitted == ""
+	case string:
+		if c == "" && submitted == nil {
+			return true
+		}
+	}
+
+	return reflect.DeepEqual(calculated, submitted)
+}
+
+// closeTo compares numbers within tolerance
+func closeTo(a, b float64) bool {
+	return math.Abs(a-b) <= tolerance*math.Max(1, math.Max(math.Abs(a), math.Abs(b)))
+}
@@ -0,0 +1,78 @@
+package calculation_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/domain/form/calculation"
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+func calculatedForm() *model.Form {
+	return &model.Form{ID: "form-1", Schema: model.JSON{
+		"components": []any{
+			map[string]any{"type": "number", "key": "price", "input": true},
+			map[string]any{"type": "number", "key": "quantity", "input": true},
+			map[string]any{"type": "panel", "components": []any{
+				map[string]any{
+					"type": "number", "key": "subtotal", "input": true,
+					"calculateValue": "value = data.price * data.quantity",
+				},
+			}},
+			map[string]any{
+				"type": "number", "key": "total", "input": true,
+				"calculateValue": "value = data.subtotal * 1.2",
+			},
+			map[string]any{
+				"type": "number", "key": "tip", "input": true,
+				"calculateValue":         "value = Math.round(data.total * 0.1)",
+				"allowCalculateOverride": true,
+			},
+			map[string]any{
+				"type": "textfield", "key": "code", "input": true,
+				"calculateValue": map[string]any{"cat": []any{"A", "B"}},
+			},
+			map[string]any{
+				"type": "textfield", "key": "unsafe", "input": true,
+				"calculateValue": "value = window.location",
+			},
+		},
+	}}
+}
+
+func TestRecalculate(t *testing.T) {
+	data := model.JSON{
+		"price":    10,
+		"quantity": 2.0,
+		"subtotal": 1.0,
+		"total":    "24",
+		"tip":      5.0,
+		"code":     "AB",
+		"unsafe":   "x",
+	}
+
+	result := calculation.Recalculate(calculatedForm(), data, now)
+
+	// The subtotal is replaced, and the total is computed from the corrected subtotal
+	assert.Equal(t, 20.0, result.Data["subtotal"])
+	assert.Equal(t, "24", result.Data["total"], "numbers submitted as strings match")
+	assert.Equal(t, 5.0, result.Data["tip"], "overridable values are kept")
+	assert.Equal(t, 1.0, data["subtotal"], "input must not be modified")
+
+	assert.Equal(t, []calculation.Mismatch{
+		{Field: "subtotal", Submitted: 1.0, Calculated: 20.0, Overridden: true},
+		{Field: "tip", Submitted: 5.0, Calculated: 2.0, Overridden: false},
+	}, result.Mismatches)
+	assert.Equal(t, []string{"code", "unsafe"}, result.Unevaluated)
+}
+
+func TestRecalculate_fillsMissingValues(t *testing.T) {
+	result := calculation.Recalculate(calculatedForm(), model.JSON{"price": 1.5, "quantity": 4.0}, now)
+
+	require.Len(t, result.Mismatches, 2)
+	assert.InDelta(t, 6.0, result.Data["subtotal"], 1e-9)
+	assert.InDelta(t, 7.2, result.Data["total"], 1e-9)
+	assert.InDelta(t, 1.0, result.Data["tip"], 1e-9, "empty overridable values are filled in")
+}
@@ -0,0 +1,639 @@
+package calculation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+const (
+	// valueVariable is the variable an expression assigns its result to
+	valueVariable = "value"
+	// maxValueLength bounds the strings, in bytes, and the arrays, in elements,
+	// an evaluation reads or builds
+	maxValueLength = 16 * 1024
+	// maxSteps bounds the nodes an evaluation visits
+	maxSteps = 2_000
+)
+
+// undefined is the result of reading a missing element
+var undefined any
+
+// Env is the data an expression is evaluated against
+type Env struct {
+	// Data is the submission data, available as data and row
+	Data map[string]any
+	// Value is the submitted value of the calculated component
+	Value any
+	// Now is the time moment() returns
+	Now time.Time
+}
+
+// Evaluate runs the expression and returns the value of its last assignment to
+// value or, for expressions written without one, of its last expression
+// statement. Numbers are float64, and dates are returned as ISO 8601 strings.
+func (e *Expression) Evaluate(env Env) (any, error) {
+	s := &scope{
+		env:    env,
+		locals: map[string]any{valueVariable: env.Value},
+	}
+
+	var result any
+
+	for _, st := range e.statements {
+		v, err := s.eval(st.expr)
+		if err != nil {
+			return nil, err
+		}
+
+		switch st.target {
+		case "":
+			result = v
+		case valueVariable:
+			result = v
+			s.locals[valueVariable] = v
+		default:
+			s.locals[st.target] = v
+		}
+	}
+
+	return export(result)
+}
+
+// export converts an evaluation result to the value stored in submission data
+func export(v any) (any, error) {
+	switch x := v.(type) {
+	case float64:
+		if math.IsNaN(x) || math.IsInf(x, 0) {
+			return nil, errors.New("result is not a finite number")
+		}
+
+		return x, nil
+	case moment:
+		return x.isoString(), nil
+	case []any:
+		out := make([]any, len(x))
+
+		for i, item := range x {
+			exported, err := export(item)
+			if err != nil {
+				return nil, err
+			}
+
+			out[i] = exported
+		}
+
+		return out, nil
+	case nil, bool, string, map[string]any:
+		return x, nil
+	default:
+		return nil, fmt.Errorf("result of type %s cannot be stored", typeName(v))
+	}
+}
+
+// scope holds the variables of one evaluation
+type scope struct {
+	env    Env
+	locals map[string]any
+	steps  int
+}
+
+// eval evaluates a node within the evaluation's budget. Expressions have no
+// loops, but each statement can double a string or array built by the ones
+// before, so the size of every value is bounded as well as the work.
+func (s *scope) eval(n node) (any, error) {
+	s.steps++
+	if s.steps > maxSteps {
+		return nil, fmt.Errorf("%w: more than %d evaluation steps", ErrUnsupported, maxSteps)
+	}
+
+	v, err := n.eval(s)
+	if err != nil {
+		return nil, err
+	}
+
+	switch x := v.(type) {
+	case string:
+		if len(x) > maxValueLength {
+			return nil, fmt.Errorf("%w: string longer than %d bytes", ErrUnsupported, maxValueLength)
+		}
+	case []any:
+		if len(x) > maxValueLength {
+			return nil, fmt.Errorf("%w: array longer than %d elements", ErrUnsupported, maxValueLength)
+		}
+	}
+
+	return v, nil
+}
+
+// lookup returns the value of a variable
+func (s *scope) lookup(name string) (any, error) {
+	if v, ok := s.locals[name]; ok {
+		return v, nil
+	}
+
+	switch name {
+	case "data", "row":
+		return map[string]any(s.env.Data), nil
+	case "moment":
+		return builtin(s.moment), nil
+	}
+
+	if g, ok := globals[name]; ok {
+		return g, nil
+	}
+
+	return nil, fmt.Errorf("%w: unknown variable %q", ErrUnsupported, name)
+}
+
+// node is a node of the expression tree
+type node interface {
+	eval(s *scope) (any, error)
+}
+
+// literalNode is a constant
+type literalNode struct {
+	value any
+}
+
+func (n *literalNode) eval(_ *scope) (any, error) {
+	return n.value, nil
+}
+
+// variableNode reads a variable
+type variableNode struct {
+	name string
+}
+
+func (n *variableNode) eval(s *scope) (any, error) {
+	return s.lookup(n.name)
+}
+
+// arrayNode is an array literal
+type arrayNode struct {
+	elements []node
+}
+
+func (n *arrayNode) eval(s *scope) (any, error) {
+	values := make([]any, len(n.elements))
+
+	for i, element := range n.elements {
+		v, err := s.eval(element)
+		if err != nil {
+			return nil, err
+		}
+
+		values[i] = v
+	}
+
+	return values, nil
+}
+
+// conditionalNode is cond ? then : otherwise
+type conditionalNode struct {
+	cond, then, otherwise node
+}
+
+func (n *conditionalNode) eval(s *scope) (any, error) {
+	cond, err := s.eval(n.cond)
+	if err != nil {
+		return nil, err
+	}
+
+	if truthy(cond) {
+		return s.eval(n.then)
+	}
+
+	return s.eval(n.otherwise)
+}
+
+// unaryNode is a prefix operator
+type unaryNode struct {
+	op      string
+	operand node
+}
+
+func (n *unaryNode) eval(s *scope) (any, error) {
+	v, err := s.eval(n.operand)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.op {
+	case "!":
+		return !truthy(v), nil
+	case "-":
+		return -toNumber(v), nil
+	default:
+		return toNumber(v), nil
+	}
+}
+
+// binaryNode is an infix operator
+type binaryNode struct {
+	op          string
+	left, right node
+}
+
+func (n *binaryNode) eval(s *scope) (any, error) {
+	left, err := s.eval(n.left)
+	if err != nil {
+		return nil, err
+	}
+
+	// The logical operators short-circuit and return an operand, as in JavaScript
+	switch n.op {
+	case "&&":
+		if !truthy(left) {
+			return left, nil
+		}
+
+		return s.eval(n.right)
+	case "||":
+		if truthy(left) {
+			return left, nil
+		}
+
+		return s.eval(n.right)
+	case "??":
+		if left != nil {
+			return left, nil
+		}
+
+		return s.eval(n.right)
+	}
+
+	right, err := s.eval(n.right)
+	if err != nil {
+		return nil, err
+	}
+
+	return binary(n.op, left, right)
+}
+
+// binary applies an arithmetic or comparison operator
+func binary(op string, left, right any) (any, error) {
+	switch op {
+	case "+":
+		if isStringLike(left) || isStringLike(right) {
+			return toString(left) + toString(right), nil
+		}
+
+		return toNumber(left) + toNumber(right), nil
+	case "-":
+		return toNumber(left) - toNumber(right), nil
+	case "*":
+		return toNumber(left) * toNumber(right), nil
+	case "/":
+		return toNumber(left) / toNumber(right), nil
+	case "%":
+		return math.Mod(toNumber(left), toNumber(right)), nil
+	case "==":
+		return looseEqual(left, right), nil
+	case "!=":
+		return !looseEqual(left, right), nil
+	case "===":
+		return strictEqual(left, right), nil
+	case "!==":
+		return !strictEqual(left, right), nil
+	default:
+		return compare(op, left, right), nil
+	}
+}
+
+// compare applies a relational operator. Strings are compared as strings,
+// everything else as numbers.
+func compare(op string, left, right any) bool {
+	if l, ok := left.(string); ok {
+		if r, isString := right.(string); isString {
+			return compareOrdered(op, strings.Compare(l, r), 0)
+		}
+	}
+
+	l, r := toNumber(left), toNumber(right)
+	if math.IsNaN(l) || math.IsNaN(r) {
+		return false
+	}
+
+	return compareOrdered(op, l, r)
+}
+
+// compareOrdered applies a relational operator to ordered values
+func compareOrdered[T int | float64](op string, l, r T) bool {
+	switch op {
+	case "<":
+		return l < r
+	case "<=":
+		return l <= r
+	case ">":
+		return l > r
+	default:
+		return l >= r
+	}
+}
+
+// memberNode is object.name or object[property]
+type memberNode struct {
+	object   node
+	property node
+	// name is set for dotted access, which can also name a method
+	name string
+}
+
+func (n *memberNode) eval(s *scope) (any, error) {
+	object, err := s.eval(n.object)
+	if err != nil {
+		return nil, err
+	}
+
+	property, err := s.eval(n.property)
+	if err != nil {
+		return nil, err
+	}
+
+	return member(object, property)
+}
+
+// member reads a property of a value
+func member(object, property any) (any, error) {
+	switch o := object.(type) {
+	case map[string]any:
+		return normalize(o[toString(property)]), nil
+	case []any:
+		if property == "length" {
+			return float64(len(o)), nil
+		}
+
+		if i, ok := index(property, len(o)); ok {
+			return normalize(o[i]), nil
+		}
+
+		return undefined, nil
+	case string:
+		if property == "length" {
+			return float64(len([]rune(o))), nil
+		}
+
+		runes := []rune(o)
+		if i, ok := index(property, len(runes)); ok {
+			return string(runes[i]), nil
+		}
+
+		return undefined, nil
+	case namespace:
+		if v, ok := o[toString(property)]; ok {
+			return v, nil
+		}
+	case nil:
+		return nil, fmt.Errorf("cannot read property %q of undefined", toString(property))
+	}
+
+	return nil, fmt.Errorf("%w: property %q of %s", ErrUnsupported, toString(property), typeName(object))
+}
+
+// normalize converts a value read from submission data to the types the
+// evaluator works with
+func normalize(v any) any {
+	switch x := v.(type) {
+	case int:
+		return float64(x)
+	case int64:
+		return float64(x)
+	case float32:
+		return float64(x)
+	case json.Number:
+		f, err := x.Float64()
+		if err != nil {
+			return x.String()
+		}
+
+		return f
+	case model.JSON:
+		return map[string]any(x)
+	default:
+		return v
+	}
+}
+
+// index converts an array index, reporting whether it is in range
+func index(property any, length int) (int, bool) {
+	f, ok := property.(float64)
+	if !ok || f != math.Trunc(f) || f < 0 || f >= float64(length) {
+		return 0, false
+	}
+
+	return int(f), true
+}
+
+// callNode is a function or method call
+type callNode struct {
+	callee node
+	args   []node
+}
+
+func (n *callNode) eval(s *scope) (any, error) {
+	args := make([]any, len(n.args))
+
+	for i, arg := range n.args {
+		v, err := s.eval(arg)
+		if err != nil {
+			return nil, err
+		}
+
+		args[i] = v
+	}
+
+	// Methods are called on the value of the object
+	if m, ok := n.callee.(*memberNode); ok && m.name != "" {
+		receiver, err := s.eval(m.object)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, isNamespace := receiver.(namespace); !isNamespace {
+			return callMethod(receiver, m.name, args)
+		}
+	}
+
+	callee, err := s.eval(n.callee)
+	if err != nil {
+		return nil, err
+	}
+
+	fn, ok := callee.(builtin)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s is not a function", ErrUnsupported, typeName(callee))
+	}
+
+	return fn(args)
+}
+
+// truthy converts a value to a boolean as JavaScript does
+func truthy(v any) bool {
+	switch x := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return x
+	case float64:
+		return x != 0 && !math.IsNaN(x)
+	case string:
+		return x != ""
+	default:
+		return true
+	}
+}
+
+// toNumber converts a value to a number as JavaScript does
+func toNumber(v any) float64 {
+	switch x := v.(type) {
+	case nil:
+		return 0
+	case bool:
+		if x {
+			return 1
+		}
+
+		return 0
+	case float64:
+		return x
+	case string:
+		trimmed := strings.TrimSpace(x)
+		if trimmed == "" {
+			return 0
+		}
+
+		f, err := strconv.ParseFloat(trimmed, 64)
+		if err != nil {
+			return math.NaN()
+		}
+
+		return f
+	case moment:
+		return float64(x.t.UnixMilli())
+	default:
+		return math.NaN()
+	}
+}
+
+// toString converts a value to a string as JavaScript does
+func toString(v any) string {
+	switch x := v.(type) {
+	case nil:
+		return "undefined"
+	case bool:
+		return strconv.FormatBool(x)
+	case float64:
+		return formatNumber(x)
+	case string:
+		return x
+	case moment:
+		return x.isoString()
+	case []any:
+		parts := make([]string, len(x))
+		for i, item := range x {
+			if item != nil {
+				parts[i] = toString(item)
+			}
+		}
+
+		return strings.Join(parts, ",")
+	default:
+		return "[object Object]"
+	}
+}
+
+// formatNumber formats a number as JavaScript does for ordinary magnitudes
+func formatNumber(f float64) string {
+	switch {
+	case math.IsNaN(f):
+		return "NaN"
+	case math.IsInf(f, 1):
+		return "Infinity"
+	case math.IsInf(f, -1):
+		return "-Infinity"
+	}
+
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// isStringLike reports whether + concatenates with v
+func isStringLike(v any) bool {
+	switch v.(type) {
+	case string, []any, map[string]any, moment:
+		return true
+	default:
+		return false
+	}
+}
+
+// looseEqual implements ==
+func looseEqual(left, right any) bool {
+	if left == nil || right == nil {
+		return left == nil && right == nil
+	}
+
+	_, leftString := left.(string)
+	_, rightString := right.(string)
+
+	if leftString && rightString {
+		return left == right
+	}
+
+	if isPrimitive(left) && isPrimitive(right) {
+		return toNumber(left) == toNumber(right)
+	}
+
+	return strictEqual(left, right)
+}
+
+// strictEqual implements ===. Arrays and objects are compared by content,
+// since values from submission data have no identity.
+func strictEqual(left, right any) bool {
+	if l, ok := left.(moment); ok {
+		r, isMoment := right.(moment)
+
+		return isMoment && l.t.Equal(r.t)
+	}
+
+	return reflect.DeepEqual(left, right)
+}
+
+// isPrimitive reports whether v is a boolean, number or string
+func isPrimitive(v any) bool {
+	switch v.(type) {
+	case bool, float64, string:
+		return true
+	default:
+		return false
+	}
+}
+
+// typeName describes the type of a value in error messages
+func typeName(v any) string {
+	switch v.(type) {
+	case nil:
+		return "undefined"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case moment:
+		return "date"
+	case builtin:
+		return "function"
+	default:
+		return "object"
+	}
+}
@@ -0,0 +1,563 @@
+package calculation
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	// maxSourceLength bounds the size of an expression
+	maxSourceLength = 4096
+	// maxDepth bounds the nesting of an expression
+	maxDepth = 64
+)
+
+// ErrUnsupported is returned for expressions that use JavaScript outside the
+// supported subset
+var ErrUnsupported = errors.New("unsupported expression")
+
+// Expression is a compiled calculateValue expression.
+//
+// The language is a side-effect free subset of JavaScript: literals, the
+// arithmetic, comparison, logical and conditional operators, property access
+// on data, and calls to a fixed set of helpers. Statements are separated by
+// semicolons and may declare local variables or assign the result to value.
+// Locals are assigned once, where they are declared. There are no loops,
+// function definitions or access to anything but the submission data and the
+// helpers, and Evaluate bounds both the steps it takes and the size of every
+// string and array, so evaluation terminates in bounded time and memory.
+type Expression struct {
+	statements []statement
+}
+
+// statement is a local declaration, an assignment to value or a bare expression
+type statement struct {
+	// target is the assigned variable, or empty for a bare expression
+	target string
+	expr   node
+}
+
+// Compile parses an expression
+func Compile(src string) (*Expression, error) {
+	if len(src) > maxSourceLength {
+		return nil, fmt.Errorf("%w: longer than %d characters", ErrUnsupported, maxSourceLength)
+	}
+
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens, locals: map[string]bool{}}
+
+	statements, err := p.program()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Expression{statements: statements}, nil
+}
+
+// token kinds
+const (
+	tokenEOF = iota
+	tokenNumber
+	tokenString
+	tokenIdent
+	tokenPunct
+)
+
+// token is a lexical token
+type token struct {
+	kind  int
+	text  string
+	num   float64
+	str   string
+	index int
+}
+
+// punctuators lists the operators, longest first
+var punctuators = []string{
+	"===", "!==", "==", "!=", "<=", ">=", "&&", "||", "??",
+	"+", "-", "*", "/", "%", "<", ">", "!", "?", ":", ".", ",", "(", ")", "[", "]", "=", ";",
+}
+
+// lex splits src into tokens
+func lex(src string) ([]token, error) {
+	var tokens []token
+
+	for i := 0; i < len(src); {
+		c := src[i]
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case strings.HasPrefix(src[i:], "//"):
+			end := strings.IndexByte(src[i:], '\n')
+			if end < 0 {
+				end = len(src) - i
+			}
+
+			i += end
+		case strings.HasPrefix(src[i:], "/*"):
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("%w: unterminated comment", ErrUnsupported)
+			}
+
+			i += end + 4
+		case c >= '0' && c <= '9' || c == '.' && i+1 < len(src) && src[i+1] >= '0' && src[i+1] <= '9':
+			start := i
+			for i < len(src) && (src[i] >= '0' && src[i] <= '9' || src[i] == '.') {
+				i++
+			}
+
+			num, err := strconv.ParseFloat(src[start:i], 64)
+			if err != nil {
+				return nil, fmt.Errorf("%w: invalid number %q", ErrUnsupported, src[start:i])
+			}
+
+			tokens = append(tokens, token{kind: tokenNumber, text: src[start:i], num: num, index: start})
+		case c == '\'' || c == '"':
+			str, end, err := lexString(src, i)
+			if err != nil {
+				return nil, err
+			}
+
+			tokens = append(tokens, token{kind: tokenString, text: src[i:end], str: str, index: i})
+			i = end
+		case c == '_' || c == '$' || unicode.IsLetter(rune(c)):
+			start := i
+			for i < len(src) && (src[i] == '_' || src[i] == '$' ||
+				unicode.IsLetter(rune(src[i])) || unicode.IsDigit(rune(src[i]))) {
+				i++
+			}
+
+			tokens = append(tokens, token{kind: tokenIdent, text: src[start:i], index: start})
+		default:
+			p := matchPunct(src[i:])
+			if p == "" {
+				return nil, fmt.Errorf("%w: unexpected %q at %d", ErrUnsupported, c, i)
+			}
+
+			tokens = append(tokens, token{kind: tokenPunct, text: p, index: i})
+			i += len(p)
+		}
+	}
+
+	return append(tokens, token{kind: tokenEOF, index: len(src)}), nil
+}
+
+// lexString reads the quoted string starting at src[start] and returns its
+// value and the index after the closing quote
+func lexString(src string, start int) (string, int, error) {
+	quote := src[start]
+
+	var b strings.Builder
+
+	for i := start + 1; i < len(src); i++ {
+		switch c := src[i]; c {
+		case quote:
+			return b.String(), i + 1, nil
+		case '\\':
+			i++
+			if i == len(src) {
+				return "", 0, fmt.Errorf("%w: unterminated string", ErrUnsupported)
+			}
+
+			switch e := src[i]; e {
+			case 'n':
+				b.WriteByte('\n')
+			case 't':
+				b.WriteByte('\t')
+			default:
+				b.WriteByte(e)
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	return "", 0, fmt.Errorf("%w: unterminated string", ErrUnsupported)
+}
+
+// matchPunct returns the operator at the start of s
+func matchPunct(s string) string {
+	for _, p := range punctuators {
+		if strings.HasPrefix(s, p) {
+			return p
+		}
+	}
+
+	return ""
+}
+
+// parser is a recursive descent parser over tokens
+type parser struct {
+	tokens []token
+	pos    int
+	depth  int
+	locals map[string]bool
+}
+
+// peek returns the current token
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+// next consumes the current token
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+
+	return t
+}
+
+// is reports whether the current token is the punctuator or keyword text
+func (p *parser) is(text string) bool {
+	t := p.peek()
+
+	return (t.kind == tokenPunct || t.kind == tokenIdent) && t.text == text
+}
+
+// accept consumes the current token if it is text
+func (p *parser) accept(text string) bool {
+	if p.is(text) {
+		p.next()
+
+		return true
+	}
+
+	return false
+}
+
+// expect consumes text or fails
+func (p *parser) expect(text string) error {
+	if !p.accept(text) {
+		return p.unexpected()
+	}
+
+	return nil
+}
+
+// unexpected returns an error for the current token
+func (p *parser) unexpected() error {
+	t := p.peek()
+	if t.kind == tokenEOF {
+		return fmt.Errorf("%w: unexpected end of expression", ErrUnsupported)
+	}
+
+	return fmt.Errorf("%w: unexpected %q at %d", ErrUnsupported, t.text, t.index)
+}
+
+// program parses statements separated by semicolons
+func (p *parser) program() ([]statement, error) {
+	var statements []statement
+
+	for p.peek().kind != tokenEOF {
+		if p.accept(";") {
+			continue
+		}
+
+		s, err := p.statement()
+		if err != nil {
+			return nil, err
+		}
+
+		statements = append(statements, s)
+
+		if p.peek().kind != tokenEOF {
+			if expectErr := p.expect(";"); expectErr != nil {
+				return nil, expectErr
+			}
+		}
+	}
+
+	if len(statements) == 0 {
+		return nil, fmt.Errorf("%w: empty expression", ErrUnsupported)
+	}
+
+	return statements, nil
+}
+
+// statement parses a declaration, an assignment or an expression
+func (p *parser) statement() (statement, error) {
+	if p.is("var") || p.is("let") || p.is("const") {
+		p.next()
+
+		name := p.next()
+		if name.kind != tokenIdent || isReserved(name.text) {
+			return statement{}, fmt.Errorf("%w: invalid variable name %q", ErrUnsupported, name.text)
+		}
+
+		if name.text == valueVariable || p.locals[name.text] {
+			return statement{}, fmt.Errorf("%w: %q is already declared", ErrUnsupported, name.text)
+		}
+
+		if err := p.expect("="); err != nil {
+			return statement{}, err
+		}
+
+		expr, err := p.expression()
+		if err != nil {
+			return statement{}, err
+		}
+
+		p.locals[name.text] = true
+
+		return statement{target: name.text, expr: expr}, nil
+	}
+
+	t := p.peek()
+	if t.kind == tokenIdent && p.tokens[p.pos+1].text == "=" && p.tokens[p.pos+1].kind == tokenPunct {
+		if t.text != valueVariable {
+			return statement{}, fmt.Errorf("%w: assignment to %q", ErrUnsupported, t.text)
+		}
+
+		p.pos += 2
+
+		expr, err := p.expression()
+		if err != nil {
+			return statement{}, err
+		}
+
+		return statement{target: t.text, expr: expr}, nil
+	}
+
+	expr, err := p.expression()
+	if err != nil {
+		return statement{}, err
+	}
+
+	return statement{expr: expr}, nil
+}
+
+// expression parses a conditional expression
+func (p *parser) expression() (node, error) {
+	p.depth++
+	defer func() { p.depth-- }()
+
+	if p.depth > maxDepth {
+		return nil, fmt.Errorf("%w: nested too deeply", ErrUnsupported)
+	}
+
+	cond, err := p.binary(0)
+	if err != nil {
+		return nil, err
+	}
+
+	if !p.accept("?") {
+		return cond, nil
+	}
+
+	then, err := p.expression()
+	if err != nil {
+		return nil, err
+	}
+
+	if expectErr := p.expect(":"); expectErr != nil {
+		return nil, expectErr
+	}
+
+	otherwise, err := p.expression()
+	if err != nil {
+		return nil, err
+	}
+
+	return &conditionalNode{cond: cond, then: then, otherwise: otherwise}, nil
+}
+
+// precedence lists the binary operators from the loosest binding
+var precedence = [][]string{
+	{"||", "??"},
+	{"&&"},
+	{"==", "!=", "===", "!=="},
+	{"<", "<=", ">", ">="},
+	{"+", "-"},
+	{"*", "/", "%"},
+}
+
+// binary parses left-associative binary operators at or above level
+func (p *parser) binary(level int) (node, error) {
+	if level == len(precedence) {
+		return p.unary()
+	}
+
+	left, err := p.binary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		t := p.peek()
+		if t.kind != tokenPunct || !slices.Contains(precedence[level], t.text) {
+			return left, nil
+		}
+
+		p.next()
+
+		right, rightErr := p.binary(level + 1)
+		if rightErr != nil {
+			return nil, rightErr
+		}
+
+		left = &binaryNode{op: t.text, left: left, right: right}
+	}
+}
+
+// unary parses prefix operators
+func (p *parser) unary() (node, error) {
+	t := p.peek()
+	if t.kind == tokenPunct && (t.text == "!" || t.text == "-" || t.text == "+") {
+		p.next()
+
+		p.depth++
+		defer func() { p.depth-- }()
+
+		if p.depth > maxDepth {
+			return nil, fmt.Errorf("%w: nested too deeply", ErrUnsupported)
+		}
+
+		operand, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+
+		return &unaryNode{op: t.text, operand: operand}, nil
+	}
+
+	return p.postfix()
+}
+
+// postfix parses property access, indexing and calls
+func (p *parser) postfix() (node, error) {
+	n, err := p.primary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		switch {
+		case p.accept("."):
+			name := p.next()
+			if name.kind != tokenIdent {
+				return nil, fmt.Errorf("%w: expected property name at %d", ErrUnsupported, name.index)
+			}
+
+			n = &memberNode{object: n, property: &literalNode{value: name.text}, name: name.text}
+		case p.accept("["):
+			index, indexErr := p.expression()
+			if indexErr != nil {
+				return nil, indexErr
+			}
+
+			if expectErr := p.expect("]"); expectErr != nil {
+				return nil, expectErr
+			}
+
+			n = &memberNode{object: n, property: index}
+		case p.accept("("):
+			args, argsErr := p.list(")")
+			if argsErr != nil {
+				return nil, argsErr
+			}
+
+			n = &callNode{callee: n, args: args}
+		default:
+			return n, nil
+		}
+	}
+}
+
+// primary parses literals, variables, parenthesized expressions and arrays
+func (p *parser) primary() (node, error) {
+	start := p.pos
+	t := p.next()
+
+	switch t.kind {
+	case tokenNumber:
+		return &literalNode{value: t.num}, nil
+	case tokenString:
+		return &literalNode{value: t.str}, nil
+	case tokenIdent:
+		switch t.text {
+		case "true":
+			return &literalNode{value: true}, nil
+		case "false":
+			return &literalNode{value: false}, nil
+		case "null", "undefined":
+			return &literalNode{value: nil}, nil
+		}
+
+		if isReserved(t.text) {
+			return nil, fmt.Errorf("%w: %q is not supported", ErrUnsupported, t.text)
+		}
+
+		return &variableNode{name: t.text}, nil
+	case tokenPunct:
+		switch t.text {
+		case "(":
+			n, err := p.expression()
+			if err != nil {
+				return nil, err
+			}
+
+			if expectErr := p.expect(")"); expectErr != nil {
+				return nil, expectErr
+			}
+
+			return n, nil
+		case "[":
+			elements, err := p.list("]")
+			if err != nil {
+				return nil, err
+			}
+
+			return &arrayNode{elements: elements}, nil
+		}
+	}
+
+	p.pos = start
+
+	return nil, p.unexpected()
+}
+
+// list parses comma separated expressions up to the closing punctuator
+func (p *parser) list(closing string) ([]node, error) {
+	var nodes []node
+
+	for !p.accept(closing) {
+		if len(nodes) > 0 {
+			if err := p.expect(","); err != nil {
+				return nil, err
+			}
+		}
+
+		n, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+
+		nodes = append(nodes, n)
+	}
+
+	return nodes, nil
+}
+
+// reserved lists JavaScript keywords outside the supported subset
+var reserved = []string{
+	"function", "return", "if", "else", "for", "while", "do", "new", "this", "delete", "typeof",
+	"instanceof", "in", "var", "let", "const", "class", "import", "export", "try", "catch",
+	"throw", "with", "yield", "await", "async", "eval", "constructor", "prototype", "__proto__",
+}
+
+// isReserved reports whether name is a keyword that cannot be used
+func isReserved(name string) bool {
+	return slices.Contains(reserved, name)
+}
@@ -0,0 +1,185 @@
+package calculation_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/domain/form/calculation"
+)
+
+var now = time.Date(2024, time.March, 15, 10, 30, 0, 0, time.UTC)
+
+func eval(t *testing.T, src string, data map[string]any) any {
+	t.Helper()
+
+	expr, err := calculation.Compile(src)
+	require.NoError(t, err, src)
+
+	v, err := expr.Evaluate(calculation.Env{Data: data, Now: now})
+	require.NoError(t, err, src)
+
+	return v
+}
+
+func TestExpression_Evaluate(t *testing.T) {
+	data := map[string]any{
+		"price":    19.99,
+		"quantity": 3,
+		"discount": "10",
+		"name":     " ada lovelace ",
+		"country":  "FR",
+		"items": []any{
+			map[string]any{"amount": 2.5},
+			map[string]any{"amount": 4},
+		},
+		"start": "2024-01-31",
+		"dob":   "1990-06-20T00:00:00Z",
+	}
+
+	tests := []struct {
+		src  string
+		want any
+	}{
+		{"value = data.price * data.quantity", 59.97},
+		{"data.price * data.quantity - data.discount", 49.97},
+		{"1 + 2 * 3 - (4 - 2) / 2", 6.0},
+		{"7 % 4", 3.0},
+		{"'total: ' + 2 + 3", "total: 23"},
+		{"data.quantity > 2 && data.country === 'FR' ? 'bulk' : 'single'", "bulk"},
+		{"data.missing ?? 'none'", "none"},
+		{"data.missing || 0", 0.0},
+		{"!data.missing", true},
+		{"data.discount == 10", true},
+		{"data.discount === 10", false},
+		{"var subtotal = data.price * data.quantity; value = Math.round(subtotal * 100) / 100", 59.97},
+		{"value = 1; value = value + 1", 2.0},
+		{"Math.max(data.quantity, 5, 2)", 5.0},
+		{"Math.round(2.5) + Math.round(-2.5)", 1.0},
+		{"Math.floor(-1.5)", -2.0},
+		{"_.sumBy(data.items, 'amount')", 6.5},
+		{"_.sum([1, 2, 3])", 6.0},
+		{"_.round(1.005, 2)", 1.01},
+		{"parseFloat('12.5kg') + parseInt('7.9')", 19.5},
+		{"Number('abc') ? 1 : 2", 2.0},
+		{"(1.005).toFixed(2)", "1.00"},
+		{"(2.5).toFixed(0)", "3"},
+		{"(-0.0001).toFixed(2)", "0.00"},
+		{"data.price.toFixed(1)", "20.0"},
+		{"data.name.trim().toUpperCase()", "ADA LOVELACE"},
+		{"data.name.trim().split(' ')[1]", "lovelace"},
+		{"data.name.trim().substring(0, 3).padStart(5, '*')", "**ada"},
+		{"data.name.trim().slice(-8)", "lovelace"},
+		{"data.name.length", 14.0},
+		{"data.country.includes('F') && data.items.length === 2", true},
+		{"['a', 'b'].join('-')", "a-b"},
+		{"data.items[1].amount", 4.0},
+		{"data.items[5]", nil},
+		{"/* discount */ data.discount / 100 // percent", 0.1},
+	}
+
+	for _, tt := range tests {
+		got := eval(t, tt.src, data)
+		if want, isNumber := tt.want.(float64); isNumber {
+			assert.InDelta(t, want, got, 1e-9, tt.src)
+		} else {
+			assert.Equal(t, tt.want, got, tt.src)
+		}
+	}
+}
+
+func TestExpression_Evaluate_dates(t *testing.T) {
+	data := map[string]any{"start": "2024-01-31", "dob": "1990-06-20T00:00:00Z"}
+
+	tests := []struct {
+		src  string
+		want any
+	}{
+		{"moment()", "2024-03-15T10:30:00.000Z"},
+		{"moment().diff(moment(data.dob), 'years')", 33.0},
+		{"moment(data.start).add(1, 'month').format('YYYY-MM-DD')", "2024-02-29"},
+		{"moment(data.start).subtract(2, 'days').format('D MMM YYYY')", "29 Jan 2024"},
+		{"moment(data.start).add(36, 'hours')", "2024-02-01T12:00:00.000Z"},
+		{"moment('15/03/2024', 'DD/MM/YYYY').isSame(moment('2024-03-15'))", true},
+		{"moment(data.start).isBefore(moment())", true},
+		{"moment(data.start).month() + 1", 1.0},
+		{"moment().diff(moment(data.start), 'days')", 44.0},
+		{"moment('2024-03-01').diff(moment('2024-01-15'), 'months', true) > 1.5", true},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, eval(t, tt.src, data), tt.src)
+	}
+}
+
+func TestCompile_rejectsUnsupported(t *testing.T) {
+	sources := []string{
+		"",
+		"function f() { return 1 }",
+		"while (true) {}",
+		"this.constructor",
+		"new Date()",
+		"data.x = 1",
+		"total = 1",
+		"value += 1",
+		"let a = 1; a = 2",
+		"let a = 1; let a = 2",
+		"let value = 1",
+		"typeof data",
+		"data.items.map(x => x)",
+		"`template`",
+		"1 +",
+		"'unterminated",
+	}
+
+	for _, src := range sources {
+		_, err := calculation.Compile(src)
+		require.ErrorIs(t, err, calculation.ErrUnsupported, src)
+	}
+}
+
+func TestExpression_Evaluate_errors(t *testing.T) {
+	sources := []string{
+		"process.exit()",
+		"data.missing.field",
+		"data.name.constructor('alert(1)')",
+		"Math.constructor",
+		"data.items.map(1)",
+		"require('fs')",
+		"1 / 0",
+		"moment('not a date')",
+		"'x'.padStart(100000)",
+	}
+
+	for _, src := range sources {
+		expr, err := calculation.Compile(src)
+		require.NoError(t, err, src)
+
+		_, err = expr.Evaluate(calculation.Env{
+			Data: map[string]any{"name": "Ada", "items": []any{}},
+			Now:  now,
+		})
+		require.Error(t, err, src)
+	}
+}
+
+func TestExpression_Evaluate_bounded(t *testing.T) {
+	sources := map[string]string{
+		"doubling string": "value = 'xxxxxxxx'" + strings.Repeat("; value = value + value", 40),
+		"long array":      "data.items.length",
+		"step budget":     "1" + strings.Repeat("+1", 2000),
+	}
+
+	data := map[string]any{"items": make([]any, 20000)}
+
+	for name, src := range sources {
+		expr, err := calculation.Compile(src)
+		require.NoError(t, err, name)
+
+		_, err = expr.Evaluate(calculation.Env{Data: data, Now: now})
+		require.ErrorIs(t, err, calculation.ErrUnsupported, name)
+	}
+}
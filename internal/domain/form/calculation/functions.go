@@ -0,0 +1,452 @@
+package calculation
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// maxPadLength bounds the strings padStart and padEnd build
+	maxPadLength = 1024
+	// maxFractionDigits is the largest precision toFixed accepts
+	maxFractionDigits = 20
+)
+
+// builtin is a helper function callable from expressions
+type builtin func(args []any) (any, error)
+
+// namespace is a helper object such as Math
+type namespace map[string]any
+
+var (
+	// floatPrefix matches the number parseFloat reads from the start of a string
+	floatPrefix = regexp.MustCompile(`^[+-]?(\d+\.?\d*|\.\d+)([eE][+-]?\d+)?`)
+	// intPrefix matches the number parseInt reads from the start of a string
+	intPrefix = regexp.MustCompile(`^[+-]?\d+`)
+)
+
+// globals are the helpers available to every expression, a subset of the
+// JavaScript globals and of lodash (_)
+var globals = map[string]any{
+	"Math": namespace{
+		"PI":    math.Pi,
+		"E":     math.E,
+		"abs":   numberFunc(math.Abs),
+		"ceil":  numberFunc(math.Ceil),
+		"floor": numberFunc(math.Floor),
+		"round": numberFunc(jsRound),
+		"trunc": numberFunc(math.Trunc),
+		"sqrt":  numberFunc(math.Sqrt),
+		"sign": numberFunc(func(x float64) float64 {
+			if x == 0 || math.IsNaN(x) {
+				return x
+			}
+
+			return math.Copysign(1, x)
+		}),
+		"pow": builtin(func(args []any) (any, error) {
+			return math.Pow(argNumber(args, 0), argNumber(args, 1)), nil
+		}),
+		"min": builtin(func(args []any) (any, error) {
+			return reduceNumbers(args, math.Inf(1), math.Min), nil
+		}),
+		"max": builtin(func(args []any) (any, error) {
+			return reduceNumbers(args, math.Inf(-1), math.Max), nil
+		}),
+	},
+	"_": namespace{
+		"sum": builtin(func(args []any) (any, error) {
+			return sumBy(arg(args, 0), nil), nil
+		}),
+		"sumBy": builtin(func(args []any) (any, error) {
+			return sumBy(arg(args, 0), arg(args, 1)), nil
+		}),
+		"round": builtin(func(args []any) (any, error) {
+			return decimalRound(argNumber(args, 0), argInt(args, 1, 0)), nil
+		}),
+		"isEmpty": builtin(func(args []any) (any, error) {
+			switch v := arg(args, 0).(type) {
+			case string:
+				return v == "", nil
+			case []any:
+				return len(v) == 0, nil
+			case map[string]any:
+				return len(v) == 0, nil
+			default:
+				return true, nil
+			}
+		}),
+	},
+	"Number": builtin(func(args []any) (any, error) {
+		return toNumber(arg(args, 0)), nil
+	}),
+	"String": builtin(func(args []any) (any, error) {
+		if len(args) == 0 {
+			return "", nil
+		}
+
+		return toString(args[0]), nil
+	}),
+	"Boolean": builtin(func(args []any) (any, error) {
+		return truthy(arg(args, 0)), nil
+	}),
+	"isNaN": builtin(func(args []any) (any, error) {
+		return math.IsNaN(argNumber(args, 0)), nil
+	}),
+	"parseFloat": builtin(func(args []any) (any, error) {
+		return parsePrefix(floatPrefix, arg(args, 0)), nil
+	}),
+	"parseInt": builtin(func(args []any) (any, error) {
+		return parsePrefix(intPrefix, arg(args, 0)), nil
+	}),
+}
+
+// numberFunc adapts a one-argument math function
+func numberFunc(fn func(float64) float64) builtin {
+	return func(args []any) (any, error) {
+		return fn(argNumber(args, 0)), nil
+	}
+}
+
+// arg returns the i-th argument, or undefined
+func arg(args []any, i int) any {
+	if i < len(args) {
+		return args[i]
+	}
+
+	return undefined
+}
+
+// argNumber returns the i-th argument as a number; missing arguments are NaN
+func argNumber(args []any, i int) float64 {
+	if i < len(args) {
+		return toNumber(args[i])
+	}
+
+	return math.NaN()
+}
+
+// argInt returns the i-th argument as an integer, or def if it is missing
+func argInt(args []any, i, def int) int {
+	if i >= len(args) || args[i] == nil {
+		return def
+	}
+
+	f := toNumber(args[i])
+	if math.IsNaN(f) {
+		return 0
+	}
+
+	return int(math.Trunc(math.Max(math.Min(f, math.MaxInt32), math.MinInt32)))
+}
+
+// jsRound rounds half up, like Math.round
+func jsRound(x float64) float64 {
+	return math.Floor(x + 0.5)
+}
+
+// decimalRound rounds x to precision decimals like lodash's _.round, which
+// shifts the decimal point in the number's string form so that 1.005 rounds
+// to 1.01
+func decimalRound(x float64, precision int) float64 {
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+		return x
+	}
+
+	shifted, err := strconv.ParseFloat(formatNumber(x)+"e"+strconv.Itoa(precision), 64)
+	if err != nil {
+		return math.NaN()
+	}
+
+	rounded, err := strconv.ParseFloat(formatNumber(jsRound(shifted))+"e"+strconv.Itoa(-precision), 64)
+	if err != nil {
+		return math.NaN()
+	}
+
+	return rounded
+}
+
+// reduceNumbers folds the arguments, as Math.min and Math.max do
+func reduceNumbers(args []any, initial float64, fn func(a, b float64) float64) float64 {
+	result := initial
+
+	for _, a := range args {
+		n := toNumber(a)
+		if math.IsNaN(n) {
+			return n
+		}
+
+		result = fn(result, n)
+	}
+
+	return result
+}
+
+// sumBy adds up the elements of an array, or the property key of each element
+func sumBy(collection, key any) float64 {
+	items, ok := collection.([]any)
+	if !ok {
+		return 0
+	}
+
+	var total float64
+
+	for _, item := range items {
+		if key != nil {
+			if m, isMap := item.(map[string]any); isMap {
+				item = normalize(m[toString(key)])
+			} else {
+				item = undefined
+			}
+		}
+
+		total += toNumber(item)
+	}
+
+	return total
+}
+
+// parsePrefix reads the number at the start of a string, as parseFloat and
+// parseInt do
+func parsePrefix(pattern *regexp.Regexp, v any) float64 {
+	if n, ok := v.(float64); ok && pattern == floatPrefix {
+		return n
+	}
+
+	match := pattern.FindString(strings.TrimSpace(toString(v)))
+	if match == "" {
+		return math.NaN()
+	}
+
+	f, err := strconv.ParseFloat(match, 64)
+	if err != nil {
+		return math.NaN()
+	}
+
+	return f
+}
+
+// callMethod calls a method on a value
+func callMethod(receiver any, name string, args []any) (any, error) {
+	if name == "toString" {
+		return toString(receiver), nil
+	}
+
+	switch r := receiver.(type) {
+	case string:
+		return stringMethod(r, name, args)
+	case float64:
+		if name == "toFixed" {
+			return toFixed(r, argInt(args, 0, 0))
+		}
+	case []any:
+		return arrayMethod(r, name, args)
+	case moment:
+		return r.call(name, args)
+	case nil:
+		return nil, fmt.Errorf("cannot call %s of undefined", name)
+	}
+
+	return nil, fmt.Errorf("%w: method %s of %s", ErrUnsupported, name, typeName(receiver))
+}
+
+// stringMethod calls a String.prototype method
+func stringMethod(s, name string, args []any) (any, error) {
+	switch name {
+	case "toUpperCase":
+		return strings.ToUpper(s), nil
+	case "toLowerCase":
+		return strings.ToLower(s), nil
+	case "trim":
+		return strings.TrimSpace(s), nil
+	case "includes":
+		return strings.Contains(s, toString(arg(args, 0))), nil
+	case "startsWith":
+		return strings.HasPrefix(s, toString(arg(args, 0))), nil
+	case "endsWith":
+		return strings.HasSuffix(s, toString(arg(args, 0))), nil
+	case "indexOf":
+		i := strings.Index(s, toString(arg(args, 0)))
+		if i > 0 {
+			i = utf8.RuneCountInString(s[:i])
+		}
+
+		return float64(i), nil
+	case "charAt":
+		runes := []rune(s)
+		if i := argInt(args, 0, 0); i >= 0 && i < len(runes) {
+			return string(runes[i]), nil
+		}
+
+		return "", nil
+	case "substring", "slice":
+		runes := []rune(s)
+		start, end := argInt(args, 0, 0), argInt(args, 1, len(runes))
+
+		if name == "slice" {
+			start, end = relativeIndex(start, len(runes)), relativeIndex(end, len(runes))
+		} else {
+			start, end = clamp(start, len(runes)), clamp(end, len(runes))
+			if start > end {
+				start, end = end, start
+			}
+		}
+
+		if start >= end {
+			return "", nil
+		}
+
+		return string(runes[start:end]), nil
+	case "replace":
+		return strings.Replace(s, toString(arg(args, 0)), toString(arg(args, 1)), 1), nil
+	case "split":
+		parts := strings.Split(s, toString(arg(args, 0)))
+		out := make([]any, len(parts))
+
+		for i, p := range parts {
+			out[i] = p
+		}
+
+		return out, nil
+	case "padStart", "padEnd":
+		return pad(s, name == "padStart", argInt(args, 0, 0), args)
+	case "concat":
+		var b strings.Builder
+
+		b.WriteString(s)
+
+		for _, a := range args {
+			b.WriteString(toString(a))
+		}
+
+		return b.String(), nil
+	}
+
+	return nil, fmt.Errorf("%w: method %s of string", ErrUnsupported, name)
+}
+
+// pad implements padStart and padEnd
+func pad(s string, start bool, length int, args []any) (any, error) {
+	if length > maxPadLength {
+		return nil, fmt.Errorf("%w: padding longer than %d", ErrUnsupported, maxPadLength)
+	}
+
+	filler := " "
+	if len(args) > 1 && args[1] != nil {
+		filler = toString(args[1])
+	}
+
+	missing := length - utf8.RuneCountInString(s)
+	if missing <= 0 || filler == "" {
+		return s, nil
+	}
+
+	padding := []rune(strings.Repeat(filler, missing/utf8.RuneCountInString(filler)+1))[:missing]
+	if start {
+		return string(padding) + s, nil
+	}
+
+	return s + string(padding), nil
+}
+
+// arrayMethod calls an Array.prototype method
+func arrayMethod(a []any, name string, args []any) (any, error) {
+	switch name {
+	case "includes":
+		return slices.ContainsFunc(a, func(item any) bool {
+			return strictEqual(normalize(item), arg(args, 0))
+		}), nil
+	case "indexOf":
+		return float64(slices.IndexFunc(a, func(item any) bool {
+			return strictEqual(normalize(item), arg(args, 0))
+		})), nil
+	case "join":
+		sep := ","
+		if len(args) > 0 && args[0] != nil {
+			sep = toString(args[0])
+		}
+
+		parts := make([]string, len(a))
+		for i, item := range a {
+			if item != nil {
+				parts[i] = toString(normalize(item))
+			}
+		}
+
+		return strings.Join(parts, sep), nil
+	}
+
+	return nil, fmt.Errorf("%w: method %s of array", ErrUnsupported, name)
+}
+
+// relativeIndex resolves a slice index that may count from the end
+func relativeIndex(i, length int) int {
+	if i < 0 {
+		i += length
+	}
+
+	return clamp(i, length)
+}
+
+// clamp limits i to [0, length]
+func clamp(i, length int) int {
+	return max(0, min(i, length))
+}
+
+// toFixed formats x with digits decimals, rounding ties up like
+// Number.prototype.toFixed
+func toFixed(x float64, digits int) (any, error) {
+	if digits < 0 || digits > maxFractionDigits {
+		return nil, fmt.Errorf("toFixed() digits must be between 0 and %d", maxFractionDigits)
+	}
+
+	if math.IsNaN(x) || math.IsInf(x, 0) || math.Abs(x) >= 1e21 {
+		return formatNumber(x), nil
+	}
+
+	s := fixed(math.Abs(x), digits)
+	if x < 0 && s != formatZero(digits) {
+		return "-" + s, nil
+	}
+
+	return s, nil
+}
+
+// fixed formats a non-negative x with digits decimals. It rounds the exact
+// binary value, so that 1.005 becomes "1.00" as in JavaScript.
+func fixed(x float64, digits int) string {
+	const precision = 2048
+
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(digits)), nil)
+	scaled := new(big.Float).SetPrec(precision).SetFloat64(x)
+	scaled.Mul(scaled, new(big.Float).SetPrec(precision).SetInt(scale))
+	scaled.Add(scaled, big.NewFloat(0.5))
+
+	n, _ := scaled.Int(nil)
+	str := n.String()
+
+	if digits == 0 {
+		return str
+	}
+
+	if len(str) <= digits {
+		str = strings.Repeat("0", digits-len(str)+1) + str
+	}
+
+	return str[:len(str)-digits] + "." + str[len(str)-digits:]
+}
+
+// formatZero returns zero formatted with digits decimals
+func formatZero(digits int) string {
+	if digits == 0 {
+		return "0"
+	}
+
+	return "0." + strings.Repeat("0", digits)
+}
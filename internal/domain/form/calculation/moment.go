@@ -0,0 +1,285 @@
+package calculation
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+const (
+	// isoLayout is the format of Date.prototype.toISOString
+	isoLayout = "2006-01-02T15:04:05.000Z"
+	// defaultLayout is the format of moment().format() without arguments
+	defaultLayout = "2006-01-02T15:04:05-07:00"
+)
+
+// dateLayouts are the string formats moment(value) accepts
+var dateLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	time.DateOnly,
+}
+
+// formatTokens maps moment.js format tokens to Go layout elements, longest
+// first so that MMMM is not read as MM twice
+var formatTokens = []struct {
+	token, layout string
+}{
+	{"YYYY", "2006"}, {"MMMM", "January"}, {"dddd", "Monday"},
+	{"MMM", "Jan"}, {"ddd", "Mon"},
+	{"YY", "06"}, {"MM", "01"}, {"DD", "02"}, {"HH", "15"}, {"hh", "03"},
+	{"mm", "04"}, {"ss", "05"}, {"ZZ", "-0700"},
+	{"M", "1"}, {"D", "2"}, {"H", "15"}, {"h", "3"}, {"A", "PM"}, {"a", "pm"}, {"Z", "-07:00"},
+}
+
+// moment is a date, with the subset of the moment.js API that form
+// calculations commonly use
+type moment struct {
+	t time.Time
+}
+
+// moment implements moment(), moment(value) and moment(value, format)
+func (s *scope) moment(args []any) (any, error) {
+	if len(args) == 0 || args[0] == nil {
+		return moment{t: s.env.Now}, nil
+	}
+
+	return toMoment(args[0], args[1:]...)
+}
+
+// toMoment converts a value to a date
+func toMoment(v any, format ...any) (moment, error) {
+	switch x := v.(type) {
+	case moment:
+		return x, nil
+	case float64:
+		if !math.IsNaN(x) && !math.IsInf(x, 0) {
+			return moment{t: time.UnixMilli(int64(x)).UTC()}, nil
+		}
+	case string:
+		layouts := dateLayouts
+		if len(format) > 0 && format[0] != nil {
+			layouts = []string{layout(toString(format[0]))}
+		}
+
+		for _, l := range layouts {
+			if t, err := time.Parse(l, strings.TrimSpace(x)); err == nil {
+				return moment{t: t}, nil
+			}
+		}
+	}
+
+	return moment{}, fmt.Errorf("invalid date %q", toString(v))
+}
+
+// layout converts a moment.js format string to a Go layout. Text in square
+// brackets is copied literally.
+func layout(format string) string {
+	var b strings.Builder
+
+	for i := 0; i < len(format); {
+		if format[i] == '[' {
+			if end := strings.IndexByte(format[i:], ']'); end > 0 {
+				b.WriteString(format[i+1 : i+end])
+				i += end + 1
+
+				continue
+			}
+		}
+
+		matched := false
+
+		for _, ft := range formatTokens {
+			if strings.HasPrefix(format[i:], ft.token) {
+				b.WriteString(ft.layout)
+				i += len(ft.token)
+				matched = true
+
+				break
+			}
+		}
+
+		if !matched {
+			b.WriteByte(format[i])
+			i++
+		}
+	}
+
+	return b.String()
+}
+
+// isoString formats the date as Date.prototype.toISOString does
+func (m moment) isoString() string {
+	return m.t.UTC().Format(isoLayout)
+}
+
+// call calls a moment method
+func (m moment) call(name string, args []any) (any, error) {
+	switch name {
+	case "add", "subtract":
+		amount := argNumber(args, 0)
+		if name == "subtract" {
+			amount = -amount
+		}
+
+		return m.add(amount, toString(arg(args, 1)))
+	case "diff":
+		other, err := toMoment(arg(args, 0))
+		if err != nil {
+			return nil, err
+		}
+
+		return m.diff(other, toString(arg(args, 1)), truthy(arg(args, 2)))
+	case "format":
+		if len(args) == 0 || args[0] == nil {
+			return m.t.Format(defaultLayout), nil
+		}
+
+		return m.t.Format(layout(toString(args[0]))), nil
+	case "toISOString", "toJSON":
+		return m.isoString(), nil
+	case "valueOf":
+		return float64(m.t.UnixMilli()), nil
+	case "unix":
+		return float64(m.t.Unix()), nil
+	case "year":
+		return float64(m.t.Year()), nil
+	case "month":
+		return float64(m.t.Month() - 1), nil
+	case "date":
+		return float64(m.t.Day()), nil
+	case "day":
+		return float64(m.t.Weekday()), nil
+	case "hour":
+		return float64(m.t.Hour()), nil
+	case "minute":
+		return float64(m.t.Minute()), nil
+	case "isValid":
+		return true, nil
+	case "clone":
+		return m, nil
+	case "isBefore", "isAfter", "isSame":
+		other, err := toMoment(arg(args, 0))
+		if err != nil {
+			return nil, err
+		}
+
+		switch name {
+		case "isBefore":
+			return m.t.Before(other.t), nil
+		case "isAfter":
+			return m.t.After(other.t), nil
+		default:
+			return m.t.Equal(other.t), nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: method %s of date", ErrUnsupported, name)
+}
+
+// add moves the date by amount units. Calendar units are whole numbers and
+// months keep the day within the target month, as in moment.js.
+func (m moment) add(amount float64, unit string) (any, error) {
+	if math.IsNaN(amount) || math.IsInf(amount, 0) {
+		return nil, fmt.Errorf("invalid amount to add: %s", formatNumber(amount))
+	}
+
+	whole := int(jsRound(amount))
+
+	switch unit {
+	case "y", "year", "years":
+		return moment{t: addMonths(m.t, whole*12)}, nil
+	case "M", "month", "months":
+		return moment{t: addMonths(m.t, whole)}, nil
+	case "w", "week", "weeks":
+		return moment{t: m.t.AddDate(0, 0, whole*7)}, nil
+	case "d", "day", "days":
+		return moment{t: m.t.AddDate(0, 0, whole)}, nil
+	}
+
+	unitDuration, err := duration(unit)
+	if err != nil {
+		return nil, err
+	}
+
+	return moment{t: m.t.Add(time.Duration(amount * float64(unitDuration)))}, nil
+}
+
+// diff returns m - other in the given unit, truncated unless precise is set
+func (m moment) diff(other moment, unit string, precise bool) (any, error) {
+	var result float64
+
+	switch unit {
+	case "y", "year", "years":
+		result = monthDiff(m.t, other.t) / 12
+	case "M", "month", "months":
+		result = monthDiff(m.t, other.t)
+	default:
+		if unit == "" {
+			unit = "ms"
+		}
+
+		unitDuration, err := duration(unit)
+		if err != nil {
+			return nil, err
+		}
+
+		result = float64(m.t.Sub(other.t)) / float64(unitDuration)
+	}
+
+	if !precise {
+		result = math.Trunc(result)
+	}
+
+	return result, nil
+}
+
+// duration returns the length of a fixed-length unit
+func duration(unit string) (time.Duration, error) {
+	switch unit {
+	case "w", "week", "weeks":
+		return 7 * 24 * time.Hour, nil
+	case "d", "day", "days":
+		return 24 * time.Hour, nil
+	case "h", "hour", "hours":
+		return time.Hour, nil
+	case "m", "minute", "minutes":
+		return time.Minute, nil
+	case "s", "second", "seconds":
+		return time.Second, nil
+	case "ms", "millisecond", "milliseconds":
+		return time.Millisecond, nil
+	default:
+		return 0, fmt.Errorf("%w: date unit %q", ErrUnsupported, unit)
+	}
+}
+
+// addMonths adds months to t, clamping the day to the end of the target month
+func addMonths(t time.Time, months int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(months), 1,
+		t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	lastDay := first.AddDate(0, 1, -1).Day()
+
+	return first.AddDate(0, 0, min(t.Day(), lastDay)-1)
+}
+
+// monthDiff returns the fractional number of months from b to a, computed
+// the way moment.js does
+func monthDiff(a, b time.Time) float64 {
+	whole := (a.Year()-b.Year())*12 + int(a.Month()-b.Month())
+	anchor := addMonths(b, whole)
+
+	var adjust float64
+
+	if a.Before(anchor) {
+		previous := addMonths(b, whole-1)
+		adjust = float64(a.Sub(anchor)) / float64(anchor.Sub(previous))
+	} else {
+		next := addMonths(b, whole+1)
+		adjust = float64(a.Sub(anchor)) / float64(next.Sub(anchor))
+	}
+
+	return float64(whole) + adjust
+}
@@ -0,0 +1,69 @@
+// Package draft provides save-and-resume drafts: partially completed submissions
+// that respondents can come back to with a resume token until they expire.
+// Drafts are kept apart from submissions, so they never show up in exports,
+// counts or submission events until they are finalized.
+package draft
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"time"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+// tokenBytes is the amount of randomness in a resume token
+const tokenBytes = 32
+
+// Draft is a partially completed submission. Only the SHA-256 hash of its
+// resume token is stored, so a leaked database cannot be used to resume drafts.
+type Draft struct {
+	ID        string     `json:"id" gorm:"column:uuid;primaryKey"`
+	FormID    string     `json:"form_id" gorm:"not null;index"`
+	TokenHash string     `json:"-" gorm:"not null;uniqueIndex;size:64"`
+	Data      model.JSON `json:"data" gorm:"type:json;not null"`
+	ExpiresAt time.Time  `json:"expires_at" gorm:"not null;index"`
+	CreatedAt time.Time  `json:"created_at" gorm:"not null;autoCreateTime"`
+	UpdatedAt time.Time  `json:"updated_at" gorm:"not null;autoUpdateTime"`
+}
+
+// TableName specifies the table name for the Draft model
+func (d *Draft) TableName() string {
+	return "form_drafts"
+}
+
+// IsExpired reports whether the draft can no longer be resumed at now
+func (d *Draft) IsExpired(now time.Time) bool {
+	return !now.Before(d.ExpiresAt)
+}
+
+// Merged returns the draft data with data applied on top. Top-level keys in data
+// replace the saved ones, so each wizard page can be saved on its own.
+func (d *Draft) Merged(data model.JSON) model.JSON {
+	merged := make(model.JSON, len(d.Data)+len(data))
+	maps.Copy(merged, d.Data)
+	maps.Copy(merged, data)
+
+	return merged
+}
+
+// NewToken generates a random, URL-safe resume token
+func NewToken() (string, error) {
+	b := make([]byte, tokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate resume token: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// HashToken returns the hex-encoded SHA-256 hash under which a token is stored
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,22 @@
+//go:generate mockgen -typed -source=repository.go -destination=../../../../test/mocks/draft/mock_repository.go -package=draft
+
+package draft
+
+import (
+	"context"
+	"time"
+)
+
+// Repository stores draft submissions
+type Repository interface {
+	// Create stores a new draft
+	Create(ctx context.Context, d *Draft) error
+	// GetByTokenHash retrieves a draft by the hash of its resume token
+	GetByTokenHash(ctx context.Context, tokenHash string) (*Draft, error)
+	// Update saves a draft's data and expiry
+	Update(ctx context.Context, d *Draft) error
+	// Delete removes a draft. It returns a not found error when the draft is already gone.
+	Delete(ctx context.Context, id string) error
+	// DeleteExpired removes drafts that expired before the given time and returns how many were removed
+	DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+}
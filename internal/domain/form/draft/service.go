@@ -0,0 +1,240 @@
+//go:generate mockgen -typed -source=service.go -destination=../../../../test/mocks/draft/mock_service.go -package=draft -mock_names=Service=MockService
+
+package draft
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	domainerrors "github.com/goformx/goforms/internal/domain/common/errors"
+	"github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/form/encryption"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+)
+
+// ErrDraftNotFound is returned when a resume token is unknown, belongs to another
+// form, has expired or its draft was already finalized
+var ErrDraftNotFound = domainerrors.New(domainerrors.ErrCodeNotFound, "draft not found or expired", nil)
+
+// ErrDataRequired is returned when a draft is saved without data
+var ErrDataRequired = domainerrors.New(domainerrors.ErrCodeValidation, "draft data is required", nil)
+
+// Service defines the interface for save-and-resume drafts
+type Service interface {
+	// Save creates a draft of the form when token is empty, otherwise merges data
+	// into the draft the token identifies. Every save restarts the form's draft
+	// expiry. It returns the draft and its resume token.
+	Save(ctx context.Context, f *model.Form, token string, data model.JSON) (*Draft, string, error)
+	// Get returns the unexpired draft of a form identified by a resume token
+	Get(ctx context.Context, f *model.Form, token string) (*Draft, error)
+	// Finalize submits data as the draft's submission and removes the draft. The
+	// submission goes through the form service, so the usual validation and
+	// form.submitted event apply. The draft is kept when submitting fails.
+	Finalize(ctx context.Context, d *Draft, data model.JSON) (*model.FormSubmission, error)
+	// PurgeExpired removes expired drafts and returns how many were removed
+	PurgeExpired(ctx context.Context) (int64, error)
+}
+
+// service implements Service. Answers to sensitive fields are encrypted in
+// stored drafts, like in submissions, and drafts are returned decrypted.
+type service struct {
+	repository  Repository
+	formService form.Service
+	encryption  encryption.Service
+	logger      logging.Logger
+	now         func() time.Time
+}
+
+// NewService creates a new draft service
+func NewService(
+	repository Repository,
+	formService form.Service,
+	encryptionService encryption.Service,
+	logger logging.Logger,
+) Service {
+	return &service{
+		repository:  repository,
+		formService: formService,
+		encryption:  encryptionService,
+		logger:      logger,
+		now:         time.Now,
+	}
+}
+
+// Save creates or updates a draft
+func (s *service) Save(ctx context.Context, f *model.Form, token string, data model.JSON) (*Draft, string, error) {
+	if data == nil {
+		return nil, "", ErrDataRequired
+	}
+
+	if err := encryption.RejectEnvelopes(data); err != nil {
+		return nil, "", fmt.Errorf("check draft data: %w", err)
+	}
+
+	expiresAt := s.now().Add(f.DraftTTL())
+
+	if token != "" {
+		d, err := s.Get(ctx, f, token)
+		if err != nil {
+			return nil, "", err
+		}
+
+		d.Data = d.Merged(data)
+		d.ExpiresAt = expiresAt
+
+		stored, encryptErr := s.encrypt(f, d)
+		if encryptErr != nil {
+			return nil, "", encryptErr
+		}
+
+		if updateErr := s.repository.Update(ctx, stored); updateErr != nil {
+			return nil, "", fmt.Errorf("update draft: %w", updateErr)
+		}
+
+		return d, token, nil
+	}
+
+	token, err := NewToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	d := &Draft{
+		ID:        uuid.New().String(),
+		FormID:    f.ID,
+		TokenHash: HashToken(token),
+		Data:      data,
+		ExpiresAt: expiresAt,
+	}
+
+	stored, err := s.encrypt(f, d)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if createErr := s.repository.Create(ctx, stored); createErr != nil {
+		return nil, "", fmt.Errorf("create draft: %w", createErr)
+	}
+
+	s.logger.Debug("draft created", "form_id", f.ID, "draft_id", d.ID)
+
+	return d, token, nil
+}
+
+// Get returns the unexpired draft identified by token. Expired drafts are
+// removed as they are found.
+func (s *service) Get(ctx context.Context, f *model.Form, token string) (*Draft, error) {
+	if token == "" {
+		return nil, ErrDraftNotFound
+	}
+
+	d, err := s.repository.GetByTokenHash(ctx, HashToken(token))
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			return nil, ErrDraftNotFound
+		}
+
+		return nil, fmt.Errorf("get draft: %w", err)
+	}
+
+	if d.FormID != f.ID {
+		return nil, ErrDraftNotFound
+	}
+
+	if d.IsExpired(s.now()) {
+		if deleteErr := s.repository.Delete(ctx, d.ID); deleteErr != nil && !errors.Is(deleteErr, common.ErrNotFound) {
+			s.logger.Warn("failed to delete expired draft", "draft_id", d.ID, "error", deleteErr)
+		}
+
+		return nil, ErrDraftNotFound
+	}
+
+	decrypted, err := s.encryption.Decrypt(f, d.Data)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt draft: %w", err)
+	}
+
+	d.Data = decrypted
+
+	return d, nil
+}
+
+// encrypt returns a copy of a draft with the answers to the form's sensitive
+// fields encrypted
+func (s *service) encrypt(f *model.Form, d *Draft) (*Draft, error) {
+	data, err := s.encryption.Encrypt(f, d.Data)
+	if err != nil {
+		return nil, fmt.Errorf("encrypt draft: %w", err)
+	}
+
+	stored := *d
+	stored.Data = data
+
+	return &stored, nil
+}
+
+// restore recreates a draft that was deleted by a failed Finalize
+func (s *service) restore(ctx context.Context, d *Draft) error {
+	f, err := s.formService.GetForm(ctx, d.FormID)
+	if err != nil {
+		return fmt.Errorf("get form: %w", err)
+	}
+
+	stored, err := s.encrypt(f, d)
+	if err != nil {
+		return err
+	}
+
+	if createErr := s.repository.Create(ctx, stored); createErr != nil {
+		return fmt.Errorf("create draft: %w", createErr)
+	}
+
+	return nil
+}
+
+// Finalize turns a draft into a submission. The draft is deleted first so that
+// a token cannot be used to submit twice, and restored if the submission fails.
+func (s *service) Finalize(ctx context.Context, d *Draft, data model.JSON) (*model.FormSubmission, error) {
+	if err := s.repository.Delete(ctx, d.ID); err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			return nil, ErrDraftNotFound
+		}
+
+		return nil, fmt.Errorf("delete draft: %w", err)
+	}
+
+	submission := &model.FormSubmission{
+		FormID:      d.FormID,
+		Data:        data,
+		SubmittedAt: s.now(),
+		Status:      model.SubmissionStatusPending,
+	}
+
+	if err := s.formService.SubmitForm(ctx, submission); err != nil {
+		if restoreErr := s.restore(ctx, d); restoreErr != nil {
+			s.logger.Error("failed to restore draft after failed submission", "draft_id", d.ID, "error", restoreErr)
+		}
+
+		return nil, fmt.Errorf("submit draft: %w", err)
+	}
+
+	s.logger.Debug("draft finalized", "form_id", d.FormID, "draft_id", d.ID, "submission_id", submission.ID)
+
+	return submission, nil
+}
+
+// PurgeExpired removes expired drafts
+func (s *service) PurgeExpired(ctx context.Context) (int64, error) {
+	count, err := s.repository.DeleteExpired(ctx, s.now())
+	if err != nil {
+		return 0, fmt.Errorf("purge expired drafts: %w", err)
+	}
+
+	return count, nil
+}
@@ -0,0 +1,222 @@
+package draft_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	domainerrors "github.com/goformx/goforms/internal/domain/common/errors"
+	"github.com/goformx/goforms/internal/domain/form/draft"
+	"github.com/goformx/goforms/internal/domain/form/encryption"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+	mockdraft "github.com/goformx/goforms/test/mocks/draft"
+	mockform "github.com/goformx/goforms/test/mocks/form"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+func publishedForm() *model.Form {
+	form := model.NewForm("owner", "Application", "", model.JSON{"components": []any{}})
+	form.Status = "published"
+	form.DraftTTLHours = 48
+
+	return form
+}
+
+func TestService_Save_new(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mockdraft.NewMockRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	svc := draft.NewService(repo, mockform.NewMockService(ctrl), encryption.NewService(nil), logger)
+	form := publishedForm()
+
+	var created *draft.Draft
+
+	repo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, d *draft.Draft) error {
+			created = d
+
+			return nil
+		})
+	logger.EXPECT().Debug("draft created", "form_id", form.ID, "draft_id", gomock.Any())
+
+	d, token, err := svc.Save(t.Context(), form, "", model.JSON{"name": "Ada"})
+	require.NoError(t, err)
+	require.Equal(t, created, d)
+	assert.NotEmpty(t, token)
+	assert.Equal(t, draft.HashToken(token), d.TokenHash)
+	assert.NotContains(t, d.TokenHash, token)
+	assert.Equal(t, form.ID, d.FormID)
+	assert.WithinDuration(t, time.Now().Add(48*time.Hour), d.ExpiresAt, time.Minute)
+}
+
+func TestService_Save_mergesAndExtends(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mockdraft.NewMockRepository(ctrl)
+	svc := draft.NewService(
+		repo, mockform.NewMockService(ctrl), encryption.NewService(nil), mocklogging.NewMockLogger(ctrl),
+	)
+	form := publishedForm()
+	existing := &draft.Draft{
+		ID:        "draft-1",
+		FormID:    form.ID,
+		TokenHash: draft.HashToken("tok"),
+		Data:      model.JSON{"name": "Ada", "email": "old@example.com"},
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+
+	repo.EXPECT().GetByTokenHash(gomock.Any(), draft.HashToken("tok")).Return(existing, nil)
+	repo.EXPECT().Update(gomock.Any(), existing).Return(nil)
+
+	d, token, err := svc.Save(t.Context(), form, "tok", model.JSON{"email": "ada@example.com", "role": "dev"})
+	require.NoError(t, err)
+	assert.Equal(t, "tok", token)
+	assert.Equal(t, model.JSON{"name": "Ada", "email": "ada@example.com", "role": "dev"}, d.Data)
+	assert.WithinDuration(t, time.Now().Add(48*time.Hour), d.ExpiresAt, time.Minute)
+}
+
+func TestService_Save_requiresData(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	svc := draft.NewService(
+		mockdraft.NewMockRepository(ctrl), mockform.NewMockService(ctrl),
+		encryption.NewService(nil), mocklogging.NewMockLogger(ctrl),
+	)
+
+	_, _, err := svc.Save(t.Context(), publishedForm(), "", nil)
+	require.ErrorIs(t, err, draft.ErrDataRequired)
+	assert.True(t, domainerrors.IsValidation(err))
+}
+
+func TestService_Save_rejectsEnvelopes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	svc := draft.NewService(
+		mockdraft.NewMockRepository(ctrl), mockform.NewMockService(ctrl),
+		encryption.NewService(nil), mocklogging.NewMockLogger(ctrl),
+	)
+	data := model.JSON{"name": map[string]any{"$enc": 1, "kid": "k", "alg": "a", "dek": "d", "ct": "c"}}
+
+	_, _, err := svc.Save(t.Context(), publishedForm(), "", data)
+	require.ErrorIs(t, err, encryption.ErrEnvelopeInInput)
+	assert.True(t, domainerrors.IsValidation(err))
+}
+
+func TestService_Get(t *testing.T) {
+	form := &model.Form{ID: "form-1"}
+	formID := form.ID
+	tests := []struct {
+		name    string
+		stored  *draft.Draft
+		repoErr error
+		deletes bool
+		wantErr bool
+	}{
+		{
+			name:   "valid token",
+			stored: &draft.Draft{ID: "d", FormID: formID, ExpiresAt: time.Now().Add(time.Hour)},
+		},
+		{
+			name:    "unknown token",
+			repoErr: common.NewNotFoundError("get", "draft", ""),
+			wantErr: true,
+		},
+		{
+			name:    "token of another form",
+			stored:  &draft.Draft{ID: "d", FormID: "form-2", ExpiresAt: time.Now().Add(time.Hour)},
+			wantErr: true,
+		},
+		{
+			name:    "expired draft is removed",
+			stored:  &draft.Draft{ID: "d", FormID: formID, ExpiresAt: time.Now().Add(-time.Minute)},
+			deletes: true,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mockdraft.NewMockRepository(ctrl)
+			svc := draft.NewService(
+				repo, mockform.NewMockService(ctrl), encryption.NewService(nil), mocklogging.NewMockLogger(ctrl),
+			)
+
+			repo.EXPECT().GetByTokenHash(gomock.Any(), draft.HashToken("tok")).Return(tt.stored, tt.repoErr)
+
+			if tt.deletes {
+				repo.EXPECT().Delete(gomock.Any(), "d").Return(nil)
+			}
+
+			d, err := svc.Get(t.Context(), form, "tok")
+			if tt.wantErr {
+				require.ErrorIs(t, err, draft.ErrDraftNotFound)
+				assert.True(t, domainerrors.IsNotFound(err))
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Same(t, tt.stored, d)
+		})
+	}
+}
+
+func TestService_Finalize(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mockdraft.NewMockRepository(ctrl)
+	formService := mockform.NewMockService(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	svc := draft.NewService(repo, formService, encryption.NewService(nil), logger)
+	d := &draft.Draft{ID: "d", FormID: "form-1", Data: model.JSON{"name": "Ada"}}
+	data := model.JSON{"name": "Ada", "email": "ada@example.com"}
+
+	gomock.InOrder(
+		repo.EXPECT().Delete(gomock.Any(), "d").Return(nil),
+		formService.EXPECT().SubmitForm(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, s *model.FormSubmission) error {
+				assert.Equal(t, "form-1", s.FormID)
+				assert.Equal(t, data, s.Data)
+				assert.Equal(t, model.SubmissionStatusPending, s.Status)
+
+				return nil
+			}),
+	)
+	logger.EXPECT().Debug("draft finalized", "form_id", "form-1", "draft_id", "d", "submission_id", gomock.Any())
+
+	submission, err := svc.Finalize(t.Context(), d, data)
+	require.NoError(t, err)
+	assert.Equal(t, data, submission.Data)
+}
+
+func TestService_Finalize_alreadyFinalized(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mockdraft.NewMockRepository(ctrl)
+	svc := draft.NewService(
+		repo, mockform.NewMockService(ctrl), encryption.NewService(nil), mocklogging.NewMockLogger(ctrl),
+	)
+	repo.EXPECT().Delete(gomock.Any(), "d").Return(common.NewNotFoundError("delete", "draft", "d"))
+
+	_, err := svc.Finalize(t.Context(), &draft.Draft{ID: "d", FormID: "form-1"}, model.JSON{})
+	require.ErrorIs(t, err, draft.ErrDraftNotFound)
+}
+
+func TestService_Finalize_restoresDraftOnFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mockdraft.NewMockRepository(ctrl)
+	formService := mockform.NewMockService(ctrl)
+	svc := draft.NewService(repo, formService, encryption.NewService(nil), mocklogging.NewMockLogger(ctrl))
+	d := &draft.Draft{ID: "d", FormID: "form-1"}
+	submitErr := errors.New("database unavailable")
+
+	repo.EXPECT().Delete(gomock.Any(), "d").Return(nil)
+	formService.EXPECT().SubmitForm(gomock.Any(), gomock.Any()).Return(submitErr)
+	formService.EXPECT().GetForm(gomock.Any(), "form-1").Return(publishedForm(), nil)
+	repo.EXPECT().Create(gomock.Any(), d).Return(nil)
+
+	_, err := svc.Finalize(t.Context(), d, model.JSON{})
+	require.ErrorIs(t, err, submitErr)
+}
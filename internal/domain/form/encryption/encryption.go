@@ -0,0 +1,167 @@
+//go:generate mockgen -typed -source=encryption.go -destination=../../../../test/mocks/encryption/mock_cipher.go -package=encryption
+
+// Package encryption encrypts the answers to sensitive form fields at rest.
+//
+// Each sensitive value is replaced in the stored data by an envelope: the value
+// is encrypted with a random data key, and the data key is encrypted ("wrapped")
+// with a key encryption key that is named by a key ID. Rotating keys only needs
+// the data keys to be re-wrapped, not the values to be re-encrypted.
+package encryption
+
+import (
+	"errors"
+	"fmt"
+
+	domainerrors "github.com/goformx/goforms/internal/domain/common/errors"
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+// EnvelopeVersion is the version of the envelope format written by this package
+const EnvelopeVersion = 1
+
+// envelopeKey marks a map in submission data as an envelope
+const envelopeKey = "$enc"
+
+var (
+	// ErrNotConfigured is returned when a form has sensitive fields but no
+	// encryption key is configured
+	ErrNotConfigured = domainerrors.New(
+		domainerrors.ErrCodeConfig, "field encryption is not configured", nil,
+	)
+	// ErrUnknownKey is returned when a value was encrypted with a key that is
+	// no longer configured
+	ErrUnknownKey = domainerrors.New(
+		domainerrors.ErrCodeConfig, "encryption key is not configured", nil,
+	)
+	// ErrEnvelopeInInput is returned when submitted data holds a value shaped
+	// like an encrypted value. Only this package writes envelopes.
+	ErrEnvelopeInInput = domainerrors.New(
+		domainerrors.ErrCodeValidation, "submitted data must not contain encrypted values", nil,
+	)
+)
+
+// Envelope is an encrypted value as stored in submission data
+type Envelope struct {
+	Version int `json:"$enc"`
+	// KeyID names the key encryption key that wrapped DataKey
+	KeyID string `json:"kid"`
+	// Algorithm is the AEAD used for both the data key and the value
+	Algorithm string `json:"alg"`
+	// DataKey is the wrapped data key, base64 encoded
+	DataKey string `json:"dek"`
+	// Ciphertext is the encrypted JSON value, base64 encoded
+	Ciphertext string `json:"ct"`
+}
+
+// Value returns the envelope in the form it is stored in submission data
+func (e *Envelope) Value() map[string]any {
+	return map[string]any{
+		envelopeKey: e.Version,
+		"kid":       e.KeyID,
+		"alg":       e.Algorithm,
+		"dek":       e.DataKey,
+		"ct":        e.Ciphertext,
+	}
+}
+
+// ParseEnvelope returns the envelope stored in value, or false if value is not
+// an encrypted value
+func ParseEnvelope(value any) (*Envelope, bool) {
+	var m map[string]any
+
+	switch v := value.(type) {
+	case map[string]any:
+		m = v
+	case model.JSON:
+		m = v
+	default:
+		return nil, false
+	}
+
+	var version int
+
+	switch v := m[envelopeKey].(type) {
+	case int:
+		version = v
+	case float64:
+		version = int(v)
+	default:
+		return nil, false
+	}
+
+	env := &Envelope{Version: version}
+	env.KeyID, _ = m["kid"].(string)
+	env.Algorithm, _ = m["alg"].(string)
+	env.DataKey, _ = m["dek"].(string)
+	env.Ciphertext, _ = m["ct"].(string)
+
+	return env, true
+}
+
+// RejectEnvelopes returns ErrEnvelopeInInput if data, at any depth, holds a
+// value shaped like an envelope. Submitted data must be checked before it is
+// encrypted, or a submitter could store a fake envelope that is later taken for
+// ciphertext, or skip the encryption of a sensitive answer.
+func RejectEnvelopes(data model.JSON) error {
+	for field, value := range data {
+		if containsEnvelope(value) {
+			return fmt.Errorf("%w: field %s", ErrEnvelopeInInput, field)
+		}
+	}
+
+	return nil
+}
+
+// containsEnvelope reports whether value is or holds an envelope
+func containsEnvelope(value any) bool {
+	if _, ok := ParseEnvelope(value); ok {
+		return true
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		for _, item := range v {
+			if containsEnvelope(item) {
+				return true
+			}
+		}
+	case model.JSON:
+		return containsEnvelope(map[string]any(v))
+	case []any:
+		for _, item := range v {
+			if containsEnvelope(item) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Validate checks that the envelope can be opened by this version
+func (e *Envelope) Validate() error {
+	if e.Version != EnvelopeVersion {
+		return fmt.Errorf("unsupported envelope version %d", e.Version)
+	}
+
+	if e.KeyID == "" || e.Algorithm == "" || e.DataKey == "" || e.Ciphertext == "" {
+		return errors.New("incomplete envelope")
+	}
+
+	return nil
+}
+
+// Cipher performs envelope encryption with a set of key encryption keys, one of
+// which is active for new values
+type Cipher interface {
+	// ActiveKeyID returns the ID of the key that wraps new data keys
+	ActiveKeyID() string
+	// Seal encrypts plaintext under a new data key wrapped by the active key
+	Seal(plaintext []byte) (*Envelope, error)
+	// Open decrypts an envelope. It returns ErrUnknownKey if the envelope's key
+	// is not configured.
+	Open(env *Envelope) ([]byte, error)
+	// Rewrap wraps the envelope's data key with the active key. The ciphertext
+	// is unchanged.
+	Rewrap(env *Envelope) (*Envelope, error)
+}
@@ -0,0 +1,292 @@
+//go:generate mockgen -typed -source=service.go -destination=../../../../test/mocks/encryption/mock_service.go -package=encryption -mock_names=Service=MockService
+
+package encryption
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+// Service encrypts and decrypts the answers to sensitive fields. Submission data
+// is encrypted before it is stored and is only decrypted by callers that show it
+// to the form's owner or searched in memory for erasure requests, so encrypted
+// answers never reach logs or events.
+// Only the answers to the form's sensitive fields are ever taken for encrypted
+// values; submitted data is checked with RejectEnvelopes before it is stored.
+type Service interface {
+	// Enabled reports whether an encryption key is configured
+	Enabled() bool
+	// Encrypt returns data with the answers to the form's sensitive fields
+	// encrypted. Answers that are already encrypted are kept as they are.
+	Encrypt(form *model.Form, data model.JSON) (model.JSON, error)
+	// EncryptField encrypts value if field is sensitive in the form
+	EncryptField(form *model.Form, field string, value any) (any, error)
+	// Decrypt returns data with the answers to the form's sensitive fields
+	// decrypted. Answers that cannot be decrypted are left out of the data
+	// returned along with the error, so that callers listing many submissions
+	// can show the rest.
+	Decrypt(form *model.Form, data model.JSON) (model.JSON, error)
+	// DecryptField decrypts value if field is sensitive in the form and value
+	// is encrypted
+	DecryptField(form *model.Form, field string, value any) (any, error)
+	// Rotate re-wraps the data keys of the encrypted values in value, at any
+	// depth, that are not wrapped by the active key. It reports whether
+	// anything changed. Callers pass the answers to sensitive fields only.
+	Rotate(value any) (any, bool, error)
+}
+
+// service implements Service
+type service struct {
+	cipher Cipher
+}
+
+// NewService creates a field encryption service. A nil cipher disables
+// encryption: data without sensitive fields passes through unchanged, and
+// everything else fails with ErrNotConfigured.
+func NewService(cipher Cipher) Service {
+	return &service{cipher: cipher}
+}
+
+// Enabled reports whether an encryption key is configured
+func (s *service) Enabled() bool {
+	return s.cipher != nil
+}
+
+// Encrypt encrypts the answers to the form's sensitive fields
+func (s *service) Encrypt(form *model.Form, data model.JSON) (model.JSON, error) {
+	fields := form.SensitiveFields()
+	if len(fields) == 0 || data == nil {
+		return data, nil
+	}
+
+	encrypted := maps.Clone(data)
+
+	for _, field := range fields {
+		if data[field] == nil {
+			continue
+		}
+
+		value, err := s.seal(data[field])
+		if err != nil {
+			return nil, fmt.Errorf("encrypt field %s: %w", field, err)
+		}
+
+		encrypted[field] = value
+	}
+
+	return encrypted, nil
+}
+
+// EncryptField encrypts a single answer
+func (s *service) EncryptField(form *model.Form, field string, value any) (any, error) {
+	if value == nil || !form.IsSensitiveField(field) {
+		return value, nil
+	}
+
+	encrypted, err := s.seal(value)
+	if err != nil {
+		return nil, fmt.Errorf("encrypt field %s: %w", field, err)
+	}
+
+	return encrypted, nil
+}
+
+// seal encrypts a value unless it is already encrypted
+func (s *service) seal(value any) (any, error) {
+	if _, ok := ParseEnvelope(value); ok {
+		return value, nil
+	}
+
+	if s.cipher == nil {
+		return nil, ErrNotConfigured
+	}
+
+	plaintext, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("marshal value: %w", err)
+	}
+
+	env, err := s.cipher.Seal(plaintext)
+	if err != nil {
+		return nil, fmt.Errorf("seal value: %w", err)
+	}
+
+	return env.Value(), nil
+}
+
+// Decrypt decrypts the encrypted answers to the form's sensitive fields
+func (s *service) Decrypt(form *model.Form, data model.JSON) (model.JSON, error) {
+	var (
+		decrypted model.JSON
+		errs      []error
+	)
+
+	for _, field := range form.SensitiveFields() {
+		value, ok := data[field]
+		if !ok {
+			continue
+		}
+
+		if _, isEnvelope := ParseEnvelope(value); !isEnvelope {
+			continue
+		}
+
+		if decrypted == nil {
+			decrypted = maps.Clone(data)
+		}
+
+		plain, err := s.open(value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("decrypt field %s: %w", field, err))
+			delete(decrypted, field)
+
+			continue
+		}
+
+		decrypted[field] = plain
+	}
+
+	if decrypted == nil {
+		return data, nil
+	}
+
+	return decrypted, errors.Join(errs...)
+}
+
+// DecryptField decrypts a single answer
+func (s *service) DecryptField(form *model.Form, field string, value any) (any, error) {
+	if !form.IsSensitiveField(field) {
+		return value, nil
+	}
+
+	plain, err := s.open(value)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt field %s: %w", field, err)
+	}
+
+	return plain, nil
+}
+
+// open decrypts value if it is encrypted
+func (s *service) open(value any) (any, error) {
+	env, ok := ParseEnvelope(value)
+	if !ok {
+		return value, nil
+	}
+
+	if s.cipher == nil {
+		return nil, ErrNotConfigured
+	}
+
+	if err := env.Validate(); err != nil {
+		return nil, err
+	}
+
+	plaintext, err := s.cipher.Open(env)
+	if err != nil {
+		return nil, fmt.Errorf("open value: %w", err)
+	}
+
+	var plain any
+	if unmarshalErr := json.Unmarshal(plaintext, &plain); unmarshalErr != nil {
+		return nil, fmt.Errorf("unmarshal value: %w", unmarshalErr)
+	}
+
+	return plain, nil
+}
+
+// Rotate re-wraps encrypted values under the active key
+func (s *service) Rotate(value any) (any, bool, error) {
+	if env, ok := ParseEnvelope(value); ok {
+		if s.cipher != nil && env.KeyID == s.cipher.ActiveKeyID() {
+			return value, false, nil
+		}
+
+		return s.rewrap(env)
+	}
+
+	switch v := value.(type) {
+	case model.JSON:
+		rotated, changed, err := s.rotateMap(v)
+
+		return model.JSON(rotated), changed, err
+	case map[string]any:
+		return s.rotateMap(v)
+	case []any:
+		var rotated []any
+
+		for i, item := range v {
+			next, changed, err := s.Rotate(item)
+			if err != nil {
+				return nil, false, err
+			}
+
+			if !changed {
+				continue
+			}
+
+			if rotated == nil {
+				rotated = slices.Clone(v)
+			}
+
+			rotated[i] = next
+		}
+
+		if rotated != nil {
+			return rotated, true, nil
+		}
+	}
+
+	return value, false, nil
+}
+
+// rotateMap re-wraps the encrypted values in a map
+func (s *service) rotateMap(m map[string]any) (map[string]any, bool, error) {
+	var rotated map[string]any
+
+	for key, item := range m {
+		next, changed, err := s.Rotate(item)
+		if err != nil {
+			return nil, false, fmt.Errorf("rotate %s: %w", key, err)
+		}
+
+		if !changed {
+			continue
+		}
+
+		if rotated == nil {
+			rotated = maps.Clone(m)
+		}
+
+		rotated[key] = next
+	}
+
+	if rotated == nil {
+		return m, false, nil
+	}
+
+	return rotated, true, nil
+}
+
+// rewrap wraps an envelope's data key with the active key
+func (s *service) rewrap(env *Envelope) (any, bool, error) {
+	if s.cipher == nil {
+		return nil, false, ErrNotConfigured
+	}
+
+	if err := env.Validate(); err != nil {
+		return nil, false, err
+	}
+
+	rewrapped, err := s.cipher.Rewrap(env)
+	if err != nil {
+		return nil, false, fmt.Errorf("rewrap value: %w", err)
+	}
+
+	return rewrapped.Value(), true, nil
+}
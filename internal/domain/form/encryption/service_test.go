@@ -0,0 +1,220 @@
+package encryption_test
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/domain/form/encryption"
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+// fakeCipher "encrypts" by base64 encoding, so tests can check what was sealed
+type fakeCipher struct {
+	active string
+}
+
+func (c *fakeCipher) ActiveKeyID() string {
+	return c.active
+}
+
+func (c *fakeCipher) Seal(plaintext []byte) (*encryption.Envelope, error) {
+	return &encryption.Envelope{
+		Version:    encryption.EnvelopeVersion,
+		KeyID:      c.active,
+		Algorithm:  "fake",
+		DataKey:    "dek-" + c.active,
+		Ciphertext: base64.StdEncoding.EncodeToString(plaintext),
+	}, nil
+}
+
+func (c *fakeCipher) Open(env *encryption.Envelope) ([]byte, error) {
+	return base64.StdEncoding.DecodeString(env.Ciphertext)
+}
+
+func (c *fakeCipher) Rewrap(env *encryption.Envelope) (*encryption.Envelope, error) {
+	rewrapped := *env
+	rewrapped.KeyID = c.active
+	rewrapped.DataKey = "dek-" + c.active
+
+	return &rewrapped, nil
+}
+
+func sensitiveForm() *model.Form {
+	return &model.Form{Schema: model.JSON{
+		"components": []any{
+			map[string]any{"type": "textfield", "key": "name", "input": true},
+			map[string]any{"type": "textfield", "key": "ssn", "input": true, "sensitive": true},
+			map[string]any{"type": "panel", "components": []any{
+				map[string]any{"type": "datetime", "key": "dob", "input": true, "sensitive": true},
+			}},
+		},
+	}}
+}
+
+func TestService_EncryptDecrypt(t *testing.T) {
+	svc := encryption.NewService(&fakeCipher{active: "k1"})
+	data := model.JSON{"name": "Ada", "ssn": "123-45-6789", "dob": nil}
+
+	encrypted, err := svc.Encrypt(sensitiveForm(), data)
+	require.NoError(t, err)
+	assert.Equal(t, "Ada", encrypted["name"])
+	assert.Nil(t, encrypted["dob"])
+	assert.Equal(t, "123-45-6789", data["ssn"], "input must not be modified")
+
+	env, ok := encryption.ParseEnvelope(encrypted["ssn"])
+	require.True(t, ok)
+	assert.Equal(t, "k1", env.KeyID)
+
+	// Encrypting again keeps the existing envelope
+	again, err := svc.Encrypt(sensitiveForm(), encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, encrypted["ssn"], again["ssn"])
+
+	decrypted, err := svc.Decrypt(sensitiveForm(), encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, data, decrypted)
+}
+
+func TestService_Decrypt_onlySensitiveFields(t *testing.T) {
+	fake := map[string]any{"$enc": 1, "kid": "k", "alg": "a", "dek": "d", "ct": "c"}
+	data := model.JSON{"name": fake, "ssn": "123-45-6789"}
+
+	// Envelope-shaped answers to other fields are data, even without a key
+	decrypted, err := encryption.NewService(nil).Decrypt(sensitiveForm(), data)
+	require.NoError(t, err)
+	assert.Equal(t, data, decrypted)
+
+	value, err := encryption.NewService(nil).DecryptField(sensitiveForm(), "name", fake)
+	require.NoError(t, err)
+	assert.Equal(t, fake, value)
+}
+
+func TestService_Decrypt_partial(t *testing.T) {
+	svc := encryption.NewService(&fakeCipher{active: "k1"})
+
+	encrypted, err := svc.Encrypt(sensitiveForm(), model.JSON{"name": "Ada", "ssn": "123-45-6789"})
+	require.NoError(t, err)
+
+	encrypted["dob"] = map[string]any{"$enc": 1, "kid": "k1", "alg": "fake", "dek": "d", "ct": "not base64"}
+
+	decrypted, err := svc.Decrypt(sensitiveForm(), encrypted)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "dob")
+	assert.Equal(t, model.JSON{"name": "Ada", "ssn": "123-45-6789"}, decrypted,
+		"the answers that could be decrypted are returned")
+}
+
+func TestRejectEnvelopes(t *testing.T) {
+	fake := map[string]any{"$enc": 1, "kid": "k", "alg": "a", "dek": "d", "ct": "c"}
+
+	require.NoError(t, encryption.RejectEnvelopes(model.JSON{"name": "Ada", "tags": []any{"a"}}))
+
+	for _, data := range []model.JSON{
+		{"x": fake},
+		{"grid": []any{map[string]any{"cell": fake}}},
+		{"address": map[string]any{"street": map[string]any{"$enc": float64(2)}}},
+	} {
+		err := encryption.RejectEnvelopes(data)
+		require.ErrorIs(t, err, encryption.ErrEnvelopeInInput)
+	}
+}
+
+func TestService_EncryptField(t *testing.T) {
+	svc := encryption.NewService(&fakeCipher{active: "k1"})
+
+	value, err := svc.EncryptField(sensitiveForm(), "name", "Ada")
+	require.NoError(t, err)
+	assert.Equal(t, "Ada", value)
+
+	value, err = svc.EncryptField(sensitiveForm(), "dob", "1815-12-10")
+	require.NoError(t, err)
+
+	plain, err := svc.DecryptField(sensitiveForm(), "dob", value)
+	require.NoError(t, err)
+	assert.Equal(t, "1815-12-10", plain)
+}
+
+func TestService_Rotate(t *testing.T) {
+	cipher := &fakeCipher{active: "k1"}
+	svc := encryption.NewService(cipher)
+
+	encrypted, err := svc.Encrypt(sensitiveForm(), model.JSON{"name": "Ada", "ssn": "123-45-6789"})
+	require.NoError(t, err)
+
+	_, changed, err := svc.Rotate(encrypted)
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	cipher.active = "k2"
+
+	rotated, changed, err := svc.Rotate(encrypted)
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	data, ok := rotated.(model.JSON)
+	require.True(t, ok)
+
+	env, ok := encryption.ParseEnvelope(data["ssn"])
+	require.True(t, ok)
+	assert.Equal(t, "k2", env.KeyID)
+
+	old, _ := encryption.ParseEnvelope(encrypted["ssn"])
+	assert.Equal(t, "k1", old.KeyID, "input must not be modified")
+
+	// Envelopes nested in revision changes are rotated too
+	nested, changed, err := svc.Rotate([]any{map[string]any{"before": encrypted["ssn"]}})
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	items, ok := nested.([]any)
+	require.True(t, ok)
+
+	item, ok := items[0].(map[string]any)
+	require.True(t, ok)
+
+	env, ok = encryption.ParseEnvelope(item["before"])
+	require.True(t, ok)
+	assert.Equal(t, "k2", env.KeyID)
+}
+
+func TestService_notConfigured(t *testing.T) {
+	svc := encryption.NewService(nil)
+	assert.False(t, svc.Enabled())
+
+	plain := model.JSON{"name": "Ada"}
+
+	data, err := svc.Encrypt(&model.Form{Schema: model.JSON{}}, plain)
+	require.NoError(t, err)
+	assert.Equal(t, plain, data)
+
+	_, err = svc.Encrypt(sensitiveForm(), model.JSON{"ssn": "123-45-6789"})
+	require.ErrorIs(t, err, encryption.ErrNotConfigured)
+
+	encrypted, err := encryption.NewService(&fakeCipher{active: "k1"}).
+		Encrypt(sensitiveForm(), model.JSON{"ssn": "123-45-6789"})
+	require.NoError(t, err)
+
+	_, err = svc.Decrypt(sensitiveForm(), encrypted)
+	require.ErrorIs(t, err, encryption.ErrNotConfigured)
+}
+
+func TestParseEnvelope(t *testing.T) {
+	_, ok := encryption.ParseEnvelope("plain")
+	assert.False(t, ok)
+
+	_, ok = encryption.ParseEnvelope(map[string]any{"kid": "k1"})
+	assert.False(t, ok)
+
+	// Envelopes read back from the database have float64 versions
+	env, ok := encryption.ParseEnvelope(map[string]any{
+		"$enc": float64(1), "kid": "k1", "alg": "aes-256-gcm", "dek": "a", "ct": "b",
+	})
+	require.True(t, ok)
+	require.NoError(t, env.Validate())
+
+	env.Version = 2
+	require.Error(t, env.Validate())
+}
@@ -31,6 +31,8 @@ const (
 	FieldEventType EventType = "form.field"
 	// AnalyticsEventType represents an analytics event
 	AnalyticsEventType EventType = "form.analytics"
+	// SubmissionUpdatedEventType represents an owner's change to a submission
+	SubmissionUpdatedEventType EventType = "submission.updated"
 )
 
 // Event represents a form-related event
@@ -39,8 +41,11 @@ type Event struct {
 	payload any
 }
 
-// Ensure Event implements events.Event
-var _ events.Event = (*Event)(nil)
+// Ensure Event implements events.Event and events.Streamed
+var (
+	_ events.Event    = (*Event)(nil)
+	_ events.Streamed = (*Event)(nil)
+)
 
 // NewEvent creates a new form event
 func NewEvent(eventType EventType, payload any) *Event {
@@ -55,6 +60,16 @@ func (e *Event) Payload() any {
 	return e.payload
 }
 
+// StreamKey returns the stream of the form or submission the event is about
+func (e *Event) StreamKey() string {
+	streamType, streamID := StreamOf(e.payload)
+	if streamID == "" {
+		return ""
+	}
+
+	return streamType + ":" + streamID
+}
+
 // NewFormCreatedEvent creates a new form created event
 func NewFormCreatedEvent(form *model.Form) *Event {
 	return NewEvent(FormCreatedEventType, form)
@@ -75,50 +90,48 @@ func NewFormSubmittedEvent(submission *model.FormSubmission) *Event {
 	return NewEvent(FormSubmittedEventType, submission)
 }
 
+// NewSubmissionUpdatedEvent creates a new submission updated event for the
+// revision that changed the submission
+func NewSubmissionUpdatedEvent(
+	submission *model.FormSubmission,
+	editorID string,
+	revision int,
+	fields []string,
+) *Event {
+	return NewEvent(SubmissionUpdatedEventType, &SubmissionUpdatedPayload{
+		Submission: submission,
+		EditorID:   editorID,
+		Revision:   revision,
+		Fields:     fields,
+	})
+}
+
 // NewFormValidatedEvent creates a new form validated event
 func NewFormValidatedEvent(formID string, isValid bool) *Event {
-	return NewEvent(FormValidatedEventType, map[string]any{
-		"form_id":  formID,
-		"is_valid": isValid,
-	})
+	return NewEvent(FormValidatedEventType, &FormValidatedPayload{FormID: formID, IsValid: isValid})
 }
 
 // NewFormProcessedEvent creates a new form processed event
 func NewFormProcessedEvent(formID, processingID string) *Event {
-	return NewEvent(FormProcessedEventType, map[string]string{
-		"form_id":       formID,
-		"processing_id": processingID,
-	})
+	return NewEvent(FormProcessedEventType, &FormProcessedPayload{FormID: formID, ProcessingID: processingID})
 }
 
 // NewFormErrorEvent creates a new form error event
 func NewFormErrorEvent(formID string, err error) *Event {
-	return NewEvent(FormErrorEventType, map[string]any{
-		"form_id": formID,
-		"error":   err.Error(),
-	})
+	return NewEvent(FormErrorEventType, &FormErrorPayload{FormID: formID, Error: err.Error()})
 }
 
 // NewFormStateEvent creates a new form state event
 func NewFormStateEvent(formID, state string) *Event {
-	return NewEvent(FormStateEventType, map[string]string{
-		"form_id": formID,
-		"state":   state,
-	})
+	return NewEvent(FormStateEventType, &FormStatePayload{FormID: formID, State: state})
 }
 
 // NewFieldEvent creates a new field event
 func NewFieldEvent(formID, fieldID string) *Event {
-	return NewEvent(FieldEventType, map[string]string{
-		"form_id":  formID,
-		"field_id": fieldID,
-	})
+	return NewEvent(FieldEventType, &FieldPayload{FormID: formID, FieldID: fieldID})
 }
 
 // NewAnalyticsEvent creates a new analytics event
 func NewAnalyticsEvent(formID, eventType string) *Event {
-	return NewEvent(AnalyticsEventType, map[string]string{
-		"form_id":    formID,
-		"event_type": eventType,
-	})
+	return NewEvent(AnalyticsEventType, &AnalyticsPayload{FormID: formID, EventType: eventType})
 }
@@ -49,14 +49,15 @@ func NewEventHandler(logger logging.Logger) *EventHandler {
 
 // Handle handles form events
 func (h *EventHandler) Handle(ctx context.Context, event events.Event) error {
-	h.logger.Info("handling form event",
+	logger := logging.WithContext(ctx, h.logger)
+	logger.Info("handling form event",
 		"event_name", event.Name(),
 		"timestamp", event.Timestamp(),
 	)
 
 	handler, exists := h.handlers[event.Name()]
 	if !exists {
-		h.logger.Warn("unknown event type",
+		logger.Warn("unknown event type",
 			"event_name", event.Name(),
 			"timestamp", event.Timestamp(),
 		)
@@ -69,10 +70,9 @@ func (h *EventHandler) Handle(ctx context.Context, event events.Event) error {
 
 // handleFormCreated handles form created events
 func (h *EventHandler) handleFormCreated(ctx context.Context, event events.Event) error {
-	h.logger.Info("handling form created event",
+	logging.WithContext(ctx, h.logger).Info("handling form created event",
 		"event_name", event.Name(),
 		"timestamp", event.Timestamp(),
-		"request_id", ctx.Value("request_id"),
 	)
 
 	return nil
@@ -80,10 +80,9 @@ func (h *EventHandler) handleFormCreated(ctx context.Context, event events.Event
 
 // handleFormUpdated handles form updated events
 func (h *EventHandler) handleFormUpdated(ctx context.Context, event events.Event) error {
-	h.logger.Info("handling form updated event",
+	logging.WithContext(ctx, h.logger).Info("handling form updated event",
 		"event_name", event.Name(),
 		"timestamp", event.Timestamp(),
-		"request_id", ctx.Value("request_id"),
 	)
 
 	return nil
@@ -91,10 +90,9 @@ func (h *EventHandler) handleFormUpdated(ctx context.Context, event events.Event
 
 // handleFormDeleted handles form deleted events
 func (h *EventHandler) handleFormDeleted(ctx context.Context, event events.Event) error {
-	h.logger.Info("handling form deleted event",
+	logging.WithContext(ctx, h.logger).Info("handling form deleted event",
 		"event_name", event.Name(),
 		"timestamp", event.Timestamp(),
-		"request_id", ctx.Value("request_id"),
 	)
 
 	return nil
@@ -102,10 +100,9 @@ func (h *EventHandler) handleFormDeleted(ctx context.Context, event events.Event
 
 // handleFormSubmitted handles form submitted events
 func (h *EventHandler) handleFormSubmitted(ctx context.Context, event events.Event) error {
-	h.logger.Info("handling form submitted event",
+	logging.WithContext(ctx, h.logger).Info("handling form submitted event",
 		"event_name", event.Name(),
 		"timestamp", event.Timestamp(),
-		"request_id", ctx.Value("request_id"),
 	)
 
 	return nil
@@ -113,10 +110,9 @@ func (h *EventHandler) handleFormSubmitted(ctx context.Context, event events.Eve
 
 // handleFormValidated handles form validated events
 func (h *EventHandler) handleFormValidated(ctx context.Context, event events.Event) error {
-	h.logger.Info("handling form validated event",
+	logging.WithContext(ctx, h.logger).Info("handling form validated event",
 		"event_name", event.Name(),
 		"timestamp", event.Timestamp(),
-		"request_id", ctx.Value("request_id"),
 	)
 
 	return nil
@@ -124,10 +120,9 @@ func (h *EventHandler) handleFormValidated(ctx context.Context, event events.Eve
 
 // handleFormProcessed handles form processed events
 func (h *EventHandler) handleFormProcessed(ctx context.Context, event events.Event) error {
-	h.logger.Info("handling form processed event",
+	logging.WithContext(ctx, h.logger).Info("handling form processed event",
 		"event_name", event.Name(),
 		"timestamp", event.Timestamp(),
-		"request_id", ctx.Value("request_id"),
 	)
 
 	return nil
@@ -135,10 +130,9 @@ func (h *EventHandler) handleFormProcessed(ctx context.Context, event events.Eve
 
 // handleFormError handles form error events
 func (h *EventHandler) handleFormError(ctx context.Context, event events.Event) error {
-	h.logger.Error("handling form error event",
+	logging.WithContext(ctx, h.logger).Error("handling form error event",
 		"event_name", event.Name(),
 		"timestamp", event.Timestamp(),
-		"request_id", ctx.Value("request_id"),
 	)
 
 	return nil
@@ -146,10 +140,9 @@ func (h *EventHandler) handleFormError(ctx context.Context, event events.Event)
 
 // handleFormState handles form state events
 func (h *EventHandler) handleFormState(ctx context.Context, event events.Event) error {
-	h.logger.Info("handling form state event",
+	logging.WithContext(ctx, h.logger).Info("handling form state event",
 		"event_name", event.Name(),
 		"timestamp", event.Timestamp(),
-		"request_id", ctx.Value("request_id"),
 	)
 
 	return nil
@@ -157,10 +150,9 @@ func (h *EventHandler) handleFormState(ctx context.Context, event events.Event)
 
 // handleFieldEvent handles field events
 func (h *EventHandler) handleFieldEvent(ctx context.Context, event events.Event) error {
-	h.logger.Info("handling field event",
+	logging.WithContext(ctx, h.logger).Info("handling field event",
 		"event_name", event.Name(),
 		"timestamp", event.Timestamp(),
-		"request_id", ctx.Value("request_id"),
 	)
 
 	return nil
@@ -168,10 +160,9 @@ func (h *EventHandler) handleFieldEvent(ctx context.Context, event events.Event)
 
 // handleAnalyticsEvent handles analytics events
 func (h *EventHandler) handleAnalyticsEvent(ctx context.Context, event events.Event) error {
-	h.logger.Info("handling analytics event",
+	logging.WithContext(ctx, h.logger).Info("handling analytics event",
 		"event_name", event.Name(),
 		"timestamp", event.Timestamp(),
-		"request_id", ctx.Value("request_id"),
 	)
 
 	return nil
@@ -0,0 +1,112 @@
+package form
+
+import (
+	"reflect"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+// Stream types group events by the aggregate they belong to
+const (
+	// StreamForm is the stream of a form's events, identified by the form ID
+	StreamForm = "form"
+	// StreamSubmission is the stream of a submission's events, identified by
+	// the submission ID
+	StreamSubmission = "submission"
+)
+
+// FormValidatedPayload is the payload of a form validated event
+type FormValidatedPayload struct {
+	FormID  string `json:"form_id"`
+	IsValid bool   `json:"is_valid"`
+}
+
+// FormProcessedPayload is the payload of a form processed event
+type FormProcessedPayload struct {
+	FormID       string `json:"form_id"`
+	ProcessingID string `json:"processing_id"`
+}
+
+// FormErrorPayload is the payload of a form error event
+type FormErrorPayload struct {
+	FormID string `json:"form_id"`
+	Error  string `json:"error"`
+}
+
+// FormStatePayload is the payload of a form state event
+type FormStatePayload struct {
+	FormID string `json:"form_id"`
+	State  string `json:"state"`
+}
+
+// FieldPayload is the payload of a field event
+type FieldPayload struct {
+	FormID  string `json:"form_id"`
+	FieldID string `json:"field_id"`
+}
+
+// AnalyticsPayload is the payload of an analytics event
+type AnalyticsPayload struct {
+	FormID    string `json:"form_id"`
+	EventType string `json:"event_type"`
+}
+
+// SubmissionUpdatedPayload is the payload of a submission updated event
+type SubmissionUpdatedPayload struct {
+	Submission *model.FormSubmission `json:"submission"`
+	EditorID   string                `json:"editor_id"`
+	Revision   int                   `json:"revision"`
+	Fields     []string              `json:"fields"`
+}
+
+// PayloadTypes returns the Go type of each event type's payload, so that
+// serialized events can be decoded into the payloads their handlers expect.
+func PayloadTypes() map[EventType]reflect.Type {
+	return map[EventType]reflect.Type{
+		FormCreatedEventType:       reflect.TypeFor[*model.Form](),
+		FormUpdatedEventType:       reflect.TypeFor[*model.Form](),
+		FormDeletedEventType:       reflect.TypeFor[string](),
+		FormSubmittedEventType:     reflect.TypeFor[*model.FormSubmission](),
+		FormValidatedEventType:     reflect.TypeFor[*FormValidatedPayload](),
+		FormProcessedEventType:     reflect.TypeFor[*FormProcessedPayload](),
+		FormErrorEventType:         reflect.TypeFor[*FormErrorPayload](),
+		FormStateEventType:         reflect.TypeFor[*FormStatePayload](),
+		FieldEventType:             reflect.TypeFor[*FieldPayload](),
+		AnalyticsEventType:         reflect.TypeFor[*AnalyticsPayload](),
+		SubmissionUpdatedEventType: reflect.TypeFor[*SubmissionUpdatedPayload](),
+	}
+}
+
+// StreamOf returns the stream type and ID of the aggregate a payload belongs
+// to. Submission events belong to the submission's stream; every other event
+// belongs to its form's. It returns empty strings for unknown payloads.
+func StreamOf(payload any) (streamType, streamID string) {
+	switch p := payload.(type) {
+	case *model.Form:
+		return StreamForm, p.ID
+	case string:
+		return StreamForm, p
+	case *model.FormSubmission:
+		return StreamSubmission, p.ID
+	case *SubmissionUpdatedPayload:
+		if p.Submission == nil {
+			return "", ""
+		}
+
+		return StreamSubmission, p.Submission.ID
+	case *FormValidatedPayload:
+		return StreamForm, p.FormID
+	case *FormProcessedPayload:
+		return StreamForm, p.FormID
+	case *FormErrorPayload:
+		return StreamForm, p.FormID
+	case *FormStatePayload:
+		return StreamForm, p.FormID
+	case *FieldPayload:
+		return StreamForm, p.FormID
+	case *AnalyticsPayload:
+		return StreamForm, p.FormID
+	default:
+		return "", ""
+	}
+}
@@ -0,0 +1,152 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	"unicode/utf8"
+)
+
+// BundleVersion is the current version of the form bundle format
+const BundleVersion = 1
+
+// Conflict policies applied when an imported bundle's form ID already exists
+const (
+	// ConflictNewID imports the form under a freshly generated ID
+	ConflictNewID = "new_id"
+	// ConflictFail rejects the import
+	ConflictFail = "fail"
+)
+
+// duplicateTitlePrefix is prepended to the title of duplicated forms
+const duplicateTitlePrefix = "Copy of "
+
+var (
+	// ErrUnsupportedBundleVersion is returned when a bundle was written by an incompatible format version
+	ErrUnsupportedBundleVersion = errors.New("unsupported form bundle version")
+
+	// ErrInvalidConflictPolicy is returned for an unknown ID conflict policy
+	ErrInvalidConflictPolicy = errors.New("invalid ID conflict policy")
+)
+
+// Bundle is a portable, versioned representation of a form used to move forms
+// between environments. It carries the form definition and settings but no
+// ownership, timestamps or submissions.
+type Bundle struct {
+	Version    int        `json:"version"`
+	ExportedAt time.Time  `json:"exported_at"`
+	Form       BundleForm `json:"form"`
+}
+
+// BundleForm holds the exported form definition and its per-form settings
+type BundleForm struct {
+	ID          string   `json:"id"`
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Schema      JSON     `json:"schema"`
+	Status      string   `json:"status"`
+	Active      bool     `json:"active"`
+	CorsOrigins []string `json:"cors_origins"`
+	CorsMethods []string `json:"cors_methods"`
+	CorsHeaders []string `json:"cors_headers"`
+
+	DraftTTLHours   int    `json:"draft_ttl_hours,omitempty"`
+	RetentionDays   int    `json:"retention_days,omitempty"`
+	RetentionAction string `json:"retention_action,omitempty"`
+}
+
+// NewBundle exports a form into a bundle
+func NewBundle(f *Form) *Bundle {
+	origins, methods, headers := f.GetCorsConfig()
+
+	return &Bundle{
+		Version:    BundleVersion,
+		ExportedAt: time.Now().UTC(),
+		Form: BundleForm{
+			ID:          f.ID,
+			Title:       f.Title,
+			Description: f.Description,
+			Schema:      f.Schema,
+			Status:      f.Status,
+			Active:      f.Active,
+			CorsOrigins: origins,
+			CorsMethods: methods,
+			CorsHeaders: headers,
+
+			DraftTTLHours:   f.DraftTTLHours,
+			RetentionDays:   f.RetentionDays,
+			RetentionAction: f.RetentionAction,
+		},
+	}
+}
+
+// Validate checks the bundle version and the fields required to recreate the form
+func (b *Bundle) Validate() error {
+	if b.Version < 1 || b.Version > BundleVersion {
+		return fmt.Errorf("%w: %d (supported: 1 to %d)", ErrUnsupportedBundleVersion, b.Version, BundleVersion)
+	}
+
+	if b.Form.Title == "" {
+		return ErrFormTitleRequired
+	}
+
+	if b.Form.Schema == nil {
+		return ErrFormSchemaRequired
+	}
+
+	return nil
+}
+
+// ToForm creates a new form owned by userID from the bundle. The form keeps the
+// bundle's ID; callers assign a new one when that ID is already taken.
+func (b *Bundle) ToForm(userID string) *Form {
+	f := NewForm(userID, b.Form.Title, b.Form.Description, b.Form.Schema)
+
+	if b.Form.ID != "" {
+		f.ID = b.Form.ID
+	}
+
+	if b.Form.Status != "" {
+		f.Status = b.Form.Status
+	}
+
+	f.Active = b.Form.Active
+	f.SetCorsConfig(b.Form.CorsOrigins, b.Form.CorsMethods, b.Form.CorsHeaders)
+
+	if b.Form.DraftTTLHours > 0 {
+		f.DraftTTLHours = b.Form.DraftTTLHours
+	}
+
+	f.RetentionDays = b.Form.RetentionDays
+
+	if b.Form.RetentionAction != "" {
+		f.RetentionAction = b.Form.RetentionAction
+	}
+
+	return f
+}
+
+// ValidateConflictPolicy checks an ID conflict policy, treating empty as ConflictNewID
+func ValidateConflictPolicy(policy string) (string, error) {
+	switch policy {
+	case "":
+		return ConflictNewID, nil
+	case ConflictNewID, ConflictFail:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("%w: %q (expected %s or %s)", ErrInvalidConflictPolicy, policy, ConflictNewID, ConflictFail)
+	}
+}
+
+// DuplicateTitle returns the title for a copy of a form, truncated to MaxTitleLength
+func DuplicateTitle(title string) string {
+	duplicate := duplicateTitlePrefix + title
+	if len(duplicate) > MaxTitleLength {
+		duplicate = duplicate[:MaxTitleLength]
+		for !utf8.ValidString(duplicate) {
+			duplicate = duplicate[:len(duplicate)-1]
+		}
+	}
+
+	return duplicate
+}
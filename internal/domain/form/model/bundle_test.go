@@ -0,0 +1,99 @@
+package model_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+func TestBundle_RoundTrip(t *testing.T) {
+	form := model.NewForm("owner-1", "Contact", "Get in touch", model.JSON{
+		"type":       "object",
+		"components": []any{map[string]any{"type": "textfield", "key": "name"}},
+	})
+	form.Status = "published"
+	form.SetCorsConfig([]string{"https://example.com"}, []string{"GET", "POST"}, []string{"Content-Type"})
+
+	data, err := json.Marshal(model.NewBundle(form))
+	require.NoError(t, err)
+
+	var decoded model.Bundle
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.NoError(t, decoded.Validate())
+	require.Equal(t, model.BundleVersion, decoded.Version)
+
+	imported := decoded.ToForm("owner-2")
+	require.Equal(t, form.ID, imported.ID)
+	require.Equal(t, "owner-2", imported.UserID)
+	require.Equal(t, form.Title, imported.Title)
+	require.Equal(t, form.Description, imported.Description)
+	require.Equal(t, "published", imported.Status)
+
+	origins, methods, headers := imported.GetCorsConfig()
+	require.Equal(t, []string{"https://example.com"}, origins)
+	require.Equal(t, []string{"GET", "POST"}, methods)
+	require.Equal(t, []string{"Content-Type"}, headers)
+}
+
+func TestBundle_Validate(t *testing.T) {
+	valid := model.BundleForm{Title: "Contact", Schema: model.JSON{"type": "object"}}
+
+	tests := []struct {
+		name    string
+		bundle  model.Bundle
+		wantErr error
+	}{
+		{"valid", model.Bundle{Version: model.BundleVersion, Form: valid}, nil},
+		{"missing version", model.Bundle{Form: valid}, model.ErrUnsupportedBundleVersion},
+		{"future version", model.Bundle{Version: model.BundleVersion + 1, Form: valid}, model.ErrUnsupportedBundleVersion},
+		{
+			"missing title",
+			model.Bundle{Version: model.BundleVersion, Form: model.BundleForm{Schema: valid.Schema}},
+			model.ErrFormTitleRequired,
+		},
+		{
+			"missing schema",
+			model.Bundle{Version: model.BundleVersion, Form: model.BundleForm{Title: "Contact"}},
+			model.ErrFormSchemaRequired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.bundle.Validate()
+			if tt.wantErr == nil {
+				require.NoError(t, err)
+
+				return
+			}
+
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestValidateConflictPolicy(t *testing.T) {
+	policy, err := model.ValidateConflictPolicy("")
+	require.NoError(t, err)
+	require.Equal(t, model.ConflictNewID, policy)
+
+	policy, err = model.ValidateConflictPolicy(model.ConflictFail)
+	require.NoError(t, err)
+	require.Equal(t, model.ConflictFail, policy)
+
+	_, err = model.ValidateConflictPolicy("overwrite")
+	require.ErrorIs(t, err, model.ErrInvalidConflictPolicy)
+}
+
+func TestDuplicateTitle(t *testing.T) {
+	require.Equal(t, "Copy of Contact", model.DuplicateTitle("Contact"))
+
+	long := model.DuplicateTitle(strings.Repeat("é", model.MaxTitleLength))
+	require.LessOrEqual(t, len(long), model.MaxTitleLength)
+	require.True(t, utf8.ValidString(long))
+}
@@ -0,0 +1,40 @@
+package model
+
+import "strings"
+
+// MatchOrigin reports whether a browser origin such as "https://app.example.com"
+// matches a CORS origin pattern. Patterns may be "*", an exact origin, or an
+// origin whose host starts with "*." to match any subdomain, for example
+// "https://*.example.com". A pattern without a scheme matches any scheme.
+func MatchOrigin(pattern, origin string) bool {
+	pattern = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(pattern), "/"))
+	origin = strings.ToLower(origin)
+
+	if pattern == "" || origin == "" {
+		return false
+	}
+
+	if pattern == "*" || pattern == origin {
+		return true
+	}
+
+	originScheme, originHost, ok := strings.Cut(origin, "://")
+	if !ok {
+		return false
+	}
+
+	patternHost := pattern
+	if scheme, host, hasScheme := strings.Cut(pattern, "://"); hasScheme {
+		if scheme != originScheme {
+			return false
+		}
+
+		patternHost = host
+	}
+
+	if suffix, isWildcard := strings.CutPrefix(patternHost, "*"); isWildcard {
+		return strings.HasPrefix(suffix, ".") && len(originHost) > len(suffix) && strings.HasSuffix(originHost, suffix)
+	}
+
+	return patternHost == originHost
+}
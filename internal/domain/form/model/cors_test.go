@@ -0,0 +1,40 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+func TestMatchOrigin(t *testing.T) {
+	tests := []struct {
+		pattern string
+		origin  string
+		want    bool
+	}{
+		{"*", "https://example.com", true},
+		{"https://example.com", "https://example.com", true},
+		{"https://example.com/", "https://example.com", true},
+		{"https://Example.com", "https://example.com", true},
+		{"https://example.com", "http://example.com", false},
+		{"https://example.com", "https://example.com:8443", false},
+		{"https://*.example.com", "https://app.example.com", true},
+		{"https://*.example.com", "https://a.b.example.com", true},
+		{"https://*.example.com", "https://example.com", false},
+		{"https://*.example.com", "https://evilexample.com", false},
+		{"https://*.example.com", "http://app.example.com", false},
+		{"*.example.com", "http://app.example.com", true},
+		{"example.com", "https://example.com", true},
+		{"https://*example.com", "https://evilexample.com", false},
+		{"", "https://example.com", false},
+		{"https://example.com", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.origin, func(t *testing.T) {
+			assert.Equal(t, tt.want, model.MatchOrigin(tt.pattern, tt.origin))
+		})
+	}
+}
@@ -22,6 +22,21 @@ const (
 	MaxDescriptionLength = 500
 	// MaxFields is the maximum number of fields allowed in a form
 	MaxFields = 50
+	// DefaultDraftTTLHours is how long a saved draft submission can be resumed by default
+	DefaultDraftTTLHours = 7 * 24
+	// MaxDraftTTLHours is the longest a form may keep draft submissions
+	MaxDraftTTLHours = 90 * 24
+	// MaxRetentionDays is the longest retention period a form can set
+	MaxRetentionDays = 10 * 365
+)
+
+// Retention actions applied to submissions older than a form's retention period
+const (
+	// RetentionActionDelete permanently deletes old submissions
+	RetentionActionDelete = "delete"
+	// RetentionActionAnonymize clears the answers of old submissions but keeps
+	// the submissions themselves, so that counts and dates stay available
+	RetentionActionAnonymize = "anonymize"
 )
 
 var (
@@ -72,6 +87,14 @@ type Form struct {
 	CorsOrigins JSON `json:"cors_origins" gorm:"type:json"`
 	CorsMethods JSON `json:"cors_methods" gorm:"type:json"`
 	CorsHeaders JSON `json:"cors_headers" gorm:"type:json"`
+
+	// DraftTTLHours is how long respondents can resume a saved draft submission
+	DraftTTLHours int `json:"draft_ttl_hours" gorm:"not null;default:168"`
+
+	// RetentionDays is how long submissions are kept; zero keeps them forever
+	RetentionDays int `json:"retention_days" gorm:"not null;default:0"`
+	// RetentionAction is applied to submissions older than RetentionDays
+	RetentionAction string `json:"retention_action" gorm:"size:20;not null;default:'delete'"`
 }
 
 // GetID returns the form's ID
@@ -244,6 +267,9 @@ func NewForm(userID, title, description string, schema JSON) *Form {
 		CorsOrigins: JSON{},
 		CorsMethods: JSON{},
 		CorsHeaders: JSON{},
+
+		DraftTTLHours:   DefaultDraftTTLHours,
+		RetentionAction: RetentionActionDelete,
 	}
 }
 
@@ -382,6 +408,18 @@ func (f *Form) Validate() error {
 		return fmt.Errorf("description must not exceed %d characters", MaxDescriptionLength)
 	}
 
+	if f.DraftTTLHours < 0 || f.DraftTTLHours > MaxDraftTTLHours {
+		return fmt.Errorf("draft expiry must be between 0 and %d hours", MaxDraftTTLHours)
+	}
+
+	if f.RetentionDays < 0 || f.RetentionDays > MaxRetentionDays {
+		return fmt.Errorf("retention period must be between 0 and %d days", MaxRetentionDays)
+	}
+
+	if f.RetentionAction != "" && !IsRetentionAction(f.RetentionAction) {
+		return fmt.Errorf("retention action must be %q or %q", RetentionActionDelete, RetentionActionAnonymize)
+	}
+
 	if len(f.Fields) > MaxFields {
 		return fmt.Errorf("form cannot have more than %d fields", MaxFields)
 	}
@@ -419,6 +457,51 @@ func (f *Form) Activate() {
 	f.UpdatedAt = time.Now()
 }
 
+// IsPublished reports whether the form is published and active, and so may be
+// shown to anonymous visitors
+func (f *Form) IsPublished() bool {
+	return f.Active && f.Status == "published"
+}
+
+// DraftTTL returns how long a draft submission stays resumable, falling back to
+// DefaultDraftTTLHours when the form does not set it
+func (f *Form) DraftTTL() time.Duration {
+	hours := f.DraftTTLHours
+	if hours <= 0 {
+		hours = DefaultDraftTTLHours
+	}
+
+	return time.Duration(hours) * time.Hour
+}
+
+// RetentionCutoff returns the time before which the form's submissions are past
+// their retention period, and false when the form keeps submissions forever
+func (f *Form) RetentionCutoff(now time.Time) (time.Time, bool) {
+	if f.RetentionDays <= 0 {
+		return time.Time{}, false
+	}
+
+	return now.AddDate(0, 0, -f.RetentionDays), true
+}
+
+// IsRetentionAction reports whether action is a known retention action
+func IsRetentionAction(action string) bool {
+	return action == RetentionActionDelete || action == RetentionActionAnonymize
+}
+
+// AllowsOrigin reports whether origin matches one of the form's CORS origins.
+// See MatchOrigin for the supported patterns.
+func (f *Form) AllowsOrigin(origin string) bool {
+	origins, _, _ := f.GetCorsConfig()
+	for _, allowed := range origins {
+		if MatchOrigin(allowed, origin) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // extractStringSlice extracts a string slice from JSON array
 func extractStringSlice(data JSON, key string) []string {
 	var result []string
@@ -426,6 +509,11 @@ func extractStringSlice(data JSON, key string) []string {
 		return result
 	}
 
+	// Values set in memory by SetCorsConfig are still typed slices
+	if arr, ok := data[key].([]string); ok {
+		return append(result, arr...)
+	}
+
 	// First try to get the value directly by key
 	if arr, ok := data[key].([]any); ok {
 		for _, item := range arr {
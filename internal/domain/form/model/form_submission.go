@@ -18,6 +18,9 @@ type FormSubmission struct {
 	Metadata    JSON             `json:"metadata" gorm:"type:jsonb"`
 	CreatedAt   time.Time        `json:"created_at" gorm:"not null;autoCreateTime"`
 	UpdatedAt   time.Time        `json:"updated_at" gorm:"not null;autoUpdateTime"`
+
+	// AnonymizedAt is set once a retention policy has cleared the submission's answers
+	AnonymizedAt *time.Time `json:"anonymized_at,omitempty"`
 }
 
 // GetID returns the submission's ID
@@ -44,6 +47,12 @@ const (
 	SubmissionStatusFailed SubmissionStatus = "failed"
 )
 
+// SubmissionSortFields are the fields submission lists can be sorted by
+var SubmissionSortFields = []string{"submitted_at", "created_at", "updated_at", "status"}
+
+// DefaultSubmissionOrder lists the newest submissions first
+const DefaultSubmissionOrder = "submitted_at DESC"
+
 // Validate validates the form submission
 func (fs *FormSubmission) Validate() error {
 	if fs.FormID == "" {
@@ -111,6 +120,22 @@ func (fs *FormSubmission) UpdateStatus(status SubmissionStatus) {
 	fs.Status = status
 }
 
+// Anonymize clears every answer in the submission while keeping the field keys,
+// and drops its metadata, which can hold the submitter's IP and user agent
+func (fs *FormSubmission) Anonymize(now time.Time) {
+	for key := range fs.Data {
+		fs.Data[key] = nil
+	}
+
+	fs.Metadata = nil
+	fs.AnonymizedAt = &now
+}
+
+// IsAnonymized reports whether the submission's answers have been cleared
+func (fs *FormSubmission) IsAnonymized() bool {
+	return fs.AnonymizedAt != nil
+}
+
 // AddMetadata adds metadata to the submission
 func (fs *FormSubmission) AddMetadata(key, value string) {
 	if fs.Metadata == nil {
@@ -217,3 +217,19 @@ func TestFormSubmission_UpdateStatus(t *testing.T) {
 	submission.AddMetadata("status_change", "processing")
 	require.Equal(t, "processing", submission.Metadata["status_change"])
 }
+
+func TestFormSubmission_Anonymize(t *testing.T) {
+	now := time.Now()
+	submission := &model.FormSubmission{
+		FormID:   "form123",
+		Data:     model.JSON{"email": "ada@example.com", "age": float64(36)},
+		Metadata: model.JSON{"ip": "203.0.113.7"},
+	}
+	require.False(t, submission.IsAnonymized())
+
+	submission.Anonymize(now)
+	require.True(t, submission.IsAnonymized())
+	require.Equal(t, model.JSON{"email": nil, "age": nil}, submission.Data)
+	require.Nil(t, submission.Metadata)
+	require.Equal(t, now, *submission.AnonymizedAt)
+}
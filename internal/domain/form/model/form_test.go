@@ -2,6 +2,7 @@ package model_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -161,3 +162,84 @@ func TestForm_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestForm_IsPublished(t *testing.T) {
+	form := model.NewForm("user123", "Test Form", "", model.JSON{"components": []any{}})
+	require.False(t, form.IsPublished())
+
+	form.Status = "published"
+	require.True(t, form.IsPublished())
+
+	form.Deactivate()
+	require.False(t, form.IsPublished())
+}
+
+func TestForm_AllowsOrigin(t *testing.T) {
+	form := model.NewForm("user123", "Test Form", "", model.JSON{"components": []any{}})
+	require.False(t, form.AllowsOrigin("https://example.com"))
+
+	form.SetCorsConfig([]string{"https://example.com/"}, nil, nil)
+	require.True(t, form.AllowsOrigin("https://example.com"))
+	require.False(t, form.AllowsOrigin("https://other.example"))
+
+	form.SetCorsConfig([]string{"*"}, nil, nil)
+	require.True(t, form.AllowsOrigin("https://other.example"))
+}
+
+func TestForm_DraftTTL(t *testing.T) {
+	form := model.NewForm("user123", "Test Form", "", model.JSON{"components": []any{}})
+	require.Equal(t, model.DefaultDraftTTLHours*time.Hour, form.DraftTTL())
+
+	form.DraftTTLHours = 2
+	require.Equal(t, 2*time.Hour, form.DraftTTL())
+
+	form.DraftTTLHours = 0
+	require.Equal(t, model.DefaultDraftTTLHours*time.Hour, form.DraftTTL())
+
+	form.DraftTTLHours = model.MaxDraftTTLHours + 1
+	require.Error(t, form.Validate())
+}
+
+func TestForm_RetentionCutoff(t *testing.T) {
+	now := time.Date(2026, 3, 31, 12, 0, 0, 0, time.UTC)
+	form := model.NewForm("user123", "Test Form", "", model.JSON{"components": []any{}})
+	require.Equal(t, model.RetentionActionDelete, form.RetentionAction)
+
+	_, ok := form.RetentionCutoff(now)
+	require.False(t, ok)
+
+	form.RetentionDays = 30
+	cutoff, ok := form.RetentionCutoff(now)
+	require.True(t, ok)
+	require.Equal(t, time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC), cutoff)
+
+	form.RetentionAction = "archive"
+	require.Error(t, form.Validate())
+
+	form.RetentionAction = model.RetentionActionAnonymize
+	form.RetentionDays = model.MaxRetentionDays + 1
+	require.Error(t, form.Validate())
+}
+
+func TestForm_SensitiveFields(t *testing.T) {
+	form := &model.Form{Schema: model.JSON{
+		"components": []any{
+			map[string]any{"type": "textfield", "key": "name", "input": true},
+			map[string]any{"type": "textfield", "key": "ssn", "input": true, "sensitive": true},
+			map[string]any{"type": "columns", "columns": []any{
+				map[string]any{"components": []any{
+					map[string]any{"type": "datetime", "key": "dob", "input": true, "sensitive": true},
+				}},
+			}},
+			map[string]any{"type": "datagrid", "key": "children", "input": true, "components": []any{
+				map[string]any{"type": "textfield", "key": "passport", "input": true, "sensitive": true},
+			}},
+			map[string]any{"type": "button", "key": "submit", "input": true},
+		},
+	}}
+
+	require.Equal(t, []string{"dob", "ssn"}, form.SensitiveFields())
+	require.True(t, form.IsSensitiveField("ssn"))
+	require.False(t, form.IsSensitiveField("passport"))
+	require.Empty(t, (&model.Form{Schema: model.JSON{}}).SensitiveFields())
+}
@@ -0,0 +1,22 @@
+package model
+
+import (
+	"time"
+)
+
+// SchemaVersion is one of the schemas a form has had. A version is stored
+// when the form is created and each time its schema changes.
+type SchemaVersion struct {
+	ID     string `json:"-" gorm:"column:uuid;primaryKey"`
+	FormID string `json:"-" gorm:"not null;index"`
+	// Version numbers a form's schemas from 1, oldest first
+	Version int  `json:"version" gorm:"not null"`
+	Schema  JSON `json:"-" gorm:"type:json;not null"`
+	// ValidFrom is when the form got the schema
+	ValidFrom time.Time `json:"valid_from" gorm:"column:created_at;not null"`
+}
+
+// TableName specifies the table name for the SchemaVersion model
+func (v *SchemaVersion) TableName() string {
+	return "form_schemas"
+}
@@ -0,0 +1,61 @@
+package model
+
+import "slices"
+
+// SensitiveProperty is the Form.io component property an owner sets to have a
+// field's answers encrypted at rest
+const SensitiveProperty = "sensitive"
+
+// SensitiveFields returns the submission data keys of the components marked
+// sensitive in the form's schema. Layout components such as panels and columns
+// are searched for nested fields; the children of data components such as data
+// grids are stored under the parent's key, so the parent must be marked instead.
+func (f *Form) SensitiveFields() []string {
+	var fields []string
+
+	var walk func(components any)
+
+	walk = func(components any) {
+		list, ok := components.([]any)
+		if !ok {
+			return
+		}
+
+		for _, c := range list {
+			component, isMap := c.(map[string]any)
+			if !isMap {
+				continue
+			}
+
+			if input, _ := component["input"].(bool); input {
+				key, _ := component["key"].(string)
+				if sensitive, _ := component[SensitiveProperty].(bool); sensitive && key != "" {
+					fields = append(fields, key)
+				}
+
+				continue
+			}
+
+			walk(component["components"])
+
+			if columns, hasColumns := component["columns"].([]any); hasColumns {
+				for _, column := range columns {
+					if col, isCol := column.(map[string]any); isCol {
+						walk(col["components"])
+					}
+				}
+			}
+		}
+	}
+
+	walk(f.Schema["components"])
+
+	slices.Sort(fields)
+
+	return slices.Compact(fields)
+}
+
+// IsSensitiveField reports whether the answers to field are encrypted at rest
+func (f *Form) IsSensitiveField(field string) bool {
+	return slices.Contains(f.SensitiveFields(), field)
+}
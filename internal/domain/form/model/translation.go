@@ -0,0 +1,237 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// TranslationsProperty is the schema property holding a form's translations,
+// keyed by locale. Keeping them in the schema means they are saved, exported,
+// duplicated and revised together with the fields they translate.
+const TranslationsProperty = "translations"
+
+// Translation holds the texts of a form in one language. Empty texts keep the
+// form's own.
+type Translation struct {
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	// Fields holds the component translations, keyed by component key
+	Fields map[string]FieldTranslation `json:"fields,omitempty"`
+}
+
+// FieldTranslation holds the texts of one component in one language
+type FieldTranslation struct {
+	Label       string `json:"label,omitempty"`
+	Placeholder string `json:"placeholder,omitempty"`
+	Description string `json:"description,omitempty"`
+	Tooltip     string `json:"tooltip,omitempty"`
+	// Title is the heading of layout components such as panels
+	Title string `json:"title,omitempty"`
+	// CustomMessage replaces the component's validate.customMessage
+	CustomMessage string `json:"customMessage,omitempty"`
+	// Errors replace the component's per-rule error messages
+	Errors map[string]string `json:"errors,omitempty"`
+	// Options holds option labels, keyed by option value. Values are what is
+	// submitted, so they are never translated.
+	Options map[string]string `json:"options,omitempty"`
+}
+
+// Translations returns the form's translations, keyed by locale. Malformed
+// translations are ignored.
+func (f *Form) Translations() map[string]Translation {
+	raw, ok := f.Schema[TranslationsProperty]
+	if !ok || raw == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil
+	}
+
+	var translations map[string]Translation
+	if unmarshalErr := json.Unmarshal(data, &translations); unmarshalErr != nil {
+		return nil
+	}
+
+	return translations
+}
+
+// SetTranslations replaces the form's translations
+func (f *Form) SetTranslations(translations map[string]Translation) error {
+	if f.Schema == nil {
+		f.Schema = JSON{}
+	}
+
+	if len(translations) == 0 {
+		delete(f.Schema, TranslationsProperty)
+
+		return nil
+	}
+
+	data, err := json.Marshal(translations)
+	if err != nil {
+		return fmt.Errorf("marshal translations: %w", err)
+	}
+
+	var raw map[string]any
+	if unmarshalErr := json.Unmarshal(data, &raw); unmarshalErr != nil {
+		return fmt.Errorf("unmarshal translations: %w", unmarshalErr)
+	}
+
+	f.Schema[TranslationsProperty] = raw
+
+	return nil
+}
+
+// Languages returns the locales the form is translated into, sorted
+func (f *Form) Languages() []string {
+	return slices.Sorted(maps.Keys(f.Translations()))
+}
+
+// Localized returns a copy of the form with its title, description and
+// component texts in locale, falling back to the form's own texts where no
+// translation exists. The copy's schema has no translations property, so it
+// can be handed to the renderer as is. The form itself is not modified.
+func (f *Form) Localized(locale string) *Form {
+	localized := *f
+	localized.Schema = copySchema(f.Schema)
+	delete(localized.Schema, TranslationsProperty)
+
+	translation, ok := f.Translations()[locale]
+	if !ok {
+		return &localized
+	}
+
+	if translation.Title != "" {
+		localized.Title = translation.Title
+	}
+
+	if translation.Description != "" {
+		localized.Description = translation.Description
+	}
+
+	translateComponents(localized.Schema["components"], translation.Fields)
+
+	return &localized
+}
+
+// copySchema returns a deep copy of a schema
+func copySchema(schema JSON) JSON {
+	if schema == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(schema)
+	if err != nil {
+		return maps.Clone(schema)
+	}
+
+	var copied JSON
+	if unmarshalErr := json.Unmarshal(data, &copied); unmarshalErr != nil {
+		return maps.Clone(schema)
+	}
+
+	return copied
+}
+
+// translateComponents applies field translations to a component list and the
+// components nested in it
+func translateComponents(components any, fields map[string]FieldTranslation) {
+	list, ok := components.([]any)
+	if !ok {
+		return
+	}
+
+	for _, c := range list {
+		component, isMap := c.(map[string]any)
+		if !isMap {
+			continue
+		}
+
+		if key, _ := component["key"].(string); key != "" {
+			if translation, has := fields[key]; has {
+				translateComponent(component, &translation)
+			}
+		}
+
+		translateComponents(component["components"], fields)
+
+		if columns, hasColumns := component["columns"].([]any); hasColumns {
+			for _, column := range columns {
+				if col, isCol := column.(map[string]any); isCol {
+					translateComponents(col["components"], fields)
+				}
+			}
+		}
+	}
+}
+
+// translateComponent applies a translation to one component
+func translateComponent(component map[string]any, t *FieldTranslation) {
+	texts := map[string]string{
+		"label":       t.Label,
+		"placeholder": t.Placeholder,
+		"description": t.Description,
+		"tooltip":     t.Tooltip,
+		"title":       t.Title,
+	}
+
+	for property, text := range texts {
+		if text != "" {
+			component[property] = text
+		}
+	}
+
+	if t.CustomMessage != "" {
+		validate, _ := component["validate"].(map[string]any)
+		if validate == nil {
+			validate = map[string]any{}
+			component["validate"] = validate
+		}
+
+		validate["customMessage"] = t.CustomMessage
+	}
+
+	if len(t.Errors) > 0 {
+		errorMessages, _ := component["errors"].(map[string]any)
+		if errorMessages == nil {
+			errorMessages = map[string]any{}
+			component["errors"] = errorMessages
+		}
+
+		for rule, message := range t.Errors {
+			errorMessages[rule] = message
+		}
+	}
+
+	// Select components list their options in data.values; radios and
+	// select boxes in values
+	translateOptions(component["values"], t.Options)
+
+	if data, ok := component["data"].(map[string]any); ok {
+		translateOptions(data["values"], t.Options)
+	}
+}
+
+// translateOptions replaces option labels by option value
+func translateOptions(values any, labels map[string]string) {
+	list, ok := values.([]any)
+	if !ok || len(labels) == 0 {
+		return
+	}
+
+	for _, v := range list {
+		option, isMap := v.(map[string]any)
+		if !isMap {
+			continue
+		}
+
+		value := fmt.Sprint(option["value"])
+		if label, has := labels[value]; has && label != "" {
+			option["label"] = label
+		}
+	}
+}
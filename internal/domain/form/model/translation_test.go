@@ -0,0 +1,114 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+func translatedForm() *model.Form {
+	return &model.Form{
+		ID:          "form-1",
+		Title:       "Contact us",
+		Description: "We reply within a day",
+		Schema: model.JSON{
+			"components": []any{
+				map[string]any{
+					"type": "textfield", "key": "name", "input": true,
+					"label": "Name", "placeholder": "Your name",
+					"validate": map[string]any{"required": true},
+				},
+				map[string]any{"type": "panel", "key": "details", "title": "Details", "components": []any{
+					map[string]any{
+						"type": "select", "key": "topic", "input": true, "label": "Topic",
+						"data": map[string]any{"values": []any{
+							map[string]any{"label": "Billing", "value": "billing"},
+							map[string]any{"label": "Support", "value": "support"},
+						}},
+					},
+				}},
+				map[string]any{
+					"type": "radio", "key": "contact", "input": true, "label": "Contact me by",
+					"values": []any{map[string]any{"label": "Email", "value": "email"}},
+				},
+			},
+			model.TranslationsProperty: map[string]any{
+				"fr": map[string]any{
+					"title": "Contactez-nous",
+					"fields": map[string]any{
+						"name": map[string]any{
+							"label": "Nom", "placeholder": "Votre nom",
+							"errors": map[string]any{"required": "Le nom est obligatoire"},
+						},
+						"details": map[string]any{"title": "Détails"},
+						"topic": map[string]any{
+							"label":   "Sujet",
+							"options": map[string]any{"billing": "Facturation"},
+						},
+						"contact": map[string]any{"options": map[string]any{"email": "Courriel"}},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestForm_Localized(t *testing.T) {
+	form := translatedForm()
+
+	fr := form.Localized("fr")
+
+	assert.Equal(t, "Contactez-nous", fr.Title)
+	assert.Equal(t, "We reply within a day", fr.Description, "untranslated texts are kept")
+	assert.NotContains(t, fr.Schema, model.TranslationsProperty)
+
+	components := fr.Schema["components"].([]any)
+	name := components[0].(map[string]any)
+	assert.Equal(t, "Nom", name["label"])
+	assert.Equal(t, "Votre nom", name["placeholder"])
+	assert.Equal(t, map[string]any{"required": "Le nom est obligatoire"}, name["errors"])
+
+	panel := components[1].(map[string]any)
+	assert.Equal(t, "Détails", panel["title"])
+
+	topic := panel["components"].([]any)[0].(map[string]any)
+	assert.Equal(t, "Sujet", topic["label"])
+	assert.Equal(t, []any{
+		map[string]any{"label": "Facturation", "value": "billing"},
+		map[string]any{"label": "Support", "value": "support"},
+	}, topic["data"].(map[string]any)["values"])
+
+	contact := components[2].(map[string]any)
+	assert.Equal(t, "Courriel", contact["values"].([]any)[0].(map[string]any)["label"])
+
+	// The original form is untouched
+	assert.Equal(t, "Contact us", form.Title)
+	assert.Equal(t, "Name", form.Schema["components"].([]any)[0].(map[string]any)["label"])
+	assert.Contains(t, form.Schema, model.TranslationsProperty)
+}
+
+func TestForm_Localized_untranslatedLocale(t *testing.T) {
+	en := translatedForm().Localized("en")
+
+	assert.Equal(t, "Contact us", en.Title)
+	assert.Equal(t, "Name", en.Schema["components"].([]any)[0].(map[string]any)["label"])
+	assert.NotContains(t, en.Schema, model.TranslationsProperty)
+}
+
+func TestForm_Translations(t *testing.T) {
+	form := translatedForm()
+	assert.Equal(t, []string{"fr"}, form.Languages())
+
+	translations := form.Translations()
+	translations["en"] = model.Translation{Title: "Contact"}
+	require.NoError(t, form.SetTranslations(translations))
+	assert.Equal(t, []string{"en", "fr"}, form.Languages())
+	assert.Equal(t, "Facturation", form.Translations()["fr"].Fields["topic"].Options["billing"])
+
+	require.NoError(t, form.SetTranslations(nil))
+	assert.NotContains(t, form.Schema, model.TranslationsProperty)
+	assert.Empty(t, form.Languages())
+}
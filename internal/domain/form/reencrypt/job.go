@@ -0,0 +1,92 @@
+package reencrypt
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+// DefaultInterval is how often the job re-encrypts stored answers when no
+// interval is configured
+const DefaultInterval = 6 * time.Hour
+
+// Job re-encrypts stored answers in the background on a fixed interval
+type Job struct {
+	service  Service
+	logger   logging.Logger
+	interval time.Duration
+	stop     chan struct{}
+	wg       sync.WaitGroup
+}
+
+// NewJob creates a re-encryption job that runs every interval
+func NewJob(service Service, logger logging.Logger, interval time.Duration) *Job {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+
+	return &Job{
+		service:  service,
+		logger:   logger,
+		interval: interval,
+		stop:     make(chan struct{}),
+	}
+}
+
+// Start starts the job. The first run happens one interval after start.
+func (j *Job) Start(_ context.Context) error {
+	j.wg.Add(1)
+
+	go j.loop()
+
+	j.logger.Info("re-encryption job started", "interval", j.interval.String())
+
+	return nil
+}
+
+// Stop stops the job and waits for a run in progress to finish
+func (j *Job) Stop(_ context.Context) error {
+	close(j.stop)
+	j.wg.Wait()
+
+	return nil
+}
+
+// loop runs the job until it is stopped
+func (j *Job) loop() {
+	defer j.wg.Done()
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			j.RunOnce()
+		case <-j.stop:
+			return
+		}
+	}
+}
+
+// RunOnce re-encrypts stored answers once, bounded by the job's interval
+func (j *Job) RunOnce() {
+	ctx, cancel := context.WithTimeout(context.Background(), j.interval)
+	defer cancel()
+
+	report, err := j.service.Run(ctx)
+	if err != nil {
+		j.logger.Error("re-encryption run failed", "error", err)
+	}
+
+	if report != nil {
+		j.logger.Info("re-encryption run finished",
+			"forms_checked", report.FormsChecked,
+			"submissions", report.Submissions,
+			"revisions", report.Revisions,
+			"drafts", report.Drafts,
+		)
+	}
+}
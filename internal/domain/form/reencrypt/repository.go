@@ -0,0 +1,30 @@
+//go:generate mockgen -typed -source=repository.go -destination=../../../../test/mocks/reencrypt/mock_repository.go -package=reencrypt
+
+package reencrypt
+
+import (
+	"context"
+
+	"github.com/goformx/goforms/internal/domain/form/draft"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/domain/form/revision"
+)
+
+// Repository reads and rewrites the stored answers of every form in batches
+type Repository interface {
+	// ListForms returns every form that is not deleted
+	ListForms(ctx context.Context) ([]*model.Form, error)
+	// ScanSubmissions calls fn with successive batches of a form's submissions
+	ScanSubmissions(ctx context.Context, formID string, fn func([]*model.FormSubmission) error) error
+	// ScanRevisions calls fn with successive batches of the revisions of a
+	// form's submissions
+	ScanRevisions(ctx context.Context, formID string, fn func([]*revision.Revision) error) error
+	// ScanDrafts calls fn with successive batches of a form's drafts
+	ScanDrafts(ctx context.Context, formID string, fn func([]*draft.Draft) error) error
+	// UpdateSubmissionData replaces the data of a submission
+	UpdateSubmissionData(ctx context.Context, submissionID string, data model.JSON) error
+	// UpdateRevisionData replaces the data and changes of a revision
+	UpdateRevisionData(ctx context.Context, r *revision.Revision) error
+	// UpdateDraftData replaces the data of a draft
+	UpdateDraftData(ctx context.Context, draftID string, data model.JSON) error
+}
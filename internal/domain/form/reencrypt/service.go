@@ -0,0 +1,227 @@
+//go:generate mockgen -typed -source=service.go -destination=../../../../test/mocks/reencrypt/mock_service.go -package=reencrypt -mock_names=Service=MockService
+
+// Package reencrypt brings stored answers in line with the current field
+// encryption settings: answers to fields that were marked sensitive after they
+// were stored are encrypted, and encrypted answers whose data keys are wrapped
+// by a retired key are re-wrapped with the active key, after which the retired
+// key can be removed from the configuration.
+package reencrypt
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	"github.com/goformx/goforms/internal/domain/form/draft"
+	"github.com/goformx/goforms/internal/domain/form/encryption"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/domain/form/revision"
+)
+
+// Report summarizes a re-encryption run
+type Report struct {
+	FormsChecked int   `json:"forms_checked"`
+	Submissions  int64 `json:"submissions"`
+	Revisions    int64 `json:"revisions"`
+	Drafts       int64 `json:"drafts"`
+}
+
+// Service defines the interface for re-encrypting stored answers
+type Service interface {
+	// Run re-encrypts the submissions, revisions and drafts of every form that
+	// are out of date and reports how many were rewritten. It does nothing when
+	// field encryption is not configured.
+	Run(ctx context.Context) (*Report, error)
+}
+
+// service implements Service
+type service struct {
+	repository Repository
+	encryption encryption.Service
+}
+
+// NewService creates a new re-encryption service
+func NewService(repository Repository, encryptionService encryption.Service) Service {
+	return &service{
+		repository: repository,
+		encryption: encryptionService,
+	}
+}
+
+// Run re-encrypts the stored answers of every form
+func (s *service) Run(ctx context.Context) (*Report, error) {
+	report := &Report{}
+
+	if !s.encryption.Enabled() {
+		return report, nil
+	}
+
+	forms, err := s.repository.ListForms(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list forms: %w", err)
+	}
+
+	for _, f := range forms {
+		if runErr := s.runForm(ctx, f, report); runErr != nil {
+			return report, fmt.Errorf("re-encrypt form %s: %w", f.ID, runErr)
+		}
+
+		report.FormsChecked++
+	}
+
+	return report, nil
+}
+
+// runForm re-encrypts the submissions, revisions and drafts of a form
+func (s *service) runForm(ctx context.Context, f *model.Form, report *Report) error {
+	err := s.repository.ScanSubmissions(ctx, f.ID, func(batch []*model.FormSubmission) error {
+		for _, sub := range batch {
+			data, changed, resealErr := s.reseal(f, sub.Data)
+			if resealErr != nil {
+				return fmt.Errorf("submission %s: %w", sub.ID, resealErr)
+			}
+
+			if !changed {
+				continue
+			}
+
+			if updateErr := s.repository.UpdateSubmissionData(ctx, sub.ID, data); updateErr != nil {
+				return fmt.Errorf("update submission %s: %w", sub.ID, updateErr)
+			}
+
+			report.Submissions++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("scan submissions: %w", err)
+	}
+
+	err = s.repository.ScanRevisions(ctx, f.ID, func(batch []*revision.Revision) error {
+		for _, r := range batch {
+			changed, resealErr := s.resealRevision(f, r)
+			if resealErr != nil {
+				return fmt.Errorf("revision %s: %w", r.ID, resealErr)
+			}
+
+			if !changed {
+				continue
+			}
+
+			if updateErr := s.repository.UpdateRevisionData(ctx, r); updateErr != nil {
+				return fmt.Errorf("update revision %s: %w", r.ID, updateErr)
+			}
+
+			report.Revisions++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("scan revisions: %w", err)
+	}
+
+	err = s.repository.ScanDrafts(ctx, f.ID, func(batch []*draft.Draft) error {
+		for _, d := range batch {
+			data, changed, resealErr := s.reseal(f, d.Data)
+			if resealErr != nil {
+				return fmt.Errorf("draft %s: %w", d.ID, resealErr)
+			}
+
+			if !changed {
+				continue
+			}
+
+			if updateErr := s.repository.UpdateDraftData(ctx, d.ID, data); updateErr != nil {
+				return fmt.Errorf("update draft %s: %w", d.ID, updateErr)
+			}
+
+			report.Drafts++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("scan drafts: %w", err)
+	}
+
+	return nil
+}
+
+// reseal encrypts the plain text answers to the form's sensitive fields and
+// re-wraps encrypted answers with the active key. It reports whether anything
+// changed.
+func (s *service) reseal(f *model.Form, data model.JSON) (model.JSON, bool, error) {
+	changed := false
+
+	for _, field := range f.SensitiveFields() {
+		value, fieldChanged, err := s.resealValue(f, field, data[field])
+		if err != nil {
+			return nil, false, fmt.Errorf("field %s: %w", field, err)
+		}
+
+		if !fieldChanged {
+			continue
+		}
+
+		if !changed {
+			data = maps.Clone(data)
+			changed = true
+		}
+
+		data[field] = value
+	}
+
+	return data, changed, nil
+}
+
+// resealRevision reseals a revision's data and changes in place
+func (s *service) resealRevision(f *model.Form, r *revision.Revision) (bool, error) {
+	data, changed, err := s.reseal(f, r.Data)
+	if err != nil {
+		return false, err
+	}
+
+	r.Data = data
+
+	for i, c := range r.Changes {
+		before, beforeChanged, beforeErr := s.resealValue(f, c.Field, c.Before)
+		if beforeErr != nil {
+			return false, beforeErr
+		}
+
+		after, afterChanged, afterErr := s.resealValue(f, c.Field, c.After)
+		if afterErr != nil {
+			return false, afterErr
+		}
+
+		r.Changes[i].Before, r.Changes[i].After = before, after
+		changed = changed || beforeChanged || afterChanged
+	}
+
+	return changed, nil
+}
+
+// resealValue reseals a single answer to field. Answers to fields that are not
+// sensitive are left alone, whatever they look like.
+func (s *service) resealValue(f *model.Form, field string, value any) (any, bool, error) {
+	if value == nil || !f.IsSensitiveField(field) {
+		return value, false, nil
+	}
+
+	encrypted, err := s.encryption.EncryptField(f, field, value)
+	if err != nil {
+		return nil, false, fmt.Errorf("encrypt: %w", err)
+	}
+
+	_, wasEnvelope := encryption.ParseEnvelope(value)
+	_, isEnvelope := encryption.ParseEnvelope(encrypted)
+
+	rotated, changed, err := s.encryption.Rotate(encrypted)
+	if err != nil {
+		return nil, false, fmt.Errorf("rotate: %w", err)
+	}
+
+	return rotated, changed || wasEnvelope != isEnvelope, nil
+}
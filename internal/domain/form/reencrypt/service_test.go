@@ -0,0 +1,115 @@
+package reencrypt_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/goformx/goforms/internal/domain/form/draft"
+	"github.com/goformx/goforms/internal/domain/form/encryption"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/domain/form/reencrypt"
+	"github.com/goformx/goforms/internal/domain/form/revision"
+	mockencryption "github.com/goformx/goforms/test/mocks/encryption"
+	mockreencrypt "github.com/goformx/goforms/test/mocks/reencrypt"
+)
+
+func sensitiveForm() *model.Form {
+	return &model.Form{ID: "form-1", Schema: model.JSON{
+		"components": []any{
+			map[string]any{"type": "textfield", "key": "name", "input": true},
+			map[string]any{"type": "textfield", "key": "ssn", "input": true, "sensitive": true},
+		},
+	}}
+}
+
+func envelope(keyID string) map[string]any {
+	return (&encryption.Envelope{
+		Version:    encryption.EnvelopeVersion,
+		KeyID:      keyID,
+		Algorithm:  "aes-256-gcm",
+		DataKey:    "dek-" + keyID,
+		Ciphertext: "ct",
+	}).Value()
+}
+
+func TestService_Run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mockreencrypt.NewMockRepository(ctrl)
+	cipher := mockencryption.NewMockCipher(ctrl)
+
+	cipher.EXPECT().ActiveKeyID().Return("k2").AnyTimes()
+	cipher.EXPECT().Seal(gomock.Any()).Return(&encryption.Envelope{
+		Version: encryption.EnvelopeVersion, KeyID: "k2", Algorithm: "aes-256-gcm", DataKey: "dek-k2", Ciphertext: "ct",
+	}, nil).AnyTimes()
+	cipher.EXPECT().Rewrap(gomock.Any()).DoAndReturn(func(env *encryption.Envelope) (*encryption.Envelope, error) {
+		rewrapped := *env
+		rewrapped.KeyID = "k2"
+
+		return &rewrapped, nil
+	}).AnyTimes()
+
+	form := sensitiveForm()
+	current := &model.FormSubmission{ID: "current", Data: model.JSON{"name": "Ada", "ssn": envelope("k2")}}
+	retired := &model.FormSubmission{ID: "retired", Data: model.JSON{"name": "Ada", "ssn": envelope("k1")}}
+	plain := &model.FormSubmission{ID: "plain", Data: model.JSON{"name": "Ada", "ssn": "123-45-6789"}}
+
+	repo.EXPECT().ListForms(gomock.Any()).Return([]*model.Form{form}, nil)
+	repo.EXPECT().ScanSubmissions(gomock.Any(), "form-1", gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, fn func([]*model.FormSubmission) error) error {
+			return fn([]*model.FormSubmission{current, retired, plain})
+		})
+	repo.EXPECT().UpdateSubmissionData(gomock.Any(), "retired", gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, data model.JSON) error {
+			env, ok := encryption.ParseEnvelope(data["ssn"])
+			require.True(t, ok)
+			assert.Equal(t, "k2", env.KeyID)
+
+			return nil
+		})
+	repo.EXPECT().UpdateSubmissionData(gomock.Any(), "plain", gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, data model.JSON) error {
+			_, ok := encryption.ParseEnvelope(data["ssn"])
+			assert.True(t, ok)
+			assert.Equal(t, "Ada", data["name"])
+
+			return nil
+		})
+	repo.EXPECT().ScanRevisions(gomock.Any(), "form-1", gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, fn func([]*revision.Revision) error) error {
+			return fn([]*revision.Revision{{
+				ID:      "rev-1",
+				Data:    model.JSON{"ssn": envelope("k2")},
+				Changes: []revision.Change{{Field: "ssn", Before: "123-45-6789", After: envelope("k2")}},
+			}})
+		})
+	repo.EXPECT().UpdateRevisionData(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, r *revision.Revision) error {
+			_, ok := encryption.ParseEnvelope(r.Changes[0].Before)
+			assert.True(t, ok)
+
+			return nil
+		})
+	repo.EXPECT().ScanDrafts(gomock.Any(), "form-1", gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, fn func([]*draft.Draft) error) error {
+			return fn([]*draft.Draft{{ID: "draft-1", Data: model.JSON{"name": "Ada"}}})
+		})
+
+	svc := reencrypt.NewService(repo, encryption.NewService(cipher))
+
+	report, err := svc.Run(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, &reencrypt.Report{FormsChecked: 1, Submissions: 2, Revisions: 1}, report)
+}
+
+func TestService_Run_notConfigured(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	svc := reencrypt.NewService(mockreencrypt.NewMockRepository(ctrl), encryption.NewService(nil))
+
+	report, err := svc.Run(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, &reencrypt.Report{}, report)
+}
@@ -18,10 +18,14 @@ type Repository interface {
 	// Form operations
 	CreateForm(ctx context.Context, form *model.Form) error
 	GetFormByID(ctx context.Context, id string) (*model.Form, error)
+	// GetFormByIDUnscoped retrieves a form by ID, including a soft-deleted one
+	GetFormByIDUnscoped(ctx context.Context, id string) (*model.Form, error)
 	ListForms(ctx context.Context, userID string) ([]*model.Form, error)
 	UpdateForm(ctx context.Context, form *model.Form) error
 	DeleteForm(ctx context.Context, id string) error
 	GetFormsByStatus(ctx context.Context, status string) ([]*model.Form, error)
+	// ListSchemaVersions returns the schemas a form has had, oldest first
+	ListSchemaVersions(ctx context.Context, formID string) ([]*model.SchemaVersion, error)
 
 	// Form submission operations
 	CreateSubmission(ctx context.Context, submission *model.FormSubmission) error
@@ -37,4 +41,7 @@ type Repository interface {
 	) (*common.PaginationResult, error)
 	GetByFormAndUser(ctx context.Context, formID, userID string) (*model.FormSubmission, error)
 	GetSubmissionsByStatus(ctx context.Context, status model.SubmissionStatus) ([]*model.FormSubmission, error)
+	// CountSubmissions returns the number of submissions of each form, keyed by
+	// form ID. Forms without submissions are left out.
+	CountSubmissions(ctx context.Context, formIDs []string) (map[string]int64, error)
 }
@@ -0,0 +1,37 @@
+//go:generate mockgen -typed -source=repository.go -destination=../../../../test/mocks/retention/mock_repository.go -package=retention
+
+package retention
+
+import (
+	"context"
+	"time"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+// Repository provides the bulk submission operations behind retention rules and
+// erasure requests. Every delete is permanent.
+type Repository interface {
+	// ListFormsWithRetention returns the forms that have a retention period set
+	ListFormsWithRetention(ctx context.Context) ([]*model.Form, error)
+	// DeleteSubmittedBefore deletes a form's submissions made before cutoff
+	DeleteSubmittedBefore(ctx context.Context, formID string, cutoff time.Time) (int64, error)
+	// AnonymizeSubmittedBefore clears the answers and edit history of a form's
+	// submissions made before cutoff that are not anonymized yet
+	AnonymizeSubmittedBefore(ctx context.Context, formID string, cutoff, now time.Time) (int64, error)
+	// PurgeDeletedSubmissions deletes soft-deleted submissions
+	PurgeDeletedSubmissions(ctx context.Context) (int64, error)
+	// PurgeDeletedForms deletes forms soft-deleted before deletedBefore together
+	// with their submissions and drafts, and returns the forms that were removed
+	PurgeDeletedForms(ctx context.Context, deletedBefore time.Time) ([]*model.Form, error)
+	// ListUserForms returns a user's forms, including soft-deleted ones
+	ListUserForms(ctx context.Context, userID string) ([]*model.Form, error)
+	// ScanSubmissions calls fn with successive batches of a form's submissions
+	ScanSubmissions(ctx context.Context, formID string, fn func([]*model.FormSubmission) error) error
+	// DeleteSubmissions deletes submissions by ID
+	DeleteSubmissions(ctx context.Context, ids []string) (int64, error)
+	// RunExclusive runs fn while holding a database-wide lock, so that only one
+	// instance enforces retention at a time. It reports false without running fn
+	// when another instance holds the lock.
+	RunExclusive(ctx context.Context, fn func(ctx context.Context) error) (bool, error)
+}
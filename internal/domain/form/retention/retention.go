@@ -0,0 +1,160 @@
+// Package retention enforces per-form submission retention rules and erases a
+// submitter's data on request.
+package retention
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	domainerrors "github.com/goformx/goforms/internal/domain/common/errors"
+)
+
+const (
+	// minPhoneDigits and maxPhoneDigits bound the digits of a phone identifier
+	minPhoneDigits = 7
+	maxPhoneDigits = 15
+)
+
+// ErrInvalidIdentifier is returned when an erasure identifier is neither an email
+// address nor a phone number
+var ErrInvalidIdentifier = domainerrors.New(
+	domainerrors.ErrCodeValidation, "identifier must be an email address or a phone number", nil,
+)
+
+// Report summarizes one run of the retention rules
+type Report struct {
+	FormsChecked      int   `json:"forms_checked"`
+	Deleted           int64 `json:"deleted"`
+	Anonymized        int64 `json:"anonymized"`
+	PurgedSubmissions int64 `json:"purged_submissions"`
+	PurgedForms       int64 `json:"purged_forms"`
+	// Skipped is set when another instance was enforcing retention at the time
+	Skipped bool `json:"skipped"`
+}
+
+// Match is a submission whose answers contain a submitter's identifier
+type Match struct {
+	SubmissionID string    `json:"submission_id"`
+	FormID       string    `json:"form_id"`
+	FormTitle    string    `json:"form_title"`
+	FormDeleted  bool      `json:"form_deleted"`
+	SubmittedAt  time.Time `json:"submitted_at"`
+	// Fields are the keys of the answers that contain the identifier
+	Fields []string `json:"fields"`
+}
+
+// Identifier is a submitter's email address or phone number in normalized form
+type Identifier struct {
+	value string
+	phone bool
+}
+
+// ParseIdentifier normalizes an email address or phone number. Emails compare
+// case-insensitively; phone numbers compare by their digits.
+func ParseIdentifier(raw string) (Identifier, error) {
+	raw = strings.TrimSpace(raw)
+
+	if strings.Contains(raw, "@") {
+		local, domain, ok := strings.Cut(strings.ToLower(raw), "@")
+		if !ok || local == "" || !strings.Contains(domain, ".") || strings.ContainsAny(raw, " \t") {
+			return Identifier{}, ErrInvalidIdentifier
+		}
+
+		return Identifier{value: local + "@" + domain}, nil
+	}
+
+	digits := phoneDigits(raw)
+	if len(digits) < minPhoneDigits || len(digits) > maxPhoneDigits || !isPhoneLike(raw) {
+		return Identifier{}, ErrInvalidIdentifier
+	}
+
+	return Identifier{value: digits, phone: true}, nil
+}
+
+// String returns the normalized identifier
+func (id Identifier) String() string {
+	return id.value
+}
+
+// Hash returns a short fingerprint of the identifier. Audit records keep the
+// fingerprint instead of the identifier so that an erasure doesn't leave the
+// erased data behind in the audit log.
+func (id Identifier) Hash() string {
+	sum := sha256.Sum256([]byte(id.value))
+
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+// Matches reports whether a single answer is the identifier. A phone number
+// also matches when one side carries a country code the other lacks.
+func (id Identifier) Matches(value string) bool {
+	if !id.phone {
+		return strings.EqualFold(strings.TrimSpace(value), id.value)
+	}
+
+	digits := phoneDigits(value)
+	if len(digits) < minPhoneDigits || !isPhoneLike(value) {
+		return false
+	}
+
+	return strings.HasSuffix(digits, id.value) || strings.HasSuffix(id.value, digits)
+}
+
+// MatchFields returns the keys of the answers in data that contain the
+// identifier, searching nested answers such as data grids
+func (id Identifier) MatchFields(data map[string]any) []string {
+	var fields []string
+
+	for key, value := range data {
+		if id.matchesValue(value) {
+			fields = append(fields, key)
+		}
+	}
+
+	slices.Sort(fields)
+
+	return fields
+}
+
+// matchesValue reports whether value or any value nested in it is the identifier
+func (id Identifier) matchesValue(value any) bool {
+	switch v := value.(type) {
+	case string:
+		return id.Matches(v)
+	case float64:
+		return id.phone && id.Matches(fmt.Sprintf("%.0f", v))
+	case []any:
+		return slices.ContainsFunc(v, id.matchesValue)
+	case map[string]any:
+		for _, nested := range v {
+			if id.matchesValue(nested) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// isPhoneLike reports whether value holds only the characters used to write a
+// phone number, so that digits in free text such as dates are not compared
+func isPhoneLike(value string) bool {
+	return strings.Trim(value, "+0123456789 -.()") == ""
+}
+
+// phoneDigits returns the digits of a phone number
+func phoneDigits(value string) string {
+	var b strings.Builder
+
+	for _, r := range value {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,80 @@
+package retention_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainerrors "github.com/goformx/goforms/internal/domain/common/errors"
+	"github.com/goformx/goforms/internal/domain/form/retention"
+)
+
+func TestParseIdentifier(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want string
+	}{
+		{"  Ada@Example.COM ", "ada@example.com"},
+		{"+44 (20) 7946-0958", "442079460958"},
+		{"555.123.4567", "5551234567"},
+	}
+
+	for _, tt := range tests {
+		id, err := retention.ParseIdentifier(tt.raw)
+		require.NoError(t, err, tt.raw)
+		assert.Equal(t, tt.want, id.String(), tt.raw)
+	}
+}
+
+func TestParseIdentifier_invalid(t *testing.T) {
+	invalid := []string{"", "ada", "@example.com", "ada@localhost", "ada @example.com", "12345", "call 5551234567"}
+
+	for _, raw := range invalid {
+		_, err := retention.ParseIdentifier(raw)
+		require.ErrorIs(t, err, retention.ErrInvalidIdentifier, raw)
+		assert.True(t, domainerrors.IsValidation(err), raw)
+	}
+}
+
+func TestIdentifier_Hash(t *testing.T) {
+	a, err := retention.ParseIdentifier("Ada@example.com")
+	require.NoError(t, err)
+
+	b, err := retention.ParseIdentifier("ada@EXAMPLE.com")
+	require.NoError(t, err)
+
+	assert.Len(t, a.Hash(), 16)
+	assert.Equal(t, a.Hash(), b.Hash())
+	assert.NotContains(t, a.Hash(), "ada")
+}
+
+func TestIdentifier_MatchFields_email(t *testing.T) {
+	id, err := retention.ParseIdentifier("ada@example.com")
+	require.NoError(t, err)
+
+	fields := id.MatchFields(map[string]any{
+		"email":   "ADA@example.com",
+		"name":    "Ada",
+		"contact": map[string]any{"work": " ada@example.com"},
+		"guests":  []any{map[string]any{"email": "grace@example.com"}},
+		"notes":   "write to ada@example.com.au",
+	})
+
+	assert.Equal(t, []string{"contact", "email"}, fields)
+}
+
+func TestIdentifier_MatchFields_phone(t *testing.T) {
+	id, err := retention.ParseIdentifier("+1 555 123 4567")
+	require.NoError(t, err)
+
+	fields := id.MatchFields(map[string]any{
+		"phone":    "(555) 123-4567",
+		"mobile":   float64(15551234567),
+		"fax":      "555 123 4568",
+		"born":     "2001-05-04",
+		"comments": "ring 555 123 4567 after six",
+	})
+
+	assert.Equal(t, []string{"mobile", "phone"}, fields)
+}
@@ -0,0 +1,295 @@
+//go:generate mockgen -typed -source=service.go -destination=../../../../test/mocks/retention/mock_service.go -package=retention -mock_names=Service=MockService
+
+package retention
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/goformx/goforms/internal/domain/audit"
+	"github.com/goformx/goforms/internal/domain/form/encryption"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+// DefaultPurgeAfter is how long soft-deleted forms are kept, and can be
+// restored, before they are purged when no grace period is configured
+const DefaultPurgeAfter = 30 * 24 * time.Hour
+
+// Service defines the interface for retention rules and erasure requests
+type Service interface {
+	// Enforce applies every form's retention rule and purges soft-deleted
+	// submissions and the forms deleted longer ago than the grace period. Only
+	// one instance enforces retention at a time; the others skip the run.
+	Enforce(ctx context.Context) (*Report, error)
+	// FindSubmitter returns the submissions to a user's forms, including deleted
+	// forms, whose answers contain the identifier. Encrypted answers are
+	// decrypted in memory to be searched.
+	FindSubmitter(ctx context.Context, userID, identifier string) ([]*Match, error)
+	// EraseSubmitter permanently deletes the submissions FindSubmitter returns,
+	// or only those listed in submissionIDs when it is not empty, and records
+	// each deletion in the audit log. It returns the erased submissions.
+	EraseSubmitter(ctx context.Context, userID, identifier string, submissionIDs []string) ([]*Match, error)
+}
+
+// service implements Service
+type service struct {
+	repository Repository
+	audit      audit.Service
+	encryption encryption.Service
+	logger     logging.Logger
+	purgeAfter time.Duration
+	now        func() time.Time
+}
+
+// NewService creates a new retention service that purges soft-deleted forms
+// once they have been deleted for purgeAfter. A purgeAfter of zero or less uses
+// DefaultPurgeAfter.
+func NewService(
+	repository Repository,
+	auditService audit.Service,
+	encryptionService encryption.Service,
+	logger logging.Logger,
+	purgeAfter time.Duration,
+) Service {
+	if purgeAfter <= 0 {
+		purgeAfter = DefaultPurgeAfter
+	}
+
+	return &service{
+		repository: repository,
+		audit:      auditService,
+		encryption: encryptionService,
+		logger:     logger,
+		purgeAfter: purgeAfter,
+		now:        time.Now,
+	}
+}
+
+// Enforce applies the retention rules unless another instance is doing so
+func (s *service) Enforce(ctx context.Context) (*Report, error) {
+	report := &Report{}
+
+	ran, err := s.repository.RunExclusive(ctx, func(ctx context.Context) error {
+		return s.enforce(ctx, report)
+	})
+	if err != nil {
+		return report, fmt.Errorf("enforce retention: %w", err)
+	}
+
+	if !ran {
+		s.logger.Info("retention run skipped, another instance is enforcing retention")
+
+		report.Skipped = true
+	}
+
+	return report, nil
+}
+
+// enforce applies the retention rules, filling in report as it goes
+func (s *service) enforce(ctx context.Context, report *Report) error {
+	now := s.now().UTC()
+
+	forms, err := s.repository.ListFormsWithRetention(ctx)
+	if err != nil {
+		return fmt.Errorf("list forms with retention: %w", err)
+	}
+
+	for _, f := range forms {
+		if applyErr := s.applyRule(ctx, f, now, report); applyErr != nil {
+			return applyErr
+		}
+	}
+
+	report.FormsChecked = len(forms)
+
+	if report.PurgedSubmissions, err = s.repository.PurgeDeletedSubmissions(ctx); err != nil {
+		return fmt.Errorf("purge deleted submissions: %w", err)
+	}
+
+	purged, err := s.repository.PurgeDeletedForms(ctx, now.Add(-s.purgeAfter))
+	if err != nil {
+		return fmt.Errorf("purge deleted forms: %w", err)
+	}
+
+	report.PurgedForms = int64(len(purged))
+
+	for _, f := range purged {
+		s.record(ctx, &audit.Record{
+			Action:     audit.ActionFormPurged,
+			TargetType: audit.TargetForm,
+			TargetID:   f.ID,
+			OwnerID:    f.UserID,
+			Before:     model.JSON{"title": f.Title},
+		})
+	}
+
+	return nil
+}
+
+// applyRule deletes or anonymizes a form's submissions that are past its
+// retention period
+func (s *service) applyRule(ctx context.Context, f *model.Form, now time.Time, report *Report) error {
+	cutoff, ok := f.RetentionCutoff(now)
+	if !ok {
+		return nil
+	}
+
+	var (
+		count int64
+		err   error
+	)
+
+	if f.RetentionAction == model.RetentionActionAnonymize {
+		count, err = s.repository.AnonymizeSubmittedBefore(ctx, f.ID, cutoff, now)
+		report.Anonymized += count
+	} else {
+		count, err = s.repository.DeleteSubmittedBefore(ctx, f.ID, cutoff)
+		report.Deleted += count
+	}
+
+	if err != nil {
+		return fmt.Errorf("apply retention to form %s: %w", f.ID, err)
+	}
+
+	if count > 0 {
+		s.record(ctx, &audit.Record{
+			Action:     audit.ActionRetentionApplied,
+			TargetType: audit.TargetForm,
+			TargetID:   f.ID,
+			OwnerID:    f.UserID,
+			After: model.JSON{
+				"action":         f.RetentionAction,
+				"retention_days": f.RetentionDays,
+				"cutoff":         cutoff.Format(time.RFC3339),
+				"submissions":    count,
+			},
+		})
+	}
+
+	return nil
+}
+
+// FindSubmitter searches a user's submissions for an identifier
+func (s *service) FindSubmitter(ctx context.Context, userID, identifier string) ([]*Match, error) {
+	id, err := ParseIdentifier(identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.find(ctx, userID, id)
+}
+
+// find returns the submissions to userID's forms that contain id
+func (s *service) find(ctx context.Context, userID string, id Identifier) ([]*Match, error) {
+	forms, err := s.repository.ListUserForms(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list user forms: %w", err)
+	}
+
+	var matches []*Match
+
+	for _, f := range forms {
+		scanErr := s.repository.ScanSubmissions(ctx, f.ID, func(batch []*model.FormSubmission) error {
+			for _, sub := range batch {
+				if fields := id.MatchFields(s.searchable(f, sub)); len(fields) > 0 {
+					matches = append(matches, &Match{
+						SubmissionID: sub.ID,
+						FormID:       f.ID,
+						FormTitle:    f.Title,
+						FormDeleted:  f.DeletedAt.Valid,
+						SubmittedAt:  sub.SubmittedAt,
+						Fields:       fields,
+					})
+				}
+			}
+
+			return nil
+		})
+		if scanErr != nil {
+			return nil, fmt.Errorf("search submissions of form %s: %w", f.ID, scanErr)
+		}
+	}
+
+	return matches, nil
+}
+
+// searchable returns a submission's answers with the encrypted ones decrypted.
+// The decrypted answers are only kept in memory for the search. An answer that
+// cannot be decrypted is left out and logged, so the submission can be found
+// by its other answers and the operator knows to check it.
+func (s *service) searchable(f *model.Form, sub *model.FormSubmission) model.JSON {
+	data, err := s.encryption.Decrypt(f, sub.Data)
+	if err != nil {
+		s.logger.Warn("erasure search could not decrypt submission answers",
+			"form_id", f.ID, "submission_id", sub.ID, "error", err)
+	}
+
+	return data
+}
+
+// EraseSubmitter deletes a submitter's submissions
+func (s *service) EraseSubmitter(
+	ctx context.Context,
+	userID, identifier string,
+	submissionIDs []string,
+) ([]*Match, error) {
+	id, err := ParseIdentifier(identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	// Search again rather than trusting the IDs, so only submissions that
+	// belong to the user and contain the identifier can be erased
+	matches, err := s.find(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(submissionIDs) > 0 {
+		matches = slices.DeleteFunc(matches, func(m *Match) bool {
+			return !slices.Contains(submissionIDs, m.SubmissionID)
+		})
+	}
+
+	if len(matches) == 0 {
+		return matches, nil
+	}
+
+	ids := make([]string, len(matches))
+	for i, m := range matches {
+		ids[i] = m.SubmissionID
+	}
+
+	if _, err = s.repository.DeleteSubmissions(ctx, ids); err != nil {
+		return nil, fmt.Errorf("erase submissions: %w", err)
+	}
+
+	for _, m := range matches {
+		s.record(ctx, &audit.Record{
+			Action:     audit.ActionSubmissionErased,
+			TargetType: audit.TargetSubmission,
+			TargetID:   m.SubmissionID,
+			OwnerID:    userID,
+			Before: model.JSON{
+				"form_id":         m.FormID,
+				"fields":          m.Fields,
+				"submitted_at":    m.SubmittedAt.Format(time.RFC3339),
+				"identifier_hash": id.Hash(),
+			},
+		})
+	}
+
+	return matches, nil
+}
+
+// record appends an audit record. The deletion it describes has already
+// happened, so a failure is logged rather than returned.
+func (s *service) record(ctx context.Context, record *audit.Record) {
+	if err := s.audit.Record(ctx, record); err != nil {
+		s.logger.Error("failed to record retention audit event",
+			"action", record.Action, "target_id", record.TargetID, "error", err)
+	}
+}
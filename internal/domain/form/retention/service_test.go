@@ -0,0 +1,265 @@
+package retention_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+
+	"github.com/goformx/goforms/internal/domain/audit"
+	"github.com/goformx/goforms/internal/domain/form/encryption"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/domain/form/retention"
+	mockaudit "github.com/goformx/goforms/test/mocks/audit"
+	mockencryption "github.com/goformx/goforms/test/mocks/encryption"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+	mockretention "github.com/goformx/goforms/test/mocks/retention"
+)
+
+func retentionForm(id string, days int, action string) *model.Form {
+	return &model.Form{ID: id, UserID: "owner", Title: "Survey " + id, RetentionDays: days, RetentionAction: action}
+}
+
+func TestService_Enforce(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mockretention.NewMockRepository(ctrl)
+	auditService := mockaudit.NewMockService(ctrl)
+	svc := retention.NewService(
+		repo, auditService, encryption.NewService(mockencryption.NewMockCipher(ctrl)), mocklogging.NewMockLogger(ctrl),
+		48*time.Hour,
+	)
+
+	var records []*audit.Record
+
+	auditService.EXPECT().Record(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, r *audit.Record) error {
+			records = append(records, r)
+
+			return nil
+		}).Times(2)
+
+	repo.EXPECT().RunExclusive(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context) error) (bool, error) {
+			return true, fn(ctx)
+		})
+	repo.EXPECT().ListFormsWithRetention(gomock.Any()).Return([]*model.Form{
+		retentionForm("f1", 30, model.RetentionActionDelete),
+		retentionForm("f2", 7, model.RetentionActionAnonymize),
+	}, nil)
+
+	repo.EXPECT().DeleteSubmittedBefore(gomock.Any(), "f1", gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, cutoff time.Time) (int64, error) {
+			assert.WithinDuration(t, time.Now().AddDate(0, 0, -30), cutoff, time.Minute)
+
+			return 3, nil
+		})
+	repo.EXPECT().AnonymizeSubmittedBefore(gomock.Any(), "f2", gomock.Any(), gomock.Any()).Return(int64(0), nil)
+	repo.EXPECT().PurgeDeletedSubmissions(gomock.Any()).Return(int64(2), nil)
+	repo.EXPECT().PurgeDeletedForms(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, deletedBefore time.Time) ([]*model.Form, error) {
+			// Forms stay restorable for the grace period
+			assert.WithinDuration(t, time.Now().Add(-48*time.Hour), deletedBefore, time.Minute)
+
+			return []*model.Form{retentionForm("f3", 0, "")}, nil
+		})
+
+	report, err := svc.Enforce(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, &retention.Report{
+		FormsChecked:      2,
+		Deleted:           3,
+		PurgedSubmissions: 2,
+		PurgedForms:       1,
+	}, report)
+
+	require.Len(t, records, 2)
+	assert.Equal(t, audit.ActionRetentionApplied, records[0].Action)
+	assert.Equal(t, "f1", records[0].TargetID)
+	assert.EqualValues(t, 3, records[0].After["submissions"])
+	assert.Equal(t, audit.ActionFormPurged, records[1].Action)
+	assert.Equal(t, "f3", records[1].TargetID)
+}
+
+func TestService_Enforce_stopsOnError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mockretention.NewMockRepository(ctrl)
+	svc := retention.NewService(
+		repo, mockaudit.NewMockService(ctrl), encryption.NewService(mockencryption.NewMockCipher(ctrl)),
+		mocklogging.NewMockLogger(ctrl), 0,
+	)
+	boom := errors.New("boom")
+
+	repo.EXPECT().RunExclusive(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context) error) (bool, error) {
+			return true, fn(ctx)
+		})
+	repo.EXPECT().ListFormsWithRetention(gomock.Any()).Return([]*model.Form{
+		retentionForm("f1", 30, model.RetentionActionDelete),
+	}, nil)
+	repo.EXPECT().DeleteSubmittedBefore(gomock.Any(), "f1", gomock.Any()).Return(int64(0), boom)
+
+	_, err := svc.Enforce(t.Context())
+	require.ErrorIs(t, err, boom)
+}
+
+func TestService_Enforce_skipsWhileAnotherInstanceRuns(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mockretention.NewMockRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	svc := retention.NewService(
+		repo, mockaudit.NewMockService(ctrl), encryption.NewService(mockencryption.NewMockCipher(ctrl)), logger, 0,
+	)
+
+	repo.EXPECT().RunExclusive(gomock.Any(), gomock.Any()).Return(false, nil)
+	logger.EXPECT().Info("retention run skipped, another instance is enforcing retention")
+
+	report, err := svc.Enforce(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, &retention.Report{Skipped: true}, report)
+}
+
+func expectSubmissions(repo *mockretention.MockRepository) {
+	deleted := retentionForm("f2", 0, "")
+	deleted.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+
+	repo.EXPECT().ListUserForms(gomock.Any(), "owner").Return([]*model.Form{
+		retentionForm("f1", 0, ""), deleted,
+	}, nil)
+
+	repo.EXPECT().ScanSubmissions(gomock.Any(), "f1", gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, fn func([]*model.FormSubmission) error) error {
+			return fn([]*model.FormSubmission{
+				{ID: "s1", FormID: "f1", Data: model.JSON{"email": "Ada@example.com", "name": "Ada"}},
+				{ID: "s2", FormID: "f1", Data: model.JSON{"email": "grace@example.com"}},
+			})
+		})
+	repo.EXPECT().ScanSubmissions(gomock.Any(), "f2", gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, fn func([]*model.FormSubmission) error) error {
+			return fn([]*model.FormSubmission{
+				{ID: "s3", FormID: "f2", Data: model.JSON{"contact": "ada@example.com"}},
+			})
+		})
+}
+
+func TestService_FindSubmitter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mockretention.NewMockRepository(ctrl)
+	svc := retention.NewService(
+		repo, mockaudit.NewMockService(ctrl), encryption.NewService(mockencryption.NewMockCipher(ctrl)),
+		mocklogging.NewMockLogger(ctrl), 0,
+	)
+	expectSubmissions(repo)
+
+	matches, err := svc.FindSubmitter(t.Context(), "owner", "ada@example.com")
+	require.NoError(t, err)
+	require.Len(t, matches, 2)
+	assert.Equal(t, "s1", matches[0].SubmissionID)
+	assert.Equal(t, []string{"email"}, matches[0].Fields)
+	assert.False(t, matches[0].FormDeleted)
+	assert.Equal(t, "s3", matches[1].SubmissionID)
+	assert.True(t, matches[1].FormDeleted)
+}
+
+func TestService_FindSubmitter_invalidIdentifier(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	svc := retention.NewService(
+		mockretention.NewMockRepository(ctrl), mockaudit.NewMockService(ctrl),
+		encryption.NewService(mockencryption.NewMockCipher(ctrl)), mocklogging.NewMockLogger(ctrl), 0,
+	)
+
+	_, err := svc.FindSubmitter(t.Context(), "owner", "ada")
+	require.ErrorIs(t, err, retention.ErrInvalidIdentifier)
+}
+
+func TestService_EraseSubmitter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mockretention.NewMockRepository(ctrl)
+	auditService := mockaudit.NewMockService(ctrl)
+	svc := retention.NewService(
+		repo, auditService, encryption.NewService(mockencryption.NewMockCipher(ctrl)), mocklogging.NewMockLogger(ctrl), 0,
+	)
+
+	var records []*audit.Record
+
+	auditService.EXPECT().Record(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, r *audit.Record) error {
+			records = append(records, r)
+
+			return nil
+		}).Times(1)
+	expectSubmissions(repo)
+
+	// s2 doesn't contain the identifier and is never erased
+	repo.EXPECT().DeleteSubmissions(gomock.Any(), []string{"s3"}).Return(int64(1), nil)
+
+	matches, err := svc.EraseSubmitter(t.Context(), "owner", "ada@example.com", []string{"s2", "s3"})
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	assert.Equal(t, "s3", matches[0].SubmissionID)
+
+	require.Len(t, records, 1)
+	assert.Equal(t, audit.ActionSubmissionErased, records[0].Action)
+	assert.Equal(t, "s3", records[0].TargetID)
+	assert.Equal(t, "owner", records[0].OwnerID)
+	assert.NotContains(t, records[0].Before, "ada@example.com")
+	assert.Len(t, records[0].Before["identifier_hash"], 16)
+}
+
+func TestService_EraseSubmitter_noMatches(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mockretention.NewMockRepository(ctrl)
+	svc := retention.NewService(
+		repo, mockaudit.NewMockService(ctrl), encryption.NewService(mockencryption.NewMockCipher(ctrl)),
+		mocklogging.NewMockLogger(ctrl), 0,
+	)
+	repo.EXPECT().ListUserForms(gomock.Any(), "owner").Return(nil, nil)
+
+	matches, err := svc.EraseSubmitter(t.Context(), "owner", "ada@example.com", nil)
+	require.NoError(t, err)
+	assert.Empty(t, matches)
+}
+
+func TestService_FindSubmitter_sensitiveField(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mockretention.NewMockRepository(ctrl)
+	cipher := mockencryption.NewMockCipher(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	svc := retention.NewService(repo, mockaudit.NewMockService(ctrl), encryption.NewService(cipher), logger, 0)
+
+	form := retentionForm("f1", 0, "")
+	form.Schema = model.JSON{"components": []any{
+		map[string]any{"type": "email", "key": "email", "input": true, "sensitive": true},
+	}}
+	sealed := &encryption.Envelope{
+		Version: encryption.EnvelopeVersion, KeyID: "k1", Algorithm: "aes-256-gcm", DataKey: "dek", Ciphertext: "ct",
+	}
+	broken := &encryption.Envelope{
+		Version: encryption.EnvelopeVersion, KeyID: "k1", Algorithm: "aes-256-gcm", DataKey: "dek", Ciphertext: "bad",
+	}
+
+	repo.EXPECT().ListUserForms(gomock.Any(), "owner").Return([]*model.Form{form}, nil)
+	repo.EXPECT().ScanSubmissions(gomock.Any(), "f1", gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, fn func([]*model.FormSubmission) error) error {
+			return fn([]*model.FormSubmission{
+				{ID: "s1", FormID: "f1", Data: model.JSON{"email": sealed.Value()}},
+				{ID: "s2", FormID: "f1", Data: model.JSON{"email": broken.Value(), "backup": "ada@example.com"}},
+			})
+		})
+	cipher.EXPECT().Open(sealed).Return([]byte(`"Ada@example.com"`), nil)
+	cipher.EXPECT().Open(broken).Return(nil, errors.New("authentication failed"))
+	logger.EXPECT().Warn("erasure search could not decrypt submission answers",
+		"form_id", "f1", "submission_id", "s2", "error", gomock.Any())
+
+	matches, err := svc.FindSubmitter(t.Context(), "owner", "ada@example.com")
+	require.NoError(t, err)
+	require.Len(t, matches, 2)
+	assert.Equal(t, "s1", matches[0].SubmissionID)
+	assert.Equal(t, []string{"email"}, matches[0].Fields)
+	assert.Equal(t, "s2", matches[1].SubmissionID, "answers that decrypt are still searched")
+	assert.Equal(t, []string{"backup"}, matches[1].Fields)
+}
@@ -0,0 +1,21 @@
+//go:generate mockgen -typed -source=repository.go -destination=../../../../test/mocks/revision/mock_repository.go -package=revision
+
+package revision
+
+import (
+	"context"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+// Repository stores submission revisions. Revisions are append-only: there is
+// no way to change or remove one once it is stored, other than deleting its
+// submission.
+type Repository interface {
+	// ListBySubmission returns a submission's revisions, oldest first
+	ListBySubmission(ctx context.Context, submissionID string) ([]*Revision, error)
+	// Apply stores the revisions and saves the submission's data in one transaction
+	Apply(ctx context.Context, submission *model.FormSubmission, revisions ...*Revision) error
+	// Delete removes a submission and its revisions in one transaction
+	Delete(ctx context.Context, submissionID string) error
+}
@@ -0,0 +1,90 @@
+// Package revision keeps an immutable history of changes that form owners make
+// to submissions, so that no edit ever destroys what was originally submitted.
+package revision
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+// Revision actions
+const (
+	// ActionOriginal records the data as it was first submitted
+	ActionOriginal = "original"
+	// ActionEdit records an owner's edit
+	ActionEdit = "edit"
+	// ActionRestore records that an earlier revision was restored
+	ActionRestore = "restore"
+)
+
+// Revision is one immutable entry in a submission's history. Data holds the
+// complete submission data as of the revision, so any revision can be restored.
+type Revision struct {
+	ID           string     `json:"id" gorm:"column:uuid;primaryKey"`
+	SubmissionID string     `json:"submission_id" gorm:"not null;index"`
+	Number       int        `json:"number" gorm:"not null"`
+	Action       string     `json:"action" gorm:"not null;size:20"`
+	EditorID     string     `json:"editor_id" gorm:"size:36"`
+	RestoredFrom int        `json:"restored_from,omitempty"`
+	Changes      []Change   `json:"changes" gorm:"serializer:json"`
+	Data         model.JSON `json:"data" gorm:"type:json;not null"`
+	CreatedAt    time.Time  `json:"created_at" gorm:"not null"`
+}
+
+// TableName specifies the table name for the Revision model
+func (r *Revision) TableName() string {
+	return "form_submission_revisions"
+}
+
+// Change describes how one top-level field changed. A nil Before means the field
+// was added; a nil After means it was removed.
+type Change struct {
+	Field  string `json:"field"`
+	Before any    `json:"before"`
+	After  any    `json:"after"`
+}
+
+// Fields returns the names of the changed fields
+func (r *Revision) Fields() []string {
+	fields := make([]string, 0, len(r.Changes))
+	for _, c := range r.Changes {
+		fields = append(fields, c.Field)
+	}
+
+	return fields
+}
+
+// Diff lists the top-level fields that differ between before and after, sorted
+// by field name
+func Diff(before, after model.JSON) []Change {
+	changes := []Change{}
+
+	for field, old := range before {
+		value, ok := after[field]
+		if !ok {
+			changes = append(changes, Change{Field: field, Before: old})
+
+			continue
+		}
+
+		if !reflect.DeepEqual(old, value) {
+			changes = append(changes, Change{Field: field, Before: old, After: value})
+		}
+	}
+
+	for field, value := range after {
+		if _, ok := before[field]; !ok {
+			changes = append(changes, Change{Field: field, After: value})
+		}
+	}
+
+	slices.SortFunc(changes, func(a, b Change) int {
+		return strings.Compare(a.Field, b.Field)
+	})
+
+	return changes
+}
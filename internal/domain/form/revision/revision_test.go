@@ -0,0 +1,41 @@
+package revision_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/domain/form/revision"
+)
+
+func TestDiff(t *testing.T) {
+	before := model.JSON{
+		"name":   "Ada",
+		"email":  "ada@example.com",
+		"tags":   []any{"a", "b"},
+		"legacy": true,
+	}
+	after := model.JSON{
+		"name":  "Ada",
+		"email": "ada@lovelace.dev",
+		"tags":  []any{"a", "b"},
+		"phone": "555-0100",
+	}
+
+	assert.Equal(t, []revision.Change{
+		{Field: "email", Before: "ada@example.com", After: "ada@lovelace.dev"},
+		{Field: "legacy", Before: true},
+		{Field: "phone", After: "555-0100"},
+	}, revision.Diff(before, after))
+}
+
+func TestDiff_nestedValues(t *testing.T) {
+	before := model.JSON{"address": map[string]any{"city": "London"}}
+	after := model.JSON{"address": map[string]any{"city": "Paris"}}
+
+	changes := revision.Diff(before, after)
+	assert.Len(t, changes, 1)
+	assert.Equal(t, "address", changes[0].Field)
+	assert.Empty(t, revision.Diff(before, before))
+}
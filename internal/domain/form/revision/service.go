@@ -0,0 +1,349 @@
+//go:generate mockgen -typed -source=service.go -destination=../../../../test/mocks/revision/mock_service.go -package=revision -mock_names=Service=MockService
+
+package revision
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	domainerrors "github.com/goformx/goforms/internal/domain/common/errors"
+	"github.com/goformx/goforms/internal/domain/common/events"
+	"github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/form/calculation"
+	"github.com/goformx/goforms/internal/domain/form/encryption"
+	formevents "github.com/goformx/goforms/internal/domain/form/events"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+)
+
+var (
+	// ErrSubmissionNotFound is returned when a submission does not exist or belongs to another form
+	ErrSubmissionNotFound = domainerrors.New(domainerrors.ErrCodeNotFound, "submission not found", nil)
+	// ErrRevisionNotFound is returned when a submission has no revision with the requested number
+	ErrRevisionNotFound = domainerrors.New(domainerrors.ErrCodeNotFound, "revision not found", nil)
+	// ErrNoChanges is returned when an edit or restore would leave the submission unchanged
+	ErrNoChanges = domainerrors.New(domainerrors.ErrCodeValidation, "submission data is unchanged", nil)
+	// ErrDataRequired is returned when an edit has no data
+	ErrDataRequired = domainerrors.New(domainerrors.ErrCodeValidation, "submission data is required", nil)
+)
+
+// Service defines the interface for editing submissions with a revision history
+type Service interface {
+	// GetSubmission returns a submission of the form
+	GetSubmission(ctx context.Context, formID, submissionID string) (*model.FormSubmission, error)
+	// ListRevisions returns a submission's history, oldest first. Submissions
+	// that were never edited have no history.
+	ListRevisions(ctx context.Context, formID, submissionID string) ([]*Revision, error)
+	// Edit replaces a submission's data on behalf of editorID and records the change
+	Edit(ctx context.Context, formID, submissionID, editorID string, data model.JSON) (*Revision, error)
+	// Restore sets a submission's data back to an earlier revision, recording
+	// the restore as a new revision
+	Restore(ctx context.Context, formID, submissionID, editorID string, number int) (*Revision, error)
+	// Delete removes a submission of the form together with its history and
+	// returns the deleted submission
+	Delete(ctx context.Context, formID, submissionID string) (*model.FormSubmission, error)
+}
+
+// service implements Service
+type service struct {
+	repository  Repository
+	formService form.Service
+	encryption  encryption.Service
+	eventBus    events.EventBus
+	logger      logging.Logger
+	now         func() time.Time
+}
+
+// NewService creates a new submission revision service
+func NewService(
+	repository Repository,
+	formService form.Service,
+	encryptionService encryption.Service,
+	eventBus events.EventBus,
+	logger logging.Logger,
+) Service {
+	return &service{
+		repository:  repository,
+		formService: formService,
+		encryption:  encryptionService,
+		eventBus:    eventBus,
+		logger:      logger,
+		now:         time.Now,
+	}
+}
+
+// GetSubmission returns a submission of the form
+func (s *service) GetSubmission(ctx context.Context, formID, submissionID string) (*model.FormSubmission, error) {
+	submission, err := s.formService.GetFormSubmission(ctx, submissionID)
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			return nil, ErrSubmissionNotFound
+		}
+
+		return nil, fmt.Errorf("get submission: %w", err)
+	}
+
+	if submission == nil || submission.FormID != formID {
+		return nil, ErrSubmissionNotFound
+	}
+
+	return submission, nil
+}
+
+// ListRevisions returns a submission's history
+func (s *service) ListRevisions(ctx context.Context, formID, submissionID string) ([]*Revision, error) {
+	_, _, revisions, err := s.load(ctx, formID, submissionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
+
+// Edit replaces a submission's data. The first edit also records the data as
+// originally submitted, so the history always starts from the original.
+func (s *service) Edit(
+	ctx context.Context,
+	formID, submissionID, editorID string,
+	data model.JSON,
+) (*Revision, error) {
+	if data == nil {
+		return nil, ErrDataRequired
+	}
+
+	if err := encryption.RejectEnvelopes(data); err != nil {
+		return nil, fmt.Errorf("check submission data: %w", err)
+	}
+
+	form, submission, history, err := s.load(ctx, formID, submissionID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Calculated fields are recomputed as on submission, so an edit cannot
+	// store values that do not follow from the other answers
+	data = calculation.Recalculate(form, data, s.now()).Data
+
+	var pending []*Revision
+	if len(history) == 0 {
+		original := s.newRevision(submission, ActionOriginal, "", 1, submission.Data)
+		original.CreatedAt = submission.SubmittedAt
+		pending = append(pending, original)
+		history = pending
+	}
+
+	rev := s.newRevision(submission, ActionEdit, editorID, nextNumber(history), data)
+	if len(rev.Changes) == 0 {
+		return nil, ErrNoChanges
+	}
+
+	return s.apply(ctx, form, submission, append(pending, rev))
+}
+
+// Restore sets a submission's data back to an earlier revision
+func (s *service) Restore(
+	ctx context.Context,
+	formID, submissionID, editorID string,
+	number int,
+) (*Revision, error) {
+	form, submission, history, err := s.load(ctx, formID, submissionID)
+	if err != nil {
+		return nil, err
+	}
+
+	var target *Revision
+
+	for _, r := range history {
+		if r.Number == number {
+			target = r
+
+			break
+		}
+	}
+
+	if target == nil {
+		return nil, ErrRevisionNotFound
+	}
+
+	rev := s.newRevision(submission, ActionRestore, editorID, nextNumber(history), target.Data)
+	rev.RestoredFrom = number
+
+	if len(rev.Changes) == 0 {
+		return nil, ErrNoChanges
+	}
+
+	return s.apply(ctx, form, submission, []*Revision{rev})
+}
+
+// Delete removes a submission of the form together with its history
+func (s *service) Delete(ctx context.Context, formID, submissionID string) (*model.FormSubmission, error) {
+	submission, err := s.GetSubmission(ctx, formID, submissionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if deleteErr := s.repository.Delete(ctx, submission.ID); deleteErr != nil {
+		if errors.Is(deleteErr, common.ErrNotFound) {
+			return nil, ErrSubmissionNotFound
+		}
+
+		return nil, fmt.Errorf("delete submission: %w", deleteErr)
+	}
+
+	return submission, nil
+}
+
+// load returns the form, a submission of the form and its history, with
+// sensitive answers decrypted
+func (s *service) load(
+	ctx context.Context,
+	formID, submissionID string,
+) (*model.Form, *model.FormSubmission, []*Revision, error) {
+	submission, err := s.GetSubmission(ctx, formID, submissionID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	form, err := s.formService.GetForm(ctx, formID)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("get form: %w", err)
+	}
+
+	history, err := s.repository.ListBySubmission(ctx, submissionID)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("list revisions: %w", err)
+	}
+
+	for _, r := range history {
+		if decryptErr := s.decrypt(form, r); decryptErr != nil {
+			return nil, nil, nil, decryptErr
+		}
+	}
+
+	return form, submission, history, nil
+}
+
+// decrypt decrypts the sensitive answers of a revision in place
+func (s *service) decrypt(form *model.Form, r *Revision) error {
+	data, err := s.encryption.Decrypt(form, r.Data)
+	if err != nil {
+		return fmt.Errorf("decrypt revision %d: %w", r.Number, err)
+	}
+
+	r.Data = data
+
+	for i, c := range r.Changes {
+		if r.Changes[i].Before, err = s.encryption.DecryptField(form, c.Field, c.Before); err != nil {
+			return fmt.Errorf("decrypt revision %d: %w", r.Number, err)
+		}
+
+		if r.Changes[i].After, err = s.encryption.DecryptField(form, c.Field, c.After); err != nil {
+			return fmt.Errorf("decrypt revision %d: %w", r.Number, err)
+		}
+	}
+
+	return nil
+}
+
+// encrypt returns a copy of a revision with the answers to the form's
+// sensitive fields encrypted
+func (s *service) encrypt(form *model.Form, r *Revision) (*Revision, error) {
+	encrypted := *r
+
+	data, err := s.encryption.Encrypt(form, r.Data)
+	if err != nil {
+		return nil, fmt.Errorf("encrypt revision %d: %w", r.Number, err)
+	}
+
+	encrypted.Data = data
+	encrypted.Changes = make([]Change, len(r.Changes))
+
+	for i, c := range r.Changes {
+		encrypted.Changes[i].Field = c.Field
+
+		if encrypted.Changes[i].Before, err = s.encryption.EncryptField(form, c.Field, c.Before); err != nil {
+			return nil, fmt.Errorf("encrypt revision %d: %w", r.Number, err)
+		}
+
+		if encrypted.Changes[i].After, err = s.encryption.EncryptField(form, c.Field, c.After); err != nil {
+			return nil, fmt.Errorf("encrypt revision %d: %w", r.Number, err)
+		}
+	}
+
+	return &encrypted, nil
+}
+
+// newRevision builds a revision that changes the submission's data to data
+func (s *service) newRevision(
+	submission *model.FormSubmission,
+	action, editorID string,
+	number int,
+	data model.JSON,
+) *Revision {
+	var changes []Change
+	if action != ActionOriginal {
+		changes = Diff(submission.Data, data)
+	}
+
+	return &Revision{
+		ID:           uuid.New().String(),
+		SubmissionID: submission.ID,
+		Number:       number,
+		Action:       action,
+		EditorID:     editorID,
+		Changes:      changes,
+		Data:         data,
+		CreatedAt:    s.now(),
+	}
+}
+
+// apply stores the revisions, the last of which holds the submission's new
+// data, and announces the change. Sensitive answers are encrypted in what is
+// stored and published; the returned revision keeps them in plain text.
+func (s *service) apply(
+	ctx context.Context,
+	form *model.Form,
+	submission *model.FormSubmission,
+	revisions []*Revision,
+) (*Revision, error) {
+	var err error
+
+	encrypted := make([]*Revision, len(revisions))
+
+	for i, r := range revisions {
+		if encrypted[i], err = s.encrypt(form, r); err != nil {
+			return nil, err
+		}
+	}
+
+	latest := revisions[len(revisions)-1]
+	submission.Data = latest.Data
+
+	stored := *submission
+	stored.Data = encrypted[len(encrypted)-1].Data
+
+	if applyErr := s.repository.Apply(ctx, &stored, encrypted...); applyErr != nil {
+		return nil, fmt.Errorf("save submission revision: %w", applyErr)
+	}
+
+	event := formevents.NewSubmissionUpdatedEvent(&stored, latest.EditorID, latest.Number, latest.Fields())
+	if err := s.eventBus.Publish(ctx, event); err != nil {
+		s.logger.Error("failed to publish submission updated event", "error", err)
+	}
+
+	return latest, nil
+}
+
+// nextNumber returns the number of the revision following history
+func nextNumber(history []*Revision) int {
+	if len(history) == 0 {
+		return 1
+	}
+
+	return history[len(history)-1].Number + 1
+}
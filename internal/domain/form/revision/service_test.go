@@ -0,0 +1,327 @@
+package revision_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	domainerrors "github.com/goformx/goforms/internal/domain/common/errors"
+	"github.com/goformx/goforms/internal/domain/common/events"
+	"github.com/goformx/goforms/internal/domain/form/encryption"
+	formevents "github.com/goformx/goforms/internal/domain/form/events"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/domain/form/revision"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+	mockencryption "github.com/goformx/goforms/test/mocks/encryption"
+	mockevents "github.com/goformx/goforms/test/mocks/events"
+	mockform "github.com/goformx/goforms/test/mocks/form"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+	mockrevision "github.com/goformx/goforms/test/mocks/revision"
+)
+
+func form() *model.Form {
+	return &model.Form{ID: "form-1", Schema: model.JSON{"type": "object"}}
+}
+
+func submission() *model.FormSubmission {
+	return &model.FormSubmission{
+		ID:          "sub-1",
+		FormID:      "form-1",
+		Data:        model.JSON{"name": "Ada", "email": "ada@example.com"},
+		SubmittedAt: time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestService_GetSubmission_otherForm(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	formService := mockform.NewMockService(ctrl)
+	svc := revision.NewService(
+		mockrevision.NewMockRepository(ctrl), formService, encryption.NewService(nil), mockevents.NewMockEventBus(ctrl),
+		mocklogging.NewMockLogger(ctrl),
+	)
+
+	formService.EXPECT().GetFormSubmission(gomock.Any(), "sub-1").Return(submission(), nil)
+
+	_, err := svc.GetSubmission(t.Context(), "form-2", "sub-1")
+	require.ErrorIs(t, err, revision.ErrSubmissionNotFound)
+	assert.True(t, domainerrors.IsNotFound(err))
+}
+
+func TestService_GetSubmission_missing(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	formService := mockform.NewMockService(ctrl)
+	svc := revision.NewService(
+		mockrevision.NewMockRepository(ctrl), formService, encryption.NewService(nil), mockevents.NewMockEventBus(ctrl),
+		mocklogging.NewMockLogger(ctrl),
+	)
+
+	formService.EXPECT().GetFormSubmission(gomock.Any(), "sub-1").
+		Return(nil, common.NewNotFoundError("get", "form_submission", "sub-1"))
+
+	_, err := svc.GetSubmission(t.Context(), "form-1", "sub-1")
+	require.ErrorIs(t, err, revision.ErrSubmissionNotFound)
+}
+
+func TestService_Edit_firstEditRecordsOriginal(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mockrevision.NewMockRepository(ctrl)
+	formService := mockform.NewMockService(ctrl)
+	eventBus := mockevents.NewMockEventBus(ctrl)
+	svc := revision.NewService(repo, formService, encryption.NewService(nil), eventBus, mocklogging.NewMockLogger(ctrl))
+
+	sub := submission()
+	original := sub.Data
+	edited := model.JSON{"name": "Ada", "email": "ada@lovelace.dev"}
+
+	formService.EXPECT().GetFormSubmission(gomock.Any(), "sub-1").Return(sub, nil)
+	formService.EXPECT().GetForm(gomock.Any(), "form-1").Return(form(), nil)
+	repo.EXPECT().ListBySubmission(gomock.Any(), "sub-1").Return(nil, nil)
+	repo.EXPECT().Apply(gomock.Any(), sub, gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, s *model.FormSubmission, revisions ...*revision.Revision) error {
+			require.Len(t, revisions, 2)
+			assert.Equal(t, edited, s.Data)
+
+			assert.Equal(t, revision.ActionOriginal, revisions[0].Action)
+			assert.Equal(t, 1, revisions[0].Number)
+			assert.Equal(t, original, revisions[0].Data)
+			assert.Equal(t, sub.SubmittedAt, revisions[0].CreatedAt)
+			assert.Empty(t, revisions[0].EditorID)
+
+			assert.Equal(t, revision.ActionEdit, revisions[1].Action)
+			assert.Equal(t, 2, revisions[1].Number)
+			assert.Equal(t, "owner-1", revisions[1].EditorID)
+			assert.Equal(t, []string{"email"}, revisions[1].Fields())
+
+			return nil
+		})
+	eventBus.EXPECT().Publish(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, event events.Event) error {
+			assert.Equal(t, string(formevents.SubmissionUpdatedEventType), event.Name())
+
+			payload, ok := event.Payload().(*formevents.SubmissionUpdatedPayload)
+			require.True(t, ok)
+			assert.Equal(t, "owner-1", payload.EditorID)
+			assert.Equal(t, 2, payload.Revision)
+			assert.Equal(t, []string{"email"}, payload.Fields)
+
+			return nil
+		})
+
+	rev, err := svc.Edit(t.Context(), "form-1", "sub-1", "owner-1", edited)
+	require.NoError(t, err)
+	assert.Equal(t, 2, rev.Number)
+}
+
+func TestService_Edit_noChanges(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mockrevision.NewMockRepository(ctrl)
+	formService := mockform.NewMockService(ctrl)
+	svc := revision.NewService(
+		repo, formService, encryption.NewService(nil), mockevents.NewMockEventBus(ctrl),
+		mocklogging.NewMockLogger(ctrl),
+	)
+
+	sub := submission()
+
+	formService.EXPECT().GetFormSubmission(gomock.Any(), "sub-1").Return(sub, nil)
+	formService.EXPECT().GetForm(gomock.Any(), "form-1").Return(form(), nil)
+	repo.EXPECT().ListBySubmission(gomock.Any(), "sub-1").Return(nil, nil)
+
+	_, err := svc.Edit(t.Context(), "form-1", "sub-1", "owner-1", model.JSON{
+		"name": "Ada", "email": "ada@example.com",
+	})
+	require.ErrorIs(t, err, revision.ErrNoChanges)
+	assert.True(t, domainerrors.IsValidation(err))
+}
+
+func TestService_Edit_recalculatesCalculatedFields(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mockrevision.NewMockRepository(ctrl)
+	formService := mockform.NewMockService(ctrl)
+	eventBus := mockevents.NewMockEventBus(ctrl)
+	svc := revision.NewService(repo, formService, encryption.NewService(nil), eventBus, mocklogging.NewMockLogger(ctrl))
+
+	order := &model.Form{ID: "form-1", Schema: model.JSON{"components": []any{
+		map[string]any{"type": "number", "key": "quantity", "input": true},
+		map[string]any{
+			"type": "number", "key": "total", "input": true,
+			"calculateValue": "value = data.quantity * 2.5",
+		},
+	}}}
+	sub := submission()
+	sub.Data = model.JSON{"quantity": 4.0, "total": 10.0}
+
+	formService.EXPECT().GetFormSubmission(gomock.Any(), "sub-1").Return(sub, nil)
+	formService.EXPECT().GetForm(gomock.Any(), "form-1").Return(order, nil)
+	repo.EXPECT().ListBySubmission(gomock.Any(), "sub-1").Return(nil, nil)
+	repo.EXPECT().Apply(gomock.Any(), sub, gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, s *model.FormSubmission, revisions ...*revision.Revision) error {
+			require.InDelta(t, 15.0, s.Data["total"], 1e-9)
+			assert.ElementsMatch(t, []string{"quantity", "total"}, revisions[1].Fields())
+
+			return nil
+		})
+	eventBus.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(nil)
+
+	_, err := svc.Edit(t.Context(), "form-1", "sub-1", "owner-1", model.JSON{"quantity": 6.0, "total": 10.0})
+	require.NoError(t, err)
+}
+
+func TestService_Edit_rejectsEnvelopes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	svc := revision.NewService(
+		mockrevision.NewMockRepository(ctrl), mockform.NewMockService(ctrl), encryption.NewService(nil),
+		mockevents.NewMockEventBus(ctrl), mocklogging.NewMockLogger(ctrl),
+	)
+
+	_, err := svc.Edit(t.Context(), "form-1", "sub-1", "owner-1", model.JSON{
+		"name": "Ada", "email": map[string]any{"$enc": 1, "kid": "k", "alg": "a", "dek": "d", "ct": "c"},
+	})
+	require.ErrorIs(t, err, encryption.ErrEnvelopeInInput)
+	assert.True(t, domainerrors.IsValidation(err))
+}
+
+func TestService_Restore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mockrevision.NewMockRepository(ctrl)
+	formService := mockform.NewMockService(ctrl)
+	eventBus := mockevents.NewMockEventBus(ctrl)
+	svc := revision.NewService(repo, formService, encryption.NewService(nil), eventBus, mocklogging.NewMockLogger(ctrl))
+
+	sub := submission()
+	sub.Data = model.JSON{"name": "Ada", "email": "ada@lovelace.dev"}
+	history := []*revision.Revision{
+		{Number: 1, Action: revision.ActionOriginal, Data: model.JSON{"name": "Ada", "email": "ada@example.com"}},
+		{Number: 2, Action: revision.ActionEdit, Data: sub.Data},
+	}
+
+	formService.EXPECT().GetFormSubmission(gomock.Any(), "sub-1").Return(sub, nil)
+	formService.EXPECT().GetForm(gomock.Any(), "form-1").Return(form(), nil)
+	repo.EXPECT().ListBySubmission(gomock.Any(), "sub-1").Return(history, nil)
+	repo.EXPECT().Apply(gomock.Any(), sub, gomock.Any()).DoAndReturn(
+		func(_ context.Context, s *model.FormSubmission, revisions ...*revision.Revision) error {
+			require.Len(t, revisions, 1)
+			assert.Equal(t, history[0].Data, s.Data)
+
+			return nil
+		})
+	eventBus.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(nil)
+
+	rev, err := svc.Restore(t.Context(), "form-1", "sub-1", "owner-1", 1)
+	require.NoError(t, err)
+	assert.Equal(t, 3, rev.Number)
+	assert.Equal(t, revision.ActionRestore, rev.Action)
+	assert.Equal(t, 1, rev.RestoredFrom)
+	assert.Equal(t, []revision.Change{
+		{Field: "email", Before: "ada@lovelace.dev", After: "ada@example.com"},
+	}, rev.Changes)
+}
+
+func TestService_Restore_unknownRevision(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mockrevision.NewMockRepository(ctrl)
+	formService := mockform.NewMockService(ctrl)
+	svc := revision.NewService(
+		repo, formService, encryption.NewService(nil), mockevents.NewMockEventBus(ctrl),
+		mocklogging.NewMockLogger(ctrl),
+	)
+
+	formService.EXPECT().GetFormSubmission(gomock.Any(), "sub-1").Return(submission(), nil)
+	formService.EXPECT().GetForm(gomock.Any(), "form-1").Return(form(), nil)
+	repo.EXPECT().ListBySubmission(gomock.Any(), "sub-1").Return(nil, nil)
+
+	_, err := svc.Restore(t.Context(), "form-1", "sub-1", "owner-1", 4)
+	require.ErrorIs(t, err, revision.ErrRevisionNotFound)
+}
+
+func TestService_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mockrevision.NewMockRepository(ctrl)
+	formService := mockform.NewMockService(ctrl)
+	svc := revision.NewService(
+		repo, formService, encryption.NewService(nil), mockevents.NewMockEventBus(ctrl),
+		mocklogging.NewMockLogger(ctrl),
+	)
+
+	formService.EXPECT().GetFormSubmission(gomock.Any(), "sub-1").Return(submission(), nil)
+	repo.EXPECT().Delete(gomock.Any(), "sub-1").Return(nil)
+
+	deleted, err := svc.Delete(t.Context(), "form-1", "sub-1")
+	require.NoError(t, err)
+	assert.Equal(t, "sub-1", deleted.ID)
+}
+
+func TestService_Delete_otherForm(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	formService := mockform.NewMockService(ctrl)
+	svc := revision.NewService(
+		mockrevision.NewMockRepository(ctrl), formService, encryption.NewService(nil), mockevents.NewMockEventBus(ctrl),
+		mocklogging.NewMockLogger(ctrl),
+	)
+
+	formService.EXPECT().GetFormSubmission(gomock.Any(), "sub-1").Return(submission(), nil)
+
+	_, err := svc.Delete(t.Context(), "form-2", "sub-1")
+	require.ErrorIs(t, err, revision.ErrSubmissionNotFound)
+}
+
+func TestService_Delete_alreadyDeleted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mockrevision.NewMockRepository(ctrl)
+	formService := mockform.NewMockService(ctrl)
+	svc := revision.NewService(
+		repo, formService, encryption.NewService(nil), mockevents.NewMockEventBus(ctrl),
+		mocklogging.NewMockLogger(ctrl),
+	)
+
+	formService.EXPECT().GetFormSubmission(gomock.Any(), "sub-1").Return(submission(), nil)
+	repo.EXPECT().Delete(gomock.Any(), "sub-1").
+		Return(common.NewNotFoundError("delete", "form_submission", "sub-1"))
+
+	_, err := svc.Delete(t.Context(), "form-1", "sub-1")
+	require.ErrorIs(t, err, revision.ErrSubmissionNotFound)
+}
+
+func TestService_Edit_encryptsSensitiveAnswers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mockrevision.NewMockRepository(ctrl)
+	formService := mockform.NewMockService(ctrl)
+	eventBus := mockevents.NewMockEventBus(ctrl)
+	encryptionService := mockencryption.NewMockService(ctrl)
+	svc := revision.NewService(repo, formService, encryptionService, eventBus, mocklogging.NewMockLogger(ctrl))
+
+	sub := submission()
+	edited := model.JSON{"name": "Ada", "email": "ada@lovelace.dev"}
+	sealed := map[string]any{"$enc": 1, "kid": "k1"}
+	stored := model.JSON{"name": "Ada", "email": sealed}
+
+	formService.EXPECT().GetFormSubmission(gomock.Any(), "sub-1").Return(sub, nil)
+	formService.EXPECT().GetForm(gomock.Any(), "form-1").Return(form(), nil)
+	repo.EXPECT().ListBySubmission(gomock.Any(), "sub-1").Return([]*revision.Revision{
+		{Number: 1, Action: revision.ActionOriginal, Data: stored},
+	}, nil)
+	encryptionService.EXPECT().Decrypt(gomock.Any(), stored).Return(sub.Data, nil)
+	encryptionService.EXPECT().Encrypt(gomock.Any(), edited).Return(stored, nil)
+	encryptionService.EXPECT().EncryptField(gomock.Any(), "email", "ada@example.com").Return(sealed, nil)
+	encryptionService.EXPECT().EncryptField(gomock.Any(), "email", "ada@lovelace.dev").Return(sealed, nil)
+	repo.EXPECT().Apply(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, s *model.FormSubmission, revisions ...*revision.Revision) error {
+			assert.Equal(t, stored, s.Data)
+			assert.Equal(t, stored, revisions[0].Data)
+			assert.Equal(t, sealed, revisions[0].Changes[0].Before)
+			assert.Equal(t, sealed, revisions[0].Changes[0].After)
+
+			return nil
+		})
+	eventBus.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(nil)
+
+	rev, err := svc.Edit(t.Context(), "form-1", "sub-1", "owner-1", edited)
+	require.NoError(t, err)
+	assert.Equal(t, edited, rev.Data)
+	assert.Equal(t, "ada@lovelace.dev", rev.Changes[0].After)
+	assert.Equal(t, edited, sub.Data)
+}
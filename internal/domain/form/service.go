@@ -12,15 +12,31 @@ import (
 
 	"github.com/google/uuid"
 
+	domainerrors "github.com/goformx/goforms/internal/domain/common/errors"
 	"github.com/goformx/goforms/internal/domain/common/events"
+	"github.com/goformx/goforms/internal/domain/form/calculation"
+	"github.com/goformx/goforms/internal/domain/form/encryption"
 	formevents "github.com/goformx/goforms/internal/domain/form/events"
 	"github.com/goformx/goforms/internal/domain/form/model"
 	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
 )
 
 const (
 	// DefaultTimeout is the default timeout for form operations
 	DefaultTimeout = 30 * time.Second
+	// CalculationMismatchesMetadataKey is the submission metadata key listing
+	// calculated fields whose submitted value did not match the server's result
+	CalculationMismatchesMetadataKey = "calculation_mismatches"
+)
+
+// ErrFormIDConflict is returned when an imported form's ID is already in use
+var ErrFormIDConflict = domainerrors.New(domainerrors.ErrCodeConflict, "form ID already exists", nil)
+
+// ErrEncryptionRequired is returned when a form marks fields sensitive but field
+// encryption is not configured
+var ErrEncryptionRequired = domainerrors.New(
+	domainerrors.ErrCodeValidation, "sensitive fields require field encryption to be configured", nil,
 )
 
 // Service defines the interface for form-related business logic
@@ -33,22 +49,36 @@ type Service interface {
 	SubmitForm(ctx context.Context, submission *model.FormSubmission) error
 	GetFormSubmission(ctx context.Context, submissionID string) (*model.FormSubmission, error)
 	ListFormSubmissions(ctx context.Context, formID string) ([]*model.FormSubmission, error)
+	ListFormSubmissionsPaginated(
+		ctx context.Context, formID string, params common.PaginationParams,
+	) (*common.PaginationResult, error)
+	CountFormSubmissions(ctx context.Context, formIDs []string) (map[string]int64, error)
 	UpdateFormState(ctx context.Context, formID, state string) error
 	TrackFormAnalytics(ctx context.Context, formID, eventType string) error
+	ImportBundle(ctx context.Context, userID string, bundle *model.Bundle, onConflict string) (*model.Form, error)
+	DuplicateForm(ctx context.Context, formID string) (*model.Form, error)
+	SchemaVersions(ctx context.Context, formID string) ([]*model.SchemaVersion, error)
 }
 
 // formService handles form-related business logic
 type formService struct {
 	repository Repository
 	eventBus   events.EventBus
+	encryption encryption.Service
 	logger     logging.Logger
 }
 
 // NewService creates a new form service
-func NewService(repository Repository, eventBus events.EventBus, logger logging.Logger) Service {
+func NewService(
+	repository Repository,
+	eventBus events.EventBus,
+	encryptionService encryption.Service,
+	logger logging.Logger,
+) Service {
 	return &formService{
 		repository: repository,
 		eventBus:   eventBus,
+		encryption: encryptionService,
 		logger:     logger,
 	}
 }
@@ -56,7 +86,11 @@ func NewService(repository Repository, eventBus events.EventBus, logger logging.
 // CreateForm creates a new form
 func (s *formService) CreateForm(ctx context.Context, form *model.Form) error {
 	if err := form.Validate(); err != nil {
-		return fmt.Errorf("form validation failed: %w", err)
+		return fmt.Errorf("form validation failed: %w", domainerrors.WrapValidationError(err, err.Error()))
+	}
+
+	if err := s.checkSensitiveFields(form); err != nil {
+		return err
 	}
 
 	// Set form ID if not already set
@@ -64,12 +98,14 @@ func (s *formService) CreateForm(ctx context.Context, form *model.Form) error {
 		form.ID = uuid.New().String()
 	}
 
+	ctx = logging.ContextWithFormID(ctx, form.ID)
+
 	if err := s.repository.CreateForm(ctx, form); err != nil {
 		return fmt.Errorf("failed to create form: %w", err)
 	}
 
 	if err := s.eventBus.Publish(ctx, formevents.NewFormCreatedEvent(form)); err != nil {
-		s.logger.Error("failed to publish form created event", "error", err)
+		logging.WithContext(ctx, s.logger).Error("failed to publish form created event", "error", err)
 	}
 
 	return nil
@@ -78,9 +114,15 @@ func (s *formService) CreateForm(ctx context.Context, form *model.Form) error {
 // UpdateForm updates a form
 func (s *formService) UpdateForm(ctx context.Context, form *model.Form) error {
 	if validateErr := form.Validate(); validateErr != nil {
-		return fmt.Errorf("validate form: %w", validateErr)
+		return fmt.Errorf("validate form: %w", domainerrors.WrapValidationError(validateErr, validateErr.Error()))
+	}
+
+	if sensitiveErr := s.checkSensitiveFields(form); sensitiveErr != nil {
+		return sensitiveErr
 	}
 
+	ctx = logging.ContextWithFormID(ctx, form.ID)
+
 	// Update the form
 	if updateErr := s.repository.UpdateForm(ctx, form); updateErr != nil {
 		return fmt.Errorf("update form in repository: %w", updateErr)
@@ -89,7 +131,7 @@ func (s *formService) UpdateForm(ctx context.Context, form *model.Form) error {
 	// Publish form updated event
 	event := formevents.NewFormUpdatedEvent(form)
 	if publishErr := s.eventBus.Publish(ctx, event); publishErr != nil {
-		s.logger.Error("failed to publish form updated event", "error", publishErr)
+		logging.WithContext(ctx, s.logger).Error("failed to publish form updated event", "error", publishErr)
 	}
 
 	return nil
@@ -101,12 +143,14 @@ func (s *formService) DeleteForm(ctx context.Context, formID string) error {
 		return fmt.Errorf("failed to delete form: formID is required")
 	}
 
+	ctx = logging.ContextWithFormID(ctx, formID)
+
 	if err := s.repository.DeleteForm(ctx, formID); err != nil {
 		return fmt.Errorf("failed to delete form: %w", err)
 	}
 
 	if err := s.eventBus.Publish(ctx, formevents.NewFormDeletedEvent(formID)); err != nil {
-		s.logger.Error("failed to publish form deleted event", "error", err)
+		logging.WithContext(ctx, s.logger).Error("failed to publish form deleted event", "error", err)
 	}
 
 	return nil
@@ -122,6 +166,16 @@ func (s *formService) GetForm(ctx context.Context, formID string) (*model.Form,
 	return form, nil
 }
 
+// SchemaVersions returns the schemas the form has had, oldest first
+func (s *formService) SchemaVersions(ctx context.Context, formID string) ([]*model.SchemaVersion, error) {
+	versions, err := s.repository.ListSchemaVersions(ctx, formID)
+	if err != nil {
+		return nil, fmt.Errorf("list schema versions: %w", err)
+	}
+
+	return versions, nil
+}
+
 // ListForms retrieves a list of forms
 func (s *formService) ListForms(ctx context.Context, userID string) ([]*model.Form, error) {
 	forms, err := s.repository.ListForms(ctx, userID)
@@ -132,12 +186,56 @@ func (s *formService) ListForms(ctx context.Context, userID string) ([]*model.Fo
 	return forms, nil
 }
 
+// recalculate recomputes the calculated fields of a submission, replacing
+// values the submitter could not have changed and recording every mismatch
+// in the submission metadata. Answers to sensitive fields are left out of
+// the metadata, which is not encrypted.
+func (s *formService) recalculate(ctx context.Context, form *model.Form, submission *model.FormSubmission) {
+	result := calculation.Recalculate(form, submission.Data, time.Now())
+	submission.Data = result.Data
+
+	if len(result.Unevaluated) > 0 {
+		logging.WithContext(ctx, s.logger).Debug("calculated fields not checked", "fields", result.Unevaluated)
+	}
+
+	if len(result.Mismatches) == 0 {
+		return
+	}
+
+	fields := make([]string, 0, len(result.Mismatches))
+	mismatches := make([]any, 0, len(result.Mismatches))
+
+	for _, m := range result.Mismatches {
+		entry := map[string]any{"field": m.Field, "overridden": m.Overridden}
+		if !form.IsSensitiveField(m.Field) {
+			entry["submitted"] = m.Submitted
+		}
+
+		fields = append(fields, m.Field)
+		mismatches = append(mismatches, entry)
+	}
+
+	if submission.Metadata == nil {
+		submission.Metadata = model.JSON{}
+	}
+
+	submission.Metadata[CalculationMismatchesMetadataKey] = mismatches
+
+	logging.WithContext(ctx, s.logger).Warn("submitted calculated values do not match", "fields", fields)
+}
+
 // SubmitForm submits a form
 func (s *formService) SubmitForm(ctx context.Context, submission *model.FormSubmission) error {
 	if validateErr := submission.Validate(); validateErr != nil {
 		return fmt.Errorf("validate form submission: %w", validateErr)
 	}
 
+	if envelopeErr := encryption.RejectEnvelopes(submission.Data); envelopeErr != nil {
+		return fmt.Errorf("%w: %w", model.ErrFormInvalid, envelopeErr)
+	}
+
+	ctx = logging.ContextWithFormID(ctx, submission.FormID)
+
 	// Validate the form exists
 	form, getErr := s.repository.GetFormByID(ctx, submission.FormID)
 	if getErr != nil {
@@ -148,6 +246,16 @@ func (s *formService) SubmitForm(ctx context.Context, submission *model.FormSubm
 		return errors.New("form not found")
 	}
 
+	s.recalculate(ctx, form, submission)
+
+	// Encrypt sensitive answers so that only ciphertext is stored and published
+	data, encryptErr := s.encryption.Encrypt(form, submission.Data)
+	if encryptErr != nil {
+		return fmt.Errorf("encrypt form submission: %w", encryptErr)
+	}
+
+	submission.Data = data
+
 	// Create the submission
 	createErr := s.repository.CreateSubmission(ctx, submission)
 	if createErr != nil {
@@ -159,7 +267,7 @@ func (s *formService) SubmitForm(ctx context.Context, submission *model.FormSubm
 
 	publishErr := s.eventBus.Publish(ctx, event)
 	if publishErr != nil {
-		s.logger.Error("failed to publish form submitted event", "error", publishErr)
+		logging.WithContext(ctx, s.logger).Error("failed to publish form submitted event", "error", publishErr)
 	}
 
 	// Validate the submission
@@ -169,7 +277,7 @@ func (s *formService) SubmitForm(ctx context.Context, submission *model.FormSubm
 
 	validationPublishErr := s.eventBus.Publish(ctx, validationEvent)
 	if validationPublishErr != nil {
-		s.logger.Error("failed to publish form validated event", "error", validationPublishErr)
+		logging.WithContext(ctx, s.logger).Error("failed to publish form validated event", "error", validationPublishErr)
 	}
 
 	if !isValid {
@@ -180,34 +288,145 @@ func (s *formService) SubmitForm(ctx context.Context, submission *model.FormSubm
 
 	processingPublishErr := s.eventBus.Publish(ctx, processingEvent)
 	if processingPublishErr != nil {
-		s.logger.Error("failed to publish form processed event", "error", processingPublishErr)
+		logging.WithContext(ctx, s.logger).Error("failed to publish form processed event", "error", processingPublishErr)
 	}
 
 	return nil
 }
 
-// GetFormSubmission retrieves a form submission by ID
+// GetFormSubmission retrieves a form submission by ID with its sensitive
+// answers decrypted
 func (s *formService) GetFormSubmission(ctx context.Context, submissionID string) (*model.FormSubmission, error) {
 	submission, err := s.repository.GetSubmissionByID(ctx, submissionID)
 	if err != nil {
 		return nil, fmt.Errorf("get form submission by ID: %w", err)
 	}
 
+	if submission == nil {
+		return nil, common.ErrNotFound
+	}
+
+	form, err := s.repository.GetFormByID(ctx, submission.FormID)
+	if err != nil {
+		return nil, fmt.Errorf("get form of submission: %w", err)
+	}
+
+	data, err := s.encryption.Decrypt(form, submission.Data)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt form submission %s: %w", submission.ID, err)
+	}
+
+	submission.Data = data
+
 	return submission, nil
 }
 
-// ListFormSubmissions retrieves a list of form submissions
+// ListFormSubmissions retrieves a list of form submissions with their
+// sensitive answers decrypted
 func (s *formService) ListFormSubmissions(ctx context.Context, formID string) ([]*model.FormSubmission, error) {
 	submissions, err := s.repository.ListSubmissions(ctx, formID)
 	if err != nil {
 		return nil, fmt.Errorf("list form submissions: %w", err)
 	}
 
+	if decryptErr := s.decryptSubmissions(ctx, formID, submissions); decryptErr != nil {
+		return nil, decryptErr
+	}
+
 	return submissions, nil
 }
 
+// ListFormSubmissionsPaginated retrieves a page of a form's submissions with
+// their sensitive answers decrypted. The result's items are a
+// []*model.FormSubmission, empty when the page has no submissions.
+func (s *formService) ListFormSubmissionsPaginated(
+	ctx context.Context,
+	formID string,
+	params common.PaginationParams,
+) (*common.PaginationResult, error) {
+	result, err := s.repository.GetByFormIDPaginated(ctx, formID, params)
+	if err != nil {
+		return nil, fmt.Errorf("list form submissions page: %w", err)
+	}
+
+	submissions, _ := result.Items.([]*model.FormSubmission)
+	if submissions == nil {
+		submissions = []*model.FormSubmission{}
+	}
+
+	if decryptErr := s.decryptSubmissions(ctx, formID, submissions); decryptErr != nil {
+		return nil, decryptErr
+	}
+
+	result.Items = submissions
+
+	return result, nil
+}
+
+// CountFormSubmissions returns the number of submissions of each form, keyed by
+// form ID, with 0 for forms without submissions
+func (s *formService) CountFormSubmissions(ctx context.Context, formIDs []string) (map[string]int64, error) {
+	counts := make(map[string]int64, len(formIDs))
+	if len(formIDs) == 0 {
+		return counts, nil
+	}
+
+	stored, err := s.repository.CountSubmissions(ctx, formIDs)
+	if err != nil {
+		return nil, fmt.Errorf("count form submissions: %w", err)
+	}
+
+	for _, formID := range formIDs {
+		counts[formID] = stored[formID]
+	}
+
+	return counts, nil
+}
+
+// decryptSubmissions decrypts the sensitive answers of a form's submissions in
+// place. An answer that cannot be decrypted is left out and logged rather than
+// failing the whole list.
+func (s *formService) decryptSubmissions(
+	ctx context.Context,
+	formID string,
+	submissions []*model.FormSubmission,
+) error {
+	if len(submissions) == 0 {
+		return nil
+	}
+
+	form, err := s.repository.GetFormByID(ctx, formID)
+	if err != nil {
+		return fmt.Errorf("get form of submissions: %w", err)
+	}
+
+	for _, submission := range submissions {
+		data, decryptErr := s.encryption.Decrypt(form, submission.Data)
+		if decryptErr != nil {
+			logging.WithContext(ctx, s.logger).Warn("failed to decrypt submission answers",
+				"submission_id", submission.ID, "error", decryptErr)
+		}
+
+		submission.Data = data
+	}
+
+	return nil
+}
+
+// checkSensitiveFields rejects forms with sensitive fields when their answers
+// could not be encrypted
+func (s *formService) checkSensitiveFields(form *model.Form) error {
+	if !s.encryption.Enabled() && len(form.SensitiveFields()) > 0 {
+		return ErrEncryptionRequired
+	}
+
+	return nil
+}
+
 // UpdateFormState updates the state of a form
 func (s *formService) UpdateFormState(ctx context.Context, formID, state string) error {
+	ctx = logging.ContextWithFormID(ctx, formID)
+
 	form, getErr := s.repository.GetFormByID(ctx, formID)
 	if getErr != nil {
 		return fmt.Errorf("failed to get form: %w", getErr)
@@ -220,7 +439,7 @@ func (s *formService) UpdateFormState(ctx context.Context, formID, state string)
 
 	event := formevents.NewFormStateEvent(formID, state)
 	if publishErr := s.eventBus.Publish(ctx, event); publishErr != nil {
-		s.logger.Error("failed to publish form state event", "error", publishErr)
+		logging.WithContext(ctx, s.logger).Error("failed to publish form state event", "error", publishErr)
 	}
 
 	return nil
@@ -228,6 +447,8 @@ func (s *formService) UpdateFormState(ctx context.Context, formID, state string)
 
 // TrackFormAnalytics tracks form analytics
 func (s *formService) TrackFormAnalytics(ctx context.Context, formID, eventType string) error {
+	ctx = logging.ContextWithFormID(ctx, formID)
+
 	event := formevents.NewAnalyticsEvent(formID, eventType)
 	if err := s.eventBus.Publish(ctx, event); err != nil {
 		return fmt.Errorf("failed to publish analytics event: %w", err)
@@ -235,3 +456,94 @@ func (s *formService) TrackFormAnalytics(ctx context.Context, formID, eventType
 
 	return nil
 }
+
+// ImportBundle creates a form owned by userID from an exported bundle. The
+// bundle's form ID is kept when it is free. When it is taken the form gets a
+// new ID, or ErrFormIDConflict is returned if onConflict is model.ConflictFail
+// and the ID belongs to one of the user's own forms. Other users' forms and
+// deleted forms are not reported, so an import cannot reveal that they exist.
+func (s *formService) ImportBundle(
+	ctx context.Context,
+	userID string,
+	bundle *model.Bundle,
+	onConflict string,
+) (*model.Form, error) {
+	policy, err := model.ValidateConflictPolicy(onConflict)
+	if err != nil {
+		return nil, domainerrors.Wrap(err, domainerrors.ErrCodeInvalidInput, "invalid conflict policy")
+	}
+
+	if validateErr := bundle.Validate(); validateErr != nil {
+		return nil, domainerrors.Wrap(validateErr, domainerrors.ErrCodeFormInvalid, "invalid form bundle")
+	}
+
+	form := bundle.ToForm(userID)
+	if validateErr := form.Validate(); validateErr != nil {
+		return nil, domainerrors.Wrap(validateErr, domainerrors.ErrCodeFormInvalid, "invalid form bundle")
+	}
+
+	if sensitiveErr := s.checkSensitiveFields(form); sensitiveErr != nil {
+		return nil, sensitiveErr
+	}
+
+	// IDs that are not UUIDs cannot be stored and are always replaced
+	if _, parseErr := uuid.Parse(form.ID); parseErr != nil {
+		form.ID = uuid.New().String()
+	} else {
+		existing, taken, takenErr := s.formWithID(ctx, form.ID)
+		if takenErr != nil {
+			return nil, takenErr
+		}
+
+		ownForm := taken && existing.UserID == userID && !existing.DeletedAt.Valid
+		if ownForm && policy == model.ConflictFail {
+			return nil, fmt.Errorf("%w: %s", ErrFormIDConflict, form.ID)
+		}
+
+		if taken {
+			form.ID = uuid.New().String()
+		}
+	}
+
+	if createErr := s.CreateForm(ctx, form); createErr != nil {
+		return nil, fmt.Errorf("import form bundle: %w", createErr)
+	}
+
+	return form, nil
+}
+
+// DuplicateForm copies a form, including its schema and settings, into a new draft
+// owned by the same user
+func (s *formService) DuplicateForm(ctx context.Context, formID string) (*model.Form, error) {
+	source, err := s.repository.GetFormByID(ctx, formID)
+	if err != nil {
+		return nil, fmt.Errorf("get form to duplicate: %w", err)
+	}
+
+	bundle := model.NewBundle(source)
+	bundle.Form.ID = uuid.New().String()
+	bundle.Form.Title = model.DuplicateTitle(source.Title)
+	bundle.Form.Status = "draft"
+
+	form := bundle.ToForm(source.UserID)
+	if createErr := s.CreateForm(ctx, form); createErr != nil {
+		return nil, fmt.Errorf("duplicate form: %w", createErr)
+	}
+
+	return form, nil
+}
+
+// formWithID returns the form, deleted or not, that uses formID. It reports
+// false when the ID is free.
+func (s *formService) formWithID(ctx context.Context, formID string) (*model.Form, bool, error) {
+	form, err := s.repository.GetFormByIDUnscoped(ctx, formID)
+	if err == nil {
+		return form, true, nil
+	}
+
+	if errors.Is(err, common.ErrNotFound) {
+		return nil, false, nil
+	}
+
+	return nil, false, fmt.Errorf("check form ID: %w", err)
+}
@@ -8,10 +8,15 @@ import (
 
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
 
+	domainerrors "github.com/goformx/goforms/internal/domain/common/errors"
 	"github.com/goformx/goforms/internal/domain/common/events"
 	domainform "github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/form/encryption"
 	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+	mockencryption "github.com/goformx/goforms/test/mocks/encryption"
 	mockevents "github.com/goformx/goforms/test/mocks/events"
 	mockform "github.com/goformx/goforms/test/mocks/form"
 	mocklogging "github.com/goformx/goforms/test/mocks/logging"
@@ -51,7 +56,7 @@ func TestService_CreateForm_minimal(t *testing.T) {
 	})
 	eventBus.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(nil)
 
-	svc := domainform.NewService(repo, eventBus, logger)
+	svc := domainform.NewService(repo, eventBus, encryption.NewService(nil), logger)
 
 	ctx, cancel := context.WithTimeout(t.Context(), 2*time.Second)
 	defer cancel()
@@ -94,7 +99,7 @@ func TestService_ListForms(t *testing.T) {
 	t.Run("successful list", func(t *testing.T) {
 		repo.EXPECT().ListForms(gomock.Any(), userID).Return(expectedForms, nil)
 
-		svc := domainform.NewService(repo, eventBus, logger)
+		svc := domainform.NewService(repo, eventBus, encryption.NewService(nil), logger)
 
 		ctx, cancel := context.WithTimeout(t.Context(), 2*time.Second)
 		defer cancel()
@@ -109,7 +114,7 @@ func TestService_ListForms(t *testing.T) {
 	t.Run("repository error", func(t *testing.T) {
 		repo.EXPECT().ListForms(gomock.Any(), userID).Return(nil, errors.New("database error"))
 
-		svc := domainform.NewService(repo, eventBus, logger)
+		svc := domainform.NewService(repo, eventBus, encryption.NewService(nil), logger)
 
 		ctx, cancel := context.WithTimeout(t.Context(), 2*time.Second)
 		defer cancel()
@@ -123,7 +128,7 @@ func TestService_ListForms(t *testing.T) {
 	t.Run("empty list", func(t *testing.T) {
 		repo.EXPECT().ListForms(gomock.Any(), userID).Return([]*model.Form{}, nil)
 
-		svc := domainform.NewService(repo, eventBus, logger)
+		svc := domainform.NewService(repo, eventBus, encryption.NewService(nil), logger)
 
 		ctx, cancel := context.WithTimeout(t.Context(), 2*time.Second)
 		defer cancel()
@@ -179,7 +184,7 @@ func TestService_UpdateForm(t *testing.T) {
 			return nil
 		})
 
-		svc := domainform.NewService(repo, eventBus, logger)
+		svc := domainform.NewService(repo, eventBus, encryption.NewService(nil), logger)
 
 		ctx, cancel := context.WithTimeout(t.Context(), 2*time.Second)
 		defer cancel()
@@ -195,7 +200,7 @@ func TestService_UpdateForm(t *testing.T) {
 			Title:  "", // Invalid: empty title
 		}
 
-		svc := domainform.NewService(repo, eventBus, logger)
+		svc := domainform.NewService(repo, eventBus, encryption.NewService(nil), logger)
 
 		ctx, cancel := context.WithTimeout(t.Context(), 2*time.Second)
 		defer cancel()
@@ -203,12 +208,13 @@ func TestService_UpdateForm(t *testing.T) {
 		err := svc.UpdateForm(ctx, invalidForm)
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "validate form")
+		require.True(t, domainerrors.IsValidation(err))
 	})
 
 	t.Run("repository error", func(t *testing.T) {
 		repo.EXPECT().UpdateForm(gomock.Any(), gomock.Any()).Return(errors.New("database error"))
 
-		svc := domainform.NewService(repo, eventBus, logger)
+		svc := domainform.NewService(repo, eventBus, encryption.NewService(nil), logger)
 
 		ctx, cancel := context.WithTimeout(t.Context(), 2*time.Second)
 		defer cancel()
@@ -221,9 +227,10 @@ func TestService_UpdateForm(t *testing.T) {
 	t.Run("event bus error", func(t *testing.T) {
 		repo.EXPECT().UpdateForm(gomock.Any(), gomock.Any()).Return(nil)
 		eventBus.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(errors.New("event bus error"))
+		logger.EXPECT().With("form_id", "form123").Return(logger)
 		logger.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any()).Return()
 
-		svc := domainform.NewService(repo, eventBus, logger)
+		svc := domainform.NewService(repo, eventBus, encryption.NewService(nil), logger)
 
 		ctx, cancel := context.WithTimeout(t.Context(), 2*time.Second)
 		defer cancel()
@@ -249,7 +256,7 @@ func TestService_DeleteForm(t *testing.T) {
 			return nil
 		})
 
-		svc := domainform.NewService(repo, eventBus, logger)
+		svc := domainform.NewService(repo, eventBus, encryption.NewService(nil), logger)
 
 		ctx, cancel := context.WithTimeout(t.Context(), 2*time.Second)
 		defer cancel()
@@ -266,7 +273,7 @@ func TestService_DeleteForm(t *testing.T) {
 
 		repo.EXPECT().DeleteForm(gomock.Any(), formID).Return(errors.New("database error"))
 
-		svc := domainform.NewService(repo, eventBus, logger)
+		svc := domainform.NewService(repo, eventBus, encryption.NewService(nil), logger)
 
 		ctx, cancel := context.WithTimeout(t.Context(), 2*time.Second)
 		defer cancel()
@@ -284,9 +291,10 @@ func TestService_DeleteForm(t *testing.T) {
 
 		repo.EXPECT().DeleteForm(gomock.Any(), formID).Return(nil)
 		eventBus.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(errors.New("event bus error"))
+		logger.EXPECT().With("form_id", "form123").Return(logger)
 		logger.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any()).Return()
 
-		svc := domainform.NewService(repo, eventBus, logger)
+		svc := domainform.NewService(repo, eventBus, encryption.NewService(nil), logger)
 
 		ctx, cancel := context.WithTimeout(t.Context(), 2*time.Second)
 		defer cancel()
@@ -301,7 +309,7 @@ func TestService_DeleteForm(t *testing.T) {
 		eventBus := mockevents.NewMockEventBus(ctrl)
 		logger := mocklogging.NewMockLogger(ctrl)
 
-		svc := domainform.NewService(repo, eventBus, logger)
+		svc := domainform.NewService(repo, eventBus, encryption.NewService(nil), logger)
 
 		ctx, cancel := context.WithTimeout(t.Context(), 2*time.Second)
 		defer cancel()
@@ -332,7 +340,7 @@ func TestService_GetForm(t *testing.T) {
 	t.Run("successful get", func(t *testing.T) {
 		repo.EXPECT().GetFormByID(gomock.Any(), "form123").Return(expectedForm, nil)
 
-		svc := domainform.NewService(repo, eventBus, logger)
+		svc := domainform.NewService(repo, eventBus, encryption.NewService(nil), logger)
 
 		ctx, cancel := context.WithTimeout(t.Context(), 2*time.Second)
 		defer cancel()
@@ -345,7 +353,7 @@ func TestService_GetForm(t *testing.T) {
 	t.Run("form not found", func(t *testing.T) {
 		repo.EXPECT().GetFormByID(gomock.Any(), "nonexistent").Return(nil, errors.New("not found"))
 
-		svc := domainform.NewService(repo, eventBus, logger)
+		svc := domainform.NewService(repo, eventBus, encryption.NewService(nil), logger)
 
 		ctx, cancel := context.WithTimeout(t.Context(), 2*time.Second)
 		defer cancel()
@@ -357,6 +365,87 @@ func TestService_GetForm(t *testing.T) {
 	})
 }
 
+func TestService_ListFormSubmissionsPaginated(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	repo := mockform.NewMockRepository(ctrl)
+	svc := domainform.NewService(
+		repo, mockevents.NewMockEventBus(ctrl), encryption.NewService(nil), mocklogging.NewMockLogger(ctrl),
+	)
+
+	params := common.PaginationParams{Page: 2, PageSize: 10, SortBy: "status"}
+	submission := &model.FormSubmission{ID: "sub-1", FormID: "form123", Data: model.JSON{"name": "Ada"}}
+
+	repo.EXPECT().GetByFormIDPaginated(gomock.Any(), "form123", params).Return(&common.PaginationResult{
+		Items: []*model.FormSubmission{submission}, TotalItems: 11, Page: 2, PageSize: 10, TotalPages: 2,
+	}, nil)
+	repo.EXPECT().GetFormByID(gomock.Any(), "form123").Return(&model.Form{ID: "form123"}, nil)
+
+	page, err := svc.ListFormSubmissionsPaginated(t.Context(), "form123", params)
+	require.NoError(t, err)
+	require.Equal(t, []*model.FormSubmission{submission}, page.Items)
+	require.Equal(t, 11, page.TotalItems)
+
+	// An empty page still lists its submissions as a slice
+	repo.EXPECT().GetByFormIDPaginated(gomock.Any(), "form123", params).Return(&common.PaginationResult{
+		Page: 2, PageSize: 10,
+	}, nil)
+
+	page, err = svc.ListFormSubmissionsPaginated(t.Context(), "form123", params)
+	require.NoError(t, err)
+	require.Equal(t, []*model.FormSubmission{}, page.Items)
+}
+
+func TestService_ListFormSubmissions_envelopes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	repo := mockform.NewMockRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	svc := domainform.NewService(repo, mockevents.NewMockEventBus(ctrl), encryption.NewService(nil), logger)
+
+	form := model.NewForm("user123", "Form", "", model.JSON{"components": []any{
+		map[string]any{"type": "textfield", "key": "name", "input": true},
+		map[string]any{"type": "textfield", "key": "ssn", "input": true, "sensitive": true},
+	}})
+	fake := map[string]any{"$enc": 1, "kid": "k", "alg": "a", "dek": "d", "ct": "c"}
+
+	// An envelope-shaped answer to a field that is not sensitive is data, and an
+	// answer that cannot be decrypted does not fail the list
+	injected := &model.FormSubmission{ID: "sub-1", FormID: form.ID, Data: model.JSON{"name": fake}}
+	undecryptable := &model.FormSubmission{ID: "sub-2", FormID: form.ID, Data: model.JSON{"name": "Ada", "ssn": fake}}
+
+	repo.EXPECT().ListSubmissions(gomock.Any(), form.ID).Return([]*model.FormSubmission{injected, undecryptable}, nil)
+	repo.EXPECT().GetFormByID(gomock.Any(), form.ID).Return(form, nil)
+	logger.EXPECT().Warn("failed to decrypt submission answers", "submission_id", "sub-2", "error", gomock.Any())
+
+	submissions, err := svc.ListFormSubmissions(t.Context(), form.ID)
+	require.NoError(t, err)
+	require.Len(t, submissions, 2)
+	require.Equal(t, model.JSON{"name": fake}, submissions[0].Data)
+	require.Equal(t, model.JSON{"name": "Ada"}, submissions[1].Data)
+}
+
+func TestService_CountFormSubmissions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	repo := mockform.NewMockRepository(ctrl)
+	svc := domainform.NewService(
+		repo, mockevents.NewMockEventBus(ctrl), encryption.NewService(nil), mocklogging.NewMockLogger(ctrl),
+	)
+
+	repo.EXPECT().CountSubmissions(gomock.Any(), []string{"form-1", "form-2"}).
+		Return(map[string]int64{"form-1": 3}, nil)
+
+	counts, err := svc.CountFormSubmissions(t.Context(), []string{"form-1", "form-2"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]int64{"form-1": 3, "form-2": 0}, counts)
+
+	// No forms need no query
+	counts, err = svc.CountFormSubmissions(t.Context(), nil)
+	require.NoError(t, err)
+	require.Empty(t, counts)
+}
+
 func TestService_SubmitForm(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	t.Cleanup(ctrl.Finish)
@@ -429,7 +518,7 @@ func TestService_SubmitForm(t *testing.T) {
 			return nil
 		})
 
-		svc := domainform.NewService(repo, eventBus, logger)
+		svc := domainform.NewService(repo, eventBus, encryption.NewService(nil), logger)
 
 		ctx, cancel := context.WithTimeout(t.Context(), 2*time.Second)
 		defer cancel()
@@ -442,7 +531,7 @@ func TestService_SubmitForm(t *testing.T) {
 		// Set up mock expectations
 		repo.EXPECT().GetFormByID(gomock.Any(), form.ID).Return(nil, nil)
 
-		svc := domainform.NewService(repo, eventBus, logger)
+		svc := domainform.NewService(repo, eventBus, encryption.NewService(nil), logger)
 
 		ctx, cancel := context.WithTimeout(t.Context(), 2*time.Second)
 		defer cancel()
@@ -458,7 +547,7 @@ func TestService_SubmitForm(t *testing.T) {
 			Data:   nil, // Missing required data
 		}
 
-		svc := domainform.NewService(repo, eventBus, logger)
+		svc := domainform.NewService(repo, eventBus, encryption.NewService(nil), logger)
 
 		ctx, cancel := context.WithTimeout(t.Context(), 2*time.Second)
 		defer cancel()
@@ -473,7 +562,7 @@ func TestService_SubmitForm(t *testing.T) {
 		repo.EXPECT().GetFormByID(gomock.Any(), form.ID).Return(form, nil)
 		repo.EXPECT().CreateSubmission(gomock.Any(), gomock.Any()).Return(errors.New("database error"))
 
-		svc := domainform.NewService(repo, eventBus, logger)
+		svc := domainform.NewService(repo, eventBus, encryption.NewService(nil), logger)
 
 		ctx, cancel := context.WithTimeout(t.Context(), 2*time.Second)
 		defer cancel()
@@ -482,4 +571,247 @@ func TestService_SubmitForm(t *testing.T) {
 		require.Error(t, err)
 		require.Equal(t, "create form submission: database error", err.Error())
 	})
+
+	t.Run("encrypts sensitive answers", func(t *testing.T) {
+		encryptionService := mockencryption.NewMockService(ctrl)
+		encrypted := model.JSON{"name": "John Doe", "email": map[string]any{"$enc": 1}}
+
+		repo.EXPECT().GetFormByID(gomock.Any(), form.ID).Return(form, nil)
+		encryptionService.EXPECT().Encrypt(form, submission.Data).Return(encrypted, nil)
+		repo.EXPECT().CreateSubmission(
+			gomock.Any(),
+			gomock.Any(),
+		).DoAndReturn(func(_ context.Context, s *model.FormSubmission) error {
+			require.Equal(t, encrypted, s.Data)
+
+			return nil
+		})
+		eventBus.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(nil).Times(3)
+
+		svc := domainform.NewService(repo, eventBus, encryptionService, logger)
+
+		sensitive := *submission
+		require.NoError(t, svc.SubmitForm(t.Context(), &sensitive))
+	})
+
+	t.Run("rejects encrypted values", func(t *testing.T) {
+		svc := domainform.NewService(repo, eventBus, encryption.NewService(nil), logger)
+
+		injected := *submission
+		injected.Data = model.JSON{"name": "John Doe", "x": map[string]any{
+			"$enc": 1, "kid": "k", "alg": "a", "dek": "d", "ct": "c",
+		}}
+
+		err := svc.SubmitForm(t.Context(), &injected)
+		require.ErrorIs(t, err, model.ErrFormInvalid)
+		require.ErrorIs(t, err, encryption.ErrEnvelopeInInput)
+	})
+
+	t.Run("recalculates calculated fields", func(t *testing.T) {
+		calculated := model.NewForm("user123", "Order", "", model.JSON{
+			"components": []any{
+				map[string]any{"type": "number", "key": "quantity", "input": true},
+				map[string]any{
+					"type": "number", "key": "total", "input": true,
+					"calculateValue": "value = data.quantity * 2.5",
+				},
+			},
+		})
+
+		repo.EXPECT().GetFormByID(gomock.Any(), calculated.ID).Return(calculated, nil)
+		repo.EXPECT().CreateSubmission(
+			gomock.Any(),
+			gomock.Any(),
+		).DoAndReturn(func(_ context.Context, s *model.FormSubmission) error {
+			require.InDelta(t, 10.0, s.Data["total"], 1e-9)
+			require.Equal(t, []any{
+				map[string]any{"field": "total", "overridden": true, "submitted": 1.0},
+			}, s.Metadata[domainform.CalculationMismatchesMetadataKey])
+
+			return nil
+		})
+		eventBus.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(nil).Times(3)
+		logger.EXPECT().With("form_id", calculated.ID).Return(logger)
+		logger.EXPECT().Warn("submitted calculated values do not match", "fields", []string{"total"})
+
+		svc := domainform.NewService(repo, eventBus, encryption.NewService(nil), logger)
+
+		require.NoError(t, svc.SubmitForm(t.Context(), &model.FormSubmission{
+			FormID: calculated.ID,
+			Data:   model.JSON{"quantity": 4.0, "total": 1.0},
+		}))
+	})
+}
+
+func TestService_CreateForm_sensitiveFields(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	form := model.NewForm("user123", "Intake", "", model.JSON{
+		"type": "object",
+		"components": []any{
+			map[string]any{"type": "textfield", "key": "ssn", "input": true, "sensitive": true},
+		},
+	})
+
+	svc := domainform.NewService(
+		mockform.NewMockRepository(ctrl), mockevents.NewMockEventBus(ctrl),
+		encryption.NewService(nil), mocklogging.NewMockLogger(ctrl),
+	)
+
+	err := svc.CreateForm(t.Context(), form)
+	require.ErrorIs(t, err, domainform.ErrEncryptionRequired)
+}
+
+func TestService_ImportBundle(t *testing.T) {
+	schema := model.JSON{
+		"type":       "object",
+		"components": []any{map[string]any{"type": "textfield", "key": "name"}},
+	}
+	source := model.NewForm("owner-1", "Contact", "", schema)
+	bundle := model.NewBundle(source)
+
+	t.Run("keeps free ID", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := mockform.NewMockRepository(ctrl)
+		eventBus := mockevents.NewMockEventBus(ctrl)
+
+		repo.EXPECT().GetFormByIDUnscoped(gomock.Any(), source.ID).
+			Return(nil, common.NewNotFoundError("get", "form", source.ID))
+		repo.EXPECT().CreateForm(gomock.Any(), gomock.Any()).Return(nil)
+		eventBus.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(nil)
+
+		svc := domainform.NewService(repo, eventBus, encryption.NewService(nil), mocklogging.NewMockLogger(ctrl))
+
+		form, err := svc.ImportBundle(t.Context(), "owner-2", bundle, "")
+		require.NoError(t, err)
+		require.Equal(t, source.ID, form.ID)
+		require.Equal(t, "owner-2", form.UserID)
+	})
+
+	t.Run("assigns new ID on conflict", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := mockform.NewMockRepository(ctrl)
+		eventBus := mockevents.NewMockEventBus(ctrl)
+
+		repo.EXPECT().GetFormByIDUnscoped(gomock.Any(), source.ID).Return(source, nil)
+		repo.EXPECT().CreateForm(gomock.Any(), gomock.Any()).Return(nil)
+		eventBus.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(nil)
+
+		svc := domainform.NewService(repo, eventBus, encryption.NewService(nil), mocklogging.NewMockLogger(ctrl))
+
+		form, err := svc.ImportBundle(t.Context(), "owner-1", bundle, model.ConflictNewID)
+		require.NoError(t, err)
+		require.NotEqual(t, source.ID, form.ID)
+	})
+
+	t.Run("fails on conflict when requested", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := mockform.NewMockRepository(ctrl)
+
+		repo.EXPECT().GetFormByIDUnscoped(gomock.Any(), source.ID).Return(source, nil)
+
+		svc := domainform.NewService(
+			repo, mockevents.NewMockEventBus(ctrl), encryption.NewService(nil), mocklogging.NewMockLogger(ctrl),
+		)
+
+		_, err := svc.ImportBundle(t.Context(), "owner-1", bundle, model.ConflictFail)
+		require.ErrorIs(t, err, domainform.ErrFormIDConflict)
+		require.True(t, domainerrors.IsConflictError(err))
+	})
+
+	deleted := *source
+	deleted.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+
+	for _, tt := range []struct {
+		name     string
+		userID   string
+		existing *model.Form
+	}{
+		{name: "assigns new ID when another user's form has it", userID: "owner-2", existing: source},
+		{name: "assigns new ID when a deleted form has it", userID: "owner-1", existing: &deleted},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mockform.NewMockRepository(ctrl)
+			eventBus := mockevents.NewMockEventBus(ctrl)
+
+			repo.EXPECT().GetFormByIDUnscoped(gomock.Any(), source.ID).Return(tt.existing, nil)
+			repo.EXPECT().CreateForm(gomock.Any(), gomock.Any()).Return(nil)
+			eventBus.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(nil)
+
+			svc := domainform.NewService(repo, eventBus, encryption.NewService(nil), mocklogging.NewMockLogger(ctrl))
+
+			form, err := svc.ImportBundle(t.Context(), tt.userID, bundle, model.ConflictFail)
+			require.NoError(t, err)
+			require.NotEqual(t, source.ID, form.ID)
+		})
+	}
+
+	t.Run("rejects unsupported version", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		svc := domainform.NewService(
+			mockform.NewMockRepository(ctrl), mockevents.NewMockEventBus(ctrl),
+			encryption.NewService(nil), mocklogging.NewMockLogger(ctrl),
+		)
+
+		future := *bundle
+		future.Version = model.BundleVersion + 1
+
+		_, err := svc.ImportBundle(t.Context(), "owner-1", &future, "")
+		require.ErrorIs(t, err, model.ErrUnsupportedBundleVersion)
+	})
+}
+
+func TestService_DuplicateForm(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mockform.NewMockRepository(ctrl)
+	eventBus := mockevents.NewMockEventBus(ctrl)
+
+	source := model.NewForm("owner-1", "Contact", "Get in touch", model.JSON{
+		"type":       "object",
+		"components": []any{map[string]any{"type": "textfield", "key": "name"}},
+	})
+	source.Status = "published"
+	source.SetCorsConfig([]string{"https://example.com"}, nil, nil)
+
+	repo.EXPECT().GetFormByID(gomock.Any(), source.ID).Return(source, nil)
+	repo.EXPECT().CreateForm(gomock.Any(), gomock.Any()).Return(nil)
+	eventBus.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(nil)
+
+	svc := domainform.NewService(repo, eventBus, encryption.NewService(nil), mocklogging.NewMockLogger(ctrl))
+
+	copied, err := svc.DuplicateForm(t.Context(), source.ID)
+	require.NoError(t, err)
+	require.NotEqual(t, source.ID, copied.ID)
+	require.Equal(t, source.UserID, copied.UserID)
+	require.Equal(t, "Copy of Contact", copied.Title)
+	require.Equal(t, "draft", copied.Status)
+	require.Equal(t, source.Schema, copied.Schema)
+
+	origins, _, _ := copied.GetCorsConfig()
+	require.Equal(t, []string{"https://example.com"}, origins)
+}
+
+func TestService_SchemaVersions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mockform.NewMockRepository(ctrl)
+	service := domainform.NewService(
+		repo, mockevents.NewMockEventBus(ctrl), encryption.NewService(nil), mocklogging.NewMockLogger(ctrl),
+	)
+
+	versions := []*model.SchemaVersion{
+		{Version: 1, Schema: model.JSON{"components": []any{}}},
+		{Version: 2, Schema: model.JSON{"components": []any{map[string]any{"key": "name"}}}},
+	}
+	repo.EXPECT().ListSchemaVersions(gomock.Any(), "form-1").Return(versions, nil)
+
+	got, err := service.SchemaVersions(t.Context(), "form-1")
+	require.NoError(t, err)
+	require.Equal(t, versions, got)
+
+	repo.EXPECT().ListSchemaVersions(gomock.Any(), "form-2").Return(nil, errors.New("database error"))
+
+	_, err = service.SchemaVersions(t.Context(), "form-2")
+	require.Error(t, err)
 }
@@ -4,6 +4,7 @@ package form
 
 import (
 	"context"
+	"time"
 
 	"github.com/goformx/goforms/internal/domain/common/repository"
 	"github.com/goformx/goforms/internal/domain/form/model"
@@ -39,4 +40,9 @@ type SubmissionRepository interface {
 	UpdateSubmission(ctx context.Context, submission *model.FormSubmission) error
 	// DeleteSubmission deletes a form submission
 	DeleteSubmission(ctx context.Context, id string) error
+	// CountOlderThan counts submissions made before the given time, optionally limited to one form
+	CountOlderThan(ctx context.Context, before time.Time, formID string) (int64, error)
+	// PurgeOlderThan permanently deletes submissions made before the given time,
+	// optionally limited to one form, and returns the number of rows removed
+	PurgeOlderThan(ctx context.Context, before time.Time, formID string) (int64, error)
 }
@@ -0,0 +1,111 @@
+package template
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+)
+
+//go:embed builtin/*.json
+var builtinFS embed.FS
+
+// ErrDuplicateTemplate is returned when a template ID is registered twice
+var ErrDuplicateTemplate = errors.New("duplicate template ID")
+
+// Registry holds templates that are available to every user
+type Registry struct {
+	templates map[string]*Template
+	order     []string
+}
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{
+		templates: make(map[string]*Template),
+	}
+}
+
+// NewBuiltinRegistry creates a registry with the built-in templates
+func NewBuiltinRegistry() (*Registry, error) {
+	registry := NewRegistry()
+	if err := registry.Load(builtinFS, "builtin"); err != nil {
+		return nil, err
+	}
+
+	return registry, nil
+}
+
+// Load registers every *.json template found in dir, in file name order
+func (r *Registry) Load(fsys fs.FS, dir string) error {
+	names, err := fs.Glob(fsys, path.Join(dir, "*.json"))
+	if err != nil {
+		return fmt.Errorf("list templates: %w", err)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		data, readErr := fs.ReadFile(fsys, name)
+		if readErr != nil {
+			return fmt.Errorf("read template %s: %w", name, readErr)
+		}
+
+		var t Template
+		if unmarshalErr := json.Unmarshal(data, &t); unmarshalErr != nil {
+			return fmt.Errorf("decode template %s: %w", name, unmarshalErr)
+		}
+
+		if registerErr := r.Register(&t); registerErr != nil {
+			return fmt.Errorf("register template %s: %w", name, registerErr)
+		}
+	}
+
+	return nil
+}
+
+// Register adds a built-in template. Its preview is derived from the schema
+// when not set explicitly.
+func (r *Registry) Register(t *Template) error {
+	if t.ID == "" {
+		return errors.New("template ID is required")
+	}
+
+	if err := t.Validate(); err != nil {
+		return err
+	}
+
+	if _, exists := r.templates[t.ID]; exists {
+		return fmt.Errorf("%w: %s", ErrDuplicateTemplate, t.ID)
+	}
+
+	if len(t.Preview) == 0 {
+		t.Preview = PreviewFromSchema(t.Schema)
+	}
+
+	t.BuiltIn = true
+	r.templates[t.ID] = t
+	r.order = append(r.order, t.ID)
+
+	return nil
+}
+
+// Get returns the template with the given ID
+func (r *Registry) Get(id string) (*Template, bool) {
+	t, ok := r.templates[id]
+
+	return t, ok
+}
+
+// List returns the registered templates in registration order
+func (r *Registry) List() []*Template {
+	templates := make([]*Template, 0, len(r.order))
+	for _, id := range r.order {
+		templates = append(templates, r.templates[id])
+	}
+
+	return templates
+}
@@ -0,0 +1,67 @@
+package template_test
+
+import (
+	"errors"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/domain/form/template"
+)
+
+func TestNewBuiltinRegistry(t *testing.T) {
+	registry, err := template.NewBuiltinRegistry()
+	require.NoError(t, err)
+
+	templates := registry.List()
+	ids := make([]string, 0, len(templates))
+
+	for _, tmpl := range templates {
+		ids = append(ids, tmpl.ID)
+
+		assert.True(t, tmpl.BuiltIn, tmpl.ID)
+		assert.NotEmpty(t, tmpl.Name, tmpl.ID)
+		assert.NotEmpty(t, tmpl.Description, tmpl.ID)
+		assert.NotEmpty(t, tmpl.Category, tmpl.ID)
+		assert.NotEmpty(t, tmpl.Preview, tmpl.ID)
+		require.NoError(t, tmpl.Validate(), tmpl.ID)
+	}
+
+	assert.ElementsMatch(t, []string{
+		"bug-report", "contact", "event-registration", "job-application", "nps-survey",
+	}, ids)
+}
+
+func TestRegistry_Load_duplicateID(t *testing.T) {
+	data := []byte(`{"id": "contact", "name": "Contact", "category": "contact",
+		"schema": {"components": [{"type": "textfield", "key": "name", "label": "Name", "input": true}]}}`)
+	fsys := fstest.MapFS{
+		"templates/a.json": {Data: data},
+		"templates/b.json": {Data: data},
+	}
+
+	registry := template.NewRegistry()
+	err := registry.Load(fsys, "templates")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, template.ErrDuplicateTemplate))
+}
+
+func TestPreviewFromSchema(t *testing.T) {
+	schema := map[string]any{
+		"components": []any{
+			map[string]any{"type": "textfield", "key": "name", "label": "Name", "input": true},
+			map[string]any{
+				"type": "panel",
+				"key":  "details",
+				"components": []any{
+					map[string]any{"type": "email", "key": "email", "label": "Email", "input": true},
+				},
+			},
+			map[string]any{"type": "button", "key": "submit", "label": "Submit", "input": true},
+		},
+	}
+
+	assert.Equal(t, []string{"Name", "Email"}, template.PreviewFromSchema(schema))
+}
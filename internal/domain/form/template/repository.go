@@ -0,0 +1,17 @@
+//go:generate mockgen -typed -source=repository.go -destination=../../../../test/mocks/template/mock_repository.go -package=template
+
+package template
+
+import "context"
+
+// Repository stores user-saved templates
+type Repository interface {
+	// Create stores a new template
+	Create(ctx context.Context, t *Template) error
+	// GetByID retrieves a template by ID
+	GetByID(ctx context.Context, id string) (*Template, error)
+	// ListByUser retrieves the templates saved by a user, newest first
+	ListByUser(ctx context.Context, userID string) ([]*Template, error)
+	// Delete removes a template
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,179 @@
+//go:generate mockgen -typed -source=service.go -destination=../../../../test/mocks/template/mock_service.go -package=template -mock_names=Service=MockService
+
+package template
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	domainerrors "github.com/goformx/goforms/internal/domain/common/errors"
+	"github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+)
+
+// ErrTemplateNotFound is returned when a template does not exist or belongs to another user
+var ErrTemplateNotFound = domainerrors.New(domainerrors.ErrCodeNotFound, "template not found", nil)
+
+// ErrBuiltInTemplate is returned when trying to delete a built-in template
+var ErrBuiltInTemplate = domainerrors.New(domainerrors.ErrCodeForbidden, "built-in templates cannot be deleted", nil)
+
+// Service defines the interface for form template operations
+type Service interface {
+	// ListTemplates returns the built-in templates followed by the user's own templates
+	ListTemplates(ctx context.Context, userID string) ([]*Template, error)
+	// GetTemplate returns a built-in template or one of the user's templates
+	GetTemplate(ctx context.Context, userID, id string) (*Template, error)
+	// CreateFormFromTemplate creates a new form for the user from a template.
+	// An empty title uses the template name.
+	CreateFormFromTemplate(ctx context.Context, userID, templateID, title string) (*model.Form, error)
+	// SaveFormAsTemplate saves a copy of a form's schema as a template owned by userID.
+	// An empty name uses the form title.
+	SaveFormAsTemplate(ctx context.Context, userID string, f *model.Form, name, description string) (*Template, error)
+	// DeleteTemplate deletes one of the user's templates
+	DeleteTemplate(ctx context.Context, userID, id string) error
+}
+
+// service implements Service
+type service struct {
+	registry    *Registry
+	repository  Repository
+	formService form.Service
+	logger      logging.Logger
+}
+
+// NewService creates a new template service
+func NewService(registry *Registry, repository Repository, formService form.Service, logger logging.Logger) Service {
+	return &service{
+		registry:    registry,
+		repository:  repository,
+		formService: formService,
+		logger:      logger,
+	}
+}
+
+// ListTemplates returns the built-in templates followed by the user's own templates
+func (s *service) ListTemplates(ctx context.Context, userID string) ([]*Template, error) {
+	templates := s.registry.List()
+
+	saved, err := s.repository.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list user templates: %w", err)
+	}
+
+	return append(templates, saved...), nil
+}
+
+// GetTemplate returns a built-in template or one of the user's templates
+func (s *service) GetTemplate(ctx context.Context, userID, id string) (*Template, error) {
+	if t, ok := s.registry.Get(id); ok {
+		return t, nil
+	}
+
+	t, err := s.repository.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			return nil, ErrTemplateNotFound
+		}
+
+		return nil, fmt.Errorf("get template: %w", err)
+	}
+
+	// Other users' templates are reported as missing rather than forbidden
+	if t.UserID != userID {
+		return nil, ErrTemplateNotFound
+	}
+
+	return t, nil
+}
+
+// CreateFormFromTemplate creates a new form for the user from a template. The
+// form is created through the form service so validation and events still apply.
+func (s *service) CreateFormFromTemplate(ctx context.Context, userID, templateID, title string) (*model.Form, error) {
+	t, err := s.GetTemplate(ctx, userID, templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	schema, err := t.CopySchema()
+	if err != nil {
+		return nil, err
+	}
+
+	if title == "" {
+		title = t.Name
+	}
+
+	f := model.NewForm(userID, title, t.Description, schema)
+	if createErr := s.formService.CreateForm(ctx, f); createErr != nil {
+		return nil, fmt.Errorf("create form from template %s: %w", templateID, createErr)
+	}
+
+	s.logger.Debug("form created from template", "form_id", f.ID, "template_id", templateID)
+
+	return f, nil
+}
+
+// SaveFormAsTemplate saves a copy of a form's schema as a template owned by userID
+func (s *service) SaveFormAsTemplate(
+	ctx context.Context,
+	userID string,
+	f *model.Form,
+	name, description string,
+) (*Template, error) {
+	if name == "" {
+		name = f.Title
+	}
+
+	if description == "" {
+		description = f.Description
+	}
+
+	t := &Template{
+		ID:          uuid.New().String(),
+		UserID:      userID,
+		Name:        name,
+		Description: description,
+		Category:    CategoryCustom,
+		Schema:      f.Schema,
+	}
+
+	schema, err := t.CopySchema()
+	if err != nil {
+		return nil, err
+	}
+
+	t.Schema = schema
+	t.Preview = PreviewFromSchema(schema)
+
+	if validateErr := t.Validate(); validateErr != nil {
+		return nil, domainerrors.Wrap(validateErr, domainerrors.ErrCodeValidation, "invalid template")
+	}
+
+	if createErr := s.repository.Create(ctx, t); createErr != nil {
+		return nil, fmt.Errorf("save template: %w", createErr)
+	}
+
+	return t, nil
+}
+
+// DeleteTemplate deletes one of the user's templates
+func (s *service) DeleteTemplate(ctx context.Context, userID, id string) error {
+	if _, builtIn := s.registry.Get(id); builtIn {
+		return ErrBuiltInTemplate
+	}
+
+	if _, err := s.GetTemplate(ctx, userID, id); err != nil {
+		return err
+	}
+
+	if err := s.repository.Delete(ctx, id); err != nil {
+		return fmt.Errorf("delete template: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,143 @@
+package template_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	domainerrors "github.com/goformx/goforms/internal/domain/common/errors"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/domain/form/template"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+	mockform "github.com/goformx/goforms/test/mocks/form"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+	mocktemplate "github.com/goformx/goforms/test/mocks/template"
+)
+
+func TestService_CreateFormFromTemplate_builtIn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	registry, err := template.NewBuiltinRegistry()
+	require.NoError(t, err)
+
+	formService := mockform.NewMockService(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	svc := template.NewService(registry, mocktemplate.NewMockRepository(ctrl), formService, logger)
+
+	var created *model.Form
+
+	formService.EXPECT().CreateForm(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, form *model.Form) error {
+			created = form
+
+			return nil
+		})
+	logger.EXPECT().Debug("form created from template", "form_id", gomock.Any(), "template_id", "contact")
+
+	form, err := svc.CreateFormFromTemplate(t.Context(), "user-1", "contact", "")
+	require.NoError(t, err)
+	require.Same(t, created, form)
+	assert.Equal(t, "user-1", form.UserID)
+	assert.Equal(t, "Contact form", form.Title)
+	assert.NotEmpty(t, form.Schema["components"])
+
+	// The form must not share its schema with the registry
+	builtIn, err := svc.GetTemplate(t.Context(), "user-1", "contact")
+	require.NoError(t, err)
+
+	form.Schema["title"] = "changed"
+	assert.NotContains(t, builtIn.Schema, "title")
+}
+
+func TestService_CreateFormFromTemplate_otherUsersTemplate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	registry, err := template.NewBuiltinRegistry()
+	require.NoError(t, err)
+
+	repo := mocktemplate.NewMockRepository(ctrl)
+	svc := template.NewService(registry, repo, mockform.NewMockService(ctrl), mocklogging.NewMockLogger(ctrl))
+
+	repo.EXPECT().GetByID(gomock.Any(), "tmpl-1").Return(&template.Template{
+		ID:     "tmpl-1",
+		UserID: "user-2",
+		Name:   "Private",
+		Schema: model.JSON{"components": []any{}},
+	}, nil)
+
+	_, err = svc.CreateFormFromTemplate(t.Context(), "user-1", "tmpl-1", "Title")
+	require.ErrorIs(t, err, template.ErrTemplateNotFound)
+}
+
+func TestService_CreateFormFromTemplate_missing(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	registry, err := template.NewBuiltinRegistry()
+	require.NoError(t, err)
+
+	repo := mocktemplate.NewMockRepository(ctrl)
+	svc := template.NewService(registry, repo, mockform.NewMockService(ctrl), mocklogging.NewMockLogger(ctrl))
+
+	repo.EXPECT().GetByID(gomock.Any(), "missing").
+		Return(nil, common.NewNotFoundError("get_template", "template", "missing"))
+
+	_, err = svc.CreateFormFromTemplate(t.Context(), "user-1", "missing", "")
+	require.ErrorIs(t, err, template.ErrTemplateNotFound)
+	assert.True(t, domainerrors.IsNotFound(err))
+}
+
+func TestService_SaveFormAsTemplate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	registry, err := template.NewBuiltinRegistry()
+	require.NoError(t, err)
+
+	repo := mocktemplate.NewMockRepository(ctrl)
+	svc := template.NewService(registry, repo, mockform.NewMockService(ctrl), mocklogging.NewMockLogger(ctrl))
+
+	source := model.NewForm("user-1", "Feedback", "Tell us what you think", model.JSON{
+		"components": []any{
+			map[string]any{"type": "textarea", "key": "comments", "label": "Comments", "input": true},
+		},
+	})
+
+	repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+	saved, err := svc.SaveFormAsTemplate(t.Context(), "user-1", source, "", "")
+	require.NoError(t, err)
+	assert.Equal(t, "Feedback", saved.Name)
+	assert.Equal(t, "Tell us what you think", saved.Description)
+	assert.Equal(t, template.CategoryCustom, saved.Category)
+	assert.Equal(t, []string{"Comments"}, saved.Preview)
+	assert.False(t, saved.BuiltIn)
+}
+
+func TestService_DeleteTemplate(t *testing.T) {
+	t.Run("built-in", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		registry, err := template.NewBuiltinRegistry()
+		require.NoError(t, err)
+
+		svc := template.NewService(
+			registry, mocktemplate.NewMockRepository(ctrl), mockform.NewMockService(ctrl), mocklogging.NewMockLogger(ctrl),
+		)
+
+		err = svc.DeleteTemplate(t.Context(), "user-1", "nps-survey")
+		require.True(t, errors.Is(err, template.ErrBuiltInTemplate))
+	})
+
+	t.Run("own template", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		registry, err := template.NewBuiltinRegistry()
+		require.NoError(t, err)
+
+		repo := mocktemplate.NewMockRepository(ctrl)
+		svc := template.NewService(registry, repo, mockform.NewMockService(ctrl), mocklogging.NewMockLogger(ctrl))
+
+		repo.EXPECT().GetByID(gomock.Any(), "tmpl-1").
+			Return(&template.Template{ID: "tmpl-1", UserID: "user-1"}, nil)
+		repo.EXPECT().Delete(gomock.Any(), "tmpl-1").Return(nil)
+
+		require.NoError(t, svc.DeleteTemplate(t.Context(), "user-1", "tmpl-1"))
+	})
+}
@@ -0,0 +1,137 @@
+// Package template provides form templates: a registry of built-in starting
+// points plus templates users save from their own forms.
+package template
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+)
+
+// Template categories
+const (
+	CategoryContact = "contact"
+	CategoryEvents  = "events"
+	CategorySurveys = "surveys"
+	CategoryHR      = "hr"
+	CategorySupport = "support"
+	CategoryCustom  = "custom"
+)
+
+const (
+	// MaxNameLength is the maximum length of a template name
+	MaxNameLength = 100
+	// MaxDescriptionLength is the maximum length of a template description
+	MaxDescriptionLength = 500
+	// maxPreviewFields limits how many field labels a preview lists
+	maxPreviewFields = 8
+)
+
+var (
+	// ErrNameRequired is returned when a template has no name
+	ErrNameRequired = errors.New("template name is required")
+	// ErrSchemaRequired is returned when a template has no schema
+	ErrSchemaRequired = errors.New("template schema is required")
+)
+
+// Template is a reusable form definition. Built-in templates live in the
+// registry; user templates are stored in the form_templates table.
+type Template struct {
+	ID          string         `json:"id" gorm:"column:uuid;primaryKey"`
+	UserID      string         `json:"-" gorm:"not null;index"`
+	Name        string         `json:"name" gorm:"not null;size:100"`
+	Description string         `json:"description" gorm:"size:500"`
+	Category    string         `json:"category" gorm:"not null;size:50"`
+	Preview     []string       `json:"preview" gorm:"serializer:json"`
+	Schema      model.JSON     `json:"schema" gorm:"type:json;not null"`
+	BuiltIn     bool           `json:"built_in" gorm:"-"`
+	CreatedAt   time.Time      `json:"created_at" gorm:"not null;autoCreateTime"`
+	UpdatedAt   time.Time      `json:"updated_at" gorm:"not null;autoUpdateTime"`
+	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+// TableName specifies the table name for the Template model
+func (t *Template) TableName() string {
+	return "form_templates"
+}
+
+// Validate validates the template
+func (t *Template) Validate() error {
+	if t.Name == "" {
+		return ErrNameRequired
+	}
+
+	if len(t.Name) > MaxNameLength {
+		return fmt.Errorf("template name must not exceed %d characters", MaxNameLength)
+	}
+
+	if len(t.Description) > MaxDescriptionLength {
+		return fmt.Errorf("template description must not exceed %d characters", MaxDescriptionLength)
+	}
+
+	if t.Schema == nil {
+		return ErrSchemaRequired
+	}
+
+	return nil
+}
+
+// CopySchema returns a deep copy of the template schema so forms created from
+// it never share nested maps with the template
+func (t *Template) CopySchema() (model.JSON, error) {
+	data, err := json.Marshal(t.Schema)
+	if err != nil {
+		return nil, fmt.Errorf("copy template schema: %w", err)
+	}
+
+	var schema model.JSON
+	if unmarshalErr := json.Unmarshal(data, &schema); unmarshalErr != nil {
+		return nil, fmt.Errorf("copy template schema: %w", unmarshalErr)
+	}
+
+	return schema, nil
+}
+
+// PreviewFromSchema lists the labels of the input fields in a Form.io schema,
+// used as a short preview of what a template contains
+func PreviewFromSchema(schema model.JSON) []string {
+	preview := []string{}
+
+	var walk func(components []any)
+
+	walk = func(components []any) {
+		for _, c := range components {
+			if len(preview) >= maxPreviewFields {
+				return
+			}
+
+			component, ok := c.(map[string]any)
+			if !ok {
+				continue
+			}
+
+			input, _ := component["input"].(bool)
+			label, _ := component["label"].(string)
+			componentType, _ := component["type"].(string)
+
+			if input && label != "" && componentType != "button" {
+				preview = append(preview, label)
+			}
+
+			if nested, hasNested := component["components"].([]any); hasNested {
+				walk(nested)
+			}
+		}
+	}
+
+	if components, ok := schema["components"].([]any); ok {
+		walk(components)
+	}
+
+	return preview
+}
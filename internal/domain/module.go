@@ -10,17 +10,35 @@
 package domain
 
 import (
+	"context"
 	"errors"
+	"fmt"
 
 	"go.uber.org/fx"
 
+	"github.com/goformx/goforms/internal/domain/audit"
 	"github.com/goformx/goforms/internal/domain/common/events"
+	"github.com/goformx/goforms/internal/domain/eventstore"
 	"github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/form/draft"
+	"github.com/goformx/goforms/internal/domain/form/encryption"
+	"github.com/goformx/goforms/internal/domain/form/reencrypt"
+	"github.com/goformx/goforms/internal/domain/form/retention"
+	"github.com/goformx/goforms/internal/domain/form/revision"
+	"github.com/goformx/goforms/internal/domain/form/template"
 	"github.com/goformx/goforms/internal/domain/user"
+	"github.com/goformx/goforms/internal/infrastructure/config"
 	"github.com/goformx/goforms/internal/infrastructure/database"
 	"github.com/goformx/goforms/internal/infrastructure/logging"
+	auditstore "github.com/goformx/goforms/internal/infrastructure/repository/audit"
+	eventstorestore "github.com/goformx/goforms/internal/infrastructure/repository/eventstore"
 	formstore "github.com/goformx/goforms/internal/infrastructure/repository/form"
+	formdraftstore "github.com/goformx/goforms/internal/infrastructure/repository/form/draft"
+	formreencryptstore "github.com/goformx/goforms/internal/infrastructure/repository/form/reencrypt"
+	formretentionstore "github.com/goformx/goforms/internal/infrastructure/repository/form/retention"
+	formrevisionstore "github.com/goformx/goforms/internal/infrastructure/repository/form/revision"
 	formsubmissionstore "github.com/goformx/goforms/internal/infrastructure/repository/form/submission"
+	formtemplatestore "github.com/goformx/goforms/internal/infrastructure/repository/form/template"
 	userstore "github.com/goformx/goforms/internal/infrastructure/repository/user"
 )
 
@@ -30,6 +48,7 @@ type UserServiceParams struct {
 
 	Repo   user.Repository
 	Logger logging.Logger
+	Config *config.Config
 }
 
 // NewUserService creates a new user service with dependencies
@@ -42,7 +61,14 @@ func NewUserService(p UserServiceParams) (user.Service, error) {
 		return nil, errors.New("logger is required")
 	}
 
-	return user.NewService(p.Repo, p.Logger), nil
+	if p.Config == nil {
+		return nil, errors.New("config is required")
+	}
+
+	return user.NewService(p.Repo, p.Logger, user.Lockout{
+		MaxAttempts: p.Config.Auth.MaxLoginAttempts,
+		Duration:    p.Config.Auth.LockoutDuration,
+	}), nil
 }
 
 // FormServiceParams contains dependencies for creating a form service
@@ -51,6 +77,7 @@ type FormServiceParams struct {
 
 	Repository form.Repository
 	EventBus   events.EventBus
+	Encryption encryption.Service
 	Logger     logging.Logger
 }
 
@@ -64,11 +91,243 @@ func NewFormService(p FormServiceParams) (form.Service, error) {
 		return nil, errors.New("event bus is required")
 	}
 
+	if p.Encryption == nil {
+		return nil, errors.New("encryption service is required")
+	}
+
 	if p.Logger == nil {
 		return nil, errors.New("logger is required")
 	}
 
-	return form.NewService(p.Repository, p.EventBus, p.Logger), nil
+	return form.NewService(p.Repository, p.EventBus, p.Encryption, p.Logger), nil
+}
+
+// TemplateServiceParams contains dependencies for creating a form template service
+type TemplateServiceParams struct {
+	fx.In
+
+	Repository  template.Repository
+	FormService form.Service
+	Logger      logging.Logger
+}
+
+// NewTemplateService creates a form template service backed by the built-in template registry
+func NewTemplateService(p TemplateServiceParams) (template.Service, error) {
+	if p.Repository == nil {
+		return nil, errors.New("template repository is required")
+	}
+
+	if p.FormService == nil {
+		return nil, errors.New("form service is required")
+	}
+
+	if p.Logger == nil {
+		return nil, errors.New("logger is required")
+	}
+
+	registry, err := template.NewBuiltinRegistry()
+	if err != nil {
+		return nil, fmt.Errorf("load built-in templates: %w", err)
+	}
+
+	return template.NewService(registry, p.Repository, p.FormService, p.Logger), nil
+}
+
+// DraftServiceParams contains dependencies for creating a draft submission service
+type DraftServiceParams struct {
+	fx.In
+
+	Repository  draft.Repository
+	FormService form.Service
+	Encryption  encryption.Service
+	Logger      logging.Logger
+}
+
+// NewDraftService creates a save-and-resume draft service
+func NewDraftService(p DraftServiceParams) (draft.Service, error) {
+	if p.Repository == nil {
+		return nil, errors.New("draft repository is required")
+	}
+
+	if p.FormService == nil {
+		return nil, errors.New("form service is required")
+	}
+
+	if p.Encryption == nil {
+		return nil, errors.New("encryption service is required")
+	}
+
+	if p.Logger == nil {
+		return nil, errors.New("logger is required")
+	}
+
+	return draft.NewService(p.Repository, p.FormService, p.Encryption, p.Logger), nil
+}
+
+// RevisionServiceParams contains dependencies for creating a submission revision service
+type RevisionServiceParams struct {
+	fx.In
+
+	Repository  revision.Repository
+	FormService form.Service
+	Encryption  encryption.Service
+	EventBus    events.EventBus
+	Logger      logging.Logger
+}
+
+// NewRevisionService creates a service for editing submissions with a revision history
+func NewRevisionService(p RevisionServiceParams) (revision.Service, error) {
+	if p.Repository == nil {
+		return nil, errors.New("revision repository is required")
+	}
+
+	if p.FormService == nil {
+		return nil, errors.New("form service is required")
+	}
+
+	if p.Encryption == nil {
+		return nil, errors.New("encryption service is required")
+	}
+
+	if p.EventBus == nil {
+		return nil, errors.New("event bus is required")
+	}
+
+	if p.Logger == nil {
+		return nil, errors.New("logger is required")
+	}
+
+	return revision.NewService(p.Repository, p.FormService, p.Encryption, p.EventBus, p.Logger), nil
+}
+
+// AuditServiceParams contains dependencies for creating an audit log service
+type AuditServiceParams struct {
+	fx.In
+
+	Repository audit.Repository
+	Logger     logging.Logger
+}
+
+// NewAuditService creates the audit log service
+func NewAuditService(p AuditServiceParams) (audit.Service, error) {
+	if p.Repository == nil {
+		return nil, errors.New("audit repository is required")
+	}
+
+	if p.Logger == nil {
+		return nil, errors.New("logger is required")
+	}
+
+	return audit.NewService(p.Repository, p.Logger), nil
+}
+
+// RegisterAuditSubscriber subscribes the audit log to domain events when the
+// application starts
+func RegisterAuditSubscriber(lc fx.Lifecycle, subscriber *audit.Subscriber, bus events.EventBus) {
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			if err := subscriber.Subscribe(ctx, bus); err != nil {
+				return fmt.Errorf("register audit subscriber: %w", err)
+			}
+
+			return nil
+		},
+	})
+}
+
+// EventStoreServiceParams contains dependencies for creating an event log service
+type EventStoreServiceParams struct {
+	fx.In
+
+	Repository eventstore.Repository
+	Logger     logging.Logger
+}
+
+// NewEventStoreService creates the event log service
+func NewEventStoreService(p EventStoreServiceParams) (eventstore.Service, error) {
+	if p.Repository == nil {
+		return nil, errors.New("event store repository is required")
+	}
+
+	if p.Logger == nil {
+		return nil, errors.New("logger is required")
+	}
+
+	return eventstore.NewService(p.Repository, p.Logger), nil
+}
+
+// RegisterEventStoreSubscriber records the domain events published on this
+// instance in the event log when the application starts
+func RegisterEventStoreSubscriber(lc fx.Lifecycle, subscriber *eventstore.Subscriber, bus events.EventBus) {
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			if err := subscriber.Subscribe(ctx, bus); err != nil {
+				return fmt.Errorf("register event store subscriber: %w", err)
+			}
+
+			return nil
+		},
+	})
+}
+
+// RetentionServiceParams contains dependencies for creating a submission retention service
+type RetentionServiceParams struct {
+	fx.In
+
+	Repository   retention.Repository
+	AuditService audit.Service
+	Encryption   encryption.Service
+	Logger       logging.Logger
+	Config       *config.Config
+}
+
+// NewRetentionService creates the service behind retention rules and erasure requests
+func NewRetentionService(p RetentionServiceParams) (retention.Service, error) {
+	if p.Repository == nil {
+		return nil, errors.New("retention repository is required")
+	}
+
+	if p.AuditService == nil {
+		return nil, errors.New("audit service is required")
+	}
+
+	if p.Encryption == nil {
+		return nil, errors.New("encryption service is required")
+	}
+
+	if p.Logger == nil {
+		return nil, errors.New("logger is required")
+	}
+
+	if p.Config == nil {
+		return nil, errors.New("config is required")
+	}
+
+	return retention.NewService(
+		p.Repository, p.AuditService, p.Encryption, p.Logger, p.Config.Form.Retention.PurgeAfter,
+	), nil
+}
+
+// ReencryptServiceParams contains dependencies for creating a re-encryption service
+type ReencryptServiceParams struct {
+	fx.In
+
+	Repository reencrypt.Repository
+	Encryption encryption.Service
+}
+
+// NewReencryptService creates the service that keeps stored answers encrypted
+// under the active key
+func NewReencryptService(p ReencryptServiceParams) (reencrypt.Service, error) {
+	if p.Repository == nil {
+		return nil, errors.New("re-encryption repository is required")
+	}
+
+	if p.Encryption == nil {
+		return nil, errors.New("encryption service is required")
+	}
+
+	return reencrypt.NewService(p.Repository, p.Encryption), nil
 }
 
 // StoreParams groups store dependencies
@@ -84,6 +343,13 @@ type Stores struct {
 	UserRepository           user.Repository
 	FormRepository           form.Repository
 	FormSubmissionRepository form.SubmissionRepository
+	FormTemplateRepository   template.Repository
+	FormDraftRepository      draft.Repository
+	FormRevisionRepository   revision.Repository
+	AuditRepository          audit.Repository
+	EventStoreRepository     eventstore.Repository
+	RetentionRepository      retention.Repository
+	ReencryptRepository      reencrypt.Repository
 }
 
 // NewStores creates new store instances with proper validation and error handling
@@ -100,12 +366,21 @@ func NewStores(p StoreParams) (Stores, error) {
 	userRepo := userstore.NewStore(p.DB, p.Logger)
 	formRepo := formstore.NewStore(p.DB, p.Logger)
 	formSubmissionRepo := formsubmissionstore.NewStore(p.DB, p.Logger)
+	formTemplateRepo := formtemplatestore.NewStore(p.DB, p.Logger)
+	formDraftRepo := formdraftstore.NewStore(p.DB, p.Logger)
+	formRevisionRepo := formrevisionstore.NewStore(p.DB, p.Logger)
+	auditRepo := auditstore.NewStore(p.DB, p.Logger)
+	eventStoreRepo := eventstorestore.NewStore(p.DB, p.Logger)
+	retentionRepo := formretentionstore.NewStore(p.DB, p.Logger)
+	reencryptRepo := formreencryptstore.NewStore(p.DB, p.Logger)
 
 	// Validate repository instances
-	if userRepo == nil || formRepo == nil || formSubmissionRepo == nil {
+	if userRepo == nil || formRepo == nil || formSubmissionRepo == nil || formTemplateRepo == nil ||
+		formDraftRepo == nil || formRevisionRepo == nil || auditRepo == nil || eventStoreRepo == nil ||
+		retentionRepo == nil || reencryptRepo == nil {
 		p.Logger.Error("failed to create repository",
 			"operation", "repository_initialization",
-			"repository_type", "user/form/submission",
+			"repository_type", "user/form/submission/template/draft/revision/audit/eventstore/retention/reencrypt",
 			"error_type", "nil_repository",
 		)
 
@@ -117,6 +392,13 @@ func NewStores(p StoreParams) (Stores, error) {
 		UserRepository:           userRepo,
 		FormRepository:           formRepo,
 		FormSubmissionRepository: formSubmissionRepo,
+		FormTemplateRepository:   formTemplateRepo,
+		FormDraftRepository:      formDraftRepo,
+		FormRevisionRepository:   formRevisionRepo,
+		AuditRepository:          auditRepo,
+		EventStoreRepository:     eventStoreRepo,
+		RetentionRepository:      retentionRepo,
+		ReencryptRepository:      reencryptRepo,
 	}, nil
 }
 
@@ -133,6 +415,44 @@ var Module = fx.Module("domain",
 			NewFormService,
 			fx.As(new(form.Service)),
 		),
+		// Form template service
+		fx.Annotate(
+			NewTemplateService,
+			fx.As(new(template.Service)),
+		),
+		// Draft submission service
+		fx.Annotate(
+			NewDraftService,
+			fx.As(new(draft.Service)),
+		),
+		// Submission revision service
+		fx.Annotate(
+			NewRevisionService,
+			fx.As(new(revision.Service)),
+		),
+		// Audit log service and its domain event subscriber
+		fx.Annotate(
+			NewAuditService,
+			fx.As(new(audit.Service)),
+		),
+		audit.NewSubscriber,
+		// Domain event log and the subscriber that records events in it
+		fx.Annotate(
+			NewEventStoreService,
+			fx.As(new(eventstore.Service)),
+		),
+		eventstore.NewSubscriber,
+		// Submission retention and erasure service
+		fx.Annotate(
+			NewRetentionService,
+			fx.As(new(retention.Service)),
+		),
+		// Re-encryption of stored answers after key rotation
+		fx.Annotate(
+			NewReencryptService,
+			fx.As(new(reencrypt.Service)),
+		),
 		NewStores,
 	),
+	fx.Invoke(RegisterAuditSubscriber, RegisterEventStoreSubscriber),
 )
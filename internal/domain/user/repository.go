@@ -5,6 +5,7 @@ package user
 
 import (
 	"context"
+	"time"
 
 	"github.com/goformx/goforms/internal/domain/common/repository"
 	"github.com/goformx/goforms/internal/domain/entities"
@@ -23,4 +24,7 @@ type Repository interface {
 	GetActiveUsers(ctx context.Context, offset, limit int) ([]*entities.User, error)
 	// GetInactiveUsers gets all inactive users
 	GetInactiveUsers(ctx context.Context, offset, limit int) ([]*entities.User, error)
+	// RevokeSessions revokes the sessions started up to at by one user, or by
+	// every user when userID is empty, and returns the number of users touched
+	RevokeSessions(ctx context.Context, userID string, at time.Time) (int64, error)
 }
@@ -6,17 +6,21 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	domainerrors "github.com/goformx/goforms/internal/domain/common/errors"
 	"github.com/goformx/goforms/internal/domain/entities"
 	"github.com/goformx/goforms/internal/infrastructure/logging"
 )
 
-// Signup represents a user signup request
+// Signup represents a user signup request. The message tags pick the messages
+// the client shows for the password rules.
 type Signup struct {
 	Email           string `json:"email" validate:"required,email"`
-	Password        string `json:"password" validate:"required,min=8"`
-	ConfirmPassword string `json:"confirm_password" validate:"required,eqfield=password"`
+	Password        string `json:"password" validate:"required,min=8" message:"min=password"`
+	ConfirmPassword string `json:"confirm_password" validate:"required,eqfield=password" message:"eqfield=password_match"`
+	// Locale is the language the user signed up in, saved as their preference
+	Locale string `json:"-"`
 }
 
 // Login represents a user login request
@@ -39,8 +43,22 @@ var (
 	ErrInvalidCredentials = domainerrors.New(domainerrors.ErrCodeAuthentication, "invalid credentials", nil)
 	// ErrUserExists indicates that a user with the given email already exists
 	ErrUserExists = domainerrors.New(domainerrors.ErrCodeAlreadyExists, "user already exists", nil)
+	// ErrAccountLocked indicates that logins are refused for a while after too
+	// many failed attempts
+	ErrAccountLocked = domainerrors.New(
+		domainerrors.ErrCodeAuthentication, "too many failed login attempts, try again later", nil,
+	)
+	// ErrLockedOut indicates that a failed login attempt locked the account
+	ErrLockedOut = domainerrors.New(domainerrors.ErrCodeAuthentication, "account locked", ErrAccountLocked)
 )
 
+// Lockout is how many failed login attempts in a row lock an account, and for
+// how long. A MaxAttempts of zero turns the lockout off.
+type Lockout struct {
+	MaxAttempts int
+	Duration    time.Duration
+}
+
 // Service defines the user service interface
 type Service interface {
 	SignUp(ctx context.Context, signup *Signup) (*entities.User, error)
@@ -53,19 +71,22 @@ type Service interface {
 	ListUsers(ctx context.Context, offset, limit int) ([]*entities.User, error)
 	GetByID(ctx context.Context, id string) (*entities.User, error)
 	Authenticate(ctx context.Context, email, password string) (*entities.User, error)
+	RevokeSessions(ctx context.Context, userID string) (int64, error)
 }
 
 // ServiceImpl implements the Service interface
 type ServiceImpl struct {
-	logger logging.Logger
-	repo   Repository
+	logger  logging.Logger
+	repo    Repository
+	lockout Lockout
 }
 
 // NewService creates a new user service
-func NewService(repo Repository, logger logging.Logger) Service {
+func NewService(repo Repository, logger logging.Logger, lockout Lockout) Service {
 	return &ServiceImpl{
-		repo:   repo,
-		logger: logger,
+		repo:    repo,
+		logger:  logger,
+		lockout: lockout,
 	}
 }
 
@@ -96,6 +117,8 @@ func (s *ServiceImpl) SignUp(ctx context.Context, signup *Signup) (*entities.Use
 		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
 
+	user.Locale = signup.Locale
+
 	// Save user
 	if createErr := s.repo.Create(ctx, user); createErr != nil {
 		s.logger.Error("failed to create user", "error", createErr)
@@ -119,17 +142,63 @@ func (s *ServiceImpl) Login(ctx context.Context, login *Login) (*LoginResponse,
 		return nil, ErrInvalidCredentials
 	}
 
+	// A locked account refuses even the right password, so the lockout cannot
+	// be used to confirm a guess
+	if user.Locked(time.Now()) {
+		s.logger.Warn("login attempt for a locked user", "user_id", user.ID)
+
+		return nil, ErrAccountLocked
+	}
+
 	if !user.CheckPassword(login.Password) {
 		s.logger.Error("password mismatch", "email", login.Email)
 
+		return nil, s.failLogin(ctx, user)
+	}
+
+	// Disabled users are refused like a wrong password, so a login attempt
+	// does not reveal that the account exists
+	if !user.Active {
+		s.logger.Warn("login attempt for a disabled user", "user_id", user.ID)
+
 		return nil, ErrInvalidCredentials
 	}
 
+	if user.FailedLoginAttempts > 0 || user.LockedUntil != nil {
+		user.ResetFailedLogins()
+
+		if err = s.repo.Update(ctx, user); err != nil {
+			s.logger.Error("failed to reset failed login attempts", "user_id", user.ID, "error", err)
+		}
+	}
+
 	return &LoginResponse{
 		User: user,
 	}, nil
 }
 
+// failLogin counts a failed login attempt against user, locking the account
+// once the lockout threshold is reached, and returns the error to report
+func (s *ServiceImpl) failLogin(ctx context.Context, user *entities.User) error {
+	if s.lockout.MaxAttempts <= 0 {
+		return ErrInvalidCredentials
+	}
+
+	locked := user.RecordFailedLogin(time.Now(), s.lockout.MaxAttempts, s.lockout.Duration)
+
+	if err := s.repo.Update(ctx, user); err != nil {
+		s.logger.Error("failed to record failed login attempt", "user_id", user.ID, "error", err)
+	}
+
+	if locked {
+		s.logger.Warn("user locked out after failed login attempts", "user_id", user.ID)
+
+		return ErrLockedOut
+	}
+
+	return ErrInvalidCredentials
+}
+
 // Logout handles user logout
 func (s *ServiceImpl) Logout(_ context.Context) error {
 	// Session-based logout is handled by session middleware
@@ -207,9 +276,20 @@ func (s *ServiceImpl) Authenticate(ctx context.Context, email, password string)
 		return nil, fmt.Errorf("authenticate user: %w", wrappedErr)
 	}
 
-	if !user.CheckPassword(password) {
+	if !user.CheckPassword(password) || !user.Active {
 		return nil, ErrInvalidCredentials
 	}
 
 	return user, nil
 }
+
+// RevokeSessions ends every session started so far by one user, or by every
+// user when userID is empty, on the session's next request
+func (s *ServiceImpl) RevokeSessions(ctx context.Context, userID string) (int64, error) {
+	revoked, err := s.repo.RevokeSessions(ctx, userID, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("revoke sessions: %w", err)
+	}
+
+	return revoked, nil
+}
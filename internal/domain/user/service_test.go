@@ -23,7 +23,7 @@ func TestService_SignUp(t *testing.T) {
 	repo := mockuser.NewMockRepository(ctrl)
 	logger := mocklogging.NewMockLogger(ctrl)
 
-	svc := user.NewService(repo, logger)
+	svc := user.NewService(repo, logger, user.Lockout{})
 
 	t.Run("successful signup", func(t *testing.T) {
 		signup := &user.Signup{
@@ -172,7 +172,7 @@ func TestService_Login(t *testing.T) {
 	repo := mockuser.NewMockRepository(ctrl)
 	logger := mocklogging.NewMockLogger(ctrl)
 
-	svc := user.NewService(repo, logger)
+	svc := user.NewService(repo, logger, user.Lockout{})
 
 	t.Run("successful login", func(t *testing.T) {
 		login := &user.Login{
@@ -238,6 +238,24 @@ func TestService_Login(t *testing.T) {
 		assert.ErrorIs(t, err, user.ErrInvalidCredentials)
 	})
 
+	t.Run("disabled user", func(t *testing.T) {
+		login := &user.Login{
+			Email:    "disabled@example.com",
+			Password: "password123",
+		}
+
+		testUser, err := entities.NewUser(login.Email, login.Password, "Test", "User")
+		require.NoError(t, err)
+		testUser.Deactivate()
+
+		repo.EXPECT().GetByEmail(gomock.Any(), login.Email).Return(testUser, nil)
+		logger.EXPECT().Warn("login attempt for a disabled user", "user_id", testUser.ID)
+
+		result, err := svc.Login(t.Context(), login)
+		require.ErrorIs(t, err, user.ErrInvalidCredentials)
+		require.Nil(t, result)
+	})
+
 	t.Run("database error", func(t *testing.T) {
 		login := &user.Login{
 			Email:    "test@example.com",
@@ -257,6 +275,66 @@ func TestService_Login(t *testing.T) {
 	})
 }
 
+func TestService_Login_lockout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mockuser.NewMockRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+
+	svc := user.NewService(repo, logger, user.Lockout{MaxAttempts: 2, Duration: 15 * time.Minute})
+
+	testUser, err := entities.NewUser("test@example.com", "password123", "Test", "User")
+	require.NoError(t, err)
+
+	wrong := &user.Login{Email: testUser.Email, Password: "wrongpassword"}
+	right := &user.Login{Email: testUser.Email, Password: "password123"}
+
+	repo.EXPECT().GetByEmail(gomock.Any(), testUser.Email).Return(testUser, nil).AnyTimes()
+	logger.EXPECT().Error("password mismatch", "email", testUser.Email).AnyTimes()
+
+	t.Run("failed attempts below the threshold", func(t *testing.T) {
+		repo.EXPECT().Update(gomock.Any(), testUser).Return(nil)
+
+		_, err := svc.Login(t.Context(), wrong)
+		require.ErrorIs(t, err, user.ErrInvalidCredentials)
+		assert.Equal(t, 1, testUser.FailedLoginAttempts)
+		assert.Nil(t, testUser.LockedUntil)
+	})
+
+	t.Run("the attempt that reaches the threshold locks the account", func(t *testing.T) {
+		repo.EXPECT().Update(gomock.Any(), testUser).Return(nil)
+		logger.EXPECT().Warn("user locked out after failed login attempts", "user_id", testUser.ID)
+
+		_, err := svc.Login(t.Context(), wrong)
+		require.ErrorIs(t, err, user.ErrLockedOut)
+		require.ErrorIs(t, err, user.ErrAccountLocked)
+		require.NotNil(t, testUser.LockedUntil)
+		assert.WithinDuration(t, time.Now().Add(15*time.Minute), *testUser.LockedUntil, time.Minute)
+	})
+
+	t.Run("a locked account refuses the right password", func(t *testing.T) {
+		logger.EXPECT().Warn("login attempt for a locked user", "user_id", testUser.ID)
+
+		_, err := svc.Login(t.Context(), right)
+		require.ErrorIs(t, err, user.ErrAccountLocked)
+		require.NotErrorIs(t, err, user.ErrLockedOut)
+	})
+
+	t.Run("a login after the lockout resets it", func(t *testing.T) {
+		expired := time.Now().Add(-time.Minute)
+		testUser.LockedUntil = &expired
+
+		repo.EXPECT().Update(gomock.Any(), testUser).Return(nil)
+
+		result, err := svc.Login(t.Context(), right)
+		require.NoError(t, err)
+		assert.Equal(t, testUser, result.User)
+		assert.Zero(t, testUser.FailedLoginAttempts)
+		assert.Nil(t, testUser.LockedUntil)
+	})
+}
+
 func TestService_GetUserByID(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	t.Cleanup(ctrl.Finish)
@@ -264,7 +342,7 @@ func TestService_GetUserByID(t *testing.T) {
 	repo := mockuser.NewMockRepository(ctrl)
 	logger := mocklogging.NewMockLogger(ctrl)
 
-	svc := user.NewService(repo, logger)
+	svc := user.NewService(repo, logger, user.Lockout{})
 
 	t.Run("user found", func(t *testing.T) {
 		userID := "test-user-id"
@@ -305,7 +383,7 @@ func TestService_GetUserByEmail(t *testing.T) {
 	repo := mockuser.NewMockRepository(ctrl)
 	logger := mocklogging.NewMockLogger(ctrl)
 
-	svc := user.NewService(repo, logger)
+	svc := user.NewService(repo, logger, user.Lockout{})
 
 	t.Run("user found", func(t *testing.T) {
 		email := "test@example.com"
@@ -338,3 +416,35 @@ func TestService_GetUserByEmail(t *testing.T) {
 		assert.ErrorIs(t, err, user.ErrUserNotFound)
 	})
 }
+
+func TestService_RevokeSessions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mockuser.NewMockRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+
+	svc := user.NewService(repo, logger, user.Lockout{})
+
+	t.Run("revokes up to now", func(t *testing.T) {
+		before := time.Now()
+
+		repo.EXPECT().RevokeSessions(gomock.Any(), "test-user-id", gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, at time.Time) (int64, error) {
+				assert.False(t, at.Before(before))
+
+				return 1, nil
+			})
+
+		revoked, err := svc.RevokeSessions(context.Background(), "test-user-id")
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), revoked)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		repo.EXPECT().RevokeSessions(gomock.Any(), "missing", gomock.Any()).Return(int64(0), user.ErrUserNotFound)
+
+		_, err := svc.RevokeSessions(context.Background(), "missing")
+		require.ErrorIs(t, err, user.ErrUserNotFound)
+	})
+}
@@ -22,6 +22,8 @@ type Config struct {
 	API      APIConfig      `json:"api"`
 	Web      WebConfig      `json:"web"`
 	User     UserConfig     `json:"user"`
+	Events   EventsConfig   `json:"events"`
+	Live     LiveConfig     `json:"live"`
 }
 
 // validateConfig validates the configuration
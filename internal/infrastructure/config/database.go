@@ -28,6 +28,10 @@ type DatabaseConfig struct {
 
 	// Logging configuration
 	Logging DatabaseLoggingConfig `json:"logging"`
+
+	// Migration settings
+	AutoMigrate          bool          `json:"auto_migrate"`
+	MigrationLockTimeout time.Duration `json:"migration_lock_timeout"`
 }
 
 // DatabaseLoggingConfig holds database logging configuration
@@ -9,6 +9,7 @@ import (
 var Module = fx.Module("config",
 	// Use Viper configuration provider instead of LoadFromEnv
 	NewViperConfigProvider(),
+	fx.Provide(NewReloader),
 	fx.Provide(NewAppConfig),
 	fx.Provide(NewDatabaseConfig),
 	fx.Provide(NewSecurityConfig),
@@ -22,6 +23,8 @@ var Module = fx.Module("config",
 	fx.Provide(NewAPIConfig),
 	fx.Provide(NewWebConfig),
 	fx.Provide(NewUserConfig),
+	fx.Provide(NewEventsConfig),
+	fx.Provide(NewLiveConfig),
 )
 
 // Individual config providers for fine-grained dependency injection
@@ -90,3 +93,13 @@ func NewWebConfig(cfg *Config) WebConfig {
 func NewUserConfig(cfg *Config) UserConfig {
 	return cfg.User
 }
+
+// NewEventsConfig provides event bus configuration
+func NewEventsConfig(cfg *Config) EventsConfig {
+	return cfg.Events
+}
+
+// NewLiveConfig provides live submissions feed configuration
+func NewLiveConfig(cfg *Config) LiveConfig {
+	return cfg.Live
+}
@@ -0,0 +1,504 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/viper"
+)
+
+// Reload sources
+const (
+	// ReloadSourceFile is a change to the configuration file
+	ReloadSourceFile = "file"
+	// ReloadSourceSignal is a SIGHUP sent to the process
+	ReloadSourceSignal = "signal"
+)
+
+// Reloadable configuration keys, as reported in ReloadEvent.Changed
+const (
+	ReloadKeyLogLevel  = "app.log_level"
+	ReloadKeyCORS      = "security.cors"
+	ReloadKeyRateLimit = "security.rate_limit"
+	ReloadKeyCSP       = "security.csp"
+	// ReloadKeyEncryptionKeyID is the ID of the active field encryption key,
+	// which changes along with the key when it is rotated
+	ReloadKeyEncryptionKeyID = "security.encryption.key_id"
+	// ReloadKeySecrets prefixes the keys of rotated secrets, as in
+	// secrets.database.password
+	ReloadKeySecrets = "secrets"
+)
+
+// reloadDebounce is how long file changes settle before they are read. Editors
+// and config map updates write a file in several steps.
+const reloadDebounce = 250 * time.Millisecond
+
+// ReloadableConfig is the part of the configuration that can change while the
+// application runs. Everything else is read once at startup.
+type ReloadableConfig struct {
+	LogLevel  string          `json:"log_level"`
+	CORS      CORSConfig      `json:"cors"`
+	RateLimit RateLimitConfig `json:"rate_limit"`
+	CSP       CSPConfig       `json:"csp"`
+	// EncryptionKeyID is the ID of the active field encryption key
+	EncryptionKeyID string `json:"key_id"`
+	// Secrets holds the resolved secrets by configuration key, so that rotated
+	// ones are seen. It is never serialized.
+	Secrets map[string]string `json:"-"`
+}
+
+// Reloadable returns the reloadable part of the configuration
+func (c *Config) Reloadable() ReloadableConfig {
+	return ReloadableConfig{
+		LogLevel:        c.App.LogLevel,
+		CORS:            c.Security.CORS,
+		RateLimit:       c.Security.RateLimit,
+		CSP:             c.Security.CSP,
+		EncryptionKeyID: c.Security.Encryption.KeyID,
+		Secrets:         c.Secrets(),
+	}
+}
+
+// Encryption returns base, the field encryption settings read at startup,
+// with the key ID and keys of the reloadable settings
+func (r *ReloadableConfig) Encryption(base EncryptionConfig) EncryptionConfig {
+	base.KeyID = r.EncryptionKeyID
+	base.Key = r.Secrets[EncryptionKeyPath]
+	base.RetiredKeys = nil
+
+	for key, secret := range r.Secrets {
+		if id, ok := strings.CutPrefix(key, RetiredKeysPath+"."); ok {
+			base.RetiredKeys = append(base.RetiredKeys, id+"="+secret)
+		}
+	}
+
+	sort.Strings(base.RetiredKeys)
+
+	return base
+}
+
+// Validate checks the settings with the validators of their sections
+func (r *ReloadableConfig) Validate() error {
+	result := ValidationResult{IsValid: true}
+	security := SecurityConfig{CORS: r.CORS, RateLimit: r.RateLimit, CSP: r.CSP}
+
+	validateAppConfigLogLevel(AppConfig{LogLevel: r.LogLevel}, &result)
+	validateSecurityCORS(security, &result)
+	validateSecurityRateLimit(security, &result)
+	validateSecurityCSP(security, &result)
+
+	return result.Err()
+}
+
+// changedKeys returns the keys of the settings that differ between r and other
+func (r *ReloadableConfig) changedKeys(other *ReloadableConfig) []string {
+	var keys []string
+
+	if r.LogLevel != other.LogLevel {
+		keys = append(keys, ReloadKeyLogLevel)
+	}
+
+	if !reflect.DeepEqual(r.CORS, other.CORS) {
+		keys = append(keys, ReloadKeyCORS)
+	}
+
+	if !reflect.DeepEqual(r.RateLimit, other.RateLimit) {
+		keys = append(keys, ReloadKeyRateLimit)
+	}
+
+	if !reflect.DeepEqual(r.CSP, other.CSP) {
+		keys = append(keys, ReloadKeyCSP)
+	}
+
+	if r.EncryptionKeyID != other.EncryptionKeyID {
+		keys = append(keys, ReloadKeyEncryptionKeyID)
+	}
+
+	return append(keys, changedSecrets(r.Secrets, other.Secrets)...)
+}
+
+// changedSecrets returns the reload keys of the secrets that differ between
+// two sets of secrets, in key order
+func changedSecrets(secrets, other map[string]string) []string {
+	var keys []string
+
+	for key, value := range secrets {
+		if previous, ok := other[key]; !ok || previous != value {
+			keys = append(keys, ReloadKeySecrets+"."+key)
+		}
+	}
+
+	for key := range other {
+		if _, ok := secrets[key]; !ok {
+			keys = append(keys, ReloadKeySecrets+"."+key)
+		}
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// ReloadEvent describes a reload that changed the configuration or failed
+type ReloadEvent struct {
+	Source string
+	// Previous holds the settings before the reload and Next those read. Next
+	// is in effect unless Err is set, in which case Previous still is.
+	Previous ReloadableConfig
+	Next     ReloadableConfig
+	// Changed lists the keys of the settings that were read with new values
+	Changed []string
+	// RestartRequired lists the changed secrets that no subscriber applies.
+	// They are redacted from logs right away, but the connections and
+	// services made with them keep the previous values until a restart.
+	RestartRequired []string
+	Err             error
+}
+
+// ReloadFunc applies reloaded settings. An error rolls the reload back.
+type ReloadFunc func(ReloadableConfig) error
+
+// Reloader reloads the reloadable settings when the configuration file
+// changes or the process receives SIGHUP; environment variables are read
+// again on each reload. New settings are validated and then handed to the
+// subscribers. If they are invalid or a subscriber rejects them, every
+// subscriber goes back to the last good settings.
+type Reloader struct {
+	viper   *ViperConfig
+	current atomic.Pointer[ReloadableConfig]
+
+	// mu serializes reloads, which read viper, and guards the callbacks
+	mu          sync.Mutex
+	subscribers []ReloadFunc
+	observers   []func(ReloadEvent)
+	// appliedSecrets are the keys of the secrets the subscribers apply
+	appliedSecrets []string
+
+	cancel  context.CancelFunc
+	done    chan struct{}
+	stopErr error
+}
+
+// NewReloader creates a reloader for the configuration loaded by vc
+func NewReloader(vc *ViperConfig, cfg *Config) *Reloader {
+	r := &Reloader{viper: vc}
+	settings := cfg.Reloadable()
+	r.current.Store(&settings)
+
+	return r
+}
+
+// Current returns the settings in effect
+func (r *Reloader) Current() ReloadableConfig {
+	return *r.current.Load()
+}
+
+// Subscribe adds a function that applies reloaded settings. It is not called
+// with the current settings.
+func (r *Reloader) Subscribe(fn ReloadFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.subscribers = append(r.subscribers, fn)
+}
+
+// ApplySecrets declares that a subscriber applies the secrets under the given
+// configuration keys. Other rotated secrets are reported in
+// ReloadEvent.RestartRequired.
+func (r *Reloader) ApplySecrets(keys ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.appliedSecrets = append(r.appliedSecrets, keys...)
+}
+
+// OnReload adds a function told about each reload that changed the settings
+// or failed, to log and audit it
+func (r *Reloader) OnReload(fn func(ReloadEvent)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.observers = append(r.observers, fn)
+}
+
+// Reload reads the configuration again and applies the reloadable settings.
+// Reading the same settings again does nothing.
+func (r *Reloader) Reload(source string) error {
+	return r.reload(source).Err
+}
+
+// reload reloads the settings and returns what happened
+func (r *Reloader) reload(source string) ReloadEvent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous := r.Current()
+	event := ReloadEvent{Source: source, Previous: previous}
+
+	next, err := r.viper.readReloadable()
+	if err == nil {
+		event.Next = next
+		event.Changed = next.changedKeys(&previous)
+
+		if len(event.Changed) == 0 {
+			return event
+		}
+
+		event.RestartRequired = r.restartRequired(event.Changed)
+
+		err = next.Validate()
+	}
+
+	if err == nil {
+		err = r.apply(next, previous)
+	}
+
+	if err != nil {
+		event.Err = fmt.Errorf("reload configuration: %w", err)
+	} else {
+		r.current.Store(&next)
+	}
+
+	for _, observer := range r.observers {
+		observer(event)
+	}
+
+	return event
+}
+
+// restartRequired returns the changed secrets no subscriber applies
+func (r *Reloader) restartRequired(changed []string) []string {
+	var keys []string
+
+	for _, key := range changed {
+		secret, ok := strings.CutPrefix(key, ReloadKeySecrets+".")
+		if !ok {
+			continue
+		}
+
+		applied := slices.ContainsFunc(r.appliedSecrets, func(prefix string) bool {
+			return secret == prefix || strings.HasPrefix(secret, prefix+".")
+		})
+		if !applied {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
+
+// apply hands settings to the subscribers, handing the previous settings back
+// to those already updated if one fails
+func (r *Reloader) apply(next, previous ReloadableConfig) error {
+	for i, subscriber := range r.subscribers {
+		if err := subscriber(next); err != nil {
+			for _, applied := range r.subscribers[:i] {
+				err = errors.Join(err, applied(previous))
+			}
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Start watches the configuration file, the secret files and SIGHUP until
+// Stop is called
+func (r *Reloader) Start(ctx context.Context) error {
+	var watcher *fsnotify.Watcher
+
+	files := r.viper.watchedFiles()
+	if len(files) > 0 {
+		var err error
+		if watcher, err = watchDirs(files); err != nil {
+			return err
+		}
+	}
+
+	ctx, r.cancel = context.WithCancel(context.WithoutCancel(ctx))
+	r.done = make(chan struct{})
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+
+	go func() {
+		defer close(r.done)
+		defer signal.Stop(signals)
+
+		r.stopErr = r.run(ctx, watcher, files, signals)
+	}()
+
+	return nil
+}
+
+// Stop stops watching for changes
+func (r *Reloader) Stop(_ context.Context) error {
+	if r.cancel == nil {
+		return nil
+	}
+
+	r.cancel()
+	<-r.done
+
+	return r.stopErr
+}
+
+// watchDirs watches the directories of files. Directories are watched rather
+// than the files so that files replaced rather than written are seen.
+func watchDirs(files []string) (*fsnotify.Watcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("watch configuration file: %w", err)
+	}
+
+	watched := make(map[string]bool)
+
+	for _, file := range files {
+		dir := filepath.Dir(file)
+		if watched[dir] {
+			continue
+		}
+
+		if err = watcher.Add(dir); err != nil {
+			return nil, errors.Join(fmt.Errorf("watch configuration file: %w", err), watcher.Close())
+		}
+
+		watched[dir] = true
+	}
+
+	return watcher, nil
+}
+
+// watchedFiles returns the files a reload reads: the configuration file and
+// the secret files
+func (vc *ViperConfig) watchedFiles() []string {
+	files := vc.secretFiles()
+
+	if file := vc.viper.ConfigFileUsed(); file != "" {
+		files = append([]string{file}, files...)
+	}
+
+	for i, file := range files {
+		files[i] = filepath.Clean(file)
+	}
+
+	return files
+}
+
+// run reloads on file changes and signals until ctx is done. A file change is
+// a write to one of the watched files or a change of the file its path
+// resolves to, as when a mounted config map or secret is updated. It returns
+// the error of closing the watcher.
+func (r *Reloader) run(ctx context.Context, watcher *fsnotify.Watcher, files []string, signals <-chan os.Signal) error {
+	var (
+		fileEvents <-chan fsnotify.Event
+		fileErrors <-chan error
+	)
+
+	if watcher != nil {
+		fileEvents, fileErrors = watcher.Events, watcher.Errors
+	}
+
+	resolved := make(map[string]string, len(files))
+	for _, file := range files {
+		resolved[file] = resolvePath(file)
+	}
+
+	debounce := time.NewTimer(reloadDebounce)
+	debounce.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			debounce.Stop()
+
+			if watcher == nil {
+				return nil
+			}
+
+			if err := watcher.Close(); err != nil {
+				return fmt.Errorf("stop watching configuration file: %w", err)
+			}
+
+			return nil
+		case <-signals:
+			r.reload(ReloadSourceSignal)
+		case event := <-fileEvents:
+			if watchedFileChanged(event, resolved) {
+				debounce.Reset(reloadDebounce)
+			}
+		case <-fileErrors:
+			// A missed change is picked up by the next one or by SIGHUP
+		case <-debounce.C:
+			r.reload(ReloadSourceFile)
+		}
+	}
+}
+
+// watchedFileChanged reports whether event changed a watched file, updating
+// the files the watched paths resolve to
+func watchedFileChanged(event fsnotify.Event, resolved map[string]string) bool {
+	_, changed := resolved[filepath.Clean(event.Name)]
+	changed = changed && event.Op != fsnotify.Chmod
+
+	for file, previous := range resolved {
+		if current := resolvePath(file); current != previous {
+			resolved[file] = current
+			changed = true
+		}
+	}
+
+	return changed
+}
+
+// resolvePath returns the file a path resolves to, or an empty string if it
+// does not exist
+func resolvePath(path string) string {
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return ""
+	}
+
+	return resolved
+}
+
+// readReloadable reads the configuration file, the secrets file and the
+// secret references again and returns the reloadable settings
+func (vc *ViperConfig) readReloadable() (ReloadableConfig, error) {
+	if err := vc.viper.ReadInConfig(); err != nil {
+		var notFound viper.ConfigFileNotFoundError
+		if !errors.As(err, &notFound) {
+			return ReloadableConfig{}, fmt.Errorf("failed to read config file: %w", err)
+		}
+	}
+
+	if err := vc.mergeSecretsFile(); err != nil {
+		return ReloadableConfig{}, fmt.Errorf("failed to load secrets file: %w", err)
+	}
+
+	config := &Config{}
+
+	if err := vc.loadAllConfigSections(config); err != nil {
+		return ReloadableConfig{}, fmt.Errorf("failed to load configuration sections: %w", err)
+	}
+
+	if err := resolveSecrets(config); err != nil {
+		return ReloadableConfig{}, fmt.Errorf("failed to resolve secrets: %w", err)
+	}
+
+	return config.Reloadable(), nil
+}
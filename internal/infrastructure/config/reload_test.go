@@ -0,0 +1,109 @@
+package config_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/infrastructure/config"
+)
+
+func TestReloadableConfig_Validate(t *testing.T) {
+	settings := createValidConfig().Reloadable()
+	require.NoError(t, settings.Validate())
+
+	settings.LogLevel = "verbose"
+	settings.CORS = config.CORSConfig{Enabled: true, AllowedOrigins: []string{"*"}, AllowCredentials: true}
+	settings.CSP = config.CSPConfig{Enabled: true, DefaultSrc: "'self'; script-src *"}
+
+	err := settings.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "app.log_level")
+	assert.Contains(t, err.Error(), "security.csp.default_src")
+}
+
+// newTestReloader creates a reloader reading only the environment. The default
+// CORS settings allow any origin with credentials, which does not validate.
+func newTestReloader(t *testing.T) *config.Reloader {
+	t.Helper()
+	t.Chdir(t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("GOFORMS_SECURITY_CORS_ALLOWED_ORIGINS", "https://example.com")
+
+	return config.NewReloader(config.NewViperConfig(), createValidConfig())
+}
+
+func TestReloader_Reload(t *testing.T) {
+	reloader := newTestReloader(t)
+
+	var (
+		applied []config.ReloadableConfig
+		events  []config.ReloadEvent
+	)
+
+	reloader.Subscribe(func(settings config.ReloadableConfig) error {
+		applied = append(applied, settings)
+
+		return nil
+	})
+	reloader.OnReload(func(event config.ReloadEvent) { events = append(events, event) })
+
+	t.Setenv("GOFORMS_APP_LOG_LEVEL", "debug")
+	require.NoError(t, reloader.Reload(config.ReloadSourceSignal))
+
+	assert.Equal(t, "debug", reloader.Current().LogLevel)
+	require.Len(t, applied, 1)
+	assert.Equal(t, "debug", applied[0].LogLevel)
+	require.Len(t, events, 1)
+	assert.Equal(t, config.ReloadSourceSignal, events[0].Source)
+	assert.Contains(t, events[0].Changed, config.ReloadKeyLogLevel)
+	assert.Empty(t, events[0].Previous.LogLevel)
+
+	require.NoError(t, reloader.Reload(config.ReloadSourceSignal))
+	assert.Len(t, applied, 1, "unchanged settings are not applied again")
+	assert.Len(t, events, 1)
+}
+
+func TestReloader_ReloadInvalid(t *testing.T) {
+	reloader := newTestReloader(t)
+
+	var events []config.ReloadEvent
+
+	reloader.Subscribe(func(config.ReloadableConfig) error {
+		t.Fatal("invalid settings must not be applied")
+
+		return nil
+	})
+	reloader.OnReload(func(event config.ReloadEvent) { events = append(events, event) })
+
+	t.Setenv("GOFORMS_APP_LOG_LEVEL", "verbose")
+	require.Error(t, reloader.Reload(config.ReloadSourceFile))
+
+	assert.Empty(t, reloader.Current().LogLevel, "the last good settings stay in effect")
+	require.Len(t, events, 1)
+	assert.Error(t, events[0].Err)
+	assert.Equal(t, "verbose", events[0].Next.LogLevel)
+}
+
+func TestReloader_ReloadRollback(t *testing.T) {
+	reloader := newTestReloader(t)
+
+	var levels []string
+
+	reloader.Subscribe(func(settings config.ReloadableConfig) error {
+		levels = append(levels, settings.LogLevel)
+
+		return nil
+	})
+	reloader.Subscribe(func(config.ReloadableConfig) error {
+		return errors.New("rejected")
+	})
+
+	t.Setenv("GOFORMS_APP_LOG_LEVEL", "warn")
+	require.ErrorContains(t, reloader.Reload(config.ReloadSourceSignal), "rejected")
+
+	assert.Equal(t, []string{"warn", ""}, levels, "applied subscribers get the previous settings back")
+	assert.Empty(t, reloader.Current().LogLevel)
+}
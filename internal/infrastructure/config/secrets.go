@@ -0,0 +1,363 @@
+package config
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Secret references. A secret setting holding one of these is replaced by the
+// value it points to when the configuration is read.
+const (
+	// SecretFilePrefix reads a secret from a file, as in
+	// file:///run/secrets/db_password. A trailing newline is dropped.
+	SecretFilePrefix = "file://"
+	// SecretEnvPrefix reads a secret from an environment variable, as in
+	// env:DB_PASSWORD
+	SecretEnvPrefix = "env:"
+)
+
+// RedactedValue replaces secrets in configuration dumps and logs
+const RedactedValue = "[REDACTED]"
+
+// Encrypted secrets file format. The file holds a single line: the header
+// followed by the base64 encoding of salt, nonce and AES-256-GCM ciphertext.
+// The key is derived from the master key with PBKDF2-SHA256.
+const (
+	secretsFileHeader    = "goforms-secrets:v1:"
+	secretsSaltLength    = 16
+	secretsKeyLength     = 32
+	secretsKeyIterations = 600_000
+)
+
+// Keys of the field encryption secrets
+const (
+	// EncryptionKeyPath is the key of the active encryption key
+	EncryptionKeyPath = "security.encryption.key"
+	// RetiredKeysPath is the key of the retired encryption keys, whose entries
+	// may hold secret references after the "key_id=" prefix
+	RetiredKeysPath = "security.encryption.retired_keys"
+)
+
+var (
+	// ErrSecretNotFound is returned when a secret reference points nowhere
+	ErrSecretNotFound = errors.New("secret not found")
+	// ErrInvalidSecretsFile is returned when an encrypted secrets file cannot
+	// be decrypted, either because it is malformed or the master key is wrong
+	ErrInvalidSecretsFile = errors.New("invalid encrypted secrets file")
+	// ErrMissingMasterKey is returned when an encrypted secrets file is
+	// configured without a master key
+	ErrMissingMasterKey = errors.New("secrets.master_key is required to read secrets.file")
+)
+
+// secretFields returns the secret settings by configuration key. Retired
+// encryption keys are handled apart since they are a list.
+func (c *Config) secretFields() map[string]*string {
+	return map[string]*string{
+		"database.password":       &c.Database.Password,
+		"security.csrf.secret":    &c.Security.CSRF.Secret,
+		"security.encryption.key": &c.Security.Encryption.Key,
+		"email.password":          &c.Email.Password,
+		"storage.s3.secret_key":   &c.Storage.S3.SecretKey,
+		"cache.redis.password":    &c.Cache.Redis.Password,
+		"session.secret":          &c.Session.Secret,
+		"user.admin.password":     &c.User.Admin.Password,
+	}
+}
+
+// Secrets returns the secret settings that are set, by configuration key.
+// Retired encryption keys are listed as security.encryption.retired_keys.<id>.
+func (c *Config) Secrets() map[string]string {
+	secrets := make(map[string]string)
+
+	for key, field := range c.secretFields() {
+		if *field != "" {
+			secrets[key] = *field
+		}
+	}
+
+	for _, entry := range c.Security.Encryption.RetiredKeys {
+		if id, key, ok := strings.Cut(entry, "="); ok && key != "" {
+			secrets[RetiredKeysPath+"."+id] = key
+		}
+	}
+
+	return secrets
+}
+
+// SecretValues returns the values of the secret settings that are set, for
+// loggers to redact
+func (c *Config) SecretValues() []string {
+	secrets := c.Secrets()
+	values := make([]string, 0, len(secrets))
+
+	for _, value := range secrets {
+		values = append(values, value)
+	}
+
+	sort.Strings(values)
+
+	return values
+}
+
+// Redacted returns a copy of the configuration with the secrets replaced by
+// RedactedValue, to be dumped or logged
+func (c *Config) Redacted() *Config {
+	redacted := *c
+
+	for _, field := range redacted.secretFields() {
+		if *field != "" {
+			*field = RedactedValue
+		}
+	}
+
+	if len(c.Security.Encryption.RetiredKeys) > 0 {
+		retired := make([]string, 0, len(c.Security.Encryption.RetiredKeys))
+		for _, entry := range c.Security.Encryption.RetiredKeys {
+			id, _, _ := strings.Cut(entry, "=")
+			retired = append(retired, id+"="+RedactedValue)
+		}
+
+		redacted.Security.Encryption.RetiredKeys = retired
+	}
+
+	return &redacted
+}
+
+// resolveSecrets replaces the secret references in config by the secrets they
+// point to
+func resolveSecrets(config *Config) error {
+	for key, field := range config.secretFields() {
+		value, err := ResolveSecret(*field)
+		if err != nil {
+			return fmt.Errorf("resolve %s: %w", key, err)
+		}
+
+		*field = value
+	}
+
+	if len(config.Security.Encryption.RetiredKeys) == 0 {
+		return nil
+	}
+
+	retired := make([]string, 0, len(config.Security.Encryption.RetiredKeys))
+
+	for _, entry := range config.Security.Encryption.RetiredKeys {
+		id, reference, ok := strings.Cut(entry, "=")
+		if !ok {
+			// Keys reports malformed entries
+			retired = append(retired, entry)
+
+			continue
+		}
+
+		value, err := ResolveSecret(reference)
+		if err != nil {
+			return fmt.Errorf("resolve %s %q: %w", RetiredKeysPath, id, err)
+		}
+
+		retired = append(retired, id+"="+value)
+	}
+
+	config.Security.Encryption.RetiredKeys = retired
+
+	return nil
+}
+
+// ResolveSecret returns the secret a value refers to. Values that are not
+// references are secrets themselves and returned as is.
+func ResolveSecret(value string) (string, error) {
+	switch {
+	case strings.HasPrefix(value, SecretFilePrefix):
+		path := strings.TrimPrefix(value, SecretFilePrefix)
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return "", fmt.Errorf("%w: file %s does not exist", ErrSecretNotFound, path)
+			}
+
+			return "", fmt.Errorf("read secret file %s: %w", path, err)
+		}
+
+		return strings.TrimRight(string(data), "\r\n"), nil
+	case strings.HasPrefix(value, SecretEnvPrefix):
+		name := strings.TrimPrefix(value, SecretEnvPrefix)
+
+		secret, ok := os.LookupEnv(name)
+		if !ok {
+			return "", fmt.Errorf("%w: environment variable %s is not set", ErrSecretNotFound, name)
+		}
+
+		return secret, nil
+	default:
+		return value, nil
+	}
+}
+
+// secretFiles returns the files the secret settings are read from: the
+// encrypted secrets file and the targets of file:// references
+func (vc *ViperConfig) secretFiles() []string {
+	var files []string
+
+	if file := vc.viper.GetString("secrets.file"); file != "" {
+		files = append(files, file)
+	}
+
+	fields := new(Config).secretFields()
+	references := make([]string, 0, len(fields))
+
+	for key := range fields {
+		references = append(references, vc.viper.GetString(key))
+	}
+
+	for _, entry := range vc.viper.GetStringSlice(RetiredKeysPath) {
+		if _, reference, ok := strings.Cut(entry, "="); ok {
+			references = append(references, reference)
+		}
+	}
+
+	for _, reference := range references {
+		if strings.HasPrefix(reference, SecretFilePrefix) {
+			files = append(files, strings.TrimPrefix(reference, SecretFilePrefix))
+		}
+	}
+
+	sort.Strings(files)
+
+	return files
+}
+
+// mergeSecretsFile merges the encrypted secrets file, if one is configured,
+// into the configuration read so far. Its settings take precedence over the
+// configuration file but not over environment variables.
+func (vc *ViperConfig) mergeSecretsFile() error {
+	file := vc.viper.GetString("secrets.file")
+	if file == "" {
+		return nil
+	}
+
+	masterKey, err := ResolveSecret(vc.viper.GetString("secrets.master_key"))
+	if err != nil {
+		return fmt.Errorf("resolve secrets.master_key: %w", err)
+	}
+
+	if masterKey == "" {
+		return ErrMissingMasterKey
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("read secrets file: %w", err)
+	}
+
+	plaintext, err := DecryptSecrets(data, masterKey)
+	if err != nil {
+		return fmt.Errorf("decrypt %s: %w", file, err)
+	}
+
+	if err = vc.viper.MergeConfig(bytes.NewReader(plaintext)); err != nil {
+		return fmt.Errorf("merge secrets file: %w", err)
+	}
+
+	return nil
+}
+
+// EncryptSecrets encrypts a YAML document of secret settings with a master key,
+// in the format read from secrets.file
+func EncryptSecrets(plaintext []byte, masterKey string) ([]byte, error) {
+	if masterKey == "" {
+		return nil, ErrMissingMasterKey
+	}
+
+	salt := make([]byte, secretsSaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("generate salt: %w", err)
+	}
+
+	aead, err := secretsCipher(masterKey, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("generate nonce: %w", err)
+	}
+
+	sealed := make([]byte, 0, len(salt)+len(nonce)+len(plaintext)+aead.Overhead())
+	sealed = append(sealed, salt...)
+	sealed = append(sealed, nonce...)
+	sealed = aead.Seal(sealed, nonce, plaintext, []byte(secretsFileHeader))
+
+	return []byte(secretsFileHeader + base64.StdEncoding.EncodeToString(sealed) + "\n"), nil
+}
+
+// DecryptSecrets decrypts a file written by EncryptSecrets
+func DecryptSecrets(data []byte, masterKey string) ([]byte, error) {
+	if masterKey == "" {
+		return nil, ErrMissingMasterKey
+	}
+
+	encoded, ok := strings.CutPrefix(strings.TrimSpace(string(data)), secretsFileHeader)
+	if !ok {
+		return nil, fmt.Errorf("%w: missing %q header", ErrInvalidSecretsFile, strings.TrimSuffix(secretsFileHeader, ":"))
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSecretsFile, err)
+	}
+
+	if len(sealed) < secretsSaltLength {
+		return nil, fmt.Errorf("%w: too short", ErrInvalidSecretsFile)
+	}
+
+	aead, err := secretsCipher(masterKey, sealed[:secretsSaltLength])
+	if err != nil {
+		return nil, err
+	}
+
+	sealed = sealed[secretsSaltLength:]
+	if len(sealed) < aead.NonceSize() {
+		return nil, fmt.Errorf("%w: too short", ErrInvalidSecretsFile)
+	}
+
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+
+	plaintext, err := aead.Open(nil, nonce, ciphertext, []byte(secretsFileHeader))
+	if err != nil {
+		return nil, fmt.Errorf("%w: wrong master key or corrupted file", ErrInvalidSecretsFile)
+	}
+
+	return plaintext, nil
+}
+
+// secretsCipher returns the AES-256-GCM cipher for a master key and salt
+func secretsCipher(masterKey string, salt []byte) (cipher.AEAD, error) {
+	key, err := pbkdf2.Key(sha256.New, masterKey, salt, secretsKeyIterations, secretsKeyLength)
+	if err != nil {
+		return nil, fmt.Errorf("derive secrets key: %w", err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("create secrets cipher: %w", err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("create secrets cipher: %w", err)
+	}
+
+	return aead, nil
+}
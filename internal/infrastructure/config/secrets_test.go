@@ -0,0 +1,180 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/infrastructure/config"
+)
+
+func TestResolveSecret(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "db_password")
+	require.NoError(t, os.WriteFile(file, []byte("from-file\n"), 0o600))
+	t.Setenv("TEST_DB_PASSWORD", "from-env")
+
+	tests := []struct {
+		name    string
+		value   string
+		want    string
+		wantErr error
+	}{
+		{name: "literal", value: "plain-secret", want: "plain-secret"},
+		{name: "empty", value: "", want: ""},
+		{name: "file", value: config.SecretFilePrefix + file, want: "from-file"},
+		{name: "env", value: "env:TEST_DB_PASSWORD", want: "from-env"},
+		{name: "missing env", value: "env:TEST_NOT_SET", wantErr: config.ErrSecretNotFound},
+		{
+			name:    "missing file",
+			value:   config.SecretFilePrefix + filepath.Join(t.TempDir(), "missing"),
+			wantErr: config.ErrSecretNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := config.ResolveSecret(tt.value)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEncryptSecrets(t *testing.T) {
+	plaintext := []byte("database:\n  password: s3cret-password\n")
+
+	encrypted, err := config.EncryptSecrets(plaintext, "master-key")
+	require.NoError(t, err)
+	assert.NotContains(t, string(encrypted), "s3cret-password")
+
+	decrypted, err := config.DecryptSecrets(encrypted, "master-key")
+	require.NoError(t, err)
+	assert.Equal(t, plaintext, decrypted)
+
+	_, err = config.DecryptSecrets(encrypted, "wrong-key")
+	require.ErrorIs(t, err, config.ErrInvalidSecretsFile)
+
+	tampered := []byte(string(encrypted[:len(encrypted)-4]) + "AAA=")
+	_, err = config.DecryptSecrets(tampered, "master-key")
+	require.ErrorIs(t, err, config.ErrInvalidSecretsFile)
+
+	_, err = config.DecryptSecrets(plaintext, "master-key")
+	require.ErrorIs(t, err, config.ErrInvalidSecretsFile)
+
+	_, err = config.EncryptSecrets(plaintext, "")
+	require.ErrorIs(t, err, config.ErrMissingMasterKey)
+}
+
+func TestConfig_Redacted(t *testing.T) {
+	cfg := createValidConfig()
+	cfg.Database.Password = "db-password"
+	cfg.Session.Secret = "session-secret"
+	cfg.Security.Encryption.RetiredKeys = []string{"v1=retired-key"}
+
+	redacted := cfg.Redacted()
+
+	assert.Equal(t, config.RedactedValue, redacted.Database.Password)
+	assert.Equal(t, config.RedactedValue, redacted.Session.Secret)
+	assert.Equal(t, []string{"v1=" + config.RedactedValue}, redacted.Security.Encryption.RetiredKeys)
+	assert.Equal(t, cfg.Database.Host, redacted.Database.Host)
+
+	assert.Equal(t, "db-password", cfg.Database.Password, "the configuration itself is left as is")
+	assert.Equal(t, []string{"v1=retired-key"}, cfg.Security.Encryption.RetiredKeys)
+
+	values := cfg.SecretValues()
+	assert.Contains(t, values, "db-password")
+	assert.Contains(t, values, "retired-key")
+}
+
+func TestReloader_ReloadRotatedSecret(t *testing.T) {
+	reloader := newTestReloader(t)
+
+	file := filepath.Join(t.TempDir(), "db_password")
+	require.NoError(t, os.WriteFile(file, []byte("first-password\n"), 0o600))
+	t.Setenv("GOFORMS_DATABASE_PASSWORD", config.SecretFilePrefix+file)
+
+	require.NoError(t, reloader.Reload(config.ReloadSourceSignal))
+	assert.Equal(t, "first-password", reloader.Current().Secrets["database.password"])
+
+	var events []config.ReloadEvent
+
+	reloader.OnReload(func(event config.ReloadEvent) { events = append(events, event) })
+
+	require.NoError(t, os.WriteFile(file, []byte("second-password\n"), 0o600))
+	require.NoError(t, reloader.Reload(config.ReloadSourceFile))
+
+	assert.Equal(t, "second-password", reloader.Current().Secrets["database.password"])
+	require.Len(t, events, 1)
+	assert.Equal(t, []string{config.ReloadKeySecrets + ".database.password"}, events[0].Changed)
+	assert.Equal(t, events[0].Changed, events[0].RestartRequired, "no subscriber applies the database password")
+
+	require.NoError(t, os.Remove(file))
+	require.ErrorIs(t, reloader.Reload(config.ReloadSourceFile), config.ErrSecretNotFound)
+	assert.Equal(t, "second-password", reloader.Current().Secrets["database.password"],
+		"the last good secrets stay in effect")
+}
+
+func TestReloader_ReloadEncryptionKeys(t *testing.T) {
+	reloader := newTestReloader(t)
+	reloader.ApplySecrets(config.EncryptionKeyPath, config.RetiredKeysPath)
+
+	t.Setenv("GOFORMS_SECURITY_ENCRYPTION_KEY", "first-key")
+	t.Setenv("GOFORMS_SECURITY_ENCRYPTION_KEY_ID", "k1")
+	require.NoError(t, reloader.Reload(config.ReloadSourceSignal))
+
+	var events []config.ReloadEvent
+
+	reloader.OnReload(func(event config.ReloadEvent) { events = append(events, event) })
+
+	t.Setenv("GOFORMS_SECURITY_ENCRYPTION_KEY", "second-key")
+	t.Setenv("GOFORMS_SECURITY_ENCRYPTION_KEY_ID", "k2")
+	t.Setenv("GOFORMS_SECURITY_ENCRYPTION_RETIRED_KEYS", "k1=first-key")
+	t.Setenv("GOFORMS_SESSION_SECRET", "rotated-session-secret")
+	require.NoError(t, reloader.Reload(config.ReloadSourceSignal))
+
+	require.Len(t, events, 1)
+	assert.Equal(t, []string{
+		config.ReloadKeyEncryptionKeyID,
+		config.ReloadKeySecrets + "." + config.EncryptionKeyPath,
+		config.ReloadKeySecrets + "." + config.RetiredKeysPath + ".k1",
+		config.ReloadKeySecrets + ".session.secret",
+	}, events[0].Changed)
+	assert.Equal(t, []string{config.ReloadKeySecrets + ".session.secret"}, events[0].RestartRequired)
+
+	settings := reloader.Current()
+	encryption := settings.Encryption(config.EncryptionConfig{Algorithm: "aes-256-gcm", RetiredKeys: []string{"old"}})
+	assert.Equal(t, config.EncryptionConfig{
+		Key:         "second-key",
+		KeyID:       "k2",
+		RetiredKeys: []string{"k1=first-key"},
+		Algorithm:   "aes-256-gcm",
+	}, encryption)
+}
+
+func TestReloader_ReloadSecretsFile(t *testing.T) {
+	reloader := newTestReloader(t)
+
+	encrypted, err := config.EncryptSecrets([]byte("session:\n  secret: from-secrets-file\n"), "master-key")
+	require.NoError(t, err)
+
+	file := filepath.Join(t.TempDir(), "secrets.enc")
+	require.NoError(t, os.WriteFile(file, encrypted, 0o600))
+	t.Setenv("GOFORMS_SECRETS_FILE", file)
+	t.Setenv("TEST_MASTER_KEY", "master-key")
+	t.Setenv("GOFORMS_SECRETS_MASTER_KEY", "env:TEST_MASTER_KEY")
+
+	require.NoError(t, reloader.Reload(config.ReloadSourceSignal))
+	assert.Equal(t, "from-secrets-file", reloader.Current().Secrets["session.secret"])
+
+	t.Setenv("TEST_MASTER_KEY", "wrong-key")
+	require.ErrorIs(t, reloader.Reload(config.ReloadSourceSignal), config.ErrInvalidSecretsFile)
+}
@@ -141,15 +141,48 @@ type TrustProxyConfig struct {
 	TrustedHeaders []string `json:"trusted_headers"`
 }
 
-// EncryptionConfig represents encryption configuration
+// MinEncryptionKeyLength is the minimum length of a field encryption key
+const MinEncryptionKeyLength = 32
+
+// EncryptionConfig represents encryption configuration. Key is the active
+// field encryption key and KeyID names it in every value it encrypts. Keys
+// rotated out stay in RetiredKeys, as "key_id=key" entries, until the
+// re-encryption job has moved every value to the active key.
 type EncryptionConfig struct {
-	Key            string `json:"key"`
-	Algorithm      string `json:"algorithm"`
-	KeySize        int    `json:"key_size"`
-	SaltLength     int    `json:"salt_length"`
-	Iterations     int    `json:"iterations"`
-	EnableAES      bool   `json:"enable_aes"`
-	EnableChaCha20 bool   `json:"enable_cha_cha20"`
+	Key            string   `json:"key"`
+	KeyID          string   `json:"key_id"`
+	RetiredKeys    []string `json:"retired_keys"`
+	Algorithm      string   `json:"algorithm"`
+	KeySize        int      `json:"key_size"`
+	SaltLength     int      `json:"salt_length"`
+	Iterations     int      `json:"iterations"`
+	EnableAES      bool     `json:"enable_aes"`
+	EnableChaCha20 bool     `json:"enable_cha_cha20"`
+}
+
+// Enabled reports whether field encryption is configured
+func (e *EncryptionConfig) Enabled() bool {
+	return e.Key != ""
+}
+
+// Keys returns every configured key by ID, the active key included
+func (e *EncryptionConfig) Keys() (map[string]string, error) {
+	keys := map[string]string{e.KeyID: e.Key}
+
+	for _, entry := range e.RetiredKeys {
+		id, key, ok := strings.Cut(entry, "=")
+		if !ok || id == "" || key == "" {
+			return nil, fmt.Errorf("retired key entries must look like key_id=key")
+		}
+
+		if _, exists := keys[id]; exists {
+			return nil, fmt.Errorf("encryption key ID %q is used more than once", id)
+		}
+
+		keys[id] = key
+	}
+
+	return keys, nil
 }
 
 // Validate validates the security configuration
@@ -177,6 +210,13 @@ func (s *SecurityConfig) Validate() error {
 		}
 	}
 
+	// Validate field encryption keys
+	if s.Encryption.Enabled() {
+		if err := s.validateEncryption(); err != nil {
+			errs = append(errs, fmt.Sprintf("Encryption: %v", err))
+		}
+	}
+
 	// Validate cookie security
 	if err := s.validateCookieSecurity(); err != nil {
 		errs = append(errs, fmt.Sprintf("Cookie Security: %v", err))
@@ -255,6 +295,26 @@ func (s *SecurityConfig) validateTLS() error {
 	return nil
 }
 
+// validateEncryption validates the field encryption keys
+func (s *SecurityConfig) validateEncryption() error {
+	if s.Encryption.KeyID == "" {
+		return fmt.Errorf("encryption key ID is required")
+	}
+
+	keys, err := s.Encryption.Keys()
+	if err != nil {
+		return err
+	}
+
+	for id, key := range keys {
+		if len(key) < MinEncryptionKeyLength {
+			return fmt.Errorf("encryption key %q must be at least %d characters", id, MinEncryptionKeyLength)
+		}
+	}
+
+	return nil
+}
+
 // validateCookieSecurity validates cookie security settings
 func (s *SecurityConfig) validateCookieSecurity() error {
 	validSameSite := []string{"Strict", "Lax", "None"}
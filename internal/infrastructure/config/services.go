@@ -98,3 +98,48 @@ type AuthConfig struct {
 	MaxLoginAttempts         int           `json:"max_login_attempts"`
 	LockoutDuration          time.Duration `json:"lockout_duration"`
 }
+
+// Event bus drivers
+const (
+	// EventDriverMemory delivers events within the instance that published them
+	EventDriverMemory = "memory"
+	// EventDriverPostgres also fans events out to other instances over Postgres LISTEN/NOTIFY
+	EventDriverPostgres = "postgres"
+)
+
+// Event bus overflow policies, applied when a subscription's queue is full
+const (
+	// EventOverflowBlock makes Publish wait for room in the queue
+	EventOverflowBlock = "block"
+	// EventOverflowDropNewest drops the event being published
+	EventOverflowDropNewest = "drop_newest"
+	// EventOverflowDropOldest drops the oldest queued event to make room
+	EventOverflowDropOldest = "drop_oldest"
+)
+
+// EventsConfig holds the in-memory event bus configuration
+type EventsConfig struct {
+	Driver          string        `json:"driver"`
+	NodeID          string        `json:"node_id"`
+	Channel         string        `json:"channel"`
+	Async           bool          `json:"async"`
+	Workers         int           `json:"workers"`
+	QueueSize       int           `json:"queue_size"`
+	Overflow        string        `json:"overflow"`
+	HandlerTimeout  time.Duration `json:"handler_timeout"`
+	RetryCount      int           `json:"retry_count"`
+	RetryDelay      time.Duration `json:"retry_delay"`
+	MaxBackoff      time.Duration `json:"max_backoff"`
+	MetricsInterval time.Duration `json:"metrics_interval"`
+}
+
+// LiveConfig holds the configuration of the live submissions feed streamed to
+// the dashboard over Server-Sent Events
+type LiveConfig struct {
+	Enabled           bool          `json:"enabled"`
+	HeartbeatInterval time.Duration `json:"heartbeat_interval"`
+	RetryInterval     time.Duration `json:"retry_interval"`
+	ClientBuffer      int           `json:"client_buffer"`
+	ReplayBuffer      int           `json:"replay_buffer"`
+	MaxStreamsPerUser int           `json:"max_streams_per_user"`
+}
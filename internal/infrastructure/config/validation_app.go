@@ -13,6 +13,7 @@ func validateAppConfig(cfg AppConfig, result *ValidationResult) {
 	validateAppConfigTimeouts(cfg, result)
 	validateAppConfigURL(cfg, result)
 	validateAppConfigEnvironment(cfg, result)
+	validateAppConfigLogLevel(cfg, result)
 }
 
 func validateAppConfigName(cfg AppConfig, result *ValidationResult) {
@@ -69,3 +70,11 @@ func validateAppConfigEnvironment(cfg AppConfig, result *ValidationResult) {
 		)
 	}
 }
+
+// validateAppConfigLogLevel validates the log level. An empty level is chosen
+// from the environment.
+func validateAppConfigLogLevel(cfg AppConfig, result *ValidationResult) {
+	if cfg.LogLevel != "" && !isValidLogLevel(cfg.LogLevel) {
+		result.AddError("app.log_level", "invalid log level", cfg.LogLevel)
+	}
+}
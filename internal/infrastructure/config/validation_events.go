@@ -0,0 +1,66 @@
+// Package config provides validation utilities for Viper-based configuration
+package config
+
+import "fmt"
+
+// MaxEventChannelLength is the longest Postgres identifier, which LISTEN channels are
+const MaxEventChannelLength = 63
+
+// validateEventsConfig validates event bus configuration
+func validateEventsConfig(cfg EventsConfig, result *ValidationResult) {
+	switch cfg.Driver {
+	case EventDriverMemory:
+	case EventDriverPostgres:
+		if cfg.Channel == "" || len(cfg.Channel) > MaxEventChannelLength {
+			result.AddError("events.channel",
+				fmt.Sprintf("channel must be 1 to %d characters", MaxEventChannelLength), cfg.Channel)
+		}
+	default:
+		result.AddError("events.driver",
+			"driver must be one of: memory, postgres", cfg.Driver)
+	}
+
+	if cfg.Async {
+		if cfg.Workers <= 0 {
+			result.AddError("events.workers",
+				"workers must be positive", cfg.Workers)
+		}
+
+		if cfg.QueueSize <= 0 {
+			result.AddError("events.queue_size",
+				"queue size must be positive", cfg.QueueSize)
+		}
+	}
+
+	switch cfg.Overflow {
+	case EventOverflowBlock, EventOverflowDropNewest, EventOverflowDropOldest:
+	default:
+		result.AddError("events.overflow",
+			"overflow must be one of: block, drop_newest, drop_oldest", cfg.Overflow)
+	}
+
+	if cfg.HandlerTimeout <= 0 {
+		result.AddError("events.handler_timeout",
+			"handler timeout must be positive", cfg.HandlerTimeout)
+	}
+
+	if cfg.RetryCount <= 0 {
+		result.AddError("events.retry_count",
+			"retry count must be positive", cfg.RetryCount)
+	}
+
+	if cfg.RetryDelay < 0 {
+		result.AddError("events.retry_delay",
+			"retry delay cannot be negative", cfg.RetryDelay)
+	}
+
+	if cfg.MaxBackoff < cfg.RetryDelay {
+		result.AddError("events.max_backoff",
+			"max backoff cannot be less than the retry delay", cfg.MaxBackoff)
+	}
+
+	if cfg.MetricsInterval < 0 {
+		result.AddError("events.metrics_interval",
+			"metrics interval cannot be negative", cfg.MetricsInterval)
+	}
+}
@@ -2,6 +2,7 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"strconv"
@@ -36,6 +37,16 @@ func (r *ValidationResult) AddError(field, message string, value interface{}) {
 	})
 }
 
+// Err returns the errors of the result joined into one, or nil if it is valid
+func (r *ValidationResult) Err() error {
+	errs := make([]error, 0, len(r.Errors))
+	for _, err := range r.Errors {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
 // ValidateConfig validates the complete configuration with detailed error reporting
 func ValidateConfig(cfg *Config) ValidationResult {
 	result := ValidationResult{IsValid: true}
@@ -54,6 +65,8 @@ func ValidateConfig(cfg *Config) ValidationResult {
 	validateAPIConfig(cfg.API, &result)
 	validateWebConfig(cfg.Web, &result)
 	validateUserConfig(cfg.User, &result)
+	validateEventsConfig(cfg.Events, &result)
+	validateLiveConfig(cfg.Live, &result)
 
 	// Validate cross-section dependencies
 	validateCrossSectionDependencies(cfg, &result)
@@ -85,6 +98,13 @@ func validateCrossSectionDependencies(cfg *Config, result *ValidationResult) {
 			cfg.Auth.RequireEmailVerification,
 		)
 	}
+
+	// Check if the event bus uses Postgres but the database is not Postgres
+	if cfg.Events.Driver == EventDriverPostgres && cfg.Database.Driver != "postgres" {
+		result.AddError("events.driver",
+			"the postgres event driver requires the postgres database driver",
+			cfg.Events.Driver)
+	}
 }
 
 // Helper functions
@@ -0,0 +1,34 @@
+// Package config provides validation utilities for Viper-based configuration
+package config
+
+// validateLiveConfig validates live submissions feed configuration
+func validateLiveConfig(cfg LiveConfig, result *ValidationResult) {
+	if !cfg.Enabled {
+		return
+	}
+
+	if cfg.HeartbeatInterval <= 0 {
+		result.AddError("live.heartbeat_interval",
+			"heartbeat interval must be positive", cfg.HeartbeatInterval)
+	}
+
+	if cfg.RetryInterval <= 0 {
+		result.AddError("live.retry_interval",
+			"retry interval must be positive", cfg.RetryInterval)
+	}
+
+	if cfg.ClientBuffer <= 0 {
+		result.AddError("live.client_buffer",
+			"client buffer must be positive", cfg.ClientBuffer)
+	}
+
+	if cfg.ReplayBuffer < 0 {
+		result.AddError("live.replay_buffer",
+			"replay buffer cannot be negative", cfg.ReplayBuffer)
+	}
+
+	if cfg.MaxStreamsPerUser <= 0 {
+		result.AddError("live.max_streams_per_user",
+			"max streams per user must be positive", cfg.MaxStreamsPerUser)
+	}
+}
@@ -15,20 +15,20 @@ func validateLoggingConfig(cfg LoggingConfig, result *ValidationResult) {
 }
 
 func validateLoggingLevel(cfg LoggingConfig, result *ValidationResult) {
-	validLevels := []string{"debug", "info", "warn", "error", "fatal"}
-	levelValid := false
-
-	for _, level := range validLevels {
-		if strings.EqualFold(cfg.Level, level) {
-			levelValid = true
+	if !isValidLogLevel(cfg.Level) {
+		result.AddError("logging.level", "invalid log level", cfg.Level)
+	}
+}
 
-			break
+// isValidLogLevel reports whether the logger accepts a level
+func isValidLogLevel(level string) bool {
+	for _, valid := range []string{"debug", "info", "warn", "error", "fatal"} {
+		if strings.EqualFold(level, valid) {
+			return true
 		}
 	}
 
-	if !levelValid {
-		result.AddError("logging.level", "invalid log level", cfg.Level)
-	}
+	return false
 }
 
 func validateLoggingFormat(cfg LoggingConfig, result *ValidationResult) {
@@ -1,12 +1,26 @@
 // Package config provides validation utilities for Viper-based configuration
 package config
 
+import "strings"
+
 // validateSecurityConfig validates security configuration
 func validateSecurityConfig(cfg SecurityConfig, result *ValidationResult) {
 	validateSecurityCSRF(cfg, result)
 	validateSecurityCORS(cfg, result)
 	validateSecurityRateLimit(cfg, result)
+	validateSecurityCSP(cfg, result)
 	validateSecurityTLS(cfg, result)
+	validateSecurityEncryption(cfg, result)
+}
+
+func validateSecurityEncryption(cfg SecurityConfig, result *ValidationResult) {
+	if !cfg.Encryption.Enabled() {
+		return
+	}
+
+	if err := cfg.validateEncryption(); err != nil {
+		result.AddError("security.encryption", err.Error(), "***")
+	}
 }
 
 func validateSecurityCSRF(cfg SecurityConfig, result *ValidationResult) {
@@ -57,6 +71,34 @@ func validateSecurityRateLimit(cfg SecurityConfig, result *ValidationResult) {
 	}
 }
 
+// validateSecurityCSP checks that no CSP source list can end its directive and
+// start another, or break out of the header
+func validateSecurityCSP(cfg SecurityConfig, result *ValidationResult) {
+	if !cfg.CSP.Enabled {
+		return
+	}
+
+	sources := map[string]string{
+		"default_src": cfg.CSP.DefaultSrc,
+		"script_src":  cfg.CSP.ScriptSrc,
+		"style_src":   cfg.CSP.StyleSrc,
+		"img_src":     cfg.CSP.ImgSrc,
+		"connect_src": cfg.CSP.ConnectSrc,
+		"font_src":    cfg.CSP.FontSrc,
+		"object_src":  cfg.CSP.ObjectSrc,
+		"media_src":   cfg.CSP.MediaSrc,
+		"frame_src":   cfg.CSP.FrameSrc,
+		"report_uri":  cfg.CSP.ReportURI,
+	}
+
+	for key, value := range sources {
+		if strings.ContainsAny(value, ";\r\n") {
+			result.AddError("security.csp."+key,
+				"CSP sources must not contain semicolons or line breaks", value)
+		}
+	}
+}
+
 func validateSecurityTLS(cfg SecurityConfig, result *ValidationResult) {
 	if !cfg.TLS.Enabled {
 		return
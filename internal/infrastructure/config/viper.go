@@ -49,12 +49,21 @@ func (vc *ViperConfig) Load() (*Config, error) {
 		return nil, fmt.Errorf("failed to load configuration files: %w", err)
 	}
 
+	if err := vc.mergeSecretsFile(); err != nil {
+		return nil, fmt.Errorf("failed to load secrets file: %w", err)
+	}
+
 	config := &Config{}
 
 	if err := vc.loadAllConfigSections(config); err != nil {
 		return nil, fmt.Errorf("failed to load configuration sections: %w", err)
 	}
 
+	// Replace secret references by the secrets they point to
+	if err := resolveSecrets(config); err != nil {
+		return nil, fmt.Errorf("failed to resolve secrets: %w", err)
+	}
+
 	// Validate configuration with detailed error reporting
 	if err := config.validateConfig(); err != nil {
 		return nil, fmt.Errorf("configuration validation failed: %w", err)
@@ -70,7 +79,7 @@ func (vc *ViperConfig) loadConfigFiles() error {
 		var configFileNotFoundError viper.ConfigFileNotFoundError
 		if errors.As(err, &configFileNotFoundError) {
 			// Config file not found is not an error - we can use environment variables
-			fmt.Printf("No configuration file found, using environment variables and defaults\n")
+			fmt.Fprintf(os.Stderr, "No configuration file found, using environment variables and defaults\n")
 
 			return nil
 		}
@@ -79,7 +88,7 @@ func (vc *ViperConfig) loadConfigFiles() error {
 	}
 
 	// Log which config file was loaded
-	fmt.Printf("Loaded configuration from: %s\n", vc.viper.ConfigFileUsed())
+	fmt.Fprintf(os.Stderr, "Loaded configuration from: %s\n", vc.viper.ConfigFileUsed())
 
 	// Try to merge additional config files (like .env)
 	if err := vc.viper.MergeInConfig(); err != nil {
@@ -111,6 +120,8 @@ func (vc *ViperConfig) loadAllConfigSections(config *Config) error {
 		vc.loadAPIConfig,
 		vc.loadWebConfig,
 		vc.loadUserConfig,
+		vc.loadEventsConfig,
+		vc.loadLiveConfig,
 	}
 
 	for _, loader := range loaders {
@@ -148,17 +159,19 @@ func (vc *ViperConfig) loadAppConfig(config *Config) error {
 // loadDatabaseConfig loads database configuration
 func (vc *ViperConfig) loadDatabaseConfig(config *Config) error {
 	config.Database = DatabaseConfig{
-		Driver:          vc.viper.GetString("database.driver"),
-		Host:            vc.viper.GetString("database.host"),
-		Port:            vc.viper.GetInt("database.port"),
-		Name:            vc.viper.GetString("database.name"),
-		Username:        vc.viper.GetString("database.username"),
-		Password:        vc.viper.GetString("database.password"),
-		SSLMode:         vc.viper.GetString("database.ssl_mode"),
-		MaxOpenConns:    vc.viper.GetInt("database.max_open_conns"),
-		MaxIdleConns:    vc.viper.GetInt("database.max_idle_conns"),
-		ConnMaxLifetime: vc.viper.GetDuration("database.conn_max_lifetime"),
-		ConnMaxIdleTime: vc.viper.GetDuration("database.conn_max_idle_time"),
+		Driver:               vc.viper.GetString("database.driver"),
+		Host:                 vc.viper.GetString("database.host"),
+		Port:                 vc.viper.GetInt("database.port"),
+		Name:                 vc.viper.GetString("database.name"),
+		Username:             vc.viper.GetString("database.username"),
+		Password:             vc.viper.GetString("database.password"),
+		SSLMode:              vc.viper.GetString("database.ssl_mode"),
+		MaxOpenConns:         vc.viper.GetInt("database.max_open_conns"),
+		MaxIdleConns:         vc.viper.GetInt("database.max_idle_conns"),
+		ConnMaxLifetime:      vc.viper.GetDuration("database.conn_max_lifetime"),
+		ConnMaxIdleTime:      vc.viper.GetDuration("database.conn_max_idle_time"),
+		AutoMigrate:          vc.viper.GetBool("database.auto_migrate"),
+		MigrationLockTimeout: vc.viper.GetDuration("database.migration_lock_timeout"),
 	}
 
 	return nil
@@ -260,7 +273,15 @@ func (vc *ViperConfig) loadSecurityConfig(config *Config) error {
 			KeyFile:  vc.viper.GetString("security.tls.key_file"),
 		},
 		Encryption: EncryptionConfig{
-			Key: vc.viper.GetString("security.encryption.key"),
+			Key:            vc.viper.GetString("security.encryption.key"),
+			KeyID:          vc.viper.GetString("security.encryption.key_id"),
+			RetiredKeys:    vc.viper.GetStringSlice("security.encryption.retired_keys"),
+			Algorithm:      vc.viper.GetString("security.encryption.algorithm"),
+			KeySize:        vc.viper.GetInt("security.encryption.key_size"),
+			SaltLength:     vc.viper.GetInt("security.encryption.salt_length"),
+			Iterations:     vc.viper.GetInt("security.encryption.iterations"),
+			EnableAES:      vc.viper.GetBool("security.encryption.enable_aes"),
+			EnableChaCha20: vc.viper.GetBool("security.encryption.enable_cha_cha20"),
 		},
 		SecurityHeaders: vc.loadSecurityHeadersConfig(),
 		CookieSecurity: CookieSecurityConfig{
@@ -398,6 +419,15 @@ func (vc *ViperConfig) loadFormConfig(config *Config) error {
 			StrictMode: vc.viper.GetBool("form.validation.strict_mode"),
 			MaxErrors:  vc.viper.GetInt("form.validation.max_errors"),
 		},
+		Retention: RetentionConfig{
+			Enabled:    vc.viper.GetBool("form.retention.enabled"),
+			Interval:   vc.viper.GetDuration("form.retention.interval"),
+			PurgeAfter: vc.viper.GetDuration("form.retention.purge_after"),
+		},
+		Reencryption: ReencryptionConfig{
+			Enabled:  vc.viper.GetBool("form.reencryption.enabled"),
+			Interval: vc.viper.GetDuration("form.reencryption.interval"),
+		},
 	}
 
 	return nil
@@ -452,6 +482,40 @@ func (vc *ViperConfig) loadUserConfig(config *Config) error {
 	return nil
 }
 
+// loadEventsConfig loads event bus configuration
+func (vc *ViperConfig) loadEventsConfig(config *Config) error {
+	config.Events = EventsConfig{
+		Driver:          vc.viper.GetString("events.driver"),
+		NodeID:          vc.viper.GetString("events.node_id"),
+		Channel:         vc.viper.GetString("events.channel"),
+		Async:           vc.viper.GetBool("events.async"),
+		Workers:         vc.viper.GetInt("events.workers"),
+		QueueSize:       vc.viper.GetInt("events.queue_size"),
+		Overflow:        vc.viper.GetString("events.overflow"),
+		HandlerTimeout:  vc.viper.GetDuration("events.handler_timeout"),
+		RetryCount:      vc.viper.GetInt("events.retry_count"),
+		RetryDelay:      vc.viper.GetDuration("events.retry_delay"),
+		MaxBackoff:      vc.viper.GetDuration("events.max_backoff"),
+		MetricsInterval: vc.viper.GetDuration("events.metrics_interval"),
+	}
+
+	return nil
+}
+
+// loadLiveConfig loads live submissions feed configuration
+func (vc *ViperConfig) loadLiveConfig(config *Config) error {
+	config.Live = LiveConfig{
+		Enabled:           vc.viper.GetBool("live.enabled"),
+		HeartbeatInterval: vc.viper.GetDuration("live.heartbeat_interval"),
+		RetryInterval:     vc.viper.GetDuration("live.retry_interval"),
+		ClientBuffer:      vc.viper.GetInt("live.client_buffer"),
+		ReplayBuffer:      vc.viper.GetInt("live.replay_buffer"),
+		MaxStreamsPerUser: vc.viper.GetInt("live.max_streams_per_user"),
+	}
+
+	return nil
+}
+
 // LoadForEnvironment loads configuration for a specific environment
 func (vc *ViperConfig) LoadForEnvironment(env string) (*Config, error) {
 	// Set environment-specific config file
@@ -494,6 +558,9 @@ func setDefaults(v *viper.Viper) {
 	setAPIDefaults(v)
 	setWebDefaults(v)
 	setUserDefaults(v)
+	setEventsDefaults(v)
+	setLiveDefaults(v)
+	setSecretsDefaults(v)
 }
 
 // setAppDefaults sets application default values
@@ -528,6 +595,8 @@ func setDatabaseDefaults(v *viper.Viper) {
 	v.SetDefault("database.max_idle_conns", 25)
 	v.SetDefault("database.conn_max_lifetime", 5*time.Minute)
 	v.SetDefault("database.conn_max_idle_time", 5*time.Minute)
+	v.SetDefault("database.auto_migrate", false)
+	v.SetDefault("database.migration_lock_timeout", 5*time.Minute)
 }
 
 // setCSRFDefaults sets CSRF default values
@@ -596,6 +665,14 @@ func setSecurityDefaults(v *viper.Viper) {
 	setCSPDefaults(v)
 	v.SetDefault("security.tls.enabled", false)
 	v.SetDefault("security.encryption.key", "")
+	v.SetDefault("security.encryption.key_id", "k1")
+	v.SetDefault("security.encryption.retired_keys", []string{})
+	v.SetDefault("security.encryption.algorithm", "aes-256-gcm")
+	v.SetDefault("security.encryption.key_size", 32)
+	v.SetDefault("security.encryption.salt_length", 16)
+	v.SetDefault("security.encryption.iterations", 600000)
+	v.SetDefault("security.encryption.enable_aes", true)
+	v.SetDefault("security.encryption.enable_cha_cha20", true)
 	v.SetDefault("security.secure_cookie", false)
 	v.SetDefault("security.debug", false)
 	setSecurityHeadersDefaults(v)
@@ -680,6 +757,11 @@ func setFormDefaults(v *viper.Viper) {
 	v.SetDefault("form.max_memory", 32*1024*1024) // 32MB
 	v.SetDefault("form.validation.strict_mode", false)
 	v.SetDefault("form.validation.max_errors", 10)
+	v.SetDefault("form.retention.enabled", true)
+	v.SetDefault("form.retention.interval", "1h")
+	v.SetDefault("form.retention.purge_after", "720h")
+	v.SetDefault("form.reencryption.enabled", true)
+	v.SetDefault("form.reencryption.interval", "6h")
 }
 
 // setAPIDefaults sets API default values
@@ -713,11 +795,46 @@ func setUserDefaults(v *viper.Viper) {
 	v.SetDefault("user.default.permissions", []string{"read"})
 }
 
-// NewViperConfigProvider creates an Fx provider for Viper configuration
+// setEventsDefaults sets event bus default values
+func setEventsDefaults(v *viper.Viper) {
+	v.SetDefault("events.driver", EventDriverMemory)
+	v.SetDefault("events.node_id", "")
+	v.SetDefault("events.channel", "goforms_events")
+	v.SetDefault("events.async", true)
+	v.SetDefault("events.workers", 4)
+	v.SetDefault("events.queue_size", 256)
+	v.SetDefault("events.overflow", EventOverflowBlock)
+	v.SetDefault("events.handler_timeout", 30*time.Second)
+	v.SetDefault("events.retry_count", 3)
+	v.SetDefault("events.retry_delay", time.Second)
+	v.SetDefault("events.max_backoff", 30*time.Second)
+	v.SetDefault("events.metrics_interval", time.Minute)
+}
+
+// setLiveDefaults sets live submissions feed default values
+func setLiveDefaults(v *viper.Viper) {
+	v.SetDefault("live.enabled", true)
+	v.SetDefault("live.heartbeat_interval", 15*time.Second)
+	v.SetDefault("live.retry_interval", 3*time.Second)
+	v.SetDefault("live.client_buffer", 32)
+	v.SetDefault("live.replay_buffer", 512)
+	v.SetDefault("live.max_streams_per_user", 10)
+}
+
+// setSecretsDefaults sets encrypted secrets file default values. No file is
+// read unless secrets.file is set.
+func setSecretsDefaults(v *viper.Viper) {
+	v.SetDefault("secrets.file", "")
+	v.SetDefault("secrets.master_key", "")
+}
+
+// NewViperConfigProvider creates an Fx provider for Viper configuration. The
+// loader is provided too, so that the configuration can be reloaded.
 func NewViperConfigProvider() fx.Option {
-	return fx.Provide(func() (*Config, error) {
-		vc := NewViperConfig()
-
-		return vc.Load()
-	})
+	return fx.Provide(
+		NewViperConfig,
+		func(vc *ViperConfig) (*Config, error) {
+			return vc.Load()
+		},
+	)
 }
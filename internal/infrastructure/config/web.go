@@ -4,11 +4,28 @@ import "time"
 
 // FormConfig holds form-related configuration
 type FormConfig struct {
-	MaxFileSize      int64            `json:"max_file_size"`
-	AllowedFileTypes []string         `json:"allowed_file_types"`
-	MaxFields        int              `json:"max_fields"`
-	MaxMemory        int64            `json:"max_memory"`
-	Validation       ValidationConfig `json:"validation"`
+	MaxFileSize      int64              `json:"max_file_size"`
+	AllowedFileTypes []string           `json:"allowed_file_types"`
+	MaxFields        int                `json:"max_fields"`
+	MaxMemory        int64              `json:"max_memory"`
+	Validation       ValidationConfig   `json:"validation"`
+	Retention        RetentionConfig    `json:"retention"`
+	Reencryption     ReencryptionConfig `json:"reencryption"`
+}
+
+// RetentionConfig holds the submission retention job configuration
+type RetentionConfig struct {
+	Enabled  bool          `json:"enabled"`
+	Interval time.Duration `json:"interval"`
+	// PurgeAfter is how long soft-deleted forms are kept before they are purged
+	PurgeAfter time.Duration `json:"purge_after"`
+}
+
+// ReencryptionConfig holds the configuration of the job that moves encrypted
+// answers to the active encryption key
+type ReencryptionConfig struct {
+	Enabled  bool          `json:"enabled"`
+	Interval time.Duration `json:"interval"`
 }
 
 // ValidationConfig holds form validation configuration
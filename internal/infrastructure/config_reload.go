@@ -0,0 +1,176 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"go.uber.org/fx"
+
+	"github.com/goformx/goforms/internal/domain/audit"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/keyring"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+// RegisterConfigReload applies reloaded settings to the logger, redacting
+// rotated secrets from then on, and rotated field encryption keys to the field
+// keyring. It logs and audits each reload, with the rotated secrets that only
+// take effect after a restart, and watches for configuration changes while the
+// application runs. The middleware manager applies the CORS, rate limit and CSP
+// settings.
+func RegisterConfigReload(
+	lc fx.Lifecycle,
+	reloader *config.Reloader,
+	cfg *config.Config,
+	factory *logging.Factory,
+	fieldKeys *keyring.Rotating,
+	logger logging.Logger,
+	auditService audit.Service,
+) {
+	reloader.Subscribe(func(settings config.ReloadableConfig) error {
+		app := cfg.App
+		app.LogLevel = settings.LogLevel
+
+		if err := factory.SetLevel(determineLogLevel(&app)); err != nil {
+			return fmt.Errorf("set log level: %w", err)
+		}
+
+		factory.RedactSecrets(secretValues(cfg, settings.Secrets))
+
+		return nil
+	})
+
+	// Field encryption can't be turned on without a restart, since the
+	// services were created without it
+	if fieldKeys.Enabled() {
+		current := cfg.Reloadable()
+		applied := current.Encryption(cfg.Security.Encryption)
+
+		reloader.ApplySecrets(config.EncryptionKeyPath, config.RetiredKeysPath)
+		reloader.Subscribe(func(settings config.ReloadableConfig) error {
+			next := settings.Encryption(cfg.Security.Encryption)
+			if reflect.DeepEqual(next, applied) {
+				return nil
+			}
+
+			if err := rotateFieldKeys(fieldKeys, next); err != nil {
+				return err
+			}
+
+			applied = next
+
+			logger.Info("field encryption keys rotated", "key_id", next.KeyID)
+
+			return nil
+		})
+	}
+
+	reloader.OnReload(func(event config.ReloadEvent) {
+		recordConfigReload(context.Background(), logger, auditService, &event)
+	})
+
+	lc.Append(fx.Hook{
+		OnStart: reloader.Start,
+		OnStop:  reloader.Stop,
+	})
+}
+
+// recordConfigReload logs a reload and records it in the audit log with the
+// settings that changed
+func recordConfigReload(
+	ctx context.Context,
+	logger logging.Logger,
+	auditService audit.Service,
+	event *config.ReloadEvent,
+) {
+	record := &audit.Record{
+		Action:     audit.ActionConfigReloaded,
+		TargetType: audit.TargetConfig,
+		Before:     reloadSummary(&event.Previous, event.Changed),
+		After:      reloadSummary(&event.Next, event.Changed),
+	}
+
+	if event.Err != nil {
+		logger.Error("configuration reload failed, keeping the previous configuration",
+			"source", event.Source, "changed", event.Changed, "error", event.Err)
+
+		record.Action = audit.ActionConfigReloadFailed
+		record.After["error"] = event.Err.Error()
+	} else {
+		logger.Info("configuration reloaded", "source", event.Source, "changed", event.Changed)
+
+		if len(event.RestartRequired) > 0 {
+			logger.Warn("rotated secrets take effect after a restart", "secrets", event.RestartRequired)
+
+			record.After["restart_required"] = event.RestartRequired
+		}
+	}
+
+	if err := auditService.Record(ctx, record); err != nil {
+		logger.Error("failed to audit configuration reload", "error", err)
+	}
+}
+
+// rotateFieldKeys replaces the field keyring with one built from the reloaded
+// key ID and keys
+func rotateFieldKeys(fieldKeys *keyring.Rotating, encryptionCfg config.EncryptionConfig) error {
+	if !encryptionCfg.Enabled() {
+		return errors.New("field encryption cannot be disabled without a restart")
+	}
+
+	kr, err := keyring.New(encryptionCfg)
+	if err != nil {
+		return fmt.Errorf("rotate field encryption keys: %w", err)
+	}
+
+	if err = fieldKeys.Rotate(kr); err != nil {
+		return fmt.Errorf("rotate field encryption keys: %w", err)
+	}
+
+	return nil
+}
+
+// reloadSummary returns the changed sections of reloadable settings, by their
+// name in the configuration
+func reloadSummary(settings *config.ReloadableConfig, changed []string) model.JSON {
+	summary := model.JSON{}
+
+	data, err := json.Marshal(settings)
+	if err != nil {
+		return summary
+	}
+
+	var sections model.JSON
+	if err = json.Unmarshal(data, &sections); err != nil {
+		return summary
+	}
+
+	for _, key := range changed {
+		if strings.HasPrefix(key, config.ReloadKeySecrets+".") {
+			summary[key] = config.RedactedValue
+
+			continue
+		}
+
+		summary[key] = sections[key[strings.LastIndex(key, ".")+1:]]
+	}
+
+	return summary
+}
+
+// secretValues returns the secrets to redact from logs: the reloaded ones and
+// those read at startup, which stay in use by the connections made with them
+func secretValues(cfg *config.Config, secrets map[string]string) []string {
+	values := cfg.SecretValues()
+
+	for _, value := range secrets {
+		values = append(values, value)
+	}
+
+	return values
+}
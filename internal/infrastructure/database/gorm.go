@@ -123,7 +123,7 @@ func createDatabaseConnection(cfg *config.Config, gormConfig *gorm.Config) (*gor
 	// Create database connection based on the selected driver
 	switch cfg.Database.Driver {
 	case "postgres":
-		dsn := buildPostgresDSN(cfg)
+		dsn := PostgresDSN(cfg)
 		db, err = gorm.Open(postgres.Open(dsn), gormConfig)
 	case "mariadb":
 		dsn := buildMariaDBDSN(cfg)
@@ -139,8 +139,8 @@ func createDatabaseConnection(cfg *config.Config, gormConfig *gorm.Config) (*gor
 	return db, nil
 }
 
-// buildPostgresDSN builds the PostgreSQL connection string
-func buildPostgresDSN(cfg *config.Config) string {
+// PostgresDSN builds the PostgreSQL connection string
+func PostgresDSN(cfg *config.Config) string {
 	return fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
 		cfg.Database.Host,
 		cfg.Database.Port,
@@ -151,9 +151,10 @@ func buildPostgresDSN(cfg *config.Config) string {
 	)
 }
 
-// buildMariaDBDSN builds the MariaDB connection string
+// buildMariaDBDSN builds the MariaDB connection string.
+// multiStatements is required to run migration files containing several statements.
 func buildMariaDBDSN(cfg *config.Config) string {
-	return fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?charset=utf8mb4&parseTime=True&loc=UTC",
+	return fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?charset=utf8mb4&parseTime=True&loc=UTC&multiStatements=true",
 		cfg.Database.Username,
 		cfg.Database.Password,
 		cfg.Database.Host,
@@ -0,0 +1,114 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// TableName is the version table shared with golang-migrate, so databases
+// migrated by the external tool can be picked up by the embedded runner.
+const TableName = "schema_migrations"
+
+// advisoryLockID is the PostgreSQL advisory lock key held while migrating
+const advisoryLockID int64 = 3_817_462_905
+
+// lockName is the MariaDB named lock held while migrating
+const lockName = "goforms_schema_migrations"
+
+// dialect captures the SQL differences between the supported databases
+type dialect interface {
+	// dir is the directory of the migration set inside the embedded filesystem
+	dir() string
+	createTable() string
+	insertVersion() string
+	lock(ctx context.Context, conn *sql.Conn, timeout time.Duration) error
+	unlock(ctx context.Context, conn *sql.Conn) error
+}
+
+// dialectFor returns the dialect for a configured database driver
+func dialectFor(driver string) (dialect, error) {
+	switch driver {
+	case "postgres":
+		return postgresDialect{}, nil
+	case "mariadb":
+		return mariadbDialect{}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedDriver, driver)
+	}
+}
+
+type postgresDialect struct{}
+
+func (postgresDialect) dir() string { return "postgresql" }
+
+func (postgresDialect) createTable() string {
+	return "CREATE TABLE IF NOT EXISTS " + TableName +
+		" (version bigint NOT NULL PRIMARY KEY, dirty boolean NOT NULL)"
+}
+
+func (postgresDialect) insertVersion() string {
+	return "INSERT INTO " + TableName + " (version, dirty) VALUES ($1, $2)"
+}
+
+// lock blocks on a session-level advisory lock until it is granted or the timeout elapses
+func (postgresDialect) lock(ctx context.Context, conn *sql.Conn, timeout time.Duration) error {
+	lockCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if _, err := conn.ExecContext(lockCtx, "SELECT pg_advisory_lock($1)", advisoryLockID); err != nil {
+		if lockCtx.Err() != nil {
+			return ErrLockTimeout
+		}
+
+		return fmt.Errorf("acquire advisory lock: %w", err)
+	}
+
+	return nil
+}
+
+func (postgresDialect) unlock(ctx context.Context, conn *sql.Conn) error {
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", advisoryLockID); err != nil {
+		return fmt.Errorf("release advisory lock: %w", err)
+	}
+
+	return nil
+}
+
+type mariadbDialect struct{}
+
+func (mariadbDialect) dir() string { return "mariadb" }
+
+func (mariadbDialect) createTable() string {
+	return "CREATE TABLE IF NOT EXISTS " + TableName +
+		" (version bigint NOT NULL PRIMARY KEY, dirty boolean NOT NULL)"
+}
+
+func (mariadbDialect) insertVersion() string {
+	return "INSERT INTO " + TableName + " (version, dirty) VALUES (?, ?)"
+}
+
+// lock acquires a named lock; GET_LOCK returns 1 on success and 0 on timeout
+func (mariadbDialect) lock(ctx context.Context, conn *sql.Conn, timeout time.Duration) error {
+	var acquired sql.NullInt64
+
+	row := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, ?)", lockName, int(timeout.Seconds()))
+	if err := row.Scan(&acquired); err != nil {
+		return fmt.Errorf("acquire named lock: %w", err)
+	}
+
+	if !acquired.Valid || acquired.Int64 != 1 {
+		return ErrLockTimeout
+	}
+
+	return nil
+}
+
+func (mariadbDialect) unlock(ctx context.Context, conn *sql.Conn) error {
+	if _, err := conn.ExecContext(ctx, "SELECT RELEASE_LOCK(?)", lockName); err != nil {
+		return fmt.Errorf("release named lock: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,28 @@
+package migration
+
+import "errors"
+
+var (
+	// ErrDirty is returned when a previous migration failed part-way and the
+	// schema needs manual repair followed by "migrate force"
+	ErrDirty = errors.New("database schema is dirty")
+
+	// ErrUnsupportedDriver is returned for database drivers without a migration set
+	ErrUnsupportedDriver = errors.New("unsupported migration driver")
+
+	// ErrDuplicateVersion is returned when two migrations share a version
+	ErrDuplicateVersion = errors.New("duplicate migration version")
+
+	// ErrMissingUp is returned when a version only has a down migration
+	ErrMissingUp = errors.New("missing up migration")
+
+	// ErrMissingDown is returned when rolling back a migration without a down file
+	ErrMissingDown = errors.New("missing down migration")
+
+	// ErrUnknownVersion is returned when the database is at a version that is
+	// not part of the embedded migration set
+	ErrUnknownVersion = errors.New("unknown migration version")
+
+	// ErrLockTimeout is returned when the migration lock could not be acquired in time
+	ErrLockTimeout = errors.New("timed out waiting for migration lock")
+)
@@ -0,0 +1,364 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+	"time"
+
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+const (
+	// NilVersion is the version reported when no migration has been applied
+	NilVersion int64 = -1
+
+	// DefaultLockTimeout is used when no lock timeout is configured
+	DefaultLockTimeout = 5 * time.Minute
+
+	// unlockTimeout bounds the lock release, which runs after the caller's context may be done
+	unlockTimeout = 10 * time.Second
+)
+
+// Migrator applies embedded migrations to a database. All operations run on a
+// single connection while holding a database lock so concurrent replicas do not
+// race each other.
+type Migrator struct {
+	db          *sql.DB
+	dialect     dialect
+	migrations  []Migration
+	logger      logging.Logger
+	lockTimeout time.Duration
+}
+
+// Entry describes a single migration in a status report
+type Entry struct {
+	Version uint64 `json:"version"`
+	Name    string `json:"name"`
+	Applied bool   `json:"applied"`
+}
+
+// Status describes the schema state of the database
+type Status struct {
+	Version int64   `json:"version"`
+	Dirty   bool    `json:"dirty"`
+	Pending int     `json:"pending"`
+	Entries []Entry `json:"entries"`
+}
+
+// New creates a migrator for the given driver using the migration set found in fsys
+func New(
+	db *sql.DB,
+	driver string,
+	fsys fs.FS,
+	logger logging.Logger,
+	lockTimeout time.Duration,
+) (*Migrator, error) {
+	d, err := dialectFor(driver)
+	if err != nil {
+		return nil, err
+	}
+
+	migrations, err := Load(fsys, d.dir())
+	if err != nil {
+		return nil, err
+	}
+
+	if lockTimeout <= 0 {
+		lockTimeout = DefaultLockTimeout
+	}
+
+	return &Migrator{
+		db:          db,
+		dialect:     d,
+		migrations:  migrations,
+		logger:      logger,
+		lockTimeout: lockTimeout,
+	}, nil
+}
+
+// Migrations returns the embedded migrations ordered by version
+func (m *Migrator) Migrations() []Migration {
+	return m.migrations
+}
+
+// Status reports the current version, dirty flag and pending migrations
+func (m *Migrator) Status(ctx context.Context) (*Status, error) {
+	var status *Status
+
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		version, dirty, err := m.readVersion(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		status = buildStatus(m.migrations, version, dirty)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return status, nil
+}
+
+// Up applies all pending migrations and returns how many were applied
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	applied := 0
+
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		version, dirty, err := m.readVersion(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		if dirty {
+			return dirtyError(version)
+		}
+
+		for _, mig := range m.migrations {
+			if int64(mig.Version) <= version {
+				continue
+			}
+
+			if runErr := m.run(ctx, conn, int64(mig.Version), mig.Up); runErr != nil {
+				return fmt.Errorf("apply migration %d_%s: %w", mig.Version, mig.Name, runErr)
+			}
+
+			m.logger.Info("applied migration", "version", mig.Version, "name", mig.Name)
+
+			applied++
+		}
+
+		return nil
+	})
+
+	return applied, err
+}
+
+// Down rolls back up to steps migrations, or all of them when steps <= 0,
+// and returns how many were rolled back
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	rolledBack := 0
+
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		version, dirty, err := m.readVersion(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		if dirty {
+			return dirtyError(version)
+		}
+
+		if version == NilVersion {
+			return nil
+		}
+
+		idx := m.indexOf(version)
+		if idx < 0 {
+			return fmt.Errorf("%w: %d", ErrUnknownVersion, version)
+		}
+
+		for ; idx >= 0 && (steps <= 0 || rolledBack < steps); idx-- {
+			mig := m.migrations[idx]
+
+			if strings.TrimSpace(mig.Down) == "" {
+				return fmt.Errorf("%w: version %d (%s)", ErrMissingDown, mig.Version, mig.Name)
+			}
+
+			target := NilVersion
+			if idx > 0 {
+				target = int64(m.migrations[idx-1].Version)
+			}
+
+			if runErr := m.run(ctx, conn, target, mig.Down); runErr != nil {
+				return fmt.Errorf("roll back migration %d_%s: %w", mig.Version, mig.Name, runErr)
+			}
+
+			m.logger.Info("rolled back migration", "version", mig.Version, "name", mig.Name)
+
+			rolledBack++
+		}
+
+		return nil
+	})
+
+	return rolledBack, err
+}
+
+// Force sets the schema version without running any migration and clears the
+// dirty flag. Use NilVersion to mark the database as having no migrations applied.
+func (m *Migrator) Force(ctx context.Context, version int64) error {
+	if version < NilVersion {
+		return fmt.Errorf("%w: %d", ErrUnknownVersion, version)
+	}
+
+	if version != NilVersion && m.indexOf(version) < 0 {
+		return fmt.Errorf("%w: %d", ErrUnknownVersion, version)
+	}
+
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		return m.setVersion(ctx, conn, version, false)
+	})
+}
+
+// EnsureClean returns ErrDirty when the schema was left dirty by a failed migration
+func (m *Migrator) EnsureClean(ctx context.Context) (*Status, error) {
+	status, err := m.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if status.Dirty {
+		return status, dirtyError(status.Version)
+	}
+
+	return status, nil
+}
+
+// run marks the target version dirty, executes the migration body and clears the flag.
+// A failure leaves the version dirty, matching golang-migrate semantics.
+func (m *Migrator) run(ctx context.Context, conn *sql.Conn, target int64, body string) error {
+	if err := m.setVersion(ctx, conn, target, true); err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(body) != "" {
+		if _, err := conn.ExecContext(ctx, body); err != nil {
+			return fmt.Errorf("execute migration: %w", err)
+		}
+	}
+
+	return m.setVersion(ctx, conn, target, false)
+}
+
+// withLock runs fn on a dedicated connection while holding the migration lock
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) (err error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("get database connection: %w", err)
+	}
+
+	defer func() {
+		if closeErr := conn.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("close database connection: %w", closeErr)
+		}
+	}()
+
+	if lockErr := m.dialect.lock(ctx, conn, m.lockTimeout); lockErr != nil {
+		return lockErr
+	}
+
+	defer func() {
+		unlockCtx, cancel := context.WithTimeout(context.Background(), unlockTimeout)
+		defer cancel()
+
+		if unlockErr := m.dialect.unlock(unlockCtx, conn); unlockErr != nil {
+			m.logger.Warn("failed to release migration lock", "error", unlockErr)
+		}
+	}()
+
+	if _, createErr := conn.ExecContext(ctx, m.dialect.createTable()); createErr != nil {
+		return fmt.Errorf("create %s table: %w", TableName, createErr)
+	}
+
+	return fn(conn)
+}
+
+// readVersion returns the recorded version, or NilVersion when none is recorded
+func (m *Migrator) readVersion(ctx context.Context, conn *sql.Conn) (int64, bool, error) {
+	var (
+		version int64
+		dirty   bool
+	)
+
+	row := conn.QueryRowContext(ctx, "SELECT version, dirty FROM "+TableName+" LIMIT 1")
+	if err := row.Scan(&version, &dirty); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return NilVersion, false, nil
+		}
+
+		return 0, false, fmt.Errorf("read schema version: %w", err)
+	}
+
+	return version, dirty, nil
+}
+
+// setVersion replaces the single version row in a transaction
+func (m *Migrator) setVersion(ctx context.Context, conn *sql.Conn, version int64, dirty bool) (err error) {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin version transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				m.logger.Warn("failed to roll back version transaction", "error", rollbackErr)
+			}
+		}
+	}()
+
+	if _, err = tx.ExecContext(ctx, "DELETE FROM "+TableName); err != nil {
+		return fmt.Errorf("clear schema version: %w", err)
+	}
+
+	// A dirty nil version is still recorded so a failed first migration is not forgotten
+	if version >= 0 || dirty {
+		if _, err = tx.ExecContext(ctx, m.dialect.insertVersion(), version, dirty); err != nil {
+			return fmt.Errorf("record schema version: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit schema version: %w", err)
+	}
+
+	return nil
+}
+
+// indexOf returns the position of version in the migration set, or -1
+func (m *Migrator) indexOf(version int64) int {
+	for i, mig := range m.migrations {
+		if int64(mig.Version) == version {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// buildStatus summarises the migration set relative to the recorded version
+func buildStatus(migrations []Migration, version int64, dirty bool) *Status {
+	status := &Status{
+		Version: version,
+		Dirty:   dirty,
+		Entries: make([]Entry, 0, len(migrations)),
+	}
+
+	for _, mig := range migrations {
+		applied := int64(mig.Version) <= version
+		if !applied {
+			status.Pending++
+		}
+
+		status.Entries = append(status.Entries, Entry{
+			Version: mig.Version,
+			Name:    mig.Name,
+			Applied: applied,
+		})
+	}
+
+	return status
+}
+
+// dirtyError wraps ErrDirty with the version that needs repair
+func dirtyError(version int64) error {
+	return fmt.Errorf("%w at version %d: repair the schema and run 'goforms migrate force -version <version>'",
+		ErrDirty, version)
+}
@@ -0,0 +1,90 @@
+// Package migration provides an embedded schema migration runner that is
+// compatible with the schema_migrations table maintained by golang-migrate.
+package migration
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// fileNamePattern matches golang-migrate style file names, e.g. 1970010101_create_users_table.up.sql
+var fileNamePattern = regexp.MustCompile(`^([0-9]+)_(.+)\.(up|down)\.sql$`)
+
+// Migration represents a single versioned schema change
+type Migration struct {
+	Version uint64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Load reads every migration in dir from fsys and returns them ordered by version.
+// A version must have an up file; down files are optional.
+func Load(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("read migrations directory %s: %w", dir, err)
+	}
+
+	byVersion := make(map[uint64]*Migration)
+	hasUp := make(map[uint64]bool)
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		matches := fileNamePattern.FindStringSubmatch(entry.Name())
+		if matches == nil {
+			continue
+		}
+
+		version, parseErr := strconv.ParseUint(matches[1], 10, 64)
+		if parseErr != nil {
+			return nil, fmt.Errorf("parse migration version %s: %w", entry.Name(), parseErr)
+		}
+
+		body, readErr := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if readErr != nil {
+			return nil, fmt.Errorf("read migration %s: %w", entry.Name(), readErr)
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: matches[2]}
+			byVersion[version] = m
+		}
+
+		if m.Name != matches[2] {
+			return nil, fmt.Errorf("%w: version %d is used by %q and %q",
+				ErrDuplicateVersion, version, m.Name, matches[2])
+		}
+
+		if matches[3] == "up" {
+			m.Up = string(body)
+			hasUp[version] = true
+		} else {
+			m.Down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+
+	for _, m := range byVersion {
+		if !hasUp[m.Version] {
+			return nil, fmt.Errorf("%w: version %d (%s)", ErrMissingUp, m.Version, m.Name)
+		}
+
+		migrations = append(migrations, *m)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
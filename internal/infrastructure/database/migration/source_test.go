@@ -0,0 +1,82 @@
+package migration_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/infrastructure/database/migration"
+	"github.com/goformx/goforms/migrations"
+)
+
+func TestLoad(t *testing.T) {
+	fsys := fstest.MapFS{
+		"pg/2_add_index.up.sql":      {Data: []byte("CREATE INDEX idx ON t (c);")},
+		"pg/1_create_table.up.sql":   {Data: []byte("CREATE TABLE t (c int);")},
+		"pg/1_create_table.down.sql": {Data: []byte("DROP TABLE t;")},
+		"pg/README.md":               {Data: []byte("ignored")},
+	}
+
+	loaded, err := migration.Load(fsys, "pg")
+	require.NoError(t, err)
+	require.Len(t, loaded, 2)
+
+	assert.Equal(t, uint64(1), loaded[0].Version)
+	assert.Equal(t, "create_table", loaded[0].Name)
+	assert.Equal(t, "DROP TABLE t;", loaded[0].Down)
+	assert.Equal(t, uint64(2), loaded[1].Version)
+	assert.Empty(t, loaded[1].Down)
+}
+
+func TestLoad_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		fsys    fstest.MapFS
+		wantErr error
+	}{
+		{
+			name: "down without up",
+			fsys: fstest.MapFS{
+				"pg/1_create_table.down.sql": {Data: []byte("DROP TABLE t;")},
+			},
+			wantErr: migration.ErrMissingUp,
+		},
+		{
+			name: "duplicate version",
+			fsys: fstest.MapFS{
+				"pg/1_create_table.up.sql": {Data: []byte("CREATE TABLE t (c int);")},
+				"pg/1_other_table.up.sql":  {Data: []byte("CREATE TABLE o (c int);")},
+			},
+			wantErr: migration.ErrDuplicateVersion,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := migration.Load(tt.fsys, "pg")
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestNew_EmbeddedMigrationSets(t *testing.T) {
+	for _, driver := range []string{"postgres", "mariadb"} {
+		t.Run(driver, func(t *testing.T) {
+			migrator, err := migration.New(nil, driver, migrations.FS, nil, 0)
+			require.NoError(t, err)
+			require.NotEmpty(t, migrator.Migrations())
+
+			for _, m := range migrator.Migrations() {
+				assert.NotEmpty(t, m.Up, "version %d has an empty up migration", m.Version)
+				assert.NotEmpty(t, m.Down, "version %d has no down migration", m.Version)
+			}
+		})
+	}
+}
+
+func TestNew_UnsupportedDriver(t *testing.T) {
+	_, err := migration.New(nil, "sqlite", migrations.FS, nil, 0)
+	require.ErrorIs(t, err, migration.ErrUnsupportedDriver)
+}
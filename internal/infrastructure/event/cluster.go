@@ -0,0 +1,313 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	"github.com/google/uuid"
+
+	"github.com/goformx/goforms/internal/domain/common/events"
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+// nodeSuffixLength is the number of random characters appended to generated node IDs
+const nodeSuffixLength = 8
+
+// Transport carries encoded envelopes between the instances sharing a ClusterEventBus
+type Transport interface {
+	// Send delivers data to every instance listening, including the sender
+	Send(ctx context.Context, data []byte) error
+	// Listen passes everything sent by any instance to deliver until ctx ends
+	Listen(ctx context.Context, deliver func(data []byte)) error
+}
+
+// ClusterEventBus implements events.EventBus for several instances of the
+// application. Subscribe only receives events published on this instance;
+// SubscribeCluster also receives the events of the other instances. Events are
+// sent as versioned envelopes and an instance ignores its own when they come
+// back from the transport.
+type ClusterEventBus struct {
+	logger    logging.Logger
+	node      string
+	codec     *Codec
+	transport Transport
+
+	// local handles Subscribe handlers, cluster handles SubscribeCluster handlers
+	local   *MemoryEventBus
+	cluster *MemoryEventBus
+
+	stopListener context.CancelFunc
+	listenerDone chan struct{}
+	mu           sync.Mutex
+
+	sent     atomic.Int64
+	received atomic.Int64
+	invalid  atomic.Int64
+}
+
+// Ensure ClusterEventBus implements the bus interfaces
+var (
+	_ events.EventBus          = (*ClusterEventBus)(nil)
+	_ events.ClusterSubscriber = (*ClusterEventBus)(nil)
+)
+
+// NewClusterEventBus creates an event bus that fans events out over transport.
+// The node ID defaults to the host name with a random suffix.
+func NewClusterEventBus(
+	logger logging.Logger,
+	cfg config.EventsConfig,
+	transport Transport,
+	codec *Codec,
+) *ClusterEventBus {
+	node := cfg.NodeID
+	if node == "" {
+		node = defaultNodeID()
+	}
+
+	return &ClusterEventBus{
+		logger:    logger,
+		node:      node,
+		codec:     codec,
+		transport: transport,
+		local:     NewMemoryEventBus(logger, cfg),
+		cluster:   NewMemoryEventBus(logger, cfg),
+	}
+}
+
+// Node returns the ID this instance publishes its events under
+func (b *ClusterEventBus) Node() string {
+	return b.node
+}
+
+// Publish delivers an event to this instance's subscribers and sends it to the
+// other instances
+func (b *ClusterEventBus) Publish(ctx context.Context, event events.Event) error {
+	correlate(ctx, event)
+
+	var errs []error
+
+	if err := b.local.Publish(ctx, event); err != nil {
+		errs = append(errs, err)
+	}
+
+	if err := b.cluster.Publish(ctx, event); err != nil {
+		errs = append(errs, err)
+	}
+
+	if err := b.send(ctx, event); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+// PublishBatch publishes multiple events, continuing past events that fail
+func (b *ClusterEventBus) PublishBatch(ctx context.Context, eventList []events.Event) error {
+	var errs []error
+
+	for _, event := range eventList {
+		if err := b.Publish(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Subscribe subscribes to an event published on this instance
+func (b *ClusterEventBus) Subscribe(
+	ctx context.Context,
+	eventName string,
+	handler func(context.Context, events.Event) error,
+) error {
+	return b.local.Subscribe(ctx, eventName, handler)
+}
+
+// SubscribeCluster subscribes to an event published on any instance
+func (b *ClusterEventBus) SubscribeCluster(
+	ctx context.Context,
+	eventName string,
+	handler func(context.Context, events.Event) error,
+) error {
+	return b.cluster.Subscribe(ctx, eventName, handler)
+}
+
+// Unsubscribe unsubscribes all handlers from an event
+func (b *ClusterEventBus) Unsubscribe(ctx context.Context, eventName string) error {
+	return errors.Join(
+		b.local.Unsubscribe(ctx, eventName),
+		b.cluster.Unsubscribe(ctx, eventName),
+	)
+}
+
+// Start starts the local buses and listens for the events of other instances
+func (b *ClusterEventBus) Start(ctx context.Context) error {
+	if err := errors.Join(b.local.Start(ctx), b.cluster.Start(ctx)); err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.stopListener != nil {
+		return nil
+	}
+
+	listenCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	b.stopListener = cancel
+	b.listenerDone = make(chan struct{})
+
+	go func() {
+		defer close(b.listenerDone)
+
+		if err := b.transport.Listen(listenCtx, func(data []byte) {
+			b.receive(listenCtx, data)
+		}); err != nil {
+			b.logger.Error("event listener stopped", "node", b.node, "error", err)
+		}
+	}()
+
+	b.logger.Info("cluster event bus started", "node", b.node)
+
+	return nil
+}
+
+// Stop stops listening for other instances' events and drains the local buses
+func (b *ClusterEventBus) Stop(ctx context.Context) error {
+	b.mu.Lock()
+	stopListener, listenerDone := b.stopListener, b.listenerDone
+	b.mu.Unlock()
+
+	if stopListener != nil {
+		stopListener()
+
+		select {
+		case <-listenerDone:
+		case <-ctx.Done():
+		}
+	}
+
+	return errors.Join(b.local.Stop(ctx), b.cluster.Stop(ctx))
+}
+
+// Health returns the health status of the event bus
+func (b *ClusterEventBus) Health(ctx context.Context) error {
+	return errors.Join(b.local.Health(ctx), b.cluster.Health(ctx))
+}
+
+// GetMetrics returns the metrics of the local buses and the fan-out counters
+func (b *ClusterEventBus) GetMetrics() map[string]any {
+	return map[string]any{
+		"node":     b.node,
+		"sent":     b.sent.Load(),
+		"received": b.received.Load(),
+		"invalid":  b.invalid.Load(),
+		"local":    b.local.GetMetrics(),
+		"cluster":  b.cluster.GetMetrics(),
+	}
+}
+
+// send encodes an event and sends it to the other instances
+func (b *ClusterEventBus) send(ctx context.Context, event events.Event) error {
+	data, err := b.codec.Encode(b.node, event)
+	if err != nil {
+		return fmt.Errorf("failed to send event %s: %w", event.Name(), err)
+	}
+
+	if sendErr := b.transport.Send(ctx, data); sendErr != nil {
+		return fmt.Errorf("failed to send event %s: %w", event.Name(), sendErr)
+	}
+
+	b.sent.Add(1)
+
+	return nil
+}
+
+// receive decodes an envelope from the transport and delivers it to the
+// cluster subscribers, unless this instance published it
+func (b *ClusterEventBus) receive(ctx context.Context, data []byte) {
+	event, err := b.codec.Decode(data)
+	if err != nil {
+		b.invalid.Add(1)
+		b.logger.Warn("failed to decode event from another instance", "node", b.node, "error", err)
+
+		return
+	}
+
+	if event.Node() == b.node {
+		return
+	}
+
+	b.received.Add(1)
+
+	if publishErr := b.cluster.Publish(ctx, event); publishErr != nil {
+		b.logger.Error("failed to deliver event from another instance",
+			"event", event.Name(),
+			"from", event.Node(),
+			"error", publishErr,
+		)
+	}
+}
+
+// defaultNodeID returns the host name with a random suffix, so that instances
+// on the same host get distinct IDs
+func defaultNodeID() string {
+	suffix := uuid.NewString()[:nodeSuffixLength]
+
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		return suffix
+	}
+
+	return host + "-" + suffix
+}
+
+// MemoryTransport connects ClusterEventBus instances in one process. It is meant
+// for tests and for trying out a multi-instance setup locally.
+type MemoryTransport struct {
+	mu        sync.RWMutex
+	listeners map[int]func([]byte)
+	nextID    int
+}
+
+// NewMemoryTransport creates an in-process transport
+func NewMemoryTransport() *MemoryTransport {
+	return &MemoryTransport{listeners: make(map[int]func([]byte))}
+}
+
+// Send delivers data to every listener
+func (t *MemoryTransport) Send(_ context.Context, data []byte) error {
+	t.mu.RLock()
+	listeners := slices.Collect(maps.Values(t.listeners))
+	t.mu.RUnlock()
+
+	for _, deliver := range listeners {
+		deliver(data)
+	}
+
+	return nil
+}
+
+// Listen registers deliver until ctx ends
+func (t *MemoryTransport) Listen(ctx context.Context, deliver func([]byte)) error {
+	t.mu.Lock()
+	id := t.nextID
+	t.nextID++
+	t.listeners[id] = deliver
+	t.mu.Unlock()
+
+	<-ctx.Done()
+
+	t.mu.Lock()
+	delete(t.listeners, id)
+	t.mu.Unlock()
+
+	return nil
+}
@@ -0,0 +1,231 @@
+package event_test
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/goformx/goforms/internal/domain/common/events"
+	formevents "github.com/goformx/goforms/internal/domain/form/events"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/event"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+func newFormCodec() *event.Codec {
+	codec := event.NewCodec()
+
+	for eventType, payloadType := range formevents.PayloadTypes() {
+		codec.Register(string(eventType), payloadType)
+	}
+
+	return codec
+}
+
+func TestCodec(t *testing.T) {
+	t.Parallel()
+
+	codec := newFormCodec()
+	form := &model.Form{ID: "form-1", UserID: "user-1", Title: "Contact", Schema: model.JSON{"type": "object"}}
+	published := formevents.NewFormUpdatedEvent(form)
+	published.Metadata()["source"] = "test"
+
+	data, err := codec.Encode("node-a", published)
+	require.NoError(t, err)
+
+	var envelope event.Envelope
+	require.NoError(t, json.Unmarshal(data, &envelope))
+	assert.Equal(t, event.EnvelopeVersion, envelope.Version)
+	assert.NotEmpty(t, envelope.ID)
+
+	decoded, err := codec.Decode(data)
+	require.NoError(t, err)
+	assert.Equal(t, "node-a", decoded.Node())
+	assert.Equal(t, string(formevents.FormUpdatedEventType), decoded.Name())
+	assert.True(t, published.Timestamp().Equal(decoded.Timestamp()))
+	assert.Equal(t, "test", decoded.Metadata()["source"])
+	assert.Equal(t, "form:form-1", decoded.StreamKey())
+
+	payload, ok := decoded.Payload().(*model.Form)
+	require.True(t, ok, "payload is %T", decoded.Payload())
+	assert.Equal(t, form.ID, payload.ID)
+	assert.Equal(t, form.Title, payload.Title)
+
+	t.Run("unregistered event", func(t *testing.T) {
+		t.Parallel()
+
+		raw, encodeErr := codec.Encode("node-a", newTestEvent())
+		require.NoError(t, encodeErr)
+
+		decodedEvent, decodeErr := codec.Decode(raw)
+		require.NoError(t, decodeErr)
+		assert.IsType(t, json.RawMessage{}, decodedEvent.Payload())
+	})
+
+	t.Run("unsupported version", func(t *testing.T) {
+		t.Parallel()
+
+		_, decodeErr := codec.Decode([]byte(`{"version":2,"name":"form.updated"}`))
+		require.ErrorIs(t, decodeErr, event.ErrUnsupportedEnvelope)
+	})
+
+	t.Run("payload of the wrong type", func(t *testing.T) {
+		t.Parallel()
+
+		_, decodeErr := codec.Decode([]byte(`{"version":1,"name":"form.deleted","payload":{"id":1}}`))
+		require.Error(t, decodeErr)
+	})
+}
+
+func TestPayloadTypes_coverFormEvents(t *testing.T) {
+	t.Parallel()
+
+	types := formevents.PayloadTypes()
+
+	for eventType, payload := range map[formevents.EventType]any{
+		formevents.FormCreatedEventType:   formevents.NewFormCreatedEvent(&model.Form{}).Payload(),
+		formevents.FormDeletedEventType:   formevents.NewFormDeletedEvent("form-1").Payload(),
+		formevents.FormSubmittedEventType: formevents.NewFormSubmittedEvent(&model.FormSubmission{}).Payload(),
+		formevents.FormValidatedEventType: formevents.NewFormValidatedEvent("form-1", true).Payload(),
+		formevents.FormProcessedEventType: formevents.NewFormProcessedEvent("form-1", "p-1").Payload(),
+		formevents.FormUpdatedEventType:   formevents.NewFormUpdatedEvent(&model.Form{}).Payload(),
+		formevents.FormErrorEventType:     formevents.NewFormErrorEvent("form-1", assert.AnError).Payload(),
+		formevents.FormStateEventType:     formevents.NewFormStateEvent("form-1", "published").Payload(),
+		formevents.FieldEventType:         formevents.NewFieldEvent("form-1", "email").Payload(),
+		formevents.AnalyticsEventType:     formevents.NewAnalyticsEvent("form-1", "view").Payload(),
+		formevents.SubmissionUpdatedEventType: formevents.NewSubmissionUpdatedEvent(
+			&model.FormSubmission{}, "user-1", 2, nil,
+		).Payload(),
+	} {
+		assert.Equal(t, reflect.TypeOf(payload), types[eventType], eventType)
+	}
+
+	assert.Len(t, types, 11)
+}
+
+type clusterNode struct {
+	bus     *event.ClusterEventBus
+	local   chan events.Event
+	cluster chan events.Event
+}
+
+func newClusterNode(t *testing.T, transport event.Transport, node string) *clusterNode {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	logger := mocklogging.NewMockLogger(ctrl)
+	logger.EXPECT().Debug(gomock.Any(), gomock.Any()).AnyTimes()
+	logger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+	logger.EXPECT().Warn(gomock.Any(), gomock.Any()).AnyTimes()
+	logger.EXPECT().Error(gomock.Any(), gomock.Any()).AnyTimes()
+
+	cfg := asyncConfig()
+	cfg.NodeID = node
+	cfg.QueueSize = 10
+
+	n := &clusterNode{
+		bus:     event.NewClusterEventBus(logger, cfg, transport, newFormCodec()),
+		local:   make(chan events.Event, 10),
+		cluster: make(chan events.Event, 10),
+	}
+
+	collect := func(ch chan events.Event) func(context.Context, events.Event) error {
+		return func(_ context.Context, e events.Event) error {
+			ch <- e
+
+			return nil
+		}
+	}
+
+	eventName := string(formevents.FormUpdatedEventType)
+	require.NoError(t, n.bus.Subscribe(context.Background(), eventName, collect(n.local)))
+	require.NoError(t, n.bus.SubscribeCluster(context.Background(), eventName, collect(n.cluster)))
+	require.NoError(t, n.bus.Start(context.Background()))
+
+	t.Cleanup(func() {
+		stopCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		assert.NoError(t, n.bus.Stop(stopCtx))
+	})
+
+	return n
+}
+
+func receive(t *testing.T, ch chan events.Event) events.Event {
+	t.Helper()
+
+	select {
+	case e := <-ch:
+		return e
+	case <-time.After(5 * time.Second):
+		t.Fatal("event was not delivered")
+
+		return nil
+	}
+}
+
+func assertNothingReceived(t *testing.T, ch chan events.Event) {
+	t.Helper()
+
+	select {
+	case e := <-ch:
+		t.Fatalf("unexpected event %s", e.Name())
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestClusterEventBus(t *testing.T) {
+	t.Parallel()
+
+	transport := event.NewMemoryTransport()
+	nodeA := newClusterNode(t, transport, "node-a")
+	nodeB := newClusterNode(t, transport, "node-b")
+
+	// Wait until both listeners are registered
+	require.Eventually(t, func() bool {
+		assert.NoError(t, transport.Send(context.Background(), []byte(`{"version":0}`)))
+
+		return nodeA.bus.GetMetrics()["invalid"].(int64) > 0 && //nolint:forcetypeassert // set by GetMetrics
+			nodeB.bus.GetMetrics()["invalid"].(int64) > 0 //nolint:forcetypeassert // set by GetMetrics
+	}, 5*time.Second, 5*time.Millisecond)
+
+	form := &model.Form{ID: "form-1", Title: "Contact"}
+	require.NoError(t, nodeA.bus.Publish(context.Background(), formevents.NewFormUpdatedEvent(form)))
+
+	// The publishing node delivers the original event to both kinds of subscribers, once
+	assert.Same(t, form, receive(t, nodeA.local).Payload())
+	assert.Same(t, form, receive(t, nodeA.cluster).Payload())
+
+	// The other node only delivers it to its cluster subscribers
+	remote := receive(t, nodeB.cluster)
+	remoteForm, ok := remote.Payload().(*model.Form)
+	require.True(t, ok)
+	assert.Equal(t, form.ID, remoteForm.ID)
+
+	assertNothingReceived(t, nodeB.local)
+	assertNothingReceived(t, nodeA.cluster)
+	assert.Equal(t, int64(1), nodeB.bus.GetMetrics()["received"])
+	assert.Equal(t, int64(0), nodeA.bus.GetMetrics()["received"])
+}
+
+func TestNewClusterEventBus_defaultNodeID(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	logger := mocklogging.NewMockLogger(ctrl)
+	transport := event.NewMemoryTransport()
+
+	a := event.NewClusterEventBus(logger, config.EventsConfig{}, transport, event.NewCodec())
+	b := event.NewClusterEventBus(logger, config.EventsConfig{}, transport, event.NewCodec())
+
+	assert.NotEmpty(t, a.Node())
+	assert.NotEqual(t, a.Node(), b.Node())
+}
@@ -0,0 +1,181 @@
+package event
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/goformx/goforms/internal/domain/common/events"
+)
+
+// EnvelopeVersion is the version of the envelope format written by Codec.Encode
+const EnvelopeVersion = 1
+
+// ErrUnsupportedEnvelope is returned when decoding an envelope of an unknown version
+var ErrUnsupportedEnvelope = errors.New("unsupported event envelope version")
+
+// Envelope is the serialized form of an event sent between instances. Stream
+// carries the event's stream key, so that the receiving instances keep the
+// events of a stream in order too.
+type Envelope struct {
+	Version   int             `json:"version"`
+	ID        string          `json:"id"`
+	Node      string          `json:"node"`
+	Name      string          `json:"name"`
+	Timestamp time.Time       `json:"timestamp"`
+	Metadata  map[string]any  `json:"metadata,omitempty"`
+	Stream    string          `json:"stream,omitempty"`
+	Payload   json.RawMessage `json:"payload"`
+}
+
+// RemoteEvent is an event decoded from an envelope
+type RemoteEvent struct {
+	id        string
+	node      string
+	name      string
+	timestamp time.Time
+	metadata  map[string]any
+	stream    string
+	payload   any
+}
+
+// Ensure RemoteEvent implements events.Event and events.Streamed
+var (
+	_ events.Event    = (*RemoteEvent)(nil)
+	_ events.Streamed = (*RemoteEvent)(nil)
+)
+
+// Name returns the event name
+func (e *RemoteEvent) Name() string {
+	return e.name
+}
+
+// Timestamp returns when the event occurred on the publishing instance
+func (e *RemoteEvent) Timestamp() time.Time {
+	return e.timestamp
+}
+
+// Payload returns the decoded payload, or the raw JSON when the event name has
+// no registered payload type
+func (e *RemoteEvent) Payload() any {
+	return e.payload
+}
+
+// Metadata returns additional event metadata
+func (e *RemoteEvent) Metadata() map[string]any {
+	return e.metadata
+}
+
+// StreamKey returns the stream key the event was published with
+func (e *RemoteEvent) StreamKey() string {
+	return e.stream
+}
+
+// ID returns the envelope ID
+func (e *RemoteEvent) ID() string {
+	return e.id
+}
+
+// Node returns the ID of the instance that published the event
+func (e *RemoteEvent) Node() string {
+	return e.node
+}
+
+// Codec serializes events into envelopes and decodes them back, using the
+// payload types registered for each event name
+type Codec struct {
+	mu           sync.RWMutex
+	payloadTypes map[string]reflect.Type
+}
+
+// NewCodec creates a codec without registered payload types
+func NewCodec() *Codec {
+	return &Codec{payloadTypes: make(map[string]reflect.Type)}
+}
+
+// Register sets the type that the payloads of eventName are decoded into
+func (c *Codec) Register(eventName string, payloadType reflect.Type) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.payloadTypes[eventName] = payloadType
+}
+
+// Encode serializes an event published on node
+func (c *Codec) Encode(node string, event events.Event) ([]byte, error) {
+	payload, err := json.Marshal(event.Payload())
+	if err != nil {
+		return nil, fmt.Errorf("encode %s payload: %w", event.Name(), err)
+	}
+
+	data, err := json.Marshal(&Envelope{
+		Version:   EnvelopeVersion,
+		ID:        uuid.NewString(),
+		Node:      node,
+		Name:      event.Name(),
+		Timestamp: event.Timestamp(),
+		Metadata:  event.Metadata(),
+		Stream:    streamKey(event),
+		Payload:   payload,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("encode %s envelope: %w", event.Name(), err)
+	}
+
+	return data, nil
+}
+
+// Decode parses an envelope and decodes its event
+func (c *Codec) Decode(data []byte) (*RemoteEvent, error) {
+	var envelope Envelope
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return nil, fmt.Errorf("decode event envelope: %w", err)
+	}
+
+	if envelope.Version != EnvelopeVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedEnvelope, envelope.Version)
+	}
+
+	payload, err := c.decodePayload(envelope.Name, envelope.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := envelope.Metadata
+	if metadata == nil {
+		metadata = make(map[string]any)
+	}
+
+	return &RemoteEvent{
+		id:        envelope.ID,
+		node:      envelope.Node,
+		name:      envelope.Name,
+		timestamp: envelope.Timestamp,
+		metadata:  metadata,
+		stream:    envelope.Stream,
+		payload:   payload,
+	}, nil
+}
+
+// decodePayload decodes a payload into its registered type
+func (c *Codec) decodePayload(eventName string, raw json.RawMessage) (any, error) {
+	c.mu.RLock()
+	payloadType, ok := c.payloadTypes[eventName]
+	c.mu.RUnlock()
+
+	if !ok {
+		return raw, nil
+	}
+
+	payload := reflect.New(payloadType)
+	if err := json.Unmarshal(raw, payload.Interface()); err != nil {
+		return nil, fmt.Errorf("decode %s payload: %w", eventName, err)
+	}
+
+	return payload.Elem().Interface(), nil
+}
@@ -1,102 +1,713 @@
-// Package event provides in-memory event bus and publisher implementations.
-// It implements the domain event interfaces for local event handling.
+// Package event provides event bus and publisher implementations. It implements
+// the domain event interfaces for local event handling and for fanning events
+// out across application instances.
 package event
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"hash/maphash"
+	"maps"
+	"runtime/debug"
+	"slices"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/goformx/goforms/internal/domain/common/events"
+	"github.com/goformx/goforms/internal/infrastructure/config"
 	"github.com/goformx/goforms/internal/infrastructure/logging"
 )
 
-// MemoryEventBus implements events.EventBus using in-memory storage
+const (
+	// QueueWarningThreshold is the queue fill ratio above which the metrics monitor warns
+	QueueWarningThreshold = 0.8
+	// backoffMultiplier grows the delay between handler retries
+	backoffMultiplier = 2
+)
+
+var (
+	// ErrBusStopped is returned when publishing to or subscribing on a stopped bus
+	ErrBusStopped = errors.New("event bus is stopped")
+	// ErrQueueFull is returned when an event is dropped because a subscription's queue is full
+	ErrQueueFull = errors.New("event queue is full")
+	// ErrHandlerPanic is returned when an event handler panics
+	ErrHandlerPanic = errors.New("event handler panicked")
+
+	// errSubscriptionClosed is returned when enqueueing to an unsubscribed handler
+	errSubscriptionClosed = errors.New("subscription is closed")
+
+	// streamSeed hashes stream keys to workers
+	streamSeed = maphash.MakeSeed()
+)
+
+// SubscriptionStats is a snapshot of one subscription's queue and delivery counters
+type SubscriptionStats struct {
+	Event         string
+	QueueDepth    int
+	QueueCapacity int
+	Delivered     int64
+	Failed        int64
+	Dropped       int64
+	Panics        int64
+}
+
+// delivery is a queued event with the context it was published with
+type delivery struct {
+	ctx   context.Context
+	event events.Event
+}
+
+// subscription is one handler subscribed to an event. In async mode each of
+// its workers consumes a bounded queue of its own.
+type subscription struct {
+	eventName string
+	handler   func(context.Context, events.Event) error
+	config    events.HandlerConfig
+	queues    []chan delivery
+	// next spreads the events that belong to no stream over the queues
+	next atomic.Uint64
+
+	// closing is closed first so that publishers blocked on a full queue give up
+	// before mu is taken to close the queue
+	closing   chan struct{}
+	closeOnce sync.Once
+	mu        sync.RWMutex
+	closed    bool
+
+	delivered atomic.Int64
+	failed    atomic.Int64
+	dropped   atomic.Int64
+	panics    atomic.Int64
+}
+
+// MemoryEventBus implements events.EventBus using in-memory storage.
+//
+// In async mode every subscription gets its own pool of workers, each with a
+// bounded queue, so Publish only enqueues the event and a slow handler holds up
+// neither the publisher nor other subscribers. The events of a stream (see
+// events.Streamed) always go to the same worker and are handled in the order
+// they were published. In sync mode handlers run on the publishing goroutine.
+// In both modes each handler call gets a timeout, failed calls are retried with
+// exponential backoff and panics are recovered.
 type MemoryEventBus struct {
-	logger     logging.Logger
-	handlers   map[string][]func(context.Context, events.Event) error
-	handlersMu sync.RWMutex
+	logger        logging.Logger
+	config        config.EventsConfig
+	handlerConfig events.HandlerConfig
+
+	mu            sync.RWMutex
+	subscriptions map[string][]*subscription
+	stopped       bool
+	stopMonitor   context.CancelFunc
+
+	workers sync.WaitGroup
+	// aborted is canceled when Stop gives up draining the queues; it cancels
+	// running handlers and skips the events still queued
+	aborted context.Context
+	abort   context.CancelFunc
 }
 
-// NewMemoryEventBus creates a new memory-based event bus
-func NewMemoryEventBus(logger logging.Logger) events.EventBus {
+// Ensure MemoryEventBus implements the bus interfaces
+var (
+	_ events.EventBus          = (*MemoryEventBus)(nil)
+	_ events.ClusterSubscriber = (*MemoryEventBus)(nil)
+)
+
+// NewMemoryEventBus creates a new memory-based event bus. Unset handler settings
+// fall back to the events package defaults.
+func NewMemoryEventBus(logger logging.Logger, cfg config.EventsConfig) *MemoryEventBus {
+	handlerConfig := *events.NewHandlerConfig()
+	handlerConfig.Logger = logger
+
+	if cfg.HandlerTimeout > 0 {
+		handlerConfig.Timeout = cfg.HandlerTimeout
+	}
+
+	if cfg.RetryCount > 0 {
+		handlerConfig.RetryCount = cfg.RetryCount
+	}
+
+	if cfg.RetryDelay > 0 {
+		handlerConfig.RetryDelay = cfg.RetryDelay
+	}
+
+	if cfg.MaxBackoff > 0 {
+		handlerConfig.MaxBackoff = cfg.MaxBackoff
+	}
+
+	if cfg.Overflow == "" {
+		cfg.Overflow = config.EventOverflowBlock
+	}
+
+	cfg.Workers = max(cfg.Workers, 1)
+	cfg.QueueSize = max(cfg.QueueSize, 1)
+
+	aborted, abort := context.WithCancel(context.Background())
+
 	return &MemoryEventBus{
-		logger:   logger,
-		handlers: make(map[string][]func(context.Context, events.Event) error),
+		logger:        logger,
+		config:        cfg,
+		handlerConfig: handlerConfig,
+		subscriptions: make(map[string][]*subscription),
+		aborted:       aborted,
+		abort:         abort,
 	}
 }
 
-// Publish publishes an event to all subscribers
+// Publish publishes an event to all subscribers. In async mode it returns once
+// the event is queued; a full queue is handled according to the overflow policy.
 func (b *MemoryEventBus) Publish(ctx context.Context, event events.Event) error {
-	b.handlersMu.RLock()
-	handlers := b.handlers[event.Name()]
-	b.handlersMu.RUnlock()
+	b.mu.RLock()
+	stopped := b.stopped
+	subs := slices.Clone(b.subscriptions[event.Name()])
+	b.mu.RUnlock()
 
-	for _, handler := range handlers {
-		if err := handler(ctx, event); err != nil {
-			b.logger.Error("failed to handle event",
-				"event", event.Name(),
-				"error", err,
-			)
+	if stopped {
+		return ErrBusStopped
+	}
+
+	correlate(ctx, event)
+
+	if !b.config.Async {
+		for _, sub := range subs {
+			b.handle(ctx, sub, event)
+		}
+
+		return nil
+	}
+
+	// Handlers outlive the request that published the event, but keep its values
+	d := delivery{ctx: context.WithoutCancel(ctx), event: event}
+
+	var errs []error
+
+	for _, sub := range subs {
+		err := b.enqueue(ctx, sub, d)
+		if err != nil && !errors.Is(err, errSubscriptionClosed) {
+			errs = append(errs, err)
 		}
 	}
 
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("failed to publish event %s: %w", event.Name(), err)
+	}
+
 	return nil
 }
 
-// PublishBatch publishes multiple events
+// PublishBatch publishes multiple events, continuing past events that fail
 func (b *MemoryEventBus) PublishBatch(ctx context.Context, eventList []events.Event) error {
-	b.handlersMu.Lock()
-	defer b.handlersMu.Unlock()
+	var errs []error
 
 	for _, event := range eventList {
 		if err := b.Publish(ctx, event); err != nil {
-			return fmt.Errorf("failed to publish event: %w", err)
+			errs = append(errs, err)
 		}
 	}
 
-	return nil
+	return errors.Join(errs...)
 }
 
-// Subscribe subscribes to an event
+// Subscribe subscribes to an event using the bus's handler settings
 func (b *MemoryEventBus) Subscribe(
+	ctx context.Context,
+	eventName string,
+	handler func(context.Context, events.Event) error,
+) error {
+	return b.SubscribeWithConfig(ctx, eventName, handler, b.handlerConfig)
+}
+
+// SubscribeWithConfig subscribes to an event with its own timeout and retry
+// settings. Unset settings fall back to the bus's.
+func (b *MemoryEventBus) SubscribeWithConfig(
 	_ context.Context,
 	eventName string,
 	handler func(context.Context, events.Event) error,
+	handlerConfig events.HandlerConfig,
 ) error {
-	b.handlersMu.Lock()
-	defer b.handlersMu.Unlock()
+	sub := &subscription{
+		eventName: eventName,
+		handler:   handler,
+		config:    b.mergeHandlerConfig(handlerConfig),
+		closing:   make(chan struct{}),
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.stopped {
+		return ErrBusStopped
+	}
+
+	if b.config.Async {
+		// The queue size is shared by the workers' queues
+		size := max((b.config.QueueSize+b.config.Workers-1)/b.config.Workers, 1)
+		sub.queues = make([]chan delivery, b.config.Workers)
+
+		b.workers.Add(b.config.Workers)
+
+		for i := range sub.queues {
+			sub.queues[i] = make(chan delivery, size)
 
-	if _, exists := b.handlers[eventName]; !exists {
-		b.handlers[eventName] = make([]func(context.Context, events.Event) error, 0)
+			go b.work(sub, sub.queues[i])
+		}
 	}
 
-	b.handlers[eventName] = append(b.handlers[eventName], handler)
+	b.subscriptions[eventName] = append(b.subscriptions[eventName], sub)
 
 	return nil
 }
 
-// Unsubscribe unsubscribes from an event
+// SubscribeCluster subscribes to an event. A memory bus serves a single
+// instance, so this is the same as Subscribe.
+func (b *MemoryEventBus) SubscribeCluster(
+	ctx context.Context,
+	eventName string,
+	handler func(context.Context, events.Event) error,
+) error {
+	return b.Subscribe(ctx, eventName, handler)
+}
+
+// Unsubscribe unsubscribes all handlers from an event. Events already queued
+// for them are still handled.
 func (b *MemoryEventBus) Unsubscribe(_ context.Context, eventName string) error {
-	b.handlersMu.Lock()
-	defer b.handlersMu.Unlock()
+	b.mu.Lock()
+	subs := b.subscriptions[eventName]
+	delete(b.subscriptions, eventName)
+	b.mu.Unlock()
 
-	delete(b.handlers, eventName)
+	for _, sub := range subs {
+		sub.close()
+	}
 
 	return nil
 }
 
-// Start starts the event bus
-func (b *MemoryEventBus) Start(_ context.Context) error {
+// Start starts the event bus and, in async mode, the queue metrics monitor
+func (b *MemoryEventBus) Start(ctx context.Context) error {
+	if !b.config.Async || b.config.MetricsInterval <= 0 {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.stopped || b.stopMonitor != nil {
+		return nil
+	}
+
+	monitorCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	b.stopMonitor = cancel
+
+	go b.monitor(monitorCtx)
+
 	return nil
 }
 
-// Stop stops the event bus
-func (b *MemoryEventBus) Stop(_ context.Context) error {
-	return nil
+// Stop stops accepting events and waits for the queued ones to be handled. If ctx
+// ends first, running handlers are canceled and the remaining events are dropped.
+func (b *MemoryEventBus) Stop(ctx context.Context) error {
+	b.mu.Lock()
+
+	if b.stopped {
+		b.mu.Unlock()
+
+		return nil
+	}
+
+	b.stopped = true
+	subs := b.allSubscriptions()
+
+	if b.stopMonitor != nil {
+		b.stopMonitor()
+	}
+
+	b.mu.Unlock()
+
+	for _, sub := range subs {
+		sub.close()
+	}
+
+	drained := make(chan struct{})
+
+	go func() {
+		b.workers.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		b.abort()
+		b.logger.Info("event bus stopped", "metrics", b.GetMetrics())
+
+		return nil
+	case <-ctx.Done():
+		b.abort()
+		b.logger.Warn("event bus stopped before its queues were drained", "metrics", b.GetMetrics())
+
+		return fmt.Errorf("failed to drain event queues: %w", ctx.Err())
+	}
 }
 
 // Health returns the health status of the event bus
 func (b *MemoryEventBus) Health(_ context.Context) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if b.stopped {
+		return ErrBusStopped
+	}
+
 	return nil
 }
+
+// Stats returns a snapshot of every subscription, ordered by event name
+func (b *MemoryEventBus) Stats() []SubscriptionStats {
+	b.mu.RLock()
+	subs := b.allSubscriptions()
+	b.mu.RUnlock()
+
+	stats := make([]SubscriptionStats, 0, len(subs))
+
+	for _, sub := range subs {
+		depth, capacity := sub.size()
+
+		stats = append(stats, SubscriptionStats{
+			Event:         sub.eventName,
+			QueueDepth:    depth,
+			QueueCapacity: capacity,
+			Delivered:     sub.delivered.Load(),
+			Failed:        sub.failed.Load(),
+			Dropped:       sub.dropped.Load(),
+			Panics:        sub.panics.Load(),
+		})
+	}
+
+	return stats
+}
+
+// GetMetrics returns the bus configuration and the counters summed over all subscriptions
+func (b *MemoryEventBus) GetMetrics() map[string]any {
+	stats := b.Stats()
+
+	var depth, capacity int
+
+	var delivered, failed, dropped, panics int64
+
+	for i := range stats {
+		depth += stats[i].QueueDepth
+		capacity += stats[i].QueueCapacity
+		delivered += stats[i].Delivered
+		failed += stats[i].Failed
+		dropped += stats[i].Dropped
+		panics += stats[i].Panics
+	}
+
+	return map[string]any{
+		"async":          b.config.Async,
+		"workers":        b.config.Workers,
+		"overflow":       b.config.Overflow,
+		"subscriptions":  len(stats),
+		"queue_depth":    depth,
+		"queue_capacity": capacity,
+		"delivered":      delivered,
+		"failed":         failed,
+		"dropped":        dropped,
+		"panics":         panics,
+	}
+}
+
+// allSubscriptions returns the subscriptions ordered by event name. The caller holds b.mu.
+func (b *MemoryEventBus) allSubscriptions() []*subscription {
+	var subs []*subscription
+
+	for _, name := range slices.Sorted(maps.Keys(b.subscriptions)) {
+		subs = append(subs, b.subscriptions[name]...)
+	}
+
+	return subs
+}
+
+// mergeHandlerConfig fills unset handler settings from the bus's
+func (b *MemoryEventBus) mergeHandlerConfig(cfg events.HandlerConfig) events.HandlerConfig {
+	if cfg.Logger == nil {
+		cfg.Logger = b.handlerConfig.Logger
+	}
+
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = b.handlerConfig.Timeout
+	}
+
+	if cfg.RetryCount <= 0 {
+		cfg.RetryCount = b.handlerConfig.RetryCount
+	}
+
+	if cfg.RetryDelay <= 0 {
+		cfg.RetryDelay = b.handlerConfig.RetryDelay
+	}
+
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = b.handlerConfig.MaxBackoff
+	}
+
+	return cfg
+}
+
+// enqueue queues an event for a subscription, applying the overflow policy when
+// the queue is full
+func (b *MemoryEventBus) enqueue(ctx context.Context, sub *subscription, d delivery) error {
+	sub.mu.RLock()
+	defer sub.mu.RUnlock()
+
+	if sub.closed {
+		return errSubscriptionClosed
+	}
+
+	queue := sub.queueFor(d.event)
+
+	select {
+	case queue <- d:
+		return nil
+	default:
+	}
+
+	switch b.config.Overflow {
+	case config.EventOverflowDropNewest:
+		sub.dropped.Add(1)
+
+		return ErrQueueFull
+	case config.EventOverflowDropOldest:
+		for {
+			select {
+			case queue <- d:
+				return nil
+			case oldest := <-queue:
+				sub.dropped.Add(1)
+				b.logger.Warn("event queue is full, dropped the oldest event",
+					"event", oldest.event.Name(),
+					"capacity", cap(queue),
+				)
+			}
+		}
+	default:
+		select {
+		case queue <- d:
+			return nil
+		case <-sub.closing:
+			return ErrBusStopped
+		case <-ctx.Done():
+			return fmt.Errorf("waiting for room in the event queue: %w", ctx.Err())
+		}
+	}
+}
+
+// work handles the events of one of a subscription's queues until the queue is
+// closed and empty
+func (b *MemoryEventBus) work(sub *subscription, queue <-chan delivery) {
+	defer b.workers.Done()
+
+	for d := range queue {
+		if b.aborted.Err() != nil {
+			sub.dropped.Add(1)
+
+			continue
+		}
+
+		b.handle(d.ctx, sub, d.event)
+	}
+}
+
+// handle runs a subscription's handler for an event, retrying failed calls, and
+// records the outcome. The handler's context carries the correlation fields of
+// the event's metadata.
+func (b *MemoryEventBus) handle(ctx context.Context, sub *subscription, event events.Event) {
+	ctx = logging.ContextWithCorrelation(ctx, event.Metadata())
+
+	if err := b.handleWithRetry(ctx, sub, event); err != nil {
+		sub.failed.Add(1)
+		logging.WithContext(ctx, b.logger).Error("failed to handle event",
+			"event", event.Name(),
+			"error", err,
+		)
+
+		return
+	}
+
+	sub.delivered.Add(1)
+}
+
+// handleWithRetry calls the handler up to RetryCount times, doubling the delay
+// between attempts up to MaxBackoff. Panics are not retried.
+func (b *MemoryEventBus) handleWithRetry(ctx context.Context, sub *subscription, event events.Event) error {
+	delay := sub.config.RetryDelay
+
+	for attempt := 1; ; attempt++ {
+		err := b.call(ctx, sub, event)
+		if err == nil {
+			return nil
+		}
+
+		if attempt >= sub.config.RetryCount || errors.Is(err, ErrHandlerPanic) {
+			return fmt.Errorf("handler failed after %d attempts: %w", attempt, err)
+		}
+
+		logging.WithContext(ctx, b.logger).Warn("event handler failed, retrying",
+			"event", event.Name(),
+			"attempt", attempt,
+			"delay", delay,
+			"error", err,
+		)
+
+		if waitErr := b.wait(ctx, delay); waitErr != nil {
+			return fmt.Errorf("handler retry canceled after %d attempts: %w", attempt, errors.Join(err, waitErr))
+		}
+
+		delay = min(delay*backoffMultiplier, sub.config.MaxBackoff)
+	}
+}
+
+// call runs the handler once with the subscription's timeout and recovers a panic
+func (b *MemoryEventBus) call(ctx context.Context, sub *subscription, event events.Event) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, sub.config.Timeout)
+	defer cancel()
+
+	stop := context.AfterFunc(b.aborted, cancel)
+	defer stop()
+
+	defer func() {
+		if r := recover(); r != nil {
+			sub.panics.Add(1)
+			logging.WithContext(ctx, b.logger).Error("event handler panicked",
+				"event", event.Name(),
+				"panic", r,
+				"stack", string(debug.Stack()),
+			)
+
+			err = fmt.Errorf("%w: %v", ErrHandlerPanic, r)
+		}
+	}()
+
+	return sub.handler(ctx, event)
+}
+
+// wait sleeps for the retry delay unless ctx ends or the bus gives up draining
+func (b *MemoryEventBus) wait(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("retry wait: %w", ctx.Err())
+	case <-b.aborted.Done():
+		return ErrBusStopped
+	}
+}
+
+// monitor periodically logs the queue metrics and warns about nearly full queues
+func (b *MemoryEventBus) monitor(ctx context.Context) {
+	ticker := time.NewTicker(b.config.MetricsInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			b.logMetrics()
+		}
+	}
+}
+
+// logMetrics logs the bus metrics and warns about queues above QueueWarningThreshold
+func (b *MemoryEventBus) logMetrics() {
+	b.logger.Debug("event bus status", "metrics", b.GetMetrics())
+
+	for _, stat := range b.Stats() {
+		if stat.QueueCapacity == 0 {
+			continue
+		}
+
+		if float64(stat.QueueDepth)/float64(stat.QueueCapacity) > QueueWarningThreshold {
+			b.logger.Warn("event queue is nearly full",
+				"event", stat.Event,
+				"depth", stat.QueueDepth,
+				"capacity", stat.QueueCapacity,
+				"dropped", stat.Dropped,
+			)
+		}
+	}
+}
+
+// correlate copies the correlation fields of the publishing context into the
+// event's metadata, so that handlers running later or on another instance can
+// log them. Fields already in the metadata are kept.
+func correlate(ctx context.Context, event events.Event) {
+	metadata := event.Metadata()
+	if metadata == nil {
+		return
+	}
+
+	for name, value := range logging.CorrelationFields(ctx) {
+		if _, ok := metadata[name]; !ok {
+			metadata[name] = value
+		}
+	}
+}
+
+// queueFor returns the queue of the worker that handles event. The events of a
+// stream always go to the same worker; the others are spread over the workers.
+func (s *subscription) queueFor(event events.Event) chan delivery {
+	if len(s.queues) == 1 {
+		return s.queues[0]
+	}
+
+	var n uint64
+
+	if key := streamKey(event); key != "" {
+		n = maphash.String(streamSeed, key)
+	} else {
+		n = s.next.Add(1)
+	}
+
+	return s.queues[n%uint64(len(s.queues))]
+}
+
+// size returns the number of queued events and the capacity of the queues
+func (s *subscription) size() (depth, capacity int) {
+	for _, queue := range s.queues {
+		depth += len(queue)
+		capacity += cap(queue)
+	}
+
+	return depth, capacity
+}
+
+// streamKey returns the key of the stream an event belongs to, or an empty
+// string if it belongs to none
+func streamKey(event events.Event) string {
+	if streamed, ok := event.(events.Streamed); ok {
+		return streamed.StreamKey()
+	}
+
+	return ""
+}
+
+// close stops the subscription from accepting events and closes its queue once
+// no publisher is sending to it; the workers then drain what is left
+func (s *subscription) close() {
+	s.closeOnce.Do(func() {
+		close(s.closing)
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		s.closed = true
+
+		for _, queue := range s.queues {
+			close(queue)
+		}
+	})
+}
@@ -0,0 +1,496 @@
+package event_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/goformx/goforms/internal/domain/common/events"
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/event"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+const testEventName = "test.happened"
+
+var errHandler = errors.New("handler failed")
+
+type testEvent struct {
+	events.BaseEvent
+}
+
+func (testEvent) Payload() any {
+	return nil
+}
+
+func newTestEvent() events.Event {
+	return testEvent{BaseEvent: events.NewBaseEvent(testEventName)}
+}
+
+// streamedEvent is an event of a stream, numbered in publishing order
+type streamedEvent struct {
+	events.BaseEvent
+	stream string
+	seq    int
+}
+
+func (e streamedEvent) Payload() any {
+	return e.seq
+}
+
+func (e streamedEvent) StreamKey() string {
+	return e.stream
+}
+
+type ctxKey struct{}
+
+func newTestBus(t *testing.T, cfg config.EventsConfig) *event.MemoryEventBus {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	logger := mocklogging.NewMockLogger(ctrl)
+	logger.EXPECT().Debug(gomock.Any(), gomock.Any()).AnyTimes()
+	logger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+	logger.EXPECT().Warn(gomock.Any(), gomock.Any()).AnyTimes()
+	logger.EXPECT().Error(gomock.Any(), gomock.Any()).AnyTimes()
+
+	return event.NewMemoryEventBus(logger, cfg)
+}
+
+func asyncConfig() config.EventsConfig {
+	return config.EventsConfig{
+		Async:          true,
+		Workers:        1,
+		QueueSize:      1,
+		Overflow:       config.EventOverflowBlock,
+		HandlerTimeout: time.Second,
+		RetryCount:     1,
+		RetryDelay:     time.Millisecond,
+		MaxBackoff:     time.Millisecond,
+	}
+}
+
+func stopBus(t *testing.T, bus *event.MemoryEventBus) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	require.NoError(t, bus.Stop(ctx))
+}
+
+func TestMemoryEventBus_PublishAsync(t *testing.T) {
+	t.Parallel()
+
+	bus := newTestBus(t, asyncConfig())
+	release := make(chan struct{})
+	handled := make(chan any, 1)
+
+	require.NoError(t, bus.Subscribe(context.Background(), testEventName, func(ctx context.Context, _ events.Event) error {
+		<-release
+		handled <- ctx.Value(ctxKey{})
+
+		return nil
+	}))
+
+	// The publishing request ends before the handler runs
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), ctxKey{}, "actor"))
+	require.NoError(t, bus.Publish(ctx, newTestEvent()))
+	cancel()
+	close(release)
+
+	select {
+	case value := <-handled:
+		assert.Equal(t, "actor", value)
+	case <-time.After(5 * time.Second):
+		t.Fatal("handler was not called")
+	}
+
+	stopBus(t, bus)
+	assert.Equal(t, int64(1), bus.Stats()[0].Delivered)
+}
+
+func TestMemoryEventBus_PublishAsync_streamOrder(t *testing.T) {
+	t.Parallel()
+
+	cfg := asyncConfig()
+	cfg.Workers = 4
+	cfg.QueueSize = 256
+	bus := newTestBus(t, cfg)
+
+	var (
+		mu      sync.Mutex
+		handled = make(map[string][]int)
+	)
+
+	require.NoError(t, bus.Subscribe(context.Background(), testEventName, func(_ context.Context, e events.Event) error {
+		streamed, ok := e.(streamedEvent)
+		require.True(t, ok)
+
+		// Uneven handling times would reorder events spread over the workers
+		time.Sleep(time.Duration(streamed.seq%3) * time.Millisecond)
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		handled[streamed.stream] = append(handled[streamed.stream], streamed.seq)
+
+		return nil
+	}))
+
+	const perStream = 20
+
+	streams := []string{"form:a", "form:b", "form:c", "submission:d", "submission:e", "submission:f"}
+
+	for seq := range perStream {
+		for _, stream := range streams {
+			published := streamedEvent{BaseEvent: events.NewBaseEvent(testEventName), stream: stream, seq: seq}
+			require.NoError(t, bus.Publish(context.Background(), published))
+		}
+	}
+
+	stopBus(t, bus)
+
+	for _, stream := range streams {
+		want := make([]int, perStream)
+		for i := range want {
+			want[i] = i
+		}
+
+		assert.Equal(t, want, handled[stream], fmt.Sprintf("events of %s", stream))
+	}
+}
+
+func TestMemoryEventBus_PublishSync(t *testing.T) {
+	t.Parallel()
+
+	bus := newTestBus(t, config.EventsConfig{RetryCount: 1})
+
+	var calls atomic.Int32
+
+	require.NoError(t, bus.Subscribe(context.Background(), testEventName, func(ctx context.Context, _ events.Event) error {
+		_, hasDeadline := ctx.Deadline()
+		assert.True(t, hasDeadline)
+		calls.Add(1)
+
+		return nil
+	}))
+	require.NoError(t, bus.Publish(context.Background(), newTestEvent()))
+
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestMemoryEventBus_PublishBatch(t *testing.T) {
+	t.Parallel()
+
+	bus := newTestBus(t, config.EventsConfig{RetryCount: 1})
+
+	var calls atomic.Int32
+
+	require.NoError(t, bus.Subscribe(context.Background(), testEventName, func(context.Context, events.Event) error {
+		calls.Add(1)
+
+		return nil
+	}))
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- bus.PublishBatch(context.Background(), []events.Event{newTestEvent(), newTestEvent()})
+	}()
+
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("PublishBatch deadlocked")
+	}
+
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestMemoryEventBus_Overflow(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		overflow  string
+		wantErr   error
+		wantFirst bool
+	}{
+		{name: "drop newest", overflow: config.EventOverflowDropNewest, wantErr: event.ErrQueueFull, wantFirst: true},
+		{name: "drop oldest", overflow: config.EventOverflowDropOldest, wantFirst: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := asyncConfig()
+			cfg.Overflow = tt.overflow
+			bus := newTestBus(t, cfg)
+
+			started := make(chan struct{})
+			release := make(chan struct{})
+
+			var handled []string
+
+			require.NoError(t, bus.Subscribe(context.Background(), testEventName, func(_ context.Context, e events.Event) error {
+				if e.Metadata()["seq"] == "blocker" {
+					close(started)
+					<-release
+				}
+
+				handled = append(handled, e.Metadata()["seq"].(string)) //nolint:forcetypeassert // set below
+
+				return nil
+			}))
+
+			publish := func(seq string) error {
+				e := newTestEvent()
+				e.Metadata()["seq"] = seq
+
+				return bus.Publish(context.Background(), e)
+			}
+
+			// The worker holds the first event, the second fills the queue
+			require.NoError(t, publish("blocker"))
+			<-started
+			require.NoError(t, publish("first"))
+
+			err := publish("second")
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			close(release)
+			stopBus(t, bus)
+
+			assert.Equal(t, int64(1), bus.Stats()[0].Dropped)
+
+			if tt.wantFirst {
+				assert.Equal(t, []string{"blocker", "first"}, handled)
+			} else {
+				assert.Equal(t, []string{"blocker", "second"}, handled)
+			}
+		})
+	}
+}
+
+func TestMemoryEventBus_OverflowBlock(t *testing.T) {
+	t.Parallel()
+
+	bus := newTestBus(t, asyncConfig())
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	var once atomic.Bool
+
+	require.NoError(t, bus.Subscribe(context.Background(), testEventName, func(context.Context, events.Event) error {
+		if once.CompareAndSwap(false, true) {
+			close(started)
+			<-release
+		}
+
+		return nil
+	}))
+
+	require.NoError(t, bus.Publish(context.Background(), newTestEvent()))
+	<-started
+	require.NoError(t, bus.Publish(context.Background(), newTestEvent()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	require.ErrorIs(t, bus.Publish(ctx, newTestEvent()), context.DeadlineExceeded)
+
+	close(release)
+	stopBus(t, bus)
+
+	assert.Equal(t, int64(2), bus.Stats()[0].Delivered)
+}
+
+func TestMemoryEventBus_Retry(t *testing.T) {
+	t.Parallel()
+
+	cfg := asyncConfig()
+	cfg.RetryCount = 3
+	bus := newTestBus(t, cfg)
+
+	var calls atomic.Int32
+
+	require.NoError(t, bus.Subscribe(context.Background(), testEventName, func(context.Context, events.Event) error {
+		if calls.Add(1) < 3 {
+			return errHandler
+		}
+
+		return nil
+	}))
+	require.NoError(t, bus.Publish(context.Background(), newTestEvent()))
+	stopBus(t, bus)
+
+	stats := bus.Stats()[0]
+	assert.Equal(t, int32(3), calls.Load())
+	assert.Equal(t, int64(1), stats.Delivered)
+	assert.Equal(t, int64(0), stats.Failed)
+}
+
+func TestMemoryEventBus_Timeout(t *testing.T) {
+	t.Parallel()
+
+	bus := newTestBus(t, asyncConfig())
+
+	require.NoError(t, bus.SubscribeWithConfig(context.Background(), testEventName,
+		func(ctx context.Context, _ events.Event) error {
+			<-ctx.Done()
+
+			return ctx.Err()
+		},
+		events.HandlerConfig{Timeout: 10 * time.Millisecond, RetryCount: 1},
+	))
+	require.NoError(t, bus.Publish(context.Background(), newTestEvent()))
+	stopBus(t, bus)
+
+	assert.Equal(t, int64(1), bus.Stats()[0].Failed)
+}
+
+func TestMemoryEventBus_RecoversPanics(t *testing.T) {
+	t.Parallel()
+
+	cfg := asyncConfig()
+	cfg.RetryCount = 3
+	bus := newTestBus(t, cfg)
+
+	var calls atomic.Int32
+
+	require.NoError(t, bus.Subscribe(context.Background(), testEventName, func(context.Context, events.Event) error {
+		calls.Add(1)
+		panic("boom")
+	}))
+	require.NoError(t, bus.Publish(context.Background(), newTestEvent()))
+	require.NoError(t, bus.Publish(context.Background(), newTestEvent()))
+	stopBus(t, bus)
+
+	stats := bus.Stats()[0]
+	assert.Equal(t, int32(2), calls.Load(), "panics are not retried")
+	assert.Equal(t, int64(2), stats.Panics)
+	assert.Equal(t, int64(2), stats.Failed)
+}
+
+func TestMemoryEventBus_Stop(t *testing.T) {
+	t.Parallel()
+
+	t.Run("drains queued events", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := asyncConfig()
+		cfg.QueueSize = 10
+		bus := newTestBus(t, cfg)
+
+		var calls atomic.Int32
+
+		require.NoError(t, bus.Subscribe(context.Background(), testEventName, func(context.Context, events.Event) error {
+			time.Sleep(time.Millisecond)
+			calls.Add(1)
+
+			return nil
+		}))
+
+		for range 10 {
+			require.NoError(t, bus.Publish(context.Background(), newTestEvent()))
+		}
+
+		stopBus(t, bus)
+
+		assert.Equal(t, int32(10), calls.Load())
+		require.ErrorIs(t, bus.Publish(context.Background(), newTestEvent()), event.ErrBusStopped)
+		require.ErrorIs(t, bus.Health(context.Background()), event.ErrBusStopped)
+	})
+
+	t.Run("gives up when the context ends", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := asyncConfig()
+		cfg.QueueSize = 10
+		bus := newTestBus(t, cfg)
+
+		waitForCancel := func(ctx context.Context, _ events.Event) error {
+			<-ctx.Done()
+
+			return ctx.Err()
+		}
+
+		require.NoError(t, bus.Subscribe(context.Background(), testEventName, waitForCancel))
+
+		for range 3 {
+			require.NoError(t, bus.Publish(context.Background(), newTestEvent()))
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		require.ErrorIs(t, bus.Stop(ctx), context.DeadlineExceeded)
+
+		assert.Eventually(t, func() bool {
+			stats := bus.Stats()[0]
+
+			return stats.Failed == 1 && stats.Dropped == 2
+		}, 5*time.Second, 5*time.Millisecond)
+	})
+}
+
+func TestMemoryEventBus_GetMetrics(t *testing.T) {
+	t.Parallel()
+
+	bus := newTestBus(t, asyncConfig())
+
+	require.NoError(t, bus.Subscribe(context.Background(), testEventName, func(context.Context, events.Event) error {
+		return nil
+	}))
+	require.NoError(t, bus.Publish(context.Background(), newTestEvent()))
+	stopBus(t, bus)
+
+	metrics := bus.GetMetrics()
+	assert.Equal(t, 1, metrics["subscriptions"])
+	assert.Equal(t, 0, metrics["queue_depth"])
+	assert.Equal(t, 1, metrics["queue_capacity"])
+	assert.Equal(t, int64(1), metrics["delivered"])
+}
+
+func TestMemoryEventBus_Correlation(t *testing.T) {
+	t.Parallel()
+
+	bus := newTestBus(t, config.EventsConfig{RetryCount: 1})
+	handled := make(map[string]string)
+
+	require.NoError(t, bus.Subscribe(context.Background(), testEventName, func(ctx context.Context, _ events.Event) error {
+		handled = logging.CorrelationFields(ctx)
+
+		return nil
+	}))
+
+	ctx := logging.ContextWithFormID(logging.ContextWithRequestID(context.Background(), "req-1"), "form-1")
+	published := newTestEvent()
+	require.NoError(t, bus.Publish(ctx, published))
+
+	assert.Equal(t, "req-1", published.Metadata()[logging.FieldRequestID])
+	assert.Equal(t, "form-1", published.Metadata()[logging.FieldFormID])
+	assert.Equal(t, map[string]string{logging.FieldRequestID: "req-1", logging.FieldFormID: "form-1"}, handled)
+
+	// Events received from other instances only carry the fields in their metadata
+	remote := newTestEvent()
+	remote.Metadata()[logging.FieldRequestID] = "req-2"
+	require.NoError(t, bus.Publish(context.Background(), remote))
+	assert.Equal(t, map[string]string{logging.FieldRequestID: "req-2"}, handled)
+}
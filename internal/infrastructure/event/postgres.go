@@ -0,0 +1,185 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"gorm.io/gorm"
+
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+const (
+	// MaxNotifyPayload is the largest envelope sent inline. Postgres rejects
+	// NOTIFY payloads of 8000 bytes or more; larger envelopes are stored in the
+	// event_payloads table and the notification carries a reference to them.
+	MaxNotifyPayload = 7900
+	// PayloadRetention is how long stored envelopes are kept for listeners to fetch
+	PayloadRetention = 10 * time.Minute
+
+	payloadRefPrefix    = "ref:"
+	listenRetryDelay    = time.Second
+	maxListenRetryDelay = 30 * time.Second
+)
+
+// ErrPayloadNotFound is returned when a referenced envelope is no longer stored
+var ErrPayloadNotFound = errors.New("event payload not found")
+
+// PostgresTransport sends envelopes with Postgres NOTIFY and receives them on a
+// dedicated LISTEN connection, which is reopened when it drops. Envelopes sent
+// while the connection is down are not received.
+type PostgresTransport struct {
+	db      *gorm.DB
+	dsn     string
+	channel string
+	logger  logging.Logger
+}
+
+// NewPostgresTransport creates a transport on channel. Notifications are sent
+// through db; dsn opens the listening connection.
+func NewPostgresTransport(db *gorm.DB, dsn, channel string, logger logging.Logger) *PostgresTransport {
+	return &PostgresTransport{
+		db:      db,
+		dsn:     dsn,
+		channel: channel,
+		logger:  logger,
+	}
+}
+
+// Send notifies the channel, storing envelopes over MaxNotifyPayload first
+func (t *PostgresTransport) Send(ctx context.Context, data []byte) error {
+	message := string(data)
+
+	if len(data) > MaxNotifyPayload {
+		ref, err := t.storePayload(ctx, message)
+		if err != nil {
+			return err
+		}
+
+		message = payloadRefPrefix + ref
+	}
+
+	if err := t.db.WithContext(ctx).Exec("SELECT pg_notify(?, ?)", t.channel, message).Error; err != nil {
+		return fmt.Errorf("notify %s: %w", t.channel, err)
+	}
+
+	return nil
+}
+
+// Listen listens on the channel until ctx ends, reconnecting with backoff
+func (t *PostgresTransport) Listen(ctx context.Context, deliver func([]byte)) error {
+	delay := listenRetryDelay
+
+	for {
+		connected, err := t.listen(ctx, deliver)
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		if connected {
+			delay = listenRetryDelay
+		}
+
+		t.logger.Warn("event listener disconnected, reconnecting",
+			"channel", t.channel,
+			"delay", delay,
+			"error", err,
+		)
+
+		timer := time.NewTimer(delay)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return nil
+		case <-timer.C:
+		}
+
+		delay = min(delay*backoffMultiplier, maxListenRetryDelay)
+	}
+}
+
+// listen opens a connection, listens on the channel and delivers notifications
+// until the connection fails or ctx ends. It reports whether LISTEN succeeded.
+func (t *PostgresTransport) listen(ctx context.Context, deliver func([]byte)) (bool, error) {
+	conn, err := pgx.Connect(ctx, t.dsn)
+	if err != nil {
+		return false, fmt.Errorf("connect: %w", err)
+	}
+
+	defer func() {
+		if closeErr := conn.Close(context.WithoutCancel(ctx)); closeErr != nil {
+			t.logger.Debug("failed to close event listener connection", "error", closeErr)
+		}
+	}()
+
+	if _, err = conn.Exec(ctx, "LISTEN "+pgx.Identifier{t.channel}.Sanitize()); err != nil {
+		return false, fmt.Errorf("listen %s: %w", t.channel, err)
+	}
+
+	t.logger.Debug("event listener connected", "channel", t.channel)
+
+	for {
+		notification, waitErr := conn.WaitForNotification(ctx)
+		if waitErr != nil {
+			return true, fmt.Errorf("wait for notification: %w", waitErr)
+		}
+
+		data, resolveErr := t.resolve(ctx, notification.Payload)
+		if resolveErr != nil {
+			t.logger.Warn("failed to resolve event notification", "channel", t.channel, "error", resolveErr)
+
+			continue
+		}
+
+		deliver(data)
+	}
+}
+
+// storePayload stores an envelope for listeners to fetch and removes the
+// envelopes older than PayloadRetention
+func (t *PostgresTransport) storePayload(ctx context.Context, envelope string) (string, error) {
+	id := uuid.NewString()
+	db := t.db.WithContext(ctx)
+
+	if err := db.Exec("INSERT INTO event_payloads (id, envelope) VALUES (?, ?)", id, envelope).Error; err != nil {
+		return "", fmt.Errorf("store event payload: %w", err)
+	}
+
+	err := db.Exec("DELETE FROM event_payloads WHERE created_at < CURRENT_TIMESTAMP - make_interval(secs => ?)",
+		PayloadRetention.Seconds()).Error
+	if err != nil {
+		t.logger.Warn("failed to remove expired event payloads", "error", err)
+	}
+
+	return id, nil
+}
+
+// resolve returns the envelope of a notification, fetching stored envelopes
+func (t *PostgresTransport) resolve(ctx context.Context, message string) ([]byte, error) {
+	ref, stored := strings.CutPrefix(message, payloadRefPrefix)
+	if !stored {
+		return []byte(message), nil
+	}
+
+	var envelopes []string
+
+	err := t.db.WithContext(ctx).
+		Raw("SELECT envelope FROM event_payloads WHERE id = ?", ref).
+		Scan(&envelopes).Error
+	if err != nil {
+		return nil, fmt.Errorf("fetch event payload %s: %w", ref, err)
+	}
+
+	if len(envelopes) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrPayloadNotFound, ref)
+	}
+
+	return []byte(envelopes[0]), nil
+}
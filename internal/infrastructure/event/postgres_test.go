@@ -0,0 +1,85 @@
+package event_test
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/goformx/goforms/internal/infrastructure/event"
+	"github.com/goformx/goforms/migrations"
+	mocklogging "github.com/goformx/goforms/test/mocks/logging"
+)
+
+// postgresDSNEnv names the database the Postgres transport test runs against,
+// for example the development database from docker/development
+const postgresDSNEnv = "GOFORMS_TEST_POSTGRES_DSN"
+
+func TestPostgresTransport(t *testing.T) {
+	dsn := os.Getenv(postgresDSNEnv)
+	if dsn == "" {
+		t.Skipf("%s is not set", postgresDSNEnv)
+	}
+
+	t.Parallel()
+
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	require.NoError(t, err)
+
+	schema, err := migrations.FS.ReadFile("postgresql/2026101910_create_event_payloads.up.sql")
+	require.NoError(t, err)
+	require.NoError(t, db.Exec(string(schema)).Error)
+
+	ctrl := gomock.NewController(t)
+	logger := mocklogging.NewMockLogger(ctrl)
+	logger.EXPECT().Debug(gomock.Any(), gomock.Any()).AnyTimes()
+	logger.EXPECT().Warn(gomock.Any(), gomock.Any()).AnyTimes()
+
+	channel := "goforms_test_" + strings.ReplaceAll(uuid.NewString(), "-", "")
+	transport := event.NewPostgresTransport(db, dsn, channel, logger)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	received := make(chan []byte, 10)
+
+	go func() {
+		assert.NoError(t, transport.Listen(ctx, func(data []byte) { received <- data }))
+	}()
+
+	// Wait until the listener is connected
+	require.Eventually(t, func() bool {
+		assert.NoError(t, transport.Send(ctx, []byte("ping")))
+
+		select {
+		case <-received:
+			return true
+		case <-time.After(100 * time.Millisecond):
+			return false
+		}
+	}, 10*time.Second, 10*time.Millisecond)
+
+	for _, size := range []int{100, 2 * event.MaxNotifyPayload} {
+		message := strings.Repeat("x", size)
+		require.NoError(t, transport.Send(ctx, []byte(message)))
+
+		select {
+		case data := <-received:
+			for string(data) == "ping" {
+				data = <-received
+			}
+
+			assert.Equal(t, message, string(data))
+		case <-time.After(10 * time.Second):
+			t.Fatalf("message of %d bytes was not received", size)
+		}
+	}
+}
@@ -0,0 +1,263 @@
+// Package i18n provides the message catalogs and locale negotiation used to
+// render pages and validation messages in the user's language.
+//
+// Catalogs are flat JSON files embedded from locales/, keyed by dotted message
+// IDs. Messages may contain {name} placeholders, filled in by Localizer.T.
+package i18n
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	// English is the English locale
+	English = "en"
+	// French is the French locale
+	French = "fr"
+	// DefaultLocale is used when no supported locale was requested. Its
+	// catalog is also the fallback for messages missing from another one.
+	DefaultLocale = English
+)
+
+//go:embed locales/*.json
+var localeFiles embed.FS
+
+// Supported lists the locales that have a catalog, default first
+var Supported = []string{English, French}
+
+// Catalog holds the messages of every supported locale
+type Catalog struct {
+	messages map[string]map[string]string
+}
+
+// NewCatalog loads the embedded catalogs
+func NewCatalog() (*Catalog, error) {
+	c := &Catalog{messages: make(map[string]map[string]string, len(Supported))}
+
+	for _, locale := range Supported {
+		data, err := localeFiles.ReadFile(path.Join("locales", locale+".json"))
+		if err != nil {
+			return nil, fmt.Errorf("read %s catalog: %w", locale, err)
+		}
+
+		messages := make(map[string]string)
+		if unmarshalErr := json.Unmarshal(data, &messages); unmarshalErr != nil {
+			return nil, fmt.Errorf("parse %s catalog: %w", locale, unmarshalErr)
+		}
+
+		c.messages[locale] = messages
+	}
+
+	return c, nil
+}
+
+// defaultCatalog loads the embedded catalogs once. They are compiled in, so a
+// failure is a programming error.
+var defaultCatalog = sync.OnceValue(func() *Catalog {
+	c, err := NewCatalog()
+	if err != nil {
+		panic(err)
+	}
+
+	return c
+})
+
+// Default returns the catalog built from the embedded locale files
+func Default() *Catalog {
+	return defaultCatalog()
+}
+
+// Localizer returns a localizer for locale. Unsupported locales get the
+// default one.
+func (c *Catalog) Localizer(locale string) Localizer {
+	return Localizer{catalog: c, locale: Match(locale)}
+}
+
+// Keys returns the message IDs of a locale, sorted
+func (c *Catalog) Keys(locale string) []string {
+	keys := make([]string, 0, len(c.messages[locale]))
+	for k := range c.messages[locale] {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// Messages returns the messages whose ID starts with prefix, keyed by the rest
+// of the ID, with missing translations taken from the default locale. It is
+// used to hand a group of messages to client-side code.
+func (c *Catalog) Messages(locale, prefix string) map[string]string {
+	result := make(map[string]string)
+
+	for _, l := range []string{DefaultLocale, Match(locale)} {
+		for k, v := range c.messages[l] {
+			if rest, ok := strings.CutPrefix(k, prefix); ok {
+				result[rest] = v
+			}
+		}
+	}
+
+	return result
+}
+
+// lookup returns the message for key, falling back to the default locale
+func (c *Catalog) lookup(locale, key string) (string, bool) {
+	if msg, ok := c.messages[locale][key]; ok {
+		return msg, true
+	}
+
+	msg, ok := c.messages[DefaultLocale][key]
+
+	return msg, ok
+}
+
+// Localizer translates messages into one locale. The zero value uses the
+// default catalog and locale.
+type Localizer struct {
+	catalog *Catalog
+	locale  string
+}
+
+// For returns a localizer for locale using the default catalog
+func For(locale string) Localizer {
+	return Default().Localizer(locale)
+}
+
+// Locale returns the locale messages are translated into
+func (l Localizer) Locale() string {
+	if l.locale == "" {
+		return DefaultLocale
+	}
+
+	return l.locale
+}
+
+// T returns the message for key with its placeholders filled in from args,
+// which are name/value pairs:
+//
+//	l.T("validation.min_length", "min", 3)
+//
+// A key missing from every catalog is returned as is.
+func (l Localizer) T(key string, args ...any) string {
+	catalog := l.catalog
+	if catalog == nil {
+		catalog = Default()
+	}
+
+	msg, ok := catalog.lookup(l.Locale(), key)
+	if !ok {
+		return key
+	}
+
+	if len(args) < 2 {
+		return msg
+	}
+
+	pairs := make([]string, 0, len(args))
+	for i := 0; i+1 < len(args); i += 2 {
+		pairs = append(pairs, "{"+fmt.Sprint(args[i])+"}", format(args[i+1]))
+	}
+
+	return strings.NewReplacer(pairs...).Replace(msg)
+}
+
+// format renders a placeholder value
+func format(v any) string {
+	switch x := v.(type) {
+	case float64:
+		return strconv.FormatFloat(x, 'g', -1, 64)
+	case error:
+		return x.Error()
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// IsSupported reports whether locale has a catalog
+func IsSupported(locale string) bool {
+	for _, l := range Supported {
+		if l == locale {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Match returns the supported locale for a language tag such as "fr-CA", or
+// the default locale when the language is not supported
+func Match(tag string) string {
+	if locale, ok := match(tag); ok {
+		return locale
+	}
+
+	return DefaultLocale
+}
+
+// match maps a language tag to a supported locale by its primary subtag
+func match(tag string) (string, bool) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		tag = tag[:i]
+	}
+
+	return tag, IsSupported(tag)
+}
+
+// Negotiate picks the supported locale the client prefers from an
+// Accept-Language header, honouring quality values. It returns false when no
+// listed language is supported.
+func Negotiate(acceptLanguage string) (string, bool) {
+	best, bestQ := "", 0.0
+
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+
+		q := 1.0
+
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+
+			q = parsed
+		}
+
+		locale, ok := match(tag)
+		if !ok || q <= bestQ {
+			continue
+		}
+
+		best, bestQ = locale, q
+	}
+
+	return best, best != ""
+}
+
+// contextKey is the request context key of the locale
+type contextKey struct{}
+
+// WithLocale returns a copy of ctx carrying locale
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, contextKey{}, locale)
+}
+
+// FromContext returns the locale stored in ctx, or the default locale
+func FromContext(ctx context.Context) string {
+	if locale, ok := ctx.Value(contextKey{}).(string); ok && locale != "" {
+		return locale
+	}
+
+	return DefaultLocale
+}
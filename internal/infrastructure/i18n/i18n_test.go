@@ -0,0 +1,88 @@
+package i18n_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/infrastructure/i18n"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+		ok     bool
+	}{
+		{"fr-CA,fr;q=0.9,en;q=0.8", i18n.French, true},
+		{"en-US,en;q=0.9", i18n.English, true},
+		{"de-DE,de;q=0.9,fr;q=0.5,en;q=0.4", i18n.French, true},
+		{"en;q=0.3, fr_FR;q=0.7", i18n.French, true},
+		{"de, *;q=0.5", "", false},
+		{"fr;q=bad, en", i18n.English, true},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := i18n.Negotiate(tt.header)
+		assert.Equal(t, tt.ok, ok, tt.header)
+		assert.Equal(t, tt.want, got, tt.header)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	assert.Equal(t, i18n.French, i18n.Match("FR-ca"))
+	assert.Equal(t, i18n.English, i18n.Match("en_GB"))
+	assert.Equal(t, i18n.DefaultLocale, i18n.Match("es"))
+	assert.Equal(t, i18n.DefaultLocale, i18n.Match(""))
+}
+
+func TestLocalizer_T(t *testing.T) {
+	fr := i18n.For("fr-CA")
+	assert.Equal(t, i18n.French, fr.Locale())
+	assert.Equal(t, "Ce champ est obligatoire", fr.T("validation.required"))
+	assert.Equal(t, "La longueur minimale est de 3 caractères", fr.T("validation.min_length", "min", 3))
+	assert.Equal(t, "La valeur maximale est 2.5", fr.T("validation.max", "max", 2.5))
+	assert.Equal(t, "missing.key", fr.T("missing.key"))
+
+	var zero i18n.Localizer
+	assert.Equal(t, i18n.DefaultLocale, zero.Locale())
+	assert.Equal(t, "This field is required", zero.T("validation.required"))
+}
+
+func TestCatalog_Messages(t *testing.T) {
+	messages := i18n.Default().Messages(i18n.French, "formio.")
+
+	assert.Equal(t, "Envoyer", messages["submit"])
+	assert.Equal(t, "{{field}} est obligatoire", messages["required"])
+	assert.NotContains(t, messages, "formio.submit")
+}
+
+// Every locale must translate every message, with the same placeholders
+func TestCatalogs_complete(t *testing.T) {
+	catalog, err := i18n.NewCatalog()
+	require.NoError(t, err)
+
+	placeholders := regexp.MustCompile(`\{\{?\w+\}\}?`)
+	english := i18n.Default().Localizer(i18n.English)
+
+	for _, locale := range i18n.Supported[1:] {
+		assert.Equal(t, catalog.Keys(i18n.English), catalog.Keys(locale), locale)
+
+		localizer := catalog.Localizer(locale)
+		for _, key := range catalog.Keys(locale) {
+			assert.ElementsMatch(t,
+				placeholders.FindAllString(english.T(key), -1),
+				placeholders.FindAllString(localizer.T(key), -1),
+				"%s: %s", locale, key)
+		}
+	}
+}
+
+func TestContext(t *testing.T) {
+	assert.Equal(t, i18n.DefaultLocale, i18n.FromContext(context.Background()))
+	assert.Equal(t, i18n.French, i18n.FromContext(i18n.WithLocale(context.Background(), i18n.French)))
+}
@@ -0,0 +1,256 @@
+// Package keyring implements envelope encryption for sensitive form answers with
+// keys derived from the configured encryption secrets.
+package keyring
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/chacha20poly1305"
+
+	"github.com/goformx/goforms/internal/domain/form/encryption"
+	"github.com/goformx/goforms/internal/infrastructure/config"
+)
+
+const (
+	// AlgorithmAES256GCM is AES-256 in Galois/Counter Mode
+	AlgorithmAES256GCM = "aes-256-gcm"
+	// AlgorithmXChaCha20Poly1305 is XChaCha20-Poly1305, whose 24-byte nonces
+	// are safe to pick at random
+	AlgorithmXChaCha20Poly1305 = "xchacha20-poly1305"
+
+	// keySize is the size of every key encryption key and data key
+	keySize = 32
+	// minSaltLength is the PBKDF2 salt length used when the configured one is out of range
+	minSaltLength = 16
+	// saltPrefix is hashed with a key ID to derive the key's PBKDF2 salt
+	saltPrefix = "goforms/field-encryption/"
+)
+
+// ErrUnsupportedAlgorithm is returned for an algorithm that is unknown or disabled
+var ErrUnsupportedAlgorithm = errors.New("unsupported encryption algorithm")
+
+// Keyring holds the key encryption keys derived from the configured secrets
+type Keyring struct {
+	activeID  string
+	algorithm string
+	keys      map[string][]byte
+}
+
+// New derives the key encryption keys from the configuration. Each secret is
+// stretched with PBKDF2-SHA256; the salt is derived from the key ID, so the
+// same secret and ID always yield the same key.
+func New(cfg config.EncryptionConfig) (*Keyring, error) {
+	if cfg.KeySize != 0 && cfg.KeySize != keySize {
+		return nil, fmt.Errorf("encryption key size must be %d bytes", keySize)
+	}
+
+	// The toggles only restrict the algorithm for new values; values already
+	// stored with another algorithm can still be decrypted
+	enabled := map[string]bool{
+		AlgorithmAES256GCM:         cfg.EnableAES,
+		AlgorithmXChaCha20Poly1305: cfg.EnableChaCha20,
+	}
+
+	if !enabled[cfg.Algorithm] {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedAlgorithm, cfg.Algorithm)
+	}
+
+	k := &Keyring{
+		activeID:  cfg.KeyID,
+		algorithm: cfg.Algorithm,
+		keys:      map[string][]byte{},
+	}
+
+	saltLength := cfg.SaltLength
+	if saltLength < minSaltLength || saltLength > sha256.Size {
+		saltLength = minSaltLength
+	}
+
+	secrets, err := cfg.Keys()
+	if err != nil {
+		return nil, fmt.Errorf("read encryption keys: %w", err)
+	}
+
+	for id, secret := range secrets {
+		salt := sha256.Sum256([]byte(saltPrefix + id))
+
+		key, deriveErr := pbkdf2.Key(sha256.New, secret, salt[:saltLength], max(cfg.Iterations, 1), keySize)
+		if deriveErr != nil {
+			return nil, fmt.Errorf("derive encryption key %q: %w", id, deriveErr)
+		}
+
+		k.keys[id] = key
+	}
+
+	return k, nil
+}
+
+// ActiveKeyID returns the ID of the key that wraps new data keys
+func (k *Keyring) ActiveKeyID() string {
+	return k.activeID
+}
+
+// Seal encrypts plaintext under a new random data key
+func (k *Keyring) Seal(plaintext []byte) (*encryption.Envelope, error) {
+	dataKey := make([]byte, keySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return nil, fmt.Errorf("generate data key: %w", err)
+	}
+
+	ciphertext, err := seal(k.algorithm, dataKey, plaintext, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	env := &encryption.Envelope{
+		Version:    encryption.EnvelopeVersion,
+		Algorithm:  k.algorithm,
+		Ciphertext: base64.StdEncoding.EncodeToString(ciphertext),
+	}
+
+	if wrapErr := k.wrap(env, dataKey); wrapErr != nil {
+		return nil, wrapErr
+	}
+
+	return env, nil
+}
+
+// Open decrypts an envelope
+func (k *Keyring) Open(env *encryption.Envelope) ([]byte, error) {
+	dataKey, err := k.unwrap(env)
+	if err != nil {
+		return nil, err
+	}
+
+	ciphertext, err := base64.StdEncoding.DecodeString(env.Ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("decode ciphertext: %w", err)
+	}
+
+	return open(env.Algorithm, dataKey, ciphertext, nil)
+}
+
+// Rewrap wraps an envelope's data key with the active key
+func (k *Keyring) Rewrap(env *encryption.Envelope) (*encryption.Envelope, error) {
+	dataKey, err := k.unwrap(env)
+	if err != nil {
+		return nil, err
+	}
+
+	rewrapped := *env
+	if wrapErr := k.wrap(&rewrapped, dataKey); wrapErr != nil {
+		return nil, wrapErr
+	}
+
+	return &rewrapped, nil
+}
+
+// wrap encrypts a data key with the active key and stores it in env. The key
+// ID and algorithm are authenticated so they can't be swapped.
+func (k *Keyring) wrap(env *encryption.Envelope, dataKey []byte) error {
+	env.KeyID = k.activeID
+
+	wrapped, err := seal(env.Algorithm, k.keys[k.activeID], dataKey, wrapAAD(env))
+	if err != nil {
+		return fmt.Errorf("wrap data key: %w", err)
+	}
+
+	env.DataKey = base64.StdEncoding.EncodeToString(wrapped)
+
+	return nil
+}
+
+// unwrap decrypts the data key of env
+func (k *Keyring) unwrap(env *encryption.Envelope) ([]byte, error) {
+	kek, ok := k.keys[env.KeyID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", encryption.ErrUnknownKey, env.KeyID)
+	}
+
+	wrapped, err := base64.StdEncoding.DecodeString(env.DataKey)
+	if err != nil {
+		return nil, fmt.Errorf("decode data key: %w", err)
+	}
+
+	dataKey, err := open(env.Algorithm, kek, wrapped, wrapAAD(env))
+	if err != nil {
+		return nil, fmt.Errorf("unwrap data key: %w", err)
+	}
+
+	return dataKey, nil
+}
+
+// wrapAAD returns the additional data authenticated with a wrapped data key
+func wrapAAD(env *encryption.Envelope) []byte {
+	return []byte(env.KeyID + "|" + env.Algorithm)
+}
+
+// seal encrypts plaintext with a random nonce, which is prepended to the result
+func seal(algorithm string, key, plaintext, additionalData []byte) ([]byte, error) {
+	aead, err := newAEAD(algorithm, key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, randErr := rand.Read(nonce); randErr != nil {
+		return nil, fmt.Errorf("generate nonce: %w", randErr)
+	}
+
+	return aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+// open decrypts the output of seal
+func open(algorithm string, key, sealed, additionalData []byte) ([]byte, error) {
+	aead, err := newAEAD(algorithm, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sealed) < aead.NonceSize() {
+		return nil, errors.New("ciphertext is too short")
+	}
+
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+
+	plaintext, err := aead.Open(nil, nonce, ciphertext, additionalData)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt: %w", err)
+	}
+
+	return plaintext, nil
+}
+
+// newAEAD returns the AEAD for an algorithm
+func newAEAD(algorithm string, key []byte) (cipher.AEAD, error) {
+	switch algorithm {
+	case AlgorithmAES256GCM:
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, fmt.Errorf("create AES cipher: %w", err)
+		}
+
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, fmt.Errorf("create GCM: %w", err)
+		}
+
+		return aead, nil
+	case AlgorithmXChaCha20Poly1305:
+		aead, err := chacha20poly1305.NewX(key)
+		if err != nil {
+			return nil, fmt.Errorf("create XChaCha20-Poly1305: %w", err)
+		}
+
+		return aead, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedAlgorithm, algorithm)
+	}
+}
@@ -0,0 +1,125 @@
+package keyring_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/domain/form/encryption"
+	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/keyring"
+)
+
+const (
+	oldSecret = "an-old-field-encryption-secret-that-is-long"
+	newSecret = "a-new-field-encryption-secret-that-is-longer"
+)
+
+func testConfig(algorithm string) config.EncryptionConfig {
+	return config.EncryptionConfig{
+		Key:            oldSecret,
+		KeyID:          "k1",
+		Algorithm:      algorithm,
+		Iterations:     1,
+		EnableAES:      true,
+		EnableChaCha20: true,
+	}
+}
+
+func TestKeyring_SealOpen(t *testing.T) {
+	for _, algorithm := range []string{keyring.AlgorithmAES256GCM, keyring.AlgorithmXChaCha20Poly1305} {
+		k, err := keyring.New(testConfig(algorithm))
+		require.NoError(t, err)
+
+		env, err := k.Seal([]byte(`"123-45-6789"`))
+		require.NoError(t, err)
+		require.NoError(t, env.Validate())
+		assert.Equal(t, "k1", env.KeyID)
+		assert.Equal(t, algorithm, env.Algorithm)
+		assert.NotContains(t, env.Ciphertext, "6789")
+
+		plaintext, err := k.Open(env)
+		require.NoError(t, err)
+		assert.Equal(t, `"123-45-6789"`, string(plaintext))
+	}
+}
+
+func TestKeyring_Open_tampered(t *testing.T) {
+	k, err := keyring.New(testConfig(keyring.AlgorithmAES256GCM))
+	require.NoError(t, err)
+
+	env, err := k.Seal([]byte(`"secret"`))
+	require.NoError(t, err)
+
+	// The key ID is authenticated with the wrapped data key
+	relabeled := *env
+	relabeled.KeyID = "k2"
+	_, err = k.Open(&relabeled)
+	require.ErrorIs(t, err, encryption.ErrUnknownKey)
+
+	other, err := k.Seal([]byte(`"other"`))
+	require.NoError(t, err)
+
+	swapped := *env
+	swapped.Ciphertext = other.Ciphertext
+	_, err = k.Open(&swapped)
+	require.Error(t, err)
+}
+
+func TestKeyring_Rotation(t *testing.T) {
+	old, err := keyring.New(testConfig(keyring.AlgorithmAES256GCM))
+	require.NoError(t, err)
+
+	env, err := old.Seal([]byte(`"secret"`))
+	require.NoError(t, err)
+
+	cfg := testConfig(keyring.AlgorithmXChaCha20Poly1305)
+	cfg.Key = newSecret
+	cfg.KeyID = "k2"
+	cfg.RetiredKeys = []string{"k1=" + oldSecret}
+
+	rotated, err := keyring.New(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, "k2", rotated.ActiveKeyID())
+
+	// Values under the retired key still open
+	plaintext, err := rotated.Open(env)
+	require.NoError(t, err)
+	assert.Equal(t, `"secret"`, string(plaintext))
+
+	rewrapped, err := rotated.Rewrap(env)
+	require.NoError(t, err)
+	assert.Equal(t, "k2", rewrapped.KeyID)
+	assert.Equal(t, env.Ciphertext, rewrapped.Ciphertext)
+	assert.Equal(t, keyring.AlgorithmAES256GCM, rewrapped.Algorithm)
+
+	// Once the retired key is removed, only rewrapped values open
+	cfg.RetiredKeys = nil
+	current, err := keyring.New(cfg)
+	require.NoError(t, err)
+
+	_, err = current.Open(env)
+	require.ErrorIs(t, err, encryption.ErrUnknownKey)
+
+	plaintext, err = current.Open(rewrapped)
+	require.NoError(t, err)
+	assert.Equal(t, `"secret"`, string(plaintext))
+}
+
+func TestNew_invalidConfig(t *testing.T) {
+	cfg := testConfig(keyring.AlgorithmXChaCha20Poly1305)
+	cfg.EnableChaCha20 = false
+	_, err := keyring.New(cfg)
+	require.ErrorIs(t, err, keyring.ErrUnsupportedAlgorithm)
+
+	cfg = testConfig("rot13")
+	_, err = keyring.New(cfg)
+	require.ErrorIs(t, err, keyring.ErrUnsupportedAlgorithm)
+
+	cfg = testConfig(keyring.AlgorithmAES256GCM)
+	cfg.RetiredKeys = []string{"k1=" + strings.Repeat("x", 40)}
+	_, err = keyring.New(cfg)
+	require.Error(t, err)
+}
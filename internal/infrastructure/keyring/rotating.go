@@ -0,0 +1,78 @@
+package keyring
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/goformx/goforms/internal/domain/form/encryption"
+)
+
+// ErrKeyChanged is returned when a rotation gives an existing key ID another
+// secret. The values wrapped with the key would no longer open.
+var ErrKeyChanged = errors.New("encryption key changed under the same key ID")
+
+// Ensure Rotating implements encryption.Cipher
+var _ encryption.Cipher = (*Rotating)(nil)
+
+// Rotating is a cipher whose keyring can be replaced while it is in use, so
+// that a rotated encryption key applies without a restart. Without a keyring
+// field encryption is disabled and stays so until a restart.
+type Rotating struct {
+	current atomic.Pointer[Keyring]
+}
+
+// NewRotating creates a rotating cipher over k, which may be nil
+func NewRotating(k *Keyring) *Rotating {
+	r := &Rotating{}
+	if k != nil {
+		r.current.Store(k)
+	}
+
+	return r
+}
+
+// Enabled reports whether the cipher has a keyring
+func (r *Rotating) Enabled() bool {
+	return r.current.Load() != nil
+}
+
+// Rotate replaces the keyring with next. Keys may be added or removed, but a
+// key ID kept by next must keep its key.
+func (r *Rotating) Rotate(next *Keyring) error {
+	current := r.current.Load()
+	if current == nil {
+		return errors.New("field encryption was disabled at startup")
+	}
+
+	for id, key := range current.keys {
+		if nextKey, ok := next.keys[id]; ok && subtle.ConstantTimeCompare(key, nextKey) != 1 {
+			return fmt.Errorf("%w: %q", ErrKeyChanged, id)
+		}
+	}
+
+	r.current.Store(next)
+
+	return nil
+}
+
+// ActiveKeyID returns the ID of the active key of the current keyring
+func (r *Rotating) ActiveKeyID() string {
+	return r.current.Load().ActiveKeyID()
+}
+
+// Seal encrypts plaintext with the current keyring
+func (r *Rotating) Seal(plaintext []byte) (*encryption.Envelope, error) {
+	return r.current.Load().Seal(plaintext)
+}
+
+// Open decrypts an envelope with the current keyring
+func (r *Rotating) Open(env *encryption.Envelope) ([]byte, error) {
+	return r.current.Load().Open(env)
+}
+
+// Rewrap wraps an envelope's data key with the active key of the current keyring
+func (r *Rotating) Rewrap(env *encryption.Envelope) (*encryption.Envelope, error) {
+	return r.current.Load().Rewrap(env)
+}
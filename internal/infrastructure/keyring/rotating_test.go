@@ -0,0 +1,56 @@
+package keyring_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goformx/goforms/internal/infrastructure/keyring"
+)
+
+func TestRotating_Rotate(t *testing.T) {
+	old, err := keyring.New(testConfig(keyring.AlgorithmAES256GCM))
+	require.NoError(t, err)
+
+	r := keyring.NewRotating(old)
+	require.True(t, r.Enabled())
+
+	env, err := r.Seal([]byte(`"secret"`))
+	require.NoError(t, err)
+
+	cfg := testConfig(keyring.AlgorithmAES256GCM)
+	cfg.Key = newSecret
+	cfg.KeyID = "k2"
+	cfg.RetiredKeys = []string{"k1=" + oldSecret}
+
+	rotated, err := keyring.New(cfg)
+	require.NoError(t, err)
+	require.NoError(t, r.Rotate(rotated))
+	assert.Equal(t, "k2", r.ActiveKeyID())
+
+	plaintext, err := r.Open(env)
+	require.NoError(t, err)
+	assert.Equal(t, `"secret"`, string(plaintext))
+
+	// k1 can't be given another secret, or env would no longer open
+	cfg.RetiredKeys = []string{"k1=" + newSecret + "-changed"}
+	changed, err := keyring.New(cfg)
+	require.NoError(t, err)
+	require.ErrorIs(t, r.Rotate(changed), keyring.ErrKeyChanged)
+	assert.Equal(t, "k2", r.ActiveKeyID())
+
+	plaintext, err = r.Open(env)
+	require.NoError(t, err)
+	assert.Equal(t, `"secret"`, string(plaintext))
+}
+
+func TestRotating_disabled(t *testing.T) {
+	r := keyring.NewRotating(nil)
+	assert.False(t, r.Enabled())
+
+	k, err := keyring.New(testConfig(keyring.AlgorithmAES256GCM))
+	require.NoError(t, err)
+	require.Error(t, r.Rotate(k))
+	assert.False(t, r.Enabled())
+}
@@ -155,7 +155,7 @@ func createEncoderConfig() zapcore.EncoderConfig {
 }
 
 // createZapCore creates the zap core with the appropriate level and output
-func createZapCore(level zapcore.Level, testCore zapcore.Core) zapcore.Core {
+func createZapCore(level zapcore.LevelEnabler, testCore zapcore.Core) zapcore.Core {
 	if testCore != nil {
 		return testCore
 	}
@@ -187,7 +187,7 @@ func createJSONEncoder() zapcore.Encoder {
 }
 
 // createProductionCore creates a production-optimized zap core with stdout output
-func createProductionCore(level zapcore.Level) zapcore.Core {
+func createProductionCore(level zapcore.LevelEnabler) zapcore.Core {
 	encoder := createJSONEncoder()
 	writeSyncer := zapcore.AddSync(os.Stdout)
 
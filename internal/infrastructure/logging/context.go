@@ -0,0 +1,114 @@
+package logging
+
+import "context"
+
+// Correlation field names, used as log fields and as event metadata keys
+const (
+	// FieldRequestID is the ID of the request that led to a log line or event
+	FieldRequestID = "request_id"
+	// FieldUserID is the ID of the signed-in user making the request
+	FieldUserID = "user_id"
+	// FieldFormID is the ID of the form the request is about
+	FieldFormID = "form_id"
+)
+
+// contextKey is the type of the context keys holding correlation fields
+type contextKey string
+
+// correlationFields lists the correlation fields in the order they are logged
+var correlationFields = []string{FieldRequestID, FieldUserID, FieldFormID}
+
+// ContextWithRequestID returns a context carrying a request ID
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return contextWithField(ctx, FieldRequestID, requestID)
+}
+
+// ContextWithUserID returns a context carrying the ID of the signed-in user
+func ContextWithUserID(ctx context.Context, userID string) context.Context {
+	return contextWithField(ctx, FieldUserID, userID)
+}
+
+// ContextWithFormID returns a context carrying the ID of the form being worked on
+func ContextWithFormID(ctx context.Context, formID string) context.Context {
+	return contextWithField(ctx, FieldFormID, formID)
+}
+
+// RequestIDFromContext returns the request ID carried by ctx, if any
+func RequestIDFromContext(ctx context.Context) string {
+	return fieldFromContext(ctx, FieldRequestID)
+}
+
+// CorrelationFields returns the correlation fields carried by ctx, keyed by
+// field name. Fields that are not set are left out.
+func CorrelationFields(ctx context.Context) map[string]string {
+	fields := make(map[string]string)
+
+	for _, name := range correlationFields {
+		if value := fieldFromContext(ctx, name); value != "" {
+			fields[name] = value
+		}
+	}
+
+	return fields
+}
+
+// ContextWithCorrelation returns a context carrying the correlation fields found
+// in values, such as the metadata of an event. Fields already set on ctx are kept.
+func ContextWithCorrelation(ctx context.Context, values map[string]any) context.Context {
+	for _, name := range correlationFields {
+		if fieldFromContext(ctx, name) != "" {
+			continue
+		}
+
+		if value, ok := values[name].(string); ok {
+			ctx = contextWithField(ctx, name, value)
+		}
+	}
+
+	return ctx
+}
+
+// WithContext returns logger with the correlation fields carried by ctx. The
+// logger is returned as is when ctx carries none.
+func WithContext(ctx context.Context, logger Logger) Logger {
+	for _, name := range correlationFields {
+		value := fieldFromContext(ctx, name)
+		if value == "" {
+			continue
+		}
+
+		switch name {
+		case FieldRequestID:
+			logger = logger.WithRequestID(value)
+		case FieldUserID:
+			logger = logger.WithUserID(value)
+		default:
+			logger = logger.With(name, value)
+		}
+	}
+
+	return logger
+}
+
+// contextWithField returns a context carrying a correlation field. Empty values
+// leave ctx unchanged.
+func contextWithField(ctx context.Context, name, value string) context.Context {
+	if value == "" {
+		return ctx
+	}
+
+	return context.WithValue(ctx, contextKey(name), value)
+}
+
+// fieldFromContext returns a correlation field carried by ctx
+func fieldFromContext(ctx context.Context, name string) string {
+	if ctx == nil {
+		return ""
+	}
+
+	if value, ok := ctx.Value(contextKey(name)).(string); ok {
+		return value
+	}
+
+	return ""
+}
@@ -23,6 +23,10 @@ type Factory struct {
 	// Add testCore for test injection
 	testCore zapcore.Core
 	LogLevel string
+	// level is shared by the loggers created, so that SetLevel changes them all
+	level zap.AtomicLevel
+	// redactor is shared the same way, so that RedactSecrets changes them all
+	redactor *redactor
 }
 
 // NewFactory creates a new logger factory with the given configuration
@@ -49,6 +53,8 @@ func NewFactory(cfg *FactoryConfig, sanitizer sanitization.ServiceInterface) (*F
 		sanitizer:      sanitizer,
 		fieldSanitizer: NewSanitizer(),
 		LogLevel:       cfg.LogLevel,
+		level:          zap.NewAtomicLevelAt(parseLogLevel(cfg.LogLevel, cfg.Environment)),
+		redactor:       &redactor{},
 	}, nil
 }
 
@@ -59,21 +65,39 @@ func (f *Factory) WithTestCore(core zapcore.Core) *Factory {
 	return f
 }
 
+// SetLevel changes the level of the loggers created by the factory while they
+// run. An empty level is chosen from the environment.
+func (f *Factory) SetLevel(level string) error {
+	if level != "" && !isValidLogLevel(level) {
+		return fmt.Errorf("invalid log level: %s", level)
+	}
+
+	f.level.SetLevel(parseLogLevel(level, f.environment))
+
+	return nil
+}
+
+// RedactSecrets sets the secret values the loggers created by the factory
+// replace with a placeholder, replacing those set before. Values shorter than
+// four characters are not redacted.
+func (f *Factory) RedactSecrets(values []string) {
+	f.redactor.setSecrets(values)
+}
+
 // CreateLogger creates a new logger instance with the application name.
 func (f *Factory) CreateLogger() (Logger, error) {
-	// Parse log level using config helper
-	level := parseLogLevel(f.LogLevel, f.environment)
-
 	// Create zap core using config helper
 	var core zapcore.Core
 	if f.testCore != nil {
 		core = f.testCore
 	} else if f.environment == "production" {
-		core = createProductionCore(level)
+		core = createProductionCore(f.level)
 	} else {
-		core = createZapCore(level, f.testCore)
+		core = createZapCore(f.level, f.testCore)
 	}
 
+	core = &redactingCore{Core: core, redactor: f.redactor}
+
 	// Create logger with options
 	zapLogger := zap.New(core,
 		zap.AddCaller(),
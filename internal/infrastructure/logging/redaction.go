@@ -0,0 +1,118 @@
+package logging
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// redactedValue replaces secrets in log lines
+const redactedValue = "[REDACTED]"
+
+// minRedactedLength is the length below which values are not redacted. Short
+// values would match too much of unrelated log lines.
+const minRedactedLength = 4
+
+// redactor replaces known secret values in log lines. It is shared by the
+// loggers of a factory, so that secrets set later apply to them all.
+type redactor struct {
+	replacer atomic.Pointer[strings.Replacer]
+}
+
+// setSecrets sets the values to redact, replacing those set before
+func (r *redactor) setSecrets(values []string) {
+	pairs := make([]string, 0, 2*len(values))
+
+	for _, value := range values {
+		if len(value) >= minRedactedLength {
+			pairs = append(pairs, value, redactedValue)
+		}
+	}
+
+	if len(pairs) == 0 {
+		r.replacer.Store(nil)
+
+		return
+	}
+
+	r.replacer.Store(strings.NewReplacer(pairs...))
+}
+
+// redact replaces the secrets in s
+func (r *redactor) redact(s string) string {
+	replacer := r.replacer.Load()
+	if replacer == nil {
+		return s
+	}
+
+	return replacer.Replace(s)
+}
+
+// redactFields replaces the secrets in the string, error and stringer fields
+func (r *redactor) redactFields(fields []zapcore.Field) []zapcore.Field {
+	if r.replacer.Load() == nil {
+		return fields
+	}
+
+	redacted := make([]zapcore.Field, len(fields))
+	copy(redacted, fields)
+
+	for i := range redacted {
+		field := &redacted[i]
+
+		switch field.Type {
+		case zapcore.StringType:
+			field.String = r.redact(field.String)
+		case zapcore.ErrorType:
+			if err, ok := field.Interface.(error); ok {
+				field.Type = zapcore.StringType
+				field.String = r.redact(err.Error())
+				field.Interface = nil
+			}
+		case zapcore.StringerType:
+			if stringer, ok := field.Interface.(fmt.Stringer); ok {
+				field.Type = zapcore.StringType
+				field.String = r.redact(stringer.String())
+				field.Interface = nil
+			}
+		default:
+		}
+	}
+
+	return redacted
+}
+
+// redactingCore is a zapcore.Core that redacts secrets before writing
+type redactingCore struct {
+	zapcore.Core
+	redactor *redactor
+}
+
+// With adds fields to the core, redacted
+func (c *redactingCore) With(fields []zapcore.Field) zapcore.Core {
+	return &redactingCore{Core: c.Core.With(c.redactor.redactFields(fields)), redactor: c.redactor}
+}
+
+// Check adds the core to the checked entry if the level is enabled, so that
+// Write redacts it
+func (c *redactingCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+
+	return checked
+}
+
+// Write redacts the message and fields and writes them
+func (c *redactingCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	entry.Message = c.redactor.redact(entry.Message)
+	entry.Stack = c.redactor.redact(entry.Stack)
+
+	if err := c.Core.Write(entry, c.redactor.redactFields(fields)); err != nil {
+		return fmt.Errorf("write log entry: %w", err)
+	}
+
+	return nil
+}
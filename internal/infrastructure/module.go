@@ -14,18 +14,24 @@ import (
 	"embed"
 
 	"github.com/goformx/goforms/internal/application/handlers/web"
+	"github.com/goformx/goforms/internal/domain/common/events"
 	"github.com/goformx/goforms/internal/domain/form"
+	"github.com/goformx/goforms/internal/domain/form/encryption"
 	formevent "github.com/goformx/goforms/internal/domain/form/event"
+	formevents "github.com/goformx/goforms/internal/domain/form/events"
 	"github.com/goformx/goforms/internal/domain/user"
 	"github.com/goformx/goforms/internal/infrastructure/config"
 	"github.com/goformx/goforms/internal/infrastructure/database"
+	"github.com/goformx/goforms/internal/infrastructure/database/migration"
 	"github.com/goformx/goforms/internal/infrastructure/event"
+	"github.com/goformx/goforms/internal/infrastructure/keyring"
 	"github.com/goformx/goforms/internal/infrastructure/logging"
 	"github.com/goformx/goforms/internal/infrastructure/sanitization"
 	"github.com/goformx/goforms/internal/infrastructure/server"
 	"github.com/goformx/goforms/internal/infrastructure/version"
 	infraweb "github.com/goformx/goforms/internal/infrastructure/web"
 	"github.com/goformx/goforms/internal/presentation/view"
+	"github.com/goformx/goforms/migrations"
 )
 
 const (
@@ -161,7 +167,7 @@ func NewLoggerFactory(p LoggerFactoryParams) (*logging.Factory, error) {
 	}
 
 	// Determine log level based on configuration
-	logLevel := determineLogLevel(p.Config)
+	logLevel := determineLogLevel(&p.Config.App)
 
 	// Set output paths based on environment
 	var outputPaths []string
@@ -190,24 +196,26 @@ func NewLoggerFactory(p LoggerFactoryParams) (*logging.Factory, error) {
 		return nil, fmt.Errorf("failed to create logger factory: %w", err)
 	}
 
+	factory.RedactSecrets(p.Config.SecretValues())
+
 	return factory, nil
 }
 
 // determineLogLevel determines the appropriate log level based on configuration and environment.
 // Priority: explicit LogLevel > Debug flag > Environment > default
-func determineLogLevel(cfg *config.Config) string {
+func determineLogLevel(app *config.AppConfig) string {
 	// Explicit log level takes highest priority
-	if cfg.App.LogLevel != "" {
-		return cfg.App.LogLevel
+	if app.LogLevel != "" {
+		return app.LogLevel
 	}
 
 	// Debug flag overrides environment-based defaults
-	if cfg.App.Debug {
+	if app.Debug {
 		return DevelopmentLogLevel
 	}
 
 	// Environment-based defaults
-	switch cfg.App.Environment {
+	switch app.Environment {
 	case "development", "dev":
 		return DevelopmentLogLevel
 	case "production", "prod":
@@ -328,6 +336,148 @@ func ProvideDatabase(lc fx.Lifecycle, cfg *config.Config, logger logging.Logger)
 	return db, nil
 }
 
+// ProvideEventBus creates the event bus selected by the events driver with
+// lifecycle management. Its subscribers write to the database, so it depends on
+// the connection: fx then stops the bus, draining the queued events, before the
+// connection is closed.
+func ProvideEventBus(
+	lc fx.Lifecycle,
+	cfg *config.Config,
+	logger logging.Logger,
+	db database.DB,
+) (events.EventBus, error) {
+	if cfg == nil {
+		return nil, ErrMissingConfig
+	}
+
+	if logger == nil {
+		return nil, ErrMissingLogger
+	}
+
+	var bus events.EventBus
+
+	switch cfg.Events.Driver {
+	case config.EventDriverPostgres:
+		transport := event.NewPostgresTransport(db.GetDB(), database.PostgresDSN(cfg), cfg.Events.Channel, logger)
+		bus = event.NewClusterEventBus(logger, cfg.Events, transport, NewEventCodec())
+	default:
+		bus = event.NewMemoryEventBus(logger, cfg.Events)
+	}
+
+	lc.Append(fx.Hook{
+		OnStart: bus.Start,
+		OnStop:  bus.Stop,
+	})
+
+	return bus, nil
+}
+
+// NewEventCodec creates the codec that serializes events sent between
+// instances, with the payload types of the form events registered
+func NewEventCodec() *event.Codec {
+	codec := event.NewCodec()
+
+	for eventType, payloadType := range formevents.PayloadTypes() {
+		codec.Register(string(eventType), payloadType)
+	}
+
+	return codec
+}
+
+// ProvideMigrator creates the embedded schema migrator for the configured database driver.
+func ProvideMigrator(db database.DB, cfg *config.Config, logger logging.Logger) (*migration.Migrator, error) {
+	if cfg == nil {
+		return nil, ErrMissingConfig
+	}
+
+	if logger == nil {
+		return nil, ErrMissingLogger
+	}
+
+	sqlDB, err := db.GetDB().DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database instance: %w", err)
+	}
+
+	migrator, err := migration.New(
+		sqlDB,
+		cfg.Database.Driver,
+		migrations.FS,
+		logger,
+		cfg.Database.MigrationLockTimeout,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create migrator: %w", err)
+	}
+
+	return migrator, nil
+}
+
+// RegisterSchemaCheck applies pending migrations on startup when auto-migrate is
+// enabled and refuses to start while the schema is dirty.
+func RegisterSchemaCheck(lc fx.Lifecycle, migrator *migration.Migrator, cfg *config.Config, logger logging.Logger) {
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			if cfg.Database.AutoMigrate {
+				applied, err := migrator.Up(ctx)
+				if err != nil {
+					return fmt.Errorf("failed to run migrations: %w", err)
+				}
+
+				logger.Info("database migrations complete", "applied", applied)
+			}
+
+			status, err := migrator.EnsureClean(ctx)
+			if err != nil {
+				return fmt.Errorf("schema check failed: %w", err)
+			}
+
+			if status.Pending > 0 {
+				logger.Warn("database has pending migrations",
+					"version", status.Version,
+					"pending", status.Pending)
+			}
+
+			return nil
+		},
+	})
+}
+
+// ProvideFieldKeyring creates the keyring that encrypts sensitive submission
+// fields. Without an encryption key it is empty and forms cannot mark fields
+// sensitive. The keyring is replaced when the keys are rotated while the
+// application runs.
+func ProvideFieldKeyring(cfg *config.Config, logger logging.Logger) (*keyring.Rotating, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("field encryption creation failed: %w", ErrMissingConfig)
+	}
+
+	if !cfg.Security.Encryption.Enabled() {
+		logger.Info("Field encryption is disabled: no encryption key is configured")
+
+		return keyring.NewRotating(nil), nil
+	}
+
+	kr, err := keyring.New(cfg.Security.Encryption)
+	if err != nil {
+		return nil, fmt.Errorf("field encryption creation failed: %w", err)
+	}
+
+	logger.Info("Field encryption initialized", "key_id", kr.ActiveKeyID())
+
+	return keyring.NewRotating(kr), nil
+}
+
+// ProvideFieldEncryption creates the service that encrypts sensitive submission
+// fields with the field keyring
+func ProvideFieldEncryption(fieldKeys *keyring.Rotating) encryption.Service {
+	if !fieldKeys.Enabled() {
+		return encryption.NewService(nil)
+	}
+
+	return encryption.NewService(fieldKeys)
+}
+
 // ProvideSanitizationService creates a new sanitization service with proper annotations.
 func ProvideSanitizationService() sanitization.ServiceInterface {
 	return sanitization.NewService()
@@ -343,6 +493,7 @@ var Module = fx.Module("infrastructure",
 
 		// Database with lifecycle management
 		ProvideDatabase,
+		ProvideMigrator,
 
 		// HTTP server
 		server.New,
@@ -357,9 +508,13 @@ var Module = fx.Module("infrastructure",
 		NewLoggerFactory,
 		NewLogger,
 
+		// Sensitive field encryption
+		ProvideFieldKeyring,
+		ProvideFieldEncryption,
+
 		// Event system
 		NewEventPublisher,
-		event.NewMemoryEventBus,
+		ProvideEventBus,
 
 		// Asset handling - provide both the interface and the module
 		fx.Annotate(
@@ -372,6 +527,12 @@ var Module = fx.Module("infrastructure",
 		),
 	),
 
+	// Schema migrations and dirty-schema guard
+	fx.Invoke(RegisterSchemaCheck),
+
+	// Live reload of the log level, CORS, rate limit and CSP settings
+	fx.Invoke(RegisterConfigReload),
+
 	// Lifecycle management
 	fx.Invoke(func(lc fx.Lifecycle, logger logging.Logger, _ *config.Config) {
 		lc.Append(fx.Hook{
@@ -0,0 +1,100 @@
+// Package repository provides the audit log repository implementation
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/goformx/goforms/internal/domain/audit"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/infrastructure/database"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+// Store implements audit.Repository
+type Store struct {
+	db     database.DB
+	logger logging.Logger
+}
+
+// NewStore creates a new audit log store
+func NewStore(db database.DB, logger logging.Logger) audit.Repository {
+	return &Store{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Append stores a new record
+func (s *Store) Append(ctx context.Context, record *audit.Record) error {
+	if err := s.db.GetDB().WithContext(ctx).Create(record).Error; err != nil {
+		return fmt.Errorf("failed to append audit record: %w", err)
+	}
+
+	return nil
+}
+
+// List returns the records matching filter, newest first
+func (s *Store) List(ctx context.Context, filter audit.Filter) ([]*audit.Record, int64, error) {
+	query := s.db.GetDB().WithContext(ctx).Model(&audit.Record{})
+
+	for column, value := range map[string]string{
+		"actor_id":    filter.ActorID,
+		"owner_id":    filter.OwnerID,
+		"action":      filter.Action,
+		"target_type": filter.TargetType,
+		"target_id":   filter.TargetID,
+	} {
+		if value != "" {
+			query = query.Where(column+" = ?", value)
+		}
+	}
+
+	if !filter.Since.IsZero() {
+		query = query.Where("created_at >= ?", filter.Since)
+	}
+
+	if !filter.Until.IsZero() {
+		query = query.Where("created_at < ?", filter.Until)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count audit records: %w", err)
+	}
+
+	var records []*audit.Record
+	if err := query.
+		Order("created_at DESC").
+		Offset(filter.Offset).
+		Limit(filter.Limit).
+		Find(&records).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list audit records: %w", err)
+	}
+
+	return records, total, nil
+}
+
+// LatestState returns the most recent After summary recorded for a target by
+// one of the given actions
+func (s *Store) LatestState(
+	ctx context.Context,
+	targetType, targetID string,
+	actions ...string,
+) (model.JSON, error) {
+	var records []*audit.Record
+	if err := s.db.GetDB().WithContext(ctx).
+		Where("target_type = ? AND target_id = ? AND action IN ?", targetType, targetID, actions).
+		Order("created_at DESC").
+		Limit(1).
+		Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("failed to get latest audit state: %w", err)
+	}
+
+	var state model.JSON
+	if len(records) > 0 {
+		state = records[0].After
+	}
+
+	return state, nil
+}
@@ -53,13 +53,14 @@ func NewNotFoundError(op, entity, id string) error {
 	}
 }
 
-// NewInvalidInputError creates a new invalid input error
+// NewInvalidInputError creates a new invalid input error. It matches
+// ErrInvalidInput as well as the underlying error.
 func NewInvalidInputError(op, entity, id string, err error) error {
 	return &StoreError{
 		Op:      op,
 		Entity:  entity,
 		ID:      id,
-		Err:     err,
+		Err:     fmt.Errorf("%w: %w", ErrInvalidInput, err),
 		Details: fmt.Sprintf("%+v", err),
 	}
 }
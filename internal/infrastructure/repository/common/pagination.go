@@ -2,10 +2,17 @@
 // including error handling, pagination, and common data structures.
 package common
 
+import "slices"
+
 // PaginationParams represents the parameters for pagination
 type PaginationParams struct {
 	Page     int
 	PageSize int
+	// SortBy is the column to order by. Stores only sort by the columns they
+	// allow and use their default order otherwise.
+	SortBy string
+	// SortDesc orders by SortBy in descending order
+	SortDesc bool
 }
 
 // PaginationResult represents the result of a paginated query
@@ -43,6 +50,21 @@ func (p PaginationParams) GetLimit() int {
 	return p.PageSize
 }
 
+// OrderBy returns the ORDER BY clause for the requested sort, or fallback when
+// SortBy is not one of allowed. Only allowed columns reach the query, so the
+// clause is safe to pass to the database.
+func (p PaginationParams) OrderBy(allowed []string, fallback string) string {
+	if !slices.Contains(allowed, p.SortBy) {
+		return fallback
+	}
+
+	if p.SortDesc {
+		return p.SortBy + " DESC"
+	}
+
+	return p.SortBy + " ASC"
+}
+
 // NewPaginationResult creates a new PaginationResult
 func NewPaginationResult(items any, totalItems, page, pageSize int) PaginationResult {
 	totalPages := (totalItems + pageSize - 1) / pageSize
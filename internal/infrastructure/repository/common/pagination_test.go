@@ -0,0 +1,35 @@
+package common_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+)
+
+func TestPaginationParams_OrderBy(t *testing.T) {
+	allowed := []string{"submitted_at", "status"}
+	fallback := "submitted_at DESC"
+
+	tests := []struct {
+		params common.PaginationParams
+		want   string
+	}{
+		{common.PaginationParams{SortBy: "status"}, "status ASC"},
+		{common.PaginationParams{SortBy: "submitted_at", SortDesc: true}, "submitted_at DESC"},
+		{common.PaginationParams{}, fallback},
+		{common.PaginationParams{SortBy: "uuid; DROP TABLE forms"}, fallback},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.params.OrderBy(allowed, fallback), tt.params.SortBy)
+	}
+}
+
+func TestNewInvalidInputError(t *testing.T) {
+	err := common.NewInvalidInputError("get", "form", "bad-id", assert.AnError)
+
+	assert.ErrorIs(t, err, common.ErrInvalidInput)
+	assert.ErrorIs(t, err, assert.AnError)
+}
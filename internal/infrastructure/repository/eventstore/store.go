@@ -0,0 +1,117 @@
+// Package repository provides the event log repository implementation
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm/clause"
+
+	"github.com/goformx/goforms/internal/domain/eventstore"
+	"github.com/goformx/goforms/internal/infrastructure/database"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+// checkpoint is the last sequence number a consumer of the log handled
+type checkpoint struct {
+	Consumer  string    `gorm:"primaryKey;size:128"`
+	Sequence  int64     `gorm:"not null"`
+	UpdatedAt time.Time `gorm:"not null"`
+}
+
+// TableName specifies the table name for the checkpoint model
+func (c *checkpoint) TableName() string {
+	return "event_checkpoints"
+}
+
+// Store implements eventstore.Repository
+type Store struct {
+	db     database.DB
+	logger logging.Logger
+}
+
+// NewStore creates a new event log store
+func NewStore(db database.DB, logger logging.Logger) eventstore.Repository {
+	return &Store{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Append stores a new record
+func (s *Store) Append(ctx context.Context, record *eventstore.Record) error {
+	if err := s.db.GetDB().WithContext(ctx).Create(record).Error; err != nil {
+		return fmt.Errorf("failed to append event record: %w", err)
+	}
+
+	return nil
+}
+
+// List returns the records matching filter in sequence order
+func (s *Store) List(ctx context.Context, filter eventstore.Filter) ([]*eventstore.Record, error) {
+	query := s.db.GetDB().WithContext(ctx).Where("sequence > ?", filter.After)
+
+	if filter.Until > 0 {
+		query = query.Where("sequence <= ?", filter.Until)
+	}
+
+	if len(filter.Names) > 0 {
+		query = query.Where("name IN ?", filter.Names)
+	}
+
+	if filter.StreamType != "" {
+		query = query.Where("stream_type = ?", filter.StreamType)
+	}
+
+	if filter.StreamID != "" {
+		query = query.Where("stream_id = ?", filter.StreamID)
+	}
+
+	if !filter.RecordedBefore.IsZero() {
+		query = query.Where("recorded_at < ?", filter.RecordedBefore)
+	}
+
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	var records []*eventstore.Record
+	if err := query.Order("sequence ASC").Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("failed to list event records: %w", err)
+	}
+
+	return records, nil
+}
+
+// Checkpoint returns the last sequence number a consumer handled
+func (s *Store) Checkpoint(ctx context.Context, consumer string) (int64, error) {
+	var checkpoints []*checkpoint
+	if err := s.db.GetDB().WithContext(ctx).
+		Where("consumer = ?", consumer).
+		Limit(1).
+		Find(&checkpoints).Error; err != nil {
+		return 0, fmt.Errorf("failed to get event checkpoint: %w", err)
+	}
+
+	if len(checkpoints) == 0 {
+		return 0, nil
+	}
+
+	return checkpoints[0].Sequence, nil
+}
+
+// SaveCheckpoint stores the last sequence number a consumer handled
+func (s *Store) SaveCheckpoint(ctx context.Context, consumer string, sequence int64) error {
+	err := s.db.GetDB().WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "consumer"}},
+			DoUpdates: clause.AssignmentColumns([]string{"sequence", "updated_at"}),
+		}).
+		Create(&checkpoint{Consumer: consumer, Sequence: sequence, UpdatedAt: time.Now().UTC()}).Error
+	if err != nil {
+		return fmt.Errorf("failed to save event checkpoint: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+// Package repository provides the draft submission repository implementation
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/goformx/goforms/internal/domain/form/draft"
+	"github.com/goformx/goforms/internal/infrastructure/database"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+)
+
+// Store implements draft.Repository
+type Store struct {
+	db     database.DB
+	logger logging.Logger
+}
+
+// NewStore creates a new draft store
+func NewStore(db database.DB, logger logging.Logger) draft.Repository {
+	return &Store{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Create stores a new draft
+func (s *Store) Create(ctx context.Context, d *draft.Draft) error {
+	if err := s.db.GetDB().WithContext(ctx).Create(d).Error; err != nil {
+		return fmt.Errorf("failed to create draft: %w", err)
+	}
+
+	return nil
+}
+
+// GetByTokenHash retrieves a draft by the hash of its resume token
+func (s *Store) GetByTokenHash(ctx context.Context, tokenHash string) (*draft.Draft, error) {
+	var d draft.Draft
+	if err := s.db.GetDB().WithContext(ctx).Where("token_hash = ?", tokenHash).First(&d).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, common.NewNotFoundError("get", "draft", "")
+		}
+
+		return nil, fmt.Errorf("failed to get draft: %w", err)
+	}
+
+	return &d, nil
+}
+
+// Update saves a draft's data and expiry
+func (s *Store) Update(ctx context.Context, d *draft.Draft) error {
+	result := s.db.GetDB().WithContext(ctx).
+		Model(&draft.Draft{}).
+		Where("uuid = ?", d.ID).
+		Updates(map[string]any{"data": d.Data, "expires_at": d.ExpiresAt})
+	if result.Error != nil {
+		return fmt.Errorf("failed to update draft: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return common.NewNotFoundError("update", "draft", d.ID)
+	}
+
+	return nil
+}
+
+// Delete removes a draft
+func (s *Store) Delete(ctx context.Context, id string) error {
+	result := s.db.GetDB().WithContext(ctx).Where("uuid = ?", id).Delete(&draft.Draft{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete draft: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return common.NewNotFoundError("delete", "draft", id)
+	}
+
+	return nil
+}
+
+// DeleteExpired removes drafts that expired before the given time
+func (s *Store) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	result := s.db.GetDB().WithContext(ctx).Where("expires_at < ?", before).Delete(&draft.Draft{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to delete expired drafts: %w", result.Error)
+	}
+
+	return result.RowsAffected, nil
+}
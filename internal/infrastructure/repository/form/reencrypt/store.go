@@ -0,0 +1,135 @@
+// Package repository provides the re-encryption repository implementation
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"github.com/goformx/goforms/internal/domain/form/draft"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/domain/form/reencrypt"
+	"github.com/goformx/goforms/internal/domain/form/revision"
+	"github.com/goformx/goforms/internal/infrastructure/database"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+// batchSize is the number of rows loaded at a time when scanning
+const batchSize = 500
+
+// Store implements reencrypt.Repository
+type Store struct {
+	db     database.DB
+	logger logging.Logger
+}
+
+// NewStore creates a new re-encryption store
+func NewStore(db database.DB, logger logging.Logger) reencrypt.Repository {
+	return &Store{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// ListForms returns every form that is not deleted
+func (s *Store) ListForms(ctx context.Context) ([]*model.Form, error) {
+	var forms []*model.Form
+	if err := s.db.GetDB().WithContext(ctx).Find(&forms).Error; err != nil {
+		return nil, fmt.Errorf("failed to list forms: %w", err)
+	}
+
+	return forms, nil
+}
+
+// ScanSubmissions calls fn with successive batches of a form's submissions
+func (s *Store) ScanSubmissions(
+	ctx context.Context,
+	formID string,
+	fn func([]*model.FormSubmission) error,
+) error {
+	var batch []*model.FormSubmission
+
+	result := s.db.GetDB().WithContext(ctx).
+		Where("form_id = ?", formID).
+		FindInBatches(&batch, batchSize, func(_ *gorm.DB, _ int) error {
+			return fn(batch)
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to scan submissions: %w", result.Error)
+	}
+
+	return nil
+}
+
+// ScanRevisions calls fn with successive batches of the revisions of a form's
+// submissions
+func (s *Store) ScanRevisions(ctx context.Context, formID string, fn func([]*revision.Revision) error) error {
+	var batch []*revision.Revision
+
+	db := s.db.GetDB().WithContext(ctx)
+	submissions := db.Model(&model.FormSubmission{}).Select("uuid").Where("form_id = ?", formID)
+
+	result := db.
+		Where("submission_id IN (?)", submissions).
+		FindInBatches(&batch, batchSize, func(_ *gorm.DB, _ int) error {
+			return fn(batch)
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to scan submission revisions: %w", result.Error)
+	}
+
+	return nil
+}
+
+// ScanDrafts calls fn with successive batches of a form's drafts
+func (s *Store) ScanDrafts(ctx context.Context, formID string, fn func([]*draft.Draft) error) error {
+	var batch []*draft.Draft
+
+	result := s.db.GetDB().WithContext(ctx).
+		Where("form_id = ?", formID).
+		FindInBatches(&batch, batchSize, func(_ *gorm.DB, _ int) error {
+			return fn(batch)
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to scan drafts: %w", result.Error)
+	}
+
+	return nil
+}
+
+// UpdateSubmissionData replaces the data of a submission
+func (s *Store) UpdateSubmissionData(ctx context.Context, submissionID string, data model.JSON) error {
+	if err := s.db.GetDB().WithContext(ctx).
+		Model(&model.FormSubmission{}).
+		Where("uuid = ?", submissionID).
+		UpdateColumn("data", data).Error; err != nil {
+		return fmt.Errorf("failed to update submission data: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateRevisionData replaces the data and changes of a revision
+func (s *Store) UpdateRevisionData(ctx context.Context, r *revision.Revision) error {
+	if err := s.db.GetDB().WithContext(ctx).
+		Model(r).
+		Select("Data", "Changes").
+		Updates(r).Error; err != nil {
+		return fmt.Errorf("failed to update submission revision: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateDraftData replaces the data of a draft
+func (s *Store) UpdateDraftData(ctx context.Context, draftID string, data model.JSON) error {
+	if err := s.db.GetDB().WithContext(ctx).
+		Model(&draft.Draft{}).
+		Where("uuid = ?", draftID).
+		UpdateColumn("data", data).Error; err != nil {
+		return fmt.Errorf("failed to update draft data: %w", err)
+	}
+
+	return nil
+}
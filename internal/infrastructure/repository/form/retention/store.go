@@ -0,0 +1,256 @@
+// Package repository provides the submission retention repository implementation
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/goformx/goforms/internal/domain/form/draft"
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/domain/form/retention"
+	"github.com/goformx/goforms/internal/domain/form/revision"
+	"github.com/goformx/goforms/internal/infrastructure/database"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+)
+
+// batchSize is the number of submissions loaded at a time when scanning or
+// anonymizing
+const batchSize = 500
+
+// lockID is the PostgreSQL advisory lock key held while enforcing retention
+const lockID int64 = 3_817_462_906
+
+// lockName is the MariaDB named lock held while enforcing retention
+const lockName = "goforms_retention"
+
+// Store implements retention.Repository
+type Store struct {
+	db     database.DB
+	logger logging.Logger
+}
+
+// NewStore creates a new submission retention store
+func NewStore(db database.DB, logger logging.Logger) retention.Repository {
+	return &Store{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// ListFormsWithRetention returns the forms that have a retention period set
+func (s *Store) ListFormsWithRetention(ctx context.Context) ([]*model.Form, error) {
+	var forms []*model.Form
+	if err := s.db.GetDB().WithContext(ctx).
+		Where("retention_days > 0").
+		Find(&forms).Error; err != nil {
+		return nil, fmt.Errorf("failed to list forms with retention: %w", err)
+	}
+
+	return forms, nil
+}
+
+// DeleteSubmittedBefore deletes a form's submissions made before cutoff
+func (s *Store) DeleteSubmittedBefore(ctx context.Context, formID string, cutoff time.Time) (int64, error) {
+	result := s.db.GetDB().WithContext(ctx).
+		Where("form_id = ? AND submitted_at < ?", formID, cutoff).
+		Delete(&model.FormSubmission{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to delete expired submissions: %w", result.Error)
+	}
+
+	return result.RowsAffected, nil
+}
+
+// AnonymizeSubmittedBefore clears the answers and edit history of a form's
+// submissions made before cutoff
+func (s *Store) AnonymizeSubmittedBefore(
+	ctx context.Context,
+	formID string,
+	cutoff, now time.Time,
+) (int64, error) {
+	var (
+		count int64
+		batch []*model.FormSubmission
+	)
+
+	result := s.db.GetDB().WithContext(ctx).
+		Where("form_id = ? AND submitted_at < ? AND anonymized_at IS NULL", formID, cutoff).
+		FindInBatches(&batch, batchSize, func(_ *gorm.DB, _ int) error {
+			return s.db.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+				ids := make([]string, len(batch))
+
+				for i, sub := range batch {
+					sub.Anonymize(now)
+					ids[i] = sub.ID
+
+					if err := tx.Model(&model.FormSubmission{}).
+						Where("uuid = ?", sub.ID).
+						Updates(map[string]any{"data": sub.Data, "anonymized_at": sub.AnonymizedAt}).Error; err != nil {
+						return fmt.Errorf("anonymize submission %s: %w", sub.ID, err)
+					}
+				}
+
+				if err := tx.Where("submission_id IN ?", ids).Delete(&revision.Revision{}).Error; err != nil {
+					return fmt.Errorf("delete revisions: %w", err)
+				}
+
+				count += int64(len(batch))
+
+				return nil
+			})
+		})
+	if result.Error != nil {
+		return count, fmt.Errorf("failed to anonymize expired submissions: %w", result.Error)
+	}
+
+	return count, nil
+}
+
+// PurgeDeletedSubmissions deletes soft-deleted submissions
+func (s *Store) PurgeDeletedSubmissions(ctx context.Context) (int64, error) {
+	result := s.db.GetDB().WithContext(ctx).
+		Where("deleted_at IS NOT NULL").
+		Delete(&model.FormSubmission{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to purge deleted submissions: %w", result.Error)
+	}
+
+	return result.RowsAffected, nil
+}
+
+// PurgeDeletedForms deletes forms soft-deleted before deletedBefore with their
+// submissions and drafts
+func (s *Store) PurgeDeletedForms(ctx context.Context, deletedBefore time.Time) ([]*model.Form, error) {
+	var forms []*model.Form
+	if err := s.db.GetDB().WithContext(ctx).Unscoped().
+		Select("uuid", "user_id", "title").
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", deletedBefore).
+		Find(&forms).Error; err != nil {
+		return nil, fmt.Errorf("failed to list deleted forms: %w", err)
+	}
+
+	if len(forms) == 0 {
+		return forms, nil
+	}
+
+	ids := make([]string, len(forms))
+	for i, f := range forms {
+		ids[i] = f.ID
+	}
+
+	err := s.db.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("form_id IN ?", ids).Delete(&model.FormSubmission{}).Error; err != nil {
+			return fmt.Errorf("delete submissions: %w", err)
+		}
+
+		if err := tx.Where("form_id IN ?", ids).Delete(&draft.Draft{}).Error; err != nil {
+			return fmt.Errorf("delete drafts: %w", err)
+		}
+
+		if err := tx.Unscoped().Where("uuid IN ?", ids).Delete(&model.Form{}).Error; err != nil {
+			return fmt.Errorf("delete forms: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to purge deleted forms: %w", err)
+	}
+
+	return forms, nil
+}
+
+// RunExclusive runs fn while holding a session-level database lock. The lock is
+// taken without waiting, so an instance that finds it held skips the run.
+func (s *Store) RunExclusive(ctx context.Context, fn func(ctx context.Context) error) (bool, error) {
+	sqlDB, err := s.db.GetDB().DB()
+	if err != nil {
+		return false, fmt.Errorf("get database handle: %w", err)
+	}
+
+	// Session-level locks belong to a connection, so the lock is taken and
+	// released on one connection held for the whole run
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return false, fmt.Errorf("get database connection: %w", err)
+	}
+
+	defer func() {
+		if closeErr := conn.Close(); closeErr != nil {
+			s.logger.Warn("failed to close retention lock connection", "error", closeErr)
+		}
+	}()
+
+	lock, unlock, key := "SELECT pg_try_advisory_lock($1)", "SELECT pg_advisory_unlock($1)", any(lockID)
+	if s.db.GetDB().Name() == "mysql" {
+		lock, unlock, key = "SELECT GET_LOCK(?, 0)", "SELECT RELEASE_LOCK(?)", lockName
+	}
+
+	// GET_LOCK returns 1 when the lock is granted, 0 when it is held elsewhere
+	var acquired sql.NullBool
+	if scanErr := conn.QueryRowContext(ctx, lock, key).Scan(&acquired); scanErr != nil {
+		return false, fmt.Errorf("acquire retention lock: %w", scanErr)
+	}
+
+	if !acquired.Valid || !acquired.Bool {
+		return false, nil
+	}
+
+	runErr := fn(ctx)
+
+	if _, unlockErr := conn.ExecContext(context.WithoutCancel(ctx), unlock, key); unlockErr != nil {
+		return true, errors.Join(runErr, fmt.Errorf("release retention lock: %w", unlockErr))
+	}
+
+	return true, runErr
+}
+
+// ListUserForms returns a user's forms, including soft-deleted ones
+func (s *Store) ListUserForms(ctx context.Context, userID string) ([]*model.Form, error) {
+	var forms []*model.Form
+	if err := s.db.GetDB().WithContext(ctx).Unscoped().
+		Where("user_id = ?", userID).
+		Order("created_at ASC").
+		Find(&forms).Error; err != nil {
+		return nil, fmt.Errorf("failed to list user forms: %w", err)
+	}
+
+	return forms, nil
+}
+
+// ScanSubmissions calls fn with successive batches of a form's submissions
+func (s *Store) ScanSubmissions(
+	ctx context.Context,
+	formID string,
+	fn func([]*model.FormSubmission) error,
+) error {
+	var batch []*model.FormSubmission
+
+	result := s.db.GetDB().WithContext(ctx).
+		Where("form_id = ?", formID).
+		FindInBatches(&batch, batchSize, func(_ *gorm.DB, _ int) error {
+			return fn(batch)
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to scan submissions: %w", result.Error)
+	}
+
+	return nil
+}
+
+// DeleteSubmissions deletes submissions by ID
+func (s *Store) DeleteSubmissions(ctx context.Context, ids []string) (int64, error) {
+	result := s.db.GetDB().WithContext(ctx).
+		Where("uuid IN ?", ids).
+		Delete(&model.FormSubmission{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to delete submissions: %w", result.Error)
+	}
+
+	return result.RowsAffected, nil
+}
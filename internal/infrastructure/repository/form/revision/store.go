@@ -0,0 +1,100 @@
+// Package repository provides the submission revision repository implementation
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"github.com/goformx/goforms/internal/domain/form/model"
+	"github.com/goformx/goforms/internal/domain/form/revision"
+	"github.com/goformx/goforms/internal/infrastructure/database"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+)
+
+// Store implements revision.Repository
+type Store struct {
+	db     database.DB
+	logger logging.Logger
+}
+
+// NewStore creates a new submission revision store
+func NewStore(db database.DB, logger logging.Logger) revision.Repository {
+	return &Store{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// ListBySubmission returns a submission's revisions, oldest first
+func (s *Store) ListBySubmission(ctx context.Context, submissionID string) ([]*revision.Revision, error) {
+	var revisions []*revision.Revision
+	if err := s.db.GetDB().WithContext(ctx).
+		Where("submission_id = ?", submissionID).
+		Order("number ASC").
+		Find(&revisions).Error; err != nil {
+		return nil, fmt.Errorf("failed to list submission revisions: %w", err)
+	}
+
+	return revisions, nil
+}
+
+// Apply stores the revisions and saves the submission's data in one transaction
+func (s *Store) Apply(ctx context.Context, submission *model.FormSubmission, revisions ...*revision.Revision) error {
+	err := s.db.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, r := range revisions {
+			if err := tx.Create(r).Error; err != nil {
+				return fmt.Errorf("create revision %d: %w", r.Number, err)
+			}
+		}
+
+		result := tx.Model(&model.FormSubmission{}).
+			Where("uuid = ?", submission.ID).
+			Update("data", submission.Data)
+		if result.Error != nil {
+			return fmt.Errorf("update submission data: %w", result.Error)
+		}
+
+		if result.RowsAffected == 0 {
+			return common.NewNotFoundError("update", "form_submission", submission.ID)
+		}
+
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("failed to apply submission revision", "submission_id", submission.ID, "error", err)
+
+		return fmt.Errorf("failed to apply submission revision: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a submission and its revisions in one transaction
+func (s *Store) Delete(ctx context.Context, submissionID string) error {
+	err := s.db.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("submission_id = ?", submissionID).Delete(&revision.Revision{}).Error; err != nil {
+			return fmt.Errorf("delete revisions: %w", err)
+		}
+
+		result := tx.Where("uuid = ?", submissionID).Delete(&model.FormSubmission{})
+		if result.Error != nil {
+			return fmt.Errorf("delete submission: %w", result.Error)
+		}
+
+		if result.RowsAffected == 0 {
+			return common.NewNotFoundError("delete", "form_submission", submissionID)
+		}
+
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("failed to delete submission", "submission_id", submissionID, "error", err)
+
+		return fmt.Errorf("failed to delete submission: %w", err)
+	}
+
+	return nil
+}
@@ -2,10 +2,13 @@
 package repository
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -31,9 +34,16 @@ func NewStore(db database.DB, logger logging.Logger) form.Repository {
 	}
 }
 
-// CreateForm creates a new form
+// CreateForm creates a new form and stores its schema as the first version
 func (s *Store) CreateForm(ctx context.Context, formModel *model.Form) error {
-	if err := s.db.GetDB().WithContext(ctx).Create(formModel).Error; err != nil {
+	err := s.db.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(formModel).Error; err != nil {
+			return fmt.Errorf("insert form: %w", err)
+		}
+
+		return addSchemaVersion(tx, formModel)
+	})
+	if err != nil {
 		s.logger.Error("failed to create form",
 			"form_id", formModel.ID,
 			"error", err,
@@ -88,6 +98,20 @@ func (s *Store) GetFormByID(ctx context.Context, id string) (*model.Form, error)
 	return &formModel, nil
 }
 
+// GetFormByIDUnscoped retrieves a form by ID, including a soft-deleted one
+func (s *Store) GetFormByIDUnscoped(ctx context.Context, id string) (*model.Form, error) {
+	var formModel model.Form
+	if err := s.db.GetDB().WithContext(ctx).Unscoped().Where("uuid = ?", id).First(&formModel).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("get form by ID: %w", common.NewNotFoundError("get", "form", id))
+		}
+
+		return nil, fmt.Errorf("get form by ID: %w", common.NewDatabaseError("get", "form", id, err))
+	}
+
+	return &formModel, nil
+}
+
 // ListForms retrieves all forms for a user
 func (s *Store) ListForms(ctx context.Context, userID string) ([]*model.Form, error) {
 	var forms []*model.Form
@@ -106,20 +130,95 @@ func (s *Store) ListForms(ctx context.Context, userID string) ([]*model.Form, er
 	return forms, nil
 }
 
-// UpdateForm updates a form
+// UpdateForm updates a form. A changed schema is stored as a new version.
 func (s *Store) UpdateForm(ctx context.Context, formModel *model.Form) error {
-	result := s.db.GetDB().WithContext(ctx).Model(&model.Form{}).Where("uuid = ?", formModel.ID).Updates(formModel)
-	if result.Error != nil {
-		return fmt.Errorf("update form: %w", common.NewDatabaseError("update", "form", formModel.ID, result.Error))
+	err := s.db.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.Form{}).Where("uuid = ?", formModel.ID).Updates(formModel)
+		if result.Error != nil {
+			return common.NewDatabaseError("update", "form", formModel.ID, result.Error)
+		}
+
+		if result.RowsAffected == 0 {
+			return common.NewNotFoundError("update", "form", formModel.ID)
+		}
+
+		if formModel.Schema == nil {
+			return nil
+		}
+
+		return addSchemaVersion(tx, formModel)
+	})
+	if err != nil {
+		return fmt.Errorf("update form: %w", err)
 	}
 
-	if result.RowsAffected == 0 {
-		return fmt.Errorf("update form: %w", common.NewNotFoundError("update", "form", formModel.ID))
+	return nil
+}
+
+// ListSchemaVersions returns the schemas a form has had, oldest first
+func (s *Store) ListSchemaVersions(ctx context.Context, formID string) ([]*model.SchemaVersion, error) {
+	var versions []*model.SchemaVersion
+	if err := s.db.GetDB().WithContext(ctx).
+		Where("form_id = ?", formID).
+		Order("version ASC").
+		Find(&versions).Error; err != nil {
+		return nil, fmt.Errorf("list schema versions: %w", common.NewDatabaseError("list", "form_schema", formID, err))
+	}
+
+	return versions, nil
+}
+
+// addSchemaVersion stores the form's schema as its next version, unless it is
+// the schema of the latest version
+func addSchemaVersion(tx *gorm.DB, formModel *model.Form) error {
+	var latest model.SchemaVersion
+
+	err := tx.Where("form_id = ?", formModel.ID).Order("version DESC").First(&latest).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("get latest schema version: %w", err)
+	}
+
+	if err == nil {
+		same, sameErr := sameSchema(latest.Schema, formModel.Schema)
+		if sameErr != nil {
+			return sameErr
+		}
+
+		if same {
+			return nil
+		}
+	}
+
+	version := &model.SchemaVersion{
+		ID:        uuid.New().String(),
+		FormID:    formModel.ID,
+		Version:   latest.Version + 1,
+		Schema:    formModel.Schema,
+		ValidFrom: time.Now(),
+	}
+	if createErr := tx.Create(version).Error; createErr != nil {
+		return fmt.Errorf("add schema version: %w", createErr)
 	}
 
 	return nil
 }
 
+// sameSchema compares schemas by their JSON encoding, so that a schema read
+// back from the database equals the one it was stored from
+func sameSchema(a, b model.JSON) (bool, error) {
+	encodedA, err := json.Marshal(a)
+	if err != nil {
+		return false, fmt.Errorf("encode schema: %w", err)
+	}
+
+	encodedB, err := json.Marshal(b)
+	if err != nil {
+		return false, fmt.Errorf("encode schema: %w", err)
+	}
+
+	return bytes.Equal(encodedA, encodedB), nil
+}
+
 // DeleteForm deletes a form
 func (s *Store) DeleteForm(ctx context.Context, id string) error {
 	// Normalize the UUID by trimming spaces and converting to lowercase
@@ -264,7 +363,8 @@ func (s *Store) GetByFormID(ctx context.Context, formID string) ([]*model.FormSu
 	return s.ListSubmissions(ctx, formID)
 }
 
-// GetByFormIDPaginated retrieves paginated submissions for a form
+// GetByFormIDPaginated retrieves a page of submissions for a form, newest first
+// unless params asks for another order
 func (s *Store) GetByFormIDPaginated(
 	ctx context.Context,
 	formID string,
@@ -279,6 +379,8 @@ func (s *Store) GetByFormIDPaginated(
 
 	var submissions []*model.FormSubmission
 	if err := query.
+		Order(params.OrderBy(model.SubmissionSortFields, model.DefaultSubmissionOrder)).
+		Order("uuid").
 		Offset(params.GetOffset()).
 		Limit(params.GetLimit()).
 		Find(&submissions).Error; err != nil {
@@ -294,6 +396,30 @@ func (s *Store) GetByFormIDPaginated(
 	}, nil
 }
 
+// CountSubmissions returns the number of submissions of each form
+func (s *Store) CountSubmissions(ctx context.Context, formIDs []string) (map[string]int64, error) {
+	var rows []struct {
+		FormID string
+		Count  int64
+	}
+
+	if err := s.db.GetDB().WithContext(ctx).
+		Model(&model.FormSubmission{}).
+		Select("form_id, COUNT(*) AS count").
+		Where("form_id IN ?", formIDs).
+		Group("form_id").
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to count submissions: %w", err)
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.FormID] = row.Count
+	}
+
+	return counts, nil
+}
+
 // GetByFormAndUser retrieves a submission by form ID and user ID
 func (s *Store) GetByFormAndUser(
 	ctx context.Context,
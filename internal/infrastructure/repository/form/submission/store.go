@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"time"
 
 	"gorm.io/gorm"
 
@@ -123,7 +124,8 @@ func (s *Store) buildEmptyPaginationResult(params common.PaginationParams) *comm
 	}
 }
 
-// GetByFormIDPaginated retrieves form submissions by form ID with pagination
+// GetByFormIDPaginated retrieves form submissions by form ID with pagination,
+// newest first unless params asks for another order
 func (s *Store) GetByFormIDPaginated(
 	ctx context.Context,
 	formID string,
@@ -146,6 +148,7 @@ func (s *Store) GetByFormIDPaginated(
 
 	// Get paginated submissions
 	if err := s.db.GetDB().WithContext(ctx).Where("form_id = ?", formID).
+		Order(params.OrderBy(model.SubmissionSortFields, model.DefaultSubmissionOrder)).Order("uuid").
 		Offset(params.GetOffset()).Limit(params.GetLimit()).
 		Find(&submissions).Error; err != nil {
 		return nil, fmt.Errorf("failed to get submissions: %w", err)
@@ -165,6 +168,37 @@ func (s *Store) CountByFormID(ctx context.Context, formID string) (int64, error)
 	return count, nil
 }
 
+// olderThanQuery scopes a query to submissions made before the given time
+func (s *Store) olderThanQuery(ctx context.Context, before time.Time, formID string) *gorm.DB {
+	query := s.db.GetDB().WithContext(ctx).Model(&model.FormSubmission{}).
+		Where("submitted_at < ?", before)
+	if formID != "" {
+		query = query.Where("form_id = ?", formID)
+	}
+
+	return query
+}
+
+// CountOlderThan counts submissions made before the given time, including soft-deleted rows
+func (s *Store) CountOlderThan(ctx context.Context, before time.Time, formID string) (int64, error) {
+	var count int64
+	if err := s.olderThanQuery(ctx, before, formID).Unscoped().Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count old form submissions: %w", err)
+	}
+
+	return count, nil
+}
+
+// PurgeOlderThan permanently deletes submissions made before the given time
+func (s *Store) PurgeOlderThan(ctx context.Context, before time.Time, formID string) (int64, error) {
+	result := s.olderThanQuery(ctx, before, formID).Unscoped().Delete(&model.FormSubmission{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to purge form submissions: %w", result.Error)
+	}
+
+	return result.RowsAffected, nil
+}
+
 // GetByFormIDAndUserID retrieves a specific submission by form ID and user ID
 func (s *Store) GetByFormIDAndUserID(
 	ctx context.Context,
@@ -0,0 +1,79 @@
+// Package repository provides the form template repository implementation
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"github.com/goformx/goforms/internal/domain/form/template"
+	"github.com/goformx/goforms/internal/infrastructure/database"
+	"github.com/goformx/goforms/internal/infrastructure/logging"
+	"github.com/goformx/goforms/internal/infrastructure/repository/common"
+)
+
+// Store implements template.Repository for user-saved templates
+type Store struct {
+	db     database.DB
+	logger logging.Logger
+}
+
+// NewStore creates a new form template store
+func NewStore(db database.DB, logger logging.Logger) template.Repository {
+	return &Store{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Create stores a new template
+func (s *Store) Create(ctx context.Context, t *template.Template) error {
+	if err := s.db.GetDB().WithContext(ctx).Create(t).Error; err != nil {
+		return fmt.Errorf("failed to create form template: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a template by ID
+func (s *Store) GetByID(ctx context.Context, id string) (*template.Template, error) {
+	var t template.Template
+	if err := s.db.GetDB().WithContext(ctx).Where("uuid = ?", id).First(&t).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, common.NewNotFoundError("get", "form template", id)
+		}
+
+		return nil, fmt.Errorf("failed to get form template: %w", err)
+	}
+
+	return &t, nil
+}
+
+// ListByUser retrieves the templates saved by a user, newest first
+func (s *Store) ListByUser(ctx context.Context, userID string) ([]*template.Template, error) {
+	var templates []*template.Template
+	if err := s.db.GetDB().WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&templates).Error; err != nil {
+		return nil, fmt.Errorf("failed to list form templates: %w", err)
+	}
+
+	return templates, nil
+}
+
+// Delete removes a template
+func (s *Store) Delete(ctx context.Context, id string) error {
+	result := s.db.GetDB().WithContext(ctx).Where("uuid = ?", id).Delete(&template.Template{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete form template: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return common.NewNotFoundError("delete", "form template", id)
+	}
+
+	return nil
+}
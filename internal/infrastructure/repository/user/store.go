@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"time"
 
 	"gorm.io/gorm"
 
@@ -126,6 +127,28 @@ func (s *Store) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
+// RevokeSessions revokes the sessions started up to at by one user, or by every
+// user when userID is empty
+func (s *Store) RevokeSessions(ctx context.Context, userID string, at time.Time) (int64, error) {
+	query := s.db.GetDB().WithContext(ctx).Model(&entities.User{})
+	if userID != "" {
+		query = query.Where("uuid = ?", userID)
+	} else {
+		query = query.Session(&gorm.Session{AllowGlobalUpdate: true})
+	}
+
+	result := query.Update("sessions_revoked_at", at)
+	if result.Error != nil {
+		return 0, fmt.Errorf("revoke sessions: %w", common.NewDatabaseError("revoke_sessions", "user", userID, result.Error))
+	}
+
+	if userID != "" && result.RowsAffected == 0 {
+		return 0, fmt.Errorf("revoke sessions: %w", common.NewNotFoundError("revoke_sessions", "user", userID))
+	}
+
+	return result.RowsAffected, nil
+}
+
 // List returns all users
 func (s *Store) List(ctx context.Context, offset, limit int) ([]*entities.User, error) {
 	var users []*entities.User
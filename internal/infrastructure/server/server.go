@@ -32,6 +32,8 @@ type Server struct {
 	logger logging.Logger
 	config *config.Config
 	server *http.Server
+	// onShutdown runs when the server begins shutting down
+	onShutdown []func()
 }
 
 // URL returns the server's full HTTP URL
@@ -53,6 +55,10 @@ func (s *Server) Start() error {
 		ReadHeaderTimeout: s.config.App.ReadTimeout,
 	}
 
+	for _, f := range s.onShutdown {
+		s.server.RegisterOnShutdown(f)
+	}
+
 	// Create channels for server startup coordination
 	started := make(chan struct{})
 	errored := make(chan error, 1)
@@ -96,6 +102,13 @@ func (s *Server) Start() error {
 	}
 }
 
+// RegisterOnShutdown registers a function to call when the server begins
+// shutting down, for example to end long-lived responses that would otherwise
+// hold up the shutdown. It must be called before Start.
+func (s *Server) RegisterOnShutdown(f func()) {
+	s.onShutdown = append(s.onShutdown, f)
+}
+
 // Deps contains the dependencies for creating a server
 type Deps struct {
 	fx.In
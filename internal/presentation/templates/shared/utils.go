@@ -3,6 +3,8 @@
 package shared
 
 import (
+	"encoding/json"
+	"fmt"
 	"strings"
 
 	"github.com/goformx/goforms/internal/domain/form/model"
@@ -42,3 +44,23 @@ func GetCorsOriginsString(corsOrigins model.JSON) string {
 
 	return ""
 }
+
+// FormatFieldValue formats a submitted field value for display. Missing values
+// are shown as an em dash and structured values as compact JSON.
+func FormatFieldValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "—"
+	case string:
+		return v
+	case bool, float64, int, int64:
+		return fmt.Sprint(v)
+	default:
+		formatted, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+
+		return string(formatted)
+	}
+}
@@ -0,0 +1,41 @@
+package view
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"strings"
+)
+
+// EmbedCode holds the public link and the snippets used to embed a form on another site
+type EmbedCode struct {
+	URL    string `json:"url"`
+	Script string `json:"script"`
+	IFrame string `json:"iframe"`
+}
+
+// PublicFormURL returns the hosted page URL for a form
+func PublicFormURL(baseURL, formID string) string {
+	return strings.TrimSuffix(baseURL, "/") + "/f/" + formID
+}
+
+// PublicFormResumeURL returns the hosted page URL that resumes a saved draft
+func PublicFormResumeURL(baseURL, formID, token string) string {
+	return PublicFormURL(baseURL, formID) + "?resume=" + url.QueryEscape(token)
+}
+
+// NewEmbedCode builds the public link and embed snippets for a form. The script
+// snippet resizes the iframe to fit the form; the plain iframe has a fixed height.
+func NewEmbedCode(baseURL, formID string) EmbedCode {
+	formURL := PublicFormURL(baseURL, formID)
+	escaped := html.EscapeString(formURL)
+
+	return EmbedCode{
+		URL:    formURL,
+		Script: fmt.Sprintf(`<script src="%s/embed.js" async></script>`, escaped),
+		IFrame: fmt.Sprintf(
+			`<iframe src="%s?embed=1" title="Form" width="100%%" height="600" style="border:0"></iframe>`,
+			escaped,
+		),
+	}
+}
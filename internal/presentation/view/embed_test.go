@@ -0,0 +1,23 @@
+package view_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/goformx/goforms/internal/presentation/view"
+)
+
+func TestNewEmbedCode(t *testing.T) {
+	code := view.NewEmbedCode("https://forms.example.com/", "abc-123")
+
+	assert.Equal(t, "https://forms.example.com/f/abc-123", code.URL)
+	assert.Equal(t, `<script src="https://forms.example.com/f/abc-123/embed.js" async></script>`, code.Script)
+	assert.Contains(t, code.IFrame, `src="https://forms.example.com/f/abc-123?embed=1"`)
+}
+
+func TestPublicFormResumeURL(t *testing.T) {
+	assert.Equal(t,
+		"https://forms.example.com/f/abc-123?resume=a-b_c%2Bd",
+		view.PublicFormResumeURL("https://forms.example.com", "abc-123", "a-b_c+d"))
+}
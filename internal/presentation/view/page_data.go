@@ -2,14 +2,19 @@
 package view
 
 import (
+	"net/url"
+
 	"github.com/a-h/templ"
 	"github.com/labstack/echo/v4"
 
+	"github.com/goformx/goforms/internal/application/constants"
 	"github.com/goformx/goforms/internal/application/middleware/context"
+	"github.com/goformx/goforms/internal/application/middleware/locale"
 	"github.com/goformx/goforms/internal/application/middleware/session"
 	"github.com/goformx/goforms/internal/domain/entities"
 	"github.com/goformx/goforms/internal/domain/form/model"
 	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/i18n"
 	"github.com/goformx/goforms/internal/infrastructure/version"
 	"github.com/goformx/goforms/internal/infrastructure/web"
 )
@@ -37,6 +42,19 @@ type PageData struct {
 	Message              *Message
 	Config               *config.Config
 	Session              *session.Session
+	// Locale is the language the page is rendered in
+	Locale string
+	// Path is the path and query of the current request, returned to after
+	// switching language
+	Path      string
+	localizer i18n.Localizer
+}
+
+// Language is an entry of the language switcher
+type Language struct {
+	Locale  string
+	Name    string
+	Current bool
 }
 
 // Message represents a user-facing message
@@ -109,8 +127,31 @@ func GenerateAssetPath(manager web.AssetManagerInterface) func(string) string {
 	}
 }
 
+// GetPath returns the path and query of the current request, without the
+// lang parameter so that it does not override a language picked later
+func GetPath(c echo.Context) string {
+	if c == nil || c.Request() == nil {
+		return constants.PathHome
+	}
+
+	u := *c.Request().URL
+	query := u.Query()
+
+	if query.Has(locale.QueryParam) {
+		query.Del(locale.QueryParam)
+		u.RawQuery = query.Encode()
+	}
+
+	return u.RequestURI()
+}
+
 // NewPageData creates a new PageData instance with essential data
 func NewPageData(cfg *config.Config, manager web.AssetManagerInterface, c echo.Context, title string) *PageData {
+	selected := i18n.DefaultLocale
+	if c != nil {
+		selected = locale.Get(c)
+	}
+
 	return &PageData{
 		Title:         title,
 		Description:   "",
@@ -131,9 +172,43 @@ func NewPageData(cfg *config.Config, manager web.AssetManagerInterface, c echo.C
 		Message:       nil,
 		Config:        cfg,
 		Session:       GetSession(c),
+		Locale:        selected,
+		Path:          GetPath(c),
+		localizer:     i18n.For(selected),
 	}
 }
 
+// T translates a message into the page's language. See i18n.Localizer.T.
+func (p *PageData) T(key string, args ...any) string {
+	return p.localizer.T(key, args...)
+}
+
+// Messages returns the page's messages whose ID starts with prefix, for
+// client-side code. See i18n.Catalog.Messages.
+func (p *PageData) Messages(prefix string) map[string]string {
+	return i18n.Default().Messages(p.localizer.Locale(), prefix)
+}
+
+// Languages returns the entries of the language switcher
+func (p *PageData) Languages() []Language {
+	languages := make([]Language, 0, len(i18n.Supported))
+	for _, l := range i18n.Supported {
+		languages = append(languages, Language{
+			Locale:  l,
+			Name:    i18n.For(l).T("language.name"),
+			Current: l == p.Locale,
+		})
+	}
+
+	return languages
+}
+
+// LanguageURL returns the link that switches the interface to locale and
+// comes back to the current page
+func (p *PageData) LanguageURL(l string) string {
+	return constants.PathLocale + "/" + url.PathEscape(l) + "?redirect=" + url.QueryEscape(p.Path)
+}
+
 // WithTitle sets the page title
 func (p *PageData) WithTitle(title string) *PageData {
 	p.Title = title
@@ -12,8 +12,10 @@ import (
 	"gorm.io/gorm"
 
 	contextmw "github.com/goformx/goforms/internal/application/middleware/context"
+	"github.com/goformx/goforms/internal/application/middleware/locale"
 	"github.com/goformx/goforms/internal/domain/entities"
 	"github.com/goformx/goforms/internal/infrastructure/config"
+	"github.com/goformx/goforms/internal/infrastructure/i18n"
 	"github.com/goformx/goforms/internal/presentation/view"
 	webmocks "github.com/goformx/goforms/test/mocks/web"
 )
@@ -645,3 +647,31 @@ func TestGetMessageClass(t *testing.T) {
 func TestPageData_Construction(_ *testing.T) {
 	_ = &view.PageData{}
 }
+
+func TestPageData_Localization(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockManager := webmocks.NewMockAssetManagerInterface(ctrl)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/f/form-1?embed=1&lang=fr", http.NoBody)
+	c := e.NewContext(req, httptest.NewRecorder())
+
+	err := locale.Middleware()(func(c echo.Context) error {
+		pageData := view.NewPageData(&config.Config{}, mockManager, c, "Form")
+
+		assert.Equal(t, i18n.French, pageData.Locale)
+		assert.Equal(t, "Merci !", pageData.T("public_form.thanks_title"))
+		assert.Equal(t, "/f/form-1?embed=1", pageData.Path)
+		assert.Equal(t, "/locale/en?redirect=%2Ff%2Fform-1%3Fembed%3D1", pageData.LanguageURL(i18n.English))
+		assert.Equal(t, []view.Language{
+			{Locale: i18n.English, Name: "English", Current: false},
+			{Locale: i18n.French, Name: "Français", Current: true},
+		}, pageData.Languages())
+
+		return nil
+	})(c)
+	assert.NoError(t, err)
+
+	var zero view.PageData
+	assert.Equal(t, "Thank you!", zero.T("public_form.thanks_title"))
+}
@@ -137,9 +137,14 @@ func setupLifecycle(params appParams) {
 }
 
 // main is the entry point of the application.
-// It initializes the dependency injection container, starts the application,
+// It runs a CLI subcommand when one is given; otherwise it initializes the
+// dependency injection container, starts the application,
 // and handles graceful shutdown on termination signals.
 func main() {
+	if isCommand(os.Args[1:]) {
+		os.Exit(runCommand(os.Args[1:]))
+	}
+
 	// Initialize the fx application container with all required modules and providers
 	app := fx.New(
 		// Provide embedded filesystem
@@ -0,0 +1,11 @@
+// Package migrations embeds the SQL migration sets so the application binary
+// can upgrade its own database schema without external tooling.
+package migrations
+
+import "embed"
+
+// FS holds the PostgreSQL and MariaDB migration sets. Files follow the
+// golang-migrate naming convention: <version>_<name>.(up|down).sql
+//
+//go:embed postgresql/*.sql mariadb/*.sql
+var FS embed.FS
@@ -0,0 +1,5 @@
+-- Drop index
+DROP INDEX IF EXISTS idx_form_templates_user_id ON form_templates;
+
+-- Drop table
+DROP TABLE IF EXISTS form_templates;
\ No newline at end of file
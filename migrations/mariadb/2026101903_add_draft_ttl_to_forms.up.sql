@@ -0,0 +1,3 @@
+-- Add how long draft submissions can be resumed, in hours
+ALTER TABLE forms
+ADD COLUMN draft_ttl_hours INTEGER NOT NULL DEFAULT 168;
@@ -0,0 +1,7 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_form_drafts_expires_at ON form_drafts;
+DROP INDEX IF EXISTS idx_form_drafts_form_id ON form_drafts;
+DROP INDEX IF EXISTS idx_form_drafts_token_hash ON form_drafts;
+
+-- Drop table
+DROP TABLE IF EXISTS form_drafts;
@@ -0,0 +1,16 @@
+-- Create form_drafts table for partially completed submissions that can be resumed
+CREATE TABLE IF NOT EXISTS form_drafts (
+    uuid VARCHAR(36) PRIMARY KEY,
+    form_id VARCHAR(36) NOT NULL,
+    token_hash VARCHAR(64) NOT NULL,
+    data JSON NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (form_id) REFERENCES forms (uuid) ON DELETE CASCADE
+);
+
+-- Create indexes for resume token lookups and expiry purges
+CREATE UNIQUE INDEX IF NOT EXISTS idx_form_drafts_token_hash ON form_drafts (token_hash);
+CREATE INDEX IF NOT EXISTS idx_form_drafts_form_id ON form_drafts (form_id);
+CREATE INDEX IF NOT EXISTS idx_form_drafts_expires_at ON form_drafts (expires_at);
@@ -0,0 +1,17 @@
+-- Create form_submission_revisions table for the edit history of submissions
+CREATE TABLE IF NOT EXISTS form_submission_revisions (
+    uuid VARCHAR(36) PRIMARY KEY,
+    submission_id VARCHAR(36) NOT NULL,
+    number INT NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    editor_id VARCHAR(36),
+    restored_from INT NOT NULL DEFAULT 0,
+    changes JSON,
+    data JSON NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (submission_id) REFERENCES form_submissions (uuid) ON DELETE CASCADE
+);
+
+-- Revision numbers are unique per submission
+CREATE UNIQUE INDEX IF NOT EXISTS idx_form_submission_revisions_number
+    ON form_submission_revisions (submission_id, number);
@@ -0,0 +1,9 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_audit_records_target ON audit_records;
+DROP INDEX IF EXISTS idx_audit_records_action ON audit_records;
+DROP INDEX IF EXISTS idx_audit_records_actor_id ON audit_records;
+DROP INDEX IF EXISTS idx_audit_records_owner_id ON audit_records;
+DROP INDEX IF EXISTS idx_audit_records_created_at ON audit_records;
+
+-- Drop table
+DROP TABLE IF EXISTS audit_records;
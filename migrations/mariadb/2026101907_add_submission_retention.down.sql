@@ -0,0 +1,9 @@
+-- Remove submission retention rules
+ALTER TABLE form_submissions
+DROP COLUMN anonymized_at;
+
+ALTER TABLE forms
+DROP COLUMN retention_action;
+
+ALTER TABLE forms
+DROP COLUMN retention_days;
@@ -0,0 +1,3 @@
+-- Add the preferred interface language of each user
+ALTER TABLE users
+ADD COLUMN locale VARCHAR(10) NOT NULL DEFAULT '';
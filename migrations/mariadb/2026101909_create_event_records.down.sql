@@ -0,0 +1,8 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_event_records_recorded_at ON event_records;
+DROP INDEX IF EXISTS idx_event_records_stream ON event_records;
+DROP INDEX IF EXISTS idx_event_records_name ON event_records;
+
+-- Drop tables
+DROP TABLE IF EXISTS event_checkpoints;
+DROP TABLE IF EXISTS event_records;
@@ -0,0 +1,25 @@
+-- Create event_records table for the append-only domain event log. The
+-- sequence column is the global order in which events were recorded.
+CREATE TABLE IF NOT EXISTS event_records (
+    sequence BIGINT AUTO_INCREMENT PRIMARY KEY,
+    uuid VARCHAR(36) NOT NULL UNIQUE,
+    name VARCHAR(64) NOT NULL,
+    stream_type VARCHAR(32),
+    stream_id VARCHAR(36),
+    payload JSON,
+    metadata JSON,
+    occurred_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    recorded_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes for reading one event type or one form's or submission's stream
+CREATE INDEX IF NOT EXISTS idx_event_records_name ON event_records (name, sequence);
+CREATE INDEX IF NOT EXISTS idx_event_records_stream ON event_records (stream_type, stream_id, sequence);
+CREATE INDEX IF NOT EXISTS idx_event_records_recorded_at ON event_records (recorded_at);
+
+-- Create event_checkpoints table for the progress of named log consumers
+CREATE TABLE IF NOT EXISTS event_checkpoints (
+    consumer VARCHAR(128) PRIMARY KEY,
+    sequence BIGINT NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
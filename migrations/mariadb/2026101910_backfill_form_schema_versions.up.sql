@@ -0,0 +1,8 @@
+-- Store the current schema of existing forms as their first schema version
+INSERT INTO form_schemas (uuid, form_id, schema, version, created_at)
+SELECT UUID(), f.uuid, f.schema, 1, f.updated_at
+FROM forms f
+WHERE NOT EXISTS (SELECT 1 FROM form_schemas s WHERE s.form_id = f.uuid);
+
+-- Version numbers are unique per form
+CREATE UNIQUE INDEX IF NOT EXISTS idx_form_schemas_version ON form_schemas (form_id, version);
@@ -0,0 +1,3 @@
+-- Remove the time before which every session of a user is revoked
+ALTER TABLE users
+DROP COLUMN sessions_revoked_at;
@@ -0,0 +1,6 @@
+-- Add the failed login count and lockout time of each user
+ALTER TABLE users
+ADD COLUMN failed_login_attempts INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE users
+ADD COLUMN locked_until TIMESTAMP(6) NULL;
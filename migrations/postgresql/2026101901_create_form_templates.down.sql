@@ -0,0 +1,5 @@
+-- Drop index
+DROP INDEX IF EXISTS idx_form_templates_user_id;
+
+-- Drop table
+DROP TABLE IF EXISTS form_templates;
@@ -0,0 +1,17 @@
+-- Create form_templates table for user-saved form templates
+CREATE TABLE IF NOT EXISTS form_templates (
+    uuid VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    description VARCHAR(500),
+    category VARCHAR(50) NOT NULL DEFAULT 'custom',
+    preview TEXT,
+    schema JSON NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    FOREIGN KEY (user_id) REFERENCES users (uuid) ON DELETE CASCADE
+);
+
+-- Create index on user_id
+CREATE INDEX IF NOT EXISTS idx_form_templates_user_id ON form_templates (user_id);
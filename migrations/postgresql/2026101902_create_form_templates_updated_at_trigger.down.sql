@@ -0,0 +1 @@
+DROP TRIGGER IF EXISTS update_form_templates_updated_at ON form_templates;
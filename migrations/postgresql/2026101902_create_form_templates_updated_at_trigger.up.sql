@@ -0,0 +1,5 @@
+-- Create trigger to automatically update updated_at
+CREATE TRIGGER update_form_templates_updated_at
+    BEFORE UPDATE ON form_templates
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
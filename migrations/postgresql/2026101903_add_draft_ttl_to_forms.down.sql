@@ -0,0 +1,3 @@
+-- Remove draft expiry from forms table
+ALTER TABLE forms
+DROP COLUMN draft_ttl_hours;
@@ -0,0 +1,7 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_form_drafts_expires_at;
+DROP INDEX IF EXISTS idx_form_drafts_form_id;
+DROP INDEX IF EXISTS idx_form_drafts_token_hash;
+
+-- Drop table
+DROP TABLE IF EXISTS form_drafts;
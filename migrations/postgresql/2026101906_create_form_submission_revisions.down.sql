@@ -0,0 +1,5 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_form_submission_revisions_number;
+
+-- Drop table
+DROP TABLE IF EXISTS form_submission_revisions;
@@ -0,0 +1,9 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_audit_records_target;
+DROP INDEX IF EXISTS idx_audit_records_action;
+DROP INDEX IF EXISTS idx_audit_records_actor_id;
+DROP INDEX IF EXISTS idx_audit_records_owner_id;
+DROP INDEX IF EXISTS idx_audit_records_created_at;
+
+-- Drop table
+DROP TABLE IF EXISTS audit_records;
@@ -0,0 +1,23 @@
+-- Create audit_records table for the append-only audit log
+CREATE TABLE IF NOT EXISTS audit_records (
+    uuid VARCHAR(36) PRIMARY KEY,
+    action VARCHAR(64) NOT NULL,
+    actor_id VARCHAR(36),
+    actor_email VARCHAR(255),
+    ip VARCHAR(45),
+    user_agent VARCHAR(512),
+    request_id VARCHAR(64),
+    target_type VARCHAR(32),
+    target_id VARCHAR(36),
+    owner_id VARCHAR(36),
+    before_state JSON,
+    after_state JSON,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes for the audit log viewer filters
+CREATE INDEX IF NOT EXISTS idx_audit_records_created_at ON audit_records (created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_records_owner_id ON audit_records (owner_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_records_actor_id ON audit_records (actor_id);
+CREATE INDEX IF NOT EXISTS idx_audit_records_action ON audit_records (action);
+CREATE INDEX IF NOT EXISTS idx_audit_records_target ON audit_records (target_type, target_id);
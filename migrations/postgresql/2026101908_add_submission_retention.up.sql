@@ -0,0 +1,10 @@
+-- Add per-form retention rules for submissions
+ALTER TABLE forms
+ADD COLUMN retention_days INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE forms
+ADD COLUMN retention_action VARCHAR(20) NOT NULL DEFAULT 'delete';
+
+-- Track submissions whose answers were cleared by a retention rule
+ALTER TABLE form_submissions
+ADD COLUMN anonymized_at TIMESTAMP NULL;
@@ -0,0 +1,3 @@
+-- Remove the preferred interface language of each user
+ALTER TABLE users
+DROP COLUMN locale;
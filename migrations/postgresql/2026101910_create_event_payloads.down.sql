@@ -0,0 +1,5 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_event_payloads_created_at;
+
+-- Drop table
+DROP TABLE IF EXISTS event_payloads;
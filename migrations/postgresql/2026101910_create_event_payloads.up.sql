@@ -0,0 +1,11 @@
+-- Create event_payloads table for event envelopes too large for a NOTIFY payload.
+-- Rows are read by the other instances right after the notification and
+-- removed once they expire.
+CREATE TABLE IF NOT EXISTS event_payloads (
+    id UUID PRIMARY KEY,
+    envelope TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create index for removing expired payloads
+CREATE INDEX IF NOT EXISTS idx_event_payloads_created_at ON event_payloads (created_at);
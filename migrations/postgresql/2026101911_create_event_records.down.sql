@@ -0,0 +1,3 @@
+-- Drop tables
+DROP TABLE IF EXISTS event_checkpoints;
+DROP TABLE IF EXISTS event_records;
@@ -0,0 +1,4 @@
+-- Remove stored schema versions
+DROP INDEX IF EXISTS idx_form_schemas_version;
+
+DELETE FROM form_schemas;
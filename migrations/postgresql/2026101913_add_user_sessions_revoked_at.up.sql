@@ -0,0 +1,3 @@
+-- Add the time before which every session of a user is revoked
+ALTER TABLE users
+ADD COLUMN sessions_revoked_at TIMESTAMP NULL;
@@ -0,0 +1,6 @@
+-- Remove the failed login count and lockout time of each user
+ALTER TABLE users
+DROP COLUMN locked_until;
+
+ALTER TABLE users
+DROP COLUMN failed_login_attempts;
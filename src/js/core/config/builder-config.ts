@@ -305,6 +305,27 @@ const createBuilderSections = (customFields = createUserFields()) => ({
   },
 });
 
+// Adds a "Sensitive" checkbox to the Data tab of input components. Answers to
+// sensitive fields are encrypted at rest and kept out of logs and search.
+const sensitiveFieldSettings = {
+  key: "data",
+  components: [
+    {
+      type: "checkbox",
+      key: "sensitive",
+      label: "Sensitive",
+      tooltip:
+        "Encrypt answers to this field at rest. Only you can see them, in the submission views.",
+      input: true,
+      weight: 1000,
+    },
+  ],
+};
+
+const sensitiveEditForms = Object.fromEntries(
+  Object.values(FieldType).map((type) => [type, [sensitiveFieldSettings]]),
+);
+
 // Enhanced builder options with better defaults
 export const createBuilderOptions = (
   customFields?: ReturnType<typeof createUserFields>,
@@ -314,11 +335,13 @@ export const createBuilderOptions = (
   builder: createBuilderSections(customFields),
 
   editForm: {
+    ...sensitiveEditForms,
     textfield: [
       {
         key: "api",
         ignore: true,
       },
+      sensitiveFieldSettings,
     ],
   },
 
@@ -168,6 +168,11 @@ export class HttpClient {
       headers.set("Accept", "application/json");
       headers.set("X-Requested-With", "XMLHttpRequest");
 
+      // Ask for messages in the language the page was rendered in
+      if (document.documentElement.lang && !headers.has("Accept-Language")) {
+        headers.set("Accept-Language", document.documentElement.lang);
+      }
+
       Logger.log("Headers:", Object.fromEntries(headers.entries()));
       Logger.log("Cookies:", document.cookie);
 
@@ -270,6 +275,27 @@ export class HttpClient {
     });
   }
 
+  static async patch<T = unknown>(
+    url: string,
+    body?: FormData | string | object,
+    options: HttpRequestOptions = {},
+  ): Promise<HttpResponse<T>> {
+    let requestBody: FormData | string | null = null;
+
+    // Convert objects to JSON string
+    if (body && typeof body === "object" && !(body instanceof FormData)) {
+      requestBody = JSON.stringify(body);
+    } else if (body) {
+      requestBody = body as FormData | string;
+    }
+
+    return this.makeRequest<T>(url, {
+      ...options,
+      method: "PATCH",
+      body: requestBody,
+    });
+  }
+
   static async delete<T = unknown>(
     url: string,
     options: HttpRequestOptions = {},
@@ -305,6 +331,14 @@ export class HttpClient {
         return super.put<T>(url, body, { ...defaultOptions, ...options });
       }
 
+      static override async patch<T = unknown>(
+        url: string,
+        body?: FormData | string | object,
+        options: HttpRequestOptions = {},
+      ): Promise<HttpResponse<T>> {
+        return super.patch<T>(url, body, { ...defaultOptions, ...options });
+      }
+
       static override async delete<T = unknown>(
         url: string,
         options: HttpRequestOptions = {},
@@ -2,7 +2,31 @@ import { Logger } from "@/core/logger";
 import { FormBuilderError } from "@/core/errors/form-builder-error";
 import { HttpClient } from "@/core/http-client";
 import DOMPurify from "dompurify";
-import type { FormSchema } from "@/shared/types/form-types";
+import type {
+  FormDraft,
+  FormSchema,
+  SubmissionRevision,
+} from "@/shared/types/form-types";
+
+interface RevisionResponse {
+  data?: {
+    number: number;
+    action: SubmissionRevision["action"];
+    editor_id: string;
+    restored_from?: number;
+    changes: { field: string }[] | null;
+    created_at: string;
+  };
+}
+
+interface DraftResponse {
+  data?: {
+    resume_token: string;
+    resume_url: string;
+    expires_at: string;
+    data: Record<string, unknown>;
+  };
+}
 
 /**
  * Handles all HTTP operations for forms
@@ -198,6 +222,172 @@ export class FormApiService {
     }
   }
 
+  /**
+   * Save a partially completed submission. Without a token a new draft is
+   * started; with one the data is merged into the existing draft.
+   */
+  async saveDraft(
+    formId: string,
+    data: Record<string, unknown>,
+    token?: string,
+  ): Promise<FormDraft> {
+    try {
+      const response = await HttpClient.patch<DraftResponse>(
+        this.draftUrl(formId, token),
+        this.sanitizeFormData(data) as object,
+      );
+
+      return this.toDraft(response.data, formId);
+    } catch (error) {
+      if (error instanceof FormBuilderError) {
+        throw error;
+      }
+      throw FormBuilderError.saveFailed(
+        "Failed to save draft",
+        formId,
+        error instanceof Error ? error : undefined,
+      );
+    }
+  }
+
+  /**
+   * Load a saved draft by its resume token
+   */
+  async getDraft(formId: string, token: string): Promise<FormDraft> {
+    const response = await HttpClient.get<DraftResponse>(
+      this.draftUrl(formId, token),
+    );
+
+    return this.toDraft(response.data, formId);
+  }
+
+  /**
+   * Submit a draft together with the answers not yet saved to it
+   */
+  async submitDraft(
+    formId: string,
+    token: string,
+    data: Record<string, unknown>,
+  ): Promise<void> {
+    try {
+      await HttpClient.post(
+        `${this.draftUrl(formId, token)}/submit`,
+        this.sanitizeFormData(data) as object,
+      );
+    } catch (error) {
+      if (error instanceof FormBuilderError) {
+        throw error;
+      }
+      throw FormBuilderError.saveFailed(
+        "Failed to submit draft",
+        formId,
+        error instanceof Error ? error : undefined,
+      );
+    }
+  }
+
+  private draftUrl(formId: string, token?: string): string {
+    const url = `${this.baseUrl}/api/v1/forms/${formId}/draft`;
+    return token ? `${url}/${encodeURIComponent(token)}` : url;
+  }
+
+  private toDraft(response: DraftResponse, formId: string): FormDraft {
+    const draft = response.data;
+    if (!draft?.resume_token) {
+      throw FormBuilderError.loadFailed(
+        "Draft response did not include a resume token",
+        formId,
+      );
+    }
+
+    return {
+      token: draft.resume_token,
+      resumeUrl: draft.resume_url,
+      expiresAt: draft.expires_at,
+      data: draft.data ?? {},
+    };
+  }
+
+  /**
+   * Replace a submission's data. The previous data is kept in the
+   * submission's history.
+   */
+  async updateSubmission(
+    formId: string,
+    submissionId: string,
+    data: Record<string, unknown>,
+  ): Promise<SubmissionRevision> {
+    try {
+      const response = await HttpClient.put<RevisionResponse>(
+        this.submissionUrl(formId, submissionId),
+        this.sanitizeFormData(data) as object,
+      );
+
+      return this.toRevision(response.data, formId);
+    } catch (error) {
+      if (error instanceof FormBuilderError) {
+        throw error;
+      }
+      throw FormBuilderError.saveFailed(
+        "Failed to update submission",
+        formId,
+        error instanceof Error ? error : undefined,
+      );
+    }
+  }
+
+  /**
+   * Restore a submission to an earlier revision
+   */
+  async restoreSubmissionRevision(
+    formId: string,
+    submissionId: string,
+    revision: number,
+  ): Promise<SubmissionRevision> {
+    try {
+      const response = await HttpClient.post<RevisionResponse>(
+        `${this.submissionUrl(formId, submissionId)}/revisions/${revision}/restore`,
+      );
+
+      return this.toRevision(response.data, formId);
+    } catch (error) {
+      if (error instanceof FormBuilderError) {
+        throw error;
+      }
+      throw FormBuilderError.saveFailed(
+        "Failed to restore revision",
+        formId,
+        error instanceof Error ? error : undefined,
+      );
+    }
+  }
+
+  private submissionUrl(formId: string, submissionId: string): string {
+    return `${this.baseUrl}/api/v1/forms/${formId}/submissions/${encodeURIComponent(submissionId)}`;
+  }
+
+  private toRevision(
+    response: RevisionResponse,
+    formId: string,
+  ): SubmissionRevision {
+    const revision = response.data;
+    if (!revision) {
+      throw FormBuilderError.loadFailed(
+        "Revision response did not include a revision",
+        formId,
+      );
+    }
+
+    return {
+      number: revision.number,
+      action: revision.action,
+      editorId: revision.editor_id,
+      restoredFrom: revision.restored_from,
+      fields: (revision.changes ?? []).map((change) => change.field),
+      createdAt: revision.created_at,
+    };
+  }
+
   // =============================================
   // CREATE OPERATIONS
   // =============================================
@@ -208,6 +398,7 @@ export class FormApiService {
   public async createForm(data: {
     title: string;
     description?: string;
+    templateId?: string;
   }): Promise<{ formId: string }> {
     try {
       // Create FormData for form submission
@@ -216,6 +407,9 @@ export class FormApiService {
       if (data.description) {
         formData.append("description", data.description);
       }
+      if (data.templateId) {
+        formData.append("template_id", data.templateId);
+      }
 
       const response = await HttpClient.post<{ form_id: string }>(
         `${this.baseUrl}/forms`,
@@ -235,6 +429,57 @@ export class FormApiService {
     }
   }
 
+  /**
+   * Duplicate an existing form into a new draft
+   */
+  public async duplicateForm(formId: string): Promise<{ formId: string }> {
+    try {
+      const response = await HttpClient.post<{ data?: { form_id: string } }>(
+        `${this.baseUrl}/api/v1/forms/${formId}/duplicate`,
+      );
+
+      const newFormId = response.data.data?.form_id;
+      if (!newFormId) {
+        throw FormBuilderError.saveFailed(
+          "Duplicate response did not include a form ID",
+          formId,
+        );
+      }
+
+      return { formId: newFormId };
+    } catch (error) {
+      if (error instanceof FormBuilderError) {
+        throw error;
+      }
+      throw FormBuilderError.saveFailed(
+        "Failed to duplicate form",
+        formId,
+        error instanceof Error ? error : undefined,
+      );
+    }
+  }
+
+  /**
+   * Save an existing form as a reusable template
+   */
+  public async saveFormAsTemplate(
+    formId: string,
+    name: string,
+  ): Promise<void> {
+    try {
+      await HttpClient.post(
+        `${this.baseUrl}/api/v1/forms/${formId}/template`,
+        { name },
+      );
+    } catch (error) {
+      throw FormBuilderError.saveFailed(
+        "Failed to save form as template",
+        formId,
+        error instanceof Error ? error : undefined,
+      );
+    }
+  }
+
   // =============================================
   // READ OPERATIONS
   // =============================================
@@ -1,6 +1,10 @@
 import { Logger } from "@/core/logger";
 import { FormApiService } from "./form-api-service";
-import type { FormSchema } from "@/shared/types/form-types";
+import type {
+  FormDraft,
+  FormSchema,
+  SubmissionRevision,
+} from "@/shared/types/form-types";
 
 /**
  * Main form service that orchestrates all form-related operations
@@ -35,15 +39,23 @@ export class FormService {
   public async createForm(
     title: string,
     description?: string,
+    templateId?: string,
   ): Promise<{ formId: string }> {
     try {
       Logger.group("Form Creation");
-      Logger.debug("Creating new form:", { title, description });
+      Logger.debug("Creating new form:", { title, description, templateId });
 
-      const formData: { title: string; description?: string } = { title };
+      const formData: {
+        title: string;
+        description?: string;
+        templateId?: string;
+      } = { title };
       if (description) {
         formData.description = description;
       }
+      if (templateId) {
+        formData.templateId = templateId;
+      }
       const response = await this.apiService.createForm(formData);
 
       Logger.debug("Form created successfully:", response);
@@ -66,9 +78,14 @@ export class FormService {
   public async createFormWithRedirect(
     title: string,
     description?: string,
+    templateId?: string,
   ): Promise<void> {
     try {
-      const { formId } = await this.createForm(title, description);
+      const { formId } = await this.createForm(
+        title,
+        description,
+        templateId,
+      );
       window.location.href = `/forms/${formId}/edit`;
     } catch (error: unknown) {
       alert(
@@ -158,6 +175,46 @@ export class FormService {
   // DELETE OPERATIONS
   // =============================================
 
+  /**
+   * Duplicate a form and open the copy in the form builder
+   */
+  public async duplicateFormWithRedirect(formId: string): Promise<void> {
+    try {
+      const { formId: newFormId } = await this.apiService.duplicateForm(formId);
+      Logger.debug("Form duplicated:", formId, "->", newFormId);
+      window.location.href = `/forms/${newFormId}/edit`;
+    } catch (error: unknown) {
+      Logger.error("Failed to duplicate form:", error);
+      alert(
+        error instanceof Error
+          ? error.message
+          : "Failed to duplicate form. Please try again.",
+      );
+    }
+  }
+
+  /**
+   * Prompt for a template name and save the form as a template
+   */
+  public async saveFormAsTemplateWithPrompt(formId: string): Promise<void> {
+    const name = prompt("Template name (leave empty to use the form title):");
+    if (name === null) {
+      return;
+    }
+
+    try {
+      await this.apiService.saveFormAsTemplate(formId, name.trim());
+      this.showSuccess("Template saved");
+    } catch (error: unknown) {
+      Logger.error("Failed to save form as template:", error);
+      alert(
+        error instanceof Error
+          ? error.message
+          : "Failed to save template. Please try again.",
+      );
+    }
+  }
+
   /**
    * Basic form deletion (API only)
    */
@@ -222,6 +279,61 @@ export class FormService {
     return this.apiService.submitForm(formId, data);
   }
 
+  /**
+   * Save progress on a form so it can be resumed later
+   */
+  public async saveDraft(
+    formId: string,
+    data: Record<string, unknown>,
+    token?: string,
+  ): Promise<FormDraft> {
+    return this.apiService.saveDraft(formId, data, token);
+  }
+
+  /**
+   * Load a saved draft by its resume token
+   */
+  public async getDraft(formId: string, token: string): Promise<FormDraft> {
+    return this.apiService.getDraft(formId, token);
+  }
+
+  /**
+   * Submit a saved draft with the remaining answers
+   */
+  public async submitDraft(
+    formId: string,
+    token: string,
+    data: Record<string, unknown>,
+  ): Promise<void> {
+    return this.apiService.submitDraft(formId, token, data);
+  }
+
+  /**
+   * Replace a submission's data, recording the change in its history
+   */
+  public async updateSubmission(
+    formId: string,
+    submissionId: string,
+    data: Record<string, unknown>,
+  ): Promise<SubmissionRevision> {
+    return this.apiService.updateSubmission(formId, submissionId, data);
+  }
+
+  /**
+   * Restore a submission to an earlier revision
+   */
+  public async restoreSubmissionRevision(
+    formId: string,
+    submissionId: string,
+    revision: number,
+  ): Promise<SubmissionRevision> {
+    return this.apiService.restoreSubmissionRevision(
+      formId,
+      submissionId,
+      revision,
+    );
+  }
+
   // =============================================
   // UI SERVICE METHODS
   // =============================================
@@ -3,3 +3,4 @@ export * from "./form-api-service";
 export * from "./form-ui-service";
 export * from "./form-service";
 export * from "./form-event-service";
+export * from "./live-submissions";
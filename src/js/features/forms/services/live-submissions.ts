@@ -0,0 +1,81 @@
+import { Logger } from "@/core/logger";
+
+const LIVE_SUBMISSIONS_URL = "/api/v1/live/submissions";
+
+/** Submission totals keyed by form ID */
+export interface LiveCounts {
+  counts: Record<string, number>;
+}
+
+/** A new submission and its form's new total. Answers are never sent. */
+export interface LiveSubmission {
+  form_id: string;
+  id: string;
+  status: string;
+  submitted_at: string;
+  count: number;
+}
+
+/** A submission that passed validation */
+export interface LiveProcessed {
+  form_id: string;
+  submission_id: string;
+}
+
+export interface LiveSubmissionHandlers {
+  onCounts?: (data: LiveCounts) => void;
+  onSubmission?: (data: LiveSubmission) => void;
+  onProcessed?: (data: LiveProcessed) => void;
+}
+
+/**
+ * Follow the signed-in user's submissions, or one form's when formId is set.
+ * The browser reconnects on its own and the server replays what was missed.
+ */
+export function followLiveSubmissions(
+  handlers: LiveSubmissionHandlers,
+  formId?: string,
+): EventSource | null {
+  if (typeof EventSource === "undefined") {
+    return null;
+  }
+
+  const url = formId
+    ? `${LIVE_SUBMISSIONS_URL}?form_id=${encodeURIComponent(formId)}`
+    : LIVE_SUBMISSIONS_URL;
+  const source = new EventSource(url);
+
+  const listen = <T>(event: string, handler?: (data: T) => void) => {
+    if (!handler) {
+      return;
+    }
+    source.addEventListener(event, (message) => {
+      try {
+        handler(JSON.parse((message as MessageEvent<string>).data) as T);
+      } catch (error) {
+        Logger.error(`Failed to handle live ${event} event:`, error);
+      }
+    });
+  };
+
+  listen("counts", handlers.onCounts);
+  listen("submission", handlers.onSubmission);
+  listen("processed", handlers.onProcessed);
+
+  source.addEventListener("error", () => {
+    Logger.debug("Live submissions stream interrupted, reconnecting");
+  });
+
+  // Don't keep the connection open while the page is being left
+  window.addEventListener("pagehide", () => source.close());
+
+  return source;
+}
+
+/** Show a form's submission total in the elements marked data-live-count */
+export function showSubmissionCount(formId: string, count: number): void {
+  const selector = `[data-live-count="${CSS.escape(formId)}"]`;
+  document.querySelectorAll<HTMLElement>(selector).forEach((element) => {
+    element.textContent = String(count);
+  });
+}
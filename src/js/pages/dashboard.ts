@@ -1,11 +1,41 @@
 import { Logger } from "@/core/logger";
 import { FormService } from "@/features/forms/services/form-service";
+import {
+  followLiveSubmissions,
+  showSubmissionCount,
+} from "@/features/forms/services/live-submissions";
 
 // Initialize dashboard functionality
 function initDashboard() {
-  // Handle delete button clicks using event delegation
+  // Handle duplicate, save-as-template and delete button clicks using event delegation
   document.addEventListener("click", (event) => {
     const target = event.target as HTMLElement;
+    const duplicateButton = target.closest("button[data-duplicate-form-id]");
+    if (duplicateButton) {
+      const formId = duplicateButton.getAttribute("data-duplicate-form-id");
+      if (formId) {
+        FormService.getInstance()
+          .duplicateFormWithRedirect(formId)
+          .catch((error) => {
+            Logger.error("Failed to duplicate form:", error);
+          });
+      }
+      return;
+    }
+
+    const templateButton = target.closest("button[data-save-template-form-id]");
+    if (templateButton) {
+      const formId = templateButton.getAttribute("data-save-template-form-id");
+      if (formId) {
+        FormService.getInstance()
+          .saveFormAsTemplateWithPrompt(formId)
+          .catch((error) => {
+            Logger.error("Failed to save form as template:", error);
+          });
+      }
+      return;
+    }
+
     const deleteButton = target.closest("button[data-form-id]");
     if (deleteButton) {
       const formId = deleteButton.getAttribute("data-form-id");
@@ -20,5 +50,24 @@ function initDashboard() {
   });
 }
 
+// Keep the submission counts of the listed forms up to date
+function initLiveCounts() {
+  if (!document.querySelector("[data-live-count]")) {
+    return;
+  }
+
+  followLiveSubmissions({
+    onCounts: ({ counts }) => {
+      Object.entries(counts).forEach(([formId, count]) =>
+        showSubmissionCount(formId, count),
+      );
+    },
+    onSubmission: ({ form_id, count }) => showSubmissionCount(form_id, count),
+  });
+}
+
 // Initialize when the DOM is ready
-document.addEventListener("DOMContentLoaded", initDashboard);
+document.addEventListener("DOMContentLoaded", () => {
+  initDashboard();
+  initLiveCounts();
+});
@@ -0,0 +1,134 @@
+import { Formio } from "@formio/js";
+import goforms from "@goformx/formio";
+import { FormService } from "@/features/forms/services/form-service";
+
+// Import Form.io styles
+import "@formio/js/dist/formio.full.min.css";
+
+import { Logger } from "@/core/logger";
+
+// Register templates
+Formio.use(goforms);
+
+interface SubmissionPageData {
+  formId: string;
+  submissionId: string;
+  schema: any;
+  data: Record<string, unknown>;
+}
+
+/**
+ * Read the submission and its form schema from the editor's data attributes
+ */
+function readPageData(container: HTMLElement): SubmissionPageData {
+  const { formId, submissionId, formSchema, submissionData } =
+    container.dataset;
+  if (!formId || !submissionId || !formSchema) {
+    throw new Error("Submission data not found in data attributes");
+  }
+
+  return {
+    formId,
+    submissionId,
+    schema: JSON.parse(formSchema),
+    data: JSON.parse(submissionData ?? "{}"),
+  };
+}
+
+function showError(message: string): void {
+  const errorEl = document.getElementById("submission-editor-error");
+  if (!errorEl) {
+    return;
+  }
+  errorEl.textContent = message;
+  errorEl.hidden = false;
+}
+
+/**
+ * Render the submission read-only, switching to an editable form on request.
+ * Saving and restoring reload the page so the history is shown up to date.
+ */
+async function initializeSubmissionPage(): Promise<void> {
+  const container = document.getElementById("submission-editor");
+  if (!container) {
+    return;
+  }
+
+  const page = readPageData(container);
+  const formService = FormService.getInstance();
+
+  const render = async (readOnly: boolean) => {
+    container.innerHTML = "";
+    const form = await Formio.createForm(container, page.schema, {
+      readOnly,
+      noAlerts: true,
+      noDefaultSubmitButton: readOnly,
+      submitButton: {
+        label: "Save Changes",
+        className: "btn btn-primary",
+      },
+    });
+    form.submission = { data: page.data };
+    return form;
+  };
+
+  await render(true);
+
+  const editButton = document.getElementById("edit-submission");
+  editButton?.addEventListener("click", async () => {
+    editButton.hidden = true;
+    const form = await render(false);
+
+    form.on("submit", async (submission: any) => {
+      try {
+        await formService.updateSubmission(
+          page.formId,
+          page.submissionId,
+          submission.data,
+        );
+        window.location.reload();
+      } catch (error) {
+        Logger.error("Failed to update submission:", error);
+        showError("The submission could not be saved. Please try again.");
+        form.emit("submitDone");
+      }
+    });
+  });
+
+  document
+    .querySelectorAll<HTMLButtonElement>("[data-restore-revision]")
+    .forEach((button) => {
+      button.addEventListener("click", async () => {
+        const revision = Number(button.dataset.restoreRevision);
+        if (!window.confirm(`Restore revision ${revision}?`)) {
+          return;
+        }
+
+        button.disabled = true;
+        try {
+          await formService.restoreSubmissionRevision(
+            page.formId,
+            page.submissionId,
+            revision,
+          );
+          window.location.reload();
+        } catch (error) {
+          Logger.error("Failed to restore revision:", error);
+          showError("The revision could not be restored. Please try again.");
+          button.disabled = false;
+        }
+      });
+    });
+}
+
+if (document.readyState === "loading") {
+  document.addEventListener("DOMContentLoaded", () => {
+    initializeSubmissionPage().catch((error) =>
+      Logger.error("Failed to initialize submission page:", error),
+    );
+  });
+} else {
+  initializeSubmissionPage().catch((error) =>
+    Logger.error("Failed to initialize submission page:", error),
+  );
+}
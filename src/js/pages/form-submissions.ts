@@ -0,0 +1,74 @@
+import {
+  followLiveSubmissions,
+  showSubmissionCount,
+  type LiveSubmission,
+} from "@/features/forms/services/live-submissions";
+
+/**
+ * Build a table row for a submission that arrived while the page was open
+ */
+function submissionRow(formId: string, submission: LiveSubmission) {
+  const row = document.createElement("tr");
+  row.className = "submission-row--new";
+
+  const submittedAt = document.createElement("td");
+  submittedAt.textContent = new Date(submission.submitted_at).toLocaleString();
+
+  const status = document.createElement("td");
+  const badge = document.createElement("span");
+  badge.className = `status-badge status-badge--${submission.status}`;
+  badge.textContent = submission.status;
+  status.append(badge);
+
+  const actions = document.createElement("td");
+  const view = document.createElement("a");
+  view.href = `/forms/${encodeURIComponent(formId)}/submissions/${encodeURIComponent(submission.id)}`;
+  view.className = "gf-button gf-button--small";
+  view.textContent = "View";
+  actions.append(view);
+
+  row.append(submittedAt, status, actions);
+
+  return row;
+}
+
+function initLiveSubmissions() {
+  const card = document.querySelector<HTMLElement>("[data-live-form-id]");
+  const formId = card?.dataset.liveFormId;
+  if (!card || !formId) {
+    return;
+  }
+
+  const rows = card.querySelector<HTMLElement>("[data-live-rows]");
+  const notice = card.querySelector<HTMLElement>("[data-live-notice]");
+  const noticeText = card.querySelector<HTMLElement>(
+    "[data-live-notice-text]",
+  );
+  let unseen = 0;
+
+  followLiveSubmissions(
+    {
+      onCounts: ({ counts }) =>
+        showSubmissionCount(formId, counts[formId] ?? 0),
+      onSubmission: (submission) => {
+        showSubmissionCount(formId, submission.count);
+
+        if (rows) {
+          rows.prepend(submissionRow(formId, submission));
+          return;
+        }
+
+        // The page shows the empty state, so offer a refresh instead
+        unseen++;
+        if (notice && noticeText) {
+          noticeText.textContent =
+            unseen === 1 ? "1 new submission." : `${unseen} new submissions.`;
+          notice.hidden = false;
+        }
+      },
+    },
+    formId,
+  );
+}
+
+document.addEventListener("DOMContentLoaded", initLiveSubmissions);
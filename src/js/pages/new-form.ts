@@ -38,6 +38,7 @@ document.addEventListener("DOMContentLoaded", () => {
       const formData = new FormData(form);
       const title = formData.get("title") as string;
       const description = (formData.get("description") as string) || "";
+      const templateId = (formData.get("template_id") as string) || "";
 
       // Validate required fields
       if (!title || title.trim() === "") {
@@ -49,7 +50,11 @@ document.addEventListener("DOMContentLoaded", () => {
 
       // Use the form service to create and redirect
       const formService = FormService.getInstance();
-      await formService.createFormWithRedirect(title, description);
+      await formService.createFormWithRedirect(
+        title,
+        description,
+        templateId,
+      );
 
       Logger.debug("new-form.ts: Form creation and redirect completed");
     } catch (error) {
@@ -0,0 +1,265 @@
+import { Formio } from "@formio/js";
+import goforms from "@goformx/formio";
+import { FormService } from "@/features/forms/services/form-service";
+import type { FormDraft } from "@/shared/types/form-types";
+
+// Import Form.io styles
+import "@formio/js/dist/formio.full.min.css";
+
+import { dom } from "@/shared/utils/dom-utils";
+import { Logger } from "@/core/logger";
+
+// Register templates
+Formio.use(goforms);
+
+/**
+ * Messages of the page, translated by the server into its language
+ */
+type Messages = Record<string, string>;
+
+function parseMessages(attr: string | null): Messages {
+  if (!attr) {
+    return {};
+  }
+
+  try {
+    return JSON.parse(attr) as Messages;
+  } catch {
+    return {};
+  }
+}
+
+/**
+ * Report the page height to the embedding site so it can resize the iframe
+ */
+function reportHeight(formId: string): void {
+  window.parent.postMessage(
+    {
+      type: "goforms:resize",
+      formId,
+      height: document.documentElement.scrollHeight,
+    },
+    "*",
+  );
+}
+
+/**
+ * Keep the embedding iframe sized to the page
+ */
+function watchHeight(formId: string): void {
+  if (window.parent === window) {
+    return;
+  }
+
+  const observer = new ResizeObserver(() => reportHeight(formId));
+  observer.observe(document.body);
+  reportHeight(formId);
+}
+
+/**
+ * Local storage key remembering the resume token of a form's draft
+ */
+function draftStorageKey(formId: string): string {
+  return `goforms:draft:${formId}`;
+}
+
+function rememberDraft(formId: string, token: string): void {
+  try {
+    window.localStorage.setItem(draftStorageKey(formId), token);
+  } catch {
+    // Storage may be unavailable, e.g. in a sandboxed iframe
+  }
+}
+
+function forgetDraft(formId: string): void {
+  try {
+    window.localStorage.removeItem(draftStorageKey(formId));
+  } catch {
+    // Storage may be unavailable, e.g. in a sandboxed iframe
+  }
+}
+
+function rememberedDraft(formId: string): string | null {
+  try {
+    return window.localStorage.getItem(draftStorageKey(formId));
+  } catch {
+    return null;
+  }
+}
+
+/**
+ * Load the draft named by the ?resume= link, or the one this browser saved last
+ */
+async function loadDraft(
+  formId: string,
+  messages: Messages,
+): Promise<FormDraft | null> {
+  const fromLink = new URLSearchParams(window.location.search).get("resume");
+  const token = fromLink ?? rememberedDraft(formId);
+  if (!token) {
+    return null;
+  }
+
+  try {
+    const draft = await FormService.getInstance().getDraft(formId, token);
+    rememberDraft(formId, draft.token);
+    return draft;
+  } catch (error) {
+    Logger.warn("Saved draft could not be loaded:", error);
+    forgetDraft(formId);
+    if (fromLink) {
+      dom.showError(
+        messages["draft_expired"] ??
+          "This saved response has expired or was already submitted. Please start again.",
+      );
+    }
+    return null;
+  }
+}
+
+/**
+ * Show the link that resumes a saved draft
+ */
+function showResumeLink(draft: FormDraft): void {
+  const panel = document.getElementById("draft-link");
+  const input = document.getElementById("draft-resume-url");
+  const expires = document.getElementById("draft-expires");
+  if (!panel || !(input instanceof HTMLInputElement)) {
+    return;
+  }
+
+  input.value = draft.resumeUrl;
+  if (expires) {
+    expires.textContent = new Date(draft.expiresAt).toLocaleString();
+  }
+  panel.removeAttribute("hidden");
+}
+
+/**
+ * Initialize the public form page
+ */
+async function initializePublicForm(): Promise<void> {
+  const container = document.getElementById("form-renderer");
+  if (!container) {
+    return;
+  }
+
+  const schemaAttr = container.getAttribute("data-form-schema");
+  const formId = container.getAttribute("data-form-id");
+  if (!schemaAttr || !formId) {
+    Logger.error("Form schema or form ID not found in data attributes");
+    return;
+  }
+
+  if (container.hasAttribute("data-embedded")) {
+    watchHeight(formId);
+  }
+
+  const language = container.getAttribute("data-locale") ?? "en";
+  const messages = parseMessages(container.getAttribute("data-messages"));
+  const formioMessages = parseMessages(container.getAttribute("data-i18n"));
+
+  try {
+    container.innerHTML = "";
+
+    const draft = await loadDraft(formId, messages);
+    let draftToken = draft?.token;
+
+    const form = await Formio.createForm(container, JSON.parse(schemaAttr), {
+      noAlerts: true,
+      language,
+      i18n: { [language]: formioMessages },
+    });
+
+    if (draft) {
+      form.submission = { data: { ...draft.data } };
+    }
+
+    const saveDraft = async (): Promise<FormDraft | null> => {
+      try {
+        const saved = await FormService.getInstance().saveDraft(
+          formId,
+          form.submission?.data ?? {},
+          draftToken,
+        );
+        draftToken = saved.token;
+        rememberDraft(formId, saved.token);
+        return saved;
+      } catch (error) {
+        Logger.error("Failed to save draft:", error);
+        return null;
+      }
+    };
+
+    // Wizard forms save progress each time the respondent moves on a page
+    form.on("nextPage", () => {
+      void saveDraft();
+    });
+
+    document
+      .getElementById("save-draft")
+      ?.addEventListener("click", async () => {
+        const saved = await saveDraft();
+        if (!saved) {
+          dom.showError(
+            messages["draft_failed"] ??
+              "Failed to save your progress. Please try again.",
+          );
+          return;
+        }
+        showResumeLink(saved);
+      });
+
+    form.on("submit", async (submission: any) => {
+      try {
+        if (draftToken) {
+          await FormService.getInstance().submitDraft(
+            formId,
+            draftToken,
+            submission.data,
+          );
+        } else {
+          await FormService.getInstance().submitForm(formId, submission.data);
+        }
+        forgetDraft(formId);
+
+        container.hidden = true;
+        document.getElementById("form-draft")?.setAttribute("hidden", "");
+        document.getElementById("form-thanks")?.removeAttribute("hidden");
+      } catch (error) {
+        Logger.error("Form submission error:", error);
+        dom.showError(
+          messages["submit_failed"] ?? "Failed to submit form. Please try again.",
+        );
+        form.emit("submitDone");
+      }
+    });
+  } catch (error) {
+    Logger.error("Public form initialization error:", error);
+    const title = document.createElement("h3");
+    title.textContent = messages["load_failed_title"] ?? "Error Loading Form";
+    const text = document.createElement("p");
+    text.textContent =
+      messages["load_failed"] ??
+      "Unable to load the form. Please try refreshing the page.";
+    const icon = document.createElement("i");
+    icon.className = "bi bi-exclamation-triangle";
+    const errorState = document.createElement("div");
+    errorState.className = "form-error-state";
+    errorState.append(icon, title, text);
+    container.replaceChildren(errorState);
+  }
+}
+
+// Initialize when DOM is ready
+if (document.readyState === "loading") {
+  document.addEventListener("DOMContentLoaded", () => {
+    initializePublicForm().catch((error) => {
+      Logger.error("Failed to initialize public form:", error);
+    });
+  });
+} else {
+  initializePublicForm().catch((error) => {
+    Logger.error("Failed to initialize public form:", error);
+  });
+}
@@ -182,6 +182,28 @@ export interface FormSubmissionData {
   };
 }
 
+/**
+ * Saved draft of a partially completed submission
+ */
+export interface FormDraft {
+  readonly token: string;
+  readonly resumeUrl: string;
+  readonly expiresAt: string;
+  readonly data: Readonly<Record<string, unknown>>;
+}
+
+/**
+ * One entry in a submission's edit history
+ */
+export interface SubmissionRevision {
+  readonly number: number;
+  readonly action: "original" | "edit" | "restore";
+  readonly editorId: string;
+  readonly restoredFrom?: number;
+  readonly fields: readonly string[];
+  readonly createdAt: string;
+}
+
 /**
  * Form validation result interface
  */
@@ -20,6 +20,7 @@ vi.mock("@/core/http-client", () => ({
     get: vi.fn(),
     post: vi.fn(),
     put: vi.fn(),
+    patch: vi.fn(),
     delete: vi.fn(),
   },
 }));
@@ -36,6 +37,7 @@ describe("FormApiService", () => {
   let mockHttpGet: MockedFunction<typeof HttpClient.get>;
   let mockHttpPost: MockedFunction<typeof HttpClient.post>;
   let mockHttpPut: MockedFunction<typeof HttpClient.put>;
+  let mockHttpPatch: MockedFunction<typeof HttpClient.patch>;
   let mockHttpDelete: MockedFunction<typeof HttpClient.delete>;
 
   beforeEach(() => {
@@ -46,6 +48,7 @@ describe("FormApiService", () => {
     mockHttpGet = vi.mocked(HttpClient.get);
     mockHttpPost = vi.mocked(HttpClient.post);
     mockHttpPut = vi.mocked(HttpClient.put);
+    mockHttpPatch = vi.mocked(HttpClient.patch);
     mockHttpDelete = vi.mocked(HttpClient.delete);
 
     // Mock Logger methods
@@ -452,6 +455,123 @@ describe("FormApiService", () => {
     });
   });
 
+  describe("drafts", () => {
+    const draftResponse = {
+      data: {
+        success: true,
+        data: {
+          resume_token: "tok",
+          resume_url: "https://example.com/f/test-form-id?resume=tok",
+          expires_at: "2026-10-26T12:00:00Z",
+          data: { name: "John Doe" },
+        },
+      },
+      status: 200,
+      statusText: "OK",
+      headers: new Headers(),
+      url: "",
+    };
+
+    it("should start a new draft without a token", async () => {
+      mockHttpPatch.mockResolvedValue(draftResponse);
+
+      const draft = await service.saveDraft("test-form-id", {
+        name: "John Doe",
+      });
+
+      expect(mockHttpPatch).toHaveBeenCalledWith(
+        `${window.location.origin}/api/v1/forms/test-form-id/draft`,
+        { name: "John Doe" },
+      );
+      expect(draft.token).toBe("tok");
+      expect(draft.resumeUrl).toContain("?resume=tok");
+    });
+
+    it("should update an existing draft by token", async () => {
+      mockHttpPatch.mockResolvedValue(draftResponse);
+
+      await service.saveDraft(
+        "test-form-id",
+        { email: "j@example.com" },
+        "tok",
+      );
+
+      expect(mockHttpPatch).toHaveBeenCalledWith(
+        `${window.location.origin}/api/v1/forms/test-form-id/draft/tok`,
+        { email: "j@example.com" },
+      );
+    });
+
+    it("should submit a draft through its token", async () => {
+      mockHttpPost.mockResolvedValue({ ...draftResponse, data: {} });
+
+      await service.submitDraft("test-form-id", "tok", { name: "John Doe" });
+
+      expect(mockHttpPost).toHaveBeenCalledWith(
+        `${window.location.origin}/api/v1/forms/test-form-id/draft/tok/submit`,
+        { name: "John Doe" },
+      );
+    });
+  });
+
+  describe("submission revisions", () => {
+    const revisionResponse = (revision: Record<string, unknown>) => ({
+      data: { success: true, data: revision },
+      status: 200,
+      statusText: "OK",
+      headers: new Headers(),
+      url: "",
+    });
+
+    it("should replace submission data and return the new revision", async () => {
+      mockHttpPut.mockResolvedValue(
+        revisionResponse({
+          number: 2,
+          action: "edit",
+          editor_id: "owner-1",
+          changes: [{ field: "email", before: "a@x.io", after: "b@x.io" }],
+          created_at: "2026-10-19T12:00:00Z",
+        }),
+      );
+
+      const revision = await service.updateSubmission("test-form-id", "sub-1", {
+        email: "b@x.io",
+      });
+
+      expect(mockHttpPut).toHaveBeenCalledWith(
+        `${window.location.origin}/api/v1/forms/test-form-id/submissions/sub-1`,
+        { email: "b@x.io" },
+      );
+      expect(revision.number).toBe(2);
+      expect(revision.fields).toEqual(["email"]);
+    });
+
+    it("should restore an earlier revision", async () => {
+      mockHttpPost.mockResolvedValue(
+        revisionResponse({
+          number: 3,
+          action: "restore",
+          editor_id: "owner-1",
+          restored_from: 1,
+          changes: null,
+          created_at: "2026-10-19T12:00:00Z",
+        }),
+      );
+
+      const revision = await service.restoreSubmissionRevision(
+        "test-form-id",
+        "sub-1",
+        1,
+      );
+
+      expect(mockHttpPost).toHaveBeenCalledWith(
+        `${window.location.origin}/api/v1/forms/test-form-id/submissions/sub-1/revisions/1/restore`,
+      );
+      expect(revision.restoredFrom).toBe(1);
+      expect(revision.fields).toEqual([]);
+    });
+  });
+
   describe("sanitizeFormData", () => {
     // Test the private method indirectly through submitForm
     beforeEach(() => {
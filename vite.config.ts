@@ -259,6 +259,12 @@ export default defineConfig(({ mode, command }) => {
           "form-builder": resolve(__dirname, "src/js/pages/form-builder.ts"),
           "new-form": resolve(__dirname, "src/js/pages/new-form.ts"),
           "form-preview": resolve(__dirname, "src/js/pages/form-preview.ts"),
+          "form-submission": resolve(__dirname, "src/js/pages/form-submission.ts"),
+          "form-submissions": resolve(
+            __dirname,
+            "src/js/pages/form-submissions.ts",
+          ),
+          "public-form": resolve(__dirname, "src/js/pages/public-form.ts"),
           "cta-form": resolve(__dirname, "src/js/pages/cta-form.ts"),
           demo: resolve(__dirname, "src/js/pages/demo.ts"),
           login: resolve(__dirname, "src/js/pages/login.ts"),